@@ -148,6 +148,7 @@ func BuildCLI(releaseVersion string, gitRevision string) *cli.App {
 				)
 			},
 		},
+		newStartDevCommand(),
 	}
 
 	return app
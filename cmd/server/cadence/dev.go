@@ -0,0 +1,338 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cadence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	apiv1 "github.com/uber/cadence-idl/go/proto/api/v1"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/fx"
+	"go.uber.org/yarpc"
+	"go.uber.org/yarpc/transport/grpc"
+
+	grpcClient "github.com/uber/cadence/client/wrappers/grpc"
+	"github.com/uber/cadence/common/config"
+	sqliteplugin "github.com/uber/cadence/common/persistence/sql/sqlplugin/sqlite"
+	"github.com/uber/cadence/common/rpc"
+	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/types"
+	sqliteschema "github.com/uber/cadence/schema/sqlite"
+	"github.com/uber/cadence/tools/common/schema"
+	"github.com/uber/cadence/tools/sql"
+)
+
+const (
+	devDefaultStore    = "sqlite-default"
+	devVisibilityStore = "sqlite-visibility"
+	devDefaultDBFile   = "cadence.db"
+	devDefaultDomain   = "samples-domain"
+	devClientName      = "cadence-dev-server"
+
+	devDomainRetentionDays     = 1
+	devRegisterDomainTimeout   = time.Minute
+	devRegisterDomainRetryWait = time.Second
+	devRPCTimeout              = 5 * time.Second
+)
+
+// devOptions are the options of the start-dev command
+type devOptions struct {
+	DBFile   string
+	InMemory bool
+	Domain   string
+}
+
+func newStartDevCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "start-dev",
+		Usage: "start all cadence services in one process on a SQLite database, for local development",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "db-file",
+				Value: devDefaultDBFile,
+				Usage: "SQLite file to store data in, visibility records are stored next to it in a file with the _visibility suffix",
+			},
+			&cli.BoolFlag{
+				Name:  "in-memory",
+				Usage: "keep all data in memory instead of the db-file, data is lost when the server stops",
+			},
+			&cli.StringFlag{
+				Name:  "domain",
+				Value: devDefaultDomain,
+				Usage: "domain to register once the server is up, empty to not register any",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			opts := devOptions{
+				DBFile:   c.String("db-file"),
+				InMemory: c.Bool("in-memory"),
+				Domain:   strings.TrimSpace(c.String("domain")),
+			}
+			return startDev(c, opts)
+		},
+	}
+}
+
+func startDev(c *cli.Context, opts devOptions) error {
+	host, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("get hostname: %w", err)
+	}
+
+	appCtx := appContext{
+		CfgContext: config.Context{
+			Environment: getEnvironment(c),
+			Zone:        getZone(c),
+		},
+		ConfigDir: getConfigDir(c),
+		RootDir:   getRootDir(c),
+		HostName:  host,
+	}
+
+	var cfg config.Config
+	if err := config.Load(appCtx.CfgContext.Environment, appCtx.ConfigDir, appCtx.CfgContext.Zone, &cfg); err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	applyDevPersistence(&cfg, opts)
+
+	// the connections are kept open until the services stop, otherwise an in-memory database is dropped
+	closeDatabases, err := setupDevDatabases(cfg.Persistence)
+	if err != nil {
+		return err
+	}
+	defer closeDatabases()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		frontendCfg, err := cfg.GetServiceConfig(service.Frontend)
+		if err != nil {
+			fmt.Fprintf(c.App.ErrWriter, "Unable to find frontend config: %v\n", err)
+			return
+		}
+		if err := waitForDevServer(ctx, frontendCfg.RPC, opts.Domain); err != nil {
+			fmt.Fprintf(c.App.ErrWriter, "Development server is not ready: %v\n", err)
+			return
+		}
+		printDevEndpoints(c.App.Writer, frontendCfg.RPC, cfg.Persistence, opts)
+	}()
+
+	return runServices(
+		defaultServices,
+		func(serviceName string) fxAppInterface {
+			return fx.New(
+				fx.Module(serviceName,
+					_commonModule,
+					fx.Provide(
+						func() appContext {
+							return appCtx
+						},
+					),
+					fx.Decorate(func(cfg config.Config) config.Config {
+						applyDevPersistence(&cfg, opts)
+						return cfg
+					}),
+					Module(serviceName),
+				),
+			)
+		},
+	)
+}
+
+// applyDevPersistence replaces the configured datastores with the SQLite ones of the development server
+func applyDevPersistence(cfg *config.Config, opts devOptions) {
+	defaultDB, visibilityDB := devDatabaseNames(opts)
+	cfg.Persistence.DefaultStore = devDefaultStore
+	cfg.Persistence.VisibilityStore = devVisibilityStore
+	cfg.Persistence.AdvancedVisibilityStore = ""
+	cfg.Persistence.DataStores = map[string]config.DataStore{
+		devDefaultStore:    {SQL: devSQLConfig(defaultDB, opts.InMemory)},
+		devVisibilityStore: {SQL: devSQLConfig(visibilityDB, opts.InMemory)},
+	}
+}
+
+func devDatabaseNames(opts devOptions) (string, string) {
+	dbFile := opts.DBFile
+	if opts.InMemory {
+		// memdb shares a database between all connections opening the same absolute name
+		dbFile = "/" + devDefaultDBFile
+	}
+	ext := filepath.Ext(dbFile)
+	return dbFile, strings.TrimSuffix(dbFile, ext) + "_visibility" + ext
+}
+
+func devSQLConfig(databaseName string, inMemory bool) *config.SQL {
+	cfg := &config.SQL{
+		PluginName:   sqliteplugin.PluginName,
+		DatabaseName: databaseName,
+		MaxConns:     1,
+		MaxIdleConns: 1,
+	}
+	if inMemory {
+		cfg.ConnectAttributes = map[string]string{
+			"vfs": "memdb",
+			// memdb has no shared memory, which WAL needs
+			"_pragma.journal_mode": "memory",
+		}
+	}
+	return cfg
+}
+
+// setupDevDatabases sets up the schema of the databases which don't have any tables yet.
+// The returned function closes the connections to the databases.
+func setupDevDatabases(cfg config.Persistence) (func(), error) {
+	var conns []*sql.Connection
+	closeConns := func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+	for _, db := range []struct {
+		store     string
+		schemaDir string
+	}{
+		{store: cfg.DefaultStore, schemaDir: "cadence"},
+		{store: cfg.VisibilityStore, schemaDir: "visibility"},
+	} {
+		conn, err := sql.NewConnection(cfg.DataStores[db.store].SQL)
+		if err != nil {
+			closeConns()
+			return nil, fmt.Errorf("connect to %v database: %w", db.schemaDir, err)
+		}
+		conns = append(conns, conn)
+		if err := setupDevSchema(conn, db.schemaDir); err != nil {
+			closeConns()
+			return nil, fmt.Errorf("setup %v schema: %w", db.schemaDir, err)
+		}
+	}
+	return closeConns, nil
+}
+
+func setupDevSchema(conn *sql.Connection, schemaDir string) error {
+	tables, err := conn.ListTables()
+	if err != nil {
+		return err
+	}
+	if len(tables) > 0 {
+		// set up by a previous run, the version is verified when the services start
+		return nil
+	}
+	version, err := schema.LatestVersion(sqliteschema.SchemaFS, schemaDir+"/versioned")
+	if err != nil {
+		return err
+	}
+	return schema.SetupFromConfig(&schema.SetupConfig{
+		SchemaFilePath: schemaDir + "/schema.sql",
+		SchemaFS:       sqliteschema.SchemaFS,
+		InitialVersion: version,
+	}, conn)
+}
+
+// waitForDevServer waits until the frontend accepts requests by registering the domain, if any
+func waitForDevServer(ctx context.Context, frontendRPC config.RPC, domain string) error {
+	dispatcher := yarpc.NewDispatcher(yarpc.Config{
+		Name: devClientName,
+		Outbounds: yarpc.Outbounds{
+			service.Frontend: {Unary: grpc.NewTransport().NewSingleOutbound(devAddress(frontendRPC, frontendRPC.GRPCPort))},
+		},
+	})
+	if err := dispatcher.Start(); err != nil {
+		return err
+	}
+	defer dispatcher.Stop()
+
+	clientConfig := dispatcher.ClientConfig(service.Frontend)
+	client := grpcClient.NewFrontendClient(
+		apiv1.NewDomainAPIYARPCClient(clientConfig),
+		apiv1.NewWorkflowAPIYARPCClient(clientConfig),
+		apiv1.NewWorkerAPIYARPCClient(clientConfig),
+		apiv1.NewVisibilityAPIYARPCClient(clientConfig),
+		apiv1.NewScheduleAPIYARPCClient(clientConfig),
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, devRegisterDomainTimeout)
+	defer cancel()
+	for {
+		callCtx, callCancel := context.WithTimeout(ctx, devRPCTimeout)
+		var err error
+		if domain == "" {
+			_, err = client.GetClusterInfo(callCtx)
+		} else {
+			err = client.RegisterDomain(callCtx, &types.RegisterDomainRequest{
+				Name:                                   domain,
+				Description:                            "registered by the development server",
+				WorkflowExecutionRetentionPeriodInDays: devDomainRetentionDays,
+			})
+		}
+		callCancel()
+		var alreadyExists *types.DomainAlreadyExistsError
+		if err == nil || errors.As(err, &alreadyExists) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if domain != "" {
+				return fmt.Errorf("register domain %v: %w", domain, err)
+			}
+			return err
+		case <-time.After(devRegisterDomainRetryWait):
+		}
+	}
+}
+
+func printDevEndpoints(w io.Writer, frontendRPC config.RPC, cfg config.Persistence, opts devOptions) {
+	fmt.Fprintf(w, "\nCadence development server is ready\n")
+	fmt.Fprintf(w, "  Frontend gRPC:     %v\n", devAddress(frontendRPC, frontendRPC.GRPCPort))
+	fmt.Fprintf(w, "  Frontend TChannel: %v\n", devAddress(frontendRPC, frontendRPC.Port))
+	if frontendRPC.HTTP != nil {
+		fmt.Fprintf(w, "  Frontend HTTP:     %v\n", devAddress(frontendRPC, frontendRPC.HTTP.Port))
+	}
+	if opts.Domain != "" {
+		fmt.Fprintf(w, "  Domain:            %v\n", opts.Domain)
+	}
+	if opts.InMemory {
+		fmt.Fprintf(w, "  Database:          in-memory\n")
+	} else {
+		fmt.Fprintf(w, "  Database:          %v, %v\n",
+			cfg.DataStores[cfg.DefaultStore].SQL.DatabaseName,
+			cfg.DataStores[cfg.VisibilityStore].SQL.DatabaseName,
+		)
+	}
+}
+
+// devAddress returns the address clients on this host reach the given port of a service at
+func devAddress(rpcCfg config.RPC, port uint16) string {
+	ip, err := rpc.GetListenIP(rpcCfg)
+	if err != nil || ip.IsUnspecified() {
+		ip = net.IPv4(127, 0, 0, 1)
+	}
+	return net.JoinHostPort(ip.String(), strconv.Itoa(int(port)))
+}
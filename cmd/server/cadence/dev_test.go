@@ -0,0 +1,144 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cadence
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/config"
+	sqliteschema "github.com/uber/cadence/schema/sqlite"
+	"github.com/uber/cadence/tools/common/schema"
+	"github.com/uber/cadence/tools/sql"
+)
+
+func TestApplyDevPersistence(t *testing.T) {
+	tests := map[string]struct {
+		opts               devOptions
+		wantDefaultDB      string
+		wantVisibilityDB   string
+		wantConnectAttribs map[string]string
+	}{
+		"file": {
+			opts:             devOptions{DBFile: "/tmp/dev/cadence.db"},
+			wantDefaultDB:    "/tmp/dev/cadence.db",
+			wantVisibilityDB: "/tmp/dev/cadence_visibility.db",
+		},
+		"file without extension": {
+			opts:             devOptions{DBFile: "cadence"},
+			wantDefaultDB:    "cadence",
+			wantVisibilityDB: "cadence_visibility",
+		},
+		"in-memory": {
+			opts:             devOptions{DBFile: "ignored.db", InMemory: true},
+			wantDefaultDB:    "/cadence.db",
+			wantVisibilityDB: "/cadence_visibility.db",
+			wantConnectAttribs: map[string]string{
+				"vfs":                  "memdb",
+				"_pragma.journal_mode": "memory",
+			},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.Config{
+				Persistence: config.Persistence{
+					DefaultStore:            "cass-default",
+					VisibilityStore:         "cass-visibility",
+					AdvancedVisibilityStore: "es-visibility",
+					NumHistoryShards:        4,
+					DataStores: map[string]config.DataStore{
+						"cass-default": {NoSQL: &config.NoSQL{PluginName: "cassandra"}},
+					},
+				},
+			}
+
+			applyDevPersistence(&cfg, tc.opts)
+
+			assert.Equal(t, devDefaultStore, cfg.Persistence.DefaultStore)
+			assert.Equal(t, devVisibilityStore, cfg.Persistence.VisibilityStore)
+			assert.Empty(t, cfg.Persistence.AdvancedVisibilityStore)
+			assert.Equal(t, 4, cfg.Persistence.NumHistoryShards)
+			require.Len(t, cfg.Persistence.DataStores, 2)
+			defaultSQL := cfg.Persistence.DataStores[devDefaultStore].SQL
+			visibilitySQL := cfg.Persistence.DataStores[devVisibilityStore].SQL
+			assert.Equal(t, "sqlite", defaultSQL.PluginName)
+			assert.Equal(t, tc.wantDefaultDB, defaultSQL.DatabaseName)
+			assert.Equal(t, tc.wantVisibilityDB, visibilitySQL.DatabaseName)
+			assert.Equal(t, tc.wantConnectAttribs, defaultSQL.ConnectAttributes)
+			assert.Equal(t, tc.wantConnectAttribs, visibilitySQL.ConnectAttributes)
+		})
+	}
+}
+
+func TestSetupDevDatabases(t *testing.T) {
+	cfg := config.Config{}
+	applyDevPersistence(&cfg, devOptions{DBFile: filepath.Join(t.TempDir(), "cadence.db")})
+
+	closeDatabases, err := setupDevDatabases(cfg.Persistence)
+	require.NoError(t, err)
+	closeDatabases()
+
+	// databases set up by a previous run are left as they are
+	closeDatabases, err = setupDevDatabases(cfg.Persistence)
+	require.NoError(t, err)
+	closeDatabases()
+
+	for store, schemaDir := range map[string]string{devDefaultStore: "cadence", devVisibilityStore: "visibility"} {
+		conn, err := sql.NewConnection(cfg.Persistence.DataStores[store].SQL)
+		require.NoError(t, err)
+		version, err := conn.ReadSchemaVersion()
+		conn.Close()
+		require.NoError(t, err)
+		wantVersion, err := schema.LatestVersion(sqliteschema.SchemaFS, schemaDir+"/versioned")
+		require.NoError(t, err)
+		assert.Equal(t, wantVersion, version, store)
+	}
+}
+
+func TestDevAddress(t *testing.T) {
+	assert.Equal(t, "127.0.0.1:7833", devAddress(config.RPC{BindOnLocalHost: true}, 7833))
+	assert.Equal(t, "127.0.0.1:7933", devAddress(config.RPC{BindOnIP: "0.0.0.0"}, 7933))
+	assert.Equal(t, "10.0.0.1:7933", devAddress(config.RPC{BindOnIP: "10.0.0.1"}, 7933))
+}
+
+func TestPrintDevEndpoints(t *testing.T) {
+	cfg := config.Config{}
+	opts := devOptions{DBFile: "cadence.db", Domain: "samples-domain"}
+	applyDevPersistence(&cfg, opts)
+	rpcCfg := config.RPC{BindOnLocalHost: true, Port: 7933, GRPCPort: 7833, HTTP: &config.HTTP{Port: 8800}}
+
+	var out bytes.Buffer
+	printDevEndpoints(&out, rpcCfg, cfg.Persistence, opts)
+
+	assert.Equal(t, `
+Cadence development server is ready
+  Frontend gRPC:     127.0.0.1:7833
+  Frontend TChannel: 127.0.0.1:7933
+  Frontend HTTP:     127.0.0.1:8800
+  Domain:            samples-domain
+  Database:          cadence.db, cadence_visibility.db
+`, out.String())
+}
@@ -27,6 +27,7 @@ import (
 	"fmt"
 
 	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 	"go.uber.org/zap"
 
@@ -41,6 +42,7 @@ import (
 	"github.com/uber/cadence/common/metrics/metricsfx"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin/cassandra/gocql"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/tracing/tracingfx"
 	"github.com/uber/cadence/tools/cassandra"
 	"github.com/uber/cadence/tools/sql"
 )
@@ -50,6 +52,7 @@ var _commonModule = fx.Options(
 	dynamicconfigfx.Module,
 	logfx.Module,
 	metricsfx.Module,
+	tracingfx.Module,
 	clockfx.Module)
 
 // Module provides a cadence server initialization with root components.
@@ -69,27 +72,29 @@ func Module(serviceName string) fx.Option {
 type AppParams struct {
 	fx.In
 
-	Service       string `name:"service"`
-	AppContext    config.Context
-	Config        config.Config
-	Logger        log.Logger
-	ZapLogger     *zap.Logger
-	LifeCycle     fx.Lifecycle
-	DynamicConfig dynamicconfig.Client
-	Scope         tally.Scope
-	MetricsClient metrics.Client
+	Service        string `name:"service"`
+	AppContext     config.Context
+	Config         config.Config
+	Logger         log.Logger
+	ZapLogger      *zap.Logger
+	LifeCycle      fx.Lifecycle
+	DynamicConfig  dynamicconfig.Client
+	Scope          tally.Scope
+	MetricsClient  metrics.Client
+	TracerProvider trace.TracerProvider
 }
 
 // NewApp created a new Application from pre initalized config and logger.
 func NewApp(params AppParams) *App {
 	app := &App{
-		cfg:           params.Config,
-		logger:        params.Logger,
-		zapLogger:     params.ZapLogger,
-		service:       params.Service,
-		dynamicConfig: params.DynamicConfig,
-		scope:         params.Scope,
-		metricsClient: params.MetricsClient,
+		cfg:            params.Config,
+		logger:         params.Logger,
+		zapLogger:      params.ZapLogger,
+		service:        params.Service,
+		dynamicConfig:  params.DynamicConfig,
+		scope:          params.Scope,
+		metricsClient:  params.MetricsClient,
+		tracerProvider: params.TracerProvider,
 	}
 
 	params.LifeCycle.Append(fx.StartHook(app.verifySchema))
@@ -100,20 +105,21 @@ func NewApp(params AppParams) *App {
 // App is a fx application that registers itself into fx.Lifecycle and runs.
 // It is done implicitly, since it provides methods Start and Stop which are picked up by fx.
 type App struct {
-	cfg           config.Config
-	rootDir       string
-	logger        log.Logger
-	zapLogger     *zap.Logger
-	dynamicConfig dynamicconfig.Client
-	scope         tally.Scope
-	metricsClient metrics.Client
+	cfg            config.Config
+	rootDir        string
+	logger         log.Logger
+	zapLogger      *zap.Logger
+	dynamicConfig  dynamicconfig.Client
+	scope          tally.Scope
+	metricsClient  metrics.Client
+	tracerProvider trace.TracerProvider
 
 	daemon  common.Daemon
 	service string
 }
 
 func (a *App) Start(_ context.Context) error {
-	a.daemon = newServer(a.service, a.cfg, a.logger, a.zapLogger, a.dynamicConfig, a.scope, a.metricsClient)
+	a.daemon = newServer(a.service, a.cfg, a.logger, a.zapLogger, a.dynamicConfig, a.scope, a.metricsClient, a.tracerProvider)
 	a.daemon.Start()
 	return nil
 }
@@ -38,6 +38,7 @@ import (
 	"github.com/startreedata/pinot-client-go/pinot"
 	"github.com/uber-go/tally"
 	apiv1 "github.com/uber/cadence-idl/go/proto/api/v1"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	"go.uber.org/cadence/compatibility"
 	"go.uber.org/zap"
@@ -89,12 +90,13 @@ type (
 		dynamicCfgClient dynamicconfig.Client
 		scope            tally.Scope
 		metricsClient    metrics.Client
+		tracerProvider   trace.TracerProvider
 	}
 )
 
 // newServer returns a new instance of a daemon
 // that represents a cadence service
-func newServer(service string, cfg config.Config, logger log.Logger, zapLogger *zap.Logger, dynamicCfgClient dynamicconfig.Client, scope tally.Scope, metricsClient metrics.Client) common.Daemon {
+func newServer(service string, cfg config.Config, logger log.Logger, zapLogger *zap.Logger, dynamicCfgClient dynamicconfig.Client, scope tally.Scope, metricsClient metrics.Client, tracerProvider trace.TracerProvider) common.Daemon {
 	return &server{
 		cfg:              cfg,
 		name:             service,
@@ -104,6 +106,7 @@ func newServer(service string, cfg config.Config, logger log.Logger, zapLogger *
 		dynamicCfgClient: dynamicCfgClient,
 		scope:            scope,
 		metricsClient:    metricsClient,
+		tracerProvider:   tracerProvider,
 	}
 }
 
@@ -160,6 +163,7 @@ func (s *server) startService() common.Daemon {
 
 	params.MetricScope = s.scope
 	params.MetricsClient = s.metricsClient
+	params.TracerProvider = s.tracerProvider
 	params.ZapLogger = s.zapLogger
 
 	params.OperationalConfigStore = resolveOperationalConfigStore(&params, dc)
@@ -177,6 +181,7 @@ func (s *server) startService() common.Daemon {
 	if err != nil {
 		s.logger.Fatal("error creating rpc factory params", tag.Error(err))
 	}
+	rpcParams = rpc.WithTracing(rpcParams, s.tracerProvider)
 	rpcParams.OutboundsBuilder = rpc.CombineOutbounds(
 		rpcParams.OutboundsBuilder,
 		rpc.NewCrossDCOutbounds(clusterGroupMetadata.ClusterGroup, rpc.NewDNSPeerChooserFactory(s.cfg.PublicClient.RefreshInterval, params.Logger)),
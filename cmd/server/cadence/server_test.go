@@ -33,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/fx/fxtest"
 	"go.uber.org/mock/gomock"
 
@@ -110,7 +111,7 @@ func (s *ServerSuite) TestServerStartup() {
 		})
 
 	for _, svc := range services {
-		server := newServer(svc, cfg, logger, testlogger.NewZap(s.T()), dynamicconfig.NewNopClient(), tally.NoopScope, metrics.NewNoopMetricsClient())
+		server := newServer(svc, cfg, logger, testlogger.NewZap(s.T()), dynamicconfig.NewNopClient(), tally.NoopScope, metrics.NewNoopMetricsClient(), noop.NewTracerProvider())
 		daemons = append(daemons, server)
 		server.Start()
 	}
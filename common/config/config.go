@@ -103,6 +103,9 @@ type (
 		Gauges metrics.GaugeMigration `yaml:"gauge-migration"`
 		// Counters controls counter metric emission during migration.
 		Counters metrics.CounterMigration `yaml:"counter-migration"`
+
+		// Tracing is the config for exporting OpenTelemetry traces
+		Tracing Tracing `yaml:"tracing"`
 	}
 
 	// Membership holds peer provider configuration.
@@ -211,6 +214,8 @@ type (
 		NumHistoryShards int `yaml:"numHistoryShards" validate:"nonzero"`
 		// DataStores contains the configuration for all datastores
 		DataStores map[string]DataStore `yaml:"datastores"`
+		// Encryption contains the configuration for encrypting history events at rest, including the events kept in mutable state
+		Encryption *PayloadEncryption `yaml:"encryption"`
 		// TODO: move dynamic config out of static config
 		// TransactionSizeLimit is the largest allowed transaction size
 		TransactionSizeLimit dynamicproperties.IntPropertyFn `yaml:"-" json:"-"`
@@ -221,6 +226,16 @@ type (
 		HostName string `yaml:"-" json:"-"`
 	}
 
+	// PayloadEncryption is the configuration for envelope encryption of persisted history events.
+	// Encryption is switched on per domain with the history.enablePayloadEncryption dynamic config.
+	// Activity heartbeat and last failure details, the decision execution context, the memo and the
+	// search attributes kept in mutable state are not sealed
+	PayloadEncryption struct {
+		// KeyFile is the path to a yaml file holding the active key id and the base64 encoded master keys.
+		// Keys that were active in the past must be kept in the file as long as data sealed with them exists
+		KeyFile string `yaml:"keyFile"`
+	}
+
 	// DataStore is the configuration for a single datastore
 	DataStore struct {
 		// Cassandra contains the config for a cassandra datastore
@@ -280,10 +295,17 @@ type (
 		// Use it ONLY when a configure is too specific to a particular NoSQL database that should not be in the common struct
 		// Otherwise please add new fields to the struct for better documentation
 		// If being used in any database, update this comment here to make it clear
+		// Used by:
+		//   * dynamodb: "endpoint" overrides the service endpoint (e.g. http://localhost:8000 for DynamoDB Local)
 		ConnectAttributes map[string]string `yaml:"connectAttributes"`
 		// HostSelectionPolicy sets gocql policy for selecting host for a query
 		// Available selections are: "tokenaware,roundrobin", "hostpool-epsilon-greedy", "roundrobin"
 		HostSelectionPolicy string `yaml:"hostSelectionPolicy"`
+		// VisibilityOpenBuckets is the number of buckets the open workflows of a domain are spread over for visibility
+		// queries on Cassandra, default is 8. More buckets spread the writes of busy domains over more partitions, at the
+		// cost of reading more partitions per query. Like numHistoryShards, it can not be changed once visibility
+		// records were written, as closing a workflow removes its open record from the bucket derived from this value
+		VisibilityOpenBuckets int `yaml:"visibilityOpenBuckets"`
 	}
 
 	// ShardedNoSQL contains configuration to connect to a set of NoSQL Database clusters in a sharded manner
@@ -374,6 +396,41 @@ type (
 		// Required when UseMultipleDatabases is true
 		// the length of the list should be exactly the same as NumShards
 		MultipleDatabasesConfig []MultipleDatabasesConfigEntry `yaml:"multipleDatabasesConfig"`
+		// ReadReplicas are read replicas of the database which eventually consistent reads are sent to
+		// If useMultipleDatabases, must be empty and provide it via multipleDatabasesConfig instead
+		ReadReplicas []SQLReadReplica `yaml:"readReplicas"`
+		// ReadReplicaRouting decides which reads are sent to the read replicas
+		ReadReplicaRouting SQLReadReplicaRouting `yaml:"readReplicaRouting"`
+	}
+
+	// SQLReadReplica is the configuration for connecting to a read replica of a SQL database.
+	// User, Password and DatabaseName default to the ones of the primary database
+	SQLReadReplica struct {
+		// User is the username to be used for the conn
+		User string `yaml:"user"`
+		// Password is the password corresponding to the user name
+		Password string `yaml:"password"`
+		// DatabaseName is the name of SQL database to connect to
+		DatabaseName string `yaml:"databaseName"`
+		// ConnectAddr is the remote addr of the read replica
+		ConnectAddr string `yaml:"connectAddr" validate:"nonzero"`
+		// Managed is set for read replicas whose replication is managed by the database service and which do not
+		// report a replica status, e.g. Aurora readers. Their replication lag is not checked and they are always read from.
+		// Other read replicas which do not report a replica status are treated as lagging and not read from
+		Managed bool `yaml:"managed"`
+	}
+
+	// SQLReadReplicaRouting decides which reads are sent to the read replicas of a SQL database.
+	// Conditional writes and reads of mutable state always go to the primary database
+	SQLReadReplicaRouting struct {
+		// Reads are the eventually consistent reads sent to the read replicas, which are
+		// visibility (visibility list queries), history (history of closed workflows) and domain (domain list).
+		// Default is all of them
+		Reads []string `yaml:"reads"`
+		// MaxReplicationLag is the replication lag above which a read replica is not read from. Default is 5s
+		MaxReplicationLag time.Duration `yaml:"maxReplicationLag"`
+		// LagCheckInterval is how often the replication lag of the read replicas is checked. Default is 5s
+		LagCheckInterval time.Duration `yaml:"lagCheckInterval"`
 	}
 
 	// MultipleDatabasesConfigEntry is an entry for MultipleDatabasesConfig to connect to a single SQL database
@@ -386,6 +443,8 @@ type (
 		DatabaseName string `yaml:"databaseName" validate:"nonzero"`
 		// ConnectAddr is the remote addr of the database
 		ConnectAddr string `yaml:"connectAddr" validate:"nonzero"`
+		// ReadReplicas are read replicas of the database which eventually consistent reads are sent to
+		ReadReplicas []SQLReadReplica `yaml:"readReplicas"`
 	}
 
 	// CustomDatastoreConfig is the configuration for connecting to a custom datastore that is not supported by cadence core
@@ -636,6 +695,16 @@ type (
 	}
 )
 
+// This section defines the eventually consistent reads which can be sent to SQL read replicas
+const (
+	// SQLReplicaReadVisibility is visibility list queries
+	SQLReplicaReadVisibility = "visibility"
+	// SQLReplicaReadHistory is history reads of closed workflows
+	SQLReplicaReadHistory = "history"
+	// SQLReplicaReadDomain is domain list queries
+	SQLReplicaReadDomain = "domain"
+)
+
 const (
 	// NonShardedStoreName is the shard name used for singular (non-sharded) stores
 	NonShardedStoreName = "NonShardedStore"
@@ -703,6 +772,9 @@ func (c *Config) validate() error {
 	if err := c.Archival.Validate(&c.DomainDefaults.Archival); err != nil {
 		return err
 	}
+	if err := c.Tracing.Validate(); err != nil {
+		return err
+	}
 
 	return c.Authorization.Validate()
 }
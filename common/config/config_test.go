@@ -163,6 +163,33 @@ func TestInvalidMultipleDatabaseConfig_nonEmptySQLConnAddr(t *testing.T) {
 	require.EqualError(t, err, "sql persistence config: connectAddr can only be configured in multipleDatabasesConfig when UseMultipleDatabases is true")
 }
 
+func TestInvalidMultipleDatabaseConfig_nonEmptySQLReadReplicas(t *testing.T) {
+	cfg := getValidMultipleDatabasseConfig()
+	sqlds := cfg.Persistence.DataStores["default"]
+	sqlds.SQL.ReadReplicas = []SQLReadReplica{{ConnectAddr: "127.0.0.1:3307"}}
+	cfg.Persistence.DataStores["default"] = sqlds
+	err := cfg.ValidateAndFillDefaults()
+	require.EqualError(t, err, "sql persistence config: readReplicas can only be configured in multipleDatabasesConfig when UseMultipleDatabases is true")
+}
+
+func TestInvalidMultipleDatabaseConfig_emptyReadReplicaConnAddr(t *testing.T) {
+	cfg := getValidMultipleDatabasseConfig()
+	sqlds := cfg.Persistence.DataStores["default"]
+	sqlds.SQL.MultipleDatabasesConfig[1].ReadReplicas = []SQLReadReplica{{User: "user"}}
+	cfg.Persistence.DataStores["default"] = sqlds
+	err := cfg.ValidateAndFillDefaults()
+	require.EqualError(t, err, "sql readReplicas persistence config: connectAddr can not be empty")
+}
+
+func TestInvalidMultipleDatabaseConfig_unknownReplicaRead(t *testing.T) {
+	cfg := getValidMultipleDatabasseConfig()
+	sqlds := cfg.Persistence.DataStores["default"]
+	sqlds.SQL.ReadReplicaRouting.Reads = []string{SQLReplicaReadVisibility, "executions"}
+	cfg.Persistence.DataStores["default"] = sqlds
+	err := cfg.ValidateAndFillDefaults()
+	require.EqualError(t, err, `sql persistence config: unknown read "executions" in readReplicaRouting`)
+}
+
 func TestConfigFallbacks(t *testing.T) {
 	metadata := validClusterGroupMetadata()
 	cfg := &Config{
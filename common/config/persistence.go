@@ -71,6 +71,15 @@ func (c *Persistence) FillDefaults() {
 	}
 }
 
+func validateSQLReadReplicas(replicas []SQLReadReplica) error {
+	for _, replica := range replicas {
+		if replica.ConnectAddr == "" {
+			return fmt.Errorf("sql readReplicas persistence config: connectAddr can not be empty")
+		}
+	}
+	return nil
+}
+
 // Validate validates the persistence config
 func (c *Persistence) Validate() error {
 	dbStoreKeys := []string{c.DefaultStore}
@@ -124,6 +133,9 @@ func (c *Persistence) Validate() error {
 				if ds.SQL.Password != "" {
 					return fmt.Errorf("sql persistence config: password can only be configured in multipleDatabasesConfig when UseMultipleDatabases is true")
 				}
+				if len(ds.SQL.ReadReplicas) != 0 {
+					return fmt.Errorf("sql persistence config: readReplicas can only be configured in multipleDatabasesConfig when UseMultipleDatabases is true")
+				}
 				if ds.SQL.NumShards <= 1 || len(ds.SQL.MultipleDatabasesConfig) != ds.SQL.NumShards {
 					return fmt.Errorf("sql persistence config: nShards must be greater than one and equal to the length of multipleDatabasesConfig")
 				}
@@ -134,6 +146,9 @@ func (c *Persistence) Validate() error {
 					if entry.ConnectAddr == "" {
 						return fmt.Errorf("sql multipleDatabasesConfig persistence config: connectAddr can not be empty")
 					}
+					if err := validateSQLReadReplicas(entry.ReadReplicas); err != nil {
+						return err
+					}
 				}
 
 			// SQLite plugin doesn't require ConnectAddr and DatabaseName
@@ -144,6 +159,16 @@ func (c *Persistence) Validate() error {
 				if ds.SQL.ConnectAddr == "" {
 					return fmt.Errorf("sql persistence config: connectAddr can not be empty")
 				}
+				if err := validateSQLReadReplicas(ds.SQL.ReadReplicas); err != nil {
+					return err
+				}
+			}
+			for _, read := range ds.SQL.ReadReplicaRouting.Reads {
+				switch read {
+				case SQLReplicaReadVisibility, SQLReplicaReadHistory, SQLReplicaReadDomain:
+				default:
+					return fmt.Errorf("sql persistence config: unknown read %q in readReplicaRouting", read)
+				}
 			}
 		}
 		if ds.ShardedNoSQL != nil {
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	// Tracing is the config for exporting OpenTelemetry traces
+	Tracing struct {
+		// Enabled turns on span collection and export. When it's off every span is a no-op.
+		Enabled bool `yaml:"enabled"`
+		// OTLP is the collector that spans are exported to
+		OTLP OTLPExporter `yaml:"otlp"`
+		// SamplingRate is the fraction of new traces to sample, between 0 and 1. Traces started
+		// by an upstream caller follow the caller's sampling decision. Defaults to 1.
+		SamplingRate *float64 `yaml:"samplingRate"`
+	}
+
+	// OTLPExporter is the config for exporting spans to an OTLP collector over gRPC
+	OTLPExporter struct {
+		// Endpoint is the host:port of the collector
+		Endpoint string `yaml:"endpoint"`
+		// Insecure disables TLS on the connection to the collector
+		Insecure bool `yaml:"insecure"`
+		// Headers are sent with every export request, e.g. for authentication
+		Headers map[string]string `yaml:"headers"`
+		// Timeout bounds each export request. Defaults to the exporter's own default.
+		Timeout time.Duration `yaml:"timeout"`
+	}
+)
+
+// Validate checks that an enabled tracing config has a collector and a valid sampling rate
+func (t *Tracing) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+	if t.OTLP.Endpoint == "" {
+		return fmt.Errorf("tracing is enabled but no otlp endpoint is configured")
+	}
+	if t.SamplingRate != nil && (*t.SamplingRate < 0 || *t.SamplingRate > 1) {
+		return fmt.Errorf("tracing sampling rate %v must be between 0 and 1", *t.SamplingRate)
+	}
+	return nil
+}
+
+// GetSamplingRate returns the configured sampling rate, or 1 when none is set
+func (t *Tracing) GetSamplingRate() float64 {
+	if t.SamplingRate == nil {
+		return 1
+	}
+	return *t.SamplingRate
+}
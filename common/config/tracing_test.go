@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracingValidate(t *testing.T) {
+	tests := map[string]struct {
+		cfg     Tracing
+		wantErr bool
+	}{
+		"disabled": {
+			cfg: Tracing{},
+		},
+		"enabled": {
+			cfg: Tracing{Enabled: true, OTLP: OTLPExporter{Endpoint: "localhost:4317"}, SamplingRate: float64Ptr(0.5)},
+		},
+		"missing endpoint": {
+			cfg:     Tracing{Enabled: true},
+			wantErr: true,
+		},
+		"sampling rate out of range": {
+			cfg:     Tracing{Enabled: true, OTLP: OTLPExporter{Endpoint: "localhost:4317"}, SamplingRate: float64Ptr(2)},
+			wantErr: true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTracingGetSamplingRate(t *testing.T) {
+	assert.Equal(t, 1.0, (&Tracing{}).GetSamplingRate())
+	assert.Equal(t, 0.25, (&Tracing{SamplingRate: float64Ptr(0.25)}).GetSamplingRate())
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
@@ -63,10 +63,13 @@ const (
 	EncodingTypeJSON           EncodingType = "json"
 	EncodingTypeThriftRW       EncodingType = "thriftrw"
 	EncodingTypeThriftRWSnappy EncodingType = "thriftrw_snappy"
+	EncodingTypeThriftRWZstd   EncodingType = "thriftrw_zstd"
 	EncodingTypeGob            EncodingType = "gob"
 	EncodingTypeUnknown        EncodingType = "unknow"
 	EncodingTypeEmpty          EncodingType = ""
 	EncodingTypeProto          EncodingType = "proto3"
+	// EncodingTypeEncrypted is an envelope sealing a payload in one of the other encodings
+	EncodingTypeEncrypted EncodingType = "encrypted"
 )
 
 type (
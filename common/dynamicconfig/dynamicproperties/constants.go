@@ -911,6 +911,13 @@ const (
 	// Default value: 2
 	// Allowed filters: DomainName,TasklistName,TaskType
 	MatchingIsolationGroupsPerPartition
+	// MatchingTaskPriorityStarvationThreshold is the number of times a buffered backlog task can be passed over
+	// for higher priority tasks before it is dispatched regardless of priority. Zero or less means strict priority
+	// KeyName: matching.taskPriorityStarvationThreshold
+	// Value type: Int
+	// Default value: 10
+	// Allowed filters: DomainName,TasklistName,TaskType
+	MatchingTaskPriorityStarvationThreshold
 	// MatchingPercentageOnboardedToShardManager is the percentage of task lists that will be onboarded to the shard manager.
 	// KeyName: matching.percentageOnboardedToShardManager
 	// Value type: Int
@@ -1202,6 +1209,13 @@ const (
 	// Default value: 1000
 	// Allowed filters: N/A
 	HistoryNodeDeleteBatchSize
+	// PayloadEncryptionRewrapMaxQPS is the max qps at which history nodes sealed with a retired payload encryption key
+	// are rewritten with the active key after being read. 0 disables the rewrap
+	// KeyName: history.payloadEncryptionRewrapMaxQPS
+	// Value type: Int
+	// Default value: 10
+	// Allowed filters: N/A
+	PayloadEncryptionRewrapMaxQPS
 	// ReplicatorReadTaskMaxRetryCount is the number of read replication task retry time
 	// KeyName: history.replicatorReadTaskMaxRetryCount
 	// Value type: Int
@@ -1553,6 +1567,30 @@ const (
 	// Default value: 3
 	// Allowed filters: N/A
 	TimersScannerPeriodEnd
+	// VisibilityScannerConcurrency is the concurrency of visibility scanner
+	// KeyName: worker.visibilityScannerConcurrency
+	// Value type: Int
+	// Default value: 5
+	// Allowed filters: N/A
+	VisibilityScannerConcurrency
+	// VisibilityScannerPersistencePageSize is the page size of execution persistence fetches in visibility scanner
+	// KeyName: worker.visibilityScannerPersistencePageSize
+	// Value type: Int
+	// Default value: 1000
+	// Allowed filters: N/A
+	VisibilityScannerPersistencePageSize
+	// VisibilityScannerBlobstoreFlushThreshold is threshold to flush blob store
+	// KeyName: worker.visibilityScannerBlobstoreFlushThreshold
+	// Value type: Int
+	// Default value: 100
+	// Allowed filters: N/A
+	VisibilityScannerBlobstoreFlushThreshold
+	// VisibilityScannerActivityBatchSize is the number of shards processed by a single visibility scanner activity
+	// KeyName: worker.visibilityScannerActivityBatchSize
+	// Value type: Int
+	// Default value: 25
+	// Allowed filters: N/A
+	VisibilityScannerActivityBatchSize
 	// ESAnalyzerMaxNumDomains defines how many domains to check
 	// KeyName: worker.ESAnalyzerMaxNumDomains
 	// Value type: int
@@ -1829,6 +1867,18 @@ const (
 	// Default value: true
 	// Allowed filters: DomainName,TasklistName,TaskType
 	MatchingEnableStandbyTaskCompletion
+	// MatchingEnableTaskPriority is to enable priority-aware dispatch of decision and activity tasks
+	// KeyName: matching.enableTaskPriority
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName,TasklistName,TaskType
+	MatchingEnableTaskPriority
+	// MatchingEnableTaskFairness is to enable weighted round robin dispatch of backlog tasks across fairness keys
+	// KeyName: matching.enableTaskFairness
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName,TasklistName,TaskType
+	MatchingEnableTaskFairness
 
 	// MatchingEnableGetNumberOfPartitionsFromCache is to enable getting number of partitions from cache instead of dynamic config
 	// KeyName: matching.enableGetNumberOfPartitionsFromCache
@@ -2173,6 +2223,30 @@ const (
 	// Default value: false
 	// Allowed filters: DomainName
 	TimersFixerDomainAllow
+	// VisibilityScannerEnabled is if visibility scanner should be started as part of worker.Scanner
+	// KeyName: worker.visibilityScannerEnabled
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	VisibilityScannerEnabled
+	// VisibilityFixerEnabled is if visibility fixer should be started as part of worker.Scanner
+	// KeyName: worker.visibilityFixerEnabled
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	VisibilityFixerEnabled
+	// VisibilityFixerDomainAllow is which domains are allowed to be fixed by visibility fixer workflow
+	// KeyName: worker.visibilityFixerDomainAllow
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName
+	VisibilityFixerDomainAllow
+	// VisibilityBackfillEnabled is if the visibility backfill workflow worker should be started as part of worker
+	// KeyName: worker.visibilityBackfillEnabled
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: N/A
+	VisibilityBackfillEnabled
 	// ConcreteExecutionFixerEnabled is if concrete execution fixer workflow is enabled
 	// KeyName: worker.concreteExecutionFixerEnabled
 	// Value type: Bool
@@ -2322,6 +2396,15 @@ const (
 	// Default value: true
 	// Allowed filters: N/A
 	ReadNoSQLShardFromDataBlob
+	// EnablePayloadEncryption is to seal history events of a domain with envelope encryption, including the events kept in
+	// mutable state. Activity heartbeat and last failure details, the decision execution context, the memo and the search
+	// attributes kept in mutable state are not sealed.
+	// Payload encryption keys must be configured in the persistence config for this to take effect.
+	// KeyName: history.enablePayloadEncryption
+	// Value type: Bool
+	// Default value: false
+	// Allowed filters: DomainName
+	EnablePayloadEncryption
 	// EnableSizeBasedHistoryExecutionCache is the feature flag to enable size based cache for execution cache
 	// KeyName: history.enableSizeBasedHistoryExecutionCache
 	// Value type: Bool
@@ -2605,6 +2688,13 @@ const (
 	// Allowed filters: DomainName, TaskListName, TaskType
 	MatchingOverrideTaskListRPS
 
+	// VisibilityScannerSampleRate is the fraction of executions checked by the visibility scanner, between 0 and 1
+	// KeyName: worker.visibilityScannerSampleRate
+	// Value type: Float64
+	// Default value: 0.01
+	// Allowed filters: N/A
+	VisibilityScannerSampleRate
+
 	// LastFloatKey must be the last one in this const group
 	LastFloatKey
 )
@@ -2641,7 +2731,8 @@ const (
 	// Default value: "enabled"
 	// Allowed filters: N/A
 	VisibilityArchivalStatus
-	// DefaultEventEncoding is the encoding type for history events
+	// DefaultEventEncoding is the encoding type for history events and the events kept in mutable state.
+	// thriftrw_zstd trades some CPU for much smaller blobs on histories with large payloads
 	// KeyName: history.defaultEventEncoding
 	// Value type: String
 	// Default value: string(constants.EncodingTypeThriftRW)
@@ -3430,6 +3521,15 @@ const (
 	// Allowed filters: N/A
 	SearchAttributesHiddenValueKeys
 
+	// key for matching
+
+	// MatchingTaskFairnessKeyWeights is the weighted round robin weight of each task fairness key. Keys without a weight get weight 1
+	// KeyName: matching.taskFairnessKeyWeights
+	// Value type: Map
+	// Default value: empty map
+	// Allowed filters: DomainName
+	MatchingTaskFairnessKeyWeights
+
 	// LastMapKey must be the last one in this const group
 	LastMapKey
 )
@@ -3955,6 +4055,12 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "MatchingIsolationGroupsPerPartition is the target number of isolation groups to assign to each partition",
 		DefaultValue: 2,
 	},
+	MatchingTaskPriorityStarvationThreshold: {
+		KeyName:      "matching.taskPriorityStarvationThreshold",
+		Filters:      []Filter{DomainName, TaskListName, TaskType},
+		Description:  "MatchingTaskPriorityStarvationThreshold is the number of times a buffered backlog task can be passed over for higher priority tasks before it is dispatched regardless of priority",
+		DefaultValue: 10,
+	},
 	MatchingPercentageOnboardedToShardManager: {
 		KeyName:      "matching.percentageOnboardedToShardManager",
 		Description:  "MatchingPercentageOnboardedToShardManager is the percentage of task lists that will be onboarded to the shard manager",
@@ -4198,6 +4304,11 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "HistoryNodeDeleteBatchSize is batch size for deleting history nodes",
 		DefaultValue: 1000,
 	},
+	PayloadEncryptionRewrapMaxQPS: {
+		KeyName:      "history.payloadEncryptionRewrapMaxQPS",
+		Description:  "PayloadEncryptionRewrapMaxQPS is the max qps at which history nodes sealed with a retired payload encryption key are rewritten with the active key after being read. 0 disables the rewrap",
+		DefaultValue: 10,
+	},
 	ReplicatorReadTaskMaxRetryCount: {
 		KeyName:      "history.replicatorReadTaskMaxRetryCount",
 		Description:  "ReplicatorReadTaskMaxRetryCount is the number of read replication task retry time",
@@ -4494,6 +4605,26 @@ var IntKeys = map[IntKey]DynamicInt{
 		Description:  "TimersScannerPeriodEnd is interval end for fetching scheduled timers",
 		DefaultValue: 3,
 	},
+	VisibilityScannerConcurrency: {
+		KeyName:      "worker.visibilityScannerConcurrency",
+		Description:  "VisibilityScannerConcurrency is the concurrency of visibility scanner",
+		DefaultValue: 5,
+	},
+	VisibilityScannerPersistencePageSize: {
+		KeyName:      "worker.visibilityScannerPersistencePageSize",
+		Description:  "VisibilityScannerPersistencePageSize is the page size of execution persistence fetches in visibility scanner",
+		DefaultValue: 1000,
+	},
+	VisibilityScannerBlobstoreFlushThreshold: {
+		KeyName:      "worker.visibilityScannerBlobstoreFlushThreshold",
+		Description:  "VisibilityScannerBlobstoreFlushThreshold is threshold to flush blob store",
+		DefaultValue: 100,
+	},
+	VisibilityScannerActivityBatchSize: {
+		KeyName:      "worker.visibilityScannerActivityBatchSize",
+		Description:  "VisibilityScannerActivityBatchSize is the number of shards processed by a single visibility scanner activity",
+		DefaultValue: 25,
+	},
 	ESAnalyzerMaxNumDomains: {
 		KeyName:      "worker.ESAnalyzerMaxNumDomains",
 		Description:  "ESAnalyzerMaxNumDomains defines how many domains to check",
@@ -4758,6 +4889,18 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "MatchingEnableStandbyTaskCompletion is to enable completion of tasks in the domain's passive side",
 		DefaultValue: true,
 	},
+	MatchingEnableTaskPriority: {
+		KeyName:      "matching.enableTaskPriority",
+		Filters:      []Filter{DomainName, TaskListName, TaskType},
+		Description:  "MatchingEnableTaskPriority is to enable priority-aware dispatch of decision and activity tasks",
+		DefaultValue: false,
+	},
+	MatchingEnableTaskFairness: {
+		KeyName:      "matching.enableTaskFairness",
+		Filters:      []Filter{DomainName, TaskListName, TaskType},
+		Description:  "MatchingEnableTaskFairness is to enable weighted round robin dispatch of backlog tasks across fairness keys",
+		DefaultValue: false,
+	},
 	MatchingEnableAdaptiveScaler: {
 		KeyName:      "matching.enableAdaptiveScaler",
 		Filters:      []Filter{DomainName, TaskListName, TaskType},
@@ -5043,6 +5186,27 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "TimersFixerDomainAllow is which domains are allowed to be fixed by timer fixer workflow",
 		DefaultValue: false,
 	},
+	VisibilityScannerEnabled: {
+		KeyName:      "worker.visibilityScannerEnabled",
+		Description:  "VisibilityScannerEnabled is if visibility scanner should be started as part of worker.Scanner",
+		DefaultValue: false,
+	},
+	VisibilityFixerEnabled: {
+		KeyName:      "worker.visibilityFixerEnabled",
+		Description:  "VisibilityFixerEnabled is if visibility fixer should be started as part of worker.Scanner",
+		DefaultValue: false,
+	},
+	VisibilityFixerDomainAllow: {
+		KeyName:      "worker.visibilityFixerDomainAllow",
+		Filters:      []Filter{DomainName},
+		Description:  "VisibilityFixerDomainAllow is which domains are allowed to be fixed by visibility fixer workflow",
+		DefaultValue: false,
+	},
+	VisibilityBackfillEnabled: {
+		KeyName:      "worker.visibilityBackfillEnabled",
+		Description:  "VisibilityBackfillEnabled is if the visibility backfill workflow worker should be started as part of worker",
+		DefaultValue: false,
+	},
 	ConcreteExecutionFixerEnabled: {
 		KeyName:      "worker.concreteExecutionFixerEnabled",
 		Description:  "ConcreteExecutionFixerEnabled is if concrete execution fixer workflow is enabled",
@@ -5165,6 +5329,12 @@ var BoolKeys = map[BoolKey]DynamicBool{
 		Description:  "ReadNoSQLShardFromDataBlob is to read shards from data blob",
 		DefaultValue: true,
 	},
+	EnablePayloadEncryption: {
+		KeyName:      "history.enablePayloadEncryption",
+		Filters:      []Filter{DomainName},
+		Description:  "EnablePayloadEncryption is to seal history events of a domain with envelope encryption, including the events kept in mutable state. Activity heartbeat and last failure details, the decision execution context, the memo and the search attributes kept in mutable state are not sealed",
+		DefaultValue: false,
+	},
 	EnableSizeBasedHistoryExecutionCache: {
 		KeyName:      "history.enableSizeBasedHistoryExecutionCache",
 		Description:  "EnableSizeBasedHistoryExecutionCache is to enable size based history execution cache",
@@ -5400,6 +5570,11 @@ var FloatKeys = map[FloatKey]DynamicFloat{
 		Filters:      []Filter{DomainName, TaskListName, TaskType},
 		DefaultValue: 0,
 	},
+	VisibilityScannerSampleRate: {
+		KeyName:      "worker.visibilityScannerSampleRate",
+		Description:  "VisibilityScannerSampleRate is the fraction of executions checked by the visibility scanner, between 0 and 1",
+		DefaultValue: 0.01,
+	},
 }
 
 var StringKeys = map[StringKey]DynamicString{
@@ -5426,7 +5601,7 @@ var StringKeys = map[StringKey]DynamicString{
 	DefaultEventEncoding: {
 		KeyName:      "history.defaultEventEncoding",
 		Filters:      []Filter{DomainName},
-		Description:  "DefaultEventEncoding is the encoding type for history events",
+		Description:  "DefaultEventEncoding is the encoding type for history events and the events kept in mutable state, one of thriftrw, thriftrw_snappy or thriftrw_zstd",
 		DefaultValue: string(constants.EncodingTypeThriftRW),
 	},
 	AdminOperationToken: {
@@ -6109,6 +6284,12 @@ var MapKeys = map[MapKey]DynamicMap{
 		Description:  "SearchAttributesHiddenValueKeys is the list of search attributes that values should be hidden",
 		DefaultValue: map[string]interface{}{},
 	},
+	MatchingTaskFairnessKeyWeights: {
+		KeyName:      "matching.taskFairnessKeyWeights",
+		Filters:      []Filter{DomainName},
+		Description:  "MatchingTaskFairnessKeyWeights is the weighted round robin weight of each task fairness key. Keys without a weight get weight 1",
+		DefaultValue: map[string]interface{}{},
+	},
 }
 
 var ListKeys = map[ListKey]DynamicList{
@@ -150,20 +150,40 @@ func ConvertDynamicConfigMapPropertyToIntMap(dcValue map[string]interface{}) (ma
 			return nil, fmt.Errorf("failed to convert key %v, error: %v", key, err)
 		}
 
-		var intValue int
-		switch value := value.(type) {
-		case float64:
-			intValue = int(value)
-		case int:
-			intValue = value
-		case int32:
-			intValue = int(value)
-		case int64:
-			intValue = int(value)
-		default:
-			return nil, fmt.Errorf("unknown value %v with type %T", value, value)
+		intValue, err := convertMapPropertyValueToInt(value)
+		if err != nil {
+			return nil, err
 		}
 		intMap[intKey] = intValue
 	}
 	return intMap, nil
 }
+
+// ConvertDynamicConfigMapPropertyToStringIntMap convert a map property from dynamic config to a map
+// whose values are int
+func ConvertDynamicConfigMapPropertyToStringIntMap(dcValue map[string]interface{}) (map[string]int, error) {
+	intMap := make(map[string]int, len(dcValue))
+	for key, value := range dcValue {
+		intValue, err := convertMapPropertyValueToInt(value)
+		if err != nil {
+			return nil, err
+		}
+		intMap[key] = intValue
+	}
+	return intMap, nil
+}
+
+func convertMapPropertyValueToInt(value interface{}) (int, error) {
+	switch value := value.(type) {
+	case float64:
+		return int(value), nil
+	case int:
+		return value, nil
+	case int32:
+		return int(value), nil
+	case int64:
+		return int(value), nil
+	default:
+		return 0, fmt.Errorf("unknown value %v with type %T", value, value)
+	}
+}
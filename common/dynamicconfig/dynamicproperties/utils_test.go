@@ -42,3 +42,17 @@ func TestConvertDynamicConfigMapPropertyToIntMap(t *testing.T) {
 		require.Equal(t, i, intMap[i])
 	}
 }
+
+func TestConvertDynamicConfigMapPropertyToStringIntMap(t *testing.T) {
+	intMap, err := ConvertDynamicConfigMapPropertyToStringIntMap(map[string]interface{}{
+		"a": int(1),
+		"b": int32(2),
+		"c": int64(3),
+		"d": float64(4.0),
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}, intMap)
+
+	_, err = ConvertDynamicConfigMapPropertyToStringIntMap(map[string]interface{}{"a": "1"})
+	require.Error(t, err)
+}
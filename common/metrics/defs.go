@@ -3013,6 +3013,7 @@ const (
 	ConditionFailedErrorPerTaskListCounter
 	RespondQueryTaskFailedPerTaskListCounter
 	SyncThrottlePerTaskListCounter
+	SyncMatchSkippedForPriorityPerTaskListCounter
 	BufferThrottlePerTaskListCounter
 	BufferUnknownTaskDispatchError
 	BufferIsolationGroupRedirectCounter
@@ -3170,6 +3171,7 @@ const (
 	ScannerCheckFailedGauge
 	ScannerCorruptionByTypeGauge
 	ScannerCorruptedOpenExecutionGauge
+	ScannerVisibilityDriftCount
 	ScannerShardSizeMaxGauge
 	ScannerShardSizeMedianGauge
 	ScannerShardSizeMinGauge
@@ -3978,6 +3980,7 @@ var MetricDefs = map[ServiceIdx]map[MetricIdx]metricDefinition{
 		ConditionFailedErrorPerTaskListCounter:                           {metricName: "condition_failed_errors_per_tl", metricRollupName: "condition_failed_errors"},
 		RespondQueryTaskFailedPerTaskListCounter:                         {metricName: "respond_query_failed_per_tl", metricRollupName: "respond_query_failed"},
 		SyncThrottlePerTaskListCounter:                                   {metricName: "sync_throttle_count_per_tl", metricRollupName: "sync_throttle_count"},
+		SyncMatchSkippedForPriorityPerTaskListCounter:                    {metricName: "sync_match_skipped_for_priority_per_tl", metricRollupName: "sync_match_skipped_for_priority"},
 		BufferThrottlePerTaskListCounter:                                 {metricName: "buffer_throttle_count_per_tl", metricRollupName: "buffer_throttle_count"},
 		BufferUnknownTaskDispatchError:                                   {metricName: "buffer_unknown_task_dispatch_error_per_tl", metricRollupName: "buffer_unknown_task_dispatch_error"},
 		BufferIsolationGroupRedirectCounter:                              {metricName: "buffer_isolation_group_redirected_per_tl", metricRollupName: "buffer_isolation_group_redirected"},
@@ -4131,6 +4134,7 @@ var MetricDefs = map[ServiceIdx]map[MetricIdx]metricDefinition{
 		ScannerCheckFailedGauge:                         {metricName: "scanner_check_failed", metricType: Gauge},
 		ScannerCorruptionByTypeGauge:                    {metricName: "scanner_corruption_by_type", metricType: Gauge},
 		ScannerCorruptedOpenExecutionGauge:              {metricName: "scanner_corrupted_open_execution", metricType: Gauge},
+		ScannerVisibilityDriftCount:                     {metricName: "scanner_visibility_drift", metricType: Counter},
 		ScannerShardSizeMaxGauge:                        {metricName: "scanner_shard_size_max", metricType: Gauge},
 		ScannerShardSizeMedianGauge:                     {metricName: "scanner_shard_size_median", metricType: Gauge},
 		ScannerShardSizeMinGauge:                        {metricName: "scanner_shard_size_min", metricType: Gauge},
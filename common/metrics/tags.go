@@ -48,6 +48,7 @@ const (
 	activityType              = "activityType"
 	decisionType              = "decisionType"
 	invariantType             = "invariantType"
+	visibilityDriftType       = "visibility_drift_type"
 	shardScannerScanResult    = "shardscanner_scan_result"
 	shardScannerFixResult     = "shardscanner_fix_result"
 	kafkaPartition            = "kafkaPartition"
@@ -253,6 +254,11 @@ func InvariantTypeTag(value string) Tag {
 	return metricWithUnknown(invariantType, value)
 }
 
+// VisibilityDriftTypeTag returns a new visibility drift type tag.
+func VisibilityDriftTypeTag(value string) Tag {
+	return metricWithUnknown(visibilityDriftType, value)
+}
+
 // KafkaPartitionTag returns a new KafkaPartition type tag.
 func KafkaPartitionTag(value int32) Tag {
 	return simpleMetric{key: kafkaPartition, value: strconv.Itoa(int(value))}
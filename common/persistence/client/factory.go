@@ -26,10 +26,13 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/uber/cadence/common/clock"
 	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	es "github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
@@ -37,6 +40,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/elasticsearch"
+	"github.com/uber/cadence/common/persistence/encryption"
 	"github.com/uber/cadence/common/persistence/nosql"
 	pinotVisibility "github.com/uber/cadence/common/persistence/pinot"
 	"github.com/uber/cadence/common/persistence/serialization"
@@ -45,6 +49,7 @@ import (
 	"github.com/uber/cadence/common/persistence/wrappers/metered"
 	"github.com/uber/cadence/common/persistence/wrappers/ratelimited"
 	"github.com/uber/cadence/common/persistence/wrappers/sampled"
+	"github.com/uber/cadence/common/persistence/wrappers/traced"
 	pnt "github.com/uber/cadence/common/pinot"
 	"github.com/uber/cadence/common/quotas"
 	"github.com/uber/cadence/common/service"
@@ -113,12 +118,15 @@ type (
 	}
 	factoryImpl struct {
 		sync.RWMutex
-		config        *config.Persistence
-		metricsClient metrics.Client
-		logger        log.Logger
-		datastores    map[storeType]Datastore
-		clusterName   string
-		dc            *p.DynamicConfiguration
+		config         *config.Persistence
+		metricsClient  metrics.Client
+		tracerProvider trace.TracerProvider
+		logger         log.Logger
+		datastores     map[storeType]Datastore
+		clusterName    string
+		dc             *p.DynamicConfiguration
+		// payloadSerializer is shared by the managers that persist history and mutable state payloads
+		payloadSerializer p.PayloadSerializer
 	}
 
 	storeType int
@@ -158,15 +166,17 @@ func NewFactory(
 	persistenceMaxQPS quotas.RPSFunc,
 	clusterName string,
 	metricsClient metrics.Client,
+	tracerProvider trace.TracerProvider,
 	logger log.Logger,
 	dc *p.DynamicConfiguration,
 ) Factory {
 	factory := &factoryImpl{
-		config:        cfg,
-		metricsClient: metricsClient,
-		logger:        logger,
-		clusterName:   clusterName,
-		dc:            dc,
+		config:         cfg,
+		metricsClient:  metricsClient,
+		tracerProvider: tracerProvider,
+		logger:         logger,
+		clusterName:    clusterName,
+		dc:             dc,
 	}
 	limiters := buildRatelimiters(cfg, persistenceMaxQPS)
 	factory.init(clusterName, limiters)
@@ -190,6 +200,9 @@ func (f *factoryImpl) NewTaskManager() (p.TaskManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewTaskManager(result, f.metricsClient, f.logger, f.config)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewTaskManager(result, f.tracerProvider)
+	}
 	return result, nil
 }
 
@@ -210,6 +223,9 @@ func (f *factoryImpl) NewShardManager() (p.ShardManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewShardManager(result, f.metricsClient, f.logger, f.config)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewShardManager(result, f.tracerProvider)
+	}
 	return result, nil
 }
 
@@ -220,7 +236,11 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewHistoryV2ManagerImpl(store, f.logger, p.NewPayloadSerializer(), codec.NewThriftRWEncoder(), f.config.TransactionSizeLimit)
+	var rewrapMaxQPS dynamicproperties.IntPropertyFn
+	if f.config.Encryption != nil {
+		rewrapMaxQPS = f.dc.PayloadEncryptionRewrapMaxQPS
+	}
+	result := p.NewHistoryV2ManagerImpl(store, f.logger, f.payloadSerializer, codec.NewThriftRWEncoder(), f.config.TransactionSizeLimit, rewrapMaxQPS)
 	if errorRate := f.config.ErrorInjectionRate(); errorRate != 0 {
 		result = errorinjectors.NewHistoryManager(result, errorRate, f.logger, time.Now())
 	}
@@ -230,6 +250,9 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewHistoryManager(result, f.metricsClient, f.logger, f.config)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewHistoryManager(result, f.tracerProvider)
+	}
 	return result, nil
 }
 
@@ -252,6 +275,9 @@ func (f *factoryImpl) NewDomainManager() (p.DomainManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewDomainManager(result, f.metricsClient, f.logger, f.config)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewDomainManager(result, f.tracerProvider)
+	}
 	return result, nil
 }
 
@@ -288,6 +314,9 @@ func (f *factoryImpl) NewHistoryTaskDLQManager() (p.HistoryTaskDLQManager, error
 	if f.metricsClient != nil {
 		result = metered.NewHistoryTaskDLQManager(result, f.metricsClient, f.logger, f.config)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewHistoryTaskDLQManager(result, f.tracerProvider)
+	}
 	return result, nil
 }
 
@@ -298,7 +327,7 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewExecutionManagerImpl(store, f.logger, p.NewPayloadSerializer(), f.dc)
+	result := p.NewExecutionManagerImpl(store, f.logger, f.payloadSerializer, f.dc)
 	if errorRate := f.config.ErrorInjectionRate(); errorRate != 0 {
 		result = errorinjectors.NewExecutionManager(result, errorRate, f.logger, time.Now())
 	}
@@ -308,6 +337,9 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 	if f.metricsClient != nil {
 		result = metered.NewExecutionManager(result, f.metricsClient, f.logger, f.config, f.dc.EnableShardIDMetrics)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewExecutionManager(result, f.tracerProvider)
+	}
 	return result, nil
 }
 
@@ -516,6 +548,9 @@ func (f *factoryImpl) newDBVisibilityManager(
 	if f.metricsClient != nil {
 		result = metered.NewVisibilityManager(result, f.metricsClient, f.logger, f.config)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewVisibilityManager(result, f.tracerProvider)
+	}
 
 	return result, nil
 }
@@ -536,6 +571,9 @@ func (f *factoryImpl) NewDomainReplicationQueueManager() (p.QueueManager, error)
 	if f.metricsClient != nil {
 		result = metered.NewQueueManager(result, f.metricsClient, f.logger, f.config)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewQueueManager(result, f.tracerProvider)
+	}
 
 	return result, nil
 }
@@ -556,6 +594,9 @@ func (f *factoryImpl) NewConfigStoreManager() (p.ConfigStoreManager, error) {
 	if f.metricsClient != nil {
 		result = metered.NewConfigStoreManager(result, f.metricsClient, f.logger, f.config)
 	}
+	if f.tracerProvider != nil {
+		result = traced.NewConfigStoreManager(result, f.tracerProvider)
+	}
 
 	return result, nil
 }
@@ -568,6 +609,7 @@ func (f *factoryImpl) Close() {
 
 func (f *factoryImpl) init(clusterName string, limiters map[string]quotas.Limiter) {
 	f.datastores = make(map[storeType]Datastore, len(storeTypes))
+	f.payloadSerializer = f.getPayloadSerializer()
 	defaultCfg := f.config.DataStores[f.config.DefaultStore]
 	if defaultCfg.Cassandra != nil {
 		f.logger.Warn("Cassandra config is deprecated, please use NoSQL with pluginName of cassandra.")
@@ -637,6 +679,17 @@ func (f *factoryImpl) getParser() serialization.Parser {
 	return parser
 }
 
+func (f *factoryImpl) getPayloadSerializer() p.PayloadSerializer {
+	if f.config.Encryption == nil || f.config.Encryption.KeyFile == "" {
+		return p.NewPayloadSerializer()
+	}
+	keyProvider, err := encryption.NewFileKeyProvider(f.config.Encryption.KeyFile)
+	if err != nil {
+		f.logger.Fatal("failed to load payload encryption keys", tag.Error(err))
+	}
+	return p.NewPayloadSerializerWithEncryption(encryption.NewEncryptor(keyProvider), f.dc.EnablePayloadEncryption)
+}
+
 func buildRatelimiters(cfg *config.Persistence, maxQPS quotas.RPSFunc) map[string]quotas.Limiter {
 	result := make(map[string]quotas.Limiter, len(cfg.DataStores))
 	for dsName := range cfg.DataStores {
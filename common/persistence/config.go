@@ -36,10 +36,13 @@ type (
 		EnableHistoryTaskDualWriteMode           dynamicproperties.BoolPropertyFn
 		ReadNoSQLHistoryTaskFromDataBlob         dynamicproperties.BoolPropertyFn
 		ReadNoSQLShardFromDataBlob               dynamicproperties.BoolPropertyFn
+		EnablePayloadEncryption                  dynamicproperties.BoolPropertyFnWithDomainFilter
 		SerializationEncoding                    dynamicproperties.StringPropertyFn
 		DomainAuditLogTTL                        dynamicproperties.DurationPropertyFnWithDomainIDFilter
 		HistoryNodeDeleteBatchSize               dynamicproperties.IntPropertyFn
+		PayloadEncryptionRewrapMaxQPS            dynamicproperties.IntPropertyFn
 		RateLimiterBypassCallerTypes             dynamicproperties.ListPropertyFn
+		ValidSearchAttributes                    dynamicproperties.MapPropertyFn
 	}
 )
 
@@ -54,9 +57,12 @@ func NewDynamicConfiguration(dc *dynamicconfig.Collection) *DynamicConfiguration
 		EnableHistoryTaskDualWriteMode:           dc.GetBoolProperty(dynamicproperties.EnableNoSQLHistoryTaskDualWriteMode),
 		ReadNoSQLHistoryTaskFromDataBlob:         dc.GetBoolProperty(dynamicproperties.ReadNoSQLHistoryTaskFromDataBlob),
 		ReadNoSQLShardFromDataBlob:               dc.GetBoolProperty(dynamicproperties.ReadNoSQLShardFromDataBlob),
+		EnablePayloadEncryption:                  dc.GetBoolPropertyFilteredByDomain(dynamicproperties.EnablePayloadEncryption),
 		SerializationEncoding:                    dc.GetStringProperty(dynamicproperties.SerializationEncoding),
 		DomainAuditLogTTL:                        dc.GetDurationPropertyFilteredByDomainID(dynamicproperties.DomainAuditLogTTL),
 		HistoryNodeDeleteBatchSize:               dc.GetIntProperty(dynamicproperties.HistoryNodeDeleteBatchSize),
+		PayloadEncryptionRewrapMaxQPS:            dc.GetIntProperty(dynamicproperties.PayloadEncryptionRewrapMaxQPS),
 		RateLimiterBypassCallerTypes:             dc.GetListProperty(dynamicproperties.RateLimiterBypassCallerTypes),
+		ValidSearchAttributes:                    dc.GetMapProperty(dynamicproperties.ValidSearchAttributes),
 	}
 }
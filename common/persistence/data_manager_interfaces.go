@@ -50,6 +50,16 @@
 // execution metered wrapper is special
 //go:generate gowrap gen -g -p . -i ExecutionManager -t ./wrappers/templates/metered_execution.tmpl -o wrappers/metered/execution_generated.go
 
+// Generate traced wrappers.
+//go:generate gowrap gen -g -p . -i ConfigStoreManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/configstore_generated.go
+//go:generate gowrap gen -g -p . -i ShardManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/shard_generated.go
+//go:generate gowrap gen -g -p . -i ExecutionManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/execution_generated.go
+//go:generate gowrap gen -g -p . -i TaskManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/task_generated.go
+//go:generate gowrap gen -g -p . -i HistoryManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/history_generated.go
+//go:generate gowrap gen -g -p . -i DomainManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/domain_generated.go
+//go:generate gowrap gen -g -p . -i HistoryTaskDLQManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/historytaskdlq_generated.go
+//go:generate gowrap gen -g -p . -i QueueManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/queue_generated.go
+
 package persistence
 
 import (
@@ -585,6 +595,9 @@ type (
 		Expiry                        time.Time
 		CreatedTime                   time.Time
 		PartitionConfig               map[string]string
+		// Priority is the dispatch priority of the task, zero for tasks
+		// written before priorities were persisted
+		Priority int
 	}
 
 	// TaskKey gives primary key info for a specific task
@@ -1507,6 +1520,9 @@ type (
 		ShardID *int
 
 		DomainName string
+		// ReadFromReplica allows reading from a read replica of the database, which may lag behind.
+		// Only set it for branches which can no longer change, e.g. of closed workflows
+		ReadFromReplica bool
 	}
 
 	// ReadHistoryBranchResponse is the response to ReadHistoryBranchRequest
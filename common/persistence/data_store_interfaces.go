@@ -164,6 +164,8 @@ type (
 
 		// AppendHistoryNodes add(or override) a node to a history branch
 		AppendHistoryNodes(ctx context.Context, request *InternalAppendHistoryNodesRequest) error
+		// RewriteHistoryNode replaces the data of an existing node, it does nothing if the node does not exist
+		RewriteHistoryNode(ctx context.Context, request *InternalRewriteHistoryNodeRequest) error
 		// ReadHistoryBranch returns history node data for a branch
 		ReadHistoryBranch(ctx context.Context, request *InternalReadHistoryBranchRequest) (*InternalReadHistoryBranchResponse, error)
 		// ForkHistoryBranch forks a new branch from a old branch
@@ -646,6 +648,21 @@ type (
 		CurrentTimeStamp time.Time
 	}
 
+	// InternalRewriteHistoryNodeRequest is used to replace the data of a history node
+	InternalRewriteHistoryNodeRequest struct {
+		// A UUID of a tree
+		TreeID string
+		// A UUID of the branch the node belongs to
+		BranchID string
+		// The node to be rewritten
+		NodeID        int64
+		TransactionID int64
+		// The new data of the node, it must hold the same events
+		Events *DataBlob
+		// Used in sharded data stores to identify which shard to use
+		ShardID int
+	}
+
 	// InternalGetWorkflowExecutionRequest is used to retrieve the info of a workflow execution
 	InternalGetWorkflowExecutionRequest struct {
 		ShardID   ShardID
@@ -721,6 +738,8 @@ type (
 		LastTransactionID int64
 		// Used in sharded data stores to identify which shard to use
 		ShardID int
+		// ReadFromReplica allows reading from a read replica of the database
+		ReadFromReplica bool
 	}
 
 	// InternalCompleteForkBranchRequest is used to update some tree/branch meta data for forking
@@ -737,6 +756,9 @@ type (
 	InternalReadHistoryBranchResponse struct {
 		// History events
 		History []*DataBlob
+		// NodeIDs and TransactionIDs identify the node each batch of History was read from
+		NodeIDs        []int64
+		TransactionIDs []int64
 		// Pagination token
 		NextPageToken []byte
 		// LastNodeID is the last known node ID attached to a history node
@@ -1228,6 +1250,10 @@ func (d *DataBlob) GetEncoding() constants.EncodingType {
 		return constants.EncodingTypeThriftRW
 	case constants.EncodingTypeThriftRWSnappy:
 		return constants.EncodingTypeThriftRWSnappy
+	case constants.EncodingTypeThriftRWZstd:
+		return constants.EncodingTypeThriftRWZstd
+	case constants.EncodingTypeEncrypted:
+		return constants.EncodingTypeEncrypted
 	case constants.EncodingTypeEmpty:
 		return constants.EncodingTypeEmpty
 	default:
@@ -1011,6 +1011,20 @@ func (mr *MockHistoryStoreMockRecorder) ReadHistoryBranch(ctx, request any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadHistoryBranch", reflect.TypeOf((*MockHistoryStore)(nil).ReadHistoryBranch), ctx, request)
 }
 
+// RewriteHistoryNode mocks base method.
+func (m *MockHistoryStore) RewriteHistoryNode(ctx context.Context, request *InternalRewriteHistoryNodeRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewriteHistoryNode", ctx, request)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RewriteHistoryNode indicates an expected call of RewriteHistoryNode.
+func (mr *MockHistoryStoreMockRecorder) RewriteHistoryNode(ctx, request any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewriteHistoryNode", reflect.TypeOf((*MockHistoryStore)(nil).RewriteHistoryNode), ctx, request)
+}
+
 // MockConfigStore is a mock of ConfigStore interface.
 type MockConfigStore struct {
 	ctrl     *gomock.Controller
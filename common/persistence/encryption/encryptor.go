@@ -0,0 +1,242 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/uber/cadence/common/constants"
+)
+
+const envelopeVersion byte = 1
+
+var errMalformedEnvelope = errors.New("malformed encryption envelope")
+
+type (
+	// Encryptor seals serialized payloads with envelope encryption: every payload is
+	// encrypted with a fresh data encryption key, which is in turn wrapped with the
+	// data key of the payload's domain.
+	Encryptor interface {
+		// Encrypt seals the payload of the domain with the active key
+		Encrypt(domain string, encoding constants.EncodingType, data []byte) ([]byte, error)
+		// Decrypt opens an envelope produced by Encrypt, using the key it was sealed with
+		Decrypt(envelope []byte) (Payload, error)
+		// Rewrap re-wraps the data key of an envelope sealed with a key other than the active one,
+		// leaving the encrypted payload as is. It returns false if the envelope already uses the active key.
+		Rewrap(envelope []byte) ([]byte, bool, error)
+	}
+
+	// Payload is the content of an opened envelope
+	Payload struct {
+		Domain   string
+		KeyID    string
+		Encoding constants.EncodingType
+		Data     []byte
+	}
+
+	encryptorImpl struct {
+		keyProvider KeyProvider
+	}
+
+	// envelope is serialized as a version byte followed by length-prefixed fields
+	envelope struct {
+		keyID      string
+		domain     string
+		encoding   string
+		wrappedKey []byte
+		ciphertext []byte
+	}
+)
+
+// NewEncryptor returns an Encryptor using AES-256-GCM with keys from the given provider
+func NewEncryptor(keyProvider KeyProvider) Encryptor {
+	return &encryptorImpl{
+		keyProvider: keyProvider,
+	}
+}
+
+func (e *encryptorImpl) Encrypt(domain string, encoding constants.EncodingType, data []byte) ([]byte, error) {
+	keyID := e.keyProvider.ActiveKeyID()
+	domainKey, err := e.keyProvider.DomainKey(domain, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataKey := make([]byte, KeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	wrappedKey, err := seal(domainKey, dataKey, []byte(domain))
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := seal(dataKey, data, payloadAdditionalData(domain, string(encoding)))
+	if err != nil {
+		return nil, err
+	}
+
+	return envelope{
+		keyID:      keyID,
+		domain:     domain,
+		encoding:   string(encoding),
+		wrappedKey: wrappedKey,
+		ciphertext: ciphertext,
+	}.marshal(), nil
+}
+
+func (e *encryptorImpl) Decrypt(data []byte) (Payload, error) {
+	env, err := unmarshalEnvelope(data)
+	if err != nil {
+		return Payload{}, err
+	}
+	domainKey, err := e.keyProvider.DomainKey(env.domain, env.keyID)
+	if err != nil {
+		return Payload{}, err
+	}
+
+	dataKey, err := open(domainKey, env.wrappedKey, []byte(env.domain))
+	if err != nil {
+		return Payload{}, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	plaintext, err := open(dataKey, env.ciphertext, payloadAdditionalData(env.domain, env.encoding))
+	if err != nil {
+		return Payload{}, fmt.Errorf("failed to decrypt payload: %w", err)
+	}
+
+	return Payload{
+		Domain:   env.domain,
+		KeyID:    env.keyID,
+		Encoding: constants.EncodingType(env.encoding),
+		Data:     plaintext,
+	}, nil
+}
+
+func (e *encryptorImpl) Rewrap(data []byte) ([]byte, bool, error) {
+	env, err := unmarshalEnvelope(data)
+	if err != nil {
+		return nil, false, err
+	}
+	keyID := e.keyProvider.ActiveKeyID()
+	if env.keyID == keyID {
+		return data, false, nil
+	}
+	domainKey, err := e.keyProvider.DomainKey(env.domain, env.keyID)
+	if err != nil {
+		return nil, false, err
+	}
+	dataKey, err := open(domainKey, env.wrappedKey, []byte(env.domain))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	activeDomainKey, err := e.keyProvider.DomainKey(env.domain, keyID)
+	if err != nil {
+		return nil, false, err
+	}
+	env.keyID = keyID
+	if env.wrappedKey, err = seal(activeDomainKey, dataKey, []byte(env.domain)); err != nil {
+		return nil, false, err
+	}
+	return env.marshal(), true, nil
+}
+
+// payloadAdditionalData binds the ciphertext to the domain and encoding recorded in the envelope
+func payloadAdditionalData(domain string, encoding string) []byte {
+	return []byte(domain + "/" + encoding)
+}
+
+// seal encrypts plaintext with AES-GCM and prepends the random nonce to the result
+func seal(key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key []byte, sealed []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, errMalformedEnvelope
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (e envelope) marshal() []byte {
+	fields := [][]byte{[]byte(e.keyID), []byte(e.domain), []byte(e.encoding), e.wrappedKey, e.ciphertext}
+	size := 1
+	for _, field := range fields {
+		size += binary.MaxVarintLen64 + len(field)
+	}
+	buf := make([]byte, 1, size)
+	buf[0] = envelopeVersion
+	for _, field := range fields {
+		buf = binary.AppendUvarint(buf, uint64(len(field)))
+		buf = append(buf, field...)
+	}
+	return buf
+}
+
+func unmarshalEnvelope(data []byte) (envelope, error) {
+	if len(data) == 0 || data[0] != envelopeVersion {
+		return envelope{}, errMalformedEnvelope
+	}
+	data = data[1:]
+	fields := make([][]byte, 5)
+	for i := range fields {
+		length, n := binary.Uvarint(data)
+		if n <= 0 || uint64(len(data)-n) < length {
+			return envelope{}, errMalformedEnvelope
+		}
+		fields[i] = data[n : n+int(length)]
+		data = data[n+int(length):]
+	}
+	if len(data) != 0 {
+		return envelope{}, errMalformedEnvelope
+	}
+	return envelope{
+		keyID:      string(fields[0]),
+		domain:     string(fields[1]),
+		encoding:   string(fields[2]),
+		wrappedKey: fields[3],
+		ciphertext: fields[4],
+	}, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package encryption
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/constants"
+)
+
+func newTestEncryptor(t *testing.T, activeKey string) Encryptor {
+	provider, err := NewKeyProvider(KeyFile{
+		ActiveKey: activeKey,
+		Keys: map[string]string{
+			"k1": testKey('a'),
+			"k2": testKey('b'),
+		},
+	})
+	require.NoError(t, err)
+	return NewEncryptor(provider)
+}
+
+func TestEncryptor_RoundTrip(t *testing.T) {
+	encryptor := newTestEncryptor(t, "k1")
+	plaintext := []byte("workflow input")
+
+	sealed, err := encryptor.Encrypt("test-domain", constants.EncodingTypeThriftRW, plaintext)
+	require.NoError(t, err)
+	assert.NotContains(t, string(sealed), string(plaintext))
+
+	payload, err := encryptor.Decrypt(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, Payload{
+		Domain:   "test-domain",
+		KeyID:    "k1",
+		Encoding: constants.EncodingTypeThriftRW,
+		Data:     plaintext,
+	}, payload)
+
+	// every payload gets its own data key and nonce
+	sealedAgain, err := encryptor.Encrypt("test-domain", constants.EncodingTypeThriftRW, plaintext)
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, sealedAgain)
+}
+
+func TestEncryptor_KeyRotation(t *testing.T) {
+	sealed, err := newTestEncryptor(t, "k1").Encrypt("test-domain", constants.EncodingTypeJSON, []byte("{}"))
+	require.NoError(t, err)
+
+	rotated := newTestEncryptor(t, "k2")
+	payload, err := rotated.Decrypt(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "k1", payload.KeyID)
+	assert.Equal(t, []byte("{}"), payload.Data)
+
+	resealed, err := rotated.Encrypt(payload.Domain, payload.Encoding, payload.Data)
+	require.NoError(t, err)
+	payload, err = rotated.Decrypt(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", payload.KeyID)
+}
+
+func TestEncryptor_Rewrap(t *testing.T) {
+	sealed, err := newTestEncryptor(t, "k1").Encrypt("test-domain", constants.EncodingTypeJSON, []byte("{}"))
+	require.NoError(t, err)
+
+	_, rewrapped, err := newTestEncryptor(t, "k1").Rewrap(sealed)
+	require.NoError(t, err)
+	assert.False(t, rewrapped, "envelope already uses the active key")
+
+	rotated := newTestEncryptor(t, "k2")
+	resealed, rewrapped, err := rotated.Rewrap(sealed)
+	require.NoError(t, err)
+	assert.True(t, rewrapped)
+
+	// only the data key is wrapped again, the payload is not re-encrypted
+	before, err := unmarshalEnvelope(sealed)
+	require.NoError(t, err)
+	after, err := unmarshalEnvelope(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, before.ciphertext, after.ciphertext)
+	assert.NotEqual(t, before.wrappedKey, after.wrappedKey)
+
+	// the retired key is not needed to open the envelope anymore
+	provider, err := NewKeyProvider(KeyFile{
+		ActiveKey: "k2",
+		Keys:      map[string]string{"k2": testKey('b')},
+	})
+	require.NoError(t, err)
+	payload, err := NewEncryptor(provider).Decrypt(resealed)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", payload.KeyID)
+	assert.Equal(t, []byte("{}"), payload.Data)
+
+	_, _, err = rotated.Rewrap(sealed[:len(sealed)-1])
+	assert.ErrorIs(t, err, errMalformedEnvelope)
+}
+
+func TestEncryptor_Tampering(t *testing.T) {
+	encryptor := newTestEncryptor(t, "k1")
+	sealed, err := encryptor.Encrypt("test-domain", constants.EncodingTypeThriftRW, []byte("payload"))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		data        []byte
+		expectedErr string
+	}{
+		"empty": {
+			data:        nil,
+			expectedErr: errMalformedEnvelope.Error(),
+		},
+		"unknown version": {
+			data:        append([]byte{envelopeVersion + 1}, sealed[1:]...),
+			expectedErr: errMalformedEnvelope.Error(),
+		},
+		"truncated": {
+			data:        sealed[:len(sealed)-1],
+			expectedErr: errMalformedEnvelope.Error(),
+		},
+		"modified ciphertext": {
+			data: func() []byte {
+				modified := append([]byte(nil), sealed...)
+				modified[len(modified)-1] ^= 0xff
+				return modified
+			}(),
+			expectedErr: "failed to decrypt payload",
+		},
+		"moved to another domain": {
+			data: func() []byte {
+				env, err := unmarshalEnvelope(sealed)
+				require.NoError(t, err)
+				env.domain = "other-domain"
+				return env.marshal()
+			}(),
+			expectedErr: "failed to unwrap data key",
+		},
+		"modified encoding": {
+			data: func() []byte {
+				env, err := unmarshalEnvelope(sealed)
+				require.NoError(t, err)
+				env.encoding = string(constants.EncodingTypeJSON)
+				return env.marshal()
+			}(),
+			expectedErr: "failed to decrypt payload",
+		},
+		"unknown key": {
+			data: func() []byte {
+				env, err := unmarshalEnvelope(sealed)
+				require.NoError(t, err)
+				env.keyID = "k3"
+				return env.marshal()
+			}(),
+			expectedErr: `key "k3" not found`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := encryptor.Decrypt(tc.data)
+			assert.ErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
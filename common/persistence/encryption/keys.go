@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package encryption
+
+import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// KeySize is the size in bytes of master keys and the data keys derived from them
+	KeySize = 32
+
+	domainKeyInfoPrefix = "cadence-domain-data-key:"
+)
+
+type (
+	// KeyProvider vends the per-domain data keys used to wrap payload encryption keys
+	KeyProvider interface {
+		// ActiveKeyID returns the ID of the key new payloads are sealed with
+		ActiveKeyID() string
+		// DomainKey returns the data key of the domain for the given key ID
+		DomainKey(domain string, keyID string) ([]byte, error)
+	}
+
+	// KeyFile is the on-disk format read by NewFileKeyProvider.
+	// Keys are base64 encoded 32 byte secrets. Rotating keys is done by adding a new key
+	// and pointing ActiveKey at it; retired keys must stay in the file as long as
+	// any payload sealed with them may still be read. History nodes are rewrapped
+	// with the active key when they are read, other payloads when they are rewritten.
+	KeyFile struct {
+		ActiveKey string            `yaml:"activeKey"`
+		Keys      map[string]string `yaml:"keys"`
+	}
+
+	fileKeyProvider struct {
+		activeKeyID string
+		masterKeys  map[string][]byte
+	}
+)
+
+// NewFileKeyProvider returns a KeyProvider backed by a local key file.
+// Per-domain data keys are derived from the master keys with HKDF-SHA256.
+func NewFileKeyProvider(path string) (KeyProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	var keyFile KeyFile
+	if err := yaml.Unmarshal(content, &keyFile); err != nil {
+		return nil, fmt.Errorf("failed to parse key file: %w", err)
+	}
+	return NewKeyProvider(keyFile)
+}
+
+// NewKeyProvider returns a KeyProvider for the given keys
+func NewKeyProvider(keyFile KeyFile) (KeyProvider, error) {
+	if keyFile.ActiveKey == "" {
+		return nil, fmt.Errorf("active key must be specified")
+	}
+	masterKeys := make(map[string][]byte, len(keyFile.Keys))
+	for id, encoded := range keyFile.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key %q is not valid base64: %w", id, err)
+		}
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("key %q must be %d bytes, got %d", id, KeySize, len(key))
+		}
+		masterKeys[id] = key
+	}
+	if _, ok := masterKeys[keyFile.ActiveKey]; !ok {
+		return nil, fmt.Errorf("active key %q not found", keyFile.ActiveKey)
+	}
+	return &fileKeyProvider{
+		activeKeyID: keyFile.ActiveKey,
+		masterKeys:  masterKeys,
+	}, nil
+}
+
+func (p *fileKeyProvider) ActiveKeyID() string {
+	return p.activeKeyID
+}
+
+func (p *fileKeyProvider) DomainKey(domain string, keyID string) ([]byte, error) {
+	masterKey, ok := p.masterKeys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found", keyID)
+	}
+	return hkdf.Key(sha256.New, masterKey, nil, domainKeyInfoPrefix+domain, KeySize)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package encryption
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) string {
+	return base64.StdEncoding.EncodeToString([]byte(strings.Repeat(string([]byte{b}), KeySize)))
+}
+
+func TestNewKeyProvider(t *testing.T) {
+	tests := map[string]struct {
+		keyFile     KeyFile
+		expectedErr string
+	}{
+		"valid": {
+			keyFile: KeyFile{ActiveKey: "k1", Keys: map[string]string{"k1": testKey('a')}},
+		},
+		"missing active key": {
+			keyFile:     KeyFile{Keys: map[string]string{"k1": testKey('a')}},
+			expectedErr: "active key must be specified",
+		},
+		"active key not found": {
+			keyFile:     KeyFile{ActiveKey: "k2", Keys: map[string]string{"k1": testKey('a')}},
+			expectedErr: `active key "k2" not found`,
+		},
+		"invalid base64": {
+			keyFile:     KeyFile{ActiveKey: "k1", Keys: map[string]string{"k1": "not base64!"}},
+			expectedErr: `key "k1" is not valid base64`,
+		},
+		"invalid key size": {
+			keyFile:     KeyFile{ActiveKey: "k1", Keys: map[string]string{"k1": base64.StdEncoding.EncodeToString([]byte("short"))}},
+			expectedErr: `key "k1" must be 32 bytes, got 5`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			provider, err := NewKeyProvider(tc.keyFile)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.keyFile.ActiveKey, provider.ActiveKeyID())
+		})
+	}
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.yaml")
+	content := "activeKey: k2\nkeys:\n  k1: " + testKey('a') + "\n  k2: " + testKey('b') + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	provider, err := NewFileKeyProvider(path)
+	require.NoError(t, err)
+	assert.Equal(t, "k2", provider.ActiveKeyID())
+
+	domainKey, err := provider.DomainKey("domain-a", "k1")
+	require.NoError(t, err)
+	assert.Len(t, domainKey, KeySize)
+
+	sameDomainKey, err := provider.DomainKey("domain-a", "k1")
+	require.NoError(t, err)
+	assert.Equal(t, domainKey, sameDomainKey)
+
+	otherDomainKey, err := provider.DomainKey("domain-b", "k1")
+	require.NoError(t, err)
+	assert.NotEqual(t, domainKey, otherDomainKey)
+
+	rotatedKey, err := provider.DomainKey("domain-a", "k2")
+	require.NoError(t, err)
+	assert.NotEqual(t, domainKey, rotatedKey)
+
+	_, err = provider.DomainKey("domain-a", "k3")
+	assert.ErrorContains(t, err, `key "k3" not found`)
+
+	_, err = NewFileKeyProvider(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read key file")
+}
@@ -349,12 +349,18 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
+	if err := m.encryptWorkflowMutation(request.DomainName, serializedWorkflowMutation); err != nil {
+		return nil, err
+	}
 	var serializedNewWorkflowSnapshot *InternalWorkflowSnapshot
 	if request.NewWorkflowSnapshot != nil {
 		serializedNewWorkflowSnapshot, err = m.SerializeWorkflowSnapshot(request.NewWorkflowSnapshot, request.Encoding)
 		if err != nil {
 			return nil, err
 		}
+		if err := m.encryptWorkflowSnapshot(request.DomainName, serializedNewWorkflowSnapshot); err != nil {
+			return nil, err
+		}
 	}
 
 	newRequest := &InternalUpdateWorkflowExecutionRequest{
@@ -570,12 +576,18 @@ func (m *executionManagerImpl) ConflictResolveWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
+	if err := m.encryptWorkflowSnapshot(request.DomainName, serializedResetWorkflowSnapshot); err != nil {
+		return nil, err
+	}
 	var serializedCurrentWorkflowMutation *InternalWorkflowMutation
 	if request.CurrentWorkflowMutation != nil {
 		serializedCurrentWorkflowMutation, err = m.SerializeWorkflowMutation(request.CurrentWorkflowMutation, request.Encoding)
 		if err != nil {
 			return nil, err
 		}
+		if err := m.encryptWorkflowMutation(request.DomainName, serializedCurrentWorkflowMutation); err != nil {
+			return nil, err
+		}
 	}
 	var serializedNewWorkflowMutation *InternalWorkflowSnapshot
 	if request.NewWorkflowSnapshot != nil {
@@ -583,6 +595,9 @@ func (m *executionManagerImpl) ConflictResolveWorkflowExecution(
 		if err != nil {
 			return nil, err
 		}
+		if err := m.encryptWorkflowSnapshot(request.DomainName, serializedNewWorkflowMutation); err != nil {
+			return nil, err
+		}
 	}
 
 	newRequest := &InternalConflictResolveWorkflowExecutionRequest{
@@ -617,6 +632,9 @@ func (m *executionManagerImpl) CreateWorkflowExecution(
 	if err != nil {
 		return nil, err
 	}
+	if err := m.encryptWorkflowSnapshot(request.DomainName, serializedNewWorkflowSnapshot); err != nil {
+		return nil, err
+	}
 
 	newRequest := &InternalCreateWorkflowExecutionRequest{
 		ShardID: request.ShardID,
@@ -831,6 +849,46 @@ func (m *executionManagerImpl) SerializeWorkflowSnapshot(
 	}, nil
 }
 
+func (m *executionManagerImpl) encryptWorkflowMutation(domainName string, mutation *InternalWorkflowMutation) error {
+	if err := m.encryptEvents(domainName, mutation.ExecutionInfo, mutation.UpsertActivityInfos, mutation.UpsertChildExecutionInfos); err != nil {
+		return err
+	}
+	var err error
+	mutation.NewBufferedEvents, err = m.serializer.EncryptBlob(domainName, mutation.NewBufferedEvents)
+	return err
+}
+
+func (m *executionManagerImpl) encryptWorkflowSnapshot(domainName string, snapshot *InternalWorkflowSnapshot) error {
+	return m.encryptEvents(domainName, snapshot.ExecutionInfo, snapshot.ActivityInfos, snapshot.ChildExecutionInfos)
+}
+
+// encryptEvents seals the history events kept in mutable state, as they carry workflow inputs, results and signal payloads.
+// Only these events and buffered events are sealed. Mutable state also keeps user payloads outside of events which are
+// stored as is: activity heartbeat details, the details of the last activity failure, the decision execution context,
+// the memo and the search attributes. Memo and search attributes are written to visibility as is too.
+func (m *executionManagerImpl) encryptEvents(
+	domainName string,
+	info *InternalWorkflowExecutionInfo,
+	activityInfos []*InternalActivityInfo,
+	childInfos []*InternalChildExecutionInfo,
+) error {
+	blobs := []**DataBlob{&info.CompletionEvent}
+	for _, activityInfo := range activityInfos {
+		blobs = append(blobs, &activityInfo.ScheduledEvent, &activityInfo.StartedEvent)
+	}
+	for _, childInfo := range childInfos {
+		blobs = append(blobs, &childInfo.InitiatedEvent, &childInfo.StartedEvent)
+	}
+	for _, blob := range blobs {
+		encrypted, err := m.serializer.EncryptBlob(domainName, *blob)
+		if err != nil {
+			return err
+		}
+		*blob = encrypted
+	}
+	return nil
+}
+
 func (m *executionManagerImpl) SerializeVersionHistories(
 	versionHistories *VersionHistories,
 	encoding constants.EncodingType,
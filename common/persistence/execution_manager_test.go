@@ -299,6 +299,7 @@ func TestExecutionManager_UpdateWorkflowExecution(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
+	expectPassThroughEncryption(mockedSerializer)
 
 	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, &DynamicConfiguration{
 		SerializationEncoding: dynamicproperties.GetStringPropertyFn(string(constants.EncodingTypeThriftRW)),
@@ -1081,6 +1082,7 @@ func TestCreateWorkflowExecution(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockedStore := NewMockExecutionStore(ctrl)
 			mockedSerializer := NewMockPayloadSerializer(ctrl)
+			expectPassThroughEncryption(mockedSerializer)
 
 			tc.prepareMocks(mockedStore, mockedSerializer)
 
@@ -1258,6 +1260,7 @@ func TestConflictResolveWorkflowExecution(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockedStore := NewMockExecutionStore(ctrl)
 			mockedSerializer := NewMockPayloadSerializer(ctrl)
+			expectPassThroughEncryption(mockedSerializer)
 
 			tc.prepareMocks(mockedStore, mockedSerializer)
 
@@ -1777,6 +1780,7 @@ func TestUpdateWorkflowExecution_TimerTaskTracking(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
+	expectPassThroughEncryption(mockedSerializer)
 
 	minTTL := time.Hour
 	longLivedID := int64(999)
@@ -1840,6 +1844,7 @@ func TestCreateWorkflowExecution_TimerTaskTracking(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
+	expectPassThroughEncryption(mockedSerializer)
 
 	minTTL := time.Hour
 	longLivedID := int64(888)
@@ -1902,6 +1907,7 @@ func TestUpdateWorkflowExecution_TimerTaskTrackingFlagOff(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
+	expectPassThroughEncryption(mockedSerializer)
 
 	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, &DynamicConfiguration{
 		SerializationEncoding:          dynamicproperties.GetStringPropertyFn(string(constants.EncodingTypeThriftRW)),
@@ -1944,6 +1950,7 @@ func TestCreateWorkflowExecution_TimerTaskTrackingFlagOff(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
+	expectPassThroughEncryption(mockedSerializer)
 
 	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, &DynamicConfiguration{
 		SerializationEncoding:          dynamicproperties.GetStringPropertyFn(string(constants.EncodingTypeThriftRW)),
@@ -1986,6 +1993,7 @@ func TestConflictResolveWorkflowExecution_TimerTaskTracking(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
+	expectPassThroughEncryption(mockedSerializer)
 
 	minTTL := time.Hour
 	resetTaskID := int64(111)
@@ -2069,6 +2077,7 @@ func TestConflictResolveWorkflowExecution_TimerTaskTrackingFlagOff(t *testing.T)
 	ctrl := gomock.NewController(t)
 	mockedStore := NewMockExecutionStore(ctrl)
 	mockedSerializer := NewMockPayloadSerializer(ctrl)
+	expectPassThroughEncryption(mockedSerializer)
 
 	manager := NewExecutionManagerImpl(mockedStore, testlogger.New(t), mockedSerializer, &DynamicConfiguration{
 		SerializationEncoding:          dynamicproperties.GetStringPropertyFn(string(constants.EncodingTypeThriftRW)),
@@ -2204,3 +2213,10 @@ func TestCompleteHistoryTasks(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+// expectPassThroughEncryption lets blobs through the mocked serializer unencrypted
+func expectPassThroughEncryption(mockedSerializer *MockPayloadSerializer) {
+	mockedSerializer.EXPECT().EncryptBlob(gomock.Any(), gomock.Any()).DoAndReturn(func(_ string, blob *DataBlob) (*DataBlob, error) {
+		return blob, nil
+	}).AnyTimes()
+}
@@ -68,6 +68,7 @@ type (
 		readRawHistoryBranchFn func(context.Context, *ReadHistoryBranchRequest) ([]*DataBlob, *historyV2PagingToken, int, log.Logger, error)
 		readHistoryBranchFn    func(context.Context, bool, *ReadHistoryBranchRequest) ([]*types.HistoryEvent, []*types.History, []byte, int, int64, error)
 		timeSrc                clock.TimeSource
+		rewrapper              *historyNodeRewrapper
 	}
 )
 
@@ -83,13 +84,15 @@ var (
 
 var _ HistoryManager = (*historyV2ManagerImpl)(nil)
 
-// NewHistoryV2ManagerImpl returns new HistoryManager
+// NewHistoryV2ManagerImpl returns new HistoryManager. History nodes sealed with a retired payload encryption key
+// are rewritten with the active key at up to rewrapMaxQPS after being read, rewrapMaxQPS may be nil to never rewrite them
 func NewHistoryV2ManagerImpl(
 	persistence HistoryStore,
 	logger log.Logger,
 	historySerializer PayloadSerializer,
 	binaryEncoder codec.BinaryEncoder,
 	transactionSizeLimit dynamicproperties.IntPropertyFn,
+	rewrapMaxQPS dynamicproperties.IntPropertyFn,
 ) HistoryManager {
 	hm := &historyV2ManagerImpl{
 		historySerializer:    historySerializer,
@@ -101,6 +104,10 @@ func NewHistoryV2ManagerImpl(
 		deserializeTokenFn:   deserializeToken,
 		timeSrc:              clock.NewRealTimeSource(),
 	}
+	if rewrapMaxQPS != nil {
+		hm.rewrapper = newHistoryNodeRewrapper(persistence, historySerializer, logger, rewrapMaxQPS)
+		hm.rewrapper.start()
+	}
 	hm.readRawHistoryBranchFn = hm.readRawHistoryBranch
 	hm.readHistoryBranchFn = hm.readHistoryBranch
 	return hm
@@ -262,6 +269,10 @@ func (m *historyV2ManagerImpl) AppendHistoryNodes(
 	if err != nil {
 		return nil, err
 	}
+	blob, err = m.historySerializer.EncryptBlob(request.DomainName, blob)
+	if err != nil {
+		return nil, err
+	}
 	size := len(blob.Data)
 	sizeLimit := m.transactionSizeLimit()
 	if size > sizeLimit {
@@ -440,6 +451,7 @@ func (m *historyV2ManagerImpl) readRawHistoryBranch(
 		LastTransactionID: token.LastTransactionID,
 		ShardID:           shardID,
 		PageSize:          pageSize,
+		ReadFromReplica:   request.ReadFromReplica,
 	}
 
 	resp, err := m.persistence.ReadHistoryBranch(ctx, req)
@@ -453,8 +465,23 @@ func (m *historyV2ManagerImpl) readRawHistoryBranch(
 
 	dataBlobs := resp.History
 	dataSize := 0
-	for _, dataBlob := range resp.History {
+	for i, dataBlob := range resp.History {
 		dataSize += len(dataBlob.Data)
+		// payloads are only encrypted at rest, callers always get the serialized events back
+		if dataBlob.Encoding == constants.EncodingTypeEncrypted {
+			if i < len(resp.NodeIDs) && i < len(resp.TransactionIDs) {
+				m.rewrapper.enqueue(req, resp.NodeIDs[i], resp.TransactionIDs[i], dataBlob)
+			}
+			if dataBlobs[i], err = m.historySerializer.DecryptBlob(dataBlob); err != nil {
+				return nil, nil, 0, nil, err
+			}
+		}
+		// zstd is a storage detail as well, raw history leaves persistence as thriftrw which every cluster can decode
+		if dataBlobs[i].Encoding == constants.EncodingTypeThriftRWZstd {
+			if dataBlobs[i], err = decompressZstdBlob(dataBlobs[i]); err != nil {
+				return nil, nil, 0, nil, err
+			}
+		}
 	}
 
 	token.StoreToken = resp.NextPageToken
@@ -589,6 +616,7 @@ func serializeToken(pagingToken *historyV2PagingToken) ([]byte, error) {
 }
 
 func (m *historyV2ManagerImpl) Close() {
+	m.rewrapper.stop()
 	m.persistence.Close()
 }
 
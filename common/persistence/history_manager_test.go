@@ -26,8 +26,10 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	workflow "github.com/uber/cadence/.gen/go/shared"
@@ -54,6 +56,7 @@ func setUpMocksForHistoryV2Manager(t *testing.T) (*historyV2ManagerImpl, *MockHi
 		mockSerializer,
 		mockEncoder,
 		dynamicproperties.GetIntPropertyFn(1024*10),
+		nil,
 	)
 	assert.Equal(t, "mock history store", historyManager.GetName())
 
@@ -435,6 +438,9 @@ func TestAppendHistoryNodes(t *testing.T) {
 				mockSerializer.EXPECT().
 					SerializeBatchEvents(gomock.Any(), gomock.Any()).
 					Return(&DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: []byte("events")}, nil).Times(1)
+				mockSerializer.EXPECT().
+					EncryptBlob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ string, blob *DataBlob) (*DataBlob, error) { return blob, nil }).Times(1)
 				mockStore.EXPECT().
 					AppendHistoryNodes(gomock.Any(), gomock.Any()).
 					Return(nil).Times(1)
@@ -546,6 +552,29 @@ func TestAppendHistoryNodes(t *testing.T) {
 			expectError:   true,
 			expectedError: "serialization error",
 		},
+		{
+			name: "encryption error",
+			setupMock: func(mockStore *MockHistoryStore, mockEncoder *codec.MockBinaryEncoder, mockSerializer *MockPayloadSerializer) {
+				mockEncoder.EXPECT().
+					Decode(gomock.Any(), gomock.Any()).
+					Return(nil).Times(1)
+				mockSerializer.EXPECT().
+					SerializeBatchEvents(gomock.Any(), gomock.Any()).
+					Return(&DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: []byte("events")}, nil).Times(1)
+				mockSerializer.EXPECT().
+					EncryptBlob("test-domain", &DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: []byte("events")}).
+					Return(nil, errors.New("encryption error")).Times(1)
+			},
+			request: &AppendHistoryNodesRequest{
+				BranchToken:   []byte("branch-token"),
+				Events:        []*types.HistoryEvent{{ID: 1, Version: 1}},
+				TransactionID: 1234,
+				ShardID:       common.Ptr(10),
+				DomainName:    "test-domain",
+			},
+			expectError:   true,
+			expectedError: "encryption error",
+		},
 		{
 			name: "transaction size limit error",
 			setupMock: func(mockStore *MockHistoryStore, mockEncoder *codec.MockBinaryEncoder, mockSerializer *MockPayloadSerializer) {
@@ -558,6 +587,9 @@ func TestAppendHistoryNodes(t *testing.T) {
 				mockSerializer.EXPECT().
 					SerializeBatchEvents(gomock.Any(), gomock.Any()).
 					Return(&DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: make([]byte, 1024*10+1)}, nil).Times(1)
+				mockSerializer.EXPECT().
+					EncryptBlob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ string, blob *DataBlob) (*DataBlob, error) { return blob, nil }).Times(1)
 			},
 			request: &AppendHistoryNodesRequest{
 				BranchToken:   []byte("branch-token"),
@@ -580,6 +612,9 @@ func TestAppendHistoryNodes(t *testing.T) {
 				mockSerializer.EXPECT().
 					SerializeBatchEvents(gomock.Any(), gomock.Any()).
 					Return(&DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: []byte("events")}, nil).Times(1)
+				mockSerializer.EXPECT().
+					EncryptBlob(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ string, blob *DataBlob) (*DataBlob, error) { return blob, nil }).Times(1)
 				mockStore.EXPECT().
 					AppendHistoryNodes(gomock.Any(), gomock.Any()).
 					Return(errors.New("persistence error")).Times(1)
@@ -915,6 +950,133 @@ func TestReadRawHistoryBranch(t *testing.T) {
 	}
 }
 
+func TestReadRawHistoryBranch_ZstdBlobsAreDecompressed(t *testing.T) {
+	historyManager, mockStore, _, mockEncoder := setUpMocksForHistoryV2Manager(t)
+
+	events := []*types.HistoryEvent{{ID: 1, Version: 1, EventType: types.EventTypeWorkflowExecutionStarted.Ptr()}}
+	serializer := NewPayloadSerializer()
+	zstdBlob, err := serializer.SerializeBatchEvents(events, constants.EncodingTypeThriftRWZstd)
+	require.NoError(t, err)
+	thriftBlob, err := serializer.SerializeBatchEvents(events, constants.EncodingTypeThriftRW)
+	require.NoError(t, err)
+
+	mockEncoder.EXPECT().
+		Decode([]byte("branch-token"), &workflow.HistoryBranch{}).DoAndReturn(func(data []byte, value *workflow.HistoryBranch) error {
+		value.TreeID = common.Ptr("tree-id")
+		value.BranchID = common.Ptr("branch-id")
+		return nil
+	}).Times(1)
+	mockStore.EXPECT().
+		ReadHistoryBranch(gomock.Any(), gomock.Any()).
+		Return(&InternalReadHistoryBranchResponse{History: []*DataBlob{zstdBlob}}, nil).Times(1)
+
+	blobs, _, _, _, err := historyManager.readRawHistoryBranch(context.Background(), &ReadHistoryBranchRequest{
+		BranchToken: []byte("branch-token"),
+		ShardID:     common.IntPtr(1),
+		PageSize:    10,
+		MinEventID:  1,
+		MaxEventID:  100,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []*DataBlob{thriftBlob}, blobs)
+}
+
+func TestReadRawHistoryBranch_RetiredKeyBlobsAreRewrappedInBackground(t *testing.T) {
+	testCases := []struct {
+		name        string
+		maxQPS      int
+		wantRewrite bool
+	}{
+		{
+			name:        "rewrap enabled",
+			maxQPS:      100,
+			wantRewrite: true,
+		},
+		{
+			name:        "rewrap disabled",
+			maxQPS:      0,
+			wantRewrite: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockStore := NewMockHistoryStore(ctrl)
+			mockSerializer := NewMockPayloadSerializer(ctrl)
+			mockEncoder := codec.NewMockBinaryEncoder(ctrl)
+			historyManager := NewHistoryV2ManagerImpl(
+				mockStore,
+				log.NewNoop(),
+				mockSerializer,
+				mockEncoder,
+				dynamicproperties.GetIntPropertyFn(1024*10),
+				dynamicproperties.GetIntPropertyFn(tc.maxQPS),
+			).(*historyV2ManagerImpl)
+
+			sealed := &DataBlob{Encoding: constants.EncodingTypeEncrypted, Data: []byte("old-key")}
+			rewrapped := &DataBlob{Encoding: constants.EncodingTypeEncrypted, Data: []byte("active-key")}
+			plain := &DataBlob{Encoding: constants.EncodingTypeThriftRW, Data: []byte("events")}
+
+			mockEncoder.EXPECT().
+				Decode([]byte("branch-token"), &workflow.HistoryBranch{}).DoAndReturn(func(data []byte, value *workflow.HistoryBranch) error {
+				value.TreeID = common.Ptr("tree-id")
+				value.BranchID = common.Ptr("branch-id")
+				return nil
+			}).Times(1)
+			mockStore.EXPECT().
+				ReadHistoryBranch(gomock.Any(), gomock.Any()).
+				Return(&InternalReadHistoryBranchResponse{
+					History:        []*DataBlob{sealed},
+					NodeIDs:        []int64{1},
+					TransactionIDs: []int64{7},
+				}, nil).Times(1)
+			mockSerializer.EXPECT().DecryptBlob(sealed).Return(plain, nil).Times(1)
+
+			readDone := make(chan struct{})
+			rewritten := make(chan struct{})
+			if tc.wantRewrite {
+				mockSerializer.EXPECT().RewrapBlob(sealed).Return(rewrapped, true, nil).Times(1)
+				mockStore.EXPECT().
+					RewriteHistoryNode(gomock.Any(), &InternalRewriteHistoryNodeRequest{
+						TreeID:        "tree-id",
+						BranchID:      "branch-id",
+						NodeID:        1,
+						TransactionID: 7,
+						Events:        rewrapped,
+						ShardID:       1,
+					}).DoAndReturn(func(context.Context, *InternalRewriteHistoryNodeRequest) error {
+					// the read must not wait for the rewrite
+					<-readDone
+					close(rewritten)
+					return errors.New("rewrite failed")
+				}).Times(1)
+			}
+
+			blobs, _, _, _, err := historyManager.readRawHistoryBranch(context.Background(), &ReadHistoryBranchRequest{
+				BranchToken: []byte("branch-token"),
+				ShardID:     common.IntPtr(1),
+				PageSize:    10,
+				MinEventID:  1,
+				MaxEventID:  100,
+			})
+			close(readDone)
+			require.NoError(t, err)
+			assert.Equal(t, []*DataBlob{plain}, blobs)
+
+			if tc.wantRewrite {
+				select {
+				case <-rewritten:
+				case <-time.After(5 * time.Second):
+					t.Fatal("history node was not rewritten")
+				}
+			}
+			mockStore.EXPECT().Close().Times(1)
+			historyManager.Close()
+		})
+	}
+}
+
 func TestReadHistoryBranch(t *testing.T) {
 	testCases := []struct {
 		name               string
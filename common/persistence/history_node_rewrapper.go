@@ -0,0 +1,176 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/quotas"
+)
+
+const (
+	historyNodeRewrapQueueSize = 1000
+	historyNodeRewriteTimeout  = 5 * time.Second
+)
+
+type (
+	// historyNodeRewrapper rewrites history nodes sealed with a retired key with their data key re-wrapped
+	// under the active key, so retired keys can eventually be dropped from the key file.
+	// Nodes are only queued on reads, they are rewritten in background at a limited rate. Rewrites are
+	// writes to the history store, so they go to the primary database even if the node was read from a
+	// read replica. They are best effort: nodes that do not fit in the queue are rewritten on a later read.
+	historyNodeRewrapper struct {
+		persistence HistoryStore
+		serializer  PayloadSerializer
+		logger      log.Logger
+		maxQPS      dynamicproperties.IntPropertyFn
+		limiter     quotas.Limiter
+
+		sync.Mutex
+		queued   map[historyNodeKey]struct{}
+		requests chan *InternalRewriteHistoryNodeRequest
+
+		ctx       context.Context
+		cancel    context.CancelFunc
+		waitGroup sync.WaitGroup
+	}
+
+	historyNodeKey struct {
+		treeID        string
+		branchID      string
+		nodeID        int64
+		transactionID int64
+	}
+)
+
+func newHistoryNodeRewrapper(
+	persistence HistoryStore,
+	serializer PayloadSerializer,
+	logger log.Logger,
+	maxQPS dynamicproperties.IntPropertyFn,
+) *historyNodeRewrapper {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &historyNodeRewrapper{
+		persistence: persistence,
+		serializer:  serializer,
+		logger:      logger,
+		maxQPS:      maxQPS,
+		limiter:     quotas.NewDynamicRateLimiter(maxQPS.AsFloat64()),
+		queued:      make(map[historyNodeKey]struct{}),
+		requests:    make(chan *InternalRewriteHistoryNodeRequest, historyNodeRewrapQueueSize),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+func (r *historyNodeRewrapper) start() {
+	if r == nil {
+		return
+	}
+	r.waitGroup.Add(1)
+	go r.rewriteLoop()
+}
+
+func (r *historyNodeRewrapper) stop() {
+	if r == nil {
+		return
+	}
+	r.cancel()
+	r.waitGroup.Wait()
+}
+
+// enqueue queues the node for a rewrite if it was sealed with a retired key. It never blocks nor writes
+func (r *historyNodeRewrapper) enqueue(
+	req *InternalReadHistoryBranchRequest,
+	nodeID int64,
+	transactionID int64,
+	blob *DataBlob,
+) {
+	if r == nil || r.maxQPS() <= 0 {
+		return
+	}
+	rewrapped, ok, err := r.serializer.RewrapBlob(blob)
+	if err != nil {
+		r.logger.Warn("failed to rewrap history node", tag.Error(err))
+		return
+	}
+	if !ok {
+		return
+	}
+
+	key := historyNodeKey{treeID: req.TreeID, branchID: req.BranchID, nodeID: nodeID, transactionID: transactionID}
+	r.Lock()
+	defer r.Unlock()
+	if _, ok := r.queued[key]; ok {
+		return
+	}
+	select {
+	case r.requests <- &InternalRewriteHistoryNodeRequest{
+		TreeID:        req.TreeID,
+		BranchID:      req.BranchID,
+		NodeID:        nodeID,
+		TransactionID: transactionID,
+		Events:        rewrapped,
+		ShardID:       req.ShardID,
+	}:
+		r.queued[key] = struct{}{}
+	default:
+	}
+}
+
+func (r *historyNodeRewrapper) rewriteLoop() {
+	defer r.waitGroup.Done()
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case request := <-r.requests:
+			if err := r.limiter.Wait(r.ctx); err != nil {
+				return
+			}
+			r.rewrite(request)
+		}
+	}
+}
+
+func (r *historyNodeRewrapper) rewrite(request *InternalRewriteHistoryNodeRequest) {
+	defer func() {
+		r.Lock()
+		defer r.Unlock()
+		delete(r.queued, historyNodeKey{
+			treeID:        request.TreeID,
+			branchID:      request.BranchID,
+			nodeID:        request.NodeID,
+			transactionID: request.TransactionID,
+		})
+	}()
+
+	ctx, cancel := context.WithTimeout(r.ctx, historyNodeRewriteTimeout)
+	defer cancel()
+	if err := r.persistence.RewriteHistoryNode(ctx, request); err != nil {
+		r.logger.Warn("failed to rewrite rewrapped history node", tag.Error(err))
+	}
+}
@@ -107,6 +107,32 @@ func (h *nosqlHistoryStore) AppendHistoryNodes(
 	return nil
 }
 
+// RewriteHistoryNode replaces the data of an existing node
+func (h *nosqlHistoryStore) RewriteHistoryNode(
+	ctx context.Context,
+	request *persistence.InternalRewriteHistoryNodeRequest,
+) error {
+	nodeRow := &nosqlplugin.HistoryNodeRow{
+		TreeID:       request.TreeID,
+		BranchID:     request.BranchID,
+		NodeID:       request.NodeID,
+		TxnID:        &request.TransactionID,
+		Data:         request.Events.Data,
+		DataEncoding: string(request.Events.Encoding),
+		ShardID:      request.ShardID,
+	}
+
+	storeShard, err := h.GetStoreShardByHistoryShard(request.ShardID)
+	if err != nil {
+		return err
+	}
+
+	if err := storeShard.db.UpdateHistoryNode(ctx, nodeRow); err != nil {
+		return convertCommonErrors(storeShard.db, "RewriteHistoryNode", err)
+	}
+	return nil
+}
+
 // ReadHistoryBranch returns history node data for a branch
 // NOTE: For branch that has ancestors, we need to query Cassandra multiple times, because it doesn't support OR/UNION operator
 func (h *nosqlHistoryStore) ReadHistoryBranch(
@@ -134,6 +160,8 @@ func (h *nosqlHistoryStore) ReadHistoryBranch(
 	}
 
 	history := make([]*persistence.DataBlob, 0, int(request.PageSize))
+	nodeIDs := make([]int64, 0, int(request.PageSize))
+	txnIDs := make([]int64, 0, int(request.PageSize))
 
 	eventBlob := &persistence.DataBlob{}
 	nodeID := int64(0)
@@ -174,12 +202,16 @@ func (h *nosqlHistoryStore) ReadHistoryBranch(
 			lastTxnID = txnID
 			lastNodeID = nodeID
 			history = append(history, eventBlob)
+			nodeIDs = append(nodeIDs, nodeID)
+			txnIDs = append(txnIDs, txnID)
 			eventBlob = &persistence.DataBlob{}
 		}
 	}
 
 	return &persistence.InternalReadHistoryBranchResponse{
 		History:           history,
+		NodeIDs:           nodeIDs,
+		TransactionIDs:    txnIDs,
 		NextPageToken:     pagingToken,
 		LastNodeID:        lastNodeID,
 		LastTransactionID: lastTxnID,
@@ -180,6 +180,43 @@ func TestAppendHistoryNodes_NewBranch(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAppendHistoryNodes_TransactionSizeLimit(t *testing.T) {
+	store, dbMock, _ := setUpMocks(t)
+
+	// Plugins reject nodes which exceed the limits of the database as non-retryable
+	dbMock.EXPECT().InsertIntoHistoryTreeAndNode(gomock.Any(), nil, validHistoryNodeRow()).
+		Return(&persistence.TransactionSizeLimitError{Msg: "item too large"}).Times(1)
+
+	err := store.AppendHistoryNodes(ctx.Background(), validInternalAppendHistoryNodesRequest())
+
+	var sizeLimitErr *persistence.TransactionSizeLimitError
+	assert.ErrorAs(t, err, &sizeLimitErr)
+}
+
+func TestRewriteHistoryNode(t *testing.T) {
+	store, dbMock, _ := setUpMocks(t)
+
+	dbMock.EXPECT().UpdateHistoryNode(gomock.Any(), &nosqlplugin.HistoryNodeRow{
+		TreeID:       "TestTreeID",
+		BranchID:     "TestBranchID",
+		NodeID:       5,
+		TxnID:        common.Ptr[int64](123),
+		Data:         []byte("TestEvents"),
+		DataEncoding: string(constants.EncodingTypeEncrypted),
+		ShardID:      testShardID,
+	}).Return(nil).Times(1)
+
+	err := store.RewriteHistoryNode(ctx.Background(), &persistence.InternalRewriteHistoryNodeRequest{
+		TreeID:        "TestTreeID",
+		BranchID:      "TestBranchID",
+		NodeID:        5,
+		TransactionID: 123,
+		Events:        persistence.NewDataBlob([]byte("TestEvents"), constants.EncodingTypeEncrypted),
+		ShardID:       testShardID,
+	})
+	assert.NoError(t, err)
+}
+
 const (
 	testMinNodeID         = 111
 	testMaxNodeID         = 222
@@ -267,6 +304,8 @@ func TestReadHistoryBranch(t *testing.T) {
 	assert.Equal(t, rows[1].Data, resp.History[1].Data)
 	assert.Equal(t, constants.EncodingTypeThriftRW, resp.History[0].Encoding)
 	assert.Equal(t, constants.EncodingTypeThriftRW, resp.History[1].Encoding)
+	assert.Equal(t, []int64{testRowNodeID1, testRowNodeID2}, resp.NodeIDs)
+	assert.Equal(t, []int64{testRowTxnID1, testRowTxnID2}, resp.TransactionIDs)
 
 	assert.Nil(t, resp.NextPageToken)
 
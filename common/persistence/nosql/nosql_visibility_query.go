@@ -0,0 +1,619 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package nosql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xwb1989/sqlparser"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	// visibilityQuery is a query of the visibility query language translated for the denormalized visibility records.
+	// The records can only be read bucket by bucket in time order, so the query is split into the buckets and the time
+	// ranges to read, and a filter that is applied to the records that are read
+	visibilityQuery struct {
+		includeOpen   bool
+		includeClosed bool
+		// open records are sorted by start time and closed records by close time, both ranges are inclusive
+		startTimeRange visibilityTimeRange
+		closeTimeRange visibilityTimeRange
+		// filter is nil when the query has no condition
+		filter visibilityRecordFilter
+	}
+
+	visibilityTimeRange struct {
+		min time.Time
+		max time.Time
+	}
+
+	visibilityRecordFilter func(record *nosqlplugin.VisibilityRow) bool
+
+	// visibilityQueryParser supports the system search attributes and the keyword search attributes,
+	// the only custom search attributes that are written to the denormalized visibility records
+	visibilityQueryParser struct {
+		validSearchAttributes map[string]interface{}
+		logger                log.Logger
+	}
+
+	visibilityValueKind int
+)
+
+const (
+	stringValue visibilityValueKind = iota
+	intValue
+	boolValue
+	timeValue
+)
+
+const missingValue = "missing"
+
+var (
+	visibilitySystemSearchAttributes = map[string]visibilityValueKind{
+		definition.WorkflowID:             stringValue,
+		definition.RunID:                  stringValue,
+		definition.WorkflowType:           stringValue,
+		definition.TaskList:               stringValue,
+		definition.CronSchedule:           stringValue,
+		definition.StartTime:              timeValue,
+		definition.ExecutionTime:          timeValue,
+		definition.CloseTime:              timeValue,
+		definition.UpdateTime:             timeValue,
+		definition.ScheduledExecutionTime: timeValue,
+		definition.CloseStatus:            intValue,
+		definition.ExecutionStatus:        intValue,
+		definition.HistoryLength:          intValue,
+		definition.NumClusters:            intValue,
+		definition.IsCron:                 boolValue,
+	}
+
+	// search attributes that only closed records have
+	visibilityClosedSearchAttributes = map[string]bool{
+		definition.CloseTime:     true,
+		definition.CloseStatus:   true,
+		definition.HistoryLength: true,
+	}
+
+	minVisibilityTime = time.Unix(0, 0).UTC()
+	maxVisibilityTime = time.Unix(0, math.MaxInt64).UTC()
+)
+
+func newVisibilityQueryParser(validSearchAttributes map[string]interface{}, logger log.Logger) *visibilityQueryParser {
+	return &visibilityQueryParser{
+		validSearchAttributes: validSearchAttributes,
+		logger:                logger,
+	}
+}
+
+func (p *visibilityQueryParser) parse(query string) (*visibilityQuery, error) {
+	result := &visibilityQuery{
+		includeOpen:    true,
+		includeClosed:  true,
+		startTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+		closeTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+	}
+	query = strings.TrimSpace(query)
+	if len(query) == 0 {
+		return result, nil
+	}
+
+	// Build a placeholder query that allows us to easily parse the contents of the where clause.
+	// IMPORTANT: This query is never executed, it is just used to parse the query
+	var placeholderQuery string
+	if common.IsJustOrderByClause(query) {
+		placeholderQuery = fmt.Sprintf("SELECT * FROM dummy %s", query)
+	} else {
+		placeholderQuery = fmt.Sprintf("SELECT * FROM dummy WHERE %s", query)
+	}
+	stmt, err := sqlparser.Parse(placeholderQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %v", err)
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, errors.New("invalid select query")
+	}
+	if len(sel.OrderBy) > 0 {
+		return nil, errors.New("ORDER BY is not supported, open workflows are sorted by start time and closed workflows by close time")
+	}
+	if sel.Where == nil {
+		return result, nil
+	}
+
+	result.filter, err = p.parseWhereExpr(sel.Where.Expr)
+	if err != nil {
+		return nil, err
+	}
+	// the conditions that all records must meet narrow down the records to read
+	for _, expr := range conjuncts(sel.Where.Expr) {
+		if err := p.narrow(result, expr); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (p *visibilityQueryParser) parseWhereExpr(expr sqlparser.Expr) (visibilityRecordFilter, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.AndExpr:
+		left, right, err := p.parseAndOrExpr(expr.Left, expr.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(record *nosqlplugin.VisibilityRow) bool {
+			return left(record) && right(record)
+		}, nil
+	case *sqlparser.OrExpr:
+		left, right, err := p.parseAndOrExpr(expr.Left, expr.Right)
+		if err != nil {
+			return nil, err
+		}
+		return func(record *nosqlplugin.VisibilityRow) bool {
+			return left(record) || right(record)
+		}, nil
+	case *sqlparser.ComparisonExpr:
+		return p.parseComparisonExpr(expr)
+	case *sqlparser.RangeCond:
+		return p.parseRangeExpr(expr)
+	case *sqlparser.ParenExpr:
+		return p.parseWhereExpr(expr.Expr)
+	default:
+		return nil, errors.New("invalid where clause")
+	}
+}
+
+func (p *visibilityQueryParser) parseAndOrExpr(left, right sqlparser.Expr) (visibilityRecordFilter, visibilityRecordFilter, error) {
+	leftFilter, err := p.parseWhereExpr(left)
+	if err != nil {
+		return nil, nil, err
+	}
+	rightFilter, err := p.parseWhereExpr(right)
+	if err != nil {
+		return nil, nil, err
+	}
+	return leftFilter, rightFilter, nil
+}
+
+func (p *visibilityQueryParser) parseComparisonExpr(expr *sqlparser.ComparisonExpr) (visibilityRecordFilter, error) {
+	colName, ok := expr.Left.(*sqlparser.ColName)
+	if !ok {
+		return nil, errors.New("invalid comparison expression")
+	}
+	key, kind, err := p.searchAttribute(colName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the value of a comparison is parsed as a column name when it's not quoted, e.g. CloseTime = missing
+	if val, ok := expr.Right.(*sqlparser.ColName); ok {
+		if val.Name.String() != missingValue {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q", val.Name.String(), key)
+		}
+		switch expr.Operator {
+		case sqlparser.EqualStr:
+			return func(record *nosqlplugin.VisibilityRow) bool {
+				return len(searchAttributeValues(record, key)) == 0
+			}, nil
+		case sqlparser.NotEqualStr:
+			return func(record *nosqlplugin.VisibilityRow) bool {
+				return len(searchAttributeValues(record, key)) != 0
+			}, nil
+		default:
+			return nil, fmt.Errorf("invalid operator %q for missing value of search attribute %q", expr.Operator, key)
+		}
+	}
+
+	switch expr.Operator {
+	case sqlparser.InStr, sqlparser.NotInStr:
+		tuple, ok := expr.Right.(sqlparser.ValTuple)
+		if !ok || len(tuple) == 0 {
+			return nil, fmt.Errorf("invalid value list of search attribute %q", key)
+		}
+		vals := make([]interface{}, 0, len(tuple))
+		for _, valExpr := range tuple {
+			val, err := p.parseValue(key, kind, valExpr)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, val)
+		}
+		in := func(record *nosqlplugin.VisibilityRow) bool {
+			return anyValue(searchAttributeValues(record, key), func(recordVal interface{}) bool {
+				for _, val := range vals {
+					if c, ok := compareValues(recordVal, val); ok && c == 0 {
+						return true
+					}
+				}
+				return false
+			})
+		}
+		if expr.Operator == sqlparser.NotInStr {
+			return negate(in), nil
+		}
+		return in, nil
+	case sqlparser.EqualStr, sqlparser.NotEqualStr, sqlparser.LessThanStr, sqlparser.LessEqualStr,
+		sqlparser.GreaterThanStr, sqlparser.GreaterEqualStr:
+	default:
+		return nil, fmt.Errorf("operator %q is not supported", expr.Operator)
+	}
+
+	val, err := p.parseValue(key, kind, expr.Right)
+	if err != nil {
+		return nil, err
+	}
+	operator := expr.Operator
+	if operator == sqlparser.NotEqualStr {
+		// like Elasticsearch, records without the search attribute don't equal any value
+		operator = sqlparser.EqualStr
+	}
+	filter := func(record *nosqlplugin.VisibilityRow) bool {
+		return anyValue(searchAttributeValues(record, key), func(recordVal interface{}) bool {
+			c, ok := compareValues(recordVal, val)
+			if !ok {
+				return false
+			}
+			switch operator {
+			case sqlparser.EqualStr:
+				return c == 0
+			case sqlparser.LessThanStr:
+				return c < 0
+			case sqlparser.LessEqualStr:
+				return c <= 0
+			case sqlparser.GreaterThanStr:
+				return c > 0
+			default:
+				return c >= 0
+			}
+		})
+	}
+	if expr.Operator == sqlparser.NotEqualStr {
+		return negate(filter), nil
+	}
+	return filter, nil
+}
+
+// for "between...and..." only
+func (p *visibilityQueryParser) parseRangeExpr(expr *sqlparser.RangeCond) (visibilityRecordFilter, error) {
+	colName, ok := expr.Left.(*sqlparser.ColName)
+	if !ok {
+		return nil, errors.New("invalid range expression")
+	}
+	key, kind, err := p.searchAttribute(colName)
+	if err != nil {
+		return nil, err
+	}
+	from, err := p.parseValue(key, kind, expr.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := p.parseValue(key, kind, expr.To)
+	if err != nil {
+		return nil, err
+	}
+	between := func(record *nosqlplugin.VisibilityRow) bool {
+		return anyValue(searchAttributeValues(record, key), func(recordVal interface{}) bool {
+			c1, ok1 := compareValues(recordVal, from)
+			c2, ok2 := compareValues(recordVal, to)
+			return ok1 && ok2 && c1 >= 0 && c2 <= 0
+		})
+	}
+	if expr.Operator == sqlparser.NotBetweenStr {
+		return negate(between), nil
+	}
+	return between, nil
+}
+
+// narrow restricts the records to read with a condition that all records must meet
+func (p *visibilityQueryParser) narrow(q *visibilityQuery, expr sqlparser.Expr) error {
+	var key string
+	var operator string
+	var values []sqlparser.Expr
+	switch expr := expr.(type) {
+	case *sqlparser.ComparisonExpr:
+		colName, ok := expr.Left.(*sqlparser.ColName)
+		if !ok {
+			return nil
+		}
+		key = strings.TrimPrefix(colName.Name.String(), definition.Attr+".")
+		if val, ok := expr.Right.(*sqlparser.ColName); ok && val.Name.String() == missingValue {
+			if visibilityClosedSearchAttributes[key] && expr.Operator == sqlparser.EqualStr {
+				q.includeClosed = false
+			}
+			if visibilityClosedSearchAttributes[key] && expr.Operator == sqlparser.NotEqualStr {
+				q.includeOpen = false
+			}
+			return nil
+		}
+		operator = expr.Operator
+		values = []sqlparser.Expr{expr.Right}
+	case *sqlparser.RangeCond:
+		colName, ok := expr.Left.(*sqlparser.ColName)
+		if !ok || expr.Operator != sqlparser.BetweenStr {
+			return nil
+		}
+		key = strings.TrimPrefix(colName.Name.String(), definition.Attr+".")
+		operator = sqlparser.BetweenStr
+		values = []sqlparser.Expr{expr.From, expr.To}
+	default:
+		return nil
+	}
+
+	switch operator {
+	case sqlparser.EqualStr, sqlparser.LessThanStr, sqlparser.LessEqualStr, sqlparser.GreaterThanStr,
+		sqlparser.GreaterEqualStr, sqlparser.BetweenStr, sqlparser.InStr:
+		// only closed records have a value that can meet these conditions
+		if visibilityClosedSearchAttributes[key] {
+			q.includeOpen = false
+		}
+	default:
+		return nil
+	}
+
+	var timeRange *visibilityTimeRange
+	switch key {
+	case definition.StartTime:
+		timeRange = &q.startTimeRange
+	case definition.CloseTime:
+		timeRange = &q.closeTimeRange
+	default:
+		return nil
+	}
+	times := make([]time.Time, 0, len(values))
+	for _, valExpr := range values {
+		val, err := p.parseValue(key, timeValue, valExpr)
+		if err != nil {
+			return err
+		}
+		times = append(times, time.Unix(0, val.(int64)).UTC())
+	}
+	switch operator {
+	case sqlparser.EqualStr:
+		timeRange.narrow(times[0], times[0])
+	case sqlparser.LessThanStr, sqlparser.LessEqualStr:
+		timeRange.narrow(minVisibilityTime, times[0])
+	case sqlparser.GreaterThanStr, sqlparser.GreaterEqualStr:
+		timeRange.narrow(times[0], maxVisibilityTime)
+	case sqlparser.BetweenStr:
+		timeRange.narrow(times[0], times[1])
+	}
+	return nil
+}
+
+// searchAttribute returns the key and the kind of the values of the search attribute referred by colName.
+// Custom search attributes may be prefixed by the query validator of the frontend
+func (p *visibilityQueryParser) searchAttribute(colName *sqlparser.ColName) (string, visibilityValueKind, error) {
+	key := strings.TrimPrefix(colName.Name.String(), definition.Attr+".")
+	if kind, ok := visibilitySystemSearchAttributes[key]; ok {
+		return key, kind, nil
+	}
+	fieldType, ok := p.validSearchAttributes[key]
+	if !ok {
+		return "", 0, fmt.Errorf("invalid search attribute %q", key)
+	}
+	if valueType := common.ConvertIndexedValueTypeToInternalType(fieldType, p.logger); valueType != types.IndexedValueTypeKeyword {
+		return "", 0, fmt.Errorf("search attribute %q of type %v is not supported, only keyword search attributes can be queried", key, valueType)
+	}
+	return key, stringValue, nil
+}
+
+// parseValue converts a value of the query into the kind of the values of the search attribute, see searchAttributeValues
+func (p *visibilityQueryParser) parseValue(key string, kind visibilityValueKind, expr sqlparser.Expr) (interface{}, error) {
+	var val string
+	switch expr := expr.(type) {
+	case *sqlparser.SQLVal:
+		val = string(expr.Val)
+	case sqlparser.BoolVal:
+		val = strconv.FormatBool(bool(expr))
+	default:
+		return nil, fmt.Errorf("invalid value of search attribute %q", key)
+	}
+
+	switch key {
+	case definition.CloseStatus:
+		if status, err := strconv.ParseInt(val, 10, 32); err == nil {
+			return status, nil
+		}
+		var status types.WorkflowExecutionCloseStatus
+		if err := status.UnmarshalText([]byte(val)); err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return int64(status), nil
+	case definition.ExecutionStatus:
+		if status, err := strconv.ParseInt(val, 10, 32); err == nil {
+			return status, nil
+		}
+		var status types.WorkflowExecutionStatus
+		if err := status.UnmarshalText([]byte(val)); err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return int64(status), nil
+	}
+
+	switch kind {
+	case intValue:
+		res, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return res, nil
+	case boolValue:
+		res, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return res, nil
+	case timeValue:
+		res, err := parseVisibilityTime(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return res, nil
+	default:
+		return val, nil
+	}
+}
+
+// searchAttributeValues returns the values of a search attribute of a record: strings, int64 (times in unix nanoseconds) or bools.
+// It's empty when the record doesn't have the search attribute, and a keyword search attribute may have several values
+func searchAttributeValues(record *nosqlplugin.VisibilityRow, key string) []interface{} {
+	closed := record.Status != nil
+	switch key {
+	case definition.WorkflowID:
+		return []interface{}{record.WorkflowID}
+	case definition.RunID:
+		return []interface{}{record.RunID}
+	case definition.WorkflowType:
+		return []interface{}{record.TypeName}
+	case definition.TaskList:
+		return []interface{}{record.TaskList}
+	case definition.CronSchedule:
+		return []interface{}{record.CronSchedule}
+	case definition.StartTime:
+		return []interface{}{record.StartTime.UnixNano()}
+	case definition.ExecutionTime:
+		return []interface{}{record.ExecutionTime.UnixNano()}
+	case definition.UpdateTime:
+		return []interface{}{record.UpdateTime.UnixNano()}
+	case definition.ScheduledExecutionTime:
+		return []interface{}{record.ScheduledExecutionTime.UnixNano()}
+	case definition.ExecutionStatus:
+		return []interface{}{int64(record.ExecutionStatus)}
+	case definition.NumClusters:
+		return []interface{}{int64(record.NumClusters)}
+	case definition.IsCron:
+		return []interface{}{record.IsCron}
+	case definition.CloseTime:
+		if closed {
+			return []interface{}{record.CloseTime.UnixNano()}
+		}
+		return nil
+	case definition.CloseStatus:
+		if closed {
+			return []interface{}{int64(*record.Status)}
+		}
+		return nil
+	case definition.HistoryLength:
+		if closed {
+			return []interface{}{record.HistoryLength}
+		}
+		return nil
+	}
+
+	switch val := record.SearchAttributes[key].(type) {
+	case string:
+		return []interface{}{val}
+	case []interface{}:
+		return val
+	default:
+		return nil
+	}
+}
+
+// compareValues returns false when the values are not of the same kind
+func compareValues(a, b interface{}) (int, bool) {
+	switch a := a.(type) {
+	case string:
+		if b, ok := b.(string); ok {
+			return strings.Compare(a, b), true
+		}
+	case int64:
+		if b, ok := b.(int64); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return 1, true
+			default:
+				return 0, true
+			}
+		}
+	case bool:
+		if b, ok := b.(bool); ok {
+			switch {
+			case a == b:
+				return 0, true
+			case b:
+				return -1, true
+			default:
+				return 1, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func anyValue(values []interface{}, match func(interface{}) bool) bool {
+	for _, val := range values {
+		if match(val) {
+			return true
+		}
+	}
+	return false
+}
+
+func negate(filter visibilityRecordFilter) visibilityRecordFilter {
+	return func(record *nosqlplugin.VisibilityRow) bool {
+		return !filter(record)
+	}
+}
+
+// conjuncts returns the conditions joined by the top level ANDs of a where clause
+func conjuncts(expr sqlparser.Expr) []sqlparser.Expr {
+	switch expr := expr.(type) {
+	case *sqlparser.AndExpr:
+		return append(conjuncts(expr.Left), conjuncts(expr.Right)...)
+	case *sqlparser.ParenExpr:
+		return conjuncts(expr.Expr)
+	default:
+		return []sqlparser.Expr{expr}
+	}
+}
+
+func (r *visibilityTimeRange) narrow(min, max time.Time) {
+	if min.After(r.min) {
+		r.min = min
+	}
+	if max.Before(r.max) {
+		r.max = max
+	}
+}
+
+// parseVisibilityTime parses a time in unix nanoseconds or in RFC3339 format into unix nanoseconds
+func parseVisibilityTime(val string) (int64, error) {
+	if nanos, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return nanos, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, val)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixNano(), nil
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package nosql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestVisibilityQueryParser_Scope(t *testing.T) {
+	startTime := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	closeTime := time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		query                  string
+		expectedIncludeOpen    bool
+		expectedIncludeClosed  bool
+		expectedStartTimeRange visibilityTimeRange
+		expectedCloseTimeRange visibilityTimeRange
+	}{
+		"empty query": {
+			query:                  "",
+			expectedIncludeOpen:    true,
+			expectedIncludeClosed:  true,
+			expectedStartTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+			expectedCloseTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+		},
+		"open workflows": {
+			query:                  "WorkflowType = 'test' AND CloseTime = missing",
+			expectedIncludeOpen:    true,
+			expectedIncludeClosed:  false,
+			expectedStartTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+			expectedCloseTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+		},
+		"closed workflows": {
+			query:                  "CloseStatus != missing",
+			expectedIncludeOpen:    false,
+			expectedIncludeClosed:  true,
+			expectedStartTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+			expectedCloseTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+		},
+		"time ranges": {
+			query:                  "StartTime >= '2024-04-01T00:00:00Z' AND (CloseTime BETWEEN 0 AND '2024-04-02T00:00:00Z')",
+			expectedIncludeOpen:    false,
+			expectedIncludeClosed:  true,
+			expectedStartTimeRange: visibilityTimeRange{min: startTime, max: maxVisibilityTime},
+			expectedCloseTimeRange: visibilityTimeRange{min: minVisibilityTime, max: closeTime},
+		},
+		"conditions under OR don't narrow the records to read": {
+			query:                  "CloseStatus = 'COMPLETED' OR StartTime > 0",
+			expectedIncludeOpen:    true,
+			expectedIncludeClosed:  true,
+			expectedStartTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+			expectedCloseTimeRange: visibilityTimeRange{min: minVisibilityTime, max: maxVisibilityTime},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			query, err := newVisibilityQueryParser(definition.GetDefaultIndexedKeys(), log.NewNoop()).parse(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedIncludeOpen, query.includeOpen)
+			assert.Equal(t, test.expectedIncludeClosed, query.includeClosed)
+			assert.True(t, test.expectedStartTimeRange.min.Equal(query.startTimeRange.min))
+			assert.True(t, test.expectedStartTimeRange.max.Equal(query.startTimeRange.max))
+			assert.True(t, test.expectedCloseTimeRange.min.Equal(query.closeTimeRange.min))
+			assert.True(t, test.expectedCloseTimeRange.max.Equal(query.closeTimeRange.max))
+		})
+	}
+}
+
+func TestVisibilityQueryParser_Filter(t *testing.T) {
+	now := time.Unix(1712009321, 0)
+	completed := types.WorkflowExecutionCloseStatusCompleted
+	openRecord := &nosqlplugin.VisibilityRow{
+		WorkflowID: "wid",
+		RunID:      "rid",
+		TypeName:   "test-type",
+		StartTime:  now,
+		IsCron:     true,
+		SearchAttributes: map[string]interface{}{
+			definition.CustomKeywordField: []interface{}{"a", "b"},
+		},
+	}
+	closedRecord := &nosqlplugin.VisibilityRow{
+		WorkflowID:    "wid",
+		RunID:         "rid",
+		TypeName:      "test-type",
+		StartTime:     now,
+		CloseTime:     now.Add(time.Minute),
+		Status:        &completed,
+		HistoryLength: 10,
+	}
+	tests := map[string]struct {
+		query          string
+		expectedOpen   bool
+		expectedClosed bool
+	}{
+		"equal": {
+			query:          "WorkflowID = 'wid'",
+			expectedOpen:   true,
+			expectedClosed: true,
+		},
+		"prefixed custom search attribute": {
+			query:          "Attr.CustomKeywordField = 'b'",
+			expectedOpen:   true,
+			expectedClosed: false,
+		},
+		"not equal matches the records without the search attribute": {
+			query:          "CustomKeywordField != 'a'",
+			expectedOpen:   false,
+			expectedClosed: true,
+		},
+		"in": {
+			query:          "WorkflowType IN ('other-type', 'test-type')",
+			expectedOpen:   true,
+			expectedClosed: true,
+		},
+		"not in": {
+			query:          "CustomKeywordField NOT IN ('c', 'd')",
+			expectedOpen:   true,
+			expectedClosed: true,
+		},
+		"close status name": {
+			query:          "CloseStatus = 'COMPLETED'",
+			expectedOpen:   false,
+			expectedClosed: true,
+		},
+		"missing": {
+			query:          "CloseTime = missing",
+			expectedOpen:   true,
+			expectedClosed: false,
+		},
+		"range": {
+			query:          "HistoryLength BETWEEN 5 AND 20 AND IsCron = false",
+			expectedOpen:   false,
+			expectedClosed: true,
+		},
+		"not between": {
+			query:          "StartTime NOT BETWEEN 0 AND 1000",
+			expectedOpen:   true,
+			expectedClosed: true,
+		},
+		"or": {
+			query:          "(CloseTime < '2024-01-01T00:00:00Z' OR IsCron = true) AND RunID = 'rid'",
+			expectedOpen:   true,
+			expectedClosed: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			query, err := newVisibilityQueryParser(definition.GetDefaultIndexedKeys(), log.NewNoop()).parse(test.query)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOpen, query.filter(openRecord))
+			assert.Equal(t, test.expectedClosed, query.filter(closedRecord))
+		})
+	}
+}
+
+func TestVisibilityQueryParser_Errors(t *testing.T) {
+	tests := map[string]string{
+		"WorkflowID LIKE 'wid%'":                 "operator \"like\" is not supported",
+		"CustomIntField = 1":                     "only keyword search attributes can be queried",
+		"UnknownField = 'value'":                 "invalid search attribute \"UnknownField\"",
+		"WorkflowID = 'wid' ORDER BY StartTime":  "ORDER BY is not supported",
+		"StartTime > 'yesterday'":                "invalid value \"yesterday\" of search attribute \"StartTime\"",
+		"CloseTime > missing":                    "invalid operator",
+		"WorkflowID = ":                          "invalid query",
+		"CloseStatus = 'NOT_A_STATUS'":           "invalid value \"NOT_A_STATUS\" of search attribute \"CloseStatus\"",
+		"WorkflowID = 'wid' AND RunID = unknown": "invalid value \"unknown\" of search attribute \"RunID\"",
+	}
+
+	for query, expectedError := range tests {
+		t.Run(query, func(t *testing.T) {
+			_, err := newVisibilityQueryParser(definition.GetDefaultIndexedKeys(), log.NewNoop()).parse(query)
+			assert.ErrorContains(t, err, expectedError)
+		})
+	}
+}
@@ -22,10 +22,16 @@ package nosql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
@@ -37,12 +43,41 @@ import (
 const (
 	defaultCloseTTLSeconds = 86400
 	openExecutionTTLBuffer = int64(86400) // setting it to a day to account for shard going down
+
+	// visibilityQueryScanLimit bounds the records read for a page of a visibility query. The page is shorter than
+	// requested when the limit is reached, and its token continues from the last record read
+	visibilityQueryScanLimit = 10000
+	// visibilityQueryMinReadSize is the least number of records read from a bucket at once
+	visibilityQueryMinReadSize = 100
+	// visibilityCountScanLimit bounds the records read to count the workflows that match a visibility query
+	visibilityCountScanLimit = 100000
+	visibilityCountPageSize  = 1000
 )
 
-type nosqlVisibilityStore struct {
-	sortByCloseTime bool
-	nosqlStore
-}
+type (
+	nosqlVisibilityStore struct {
+		sortByCloseTime bool
+		nosqlStore
+		validSearchAttributes dynamicproperties.MapPropertyFn
+		numOpenBuckets        int
+	}
+
+	// nosqlVisibilityPageToken is the position of a visibility query, which lists the open records before the closed ones
+	nosqlVisibilityPageToken struct {
+		Closed    bool
+		PageAfter *nosqlplugin.VisibilitySortKey `json:",omitempty"`
+	}
+
+	// visibilityQueryScanner reads the denormalized visibility records of a domain that match a query
+	visibilityQueryScanner struct {
+		db       nosqlplugin.DB
+		domainID string
+		query    *visibilityQuery
+		// budget is the number of records that can still be read
+		budget         int
+		numOpenBuckets int
+	}
+)
 
 // newNoSQLVisibilityStore is used to create an instance of VisibilityStore implementation
 func newNoSQLVisibilityStore(
@@ -56,9 +91,19 @@ func newNoSQLVisibilityStore(
 	if err != nil {
 		return nil, err
 	}
+	validSearchAttributes := dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys())
+	if dc != nil && dc.ValidSearchAttributes != nil {
+		validSearchAttributes = dc.ValidSearchAttributes
+	}
+	var defaultShardCfg *config.NoSQL
+	if connection, ok := cfg.Connections[cfg.DefaultShard]; ok {
+		defaultShardCfg = connection.NoSQLPlugin
+	}
 	return &nosqlVisibilityStore{
-		sortByCloseTime: listClosedOrderingByCloseTime,
-		nosqlStore:      shardedStore.GetDefaultShard(),
+		sortByCloseTime:       listClosedOrderingByCloseTime,
+		nosqlStore:            shardedStore.GetDefaultShard(),
+		validSearchAttributes: validSearchAttributes,
+		numOpenBuckets:        nosqlplugin.GetNumOpenVisibilityBuckets(defaultShardCfg),
 	}, nil
 }
 
@@ -86,6 +131,7 @@ func (v *nosqlVisibilityStore) RecordWorkflowExecutionStarted(
 			CronSchedule:           request.CronSchedule,
 			ScheduledExecutionTime: request.ScheduledExecutionTime,
 		},
+		KeywordSearchAttributes: v.keywordSearchAttributes(request.SearchAttributes),
 	})
 	if err != nil {
 		return convertCommonErrors(v.db, "RecordWorkflowExecutionStarted", err)
@@ -126,6 +172,7 @@ func (v *nosqlVisibilityStore) RecordWorkflowExecutionClosed(
 			ExecutionStatus:        request.ExecutionStatus,
 			ScheduledExecutionTime: request.ScheduledExecutionTime,
 		},
+		KeywordSearchAttributes: v.keywordSearchAttributes(request.SearchAttributes),
 	})
 
 	if err != nil {
@@ -149,7 +196,32 @@ func (v *nosqlVisibilityStore) UpsertWorkflowExecution(
 	if persistence.IsNopUpsertWorkflowRequest(request) {
 		return nil
 	}
-	return persistence.ErrVisibilityOperationNotSupported
+	ttl := int64(request.WorkflowTimeout.Seconds()) + openExecutionTTLBuffer
+
+	err := v.db.UpsertVisibility(ctx, ttl, &nosqlplugin.VisibilityRowForInsert{
+		DomainID: request.DomainUUID,
+		VisibilityRow: nosqlplugin.VisibilityRow{
+			WorkflowID:             request.WorkflowID,
+			RunID:                  request.RunID,
+			TypeName:               request.WorkflowTypeName,
+			StartTime:              request.StartTimestamp,
+			ExecutionTime:          request.ExecutionTimestamp,
+			Memo:                   request.Memo,
+			TaskList:               request.TaskList,
+			IsCron:                 request.IsCron,
+			NumClusters:            request.NumClusters,
+			UpdateTime:             request.UpdateTimestamp,
+			ShardID:                int16(request.ShardID),
+			ExecutionStatus:        request.ExecutionStatus,
+			CronSchedule:           request.CronSchedule,
+			ScheduledExecutionTime: time.Unix(0, request.ScheduledExecutionTimestamp),
+		},
+		KeywordSearchAttributes: v.keywordSearchAttributes(request.SearchAttributes),
+	})
+	if err != nil {
+		return v.convertVisibilityQueryError("UpsertWorkflowExecution", err)
+	}
+	return nil
 }
 
 func (v *nosqlVisibilityStore) ListOpenWorkflowExecutions(
@@ -374,21 +446,329 @@ func (v *nosqlVisibilityStore) DeleteUninitializedWorkflowExecution(
 }
 
 func (v *nosqlVisibilityStore) ListWorkflowExecutions(
-	_ context.Context,
-	_ *persistence.ListWorkflowExecutionsByQueryRequest,
+	ctx context.Context,
+	request *persistence.ListWorkflowExecutionsByQueryRequest,
 ) (*persistence.InternalListWorkflowExecutionsResponse, error) {
-	return nil, persistence.ErrVisibilityOperationNotSupported
+	return v.listWorkflowExecutionsByQuery(ctx, "ListWorkflowExecutions", request)
 }
 
 func (v *nosqlVisibilityStore) ScanWorkflowExecutions(
-	_ context.Context,
-	_ *persistence.ListWorkflowExecutionsByQueryRequest) (*persistence.InternalListWorkflowExecutionsResponse, error) {
-	return nil, persistence.ErrVisibilityOperationNotSupported
+	ctx context.Context,
+	request *persistence.ListWorkflowExecutionsByQueryRequest) (*persistence.InternalListWorkflowExecutionsResponse, error) {
+	return v.listWorkflowExecutionsByQuery(ctx, "ScanWorkflowExecutions", request)
 }
 
+// CountWorkflowExecutions counts the matching records by reading them, as Cassandra can not count across the buckets.
+// Reading stops after visibilityCountScanLimit records, and the query is rejected rather than answered with the
+// number of records read so far or an estimate: the response has no way to tell that a count is incomplete, and
+// callers use it as an exact number, e.g. to size batch operations or in dashboards. An estimate can not be made
+// reliable either, since how the records spread over the buckets depends on the start and close times of the domain.
+// Narrowing the query with StartTime or CloseTime bounds the buckets read, which is what the error asks for
 func (v *nosqlVisibilityStore) CountWorkflowExecutions(
-	_ context.Context,
-	_ *persistence.CountWorkflowExecutionsRequest,
+	ctx context.Context,
+	request *persistence.CountWorkflowExecutionsRequest,
 ) (*persistence.CountWorkflowExecutionsResponse, error) {
-	return nil, persistence.ErrVisibilityOperationNotSupported
+	query, err := v.parseQuery(request.Query)
+	if err != nil {
+		return nil, err
+	}
+	scanner := v.newVisibilityQueryScanner(request.DomainUUID, query, visibilityCountScanLimit)
+	var count int64
+	token := &nosqlVisibilityPageToken{}
+	for token != nil {
+		if scanner.budget <= 0 {
+			return nil, &types.BadRequestError{
+				Message: fmt.Sprintf("counting the workflows reads more than %d records, narrow down the query with StartTime or CloseTime", visibilityCountScanLimit),
+			}
+		}
+		var executions []*nosqlplugin.VisibilityRow
+		executions, token, err = scanner.selectPage(ctx, token, visibilityCountPageSize)
+		if err != nil {
+			return nil, v.convertVisibilityQueryError("CountWorkflowExecutions", err)
+		}
+		count += int64(len(executions))
+	}
+	return &persistence.CountWorkflowExecutionsResponse{Count: count}, nil
+}
+
+// listWorkflowExecutionsByQuery lists the open workflows sorted by start time, followed by the closed workflows sorted by close time
+func (v *nosqlVisibilityStore) listWorkflowExecutionsByQuery(
+	ctx context.Context,
+	operation string,
+	request *persistence.ListWorkflowExecutionsByQueryRequest,
+) (*persistence.InternalListWorkflowExecutionsResponse, error) {
+	query, err := v.parseQuery(request.Query)
+	if err != nil {
+		return nil, err
+	}
+	token := &nosqlVisibilityPageToken{}
+	if len(request.NextPageToken) > 0 {
+		if err := json.Unmarshal(request.NextPageToken, token); err != nil {
+			return nil, &types.BadRequestError{Message: fmt.Sprintf("invalid next page token: %v", err)}
+		}
+	}
+
+	if request.PageSize <= 0 {
+		return nil, &types.BadRequestError{Message: fmt.Sprintf("invalid page size: %d", request.PageSize)}
+	}
+
+	scanner := v.newVisibilityQueryScanner(request.DomainUUID, query, visibilityQueryScanLimit)
+	executions, nextToken, err := scanner.selectPage(ctx, token, request.PageSize)
+	if err != nil {
+		return nil, v.convertVisibilityQueryError(operation, err)
+	}
+	var nextPageToken []byte
+	if nextToken != nil {
+		nextPageToken, err = json.Marshal(nextToken)
+		if err != nil {
+			return nil, &types.InternalServiceError{Message: fmt.Sprintf("%v failed to serialize next page token: %v", operation, err)}
+		}
+	}
+	return &persistence.InternalListWorkflowExecutionsResponse{
+		Executions:    executions,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (v *nosqlVisibilityStore) parseQuery(query string) (*visibilityQuery, error) {
+	res, err := newVisibilityQueryParser(v.getValidSearchAttributes(), v.logger).parse(query)
+	if err != nil {
+		return nil, &types.BadRequestError{Message: err.Error()}
+	}
+	return res, nil
+}
+
+func (v *nosqlVisibilityStore) newVisibilityQueryScanner(domainID string, query *visibilityQuery, budget int) *visibilityQueryScanner {
+	return &visibilityQueryScanner{
+		db:             v.db,
+		domainID:       domainID,
+		query:          query,
+		budget:         budget,
+		numOpenBuckets: v.numOpenBuckets,
+	}
+}
+
+// keywordSearchAttributes returns the keyword search attributes, which are the only ones that can be queried
+func (v *nosqlVisibilityStore) keywordSearchAttributes(searchAttributes map[string][]byte) map[string][]byte {
+	if len(searchAttributes) == 0 {
+		return nil
+	}
+	validSearchAttributes := v.getValidSearchAttributes()
+	keywords := make(map[string][]byte)
+	for key, value := range searchAttributes {
+		fieldType, ok := validSearchAttributes[key]
+		if !ok {
+			continue
+		}
+		if common.ConvertIndexedValueTypeToInternalType(fieldType, v.logger) == types.IndexedValueTypeKeyword {
+			keywords[key] = value
+		}
+	}
+	return keywords
+}
+
+func (v *nosqlVisibilityStore) getValidSearchAttributes() map[string]interface{} {
+	if v.validSearchAttributes == nil {
+		return definition.GetDefaultIndexedKeys()
+	}
+	return v.validSearchAttributes()
+}
+
+// convertVisibilityQueryError keeps the error of the plugins that don't support visibility queries
+func (v *nosqlVisibilityStore) convertVisibilityQueryError(operation string, err error) error {
+	if err == persistence.ErrVisibilityOperationNotSupported {
+		return err
+	}
+	return convertCommonErrors(v.db, operation, err)
+}
+
+// selectPage reads the open records before the closed ones, and returns nil as the token of the last page
+func (s *visibilityQueryScanner) selectPage(
+	ctx context.Context,
+	token *nosqlVisibilityPageToken,
+	pageSize int,
+) ([]*nosqlplugin.VisibilityRow, *nosqlVisibilityPageToken, error) {
+	var executions []*nosqlplugin.VisibilityRow
+	pageAfter := token.PageAfter
+	if !token.Closed && s.query.includeOpen {
+		rows, next, err := s.selectOpen(ctx, pageAfter, pageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		executions = rows
+		if next != nil {
+			return executions, &nosqlVisibilityPageToken{PageAfter: next}, nil
+		}
+		pageAfter = nil
+	}
+	if !s.query.includeClosed {
+		return executions, nil, nil
+	}
+	if len(executions) >= pageSize || s.budget <= 0 {
+		return executions, &nosqlVisibilityPageToken{Closed: true}, nil
+	}
+
+	rows, next, err := s.selectClosed(ctx, pageAfter, pageSize-len(executions))
+	if err != nil {
+		return nil, nil, err
+	}
+	executions = append(executions, rows...)
+	if next != nil {
+		return executions, &nosqlVisibilityPageToken{Closed: true, PageAfter: next}, nil
+	}
+	return executions, nil, nil
+}
+
+// selectOpen merges the open records of all buckets by descending start time. It returns the position to continue from,
+// which is nil when all the open records are read
+func (s *visibilityQueryScanner) selectOpen(
+	ctx context.Context,
+	pageAfter *nosqlplugin.VisibilitySortKey,
+	pageSize int,
+) ([]*nosqlplugin.VisibilityRow, *nosqlplugin.VisibilitySortKey, error) {
+	limit := s.budget / s.numOpenBuckets
+	if limit < 1 {
+		limit = 1
+	}
+	var candidates []*nosqlplugin.VisibilityRow
+	// the records after the frontier are not read from some buckets yet, so they can't be returned
+	var frontier *nosqlplugin.VisibilitySortKey
+	for bucket := int64(0); bucket < int64(s.numOpenBuckets); bucket++ {
+		matches, last, exhausted, err := s.selectBucket(ctx, true, bucket, s.query.startTimeRange, pageAfter, pageSize, limit)
+		if err != nil {
+			return nil, nil, err
+		}
+		candidates = append(candidates, matches...)
+		if !exhausted && (frontier == nil || compareVisibilitySortKeys(last, frontier) > 0) {
+			frontier = last
+		}
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return compareVisibilitySortKeys(visibilitySortKey(candidates[i], true), visibilitySortKey(candidates[j], true)) > 0
+	})
+	executions := make([]*nosqlplugin.VisibilityRow, 0, pageSize)
+	for _, candidate := range candidates {
+		if len(executions) == pageSize || (frontier != nil && compareVisibilitySortKeys(visibilitySortKey(candidate, true), frontier) < 0) {
+			break
+		}
+		executions = append(executions, candidate)
+	}
+	if len(executions) == pageSize && (frontier != nil || len(candidates) > pageSize) {
+		return executions, visibilitySortKey(executions[len(executions)-1], true), nil
+	}
+	return executions, frontier, nil
+}
+
+// selectClosed reads the closed records bucket by bucket, the buckets are ordered by close time.
+// It returns the position to continue from, which is nil when all the closed records are read
+func (s *visibilityQueryScanner) selectClosed(
+	ctx context.Context,
+	pageAfter *nosqlplugin.VisibilitySortKey,
+	pageSize int,
+) ([]*nosqlplugin.VisibilityRow, *nosqlplugin.VisibilitySortKey, error) {
+	timeRange := s.query.closeTimeRange
+	maxBucket := nosqlplugin.ClosedVisibilityBucket(timeRange.max)
+	if pageAfter != nil {
+		maxBucket = nosqlplugin.ClosedVisibilityBucket(pageAfter.SortTime)
+	}
+	buckets, err := s.db.SelectClosedVisibilityBuckets(ctx, s.domainID, nosqlplugin.ClosedVisibilityBucket(timeRange.min), maxBucket)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var executions []*nosqlplugin.VisibilityRow
+	position := pageAfter
+	for _, bucket := range buckets {
+		if s.budget <= 0 {
+			return executions, position, nil
+		}
+		var bucketAfter *nosqlplugin.VisibilitySortKey
+		if position != nil && nosqlplugin.ClosedVisibilityBucket(position.SortTime) == bucket {
+			bucketAfter = position
+		}
+		matches, last, exhausted, err := s.selectBucket(ctx, false, bucket, timeRange, bucketAfter, pageSize-len(executions), s.budget)
+		if err != nil {
+			return nil, nil, err
+		}
+		executions = append(executions, matches...)
+		if last != nil {
+			position = last
+		}
+		if !exhausted {
+			return executions, position, nil
+		}
+	}
+	return executions, nil, nil
+}
+
+// selectBucket reads the records of a bucket after pageAfter until it finds pageSize records that match the query,
+// reads limit records or reaches the end of the bucket. It returns the matching records, the position of the last
+// record read and whether the end of the bucket is reached
+func (s *visibilityQueryScanner) selectBucket(
+	ctx context.Context,
+	isOpen bool,
+	bucket int64,
+	timeRange visibilityTimeRange,
+	pageAfter *nosqlplugin.VisibilitySortKey,
+	pageSize int,
+	limit int,
+) ([]*nosqlplugin.VisibilityRow, *nosqlplugin.VisibilitySortKey, bool, error) {
+	var matches []*nosqlplugin.VisibilityRow
+	for limit > 0 {
+		readSize := pageSize - len(matches)
+		if readSize < visibilityQueryMinReadSize {
+			readSize = visibilityQueryMinReadSize
+		}
+		if readSize > limit {
+			readSize = limit
+		}
+		rows, err := s.db.SelectVisibilityBucket(ctx, &nosqlplugin.VisibilityBucketFilter{
+			DomainID:    s.domainID,
+			IsOpen:      isOpen,
+			Bucket:      bucket,
+			MinSortTime: timeRange.min,
+			MaxSortTime: timeRange.max,
+			PageAfter:   pageAfter,
+			PageSize:    readSize,
+		})
+		if err != nil {
+			return nil, nil, false, err
+		}
+		limit -= len(rows)
+		s.budget -= len(rows)
+		for _, row := range rows {
+			pageAfter = visibilitySortKey(row, isOpen)
+			if s.query.filter == nil || s.query.filter(row) {
+				matches = append(matches, row)
+				if len(matches) == pageSize {
+					return matches, pageAfter, false, nil
+				}
+			}
+		}
+		if len(rows) < readSize {
+			return matches, pageAfter, true, nil
+		}
+	}
+	return matches, pageAfter, false, nil
+}
+
+// visibilitySortKey returns the position of a record in its bucket
+func visibilitySortKey(record *nosqlplugin.VisibilityRow, isOpen bool) *nosqlplugin.VisibilitySortKey {
+	if isOpen {
+		return &nosqlplugin.VisibilitySortKey{SortTime: record.StartTime, RunID: record.RunID}
+	}
+	return &nosqlplugin.VisibilitySortKey{SortTime: record.CloseTime, RunID: record.RunID}
+}
+
+// compareVisibilitySortKeys orders the positions the way the records are sorted in the buckets, but ascending
+func compareVisibilitySortKeys(a, b *nosqlplugin.VisibilitySortKey) int {
+	switch {
+	case a.SortTime.Before(b.SortTime):
+		return -1
+	case a.SortTime.After(b.SortTime):
+		return 1
+	default:
+		return strings.Compare(a.RunID, b.RunID)
+	}
 }
@@ -24,6 +24,7 @@ package nosql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
@@ -77,6 +78,7 @@ func setupNoSQLVisibilityStoreMocks(t *testing.T) (*nosqlVisibilityStore, *nosql
 	visibilityStore := &nosqlVisibilityStore{
 		nosqlStore:      shardedNosqlStoreMock.GetDefaultShard(),
 		sortByCloseTime: false,
+		numOpenBuckets:  nosqlplugin.DefaultNumOpenVisibilityBuckets,
 	}
 	return visibilityStore, dbMock
 }
@@ -168,7 +170,7 @@ func TestRecordWorkflowExecutionUninitialized(t *testing.T) {
 }
 
 func TestUpsertWorkflowExecution(t *testing.T) {
-	visibilityStore, _ := setupNoSQLVisibilityStoreMocks(t)
+	visibilityStore, db := setupNoSQLVisibilityStoreMocks(t)
 
 	err := visibilityStore.UpsertWorkflowExecution(context.Background(), &persistence.InternalUpsertWorkflowExecutionRequest{
 		SearchAttributes: map[string][]byte{
@@ -178,7 +180,44 @@ func TestUpsertWorkflowExecution(t *testing.T) {
 
 	assert.NoError(t, err)
 
-	err = visibilityStore.UpsertWorkflowExecution(context.Background(), &persistence.InternalUpsertWorkflowExecutionRequest{})
+	db.EXPECT().UpsertVisibility(gomock.Any(), int64(3600)+openExecutionTTLBuffer, &nosqlplugin.VisibilityRowForInsert{
+		DomainID: testDomainID,
+		VisibilityRow: nosqlplugin.VisibilityRow{
+			WorkflowID:             testWorkflowID,
+			RunID:                  testRunID,
+			TypeName:               testWorkflowTypeName,
+			TaskList:               testTaskListName,
+			ShardID:                2,
+			ScheduledExecutionTime: time.Unix(0, 0),
+		},
+		KeywordSearchAttributes: map[string][]byte{
+			definition.CustomKeywordField: []byte(`"keyword"`),
+		},
+	}).Return(nil)
+
+	err = visibilityStore.UpsertWorkflowExecution(context.Background(), &persistence.InternalUpsertWorkflowExecutionRequest{
+		DomainUUID:       testDomainID,
+		WorkflowID:       testWorkflowID,
+		RunID:            testRunID,
+		WorkflowTypeName: testWorkflowTypeName,
+		TaskList:         testTaskListName,
+		WorkflowTimeout:  time.Hour,
+		ShardID:          2,
+		SearchAttributes: map[string][]byte{
+			definition.CustomKeywordField: []byte(`"keyword"`),
+			definition.CustomIntField:     []byte(`1`),
+		},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestUpsertWorkflowExecution_NotSupported(t *testing.T) {
+	visibilityStore, db := setupNoSQLVisibilityStoreMocks(t)
+
+	db.EXPECT().UpsertVisibility(gomock.Any(), gomock.Any(), gomock.Any()).Return(persistence.ErrVisibilityOperationNotSupported)
+
+	err := visibilityStore.UpsertWorkflowExecution(context.Background(), &persistence.InternalUpsertWorkflowExecutionRequest{})
 
 	assert.Error(t, err)
 	assert.Equal(t, persistence.ErrVisibilityOperationNotSupported, err)
@@ -565,22 +604,246 @@ func TestDeleteUninitializedWorkflowExecution(t *testing.T) {
 }
 
 func TestListWorkflowExecutions(t *testing.T) {
-	visibilityStore, _ := setupNoSQLVisibilityStoreMocks(t)
-	_, err := visibilityStore.ListWorkflowExecutions(context.Background(), &persistence.ListWorkflowExecutionsByQueryRequest{})
-	assert.Error(t, err)
-	assert.Equal(t, persistence.ErrVisibilityOperationNotSupported, err)
+	now := time.Unix(1712009321, 0)
+	closedBucket := nosqlplugin.ClosedVisibilityBucket(now)
+	tests := map[string]struct {
+		request       *persistence.ListWorkflowExecutionsByQueryRequest
+		setupMock     func(db *nosqlplugin.MockDB)
+		expectedRunID []string
+		expectedToken *nosqlVisibilityPageToken
+		expectedError error
+	}{
+		"open workflows of all buckets": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: testDomainID,
+				PageSize:   10,
+				Query:      "CloseTime = missing",
+			},
+			setupMock: func(db *nosqlplugin.MockDB) {
+				db.EXPECT().SelectVisibilityBucket(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, filter *nosqlplugin.VisibilityBucketFilter) ([]*nosqlplugin.VisibilityRow, error) {
+						assert.True(t, filter.IsOpen)
+						assert.Equal(t, visibilityQueryMinReadSize, filter.PageSize)
+						switch filter.Bucket {
+						case 1:
+							return []*nosqlplugin.VisibilityRow{
+								{RunID: "run-1", StartTime: now},
+								{RunID: "run-3", StartTime: now.Add(-2 * time.Minute)},
+							}, nil
+						case 5:
+							return []*nosqlplugin.VisibilityRow{{RunID: "run-2", StartTime: now.Add(-time.Minute)}}, nil
+						default:
+							return nil, nil
+						}
+					}).Times(nosqlplugin.DefaultNumOpenVisibilityBuckets)
+			},
+			expectedRunID: []string{"run-1", "run-2", "run-3"},
+		},
+		"open workflows with a full page": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: testDomainID,
+				PageSize:   2,
+				Query:      "CloseTime = missing",
+			},
+			setupMock: func(db *nosqlplugin.MockDB) {
+				db.EXPECT().SelectVisibilityBucket(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, filter *nosqlplugin.VisibilityBucketFilter) ([]*nosqlplugin.VisibilityRow, error) {
+						if filter.Bucket == 1 {
+							return []*nosqlplugin.VisibilityRow{
+								{RunID: "run-1", StartTime: now},
+								{RunID: "run-2", StartTime: now.Add(-time.Minute)},
+								{RunID: "run-3", StartTime: now.Add(-2 * time.Minute)},
+							}, nil
+						}
+						return nil, nil
+					}).Times(nosqlplugin.DefaultNumOpenVisibilityBuckets)
+			},
+			expectedRunID: []string{"run-1", "run-2"},
+			expectedToken: &nosqlVisibilityPageToken{
+				PageAfter: &nosqlplugin.VisibilitySortKey{SortTime: now.Add(-time.Minute), RunID: "run-2"},
+			},
+		},
+		"closed workflows across buckets": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: testDomainID,
+				PageSize:   2,
+				Query:      "CloseStatus = 0",
+			},
+			setupMock: func(db *nosqlplugin.MockDB) {
+				db.EXPECT().SelectClosedVisibilityBuckets(gomock.Any(), testDomainID, nosqlplugin.ClosedVisibilityBucket(minVisibilityTime), nosqlplugin.ClosedVisibilityBucket(maxVisibilityTime)).
+					Return([]int64{closedBucket, closedBucket - 1}, nil)
+				completed := types.WorkflowExecutionCloseStatusCompleted
+				failed := types.WorkflowExecutionCloseStatusFailed
+				db.EXPECT().SelectVisibilityBucket(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, filter *nosqlplugin.VisibilityBucketFilter) ([]*nosqlplugin.VisibilityRow, error) {
+						assert.False(t, filter.IsOpen)
+						if filter.Bucket == closedBucket {
+							return []*nosqlplugin.VisibilityRow{
+								{RunID: "run-1", CloseTime: now, Status: &completed},
+								{RunID: "run-2", CloseTime: now, Status: &failed},
+							}, nil
+						}
+						return []*nosqlplugin.VisibilityRow{
+							{RunID: "run-3", CloseTime: now.Add(-24 * time.Hour), Status: &completed},
+							{RunID: "run-4", CloseTime: now.Add(-25 * time.Hour), Status: &completed},
+						}, nil
+					}).Times(2)
+			},
+			expectedRunID: []string{"run-1", "run-3"},
+			expectedToken: &nosqlVisibilityPageToken{
+				Closed:    true,
+				PageAfter: &nosqlplugin.VisibilitySortKey{SortTime: now.Add(-24 * time.Hour), RunID: "run-3"},
+			},
+		},
+		"invalid query": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: testDomainID,
+				PageSize:   10,
+				Query:      "WorkflowID = 'wid' ORDER BY StartTime",
+			},
+			setupMock:     func(db *nosqlplugin.MockDB) {},
+			expectedError: &types.BadRequestError{},
+		},
+		"invalid page token": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID:    testDomainID,
+				PageSize:      10,
+				NextPageToken: []byte("invalid"),
+			},
+			setupMock:     func(db *nosqlplugin.MockDB) {},
+			expectedError: &types.BadRequestError{},
+		},
+		"not supported": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: testDomainID,
+				PageSize:   10,
+			},
+			setupMock: func(db *nosqlplugin.MockDB) {
+				db.EXPECT().SelectVisibilityBucket(gomock.Any(), gomock.Any()).Return(nil, persistence.ErrVisibilityOperationNotSupported)
+			},
+			expectedError: persistence.ErrVisibilityOperationNotSupported,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			visibilityStore, db := setupNoSQLVisibilityStoreMocks(t)
+			test.setupMock(db)
+
+			resp, err := visibilityStore.ListWorkflowExecutions(context.Background(), test.request)
+
+			if test.expectedError != nil {
+				assert.Error(t, err)
+				assert.IsType(t, test.expectedError, err)
+				return
+			}
+			assert.NoError(t, err)
+			var runIDs []string
+			for _, execution := range resp.Executions {
+				runIDs = append(runIDs, execution.RunID)
+			}
+			assert.Equal(t, test.expectedRunID, runIDs)
+			if test.expectedToken == nil {
+				assert.Nil(t, resp.NextPageToken)
+				return
+			}
+			token := &nosqlVisibilityPageToken{}
+			assert.NoError(t, json.Unmarshal(resp.NextPageToken, token))
+			assert.Equal(t, test.expectedToken.Closed, token.Closed)
+			assert.Equal(t, test.expectedToken.PageAfter.RunID, token.PageAfter.RunID)
+			assert.True(t, test.expectedToken.PageAfter.SortTime.Equal(token.PageAfter.SortTime))
+		})
+	}
 }
 
 func TestScanWorkflowExecutions(t *testing.T) {
-	visibilityStore, _ := setupNoSQLVisibilityStoreMocks(t)
-	_, err := visibilityStore.ScanWorkflowExecutions(context.Background(), &persistence.ListWorkflowExecutionsByQueryRequest{})
-	assert.Error(t, err)
-	assert.Equal(t, persistence.ErrVisibilityOperationNotSupported, err)
+	visibilityStore, db := setupNoSQLVisibilityStoreMocks(t)
+	now := time.Unix(1712009321, 0)
+	closedBucket := nosqlplugin.ClosedVisibilityBucket(now)
+
+	// the open page is not full, so the page continues with the closed workflows
+	db.EXPECT().SelectVisibilityBucket(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filter *nosqlplugin.VisibilityBucketFilter) ([]*nosqlplugin.VisibilityRow, error) {
+			switch {
+			case filter.IsOpen && filter.Bucket == 0:
+				return []*nosqlplugin.VisibilityRow{{RunID: "run-1", WorkflowID: testWorkflowID, StartTime: now}}, nil
+			case !filter.IsOpen:
+				return []*nosqlplugin.VisibilityRow{
+					{RunID: "run-2", WorkflowID: testWorkflowID, CloseTime: now},
+					{RunID: "run-3", WorkflowID: "other-workflow-id", CloseTime: now},
+				}, nil
+			default:
+				return nil, nil
+			}
+		}).Times(nosqlplugin.DefaultNumOpenVisibilityBuckets + 1)
+	db.EXPECT().SelectClosedVisibilityBuckets(gomock.Any(), testDomainID, gomock.Any(), gomock.Any()).Return([]int64{closedBucket}, nil)
+
+	resp, err := visibilityStore.ScanWorkflowExecutions(context.Background(), &persistence.ListWorkflowExecutionsByQueryRequest{
+		DomainUUID: testDomainID,
+		PageSize:   10,
+		Query:      fmt.Sprintf("WorkflowID = '%s'", testWorkflowID),
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Executions, 2)
+	assert.Equal(t, "run-1", resp.Executions[0].RunID)
+	assert.Equal(t, "run-2", resp.Executions[1].RunID)
+	assert.Nil(t, resp.NextPageToken)
 }
 
 func TestCountWorkflowExecutions(t *testing.T) {
+	visibilityStore, db := setupNoSQLVisibilityStoreMocks(t)
+	now := time.Unix(1712009321, 0)
+
+	db.EXPECT().SelectVisibilityBucket(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filter *nosqlplugin.VisibilityBucketFilter) ([]*nosqlplugin.VisibilityRow, error) {
+			return []*nosqlplugin.VisibilityRow{
+				{RunID: fmt.Sprintf("run-%d-1", filter.Bucket), TypeName: testWorkflowTypeName, StartTime: now},
+				{RunID: fmt.Sprintf("run-%d-2", filter.Bucket), TypeName: "other-workflow-type", StartTime: now},
+			}, nil
+		}).Times(nosqlplugin.DefaultNumOpenVisibilityBuckets)
+	db.EXPECT().SelectClosedVisibilityBuckets(gomock.Any(), testDomainID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	resp, err := visibilityStore.CountWorkflowExecutions(context.Background(), &persistence.CountWorkflowExecutionsRequest{
+		DomainUUID: testDomainID,
+		Query:      fmt.Sprintf("WorkflowType = '%s'", testWorkflowTypeName),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(nosqlplugin.DefaultNumOpenVisibilityBuckets), resp.Count)
+}
+
+func TestCountWorkflowExecutions_ConfiguredOpenBuckets(t *testing.T) {
+	visibilityStore, db := setupNoSQLVisibilityStoreMocks(t)
+	visibilityStore.numOpenBuckets = 32
+	now := time.Unix(1712009321, 0)
+
+	db.EXPECT().SelectVisibilityBucket(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, filter *nosqlplugin.VisibilityBucketFilter) ([]*nosqlplugin.VisibilityRow, error) {
+			assert.Less(t, filter.Bucket, int64(32))
+			return []*nosqlplugin.VisibilityRow{
+				{RunID: fmt.Sprintf("run-%d", filter.Bucket), TypeName: testWorkflowTypeName, StartTime: now},
+			}, nil
+		}).Times(32)
+	db.EXPECT().SelectClosedVisibilityBuckets(gomock.Any(), testDomainID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	resp, err := visibilityStore.CountWorkflowExecutions(context.Background(), &persistence.CountWorkflowExecutionsRequest{
+		DomainUUID: testDomainID,
+		Query:      fmt.Sprintf("WorkflowType = '%s'", testWorkflowTypeName),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(32), resp.Count)
+}
+
+func TestCountWorkflowExecutions_InvalidQuery(t *testing.T) {
 	visibilityStore, _ := setupNoSQLVisibilityStoreMocks(t)
-	_, err := visibilityStore.CountWorkflowExecutions(context.Background(), &persistence.CountWorkflowExecutionsRequest{})
+
+	_, err := visibilityStore.CountWorkflowExecutions(context.Background(), &persistence.CountWorkflowExecutionsRequest{
+		DomainUUID: testDomainID,
+		Query:      "WorkflowID LIKE 'test%'",
+	})
+
 	assert.Error(t, err)
-	assert.Equal(t, persistence.ErrVisibilityOperationNotSupported, err)
+	assert.IsType(t, &types.BadRequestError{}, err)
 }
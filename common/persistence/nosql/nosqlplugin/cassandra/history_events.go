@@ -73,6 +73,15 @@ func (db *CDB) InsertIntoHistoryTreeAndNode(ctx context.Context, treeRow *nosqlp
 	return err
 }
 
+// UpdateHistoryNode replaces the data and encoding of an existing node row
+func (db *CDB) UpdateHistoryNode(ctx context.Context, nodeRow *nosqlplugin.HistoryNodeRow) error {
+	query := db.session.Query(v2templateUpdateData,
+		nodeRow.Data, nodeRow.DataEncoding, nodeRow.TreeID, nodeRow.BranchID, nodeRow.NodeID, nodeRow.TxnID).WithContext(ctx)
+	// the node may have been deleted with its branch meanwhile, in which case there is nothing to update
+	_, err := query.MapScanCAS(make(map[string]interface{}))
+	return err
+}
+
 // SelectFromHistoryNode read nodes based on a filter
 func (db *CDB) SelectFromHistoryNode(ctx context.Context, filter *nosqlplugin.HistoryNodeFilter) ([]*nosqlplugin.HistoryNodeRow, []byte, error) {
 	query := db.session.Query(v2templateReadData, filter.TreeID, filter.BranchID, filter.MinNodeID, filter.MaxNodeID).WithContext(ctx)
@@ -27,6 +27,10 @@ const (
 		`tree_id, branch_id, node_id, txn_id, data, data_encoding, created_time) ` +
 		`VALUES (?, ?, ?, ?, ?, ?, ?) `
 
+	v2templateUpdateData = `UPDATE history_node SET data = ?, data_encoding = ? ` +
+		`WHERE tree_id = ? AND branch_id = ? AND node_id = ? AND txn_id = ? ` +
+		`IF EXISTS`
+
 	v2templateReadData = `SELECT node_id, txn_id, data, data_encoding FROM history_node ` +
 		`WHERE tree_id = ? AND branch_id = ? AND node_id >= ? AND node_id < ? `
 
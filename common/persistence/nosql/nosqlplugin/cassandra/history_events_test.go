@@ -24,6 +24,7 @@ package cassandra
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -131,6 +132,57 @@ func TestInsertIntoHistoryTreeAndNode(t *testing.T) {
 	}
 }
 
+func TestUpdateHistoryNode(t *testing.T) {
+	txnID := int64(10)
+	nodeRow := &nosqlplugin.HistoryNodeRow{
+		TreeID:       "treeID",
+		BranchID:     "branchID",
+		NodeID:       1,
+		TxnID:        &txnID,
+		Data:         []byte("node data"),
+		DataEncoding: "encoding",
+	}
+	tests := []struct {
+		name        string
+		queryErr    error
+		applied     bool
+		expectError bool
+	}{
+		{
+			name:    "Successfully update node row",
+			applied: true,
+		},
+		{
+			name:    "Node row does not exist",
+			applied: false,
+		},
+		{
+			name:        "Query fails",
+			queryErr:    errors.New("query failed"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			mockQuery := gocql.NewMockQuery(ctrl)
+			mockQuery.EXPECT().WithContext(gomock.Any()).Return(mockQuery)
+			mockQuery.EXPECT().MapScanCAS(gomock.Any()).Return(tt.applied, tt.queryErr)
+			session := &fakeSession{query: mockQuery}
+
+			db := &CDB{session: session}
+			err := db.UpdateHistoryNode(context.Background(), nodeRow)
+			if tt.expectError {
+				assert.Error(t, err, "Expected an error but got none")
+			} else {
+				assert.NoError(t, err, "Did not expect an error but got one")
+			}
+		})
+	}
+}
+
 func TestSelectFromHistoryNode(t *testing.T) {
 	txnID1 := int64(1)
 	txnID2 := int64(2)
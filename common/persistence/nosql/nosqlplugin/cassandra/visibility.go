@@ -22,6 +22,7 @@ package cassandra
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -39,11 +40,11 @@ const (
 )
 
 // InsertVisibility creates a new visibility record, return error is there is any.
-// TODO: Cassandra implementation ignores search attributes
+// Only the copy in executions_by_bucket keeps the keyword search attributes
 func (db *CDB) InsertVisibility(ctx context.Context, ttlSeconds int64, row *nosqlplugin.VisibilityRowForInsert) error {
-	var query gocql.Query
+	batch := db.session.NewBatch(gocql.LoggedBatch).WithContext(ctx)
 	if ttlSeconds > maxCassandraTTL {
-		query = db.session.Query(templateCreateWorkflowExecutionStarted,
+		batch.Query(templateCreateWorkflowExecutionStarted,
 			row.DomainID,
 			domainPartition,
 			row.WorkflowID,
@@ -61,9 +62,9 @@ func (db *CDB) InsertVisibility(ctx context.Context, ttlSeconds int64, row *nosq
 			row.ExecutionStatus,
 			row.CronSchedule,
 			persistence.UnixNanoToDBTimestamp(row.ScheduledExecutionTime.UnixNano()),
-		).WithContext(ctx)
+		)
 	} else {
-		query = db.session.Query(templateCreateWorkflowExecutionStartedWithTTL,
+		batch.Query(templateCreateWorkflowExecutionStartedWithTTL,
 			row.DomainID,
 			domainPartition,
 			row.WorkflowID,
@@ -82,9 +83,23 @@ func (db *CDB) InsertVisibility(ctx context.Context, ttlSeconds int64, row *nosq
 			row.CronSchedule,
 			persistence.UnixNanoToDBTimestamp(row.ScheduledExecutionTime.UnixNano()),
 			ttlSeconds,
-		).WithContext(ctx)
+		)
 	}
-	query = query.WithTimestamp(persistence.UnixNanoToDBTimestamp(row.StartTime.UnixNano()))
+	batch.Query(db.openExecutionByBucketQuery(ttlSeconds, row))
+	batch = batch.WithTimestamp(persistence.UnixNanoToDBTimestamp(row.StartTime.UnixNano()))
+	return db.session.ExecuteBatch(batch)
+}
+
+// UpsertVisibility overwrites the open record in executions_by_bucket, the other tables don't keep search attributes
+func (db *CDB) UpsertVisibility(ctx context.Context, ttlSeconds int64, row *nosqlplugin.VisibilityRowForInsert) error {
+	// like UpdateVisibility, the timestamp makes sure that the upsert does not revive a record deleted by a later close
+	queryTimeStamp := row.UpdateTime
+	if queryTimeStamp.Before(row.StartTime) {
+		queryTimeStamp = row.StartTime
+	}
+	template, args := db.openExecutionByBucketQuery(ttlSeconds, row)
+	query := db.session.Query(template, args...).WithContext(ctx)
+	query = query.WithTimestamp(persistence.UnixNanoToDBTimestamp(queryTimeStamp.UnixNano()))
 	return query.Exec()
 }
 
@@ -203,6 +218,16 @@ func (db *CDB) UpdateVisibility(ctx context.Context, ttlSeconds int64, row *nosq
 		)
 	}
 
+	if row.UpdateOpenToClose {
+		batch.Query(templateDeleteOpenExecutionByBucket,
+			row.DomainID,
+			db.openVisibilityBucket(row.RunID),
+			persistence.UnixNanoToDBTimestamp(row.StartTime.UnixNano()),
+			row.RunID,
+		)
+	}
+	addClosedExecutionByBucket(batch, ttlSeconds, row)
+
 	// RecordWorkflowExecutionStarted is using StartTimestamp as
 	// the timestamp to issue query to Cassandra
 	// due to the fact that cross DC using mutable state creation time as workflow start time
@@ -273,11 +298,188 @@ func (db *CDB) DeleteVisibility(ctx context.Context, domainID, workflowID, runID
 			record.StartTime,
 			runID,
 		).WithContext(ctx)
+		if err := db.executeWithConsistencyAll(query); err != nil {
+			return err
+		}
+
+		query = db.session.Query(templateDeleteOpenExecutionByBucket,
+			domainID,
+			db.openVisibilityBucket(runID),
+			record.StartTime,
+			runID,
+		).WithContext(ctx)
 		return db.executeWithConsistencyAll(query)
 	}
 	return nil
 }
 
+func (db *CDB) SelectVisibilityBucket(ctx context.Context, filter *nosqlplugin.VisibilityBucketFilter) ([]*nosqlplugin.VisibilityRow, error) {
+	var query gocql.Query
+	if filter.PageAfter == nil {
+		query = db.session.Query(templateGetExecutionsByBucket,
+			filter.DomainID,
+			filter.IsOpen,
+			filter.Bucket,
+			persistence.UnixNanoToDBTimestamp(filter.MinSortTime.UnixNano()),
+			persistence.UnixNanoToDBTimestamp(filter.MaxSortTime.UnixNano()),
+			filter.PageSize,
+		)
+	} else {
+		query = db.session.Query(templateGetExecutionsByBucketAfter,
+			filter.DomainID,
+			filter.IsOpen,
+			filter.Bucket,
+			persistence.UnixNanoToDBTimestamp(filter.MinSortTime.UnixNano()),
+			persistence.UnixNanoToDBTimestamp(filter.PageAfter.SortTime.UnixNano()),
+			filter.PageAfter.RunID,
+			filter.PageSize,
+		)
+	}
+
+	iter := query.Consistency(cassandraLowConslevel).WithContext(ctx).Iter()
+	if iter == nil {
+		return nil, fmt.Errorf("not able to create query iterator")
+	}
+
+	rows := make([]*nosqlplugin.VisibilityRow, 0, filter.PageSize)
+	row, has := readExecutionByBucketRecord(iter, filter.IsOpen)
+	for has {
+		rows = append(rows, row)
+		row, has = readExecutionByBucketRecord(iter, filter.IsOpen)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (db *CDB) SelectClosedVisibilityBuckets(ctx context.Context, domainID string, minBucket, maxBucket int64) ([]int64, error) {
+	query := db.session.Query(templateGetClosedExecutionBuckets,
+		domainID,
+		minBucket,
+		maxBucket,
+	).Consistency(cassandraLowConslevel).WithContext(ctx)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, fmt.Errorf("not able to create query iterator")
+	}
+
+	var buckets []int64
+	var bucket int64
+	for iter.Scan(&bucket) {
+		buckets = append(buckets, bucket)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
+
+// openVisibilityBucket returns the bucket of executions_by_bucket that the open record of a run belongs to
+func (db *CDB) openVisibilityBucket(runID string) int64 {
+	return nosqlplugin.OpenVisibilityBucket(runID, nosqlplugin.GetNumOpenVisibilityBuckets(db.cfg))
+}
+
+// openExecutionByBucketQuery returns the query that writes an open record into executions_by_bucket
+func (db *CDB) openExecutionByBucketQuery(ttlSeconds int64, row *nosqlplugin.VisibilityRowForInsert) (string, []interface{}) {
+	args := []interface{}{
+		row.DomainID,
+		true,
+		db.openVisibilityBucket(row.RunID),
+		persistence.UnixNanoToDBTimestamp(row.StartTime.UnixNano()),
+		row.WorkflowID,
+		row.RunID,
+		persistence.UnixNanoToDBTimestamp(row.StartTime.UnixNano()),
+		persistence.UnixNanoToDBTimestamp(row.ExecutionTime.UnixNano()),
+		row.TypeName,
+		row.Memo.Data,
+		row.Memo.GetEncoding(),
+		row.TaskList,
+		row.IsCron,
+		row.NumClusters,
+		row.UpdateTime,
+		row.ShardID,
+		row.ExecutionStatus,
+		row.CronSchedule,
+		persistence.UnixNanoToDBTimestamp(row.ScheduledExecutionTime.UnixNano()),
+		toSearchAttributesColumn(row.KeywordSearchAttributes),
+	}
+	if ttlSeconds > maxCassandraTTL {
+		return templateCreateOpenExecutionByBucket, args
+	}
+	return templateCreateOpenExecutionByBucketWithTTL, append(args, ttlSeconds)
+}
+
+// addClosedExecutionByBucket writes a closed record into executions_by_bucket and marks its bucket in closed_execution_buckets
+func addClosedExecutionByBucket(batch gocql.Batch, ttlSeconds int64, row *nosqlplugin.VisibilityRowForUpdate) {
+	bucket := nosqlplugin.ClosedVisibilityBucket(row.CloseTime)
+	args := []interface{}{
+		row.DomainID,
+		false,
+		bucket,
+		persistence.UnixNanoToDBTimestamp(row.CloseTime.UnixNano()),
+		row.WorkflowID,
+		row.RunID,
+		persistence.UnixNanoToDBTimestamp(row.StartTime.UnixNano()),
+		persistence.UnixNanoToDBTimestamp(row.ExecutionTime.UnixNano()),
+		persistence.UnixNanoToDBTimestamp(row.CloseTime.UnixNano()),
+		row.TypeName,
+		row.Status,
+		row.HistoryLength,
+		row.Memo.Data,
+		row.Memo.GetEncoding(),
+		row.TaskList,
+		row.IsCron,
+		row.NumClusters,
+		row.UpdateTime,
+		row.ShardID,
+		row.ExecutionStatus,
+		row.CronSchedule,
+		persistence.UnixNanoToDBTimestamp(row.ScheduledExecutionTime.UnixNano()),
+		toSearchAttributesColumn(row.KeywordSearchAttributes),
+	}
+	if ttlSeconds > maxCassandraTTL {
+		batch.Query(templateCreateClosedExecutionByBucket, args...)
+		batch.Query(templateCreateClosedExecutionBucket, row.DomainID, bucket)
+		return
+	}
+	batch.Query(templateCreateClosedExecutionByBucketWithTTL, append(args, ttlSeconds)...)
+	// the bucket has to outlive the records closed at the end of the day
+	bucketTTL := ttlSeconds + int64(nosqlplugin.ClosedVisibilityBucketSize/time.Second)
+	if bucketTTL > maxCassandraTTL {
+		bucketTTL = maxCassandraTTL
+	}
+	batch.Query(templateCreateClosedExecutionBucketWithTTL, row.DomainID, bucket, bucketTTL)
+}
+
+func toSearchAttributesColumn(searchAttributes map[string][]byte) map[string]string {
+	if len(searchAttributes) == 0 {
+		return nil
+	}
+	column := make(map[string]string, len(searchAttributes))
+	for key, value := range searchAttributes {
+		column[key] = string(value)
+	}
+	return column
+}
+
+func fromSearchAttributesColumn(column map[string]string) map[string]interface{} {
+	if len(column) == 0 {
+		return nil
+	}
+	searchAttributes := make(map[string]interface{}, len(column))
+	for key, value := range column {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			// keep the raw value rather than failing the whole page
+			decoded = value
+		}
+		searchAttributes[key] = decoded
+	}
+	return searchAttributes
+}
+
 func (db *CDB) SelectVisibility(ctx context.Context, filter *nosqlplugin.VisibilityFilter) (*nosqlplugin.SelectVisibilityResponse, error) {
 	switch filter.FilterType {
 	case nosqlplugin.AllOpen:
@@ -637,3 +839,54 @@ func readClosedWorkflowExecutionRecord(
 	}
 	return nil, false
 }
+
+func readExecutionByBucketRecord(
+	iter gocql.Iter,
+	isOpen bool,
+) (*persistence.InternalVisibilityWorkflowExecutionInfo, bool) {
+	var workflowID string
+	var runID string
+	var typeName string
+	var startTime time.Time
+	var executionTime time.Time
+	var closeTime time.Time
+	var status workflow.WorkflowExecutionCloseStatus
+	var historyLength int64
+	var memo []byte
+	var encoding string
+	var taskList string
+	var isCron bool
+	var numClusters int16
+	var updateTime time.Time
+	var shardID int16
+	var executionStatus int32
+	var cronSchedule string
+	var scheduledExecutionTime time.Time
+	var searchAttributes map[string]string
+	if iter.Scan(&workflowID, &runID, &startTime, &executionTime, &closeTime, &typeName, &status, &historyLength, &memo, &encoding, &taskList, &isCron, &numClusters, &updateTime, &shardID, &executionStatus, &cronSchedule, &scheduledExecutionTime, &searchAttributes) {
+		record := &persistence.InternalVisibilityWorkflowExecutionInfo{
+			WorkflowID:             workflowID,
+			RunID:                  runID,
+			TypeName:               typeName,
+			StartTime:              startTime,
+			ExecutionTime:          executionTime,
+			Memo:                   persistence.NewDataBlob(memo, constants.EncodingType(encoding)),
+			TaskList:               taskList,
+			IsCron:                 isCron,
+			NumClusters:            numClusters,
+			UpdateTime:             updateTime,
+			SearchAttributes:       fromSearchAttributesColumn(searchAttributes),
+			ShardID:                shardID,
+			ExecutionStatus:        types.WorkflowExecutionStatus(executionStatus),
+			CronSchedule:           cronSchedule,
+			ScheduledExecutionTime: scheduledExecutionTime,
+		}
+		if !isOpen {
+			record.CloseTime = closeTime
+			record.Status = thrift.ToWorkflowExecutionCloseStatus(&status)
+			record.HistoryLength = historyLength
+		}
+		return record, true
+	}
+	return nil, false
+}
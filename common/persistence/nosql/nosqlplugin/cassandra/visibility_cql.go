@@ -167,4 +167,65 @@ const (
 		`AND close_time >= ? ` +
 		`AND close_time <= ? ` +
 		`AND status = ? `
+
+	// /////////////// Executions By Bucket /////////////////
+	executionsByBucketColumnsForSelect = " workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes "
+
+	openExecutionsByBucketColumnsForInsert = "(domain_id, is_open, bucket, sort_time, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) "
+
+	closedExecutionsByBucketColumnsForInsert = "(domain_id, is_open, bucket, sort_time, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) "
+
+	templateCreateOpenExecutionByBucketWithTTL = `INSERT INTO executions_by_bucket ` +
+		openExecutionsByBucketColumnsForInsert +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateOpenExecutionByBucket = `INSERT INTO executions_by_bucket ` +
+		openExecutionsByBucketColumnsForInsert +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	templateCreateClosedExecutionByBucketWithTTL = `INSERT INTO executions_by_bucket ` +
+		closedExecutionsByBucketColumnsForInsert +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) using TTL ?`
+
+	templateCreateClosedExecutionByBucket = `INSERT INTO executions_by_bucket ` +
+		closedExecutionsByBucketColumnsForInsert +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	templateDeleteOpenExecutionByBucket = `DELETE FROM executions_by_bucket ` +
+		`WHERE domain_id = ? ` +
+		`AND is_open = true ` +
+		`AND bucket = ? ` +
+		`AND sort_time = ? ` +
+		`AND run_id = ?`
+
+	// the time range is a multi-column restriction because it cannot be mixed with the one of the page position
+	templateGetExecutionsByBucket = `SELECT ` + executionsByBucketColumnsForSelect +
+		`FROM executions_by_bucket ` +
+		`WHERE domain_id = ? ` +
+		`AND is_open = ? ` +
+		`AND bucket = ? ` +
+		`AND (sort_time) >= (?) ` +
+		`AND (sort_time) <= (?) ` +
+		`LIMIT ?`
+
+	templateGetExecutionsByBucketAfter = `SELECT ` + executionsByBucketColumnsForSelect +
+		`FROM executions_by_bucket ` +
+		`WHERE domain_id = ? ` +
+		`AND is_open = ? ` +
+		`AND bucket = ? ` +
+		`AND (sort_time) >= (?) ` +
+		`AND (sort_time, run_id) < (?, ?) ` +
+		`LIMIT ?`
+
+	templateCreateClosedExecutionBucketWithTTL = `INSERT INTO closed_execution_buckets (domain_id, bucket) ` +
+		`VALUES (?, ?) using TTL ?`
+
+	templateCreateClosedExecutionBucket = `INSERT INTO closed_execution_buckets (domain_id, bucket) ` +
+		`VALUES (?, ?)`
+
+	templateGetClosedExecutionBuckets = `SELECT bucket ` +
+		`FROM closed_execution_buckets ` +
+		`WHERE domain_id = ? ` +
+		`AND bucket >= ? ` +
+		`AND bucket <= ?`
 )
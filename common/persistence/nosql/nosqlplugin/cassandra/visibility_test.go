@@ -23,7 +23,9 @@ package cassandra
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -35,9 +37,65 @@ import (
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin/cassandra/gocql"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin/cassandra/testdata"
+	"github.com/uber/cadence/common/types"
 )
 
 func TestInsertVisibility(t *testing.T) {
+	openBucket := nosqlplugin.OpenVisibilityBucket(testdata.RunID, nosqlplugin.DefaultNumOpenVisibilityBuckets)
+	tests := []struct {
+		desc        string
+		row         *nosqlplugin.VisibilityRowForInsert
+		ttlSeconds  int64
+		wantQueries []string
+		wantErr     bool
+	}{
+		{
+			desc:       "Query with ttl less than maxCassandraTTL",
+			row:        testdata.NewVisibilityRowForInsert(),
+			ttlSeconds: int64(1000),
+			wantQueries: []string{
+				`INSERT INTO open_executions (domain_id, domain_partition,  workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time )VALUES (test-domain-id, 0, test-workflow-id, test-run-id, 1712009321000, 1712009321000, test-type-name, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871) using TTL 1000`,
+				fmt.Sprintf(`INSERT INTO executions_by_bucket (domain_id, is_open, bucket, sort_time, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) VALUES (test-domain-id, true, %d, 1712009321000, test-workflow-id, test-run-id, 1712009321000, 1712009321000, test-type-name, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871, map[]) using TTL 1000`, openBucket),
+			},
+			wantErr: false,
+		},
+		{
+			desc:       "Query With ttl greater than maxCassandraTTL",
+			row:        testdata.NewVisibilityRowForInsert(),
+			ttlSeconds: maxCassandraTTL + 1,
+			wantQueries: []string{
+				`INSERT INTO open_executions(domain_id, domain_partition,  workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time )VALUES (test-domain-id, 0, test-workflow-id, test-run-id, 1712009321000, 1712009321000, test-type-name, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871)`,
+				fmt.Sprintf(`INSERT INTO executions_by_bucket (domain_id, is_open, bucket, sort_time, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) VALUES (test-domain-id, true, %d, 1712009321000, test-workflow-id, test-run-id, 1712009321000, 1712009321000, test-type-name, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871, map[])`, openBucket),
+			},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			session := &fakeSession{
+				query: gocql.NewMockQuery(ctrl),
+			}
+			client := gocql.NewMockClient(ctrl)
+			cfg := &config.NoSQL{}
+			logger := testlogger.New(t)
+			dc := &persistence.DynamicConfiguration{}
+			db := NewCassandraDBFromSession(cfg, session, logger, dc, DbWithClient(client))
+
+			err := db.InsertVisibility(context.Background(), test.ttlSeconds, test.row)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Nil(t, session.queries)
+			assert.Equal(t, test.wantQueries, session.batches[0].queries)
+		})
+	}
+}
+
+func TestUpsertVisibility(t *testing.T) {
+	openBucket := nosqlplugin.OpenVisibilityBucket(testdata.RunID, nosqlplugin.DefaultNumOpenVisibilityBuckets)
 	tests := []struct {
 		desc          string
 		row           *nosqlplugin.VisibilityRowForInsert
@@ -47,16 +105,20 @@ func TestInsertVisibility(t *testing.T) {
 		wantErr       bool
 	}{
 		{
-			desc:       "Query with ttl less than maxCassandraTTL",
-			row:        testdata.NewVisibilityRowForInsert(),
+			desc: "Query with ttl less than maxCassandraTTL",
+			row: func() *nosqlplugin.VisibilityRowForInsert {
+				row := testdata.NewVisibilityRowForInsert()
+				row.KeywordSearchAttributes = map[string][]byte{"CustomKeywordField": []byte(`"keyword"`)}
+				return row
+			}(),
 			ttlSeconds: int64(1000),
 			queryMockFunc: func(query *gocql.MockQuery) {
 				query.EXPECT().WithContext(gomock.Any()).Return(query)
-				query.EXPECT().WithTimestamp(gomock.Any()).Return(query)
+				query.EXPECT().WithTimestamp(int64(1712009321000)).Return(query)
 				query.EXPECT().Exec().Return(nil)
 			},
 			wantQueries: []string{
-				`INSERT INTO open_executions (domain_id, domain_partition,  workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time )VALUES (test-domain-id, 0, test-workflow-id, test-run-id, 1712009321000, 1712009321000, test-type-name, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871) using TTL 1000`,
+				fmt.Sprintf(`INSERT INTO executions_by_bucket (domain_id, is_open, bucket, sort_time, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) VALUES (test-domain-id, true, %d, 1712009321000, test-workflow-id, test-run-id, 1712009321000, 1712009321000, test-type-name, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871, map[CustomKeywordField:"keyword"]) using TTL 1000`, openBucket),
 			},
 			wantErr: false,
 		},
@@ -67,21 +129,19 @@ func TestInsertVisibility(t *testing.T) {
 			queryMockFunc: func(query *gocql.MockQuery) {
 				query.EXPECT().WithContext(gomock.Any()).Return(query)
 				query.EXPECT().WithTimestamp(gomock.Any()).Return(query)
-				query.EXPECT().Exec().Return(nil)
+				query.EXPECT().Exec().Return(errors.New("upsert failed"))
 			},
 			wantQueries: []string{
-				`INSERT INTO open_executions(domain_id, domain_partition,  workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time )VALUES (test-domain-id, 0, test-workflow-id, test-run-id, 1712009321000, 1712009321000, test-type-name, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871)`,
+				fmt.Sprintf(`INSERT INTO executions_by_bucket (domain_id, is_open, bucket, sort_time, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) VALUES (test-domain-id, true, %d, 1712009321000, test-workflow-id, test-run-id, 1712009321000, 1712009321000, test-type-name, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871, map[])`, openBucket),
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.desc, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			query := gocql.NewMockQuery(ctrl)
-			if test.queryMockFunc != nil {
-				test.queryMockFunc(query)
-			}
+			test.queryMockFunc(query)
 			session := &fakeSession{
 				query: query,
 			}
@@ -91,7 +151,7 @@ func TestInsertVisibility(t *testing.T) {
 			dc := &persistence.DynamicConfiguration{}
 			db := NewCassandraDBFromSession(cfg, session, logger, dc, DbWithClient(client))
 
-			err := db.InsertVisibility(context.Background(), test.ttlSeconds, test.row)
+			err := db.UpsertVisibility(context.Background(), test.ttlSeconds, test.row)
 			if test.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -103,6 +163,7 @@ func TestInsertVisibility(t *testing.T) {
 }
 
 func TestUpdateVisibility(t *testing.T) {
+	openBucket := nosqlplugin.OpenVisibilityBucket(testdata.RunID, nosqlplugin.DefaultNumOpenVisibilityBuckets)
 	tests := []struct {
 		desc        string
 		row         *nosqlplugin.VisibilityRowForUpdate
@@ -119,6 +180,9 @@ func TestUpdateVisibility(t *testing.T) {
 				`DELETE FROM open_executions WHERE domain_id = test-domain-id AND domain_partition = 0 AND start_time = 1712009321000 AND run_id = test-run-id`,
 				`INSERT INTO closed_executions (domain_id, domain_partition,  workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time )VALUES (test-domain-id, 0, test-workflow-id, test-run-id, 1712009321000, 1712009321000, 1712009261000, test-type-name, COMPLETED, 1, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871) using TTL 100`,
 				`INSERT INTO closed_executions_v2 (domain_id, domain_partition,  workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time )VALUES (test-domain-id, 0, test-workflow-id, test-run-id, 1712009321000, 1712009321000, 1712009261000, test-type-name, COMPLETED, 1, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871) using TTL 100`,
+				fmt.Sprintf(`DELETE FROM executions_by_bucket WHERE domain_id = test-domain-id AND is_open = true AND bucket = %d AND sort_time = 1712009321000 AND run_id = test-run-id`, openBucket),
+				`INSERT INTO executions_by_bucket (domain_id, is_open, bucket, sort_time, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) VALUES (test-domain-id, false, 19814, 1712009261000, test-workflow-id, test-run-id, 1712009321000, 1712009321000, 1712009261000, test-type-name, COMPLETED, 1, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871, map[]) using TTL 100`,
+				`INSERT INTO closed_execution_buckets (domain_id, bucket) VALUES (test-domain-id, 19814) using TTL 86500`,
 			},
 			wantErr:   false,
 			wantPanic: false,
@@ -131,6 +195,9 @@ func TestUpdateVisibility(t *testing.T) {
 				`DELETE FROM open_executions WHERE domain_id = test-domain-id AND domain_partition = 0 AND start_time = 1712009321000 AND run_id = test-run-id`,
 				`INSERT INTO closed_executions (domain_id, domain_partition,  workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time )VALUES (test-domain-id, 0, test-workflow-id, test-run-id, 1712009321000, 1712009321000, 1712009261000, test-type-name, COMPLETED, 1, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871)`,
 				`INSERT INTO closed_executions_v2 (domain_id, domain_partition,  workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time )VALUES (test-domain-id, 0, test-workflow-id, test-run-id, 1712009321000, 1712009321000, 1712009261000, test-type-name, COMPLETED, 1, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871)`,
+				fmt.Sprintf(`DELETE FROM executions_by_bucket WHERE domain_id = test-domain-id AND is_open = true AND bucket = %d AND sort_time = 1712009321000 AND run_id = test-run-id`, openBucket),
+				`INSERT INTO executions_by_bucket (domain_id, is_open, bucket, sort_time, workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) VALUES (test-domain-id, false, 19814, 1712009261000, test-workflow-id, test-run-id, 1712009321000, 1712009321000, 1712009261000, test-type-name, COMPLETED, 1, [], json, test-task-list, false, 1, 2024-04-01T22:08:41Z, 1, PENDING, , -6795364578871, map[])`,
+				`INSERT INTO closed_execution_buckets (domain_id, bucket) VALUES (test-domain-id, 19814)`,
 			},
 			wantErr:   false,
 			wantPanic: false,
@@ -276,6 +343,7 @@ func TestSelectOneClosedWorkflow(t *testing.T) {
 }
 
 func TestDeleteVisibility(t *testing.T) {
+	openBucket := nosqlplugin.OpenVisibilityBucket(testdata.RunID, nosqlplugin.DefaultNumOpenVisibilityBuckets)
 	tests := []struct {
 		desc           string
 		domainID       string
@@ -368,8 +436,8 @@ func TestDeleteVisibility(t *testing.T) {
 				itr.EXPECT().Close().Return(nil)
 			},
 			queryMockFunc: func(query *gocql.MockQuery) {
-				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(2)
-				query.EXPECT().Exec().Return(nil)
+				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(3)
+				query.EXPECT().Exec().Return(nil).Times(2)
 			},
 			context:        context.WithValue(context.Background(), persistence.VisibilityAdminDeletionKey("visibilityAdminDelete"), true),
 			dc:             nil,
@@ -377,6 +445,7 @@ func TestDeleteVisibility(t *testing.T) {
 			wantQueries: []string{
 				`SELECT  workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time FROM open_executions WHERE domain_id = test-domain-id AND domain_partition = 0 AND run_id = test-run-id ALLOW FILTERING`,
 				`DELETE FROM open_executions WHERE domain_id = test-domain-id and domain_partition = 0 and start_time = 0001-01-01T00:00:00Z and run_id = test-run-id `,
+				fmt.Sprintf(`DELETE FROM executions_by_bucket WHERE domain_id = test-domain-id AND is_open = true AND bucket = %d AND sort_time = 0001-01-01T00:00:00Z AND run_id = test-run-id`, openBucket),
 			},
 			wantError: false,
 		},
@@ -391,9 +460,9 @@ func TestDeleteVisibility(t *testing.T) {
 				itr.EXPECT().Close().Return(nil)
 			},
 			queryMockFunc: func(query *gocql.MockQuery) {
-				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(2)
-				query.EXPECT().Consistency(gomock.Any()).Return(query)
-				query.EXPECT().Exec().Return(nil)
+				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(3)
+				query.EXPECT().Consistency(gomock.Any()).Return(query).Times(2)
+				query.EXPECT().Exec().Return(nil).Times(2)
 			},
 			context: context.WithValue(context.Background(), persistence.VisibilityAdminDeletionKey("visibilityAdminDelete"), true),
 			dc: &persistence.DynamicConfiguration{EnableCassandraAllConsistencyLevelDelete: func(opts ...dynamicproperties.FilterOption) bool {
@@ -403,6 +472,7 @@ func TestDeleteVisibility(t *testing.T) {
 			wantQueries: []string{
 				`SELECT  workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time FROM open_executions WHERE domain_id = test-domain-id AND domain_partition = 0 AND run_id = test-run-id ALLOW FILTERING`,
 				`DELETE FROM open_executions WHERE domain_id = test-domain-id and domain_partition = 0 and start_time = 0001-01-01T00:00:00Z and run_id = test-run-id `,
+				fmt.Sprintf(`DELETE FROM executions_by_bucket WHERE domain_id = test-domain-id AND is_open = true AND bucket = %d AND sort_time = 0001-01-01T00:00:00Z AND run_id = test-run-id`, openBucket),
 			},
 			wantError: false,
 		},
@@ -413,11 +483,11 @@ func TestDeleteVisibility(t *testing.T) {
 			runID:      testdata.RunID,
 			context:    context.WithValue(context.Background(), persistence.VisibilityAdminDeletionKey("visibilityAdminDelete"), true),
 			queryMockFunc: func(query *gocql.MockQuery) {
-				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(2)
+				query.EXPECT().WithContext(gomock.Any()).Return(query).Times(3)
 				query.EXPECT().Consistency(gomock.Any()).Return(query)
 				query.EXPECT().Exec().Return(errors.New("all consistency level fail"))
-				query.EXPECT().Consistency(gomock.Any()).Return(query)
-				query.EXPECT().Exec().Return(nil)
+				query.EXPECT().Consistency(gomock.Any()).Return(query).Times(2)
+				query.EXPECT().Exec().Return(nil).Times(2)
 			},
 			mockItr: true,
 			itrMockFunc: func(itr *gocql.MockIter) {
@@ -433,6 +503,7 @@ func TestDeleteVisibility(t *testing.T) {
 			wantQueries: []string{
 				`SELECT  workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time FROM open_executions WHERE domain_id = test-domain-id AND domain_partition = 0 AND run_id = test-run-id ALLOW FILTERING`,
 				`DELETE FROM open_executions WHERE domain_id = test-domain-id and domain_partition = 0 and start_time = 0001-01-01T00:00:00Z and run_id = test-run-id `,
+				fmt.Sprintf(`DELETE FROM executions_by_bucket WHERE domain_id = test-domain-id AND is_open = true AND bucket = %d AND sort_time = 0001-01-01T00:00:00Z AND run_id = test-run-id`, openBucket),
 			},
 			wantError: false,
 		},
@@ -908,3 +979,166 @@ func generateMockParams(count int) []interface{} {
 	}
 	return params
 }
+
+func TestSelectVisibilityBucket(t *testing.T) {
+	startTime := time.Unix(1712009321, 0).UTC()
+	closeTime := startTime.Add(time.Minute)
+	tests := []struct {
+		desc        string
+		filter      *nosqlplugin.VisibilityBucketFilter
+		iter        *fakeIter
+		wantQueries []string
+		wantRows    []*nosqlplugin.VisibilityRow
+		wantErr     bool
+	}{
+		{
+			desc: "open records of the first page",
+			filter: &nosqlplugin.VisibilityBucketFilter{
+				DomainID:    testdata.DomainID,
+				IsOpen:      true,
+				Bucket:      3,
+				MinSortTime: time.Unix(0, 0),
+				MaxSortTime: startTime,
+				PageSize:    10,
+			},
+			iter: &fakeIter{
+				scanInputs: [][]interface{}{
+					{testdata.WorkflowID, testdata.RunID, startTime, nil, closeTime, nil, nil, int64(10), nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, map[string]string{"CustomKeywordField": `["a","b"]`}},
+				},
+			},
+			wantQueries: []string{
+				`SELECT  workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes FROM executions_by_bucket WHERE domain_id = test-domain-id AND is_open = true AND bucket = 3 AND (sort_time) >= (0) AND (sort_time) <= (1712009321000) LIMIT 10`,
+			},
+			wantRows: []*nosqlplugin.VisibilityRow{
+				{
+					WorkflowID: testdata.WorkflowID,
+					RunID:      testdata.RunID,
+					StartTime:  startTime,
+					SearchAttributes: map[string]interface{}{
+						"CustomKeywordField": []interface{}{"a", "b"},
+					},
+				},
+			},
+		},
+		{
+			desc: "closed records after a position",
+			filter: &nosqlplugin.VisibilityBucketFilter{
+				DomainID:    testdata.DomainID,
+				IsOpen:      false,
+				Bucket:      19814,
+				MinSortTime: time.Unix(0, 0),
+				MaxSortTime: closeTime,
+				PageAfter:   &nosqlplugin.VisibilitySortKey{SortTime: closeTime, RunID: "other-run-id"},
+				PageSize:    10,
+			},
+			iter: &fakeIter{
+				scanInputs: [][]interface{}{
+					{testdata.WorkflowID, testdata.RunID, startTime, nil, closeTime, nil, nil, int64(10)},
+				},
+			},
+			wantQueries: []string{
+				`SELECT  workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes FROM executions_by_bucket WHERE domain_id = test-domain-id AND is_open = false AND bucket = 19814 AND (sort_time) >= (0) AND (sort_time, run_id) < (1712009381000, other-run-id) LIMIT 10`,
+			},
+			wantRows: []*nosqlplugin.VisibilityRow{
+				{
+					WorkflowID:    testdata.WorkflowID,
+					RunID:         testdata.RunID,
+					StartTime:     startTime,
+					CloseTime:     closeTime,
+					Status:        types.WorkflowExecutionCloseStatusCompleted.Ptr(),
+					HistoryLength: 10,
+				},
+			},
+		},
+		{
+			desc: "return error if closing iterator fails",
+			filter: &nosqlplugin.VisibilityBucketFilter{
+				DomainID:    testdata.DomainID,
+				IsOpen:      true,
+				MinSortTime: time.Unix(0, 0),
+				MaxSortTime: startTime,
+				PageSize:    10,
+			},
+			iter: &fakeIter{closeErr: errors.New("close error")},
+			wantQueries: []string{
+				`SELECT  workflow_id, run_id, start_time, execution_time, close_time, workflow_type_name, status, history_length, memo, encoding, task_list, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes FROM executions_by_bucket WHERE domain_id = test-domain-id AND is_open = true AND bucket = 0 AND (sort_time) >= (0) AND (sort_time) <= (1712009321000) LIMIT 10`,
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			query := gocql.NewMockQuery(ctrl)
+			query.EXPECT().Consistency(gomock.Any()).Return(query)
+			query.EXPECT().WithContext(gomock.Any()).Return(query)
+			query.EXPECT().Iter().Return(test.iter)
+			session := &fakeSession{
+				query: query,
+			}
+			client := gocql.NewMockClient(ctrl)
+			cfg := &config.NoSQL{}
+			logger := testlogger.New(t)
+			db := NewCassandraDBFromSession(cfg, session, logger, &persistence.DynamicConfiguration{}, DbWithClient(client))
+
+			rows, err := db.SelectVisibilityBucket(context.Background(), test.filter)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantRows, rows)
+			}
+			assert.Equal(t, test.wantQueries, session.queries)
+			assert.True(t, test.iter.closed)
+		})
+	}
+}
+
+func TestSelectClosedVisibilityBuckets(t *testing.T) {
+	tests := []struct {
+		desc        string
+		iter        *fakeIter
+		wantBuckets []int64
+		wantErr     bool
+	}{
+		{
+			desc: "success",
+			iter: &fakeIter{
+				scanInputs: [][]interface{}{{int64(19814)}, {int64(19810)}},
+			},
+			wantBuckets: []int64{19814, 19810},
+		},
+		{
+			desc:    "return error if closing iterator fails",
+			iter:    &fakeIter{closeErr: errors.New("close error")},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.desc, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			query := gocql.NewMockQuery(ctrl)
+			query.EXPECT().Consistency(gomock.Any()).Return(query)
+			query.EXPECT().WithContext(gomock.Any()).Return(query)
+			query.EXPECT().Iter().Return(test.iter)
+			session := &fakeSession{
+				query: query,
+			}
+			client := gocql.NewMockClient(ctrl)
+			cfg := &config.NoSQL{}
+			logger := testlogger.New(t)
+			db := NewCassandraDBFromSession(cfg, session, logger, &persistence.DynamicConfiguration{}, DbWithClient(client))
+
+			buckets, err := db.SelectClosedVisibilityBuckets(context.Background(), testdata.DomainID, 19800, 19814)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantBuckets, buckets)
+			}
+			assert.Equal(t, []string{
+				`SELECT bucket FROM closed_execution_buckets WHERE domain_id = test-domain-id AND bucket >= 19800 AND bucket <= 19814`,
+			}, session.queries)
+		})
+	}
+}
@@ -22,15 +22,48 @@ package dynamodb
 
 import (
 	"context"
-	"errors"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
 )
 
 func (db *ddb) InsertConfig(ctx context.Context, row *persistence.InternalConfigStoreEntry) error {
-	return errors.New("TODO")
+	item, err := newItem(strconv.Itoa(row.RowType), encodeSortableInt64(row.Version), row)
+	if err != nil {
+		return err
+	}
+	b := newExpressionBuilder()
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(db.tableName(cadence.ClusterConfigTableName)),
+		Item:                      item,
+		ConditionExpression:       aws.String(attributeNotExists(b)),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	if db.IsConditionFailedError(err) {
+		return nosqlplugin.NewConditionFailure("InsertConfig operation failed because of version collision")
+	}
+	return err
 }
 
 func (db *ddb) SelectLatestConfig(ctx context.Context, rowType int) (*persistence.InternalConfigStoreEntry, error) {
-	return nil, errors.New("TODO")
+	input := db.partitionQuery(cadence.ClusterConfigTableName, strconv.Itoa(rowType))
+	input.ScanIndexForward = aws.Bool(false)
+	items, _, err := db.queryPage(ctx, input, 1, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, errItemNotFound
+	}
+	entry := &persistence.InternalConfigStoreEntry{}
+	if err := getData(items[0], entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
 }
@@ -21,36 +21,121 @@
 package dynamodb
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+
+	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
 )
 
 const (
-	// PluginName is the name of the plugin
-	PluginName = "dynamodb"
+	// endpointAttribute is the ConnectAttributes key to override the DynamoDB endpoint
+	endpointAttribute = "endpoint"
+	// defaultRegion is used when no region is configured, which is enough for DynamoDB Local
+	defaultRegion = "us-east-1"
+
+	batchWriteInitialInterval = 50 * time.Millisecond
+	batchWriteMaximumInterval = time.Second
+	batchWriteMaximumAttempts = 5
 )
 
 var (
 	errConditionFailed = errors.New("internal condition fail error")
+	// errItemNotFound is returned when a single item lookup doesn't find anything
+	errItemNotFound = errors.New("dynamodb item not found")
+	// errUnprocessedItems is returned when a batch write still has unprocessed items after all retries
+	errUnprocessedItems = errors.New("dynamodb batch write has unprocessed items")
 )
 
 // ddb represents a logical connection to DynamoDB database
 type ddb struct {
+	client dynamodbiface.DynamoDBAPI
+	cfg    *config.NoSQL
+	logger log.Logger
+	// batchWriteRetry retries the unprocessed items of batch writes, which DynamoDB returns when the table is throttled
+	batchWriteRetry *backoff.ThrottleRetry
 }
 
 var _ nosqlplugin.DB = (*ddb)(nil)
 
 // NewDynamoDB return a new DB
 func NewDynamoDB(cfg config.NoSQL, logger log.Logger) (nosqlplugin.DB, error) {
-	return nil, fmt.Errorf("TODO")
+	return newDynamoDB(&cfg, logger)
+}
+
+func newDynamoDB(cfg *config.NoSQL, logger log.Logger) (*ddb, error) {
+	if cfg.Keyspace == "" {
+		return nil, fmt.Errorf("table prefix(keyspace) cannot be empty")
+	}
+	awsConfig := aws.NewConfig().WithRegion(defaultRegion)
+	if cfg.Region != "" {
+		awsConfig = awsConfig.WithRegion(cfg.Region)
+	}
+	if endpoint := getEndpoint(cfg); endpoint != "" {
+		awsConfig = awsConfig.WithEndpoint(endpoint)
+	}
+	if cfg.User != "" {
+		awsConfig = awsConfig.WithCredentials(credentials.NewStaticCredentials(cfg.User, cfg.Password, ""))
+	}
+	if cfg.Timeout > 0 {
+		awsConfig = awsConfig.WithHTTPClient(&http.Client{Timeout: cfg.Timeout})
+	}
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &ddb{
+		client:          dynamodb.New(sess),
+		cfg:             cfg,
+		logger:          logger,
+		batchWriteRetry: newBatchWriteRetry(defaultBatchWriteRetryPolicy()),
+	}, nil
+}
+
+func defaultBatchWriteRetryPolicy() backoff.RetryPolicy {
+	policy := backoff.NewExponentialRetryPolicy(batchWriteInitialInterval)
+	policy.SetMaximumInterval(batchWriteMaximumInterval)
+	policy.SetMaximumAttempts(batchWriteMaximumAttempts)
+	return policy
+}
+
+func newBatchWriteRetry(policy backoff.RetryPolicy) *backoff.ThrottleRetry {
+	return backoff.NewThrottleRetry(
+		backoff.WithRetryPolicy(policy),
+		backoff.WithRetryableError(func(err error) bool {
+			return errors.Is(err, errUnprocessedItems)
+		}),
+	)
+}
+
+// getEndpoint returns the endpoint to connect to. An explicit endpoint attribute always wins,
+// otherwise hosts+port is used which is the setup of DynamoDB Local. Leaving both empty
+// makes the SDK resolve the regional AWS endpoint.
+func getEndpoint(cfg *config.NoSQL) string {
+	if endpoint := cfg.ConnectAttributes[endpointAttribute]; endpoint != "" {
+		return endpoint
+	}
+	if cfg.Hosts != "" && cfg.Port != 0 {
+		return fmt.Sprintf("http://%v:%v", cfg.Hosts, cfg.Port)
+	}
+	return ""
 }
 
 func (db *ddb) Close() {
-	panic("TODO")
+	// the AWS client is stateless over HTTP, there is nothing to release
 }
 
 func (db *ddb) PluginName() string {
@@ -58,21 +143,48 @@ func (db *ddb) PluginName() string {
 }
 
 func (db *ddb) IsNotFoundError(err error) bool {
-	panic("TODO")
+	if errors.Is(err, errItemNotFound) {
+		return true
+	}
+	return errorCode(err) == dynamodb.ErrCodeResourceNotFoundException
 }
 
 func (db *ddb) IsTimeoutError(err error) bool {
-	panic("TODO")
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	return errorCode(err) == request.CanceledErrorCode
 }
 
 func (db *ddb) IsThrottlingError(err error) bool {
-	panic("TODO")
+	if errors.Is(err, errUnprocessedItems) {
+		return true
+	}
+	switch errorCode(err) {
+	case dynamodb.ErrCodeProvisionedThroughputExceededException,
+		dynamodb.ErrCodeRequestLimitExceeded,
+		"ThrottlingException":
+		return true
+	}
+	return false
 }
 
 func (db *ddb) IsDBUnavailableError(err error) bool {
-	panic("TODO")
+	switch errorCode(err) {
+	case dynamodb.ErrCodeInternalServerError, "ServiceUnavailable":
+		return true
+	}
+	return false
 }
 
 func (db *ddb) IsConditionFailedError(err error) bool {
-	return err == errConditionFailed
+	return err == errConditionFailed || errorCode(err) == dynamodb.ErrCodeConditionalCheckFailedException
+}
+
+func errorCode(err error) string {
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		return awsErr.Code()
+	}
+	return ""
 }
@@ -0,0 +1,166 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/log/testlogger"
+)
+
+func TestNewDynamoDB(t *testing.T) {
+	_, err := NewDynamoDB(config.NoSQL{}, testlogger.New(t))
+	assert.Error(t, err, "keyspace is required")
+
+	db, err := NewDynamoDB(config.NoSQL{Keyspace: "cadence", Hosts: "127.0.0.1", Port: 8000}, testlogger.New(t))
+	assert.NoError(t, err)
+	assert.Equal(t, PluginName, db.PluginName())
+	db.Close()
+}
+
+func TestGetEndpoint(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.NoSQL
+		want string
+	}{
+		{
+			name: "aws endpoint",
+			cfg:  config.NoSQL{},
+			want: "",
+		},
+		{
+			name: "host without port",
+			cfg:  config.NoSQL{Hosts: "127.0.0.1"},
+			want: "",
+		},
+		{
+			name: "host and port",
+			cfg:  config.NoSQL{Hosts: "127.0.0.1", Port: 8000},
+			want: "http://127.0.0.1:8000",
+		},
+		{
+			name: "explicit endpoint",
+			cfg: config.NoSQL{
+				Hosts:             "127.0.0.1",
+				Port:              8000,
+				ConnectAttributes: map[string]string{endpointAttribute: "https://dynamodb.example.com"},
+			},
+			want: "https://dynamodb.example.com",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, getEndpoint(&tc.cfg))
+		})
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	awsErr := func(code string) error {
+		return fmt.Errorf("wrapped: %w", awserr.New(code, "message", nil))
+	}
+	db := &ddb{}
+	tests := []struct {
+		name            string
+		err             error
+		notFound        bool
+		timeout         bool
+		throttling      bool
+		unavailable     bool
+		conditionFailed bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "unrelated",
+			err:  errors.New("some error"),
+		},
+		{
+			name:     "item not found",
+			err:      errItemNotFound,
+			notFound: true,
+		},
+		{
+			name:     "table not found",
+			err:      awsErr(dynamodb.ErrCodeResourceNotFoundException),
+			notFound: true,
+		},
+		{
+			name:    "deadline exceeded",
+			err:     context.DeadlineExceeded,
+			timeout: true,
+		},
+		{
+			name:    "request canceled",
+			err:     awsErr(request.CanceledErrorCode),
+			timeout: true,
+		},
+		{
+			name:       "provisioned throughput",
+			err:        awsErr(dynamodb.ErrCodeProvisionedThroughputExceededException),
+			throttling: true,
+		},
+		{
+			name:       "request limit",
+			err:        awsErr(dynamodb.ErrCodeRequestLimitExceeded),
+			throttling: true,
+		},
+		{
+			name:       "unprocessed batch write items",
+			err:        fmt.Errorf("wrapped: %w", errUnprocessedItems),
+			throttling: true,
+		},
+		{
+			name:        "internal server error",
+			err:         awsErr(dynamodb.ErrCodeInternalServerError),
+			unavailable: true,
+		},
+		{
+			name:            "condition check",
+			err:             awsErr(dynamodb.ErrCodeConditionalCheckFailedException),
+			conditionFailed: true,
+		},
+		{
+			name:            "internal condition failure",
+			err:             errConditionFailed,
+			conditionFailed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.notFound, db.IsNotFoundError(tc.err))
+			assert.Equal(t, tc.timeout, db.IsTimeoutError(tc.err))
+			assert.Equal(t, tc.throttling, db.IsThrottlingError(tc.err))
+			assert.Equal(t, tc.unavailable, db.IsDBUnavailableError(tc.err))
+			assert.Equal(t, tc.conditionFailed, db.IsConditionFailedError(tc.err))
+		})
+	}
+}
@@ -22,17 +22,83 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
+)
+
+const (
+	// all domain items are stored in a single partition so that they can be listed in one query
+	domainPartition         = "domains"
+	domainByNamePrefix      = "name"
+	domainByIDPrefix        = "id"
+	domainMetadataSortKey   = "metadata"
+	attrDomainName          = "name"
+	attrNotificationVersion = "notification_version"
 )
 
+func domainByNameKey(name string) map[string]*dynamodb.AttributeValue {
+	return primaryKey(domainPartition, compositeKey(domainByNamePrefix, name))
+}
+
+func domainByIDKey(id string) map[string]*dynamodb.AttributeValue {
+	return primaryKey(domainPartition, compositeKey(domainByIDPrefix, id))
+}
+
 // Insert a new record to domain, return error if failed or already exists
 // Return ConditionFailure if the condition doesn't meet
 func (db *ddb) InsertDomain(
 	ctx context.Context,
 	row *nosqlplugin.DomainRow,
 ) error {
-	panic("TODO")
+	metadataNotificationVersion, err := db.SelectDomainMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	newRow := *row
+	newRow.FailoverNotificationVersion = persistence.InitialFailoverNotificationVersion
+	newRow.PreviousFailoverVersion = constants.InitialPreviousFailoverVersion
+	newRow.NotificationVersion = metadataNotificationVersion
+	nameItem, err := newDomainItem(&newRow)
+	if err != nil {
+		return err
+	}
+	idItem := domainByIDKey(row.Info.ID)
+	idItem[attrDomainName] = stringAttr(row.Info.Name)
+
+	items := make([]*dynamodb.TransactWriteItem, 0, 3)
+	b := newExpressionBuilder()
+	items = append(items, db.putTransactItem(cadence.DomainsTableName, idItem, attributeNotExists(b), b))
+	b = newExpressionBuilder()
+	items = append(items, db.putTransactItem(cadence.DomainsTableName, nameItem, attributeNotExists(b), b))
+	items = append(items, db.updateDomainMetadataTransactItem(metadataNotificationVersion))
+
+	failures, err := db.transactWrite(ctx, items)
+	if err != nil {
+		return err
+	}
+	switch {
+	case failures.failed(1):
+		db.logger.Warn("Domain already exists")
+		return &types.DomainAlreadyExistsError{
+			Message: fmt.Sprintf("Domain %v already exists", row.Info.Name),
+		}
+	case failures.failed(0):
+		return fmt.Errorf("CreateDomain operation failed because of uuid collision")
+	case len(failures) > 0:
+		db.logger.Warn("Create domain operation failed because of condition update failure on domain metadata record")
+		return nosqlplugin.NewConditionFailure("domain")
+	}
+	return nil
 }
 
 // Update domain
@@ -40,7 +106,22 @@ func (db *ddb) UpdateDomain(
 	ctx context.Context,
 	row *nosqlplugin.DomainRow,
 ) error {
-	panic("TODO")
+	nameItem, err := newDomainItem(row)
+	if err != nil {
+		return err
+	}
+	b := newExpressionBuilder()
+	failures, err := db.transactWrite(ctx, []*dynamodb.TransactWriteItem{
+		db.putTransactItem(cadence.DomainsTableName, nameItem, fmt.Sprintf("attribute_exists(%v)", b.name(attrPK)), b),
+		db.updateDomainMetadataTransactItem(row.NotificationVersion),
+	})
+	if err != nil {
+		return err
+	}
+	if len(failures) > 0 {
+		return nosqlplugin.NewConditionFailure("domain")
+	}
+	return nil
 }
 
 // Get one domain data, either by domainID or domainName
@@ -49,7 +130,25 @@ func (db *ddb) SelectDomain(
 	domainID *string,
 	domainName *string,
 ) (*nosqlplugin.DomainRow, error) {
-	panic("TODO")
+	if domainID != nil && domainName != nil {
+		return nil, fmt.Errorf("GetDomain operation failed.  Both ID and Name specified in request")
+	} else if domainID == nil && domainName == nil {
+		return nil, fmt.Errorf("GetDomain operation failed.  Both ID and Name are empty")
+	}
+
+	if domainID != nil {
+		item, err := db.getItem(ctx, cadence.DomainsTableName, domainByIDKey(*domainID))
+		if err != nil {
+			return nil, err
+		}
+		name := getString(item, attrDomainName)
+		domainName = &name
+	}
+	item, err := db.getItem(ctx, cadence.DomainsTableName, domainByNameKey(*domainName))
+	if err != nil {
+		return nil, err
+	}
+	return parseDomainItem(item)
 }
 
 // Get all domain data
@@ -58,7 +157,26 @@ func (db *ddb) SelectAllDomains(
 	pageSize int,
 	pageToken []byte,
 ) ([]*nosqlplugin.DomainRow, []byte, error) {
-	panic("TODO")
+	b := newExpressionBuilder()
+	items, nextPageToken, err := db.queryPage(ctx, &dynamodb.QueryInput{
+		TableName: aws.String(db.tableName(cadence.DomainsTableName)),
+		KeyConditionExpression: aws.String(fmt.Sprintf("%v = %v AND begins_with(%v, %v)",
+			b.name(attrPK), b.value(stringAttr(domainPartition)), b.name(attrSK), b.value(stringAttr(domainByNamePrefix+keySeparator)))),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	}, pageSize, pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]*nosqlplugin.DomainRow, 0, len(items))
+	for _, item := range items {
+		row, err := parseDomainItem(item)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nextPageToken, nil
 }
 
 // Delete a domain, either by domainID or domainName
@@ -67,11 +185,77 @@ func (db *ddb) DeleteDomain(
 	domainID *string,
 	domainName *string,
 ) error {
-	panic("TODO")
+	if domainName == nil && domainID == nil {
+		return fmt.Errorf("must provide either domainID or domainName")
+	}
+
+	if domainName == nil {
+		item, err := db.getItem(ctx, cadence.DomainsTableName, domainByIDKey(*domainID))
+		if err != nil {
+			if db.IsNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+		name := getString(item, attrDomainName)
+		domainName = &name
+	} else {
+		row, err := db.SelectDomain(ctx, nil, domainName)
+		if err != nil {
+			if db.IsNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+		domainID = &row.Info.ID
+	}
+	return db.batchDelete(ctx, cadence.DomainsTableName, []map[string]*dynamodb.AttributeValue{
+		domainByNameKey(*domainName),
+		domainByIDKey(*domainID),
+	})
 }
 
 func (db *ddb) SelectDomainMetadata(
 	ctx context.Context,
 ) (int64, error) {
-	panic("TODO")
+	item, err := db.getItem(ctx, cadence.DomainsTableName, primaryKey(domainPartition, domainMetadataSortKey))
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			// the metadata record is created by the first domain
+			return 0, nil
+		}
+		return -1, err
+	}
+	return getInt64(item, attrNotificationVersion)
+}
+
+// updateDomainMetadataTransactItem bumps the notification version, conditioned on the current version
+func (db *ddb) updateDomainMetadataTransactItem(notificationVersion int64) *dynamodb.TransactWriteItem {
+	b := newExpressionBuilder()
+	condition := fmt.Sprintf("attribute_not_exists(%v)", b.name(attrNotificationVersion))
+	if notificationVersion > 0 {
+		condition = attributeEquals(b, attrNotificationVersion, numberAttr(notificationVersion))
+	}
+	update := fmt.Sprintf("SET %v = %v", b.name(attrNotificationVersion), b.value(numberAttr(notificationVersion+1)))
+	return db.updateTransactItem(cadence.DomainsTableName, primaryKey(domainPartition, domainMetadataSortKey), update, condition, b)
+}
+
+func newDomainItem(row *nosqlplugin.DomainRow) (map[string]*dynamodb.AttributeValue, error) {
+	key := domainByNameKey(row.Info.Name)
+	item, err := newItem(*key[attrPK].S, *key[attrSK].S, row)
+	if err != nil {
+		return nil, err
+	}
+	item[attrDomainID] = stringAttr(row.Info.ID)
+	return item, nil
+}
+
+func parseDomainItem(item map[string]*dynamodb.AttributeValue) (*nosqlplugin.DomainRow, error) {
+	row := &nosqlplugin.DomainRow{}
+	if err := getData(item, row); err != nil {
+		return nil, err
+	}
+	// CurrentTimeStamp is the time of the request, not part of the domain
+	row.CurrentTimeStamp = time.Time{}
+	return row, nil
 }
@@ -22,16 +22,73 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
 )
 
+func domainAuditLogPartitionKey(domainID string, operationType int) string {
+	return compositeKey(domainID, strconv.Itoa(operationType))
+}
+
 // InsertDomainAuditLog inserts a new audit log entry for a domain operation
 func (db *ddb) InsertDomainAuditLog(ctx context.Context, row *nosqlplugin.DomainAuditLogRow) error {
-	panic("TODO: InsertDomainAuditLog not implemented")
+	item, err := newItem(
+		domainAuditLogPartitionKey(row.DomainID, int(row.OperationType)),
+		compositeKey(encodeSortableTime(row.CreatedTime), row.EventID),
+		row,
+	)
+	if err != nil {
+		return err
+	}
+	if row.TTLSeconds > 0 {
+		item[attrTTL] = ttlAttr(row.CreatedTime, row.TTLSeconds)
+	}
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.tableName(cadence.DomainAuditLogTableName)),
+		Item:      item,
+	})
+	return err
 }
 
 // SelectDomainAuditLogs returns audit log entries for a domain and operation type
 func (db *ddb) SelectDomainAuditLogs(ctx context.Context, filter *nosqlplugin.DomainAuditLogFilter) ([]*nosqlplugin.DomainAuditLogRow, []byte, error) {
-	panic("TODO: SelectDomainAuditLogs not implemented")
+	if filter.MinCreatedTime == nil || filter.MaxCreatedTime == nil {
+		return nil, nil, &types.InternalServiceError{
+			Message: "SelectDomainAuditLogs requires non-nil MinCreatedTime and MaxCreatedTime",
+		}
+	}
+	if !filter.MinCreatedTime.Before(*filter.MaxCreatedTime) {
+		return nil, nil, nil
+	}
+	// the encoded MaxCreatedTime alone sorts before all the entries created at MaxCreatedTime, which makes it exclusive
+	input := db.rangeQuery(
+		cadence.DomainAuditLogTableName,
+		domainAuditLogPartitionKey(filter.DomainID, int(filter.OperationType)),
+		encodeSortableTime(*filter.MinCreatedTime),
+		encodeSortableTime(*filter.MaxCreatedTime),
+	)
+	input.ProjectionExpression = nil
+	// newest first, same as the clustering order of Cassandra
+	input.ScanIndexForward = aws.Bool(false)
+	items, nextPageToken, err := db.queryPage(ctx, input, filter.PageSize, filter.NextPageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]*nosqlplugin.DomainAuditLogRow, 0, len(items))
+	for _, item := range items {
+		row := &nosqlplugin.DomainAuditLogRow{}
+		if err := getData(item, row); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse domain audit log: %v", err)
+		}
+		row.TTLSeconds = 0
+		rows = append(rows, row)
+	}
+	return rows, nextPageToken, nil
 }
@@ -22,31 +22,199 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
+)
+
+const (
+	attrTxnID        = "txn_id"
+	attrDataEncoding = "data_encoding"
 )
 
+func historyNodePartitionKey(treeID, branchID string) string {
+	return compositeKey(treeID, branchID)
+}
+
+// historyNodeSortKey orders the nodes by node_id ASC, txn_id DESC
+func historyNodeSortKey(nodeID, txnID int64) string {
+	return compositeKey(encodeSortableInt64(nodeID), encodeSortableInt64(-txnID))
+}
+
 // InsertIntoHistoryTreeAndNode inserts one or two rows: tree row and node row(at least one of them)
 func (db *ddb) InsertIntoHistoryTreeAndNode(ctx context.Context, treeRow *nosqlplugin.HistoryTreeRow, nodeRow *nosqlplugin.HistoryNodeRow) error {
-	panic("TODO")
+	if treeRow == nil && nodeRow == nil {
+		return fmt.Errorf("require at least a tree row or a node row to insert")
+	}
+
+	var items []*dynamodb.TransactWriteItem
+	if treeRow != nil {
+		item, err := newItem(treeRow.TreeID, treeRow.BranchID, treeRow)
+		if err != nil {
+			return err
+		}
+		items = append(items, db.putTransactItem(cadence.HistoryTreeTableName, item, "", nil))
+	}
+	if nodeRow != nil {
+		var txnID int64
+		if nodeRow.TxnID != nil {
+			txnID = *nodeRow.TxnID
+		}
+		item := primaryKey(historyNodePartitionKey(nodeRow.TreeID, nodeRow.BranchID), historyNodeSortKey(nodeRow.NodeID, txnID))
+		item[attrTxnID] = numberAttr(txnID)
+		item[attrData] = binaryAttr(nodeRow.Data)
+		item[attrDataEncoding] = stringAttr(nodeRow.DataEncoding)
+		items = append(items, db.putTransactItem(cadence.HistoryNodeTableName, item, "", nil))
+	}
+
+	if len(items) == 1 {
+		// prefer a single write which is cheaper than a transaction
+		if err := checkItemSize(aws.StringValue(items[0].Put.TableName), items[0].Put.Item); err != nil {
+			return err
+		}
+		_, err := db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+			TableName: items[0].Put.TableName,
+			Item:      items[0].Put.Item,
+		})
+		return itemSizeLimitError(err)
+	}
+	_, err := db.transactWrite(ctx, items)
+	return err
+}
+
+// UpdateHistoryNode replaces the data and encoding of an existing node row
+func (db *ddb) UpdateHistoryNode(ctx context.Context, nodeRow *nosqlplugin.HistoryNodeRow) error {
+	var txnID int64
+	if nodeRow.TxnID != nil {
+		txnID = *nodeRow.TxnID
+	}
+	item := primaryKey(historyNodePartitionKey(nodeRow.TreeID, nodeRow.BranchID), historyNodeSortKey(nodeRow.NodeID, txnID))
+	item[attrTxnID] = numberAttr(txnID)
+	item[attrData] = binaryAttr(nodeRow.Data)
+	item[attrDataEncoding] = stringAttr(nodeRow.DataEncoding)
+	if err := checkItemSize(cadence.HistoryNodeTableName, item); err != nil {
+		return err
+	}
+	b := newExpressionBuilder()
+	_, err := db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(db.tableName(cadence.HistoryNodeTableName)),
+		Item:                      item,
+		ConditionExpression:       aws.String(fmt.Sprintf("attribute_exists(%v)", b.name(attrPK))),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	if db.IsConditionFailedError(err) {
+		// the node was deleted with its branch meanwhile, there is nothing to update
+		return nil
+	}
+	return err
 }
 
 // SelectFromHistoryNode read nodes based on a filter
 func (db *ddb) SelectFromHistoryNode(ctx context.Context, filter *nosqlplugin.HistoryNodeFilter) ([]*nosqlplugin.HistoryNodeRow, []byte, error) {
-	panic("TODO")
+	if filter.MaxNodeID <= filter.MinNodeID {
+		return nil, nil, nil
+	}
+	// the encoded MaxNodeID alone sorts before all the nodes of MaxNodeID, which makes the range [MinNodeID, MaxNodeID)
+	input := db.rangeQuery(
+		cadence.HistoryNodeTableName,
+		historyNodePartitionKey(filter.TreeID, filter.BranchID),
+		encodeSortableInt64(filter.MinNodeID),
+		encodeSortableInt64(filter.MaxNodeID),
+	)
+	input.ProjectionExpression = nil
+	items, nextPageToken, err := db.queryPage(ctx, input, filter.PageSize, filter.NextPageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]*nosqlplugin.HistoryNodeRow, 0, len(items))
+	for _, item := range items {
+		nodeID, err := decodeSortableInt64(strings.SplitN(getString(item, attrSK), keySeparator, 2)[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		txnID, err := getInt64(item, attrTxnID)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows = append(rows, &nosqlplugin.HistoryNodeRow{
+			ShardID:      filter.ShardID,
+			TreeID:       filter.TreeID,
+			BranchID:     filter.BranchID,
+			NodeID:       nodeID,
+			TxnID:        &txnID,
+			Data:         item[attrData].B,
+			DataEncoding: getString(item, attrDataEncoding),
+		})
+	}
+	return rows, nextPageToken, nil
 }
 
 // DeleteFromHistoryTreeAndNode delete a branch record, and a list of ranges of nodes.
 func (db *ddb) DeleteFromHistoryTreeAndNode(ctx context.Context, treeFilter *nosqlplugin.HistoryTreeFilter, nodeFilters []*nosqlplugin.HistoryNodeFilter) error {
-	panic("TODO")
+	// delete the nodes first so that a failure can be retried from the branch record
+	for _, nodeFilter := range nodeFilters {
+		b := newExpressionBuilder()
+		if _, err := db.deleteByQuery(ctx, cadence.HistoryNodeTableName, &dynamodb.QueryInput{
+			TableName: aws.String(db.tableName(cadence.HistoryNodeTableName)),
+			KeyConditionExpression: aws.String(fmt.Sprintf("%v = %v AND %v >= %v",
+				b.name(attrPK), b.value(stringAttr(historyNodePartitionKey(nodeFilter.TreeID, nodeFilter.BranchID))),
+				b.name(attrSK), b.value(stringAttr(encodeSortableInt64(nodeFilter.MinNodeID))))),
+			ProjectionExpression:      aws.String(b.name(attrPK) + ", " + b.name(attrSK)),
+			ExpressionAttributeNames:  b.attributeNames(),
+			ExpressionAttributeValues: b.attributeValues(),
+		}); err != nil {
+			return err
+		}
+	}
+	branchID := ""
+	if treeFilter.BranchID != nil {
+		branchID = *treeFilter.BranchID
+	}
+	_, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTreeTableName)),
+		Key:       primaryKey(treeFilter.TreeID, branchID),
+	})
+	return err
 }
 
 // SelectAllHistoryTrees will return all tree branches with pagination
 func (db *ddb) SelectAllHistoryTrees(ctx context.Context, nextPageToken []byte, pageSize int) ([]*nosqlplugin.HistoryTreeRow, []byte, error) {
-	panic("TODO")
+	items, token, err := db.scanPage(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTreeTableName)),
+	}, pageSize, nextPageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := parseHistoryTreeRows(items)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rows, token, nil
 }
 
 // SelectFromHistoryTree read branch records for a tree
 func (db *ddb) SelectFromHistoryTree(ctx context.Context, filter *nosqlplugin.HistoryTreeFilter) ([]*nosqlplugin.HistoryTreeRow, error) {
-	panic("TODO")
+	items, err := db.queryAll(ctx, db.partitionQuery(cadence.HistoryTreeTableName, filter.TreeID))
+	if err != nil {
+		return nil, err
+	}
+	return parseHistoryTreeRows(items)
+}
+
+func parseHistoryTreeRows(items []map[string]*dynamodb.AttributeValue) ([]*nosqlplugin.HistoryTreeRow, error) {
+	rows := make([]*nosqlplugin.HistoryTreeRow, 0, len(items))
+	for _, item := range items {
+		row := &nosqlplugin.HistoryTreeRow{}
+		if err := getData(item, row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
 }
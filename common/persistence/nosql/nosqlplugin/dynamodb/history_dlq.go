@@ -23,27 +23,134 @@ package dynamodb
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
 )
 
+func historyDLQTaskPartitionKey(shardID int, domainID, clusterAttributeScope, clusterAttributeName string, taskType int) string {
+	return compositeKey(shardKey(shardID), domainID, clusterAttributeScope, clusterAttributeName, strconv.Itoa(taskType))
+}
+
+func historyDLQAckLevelSortKey(row *nosqlplugin.HistoryDLQAckLevelRow) string {
+	return compositeKey(row.DomainID, row.ClusterAttributeScope, row.ClusterAttributeName, strconv.Itoa(row.TaskType))
+}
+
 // InsertHistoryDLQTaskRow writes a task to the history DLQ.
 func (db *ddb) InsertHistoryDLQTaskRow(ctx context.Context, task *nosqlplugin.HistoryDLQTaskRow) error {
-	return fmt.Errorf("InsertHistoryDLQTaskRow not implemented for DynamoDB")
+	item, err := newItem(
+		historyDLQTaskPartitionKey(task.ShardID, task.DomainID, task.ClusterAttributeScope, task.ClusterAttributeName, task.TaskType),
+		timerTaskSortKey(task.VisibilityTimestamp, task.TaskID),
+		task,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTaskDLQTableName)),
+		Item:      item,
+	})
+	return err
 }
 
+// SelectHistoryDLQTaskRows reads paginated tasks from the history DLQ within [min, max) of (visibility_ts, task_id).
 func (db *ddb) SelectHistoryDLQTaskRows(ctx context.Context, filter nosqlplugin.HistoryDLQTaskFilter) ([]*nosqlplugin.HistoryDLQTaskRow, []byte, error) {
-	return nil, nil, fmt.Errorf("SelectHistoryDLQTaskRows not implemented for DynamoDB")
+	from := timerTaskSortKey(filter.InclusiveMinVisibilityTS, filter.InclusiveMinTaskID)
+	if filter.ExclusiveMaxTaskID == math.MinInt64 {
+		return nil, nil, nil
+	}
+	to := timerTaskSortKey(filter.ExclusiveMaxVisibilityTS, filter.ExclusiveMaxTaskID-1)
+	if to < from {
+		return nil, nil, nil
+	}
+	input := db.rangeQuery(
+		cadence.HistoryTaskDLQTableName,
+		historyDLQTaskPartitionKey(filter.ShardID, filter.DomainID, filter.ClusterAttributeScope, filter.ClusterAttributeName, filter.TaskType),
+		from,
+		to,
+	)
+	input.ProjectionExpression = nil
+	items, nextPageToken, err := db.queryPage(ctx, input, filter.PageSize, filter.NextPageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]*nosqlplugin.HistoryDLQTaskRow, 0, len(items))
+	for _, item := range items {
+		row := &nosqlplugin.HistoryDLQTaskRow{}
+		if err := getData(item, row); err != nil {
+			return nil, nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nextPageToken, nil
 }
 
+// RangeDeleteHistoryDLQTaskRows deletes all tasks before the exclusive (visibility_ts, task_id) bound.
 func (db *ddb) RangeDeleteHistoryDLQTaskRows(ctx context.Context, filter nosqlplugin.HistoryDLQTaskRangeDeleteFilter) error {
-	return fmt.Errorf("RangeDeleteHistoryDLQTaskRows not implemented for DynamoDB")
+	b := newExpressionBuilder()
+	pk := historyDLQTaskPartitionKey(filter.ShardID, filter.DomainID, filter.ClusterAttributeScope, filter.ClusterAttributeName, filter.TaskType)
+	_, err := db.deleteByQuery(ctx, cadence.HistoryTaskDLQTableName, &dynamodb.QueryInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTaskDLQTableName)),
+		KeyConditionExpression: aws.String(fmt.Sprintf("%v = %v AND %v < %v",
+			b.name(attrPK), b.value(stringAttr(pk)),
+			b.name(attrSK), b.value(stringAttr(timerTaskSortKey(filter.ExclusiveMaxVisibilityTS, filter.ExclusiveMaxTaskID))))),
+		ProjectionExpression:      aws.String(b.name(attrPK) + ", " + b.name(attrSK)),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	return err
 }
 
+// SelectHistoryDLQAckLevelRows reads ack-level rows for a shard.
+// If domainID is non-empty the query is restricted to that domain.
+// If clusterAttributeScope and clusterAttributeName are also non-empty it is
+// further restricted to that cluster attribute.
 func (db *ddb) SelectHistoryDLQAckLevelRows(ctx context.Context, filter nosqlplugin.HistoryDLQAckLevelFilter) ([]*nosqlplugin.HistoryDLQAckLevelRow, error) {
-	return nil, fmt.Errorf("SelectHistoryDLQAckLevelRows not implemented for DynamoDB")
+	var prefix string
+	switch {
+	case filter.DomainID != "" && filter.ClusterAttributeScope != "" && filter.ClusterAttributeName != "":
+		prefix = compositeKey(filter.DomainID, filter.ClusterAttributeScope, filter.ClusterAttributeName) + keySeparator
+	case filter.DomainID != "":
+		prefix = filter.DomainID + keySeparator
+	}
+
+	input := db.partitionQuery(cadence.HistoryTaskDLQAckLevelTableName, shardKey(filter.ShardID))
+	if prefix != "" {
+		b := newExpressionBuilder()
+		input.KeyConditionExpression = aws.String(fmt.Sprintf("%v = %v AND begins_with(%v, %v)",
+			b.name(attrPK), b.value(stringAttr(shardKey(filter.ShardID))), b.name(attrSK), b.value(stringAttr(prefix))))
+		input.ExpressionAttributeNames = b.attributeNames()
+		input.ExpressionAttributeValues = b.attributeValues()
+	}
+	items, err := db.queryAll(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*nosqlplugin.HistoryDLQAckLevelRow, 0, len(items))
+	for _, item := range items {
+		row := &nosqlplugin.HistoryDLQAckLevelRow{}
+		if err := getData(item, row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
 }
 
+// InsertOrUpdateHistoryDLQAckLevelRow upserts a single ack-level row.
 func (db *ddb) InsertOrUpdateHistoryDLQAckLevelRow(ctx context.Context, row *nosqlplugin.HistoryDLQAckLevelRow) error {
-	return fmt.Errorf("InsertOrUpdateHistoryDLQAckLevelRow not implemented for DynamoDB")
+	item, err := newItem(shardKey(row.ShardID), historyDLQAckLevelSortKey(row), row)
+	if err != nil {
+		return err
+	}
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTaskDLQAckLevelTableName)),
+		Item:      item,
+	})
+	return err
 }
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package dynamodb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	// maxItemSize is the DynamoDB limit of the size of an item, including the attribute names
+	maxItemSize = 400 * 1024
+
+	cancellationReasonValidationError = "ValidationError"
+	errCodeValidationException        = "ValidationException"
+	itemSizeErrorMessage              = "Item size"
+)
+
+// itemSize returns the size of an item as DynamoDB accounts it against maxItemSize
+func itemSize(item map[string]*dynamodb.AttributeValue) int {
+	size := 0
+	for name, value := range item {
+		size += len(name) + attributeValueSize(value)
+	}
+	return size
+}
+
+// attributeValueSize returns the size of a value, numbers are counted by their length
+// which is an upper bound of their encoded size
+func attributeValueSize(v *dynamodb.AttributeValue) int {
+	if v == nil {
+		return 0
+	}
+	switch {
+	case v.S != nil:
+		return len(*v.S)
+	case v.N != nil:
+		return len(*v.N)
+	case v.B != nil:
+		return len(v.B)
+	case v.BOOL != nil, v.NULL != nil:
+		return 1
+	case v.M != nil:
+		size := 3
+		for name, value := range v.M {
+			size += 1 + len(name) + attributeValueSize(value)
+		}
+		return size
+	case v.L != nil:
+		size := 3
+		for _, value := range v.L {
+			size += 1 + attributeValueSize(value)
+		}
+		return size
+	}
+	size := 0
+	for _, s := range v.SS {
+		size += len(*s)
+	}
+	for _, n := range v.NS {
+		size += len(*n)
+	}
+	for _, b := range v.BS {
+		size += len(b)
+	}
+	return size
+}
+
+// checkItemSize rejects an item which DynamoDB would not store.
+// Other stores accept much larger rows, events are only guaranteed to fit when
+// system.transactionSizeLimit is configured below maxItemSize.
+func checkItemSize(table string, item map[string]*dynamodb.AttributeValue) error {
+	if size := itemSize(item); size > maxItemSize {
+		return &persistence.TransactionSizeLimitError{
+			Msg: fmt.Sprintf("%v item of %v bytes exceeds the DynamoDB item size limit of %v bytes", table, size, maxItemSize),
+		}
+	}
+	return nil
+}
+
+// itemSizeLimitError translates the error of DynamoDB rejecting an item which grows over maxItemSize,
+// e.g. by an update expression, into TransactionSizeLimitError. Other errors are returned unchanged.
+func itemSizeLimitError(err error) error {
+	var cancelled *dynamodb.TransactionCanceledException
+	if errors.As(err, &cancelled) {
+		for _, reason := range cancelled.CancellationReasons {
+			if aws.StringValue(reason.Code) == cancellationReasonValidationError &&
+				strings.Contains(aws.StringValue(reason.Message), itemSizeErrorMessage) {
+				return &persistence.TransactionSizeLimitError{Msg: aws.StringValue(reason.Message)}
+			}
+		}
+		return err
+	}
+	if errorCode(err) == errCodeValidationException && strings.Contains(err.Error(), itemSizeErrorMessage) {
+		return &persistence.TransactionSizeLimitError{Msg: err.Error()}
+	}
+	return err
+}
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+)
+
+func TestItemSize(t *testing.T) {
+	item := map[string]*dynamodb.AttributeValue{
+		"pk":   stringAttr("abc"),
+		"n":    numberAttr(12345),
+		"data": binaryAttr(make([]byte, 10)),
+		"m": {M: map[string]*dynamodb.AttributeValue{
+			"k": stringAttr("v"),
+		}},
+		"l": {L: []*dynamodb.AttributeValue{stringAttr("xy")}},
+	}
+	// pk: 2+3, n: 1+5, data: 4+10, m: 1+(3+1+1+1), l: 1+(3+1+2)
+	assert.Equal(t, 39, itemSize(item))
+}
+
+func TestInsertIntoHistoryTreeAndNode_ItemTooLarge(t *testing.T) {
+	db := &ddb{client: &transactionRecorder{}, cfg: &config.NoSQL{Keyspace: "cadence"}}
+	nodeRow := &nosqlplugin.HistoryNodeRow{
+		TreeID:       "tree",
+		BranchID:     "branch",
+		NodeID:       1,
+		Data:         make([]byte, maxItemSize),
+		DataEncoding: "thriftrw",
+	}
+	treeRow := &nosqlplugin.HistoryTreeRow{TreeID: "tree", BranchID: "branch"}
+
+	for name, tree := range map[string]*nosqlplugin.HistoryTreeRow{"single write": nil, "transaction": treeRow} {
+		t.Run(name, func(t *testing.T) {
+			err := db.InsertIntoHistoryTreeAndNode(context.Background(), tree, nodeRow)
+			var sizeErr *persistence.TransactionSizeLimitError
+			require.ErrorAs(t, err, &sizeErr)
+			assert.Contains(t, sizeErr.Msg, "exceeds the DynamoDB item size limit")
+			assert.Empty(t, db.client.(*transactionRecorder).transactions)
+		})
+	}
+}
+
+func TestItemSizeLimitError(t *testing.T) {
+	sizeMessage := "Item size has exceeded the maximum allowed size"
+	tests := []struct {
+		name        string
+		err         error
+		wantSizeErr bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "unrelated",
+			err:  errors.New("some error"),
+		},
+		{
+			name:        "validation exception",
+			err:         awserr.New(errCodeValidationException, sizeMessage, nil),
+			wantSizeErr: true,
+		},
+		{
+			name: "other validation exception",
+			err:  awserr.New(errCodeValidationException, "invalid expression", nil),
+		},
+		{
+			name: "cancelled transaction",
+			err: &dynamodb.TransactionCanceledException{
+				CancellationReasons: []*dynamodb.CancellationReason{
+					{Code: aws.String("None")},
+					{Code: aws.String(cancellationReasonValidationError), Message: aws.String(sizeMessage)},
+				},
+			},
+			wantSizeErr: true,
+		},
+		{
+			name: "conflicting transaction",
+			err: &dynamodb.TransactionCanceledException{
+				CancellationReasons: []*dynamodb.CancellationReason{{Code: aws.String("TransactionConflict")}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := itemSizeLimitError(tc.err)
+			var sizeErr *persistence.TransactionSizeLimitError
+			if tc.wantSizeErr {
+				require.ErrorAs(t, err, &sizeErr)
+				assert.Contains(t, sizeErr.Msg, sizeMessage)
+				return
+			}
+			assert.Equal(t, tc.err, err)
+		})
+	}
+}
@@ -22,18 +22,49 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
+)
+
+const (
+	queueMetadataSortKey = "metadata"
+	attrVersion          = "version"
+	attrPayload          = "payload"
+	attrClusterAckLevels = "cluster_ack_levels"
 )
 
+func queuePartitionKey(queueType persistence.QueueType) string {
+	return strconv.Itoa(int(queueType))
+}
+
 // Insert message into queue, return error if failed or already exists
 // Return ConditionFailure if the condition doesn't meet
 func (db *ddb) InsertIntoQueue(
 	ctx context.Context,
 	row *nosqlplugin.QueueMessageRow,
 ) error {
-	panic("TODO")
+	item := primaryKey(queuePartitionKey(row.QueueType), encodeSortableInt64(row.ID))
+	item[attrPayload] = binaryAttr(row.Payload)
+	b := newExpressionBuilder()
+	_, err := db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(db.tableName(cadence.QueueTableName)),
+		Item:                      item,
+		ConditionExpression:       aws.String(attributeNotExists(b)),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	if db.IsConditionFailedError(err) {
+		return nosqlplugin.NewConditionFailure("queue")
+	}
+	return err
 }
 
 // Get the ID of last message inserted into the queue
@@ -41,7 +72,16 @@ func (db *ddb) SelectLastEnqueuedMessageID(
 	ctx context.Context,
 	queueType persistence.QueueType,
 ) (int64, error) {
-	panic("TODO")
+	input := db.partitionQuery(cadence.QueueTableName, queuePartitionKey(queueType))
+	input.ScanIndexForward = aws.Bool(false)
+	items, _, err := db.queryPage(ctx, input, 1, nil)
+	if err != nil {
+		return 0, err
+	}
+	if len(items) == 0 {
+		return 0, errItemNotFound
+	}
+	return decodeSortableInt64(getString(items[0], attrSK))
 }
 
 // Read queue messages starting from the exclusiveBeginMessageID
@@ -51,7 +91,20 @@ func (db *ddb) SelectMessagesFrom(
 	exclusiveBeginMessageID int64,
 	maxRows int,
 ) ([]*nosqlplugin.QueueMessageRow, error) {
-	panic("TODO")
+	resp, err := db.SelectMessagesBetween(ctx, nosqlplugin.SelectMessagesBetweenRequest{
+		QueueType:               queueType,
+		ExclusiveBeginMessageID: exclusiveBeginMessageID,
+		InclusiveEndMessageID:   math.MaxInt64,
+		PageSize:                maxRows,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*nosqlplugin.QueueMessageRow, 0, len(resp.Rows))
+	for i := range resp.Rows {
+		result = append(result, &resp.Rows[i])
+	}
+	return result, nil
 }
 
 // Read queue message starting from exclusiveBeginMessageID int64, inclusiveEndMessageID int64
@@ -59,7 +112,31 @@ func (db *ddb) SelectMessagesBetween(
 	ctx context.Context,
 	request nosqlplugin.SelectMessagesBetweenRequest,
 ) (*nosqlplugin.SelectMessagesBetweenResponse, error) {
-	panic("TODO")
+	if request.InclusiveEndMessageID <= request.ExclusiveBeginMessageID {
+		return &nosqlplugin.SelectMessagesBetweenResponse{}, nil
+	}
+	input := db.queueRangeQuery(request.QueueType, request.ExclusiveBeginMessageID, request.InclusiveEndMessageID)
+	input.ProjectionExpression = nil
+	items, nextPageToken, err := db.queryPage(ctx, input, request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]nosqlplugin.QueueMessageRow, 0, len(items))
+	for _, item := range items {
+		id, err := decodeSortableInt64(getString(item, attrSK))
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, nosqlplugin.QueueMessageRow{
+			QueueType: request.QueueType,
+			ID:        id,
+			Payload:   item[attrPayload].B,
+		})
+	}
+	return &nosqlplugin.SelectMessagesBetweenResponse{
+		Rows:          rows,
+		NextPageToken: nextPageToken,
+	}, nil
 }
 
 // Delete all messages before exclusiveBeginMessageID
@@ -68,7 +145,10 @@ func (db *ddb) DeleteMessagesBefore(
 	queueType persistence.QueueType,
 	exclusiveBeginMessageID int64,
 ) error {
-	panic("TODO")
+	if exclusiveBeginMessageID == math.MinInt64 {
+		return nil
+	}
+	return db.DeleteMessagesInRange(ctx, queueType, math.MinInt64, exclusiveBeginMessageID-1)
 }
 
 // Delete all messages in a range between exclusiveBeginMessageID and inclusiveEndMessageID
@@ -78,7 +158,11 @@ func (db *ddb) DeleteMessagesInRange(
 	exclusiveBeginMessageID int64,
 	inclusiveEndMessageID int64,
 ) error {
-	panic("TODO")
+	if inclusiveEndMessageID <= exclusiveBeginMessageID {
+		return nil
+	}
+	_, err := db.deleteByQuery(ctx, cadence.QueueTableName, db.queueRangeQuery(queueType, exclusiveBeginMessageID, inclusiveEndMessageID))
+	return err
 }
 
 // Delete one message
@@ -87,12 +171,32 @@ func (db *ddb) DeleteMessage(
 	queueType persistence.QueueType,
 	messageID int64,
 ) error {
-	panic("TODO")
+	_, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(db.tableName(cadence.QueueTableName)),
+		Key:       primaryKey(queuePartitionKey(queueType), encodeSortableInt64(messageID)),
+	})
+	return err
 }
 
 // Insert an empty metadata row, starting from a version
 func (db *ddb) InsertQueueMetadata(ctx context.Context, row nosqlplugin.QueueMetadataRow) error {
-	panic("TODO")
+	item, err := newQueueMetadataItem(row.QueueType, map[string]int64{}, row.Version)
+	if err != nil {
+		return err
+	}
+	b := newExpressionBuilder()
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(db.tableName(cadence.QueueMetadataTableName)),
+		Item:                      item,
+		ConditionExpression:       aws.String(attributeNotExists(b)),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	if db.IsConditionFailedError(err) {
+		// it's ok if the record exists already
+		return nil
+	}
+	return err
 }
 
 // **Conditionally** update a queue metadata row, if current version is matched(meaning current == row.Version - 1),
@@ -102,7 +206,22 @@ func (db *ddb) UpdateQueueMetadataCas(
 	ctx context.Context,
 	row nosqlplugin.QueueMetadataRow,
 ) error {
-	panic("TODO")
+	item, err := newQueueMetadataItem(row.QueueType, row.ClusterAckLevels, row.Version)
+	if err != nil {
+		return err
+	}
+	b := newExpressionBuilder()
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(db.tableName(cadence.QueueMetadataTableName)),
+		Item:                      item,
+		ConditionExpression:       aws.String(attributeEquals(b, attrVersion, numberAttr(row.Version-1))),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	if db.IsConditionFailedError(err) {
+		return nosqlplugin.NewConditionFailure("queue")
+	}
+	return err
 }
 
 // Read a QueueMetadata
@@ -110,12 +229,53 @@ func (db *ddb) SelectQueueMetadata(
 	ctx context.Context,
 	queueType persistence.QueueType,
 ) (*nosqlplugin.QueueMetadataRow, error) {
-	panic("TODO")
+	item, err := db.getItem(ctx, cadence.QueueMetadataTableName, primaryKey(queuePartitionKey(queueType), queueMetadataSortKey))
+	if err != nil {
+		return nil, err
+	}
+	version, err := getInt64(item, attrVersion)
+	if err != nil {
+		return nil, err
+	}
+	ackLevels := make(map[string]int64)
+	if err := getJSON(item, attrClusterAckLevels, &ackLevels); err != nil {
+		return nil, err
+	}
+	// if record exist but ackLevels is empty, we initialize the map
+	if ackLevels == nil {
+		ackLevels = make(map[string]int64)
+	}
+	return &nosqlplugin.QueueMetadataRow{
+		QueueType:        queueType,
+		ClusterAckLevels: ackLevels,
+		Version:          version,
+	}, nil
 }
 
 func (db *ddb) GetQueueSize(
 	ctx context.Context,
 	queueType persistence.QueueType,
 ) (int64, error) {
-	panic("TODO")
+	return db.queryCount(ctx, db.partitionQuery(cadence.QueueTableName, queuePartitionKey(queueType)))
+}
+
+// queueRangeQuery queries the messages in (exclusiveBeginMessageID, inclusiveEndMessageID]
+func (db *ddb) queueRangeQuery(queueType persistence.QueueType, exclusiveBeginMessageID, inclusiveEndMessageID int64) *dynamodb.QueryInput {
+	return db.rangeQuery(
+		cadence.QueueTableName,
+		queuePartitionKey(queueType),
+		encodeSortableInt64(exclusiveBeginMessageID+1),
+		encodeSortableInt64(inclusiveEndMessageID),
+	)
+}
+
+func newQueueMetadataItem(queueType persistence.QueueType, clusterAckLevels map[string]int64, version int64) (map[string]*dynamodb.AttributeValue, error) {
+	ackLevels, err := jsonAttr(clusterAckLevels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize cluster ack levels: %v", err)
+	}
+	item := primaryKey(queuePartitionKey(queueType), queueMetadataSortKey)
+	item[attrClusterAckLevels] = ackLevels
+	item[attrVersion] = numberAttr(version)
+	return item, nil
 }
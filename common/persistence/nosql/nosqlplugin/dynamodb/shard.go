@@ -22,29 +22,176 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
+)
+
+const (
+	shardSortKey = "shard"
+
+	attrRangeID           = "range_id"
+	attrShardData         = "shard_data"
+	attrShardDataEncoding = "shard_data_encoding"
 )
 
+func shardItemKey(shardID int) map[string]*dynamodb.AttributeValue {
+	return primaryKey(shardKey(shardID), shardSortKey)
+}
+
 // InsertShard creates a new shard, return error is there is any.
 // Return ShardOperationConditionFailure if the condition doesn't meet
 func (db *ddb) InsertShard(ctx context.Context, row *nosqlplugin.ShardRow) error {
-	panic("TODO")
+	item, err := newShardItem(row)
+	if err != nil {
+		return err
+	}
+	b := newExpressionBuilder()
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                aws.String(db.tableName(cadence.ShardsTableName)),
+		Item:                     item,
+		ConditionExpression:      aws.String(attributeNotExists(b)),
+		ExpressionAttributeNames: b.attributeNames(),
+	})
+	if db.IsConditionFailedError(err) {
+		return db.shardConditionFailure(ctx, row.ShardID, "shard already exists")
+	}
+	return err
 }
 
 // SelectShard gets a shard
 func (db *ddb) SelectShard(ctx context.Context, shardID int, currentClusterName string) (int64, *nosqlplugin.ShardRow, error) {
-	panic("TODO")
+	item, err := db.getItem(ctx, cadence.ShardsTableName, shardItemKey(shardID))
+	if err != nil {
+		return 0, nil, err
+	}
+	rangeID, err := getInt64(item, attrRangeID)
+	if err != nil {
+		return 0, nil, err
+	}
+	info := &persistence.InternalShardInfo{}
+	if err := getData(item, info); err != nil {
+		return 0, nil, err
+	}
+	fillShardInfoDefaults(currentClusterName, info)
+	var data []byte
+	if v, ok := item[attrShardData]; ok {
+		data = v.B
+	}
+	return rangeID, &nosqlplugin.ShardRow{
+		InternalShardInfo: info,
+		Data:              data,
+		DataEncoding:      getString(item, attrShardDataEncoding),
+	}, nil
 }
 
 // UpdateRangeID updates the rangeID, return error is there is any
 // Return ShardOperationConditionFailure if the condition doesn't meet
 func (db *ddb) UpdateRangeID(ctx context.Context, shardID int, rangeID int64, previousRangeID int64) error {
-	panic("TODO")
+	b := newExpressionBuilder()
+	update := fmt.Sprintf("SET %v = %v", b.name(attrRangeID), b.value(numberAttr(rangeID)))
+	condition := attributeEquals(b, attrRangeID, numberAttr(previousRangeID))
+	_, err := db.client.UpdateItemWithContext(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(db.tableName(cadence.ShardsTableName)),
+		Key:                       shardItemKey(shardID),
+		UpdateExpression:          aws.String(update),
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	if db.IsConditionFailedError(err) {
+		return db.shardConditionFailure(ctx, shardID, fmt.Sprintf("previous rangeID %v doesn't match", previousRangeID))
+	}
+	return err
 }
 
 // UpdateShard updates a shard, return error is there is any.
 // Return ShardOperationConditionFailure if the condition doesn't meet
 func (db *ddb) UpdateShard(ctx context.Context, row *nosqlplugin.ShardRow, previousRangeID int64) error {
-	panic("TODO")
+	item, err := newShardItem(row)
+	if err != nil {
+		return err
+	}
+	b := newExpressionBuilder()
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(db.tableName(cadence.ShardsTableName)),
+		Item:                      item,
+		ConditionExpression:       aws.String(attributeEquals(b, attrRangeID, numberAttr(previousRangeID))),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	if db.IsConditionFailedError(err) {
+		return db.shardConditionFailure(ctx, row.ShardID, fmt.Sprintf("previous rangeID %v doesn't match", previousRangeID))
+	}
+	return err
+}
+
+func newShardItem(row *nosqlplugin.ShardRow) (map[string]*dynamodb.AttributeValue, error) {
+	info := *row.InternalShardInfo
+	info.UpdatedAt = row.CurrentTimestamp
+	item, err := newItem(shardKey(row.ShardID), shardSortKey, &info)
+	if err != nil {
+		return nil, err
+	}
+	item[attrRangeID] = numberAttr(row.RangeID)
+	item[attrShardData] = binaryAttr(row.Data)
+	item[attrShardDataEncoding] = stringAttr(row.DataEncoding)
+	return item, nil
+}
+
+// shardConditionFailure reads the current rangeID of the shard, because unlike Cassandra,
+// a failed conditional write doesn't return the existing item
+func (db *ddb) shardConditionFailure(ctx context.Context, shardID int, details string) error {
+	item, err := db.getItem(ctx, cadence.ShardsTableName, shardItemKey(shardID))
+	if err != nil {
+		return err
+	}
+	return shardConditionFailureFromItem(item, details)
+}
+
+func shardConditionFailureFromItem(item map[string]*dynamodb.AttributeValue, details string) error {
+	rangeID, err := getInt64(item, attrRangeID)
+	if err != nil {
+		return err
+	}
+	return &nosqlplugin.ShardOperationConditionFailure{
+		RangeID: rangeID,
+		Details: details,
+	}
+}
+
+// shardConditionCheck asserts the rangeID of the shard within a transaction
+func (db *ddb) shardConditionCheck(condition nosqlplugin.ShardCondition) *dynamodb.TransactWriteItem {
+	b := newExpressionBuilder()
+	return db.conditionCheckTransactItem(
+		cadence.ShardsTableName,
+		shardItemKey(condition.ShardID),
+		attributeEquals(b, attrRangeID, numberAttr(condition.RangeID)),
+		b,
+	)
+}
+
+func fillShardInfoDefaults(currentCluster string, info *persistence.InternalShardInfo) {
+	if info.ClusterTransferAckLevel == nil {
+		info.ClusterTransferAckLevel = map[string]int64{
+			currentCluster: info.TransferAckLevel,
+		}
+	}
+	if info.ClusterTimerAckLevel == nil {
+		info.ClusterTimerAckLevel = map[string]time.Time{
+			currentCluster: info.TimerAckLevel,
+		}
+	}
+	if info.ClusterReplicationLevel == nil {
+		info.ClusterReplicationLevel = make(map[string]int64)
+	}
+	if info.ReplicationDLQAckLevel == nil {
+		info.ReplicationDLQAckLevel = make(map[string]int64)
+	}
 }
@@ -22,20 +22,72 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
+)
+
+const (
+	taskListSortKey = "task_list"
+	initialRangeID  = 1 // Id of the first range of a new task list
 )
 
+func taskListPartitionKey(domainID, taskListName string, taskListType int) string {
+	return compositeKey(domainID, taskListName, strconv.Itoa(taskListType))
+}
+
+func taskListItemKey(filter *nosqlplugin.TaskListFilter) map[string]*dynamodb.AttributeValue {
+	return primaryKey(taskListPartitionKey(filter.DomainID, filter.TaskListName, filter.TaskListType), taskListSortKey)
+}
+
 // SelectTaskList returns a single tasklist row.
 // Return IsNotFoundError if the row doesn't exist
 func (db *ddb) SelectTaskList(ctx context.Context, filter *nosqlplugin.TaskListFilter) (*nosqlplugin.TaskListRow, error) {
-	panic("TODO")
+	item, err := db.getItem(ctx, cadence.TaskListsTableName, taskListItemKey(filter))
+	if err != nil {
+		return nil, err
+	}
+	row := &nosqlplugin.TaskListRow{}
+	if err := getData(item, row); err != nil {
+		return nil, err
+	}
+	if row.RangeID, err = getInt64(item, attrRangeID); err != nil {
+		return nil, err
+	}
+	return row, nil
 }
 
 // InsertTaskList insert a single tasklist row
 // Return IsConditionFailedError if the row already exists, and also the existing row
 func (db *ddb) InsertTaskList(ctx context.Context, row *nosqlplugin.TaskListRow) error {
-	panic("TODO")
+	newRow := *row
+	newRow.RangeID = initialRangeID
+	newRow.AckLevel = 0
+	item, err := newTaskListItem(&newRow)
+	if err != nil {
+		return err
+	}
+	b := newExpressionBuilder()
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(db.tableName(cadence.TaskListsTableName)),
+		Item:                      item,
+		ConditionExpression:       aws.String(attributeNotExists(b)),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	return db.taskListConditionFailure(ctx, err, &nosqlplugin.TaskListFilter{
+		DomainID:     row.DomainID,
+		TaskListName: row.TaskListName,
+		TaskListType: row.TaskListType,
+	})
 }
 
 // UpdateTaskList updates a single tasklist row
@@ -45,7 +97,7 @@ func (db *ddb) UpdateTaskList(
 	row *nosqlplugin.TaskListRow,
 	previousRangeID int64,
 ) error {
-	panic("TODO")
+	return db.UpdateTaskListWithTTL(ctx, 0, row, previousRangeID)
 }
 
 // UpdateTaskList updates a single tasklist row, and set an TTL on the record
@@ -58,19 +110,65 @@ func (db *ddb) UpdateTaskListWithTTL(
 	row *nosqlplugin.TaskListRow,
 	previousRangeID int64,
 ) error {
-	panic("TODO")
+	item, err := newTaskListItem(row)
+	if err != nil {
+		return err
+	}
+	if ttlSeconds > 0 {
+		item[attrTTL] = ttlAttr(row.CurrentTimeStamp, ttlSeconds)
+	}
+	b := newExpressionBuilder()
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(db.tableName(cadence.TaskListsTableName)),
+		Item:                      item,
+		ConditionExpression:       aws.String(attributeEquals(b, attrRangeID, numberAttr(previousRangeID))),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	return db.taskListConditionFailure(ctx, err, &nosqlplugin.TaskListFilter{
+		DomainID:     row.DomainID,
+		TaskListName: row.TaskListName,
+		TaskListType: row.TaskListType,
+	})
 }
 
 // ListTaskList returns all tasklists.
 // Noop if TTL is already implemented in other methods
 func (db *ddb) ListTaskList(ctx context.Context, pageSize int, nextPageToken []byte) (*nosqlplugin.ListTaskListResult, error) {
-	panic("TODO")
+	items, token, err := db.scanPage(ctx, &dynamodb.ScanInput{
+		TableName: aws.String(db.tableName(cadence.TaskListsTableName)),
+	}, pageSize, nextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	result := &nosqlplugin.ListTaskListResult{
+		NextPageToken: token,
+	}
+	for _, item := range items {
+		row := &nosqlplugin.TaskListRow{}
+		if err := getData(item, row); err != nil {
+			return nil, err
+		}
+		if row.RangeID, err = getInt64(item, attrRangeID); err != nil {
+			return nil, err
+		}
+		result.TaskLists = append(result.TaskLists, row)
+	}
+	return result, nil
 }
 
 // DeleteTaskList deletes a single tasklist row
 // Return TaskOperationConditionFailure if the condition doesn't meet
 func (db *ddb) DeleteTaskList(ctx context.Context, filter *nosqlplugin.TaskListFilter, previousRangeID int64) error {
-	panic("TODO")
+	b := newExpressionBuilder()
+	_, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName:                 aws.String(db.tableName(cadence.TaskListsTableName)),
+		Key:                       taskListItemKey(filter),
+		ConditionExpression:       aws.String(attributeEquals(b, attrRangeID, numberAttr(previousRangeID))),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	return db.taskListConditionFailure(ctx, err, filter)
 }
 
 // InsertTasks inserts a batch of tasks
@@ -80,17 +178,89 @@ func (db *ddb) InsertTasks(
 	tasksToInsert []*nosqlplugin.TaskRowForInsert,
 	tasklistCondition *nosqlplugin.TaskListRow,
 ) error {
-	panic("TODO")
+	pk := taskListPartitionKey(tasklistCondition.DomainID, tasklistCondition.TaskListName, tasklistCondition.TaskListType)
+	timeStamp := tasklistCondition.CurrentTimeStamp
+
+	items := make([]*dynamodb.TransactWriteItem, 0, len(tasksToInsert))
+	for _, task := range tasksToInsert {
+		row := task.TaskRow
+		row.DomainID = tasklistCondition.DomainID
+		row.TaskListName = tasklistCondition.TaskListName
+		row.TaskListType = tasklistCondition.TaskListType
+		item, err := newItem(pk, encodeSortableInt64(task.TaskID), &row)
+		if err != nil {
+			return err
+		}
+		if task.TTLSeconds > 0 {
+			item[attrTTL] = ttlAttr(timeStamp, int64(task.TTLSeconds))
+		}
+		items = append(items, db.putTransactItem(cadence.TasksTableName, item, "", nil))
+	}
+
+	// each chunk makes sure the range_id of the tasklist didn't change
+	for start := 0; start < len(items) || start == 0; start += maxTransactionItems - 1 {
+		end := start + maxTransactionItems - 1
+		if end > len(items) {
+			end = len(items)
+		}
+		b := newExpressionBuilder()
+		condition := db.conditionCheckTransactItem(
+			cadence.TaskListsTableName,
+			primaryKey(pk, taskListSortKey),
+			attributeEquals(b, attrRangeID, numberAttr(tasklistCondition.RangeID)),
+			b,
+		)
+		chunk := append(items[start:end:end], condition)
+		failures, err := db.transactWrite(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		if item, ok := failures[len(chunk)-1]; ok {
+			rangeID, _ := getInt64(item, attrRangeID)
+			return &nosqlplugin.TaskOperationConditionFailure{
+				RangeID: rangeID,
+				Details: fmt.Sprintf("Failed to insert tasks. Request RangeID: %v, Actual RangeID: %v", tasklistCondition.RangeID, rangeID),
+			}
+		}
+	}
+	return nil
 }
 
 // SelectTasks return tasks that associated to a tasklist
 func (db *ddb) SelectTasks(ctx context.Context, filter *nosqlplugin.TasksFilter) ([]*nosqlplugin.TaskRow, error) {
-	panic("TODO")
+	if filter.MaxTaskID <= filter.MinTaskID {
+		return nil, nil
+	}
+	input := db.tasksRangeQuery(filter)
+	input.ProjectionExpression = nil
+	items, _, err := db.queryPage(ctx, input, filter.BatchSize, nil)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*nosqlplugin.TaskRow, 0, len(items))
+	for _, item := range items {
+		task := &nosqlplugin.TaskRow{}
+		if err := getData(item, task); err != nil {
+			return nil, err
+		}
+		if ttl, err := getInt64(item, attrTTL); err == nil {
+			task.Expiry = time.Unix(ttl, 0)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
 }
 
 // SelectTasks return tasks that associated to a tasklist
 func (db *ddb) GetTasksCount(ctx context.Context, filter *nosqlplugin.TasksFilter) (int64, error) {
-	panic("TODO")
+	if filter.MinTaskID == math.MaxInt64 {
+		return 0, nil
+	}
+	return db.queryCount(ctx, db.tasksRangeQuery(&nosqlplugin.TasksFilter{
+		TaskListFilter: filter.TaskListFilter,
+		MinTaskID:      filter.MinTaskID,
+		MaxTaskID:      math.MaxInt64,
+	}))
 }
 
 // DeleteTask delete a batch tasks that taskIDs less than the row
@@ -98,5 +268,46 @@ func (db *ddb) GetTasksCount(ctx context.Context, filter *nosqlplugin.TasksFilte
 // NOTE: This API ignores the `BatchSize` request parameter i.e. either all tasks leq the task_id will be deleted or an error will
 // be returned to the caller, because rowsDeleted is not supported by Cassandra
 func (db *ddb) RangeDeleteTasks(ctx context.Context, filter *nosqlplugin.TasksFilter) (rowsDeleted int, err error) {
-	panic("TODO")
+	if filter.MaxTaskID <= filter.MinTaskID {
+		return 0, nil
+	}
+	if _, err := db.deleteByQuery(ctx, cadence.TasksTableName, db.tasksRangeQuery(filter)); err != nil {
+		return 0, err
+	}
+	return persistence.UnknownNumRowsAffected, nil
+}
+
+// tasksRangeQuery queries the tasks in (MinTaskID, MaxTaskID]
+func (db *ddb) tasksRangeQuery(filter *nosqlplugin.TasksFilter) *dynamodb.QueryInput {
+	return db.rangeQuery(
+		cadence.TasksTableName,
+		taskListPartitionKey(filter.DomainID, filter.TaskListName, filter.TaskListType),
+		encodeSortableInt64(filter.MinTaskID+1),
+		encodeSortableInt64(filter.MaxTaskID),
+	)
+}
+
+func newTaskListItem(row *nosqlplugin.TaskListRow) (map[string]*dynamodb.AttributeValue, error) {
+	item, err := newItem(taskListPartitionKey(row.DomainID, row.TaskListName, row.TaskListType), taskListSortKey, row)
+	if err != nil {
+		return nil, err
+	}
+	item[attrRangeID] = numberAttr(row.RangeID)
+	return item, nil
+}
+
+// taskListConditionFailure translates a failed condition on a tasklist into TaskOperationConditionFailure
+func (db *ddb) taskListConditionFailure(ctx context.Context, err error, filter *nosqlplugin.TaskListFilter) error {
+	if err == nil || !db.IsConditionFailedError(err) {
+		return err
+	}
+	item, err := db.getItem(ctx, cadence.TaskListsTableName, taskListItemKey(filter))
+	if err != nil && !db.IsNotFoundError(err) {
+		return err
+	}
+	rangeID, _ := getInt64(item, attrRangeID)
+	return &nosqlplugin.TaskOperationConditionFailure{
+		RangeID: rangeID,
+		Details: fmt.Sprintf("range_id=%v", rangeID),
+	}
 }
@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+const (
+	// maxTransactionItems is the limit of items in a single TransactWriteItems call
+	maxTransactionItems = 100
+	// maxBatchWriteItems is the limit of requests in a single BatchWriteItem call
+	maxBatchWriteItems = 25
+
+	cancellationReasonConditionalCheckFailed = "ConditionalCheckFailed"
+)
+
+// conditionFailures maps the index of a transaction item whose condition failed to the item as it was
+// when the transaction got cancelled. The item can be empty if it didn't exist.
+type conditionFailures map[int]map[string]*dynamodb.AttributeValue
+
+func (f conditionFailures) failed(index int) bool {
+	if index < 0 {
+		return false
+	}
+	_, ok := f[index]
+	return ok
+}
+
+// transactWrite executes the items atomically.
+// If the transaction is cancelled because of conditions, the failed conditions are returned with a nil error.
+// Items exceeding the DynamoDB item size limit are rejected with TransactionSizeLimitError.
+// Any other failure, including transaction conflicts, is returned as error.
+func (db *ddb) transactWrite(ctx context.Context, items []*dynamodb.TransactWriteItem) (conditionFailures, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	if len(items) > maxTransactionItems {
+		return nil, fmt.Errorf("transaction contains %v items which exceeds the DynamoDB limit of %v", len(items), maxTransactionItems)
+	}
+	for _, item := range items {
+		if item.Put == nil {
+			continue
+		}
+		if err := checkItemSize(aws.StringValue(item.Put.TableName), item.Put.Item); err != nil {
+			return nil, err
+		}
+	}
+	_, err := db.client.TransactWriteItemsWithContext(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: items,
+	})
+	if err == nil {
+		return nil, nil
+	}
+	var cancelled *dynamodb.TransactionCanceledException
+	if !errors.As(err, &cancelled) {
+		return nil, itemSizeLimitError(err)
+	}
+	failures := make(conditionFailures)
+	for i, reason := range cancelled.CancellationReasons {
+		if aws.StringValue(reason.Code) == cancellationReasonConditionalCheckFailed {
+			failures[i] = reason.Item
+		}
+	}
+	if len(failures) == 0 {
+		return nil, itemSizeLimitError(err)
+	}
+	return failures, nil
+}
+
+func (db *ddb) putTransactItem(
+	table string,
+	item map[string]*dynamodb.AttributeValue,
+	condition string,
+	b *expressionBuilder,
+) *dynamodb.TransactWriteItem {
+	put := &dynamodb.Put{
+		TableName: aws.String(db.tableName(table)),
+		Item:      item,
+	}
+	if condition != "" {
+		put.ConditionExpression = aws.String(condition)
+		put.ExpressionAttributeNames = b.attributeNames()
+		put.ExpressionAttributeValues = b.attributeValues()
+		put.ReturnValuesOnConditionCheckFailure = aws.String(dynamodb.ReturnValuesOnConditionCheckFailureAllOld)
+	}
+	return &dynamodb.TransactWriteItem{Put: put}
+}
+
+func (db *ddb) updateTransactItem(
+	table string,
+	key map[string]*dynamodb.AttributeValue,
+	update string,
+	condition string,
+	b *expressionBuilder,
+) *dynamodb.TransactWriteItem {
+	u := &dynamodb.Update{
+		TableName:                 aws.String(db.tableName(table)),
+		Key:                       key,
+		UpdateExpression:          aws.String(update),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	}
+	if condition != "" {
+		u.ConditionExpression = aws.String(condition)
+		u.ReturnValuesOnConditionCheckFailure = aws.String(dynamodb.ReturnValuesOnConditionCheckFailureAllOld)
+	}
+	return &dynamodb.TransactWriteItem{Update: u}
+}
+
+func (db *ddb) conditionCheckTransactItem(
+	table string,
+	key map[string]*dynamodb.AttributeValue,
+	condition string,
+	b *expressionBuilder,
+) *dynamodb.TransactWriteItem {
+	return &dynamodb.TransactWriteItem{
+		ConditionCheck: &dynamodb.ConditionCheck{
+			TableName:                           aws.String(db.tableName(table)),
+			Key:                                 key,
+			ConditionExpression:                 aws.String(condition),
+			ExpressionAttributeNames:            b.attributeNames(),
+			ExpressionAttributeValues:           b.attributeValues(),
+			ReturnValuesOnConditionCheckFailure: aws.String(dynamodb.ReturnValuesOnConditionCheckFailureAllOld),
+		},
+	}
+}
+
+// attributeNotExists returns a condition that the item doesn't exist yet
+func attributeNotExists(b *expressionBuilder) string {
+	return fmt.Sprintf("attribute_not_exists(%v)", b.name(attrPK))
+}
+
+// attributeEquals returns a condition that an attribute equals to the value
+func attributeEquals(b *expressionBuilder, name string, v *dynamodb.AttributeValue) string {
+	return fmt.Sprintf("%v = %v", b.name(name), b.value(v))
+}
@@ -0,0 +1,403 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamodb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+)
+
+// Every table shares the same key schema: a string partition key and a string sort key.
+// Values that need to be ordered are encoded with encodeSortableInt64 so that
+// the lexicographical order of the sort key matches the numerical order.
+const (
+	attrPK   = "pk"
+	attrSK   = "sk"
+	attrData = "data"
+	attrTTL  = "ttl"
+
+	keySeparator = "#"
+)
+
+// itemNotFoundOrError is a shortcut for GetItem results
+func itemNotFoundOrError(out *dynamodb.GetItemOutput, err error) (map[string]*dynamodb.AttributeValue, error) {
+	if err != nil {
+		return nil, err
+	}
+	if len(out.Item) == 0 {
+		return nil, errItemNotFound
+	}
+	return out.Item, nil
+}
+
+func (db *ddb) tableName(name string) string {
+	return db.cfg.Keyspace + "." + name
+}
+
+// encodeSortableInt64 encodes an int64 into a fixed width string which sorts in the same order as the number,
+// including negative numbers
+func encodeSortableInt64(v int64) string {
+	return fmt.Sprintf("%020d", uint64(v)^(1<<63))
+}
+
+func decodeSortableInt64(s string) (int64, error) {
+	u, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return int64(u ^ (1 << 63)), nil
+}
+
+func encodeSortableTime(t time.Time) string {
+	return encodeSortableInt64(t.UnixNano())
+}
+
+func compositeKey(parts ...string) string {
+	return strings.Join(parts, keySeparator)
+}
+
+func shardKey(shardID int) string {
+	return strconv.Itoa(shardID)
+}
+
+func primaryKey(pk, sk string) map[string]*dynamodb.AttributeValue {
+	return map[string]*dynamodb.AttributeValue{
+		attrPK: stringAttr(pk),
+		attrSK: stringAttr(sk),
+	}
+}
+
+func stringAttr(s string) *dynamodb.AttributeValue {
+	return &dynamodb.AttributeValue{S: aws.String(s)}
+}
+
+func numberAttr(v int64) *dynamodb.AttributeValue {
+	return &dynamodb.AttributeValue{N: aws.String(strconv.FormatInt(v, 10))}
+}
+
+func binaryAttr(b []byte) *dynamodb.AttributeValue {
+	if b == nil {
+		b = []byte{}
+	}
+	return &dynamodb.AttributeValue{B: b}
+}
+
+func jsonAttr(v interface{}) (*dynamodb.AttributeValue, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return binaryAttr(b), nil
+}
+
+func ttlAttr(now time.Time, ttlSeconds int64) *dynamodb.AttributeValue {
+	return numberAttr(now.Unix() + ttlSeconds)
+}
+
+// newItem creates an item with the primary key and the json encoded data attribute
+func newItem(pk, sk string, data interface{}) (map[string]*dynamodb.AttributeValue, error) {
+	item := primaryKey(pk, sk)
+	if data != nil {
+		dataAttr, err := jsonAttr(data)
+		if err != nil {
+			return nil, err
+		}
+		item[attrData] = dataAttr
+	}
+	return item, nil
+}
+
+func getString(item map[string]*dynamodb.AttributeValue, name string) string {
+	if v, ok := item[name]; ok && v.S != nil {
+		return *v.S
+	}
+	return ""
+}
+
+func getInt64(item map[string]*dynamodb.AttributeValue, name string) (int64, error) {
+	v, ok := item[name]
+	if !ok || v.N == nil {
+		return 0, fmt.Errorf("attribute %v is missing or not a number", name)
+	}
+	return strconv.ParseInt(*v.N, 10, 64)
+}
+
+func getJSON(item map[string]*dynamodb.AttributeValue, name string, out interface{}) error {
+	v, ok := item[name]
+	if !ok || v.B == nil {
+		return fmt.Errorf("attribute %v is missing or not a binary", name)
+	}
+	return json.Unmarshal(v.B, out)
+}
+
+func getData(item map[string]*dynamodb.AttributeValue, out interface{}) error {
+	return getJSON(item, attrData, out)
+}
+
+// expressionBuilder generates placeholders for expression attribute names and values.
+// Placeholders are always used for names so that the DynamoDB reserved words never get in the way.
+type expressionBuilder struct {
+	names  map[string]*string
+	values map[string]*dynamodb.AttributeValue
+	byName map[string]string
+}
+
+func newExpressionBuilder() *expressionBuilder {
+	return &expressionBuilder{
+		names:  make(map[string]*string),
+		values: make(map[string]*dynamodb.AttributeValue),
+		byName: make(map[string]string),
+	}
+}
+
+// name returns the placeholder of an attribute name
+func (b *expressionBuilder) name(name string) string {
+	if placeholder, ok := b.byName[name]; ok {
+		return placeholder
+	}
+	placeholder := "#n" + strconv.Itoa(len(b.names))
+	b.names[placeholder] = aws.String(name)
+	b.byName[name] = placeholder
+	return placeholder
+}
+
+// path returns the placeholder of a map entry, e.g. activity_map.5
+func (b *expressionBuilder) path(name, key string) string {
+	return b.name(name) + "." + b.name(key)
+}
+
+// value returns the placeholder of a value
+func (b *expressionBuilder) value(v *dynamodb.AttributeValue) string {
+	placeholder := ":v" + strconv.Itoa(len(b.values))
+	b.values[placeholder] = v
+	return placeholder
+}
+
+func (b *expressionBuilder) attributeNames() map[string]*string {
+	if len(b.names) == 0 {
+		return nil
+	}
+	return b.names
+}
+
+func (b *expressionBuilder) attributeValues() map[string]*dynamodb.AttributeValue {
+	if len(b.values) == 0 {
+		return nil
+	}
+	return b.values
+}
+
+// updateExpression collects the clauses of an update expression
+type updateExpression struct {
+	set    []string
+	remove []string
+}
+
+func (u *updateExpression) String() string {
+	var clauses []string
+	if len(u.set) > 0 {
+		clauses = append(clauses, "SET "+strings.Join(u.set, ", "))
+	}
+	if len(u.remove) > 0 {
+		clauses = append(clauses, "REMOVE "+strings.Join(u.remove, ", "))
+	}
+	return strings.Join(clauses, " ")
+}
+
+func serializePageToken(lastEvaluatedKey map[string]*dynamodb.AttributeValue) ([]byte, error) {
+	if len(lastEvaluatedKey) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(lastEvaluatedKey)
+}
+
+func deserializePageToken(token []byte) (map[string]*dynamodb.AttributeValue, error) {
+	if len(token) == 0 {
+		return nil, nil
+	}
+	var key map[string]*dynamodb.AttributeValue
+	if err := json.Unmarshal(token, &key); err != nil {
+		return nil, fmt.Errorf("invalid page token: %v", err)
+	}
+	return key, nil
+}
+
+// queryPage runs the query until pageSize items are collected or the end of the result is reached.
+// Because DynamoDB applies Limit before FilterExpression, a single call may return less than the
+// requested number of items even though there are more items to read.
+// A non-positive pageSize reads everything.
+func (db *ddb) queryPage(
+	ctx context.Context,
+	input *dynamodb.QueryInput,
+	pageSize int,
+	pageToken []byte,
+) ([]map[string]*dynamodb.AttributeValue, []byte, error) {
+	startKey, err := deserializePageToken(pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	input.ConsistentRead = aws.Bool(input.IndexName == nil)
+	var items []map[string]*dynamodb.AttributeValue
+	for {
+		input.ExclusiveStartKey = startKey
+		if pageSize > 0 {
+			input.Limit = aws.Int64(int64(pageSize - len(items)))
+		}
+		out, err := db.client.QueryWithContext(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, out.Items...)
+		startKey = out.LastEvaluatedKey
+		if len(startKey) == 0 || (pageSize > 0 && len(items) >= pageSize) {
+			break
+		}
+	}
+	nextPageToken, err := serializePageToken(startKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, nextPageToken, nil
+}
+
+// queryAll reads all items matching the query
+func (db *ddb) queryAll(ctx context.Context, input *dynamodb.QueryInput) ([]map[string]*dynamodb.AttributeValue, error) {
+	items, _, err := db.queryPage(ctx, input, 0, nil)
+	return items, err
+}
+
+// queryCount counts the items matching the query
+func (db *ddb) queryCount(ctx context.Context, input *dynamodb.QueryInput) (int64, error) {
+	input.Select = aws.String(dynamodb.SelectCount)
+	input.ConsistentRead = aws.Bool(true)
+	var count int64
+	for {
+		out, err := db.client.QueryWithContext(ctx, input)
+		if err != nil {
+			return 0, err
+		}
+		count += aws.Int64Value(out.Count)
+		if len(out.LastEvaluatedKey) == 0 {
+			return count, nil
+		}
+		input.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+// scanPage scans a whole table with pagination
+func (db *ddb) scanPage(
+	ctx context.Context,
+	input *dynamodb.ScanInput,
+	pageSize int,
+	pageToken []byte,
+) ([]map[string]*dynamodb.AttributeValue, []byte, error) {
+	startKey, err := deserializePageToken(pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	input.ConsistentRead = aws.Bool(true)
+	var items []map[string]*dynamodb.AttributeValue
+	for {
+		input.ExclusiveStartKey = startKey
+		if pageSize > 0 {
+			input.Limit = aws.Int64(int64(pageSize - len(items)))
+		}
+		out, err := db.client.ScanWithContext(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, out.Items...)
+		startKey = out.LastEvaluatedKey
+		if len(startKey) == 0 || (pageSize > 0 && len(items) >= pageSize) {
+			break
+		}
+	}
+	nextPageToken, err := serializePageToken(startKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return items, nextPageToken, nil
+}
+
+// getItem reads a single item with strong consistency, returns errItemNotFound if the item doesn't exist
+func (db *ddb) getItem(ctx context.Context, table string, key map[string]*dynamodb.AttributeValue) (map[string]*dynamodb.AttributeValue, error) {
+	return itemNotFoundOrError(db.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(db.tableName(table)),
+		Key:            key,
+		ConsistentRead: aws.Bool(true),
+	}))
+}
+
+// batchDelete deletes the keys from a table, in chunks of the BatchWriteItem limit.
+// The unprocessed items of a chunk are retried with exponential backoff and a bounded number of attempts.
+func (db *ddb) batchDelete(ctx context.Context, table string, keys []map[string]*dynamodb.AttributeValue) error {
+	for start := 0; start < len(keys); start += maxBatchWriteItems {
+		end := start + maxBatchWriteItems
+		if end > len(keys) {
+			end = len(keys)
+		}
+		requests := make([]*dynamodb.WriteRequest, 0, end-start)
+		for _, key := range keys[start:end] {
+			requests = append(requests, &dynamodb.WriteRequest{
+				DeleteRequest: &dynamodb.DeleteRequest{Key: key},
+			})
+		}
+		pending := map[string][]*dynamodb.WriteRequest{db.tableName(table): requests}
+		err := db.batchWriteRetry.Do(ctx, func(ctx context.Context) error {
+			out, err := db.client.BatchWriteItemWithContext(ctx, &dynamodb.BatchWriteItemInput{
+				RequestItems: pending,
+			})
+			if err != nil {
+				return err
+			}
+			pending = out.UnprocessedItems
+			if len(pending) > 0 {
+				return errUnprocessedItems
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteByQuery deletes all items returned by the query.
+// The query must project the primary key attributes.
+func (db *ddb) deleteByQuery(ctx context.Context, table string, input *dynamodb.QueryInput) (int, error) {
+	items, err := db.queryAll(ctx, input)
+	if err != nil {
+		return 0, err
+	}
+	keys := make([]map[string]*dynamodb.AttributeValue, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, primaryKey(getString(item, attrPK), getString(item, attrSK)))
+	}
+	return len(keys), db.batchDelete(ctx, table, keys)
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/config"
+)
+
+func TestEncodeSortableInt64(t *testing.T) {
+	values := []int64{math.MinInt64, -1 << 40, -2, -1, 0, 1, 2, 1 << 40, math.MaxInt64}
+
+	encoded := make([]string, len(values))
+	for i, v := range values {
+		encoded[i] = encodeSortableInt64(v)
+		assert.Len(t, encoded[i], 20)
+
+		decoded, err := decodeSortableInt64(encoded[i])
+		require.NoError(t, err)
+		assert.Equal(t, v, decoded)
+	}
+	assert.True(t, sort.StringsAreSorted(encoded), "encoded values must sort like the numbers: %v", encoded)
+
+	_, err := decodeSortableInt64("not-a-number")
+	assert.Error(t, err)
+}
+
+func TestEncodeSortableTime(t *testing.T) {
+	now := time.Now()
+	assert.Less(t, encodeSortableTime(now.Add(-time.Second)), encodeSortableTime(now))
+	assert.Less(t, encodeSortableTime(time.Unix(0, 0).Add(-time.Nanosecond)), encodeSortableTime(time.Unix(0, 0)))
+}
+
+func TestCompositeKeyHalfOpenRange(t *testing.T) {
+	// entries keyed by compositeKey(enc(x), ...) must fall outside BETWEEN enc(min) AND enc(max) when x == max
+	lower, upper := encodeSortableInt64(10), encodeSortableInt64(20)
+	tests := []struct {
+		key     string
+		inRange bool
+	}{
+		{key: compositeKey(encodeSortableInt64(10), "a"), inRange: true},
+		{key: compositeKey(encodeSortableInt64(19), "z"), inRange: true},
+		{key: compositeKey(encodeSortableInt64(20), "a"), inRange: false},
+		{key: compositeKey(encodeSortableInt64(9), "z"), inRange: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.key, func(t *testing.T) {
+			assert.Equal(t, tc.inRange, tc.key >= lower && tc.key <= upper)
+		})
+	}
+}
+
+func TestExpressionBuilder(t *testing.T) {
+	b := newExpressionBuilder()
+	assert.Nil(t, b.attributeNames())
+	assert.Nil(t, b.attributeValues())
+
+	first := b.name("range_id")
+	assert.Equal(t, first, b.name("range_id"), "the same name must reuse the placeholder")
+	assert.NotEqual(t, first, b.name("data"))
+	assert.Equal(t, b.name("activity_map")+"."+b.name("5"), b.path("activity_map", "5"))
+
+	v1 := b.value(numberAttr(1))
+	v2 := b.value(numberAttr(1))
+	assert.NotEqual(t, v1, v2, "each value gets its own placeholder")
+
+	assert.Len(t, b.attributeNames(), 4)
+	assert.Len(t, b.attributeValues(), 2)
+	assert.Equal(t, "range_id", *b.attributeNames()[first])
+}
+
+func TestUpdateExpression(t *testing.T) {
+	tests := []struct {
+		name string
+		expr updateExpression
+		want string
+	}{
+		{
+			name: "empty",
+			want: "",
+		},
+		{
+			name: "set only",
+			expr: updateExpression{set: []string{"#n0 = :v0", "#n1 = :v1"}},
+			want: "SET #n0 = :v0, #n1 = :v1",
+		},
+		{
+			name: "remove only",
+			expr: updateExpression{remove: []string{"#n0.#n1"}},
+			want: "REMOVE #n0.#n1",
+		},
+		{
+			name: "set and remove",
+			expr: updateExpression{set: []string{"#n0 = :v0"}, remove: []string{"#n1.#n2", "#n1.#n3"}},
+			want: "SET #n0 = :v0 REMOVE #n1.#n2, #n1.#n3",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.expr.String())
+		})
+	}
+}
+
+func TestPageToken(t *testing.T) {
+	token, err := serializePageToken(nil)
+	require.NoError(t, err)
+	assert.Nil(t, token)
+
+	key := primaryKey("pk", "sk")
+	token, err = serializePageToken(key)
+	require.NoError(t, err)
+
+	got, err := deserializePageToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, key, got)
+
+	_, err = deserializePageToken([]byte("{"))
+	assert.Error(t, err)
+}
+
+// batchWriter leaves the given number of items unprocessed in every call
+type batchWriter struct {
+	dynamodbiface.DynamoDBAPI
+	unprocessed []int
+	calls       [][]*dynamodb.WriteRequest
+}
+
+func (w *batchWriter) BatchWriteItemWithContext(
+	_ aws.Context,
+	input *dynamodb.BatchWriteItemInput,
+	_ ...request.Option,
+) (*dynamodb.BatchWriteItemOutput, error) {
+	out := &dynamodb.BatchWriteItemOutput{}
+	for table, requests := range input.RequestItems {
+		w.calls = append(w.calls, requests)
+		unprocessed := 0
+		if len(w.calls) <= len(w.unprocessed) {
+			unprocessed = w.unprocessed[len(w.calls)-1]
+		}
+		if unprocessed > 0 {
+			out.UnprocessedItems = map[string][]*dynamodb.WriteRequest{table: requests[:unprocessed]}
+		}
+	}
+	return out, nil
+}
+
+func TestBatchDelete(t *testing.T) {
+	keys := make([]map[string]*dynamodb.AttributeValue, 30)
+	for i := range keys {
+		keys[i] = primaryKey("pk", encodeSortableInt64(int64(i)))
+	}
+	policy := backoff.NewExponentialRetryPolicy(time.Millisecond)
+	policy.SetMaximumAttempts(3)
+
+	tests := []struct {
+		name        string
+		unprocessed []int
+		wantCalls   []int
+		wantErr     bool
+	}{
+		{
+			name:      "all processed",
+			wantCalls: []int{25, 5},
+		},
+		{
+			name:        "unprocessed items are retried",
+			unprocessed: []int{10, 4},
+			wantCalls:   []int{25, 10, 4, 5},
+		},
+		{
+			name:        "retries are bounded",
+			unprocessed: []int{10, 10, 10, 10},
+			wantCalls:   []int{25, 10, 10, 10},
+			wantErr:     true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			writer := &batchWriter{unprocessed: tc.unprocessed}
+			db := &ddb{client: writer, cfg: &config.NoSQL{Keyspace: "cadence"}, batchWriteRetry: newBatchWriteRetry(policy)}
+
+			err := db.batchDelete(context.Background(), "table", keys)
+			var calls []int
+			for _, requests := range writer.calls {
+				calls = append(calls, len(requests))
+			}
+			assert.Equal(t, tc.wantCalls, calls)
+			if tc.wantErr {
+				assert.True(t, errors.Is(err, errUnprocessedItems))
+				assert.True(t, db.IsThrottlingError(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
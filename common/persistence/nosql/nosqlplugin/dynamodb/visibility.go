@@ -22,16 +22,65 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
+)
+
+// Like Cassandra, visibility records are duplicated into partitions that are sorted differently:
+// open records by start time, closed records by start time and closed records by close time.
+// In addition, every run has an execution record so that it can be found by its IDs.
+const (
+	visibilityOpenPartition          = "open"
+	visibilityClosedPartition        = "closed"
+	visibilityClosedByClosePartition = "closed_by_close"
+	visibilityExecutionPartition     = "execution"
+
+	attrWorkflowType = "workflow_type"
+	attrCloseStatus  = "close_status"
+	attrStartTime    = "start_time"
+	attrCloseTime    = "close_time"
+
+	// sorts after any run ID, which makes a time based sort key range inclusive on the upper end
+	maxRunIDSortKey = "~"
 )
 
+func visibilityPartitionKey(domainID, partition string) string {
+	return compositeKey(domainID, partition)
+}
+
+func visibilitySortKey(t time.Time, runID string) string {
+	return compositeKey(encodeSortableTime(t), runID)
+}
+
+func visibilityExecutionKey(domainID, workflowID, runID string) map[string]*dynamodb.AttributeValue {
+	return primaryKey(visibilityPartitionKey(domainID, visibilityExecutionPartition), compositeKey(workflowID, runID))
+}
+
 func (db *ddb) InsertVisibility(
 	ctx context.Context,
 	ttlSeconds int64,
 	row *nosqlplugin.VisibilityRowForInsert,
 ) error {
-	panic("TODO")
+	openItem, err := newVisibilityItem(visibilityPartitionKey(row.DomainID, visibilityOpenPartition), visibilitySortKey(row.StartTime, row.RunID), &row.VisibilityRow, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	executionItem, err := newVisibilityExecutionItem(row.DomainID, &row.VisibilityRow, false, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	_, err = db.transactWrite(ctx, []*dynamodb.TransactWriteItem{
+		db.putTransactItem(cadence.VisibilityTableName, openItem, "", nil),
+		db.putTransactItem(cadence.VisibilityTableName, executionItem, "", nil),
+	})
+	return err
 }
 
 func (db *ddb) UpdateVisibility(
@@ -39,26 +88,205 @@ func (db *ddb) UpdateVisibility(
 	ttlSeconds int64,
 	row *nosqlplugin.VisibilityRowForUpdate,
 ) error {
-	panic("TODO")
+	if row.UpdateCloseToOpen {
+		// TODO implement it when where is a need
+		return fmt.Errorf("not supported operation")
+	}
+
+	var items []*dynamodb.TransactWriteItem
+	if row.UpdateOpenToClose {
+		items = append(items, &dynamodb.TransactWriteItem{
+			Delete: &dynamodb.Delete{
+				TableName: aws.String(db.tableName(cadence.VisibilityTableName)),
+				Key:       primaryKey(visibilityPartitionKey(row.DomainID, visibilityOpenPartition), visibilitySortKey(row.StartTime, row.RunID)),
+			},
+		})
+	}
+	closedItem, err := newVisibilityItem(visibilityPartitionKey(row.DomainID, visibilityClosedPartition), visibilitySortKey(row.StartTime, row.RunID), &row.VisibilityRow, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	closedByCloseItem, err := newVisibilityItem(visibilityPartitionKey(row.DomainID, visibilityClosedByClosePartition), visibilitySortKey(row.CloseTime, row.RunID), &row.VisibilityRow, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	executionItem, err := newVisibilityExecutionItem(row.DomainID, &row.VisibilityRow, true, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	items = append(items,
+		db.putTransactItem(cadence.VisibilityTableName, closedItem, "", nil),
+		db.putTransactItem(cadence.VisibilityTableName, closedByCloseItem, "", nil),
+		db.putTransactItem(cadence.VisibilityTableName, executionItem, "", nil),
+	)
+	_, err = db.transactWrite(ctx, items)
+	return err
 }
 
 func (db *ddb) SelectVisibility(
 	ctx context.Context,
 	filter *nosqlplugin.VisibilityFilter,
 ) (*nosqlplugin.SelectVisibilityResponse, error) {
-	panic("TODO")
+	var partition string
+	switch filter.FilterType {
+	case nosqlplugin.AllOpen, nosqlplugin.OpenByWorkflowType, nosqlplugin.OpenByWorkflowID:
+		partition = visibilityOpenPartition
+	case nosqlplugin.AllClosed, nosqlplugin.ClosedByWorkflowType, nosqlplugin.ClosedByWorkflowID, nosqlplugin.ClosedByClosedStatus:
+		switch filter.SortType {
+		case nosqlplugin.SortByStartTime:
+			partition = visibilityClosedPartition
+		case nosqlplugin.SortByClosedTime:
+			partition = visibilityClosedByClosePartition
+		default:
+			return nil, fmt.Errorf("not supported sorting type: %v", filter.SortType)
+		}
+	default:
+		return nil, fmt.Errorf("not supported filter type: %v", filter.FilterType)
+	}
+
+	request := &filter.ListRequest
+	b := newExpressionBuilder()
+	input := &dynamodb.QueryInput{
+		TableName: aws.String(db.tableName(cadence.VisibilityTableName)),
+		KeyConditionExpression: aws.String(fmt.Sprintf("%v = %v AND %v BETWEEN %v AND %v",
+			b.name(attrPK), b.value(stringAttr(visibilityPartitionKey(request.DomainUUID, partition))),
+			b.name(attrSK), b.value(stringAttr(encodeSortableTime(request.EarliestTime))),
+			b.value(stringAttr(compositeKey(encodeSortableTime(request.LatestTime), maxRunIDSortKey))))),
+		ScanIndexForward: aws.Bool(false),
+	}
+	switch filter.FilterType {
+	case nosqlplugin.OpenByWorkflowType, nosqlplugin.ClosedByWorkflowType:
+		input.FilterExpression = aws.String(attributeEquals(b, attrWorkflowType, stringAttr(filter.WorkflowType)))
+	case nosqlplugin.OpenByWorkflowID, nosqlplugin.ClosedByWorkflowID:
+		input.FilterExpression = aws.String(attributeEquals(b, attrWorkflowID, stringAttr(filter.WorkflowID)))
+	case nosqlplugin.ClosedByClosedStatus:
+		input.FilterExpression = aws.String(attributeEquals(b, attrCloseStatus, numberAttr(int64(filter.CloseStatus))))
+	}
+	input.ExpressionAttributeNames = b.attributeNames()
+	input.ExpressionAttributeValues = b.attributeValues()
+
+	items, nextPageToken, err := db.queryPage(ctx, input, request.PageSize, request.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	executions := make([]*nosqlplugin.VisibilityRow, 0, len(items))
+	for _, item := range items {
+		row := &nosqlplugin.VisibilityRow{}
+		if err := getData(item, row); err != nil {
+			return nil, err
+		}
+		executions = append(executions, row)
+	}
+	return &nosqlplugin.SelectVisibilityResponse{
+		Executions:    executions,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// UpsertVisibility is not supported because search attributes are not stored
+func (db *ddb) UpsertVisibility(
+	ctx context.Context,
+	ttlSeconds int64,
+	row *nosqlplugin.VisibilityRowForInsert,
+) error {
+	return persistence.ErrVisibilityOperationNotSupported
+}
+
+// SelectVisibilityBucket is not supported because visibility queries are not supported
+func (db *ddb) SelectVisibilityBucket(
+	ctx context.Context,
+	filter *nosqlplugin.VisibilityBucketFilter,
+) ([]*nosqlplugin.VisibilityRow, error) {
+	return nil, persistence.ErrVisibilityOperationNotSupported
 }
 
+// SelectClosedVisibilityBuckets is not supported because visibility queries are not supported
+func (db *ddb) SelectClosedVisibilityBuckets(
+	ctx context.Context,
+	domainID string,
+	minBucket, maxBucket int64,
+) ([]int64, error) {
+	return nil, persistence.ErrVisibilityOperationNotSupported
+}
+
+// DeleteVisibility is a noop because of TTL, except for the admin command which deletes all records of the run
 func (db *ddb) DeleteVisibility(
 	ctx context.Context,
 	domainID, workflowID, runID string,
 ) error {
-	panic("TODO")
+	key := persistence.VisibilityAdminDeletionKey("visibilityAdminDelete")
+	if v := ctx.Value(key); v == nil || !v.(bool) {
+		return nil
+	}
+	item, err := db.getItem(ctx, cadence.VisibilityTableName, visibilityExecutionKey(domainID, workflowID, runID))
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil // workflow not found, nothing to do
+		}
+		return err
+	}
+	startTime, err := getInt64(item, attrStartTime)
+	if err != nil {
+		return err
+	}
+	keys := []map[string]*dynamodb.AttributeValue{
+		visibilityExecutionKey(domainID, workflowID, runID),
+		primaryKey(visibilityPartitionKey(domainID, visibilityOpenPartition), visibilitySortKey(time.Unix(0, startTime), runID)),
+		primaryKey(visibilityPartitionKey(domainID, visibilityClosedPartition), visibilitySortKey(time.Unix(0, startTime), runID)),
+	}
+	if closeTime, err := getInt64(item, attrCloseTime); err == nil {
+		keys = append(keys, primaryKey(visibilityPartitionKey(domainID, visibilityClosedByClosePartition), visibilitySortKey(time.Unix(0, closeTime), runID)))
+	}
+	return db.batchDelete(ctx, cadence.VisibilityTableName, keys)
 }
 
 func (db *ddb) SelectOneClosedWorkflow(
 	ctx context.Context,
 	domainID, workflowID, runID string,
 ) (*nosqlplugin.VisibilityRow, error) {
-	panic("TODO")
+	item, err := db.getItem(ctx, cadence.VisibilityTableName, visibilityExecutionKey(domainID, workflowID, runID))
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			// Special case: return nil,nil if not found(since we will deprecate it, it's not worth refactor to be consistent)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if _, ok := item[attrCloseTime]; !ok {
+		return nil, nil
+	}
+	row := &nosqlplugin.VisibilityRow{}
+	if err := getData(item, row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func newVisibilityItem(pk, sk string, row *nosqlplugin.VisibilityRow, ttlSeconds int64) (map[string]*dynamodb.AttributeValue, error) {
+	item, err := newItem(pk, sk, row)
+	if err != nil {
+		return nil, err
+	}
+	item[attrWorkflowID] = stringAttr(row.WorkflowID)
+	item[attrWorkflowType] = stringAttr(row.TypeName)
+	if row.Status != nil {
+		item[attrCloseStatus] = numberAttr(int64(*row.Status))
+	}
+	if ttlSeconds > 0 {
+		item[attrTTL] = ttlAttr(time.Now(), ttlSeconds)
+	}
+	return item, nil
+}
+
+func newVisibilityExecutionItem(domainID string, row *nosqlplugin.VisibilityRow, closed bool, ttlSeconds int64) (map[string]*dynamodb.AttributeValue, error) {
+	key := visibilityExecutionKey(domainID, row.WorkflowID, row.RunID)
+	item, err := newVisibilityItem(*key[attrPK].S, *key[attrSK].S, row, ttlSeconds)
+	if err != nil {
+		return nil, err
+	}
+	item[attrStartTime] = numberAttr(row.StartTime.UnixNano())
+	if closed {
+		item[attrCloseTime] = numberAttr(row.CloseTime.UnixNano())
+	}
+	return item, nil
 }
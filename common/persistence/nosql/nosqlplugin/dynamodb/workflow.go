@@ -23,10 +23,15 @@ package dynamodb
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
 )
 
 var _ nosqlplugin.WorkflowCRUD = (*ddb)(nil)
@@ -40,7 +45,33 @@ func (db *ddb) InsertWorkflowExecutionWithTasks(
 	activeClusterSelectionPolicyRow *nosqlplugin.ActiveClusterSelectionPolicyRow,
 	shardCondition *nosqlplugin.ShardCondition,
 ) error {
-	panic("TODO")
+	shardID := shardCondition.ShardID
+	domainID := execution.DomainID
+	workflowID := execution.WorkflowID
+	timeStamp := execution.CurrentTimeStamp
+
+	tx := newWorkflowTransaction()
+	if err := db.addWorkflowRequests(tx, requests, timeStamp); err != nil {
+		return err
+	}
+	if err := db.addCurrentWorkflow(tx, shardID, domainID, workflowID, currentWorkflowRequest); err != nil {
+		return err
+	}
+	if err := db.addCreateExecution(tx, shardID, execution); err != nil {
+		return err
+	}
+	if err := db.addActiveClusterSelectionPolicy(tx, activeClusterSelectionPolicyRow); err != nil {
+		return err
+	}
+	if err := db.addHistoryTasks(tx, shardID, tasksByCategory); err != nil {
+		return err
+	}
+
+	failures, err := db.writeWorkflowTransaction(ctx, tx, *shardCondition)
+	if err != nil || len(failures) == 0 {
+		return err
+	}
+	return db.createWorkflowConditionFailure(tx, failures, currentWorkflowRequest, execution, shardCondition)
 }
 
 func (db *ddb) UpdateWorkflowExecutionWithTasks(
@@ -54,113 +85,452 @@ func (db *ddb) UpdateWorkflowExecutionWithTasks(
 	tasksByCategory map[persistence.HistoryTaskCategory][]*nosqlplugin.HistoryMigrationTask,
 	shardCondition *nosqlplugin.ShardCondition,
 ) error {
-	panic("TODO")
+	shardID := shardCondition.ShardID
+	var domainID, workflowID string
+	var timeStamp time.Time
+	switch {
+	case mutatedExecution != nil:
+		domainID, workflowID, timeStamp = mutatedExecution.DomainID, mutatedExecution.WorkflowID, mutatedExecution.CurrentTimeStamp
+	case resetExecution != nil:
+		domainID, workflowID, timeStamp = resetExecution.DomainID, resetExecution.WorkflowID, resetExecution.CurrentTimeStamp
+	default:
+		return fmt.Errorf("at least one of mutatedExecution and resetExecution should be provided")
+	}
+
+	tx := newWorkflowTransaction()
+	if err := db.addWorkflowRequests(tx, requests, timeStamp); err != nil {
+		return err
+	}
+	if err := db.addCurrentWorkflow(tx, shardID, domainID, workflowID, currentWorkflowRequest); err != nil {
+		return err
+	}
+	if mutatedExecution != nil {
+		if err := db.addUpdateExecution(tx, shardID, mutatedExecution, false); err != nil {
+			return err
+		}
+	}
+	if insertedExecution != nil {
+		if err := db.addCreateExecution(tx, shardID, insertedExecution); err != nil {
+			return err
+		}
+		if err := db.addActiveClusterSelectionPolicy(tx, activeClusterSelectionPolicyRow); err != nil {
+			return err
+		}
+	}
+	if resetExecution != nil {
+		if err := db.addUpdateExecution(tx, shardID, resetExecution, true); err != nil {
+			return err
+		}
+	}
+	if err := db.addHistoryTasks(tx, shardID, tasksByCategory); err != nil {
+		return err
+	}
+
+	failures, err := db.writeWorkflowTransaction(ctx, tx, *shardCondition)
+	if err != nil || len(failures) == 0 {
+		return err
+	}
+	return db.updateWorkflowConditionFailure(tx, failures, currentWorkflowRequest, shardCondition)
 }
 
 func (db *ddb) SelectCurrentWorkflow(ctx context.Context, shardID int, domainID, workflowID string) (*nosqlplugin.CurrentWorkflowRow, error) {
-	panic("TODO")
+	item, err := db.getItem(ctx, cadence.CurrentWorkflowsTableName, primaryKey(shardKey(shardID), currentWorkflowSortKey(domainID, workflowID)))
+	if err != nil {
+		return nil, err
+	}
+	return parseCurrentWorkflowRow(item)
 }
 
 func (db *ddb) SelectWorkflowExecution(ctx context.Context, shardID int, domainID, workflowID, runID string) (*nosqlplugin.WorkflowExecution, error) {
-	panic("TODO")
+	item, err := db.getItem(ctx, cadence.ExecutionsTableName, primaryKey(shardKey(shardID), executionSortKey(domainID, workflowID, runID)))
+	if err != nil {
+		return nil, err
+	}
+	return parseWorkflowExecution(item)
 }
 
 func (db *ddb) DeleteCurrentWorkflow(ctx context.Context, shardID int, domainID, workflowID, currentRunIDCondition string) error {
-	panic("TODO")
+	b := newExpressionBuilder()
+	_, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName:                 aws.String(db.tableName(cadence.CurrentWorkflowsTableName)),
+		Key:                       primaryKey(shardKey(shardID), currentWorkflowSortKey(domainID, workflowID)),
+		ConditionExpression:       aws.String(attributeEquals(b, attrRunID, stringAttr(currentRunIDCondition))),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	if db.IsConditionFailedError(err) {
+		// same as Cassandra, the current workflow has moved on to another run which must not be deleted
+		return nil
+	}
+	return err
 }
 
 func (db *ddb) DeleteWorkflowExecution(ctx context.Context, shardID int, domainID, workflowID, runID string) error {
-	panic("TODO")
+	_, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(db.tableName(cadence.ExecutionsTableName)),
+		Key:       primaryKey(shardKey(shardID), executionSortKey(domainID, workflowID, runID)),
+	})
+	return err
 }
 
 func (db *ddb) SelectWorkflowTimerTasks(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]persistence.HistoryTaskKey, error) {
-	panic("TODO")
+	b := newExpressionBuilder()
+	item, err := itemNotFoundOrError(db.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:                aws.String(db.tableName(cadence.ExecutionsTableName)),
+		Key:                      primaryKey(shardKey(shardID), executionSortKey(domainID, workflowID, runID)),
+		ProjectionExpression:     aws.String(b.name(attrWorkflowTimerTasks)),
+		ExpressionAttributeNames: b.attributeNames(),
+		ConsistentRead:           aws.Bool(true),
+	}))
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseWorkflowTimerTasks(item)
 }
 
 func (db *ddb) SelectAllCurrentWorkflows(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.CurrentWorkflowExecution, []byte, error) {
-	panic("TODO")
+	items, nextPageToken, err := db.queryPage(ctx, db.partitionQuery(cadence.CurrentWorkflowsTableName, shardKey(shardID)), pageSize, pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	executions := make([]*persistence.CurrentWorkflowExecution, 0, len(items))
+	for _, item := range items {
+		row, err := parseCurrentWorkflowRow(item)
+		if err != nil {
+			return nil, nil, err
+		}
+		executions = append(executions, &persistence.CurrentWorkflowExecution{
+			DomainID:     row.DomainID,
+			WorkflowID:   row.WorkflowID,
+			RunID:        row.RunID,
+			State:        row.State,
+			CurrentRunID: row.RunID,
+		})
+	}
+	return executions, nextPageToken, nil
 }
 
 func (db *ddb) SelectAllWorkflowExecutions(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.InternalListConcreteExecutionsEntity, []byte, error) {
-	panic("TODO")
+	items, nextPageToken, err := db.queryPage(ctx, db.partitionQuery(cadence.ExecutionsTableName, shardKey(shardID)), pageSize, pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	executions := make([]*persistence.InternalListConcreteExecutionsEntity, 0, len(items))
+	for _, item := range items {
+		data := &executionData{}
+		if err := getData(item, data); err != nil {
+			return nil, nil, err
+		}
+		executions = append(executions, &persistence.InternalListConcreteExecutionsEntity{
+			ExecutionInfo:    data.ExecutionInfo,
+			VersionHistories: data.VersionHistories,
+		})
+	}
+	return executions, nextPageToken, nil
 }
 
 func (db *ddb) IsWorkflowExecutionExists(ctx context.Context, shardID int, domainID, workflowID, runID string) (bool, error) {
-	panic("TODO")
+	b := newExpressionBuilder()
+	out, err := db.client.GetItemWithContext(ctx, &dynamodb.GetItemInput{
+		TableName:                aws.String(db.tableName(cadence.ExecutionsTableName)),
+		Key:                      primaryKey(shardKey(shardID), executionSortKey(domainID, workflowID, runID)),
+		ProjectionExpression:     aws.String(b.name(attrRunID)),
+		ExpressionAttributeNames: b.attributeNames(),
+		ConsistentRead:           aws.Bool(true),
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(out.Item) > 0, nil
 }
 
 func (db *ddb) SelectTransferTasksOrderByTaskID(ctx context.Context, shardID, pageSize int, pageToken []byte, inclusiveMinTaskID, exclusiveMaxTaskID int64) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
-	panic("TODO")
+	return db.selectTasksByTaskID(ctx, historyTaskPartition(shardID, taskPartitionTransfer), pageSize, pageToken, inclusiveMinTaskID, exclusiveMaxTaskID)
 }
 
 func (db *ddb) DeleteTransferTask(ctx context.Context, shardID int, keys []persistence.HistoryTaskKey) error {
-	panic("TODO")
+	pk := historyTaskPartition(shardID, taskPartitionTransfer)
+	taskKeys := make([]map[string]*dynamodb.AttributeValue, 0, len(keys))
+	for _, key := range keys {
+		taskKeys = append(taskKeys, primaryKey(pk, encodeSortableInt64(key.GetTaskID())))
+	}
+	return db.batchDelete(ctx, cadence.HistoryTasksTableName, taskKeys)
 }
 
 func (db *ddb) RangeDeleteTransferTasks(ctx context.Context, shardID int, inclusiveBeginTaskID, exclusiveEndTaskID int64) error {
-	panic("TODO")
+	if exclusiveEndTaskID <= inclusiveBeginTaskID {
+		return nil
+	}
+	_, err := db.deleteByQuery(ctx, cadence.HistoryTasksTableName, db.rangeQuery(
+		cadence.HistoryTasksTableName,
+		historyTaskPartition(shardID, taskPartitionTransfer),
+		encodeSortableInt64(inclusiveBeginTaskID),
+		encodeSortableInt64(exclusiveEndTaskID-1),
+	))
+	return err
 }
 
 func (db *ddb) SelectTimerTasksOrderByVisibilityTime(ctx context.Context, shardID, pageSize int, pageToken []byte, inclusiveMinTime, exclusiveMaxTime time.Time) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
-	panic("TODO")
+	if !inclusiveMinTime.Before(exclusiveMaxTime) {
+		return nil, nil, nil
+	}
+	items, nextPageToken, err := db.queryPage(ctx, db.timerRangeQuery(shardID, inclusiveMinTime, exclusiveMaxTime), pageSize, pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks, err := parseHistoryTasks(items)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, nextPageToken, nil
 }
 
 func (db *ddb) DeleteTimerTask(ctx context.Context, shardID int, keys []persistence.HistoryTaskKey) error {
-	panic("TODO")
+	pk := historyTaskPartition(shardID, taskPartitionTimer)
+	taskKeys := make([]map[string]*dynamodb.AttributeValue, 0, len(keys))
+	for _, key := range keys {
+		taskKeys = append(taskKeys, primaryKey(pk, timerTaskSortKey(key.GetScheduledTime(), key.GetTaskID())))
+	}
+	return db.batchDelete(ctx, cadence.HistoryTasksTableName, taskKeys)
 }
 
 func (db *ddb) RangeDeleteTimerTasks(ctx context.Context, shardID int, inclusiveMinTime, exclusiveMaxTime time.Time) error {
-	panic("TODO")
+	if !inclusiveMinTime.Before(exclusiveMaxTime) {
+		return nil
+	}
+	_, err := db.deleteByQuery(ctx, cadence.HistoryTasksTableName, db.timerRangeQuery(shardID, inclusiveMinTime, exclusiveMaxTime))
+	return err
 }
 
 func (db *ddb) SelectReplicationTasksOrderByTaskID(ctx context.Context, shardID, pageSize int, pageToken []byte, inclusiveMinTaskID, exclusiveMaxTaskID int64) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
-	panic("TODO")
+	return db.selectTasksByTaskID(ctx, historyTaskPartition(shardID, taskPartitionReplication), pageSize, pageToken, inclusiveMinTaskID, exclusiveMaxTaskID)
 }
 
 func (db *ddb) DeleteReplicationTask(ctx context.Context, shardID int, keys []persistence.HistoryTaskKey) error {
-	panic("TODO")
+	pk := historyTaskPartition(shardID, taskPartitionReplication)
+	taskKeys := make([]map[string]*dynamodb.AttributeValue, 0, len(keys))
+	for _, key := range keys {
+		taskKeys = append(taskKeys, primaryKey(pk, encodeSortableInt64(key.GetTaskID())))
+	}
+	return db.batchDelete(ctx, cadence.HistoryTasksTableName, taskKeys)
 }
 
 func (db *ddb) RangeDeleteReplicationTasks(ctx context.Context, shardID int, exclusiveEndTaskID int64) error {
-	panic("TODO")
+	b := newExpressionBuilder()
+	pk := historyTaskPartition(shardID, taskPartitionReplication)
+	_, err := db.deleteByQuery(ctx, cadence.HistoryTasksTableName, &dynamodb.QueryInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTasksTableName)),
+		KeyConditionExpression: aws.String(fmt.Sprintf("%v = %v AND %v < %v",
+			b.name(attrPK), b.value(stringAttr(pk)), b.name(attrSK), b.value(stringAttr(encodeSortableInt64(exclusiveEndTaskID))))),
+		ProjectionExpression:      aws.String(b.name(attrPK) + ", " + b.name(attrSK)),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	})
+	return err
 }
 
 func (db *ddb) InsertReplicationTask(ctx context.Context, tasks []*nosqlplugin.HistoryMigrationTask, condition nosqlplugin.ShardCondition) error {
-	panic("TODO")
+	if len(tasks) == 0 {
+		return nil
+	}
+	return db.InsertHistoryTasks(ctx, map[persistence.HistoryTaskCategory][]*nosqlplugin.HistoryMigrationTask{
+		persistence.HistoryTaskCategoryReplication: tasks,
+	}, tasks[0].Replication.CurrentTimeStamp, condition)
 }
 
+// InsertHistoryTasks writes the tasks in chunks, each chunk is a transaction which checks the shard rangeID.
+// Unlike Cassandra, the tasks are not written atomically when they exceed the transaction limit,
+// which is acceptable because a task is idempotent and a failed write is retried as a whole.
 func (db *ddb) InsertHistoryTasks(ctx context.Context, tasksByCategory map[persistence.HistoryTaskCategory][]*nosqlplugin.HistoryMigrationTask, currentTimeStamp time.Time, condition nosqlplugin.ShardCondition) error {
-	panic("TODO")
+	var items []*dynamodb.TransactWriteItem
+	for category, tasks := range tasksByCategory {
+		for _, task := range tasks {
+			item, err := newHistoryTaskItem(condition.ShardID, category, task)
+			if err != nil {
+				return err
+			}
+			items = append(items, db.putTransactItem(cadence.HistoryTasksTableName, item, "", nil))
+		}
+	}
+	return db.writeHistoryTaskItems(ctx, items, condition)
+}
+
+// writeWorkflowTransaction writes the items of a workflow transaction together with its history tasks
+// in a single DynamoDB transaction, fenced by the shard rangeID.
+// A workflow write is never split: when it doesn't fit in the limit of a transaction,
+// TransactionSizeLimitError is returned and nothing is written.
+func (db *ddb) writeWorkflowTransaction(ctx context.Context, tx *workflowTransaction, condition nosqlplugin.ShardCondition) (conditionFailures, error) {
+	if size := len(tx.items) + 1; size > maxTransactionItems {
+		return nil, &persistence.TransactionSizeLimitError{
+			Msg: fmt.Sprintf("workflow write needs %v items including its history tasks, which exceeds the DynamoDB transaction limit of %v items", size, maxTransactionItems),
+		}
+	}
+	tx.shardIndex = tx.add(db.shardConditionCheck(condition))
+	return db.transactWrite(ctx, tx.items)
+}
+
+// writeHistoryTaskItems writes the tasks in chunks, each chunk is a transaction which checks the shard rangeID
+func (db *ddb) writeHistoryTaskItems(ctx context.Context, items []*dynamodb.TransactWriteItem, condition nosqlplugin.ShardCondition) error {
+	for start := 0; start < len(items) || start == 0; start += maxTransactionItems - 1 {
+		end := start + maxTransactionItems - 1
+		if end > len(items) {
+			end = len(items)
+		}
+		chunk := append(items[start:end:end], db.shardConditionCheck(condition))
+		failures, err := db.transactWrite(ctx, chunk)
+		if err != nil {
+			return err
+		}
+		if len(failures) > 0 {
+			return historyTaskConditionFailure(failures, len(chunk)-1, condition)
+		}
+	}
+	return nil
 }
 
 func (db *ddb) DeleteCrossClusterTask(ctx context.Context, shardID int, targetCluster string, taskID int64) error {
-	panic("TODO")
+	_, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTasksTableName)),
+		Key:       primaryKey(historyTaskPartition(shardID, taskPartitionCrossCluster, targetCluster), encodeSortableInt64(taskID)),
+	})
+	return err
 }
 
 func (db *ddb) InsertReplicationDLQTask(ctx context.Context, shardID int, sourceCluster string, task *nosqlplugin.HistoryMigrationTask) error {
-	panic("TODO")
+	item, err := newItem(
+		historyTaskPartition(shardID, taskPartitionReplicationDLQ, sourceCluster),
+		encodeSortableInt64(task.Replication.TaskID),
+		task,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = db.client.PutItemWithContext(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTasksTableName)),
+		Item:      item,
+	})
+	return err
 }
 
 func (db *ddb) SelectReplicationDLQTasksOrderByTaskID(ctx context.Context, shardID int, sourceCluster string, pageSize int, pageToken []byte, inclusiveMinTaskID, exclusiveMaxTaskID int64) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
-	panic("TODO")
+	return db.selectTasksByTaskID(ctx, historyTaskPartition(shardID, taskPartitionReplicationDLQ, sourceCluster), pageSize, pageToken, inclusiveMinTaskID, exclusiveMaxTaskID)
 }
 
 func (db *ddb) SelectReplicationDLQTasksCount(ctx context.Context, shardID int, sourceCluster string) (int64, error) {
-	panic("TODO")
+	return db.queryCount(ctx, db.partitionQuery(cadence.HistoryTasksTableName, historyTaskPartition(shardID, taskPartitionReplicationDLQ, sourceCluster)))
 }
 
 func (db *ddb) DeleteReplicationDLQTask(ctx context.Context, shardID int, sourceCluster string, taskID int64) error {
-	panic("TODO")
+	_, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(db.tableName(cadence.HistoryTasksTableName)),
+		Key:       primaryKey(historyTaskPartition(shardID, taskPartitionReplicationDLQ, sourceCluster), encodeSortableInt64(taskID)),
+	})
+	return err
 }
 
 func (db *ddb) RangeDeleteReplicationDLQTasks(ctx context.Context, shardID int, sourceCluster string, inclusiveBeginTaskID, exclusiveEndTaskID int64) error {
-	panic("TODO")
+	if exclusiveEndTaskID <= inclusiveBeginTaskID {
+		return nil
+	}
+	_, err := db.deleteByQuery(ctx, cadence.HistoryTasksTableName, db.rangeQuery(
+		cadence.HistoryTasksTableName,
+		historyTaskPartition(shardID, taskPartitionReplicationDLQ, sourceCluster),
+		encodeSortableInt64(inclusiveBeginTaskID),
+		encodeSortableInt64(exclusiveEndTaskID-1),
+	))
+	return err
 }
 
 func (db *ddb) SelectActiveClusterSelectionPolicy(ctx context.Context, shardID int, domainID, wfID, rID string) (*nosqlplugin.ActiveClusterSelectionPolicyRow, error) {
-	panic("TODO")
+	item, err := db.getItem(ctx, cadence.ActiveClusterSelectionPoliciesTableName, primaryKey(shardKey(shardID), executionSortKey(domainID, wfID, rID)))
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	policy := &persistence.DataBlob{}
+	if err := getData(item, policy); err != nil {
+		return nil, err
+	}
+	return &nosqlplugin.ActiveClusterSelectionPolicyRow{
+		ShardID:    shardID,
+		DomainID:   domainID,
+		WorkflowID: wfID,
+		RunID:      rID,
+		Policy:     policy,
+	}, nil
 }
 
 func (db *ddb) DeleteActiveClusterSelectionPolicy(ctx context.Context, shardID int, domainID, wfID, rID string) error {
-	panic("TODO")
+	_, err := db.client.DeleteItemWithContext(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(db.tableName(cadence.ActiveClusterSelectionPoliciesTableName)),
+		Key:       primaryKey(shardKey(shardID), executionSortKey(domainID, wfID, rID)),
+	})
+	return err
+}
+
+func (db *ddb) selectTasksByTaskID(
+	ctx context.Context,
+	pk string,
+	pageSize int,
+	pageToken []byte,
+	inclusiveMinTaskID int64,
+	exclusiveMaxTaskID int64,
+) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
+	if exclusiveMaxTaskID <= inclusiveMinTaskID {
+		return nil, nil, nil
+	}
+	input := db.rangeQuery(cadence.HistoryTasksTableName, pk, encodeSortableInt64(inclusiveMinTaskID), encodeSortableInt64(exclusiveMaxTaskID-1))
+	input.ProjectionExpression = nil
+	items, nextPageToken, err := db.queryPage(ctx, input, pageSize, pageToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks, err := parseHistoryTasks(items)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, nextPageToken, nil
+}
+
+// timerRangeQuery queries the timer tasks in [inclusiveMinTime, exclusiveMaxTime).
+// The sort key of a timer task is prefixed with the encoded visibility timestamp, so the encoded exclusiveMaxTime
+// alone sorts before all the tasks of exclusiveMaxTime.
+func (db *ddb) timerRangeQuery(shardID int, inclusiveMinTime, exclusiveMaxTime time.Time) *dynamodb.QueryInput {
+	input := db.rangeQuery(
+		cadence.HistoryTasksTableName,
+		historyTaskPartition(shardID, taskPartitionTimer),
+		encodeSortableTime(inclusiveMinTime),
+		encodeSortableTime(exclusiveMaxTime),
+	)
+	input.ProjectionExpression = nil
+	return input
+}
+
+// partitionQuery queries all the items of a partition in the order of the sort key
+func (db *ddb) partitionQuery(table, pk string) *dynamodb.QueryInput {
+	b := newExpressionBuilder()
+	return &dynamodb.QueryInput{
+		TableName:                 aws.String(db.tableName(table)),
+		KeyConditionExpression:    aws.String(fmt.Sprintf("%v = %v", b.name(attrPK), b.value(stringAttr(pk)))),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	}
+}
+
+// rangeQuery queries the items of a partition with the sort key in [from, to], projecting only the primary key
+func (db *ddb) rangeQuery(table, pk, from, to string) *dynamodb.QueryInput {
+	b := newExpressionBuilder()
+	return &dynamodb.QueryInput{
+		TableName: aws.String(db.tableName(table)),
+		KeyConditionExpression: aws.String(fmt.Sprintf("%v = %v AND %v BETWEEN %v AND %v",
+			b.name(attrPK), b.value(stringAttr(pk)), b.name(attrSK), b.value(stringAttr(from)), b.value(stringAttr(to)))),
+		ProjectionExpression:      aws.String(b.name(attrPK) + ", " + b.name(attrSK)),
+		ExpressionAttributeNames:  b.attributeNames(),
+		ExpressionAttributeValues: b.attributeValues(),
+	}
 }
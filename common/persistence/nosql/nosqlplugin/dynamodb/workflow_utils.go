@@ -0,0 +1,852 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package dynamodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/dynamodb/cadence"
+)
+
+const (
+	attrDomainID         = "domain_id"
+	attrWorkflowID       = "workflow_id"
+	attrRunID            = "run_id"
+	attrNextEventID      = "next_event_id"
+	attrLastWriteVersion = "last_write_version"
+	attrState            = "state"
+
+	// the maps of workflow execution item, the values are json encoded
+	attrActivityMap        = "activity_map"
+	attrTimerMap           = "timer_map"
+	attrChildExecutionsMap = "child_executions_map"
+	attrRequestCancelMap   = "request_cancel_map"
+	attrSignalMap          = "signal_map"
+	attrSignalRequested    = "signal_requested"
+	attrWorkflowTimerTasks = "workflow_timer_tasks"
+	attrBufferedEvents     = "buffered_events"
+
+	// same as Cassandra, workflow requests are kept for 3 hours for deduplication
+	workflowRequestTTLInSeconds = 10800
+)
+
+// history task categories are stored in different partitions of the history_tasks table
+const (
+	taskPartitionTransfer       = "transfer"
+	taskPartitionTimer          = "timer"
+	taskPartitionReplication    = "replication"
+	taskPartitionReplicationDLQ = "replication_dlq"
+	taskPartitionCrossCluster   = "cross_cluster"
+)
+
+type (
+	// executionData is the data attribute of a workflow execution item
+	executionData struct {
+		ExecutionInfo    *persistence.InternalWorkflowExecutionInfo
+		VersionHistories *persistence.DataBlob
+		Checksum         checksum.Checksum
+	}
+
+	// workflowTimerTask is an entry of the workflow_timer_tasks map
+	workflowTimerTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+	}
+
+	// workflowTransaction tracks which transaction items carry a condition, so that a cancelled
+	// transaction can be translated into WorkflowOperationConditionFailure
+	workflowTransaction struct {
+		items                []*dynamodb.TransactWriteItem
+		shardIndex           int
+		currentWorkflowIndex int
+		// mutated or reset execution, keyed by the item index
+		updatedExecutions map[int]*nosqlplugin.WorkflowExecutionRequest
+		// created execution, keyed by the item index
+		createdExecutions map[int]*nosqlplugin.WorkflowExecutionRequest
+		requests          map[int]*nosqlplugin.WorkflowRequestRow
+	}
+)
+
+func newWorkflowTransaction() *workflowTransaction {
+	return &workflowTransaction{
+		shardIndex:           -1,
+		currentWorkflowIndex: -1,
+		updatedExecutions:    make(map[int]*nosqlplugin.WorkflowExecutionRequest),
+		createdExecutions:    make(map[int]*nosqlplugin.WorkflowExecutionRequest),
+		requests:             make(map[int]*nosqlplugin.WorkflowRequestRow),
+	}
+}
+
+func (t *workflowTransaction) add(item *dynamodb.TransactWriteItem) int {
+	t.items = append(t.items, item)
+	return len(t.items) - 1
+}
+
+func currentWorkflowSortKey(domainID, workflowID string) string {
+	return compositeKey(domainID, workflowID)
+}
+
+func executionSortKey(domainID, workflowID, runID string) string {
+	return compositeKey(domainID, workflowID, runID)
+}
+
+func workflowRequestKey(row *nosqlplugin.WorkflowRequestRow) map[string]*dynamodb.AttributeValue {
+	return primaryKey(
+		compositeKey(shardKey(row.ShardID), row.DomainID, row.WorkflowID),
+		compositeKey(strconv.Itoa(int(row.RequestType)), row.RequestID),
+	)
+}
+
+func historyTaskPartition(shardID int, partition ...string) string {
+	return compositeKey(append([]string{shardKey(shardID)}, partition...)...)
+}
+
+func timerTaskSortKey(visibilityTimestamp time.Time, taskID int64) string {
+	return compositeKey(encodeSortableTime(visibilityTimestamp), encodeSortableInt64(taskID))
+}
+
+func workflowTimerTaskKey(key persistence.HistoryTaskKey) string {
+	return timerTaskSortKey(key.GetScheduledTime(), key.GetTaskID())
+}
+
+func (db *ddb) addWorkflowRequests(
+	tx *workflowTransaction,
+	requests *nosqlplugin.WorkflowRequestsWriteRequest,
+	timeStamp time.Time,
+) error {
+	if requests == nil {
+		return nil
+	}
+	for _, row := range requests.Rows {
+		key := workflowRequestKey(row)
+		item, err := newItem(*key[attrPK].S, *key[attrSK].S, row)
+		if err != nil {
+			return err
+		}
+		item[attrRunID] = stringAttr(row.RunID)
+		item[attrTTL] = ttlAttr(timeStamp, workflowRequestTTLInSeconds)
+		switch requests.WriteMode {
+		case nosqlplugin.WorkflowRequestWriteModeInsert:
+			b := newExpressionBuilder()
+			tx.requests[tx.add(db.putTransactItem(cadence.WorkflowRequestsTableName, item, attributeNotExists(b), b))] = row
+		case nosqlplugin.WorkflowRequestWriteModeUpsert:
+			tx.add(db.putTransactItem(cadence.WorkflowRequestsTableName, item, "", nil))
+		default:
+			return fmt.Errorf("unknown workflow request write mode %v", requests.WriteMode)
+		}
+	}
+	return nil
+}
+
+func (db *ddb) addCurrentWorkflow(
+	tx *workflowTransaction,
+	shardID int,
+	domainID string,
+	workflowID string,
+	request *nosqlplugin.CurrentWorkflowWriteRequest,
+) error {
+	if request.WriteMode == nosqlplugin.CurrentWorkflowWriteModeNoop {
+		return nil
+	}
+	row := request.Row
+	row.ShardID = shardID
+	row.DomainID = domainID
+	row.WorkflowID = workflowID
+	item, err := newItem(shardKey(shardID), currentWorkflowSortKey(domainID, workflowID), &row)
+	if err != nil {
+		return err
+	}
+	item[attrRunID] = stringAttr(row.RunID)
+	item[attrState] = numberAttr(int64(row.State))
+	item[attrLastWriteVersion] = numberAttr(row.LastWriteVersion)
+
+	b := newExpressionBuilder()
+	var condition string
+	switch request.WriteMode {
+	case nosqlplugin.CurrentWorkflowWriteModeInsert:
+		condition = attributeNotExists(b)
+	case nosqlplugin.CurrentWorkflowWriteModeUpdate:
+		if request.Condition == nil || request.Condition.GetCurrentRunID() == "" {
+			return fmt.Errorf("CurrentWorkflowWriteModeUpdate require Condition.CurrentRunID")
+		}
+		conditions := []string{attributeEquals(b, attrRunID, stringAttr(*request.Condition.CurrentRunID))}
+		if request.Condition.LastWriteVersion != nil && request.Condition.State != nil {
+			conditions = append(conditions,
+				attributeEquals(b, attrLastWriteVersion, numberAttr(*request.Condition.LastWriteVersion)),
+				attributeEquals(b, attrState, numberAttr(int64(*request.Condition.State))),
+			)
+		}
+		condition = strings.Join(conditions, " AND ")
+	default:
+		return fmt.Errorf("unknown mode %v", request.WriteMode)
+	}
+	tx.currentWorkflowIndex = tx.add(db.putTransactItem(cadence.CurrentWorkflowsTableName, item, condition, b))
+	return nil
+}
+
+func (db *ddb) addCreateExecution(
+	tx *workflowTransaction,
+	shardID int,
+	execution *nosqlplugin.WorkflowExecutionRequest,
+) error {
+	if execution.EventBufferWriteMode != nosqlplugin.EventBufferWriteModeNone {
+		return fmt.Errorf("should only support EventBufferWriteModeNone")
+	}
+	if execution.MapsWriteMode != nosqlplugin.WorkflowExecutionMapsWriteModeCreate {
+		return fmt.Errorf("should only support WorkflowExecutionMapsWriteModeCreate")
+	}
+	item, err := newItem(
+		shardKey(shardID),
+		executionSortKey(execution.DomainID, execution.WorkflowID, execution.RunID),
+		newExecutionData(execution),
+	)
+	if err != nil {
+		return err
+	}
+	item[attrDomainID] = stringAttr(execution.DomainID)
+	item[attrWorkflowID] = stringAttr(execution.WorkflowID)
+	item[attrRunID] = stringAttr(execution.RunID)
+	item[attrNextEventID] = numberAttr(execution.NextEventID)
+	item[attrLastWriteVersion] = numberAttr(execution.LastWriteVersion)
+	item[attrState] = numberAttr(int64(execution.State))
+	maps, err := newExecutionMaps(execution)
+	if err != nil {
+		return err
+	}
+	for name, value := range maps {
+		item[name] = value
+	}
+	item[attrBufferedEvents] = &dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{}}
+
+	b := newExpressionBuilder()
+	index := tx.add(db.putTransactItem(cadence.ExecutionsTableName, item, attributeNotExists(b), b))
+	tx.createdExecutions[index] = execution
+	return nil
+}
+
+// addUpdateExecution updates an existing workflow execution, and merges(update mode) or overrides(reset mode) the maps
+func (db *ddb) addUpdateExecution(
+	tx *workflowTransaction,
+	shardID int,
+	execution *nosqlplugin.WorkflowExecutionRequest,
+	reset bool,
+) error {
+	if reset {
+		if execution.EventBufferWriteMode != nosqlplugin.EventBufferWriteModeClear {
+			return fmt.Errorf("should only support EventBufferWriteModeClear")
+		}
+		if execution.MapsWriteMode != nosqlplugin.WorkflowExecutionMapsWriteModeReset {
+			return fmt.Errorf("should only support WorkflowExecutionMapsWriteModeReset")
+		}
+	} else if execution.MapsWriteMode != nosqlplugin.WorkflowExecutionMapsWriteModeUpdate {
+		return fmt.Errorf("should only support WorkflowExecutionMapsWriteModeUpdate")
+	}
+	if execution.PreviousNextEventIDCondition == nil {
+		return fmt.Errorf("PreviousNextEventIDCondition is required for updating workflow execution")
+	}
+
+	b := newExpressionBuilder()
+	u := &updateExpression{}
+	data, err := jsonAttr(newExecutionData(execution))
+	if err != nil {
+		return err
+	}
+	u.set = append(u.set,
+		b.name(attrData)+" = "+b.value(data),
+		b.name(attrNextEventID)+" = "+b.value(numberAttr(execution.NextEventID)),
+		b.name(attrLastWriteVersion)+" = "+b.value(numberAttr(execution.LastWriteVersion)),
+		b.name(attrState)+" = "+b.value(numberAttr(int64(execution.State))),
+	)
+
+	if reset {
+		maps, err := newExecutionMaps(execution)
+		if err != nil {
+			return err
+		}
+		// workflow timer tasks are always appended, same as other modes
+		delete(maps, attrWorkflowTimerTasks)
+		for name, value := range maps {
+			u.set = append(u.set, b.name(name)+" = "+b.value(value))
+		}
+	} else if err := mergeExecutionMaps(b, u, execution); err != nil {
+		return err
+	}
+
+	for _, key := range execution.WorkflowTimerTasks {
+		value, err := jsonAttr(workflowTimerTask{VisibilityTimestamp: key.GetScheduledTime(), TaskID: key.GetTaskID()})
+		if err != nil {
+			return err
+		}
+		u.set = append(u.set, b.path(attrWorkflowTimerTasks, workflowTimerTaskKey(key))+" = "+b.value(value))
+	}
+
+	switch execution.EventBufferWriteMode {
+	case nosqlplugin.EventBufferWriteModeClear:
+		u.set = append(u.set, b.name(attrBufferedEvents)+" = "+b.value(&dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{}}))
+	case nosqlplugin.EventBufferWriteModeAppend:
+		batch, err := jsonAttr(execution.NewBufferedEventBatch)
+		if err != nil {
+			return err
+		}
+		u.set = append(u.set, fmt.Sprintf("%v = list_append(%v, %v)",
+			b.name(attrBufferedEvents), b.name(attrBufferedEvents), b.value(&dynamodb.AttributeValue{L: []*dynamodb.AttributeValue{batch}})))
+	}
+
+	condition := attributeEquals(b, attrNextEventID, numberAttr(*execution.PreviousNextEventIDCondition))
+	key := primaryKey(shardKey(shardID), executionSortKey(execution.DomainID, execution.WorkflowID, execution.RunID))
+	index := tx.add(db.updateTransactItem(cadence.ExecutionsTableName, key, u.String(), condition, b))
+	tx.updatedExecutions[index] = execution
+	return nil
+}
+
+func (db *ddb) addActiveClusterSelectionPolicy(tx *workflowTransaction, row *nosqlplugin.ActiveClusterSelectionPolicyRow) error {
+	if row == nil || row.Policy == nil {
+		return nil
+	}
+	item, err := newItem(shardKey(row.ShardID), executionSortKey(row.DomainID, row.WorkflowID, row.RunID), row.Policy)
+	if err != nil {
+		return err
+	}
+	tx.add(db.putTransactItem(cadence.ActiveClusterSelectionPoliciesTableName, item, "", nil))
+	return nil
+}
+
+func (db *ddb) addHistoryTasks(
+	tx *workflowTransaction,
+	shardID int,
+	tasksByCategory map[persistence.HistoryTaskCategory][]*nosqlplugin.HistoryMigrationTask,
+) error {
+	for category, tasks := range tasksByCategory {
+		for _, task := range tasks {
+			item, err := newHistoryTaskItem(shardID, category, task)
+			if err != nil {
+				return err
+			}
+			tx.add(db.putTransactItem(cadence.HistoryTasksTableName, item, "", nil))
+		}
+	}
+	return nil
+}
+
+// newHistoryTaskItem returns an error for the categories that are not supported,
+// so that tasks are never dropped silently
+func newHistoryTaskItem(
+	shardID int,
+	category persistence.HistoryTaskCategory,
+	task *nosqlplugin.HistoryMigrationTask,
+) (map[string]*dynamodb.AttributeValue, error) {
+	switch category.ID() {
+	case persistence.HistoryTaskCategoryIDTransfer:
+		return newItem(historyTaskPartition(shardID, taskPartitionTransfer), encodeSortableInt64(task.Transfer.TaskID), task)
+	case persistence.HistoryTaskCategoryIDTimer:
+		return newItem(
+			historyTaskPartition(shardID, taskPartitionTimer),
+			timerTaskSortKey(task.Timer.VisibilityTimestamp, task.Timer.TaskID),
+			task,
+		)
+	case persistence.HistoryTaskCategoryIDReplication:
+		return newItem(historyTaskPartition(shardID, taskPartitionReplication), encodeSortableInt64(task.Replication.TaskID), task)
+	default:
+		return nil, fmt.Errorf("history task category %v is not supported by DynamoDB", category.Name())
+	}
+}
+
+func newExecutionData(execution *nosqlplugin.WorkflowExecutionRequest) *executionData {
+	data := &executionData{
+		ExecutionInfo:    &execution.InternalWorkflowExecutionInfo,
+		VersionHistories: execution.VersionHistories,
+	}
+	if execution.Checksums != nil {
+		data.Checksum = *execution.Checksums
+	}
+	return data
+}
+
+// newExecutionMaps returns the full maps of an execution
+func newExecutionMaps(execution *nosqlplugin.WorkflowExecutionRequest) (map[string]*dynamodb.AttributeValue, error) {
+	activityMap := make(map[string]*dynamodb.AttributeValue, len(execution.ActivityInfos))
+	for k, v := range execution.ActivityInfos {
+		if err := setJSONEntry(activityMap, strconv.FormatInt(k, 10), v); err != nil {
+			return nil, err
+		}
+	}
+	timerMap := make(map[string]*dynamodb.AttributeValue, len(execution.TimerInfos))
+	for k, v := range execution.TimerInfos {
+		if err := setJSONEntry(timerMap, k, v); err != nil {
+			return nil, err
+		}
+	}
+	childMap := make(map[string]*dynamodb.AttributeValue, len(execution.ChildWorkflowInfos))
+	for k, v := range execution.ChildWorkflowInfos {
+		if err := setJSONEntry(childMap, strconv.FormatInt(k, 10), v); err != nil {
+			return nil, err
+		}
+	}
+	requestCancelMap := make(map[string]*dynamodb.AttributeValue, len(execution.RequestCancelInfos))
+	for k, v := range execution.RequestCancelInfos {
+		if err := setJSONEntry(requestCancelMap, strconv.FormatInt(k, 10), v); err != nil {
+			return nil, err
+		}
+	}
+	signalMap := make(map[string]*dynamodb.AttributeValue, len(execution.SignalInfos))
+	for k, v := range execution.SignalInfos {
+		if err := setJSONEntry(signalMap, strconv.FormatInt(k, 10), v); err != nil {
+			return nil, err
+		}
+	}
+	signalRequested := make(map[string]*dynamodb.AttributeValue, len(execution.SignalRequestedIDs))
+	for _, id := range execution.SignalRequestedIDs {
+		signalRequested[id] = signalRequestedAttr()
+	}
+	timerTasks := make(map[string]*dynamodb.AttributeValue, len(execution.WorkflowTimerTasks))
+	for _, key := range execution.WorkflowTimerTasks {
+		if err := setJSONEntry(timerTasks, workflowTimerTaskKey(key), workflowTimerTask{
+			VisibilityTimestamp: key.GetScheduledTime(),
+			TaskID:              key.GetTaskID(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return map[string]*dynamodb.AttributeValue{
+		attrActivityMap:        {M: activityMap},
+		attrTimerMap:           {M: timerMap},
+		attrChildExecutionsMap: {M: childMap},
+		attrRequestCancelMap:   {M: requestCancelMap},
+		attrSignalMap:          {M: signalMap},
+		attrSignalRequested:    {M: signalRequested},
+		attrWorkflowTimerTasks: {M: timerTasks},
+	}, nil
+}
+
+// mergeExecutionMaps upserts and deletes the entries of the maps by keys, so that the maps don't need to be read first
+func mergeExecutionMaps(b *expressionBuilder, u *updateExpression, execution *nosqlplugin.WorkflowExecutionRequest) error {
+	upserted := make(map[string]struct{})
+	upsert := func(name, key string, v interface{}) error {
+		value, err := jsonAttr(v)
+		if err != nil {
+			return err
+		}
+		path := b.path(name, key)
+		upserted[path] = struct{}{}
+		u.set = append(u.set, path+" = "+b.value(value))
+		return nil
+	}
+	remove := func(name, key string) {
+		// DynamoDB rejects overlapping paths in one expression, the upsert wins
+		path := b.path(name, key)
+		if _, ok := upserted[path]; !ok {
+			u.remove = append(u.remove, path)
+		}
+	}
+
+	for k, v := range execution.ActivityInfos {
+		if err := upsert(attrActivityMap, strconv.FormatInt(k, 10), v); err != nil {
+			return err
+		}
+	}
+	for k, v := range execution.TimerInfos {
+		if err := upsert(attrTimerMap, k, v); err != nil {
+			return err
+		}
+	}
+	for k, v := range execution.ChildWorkflowInfos {
+		if err := upsert(attrChildExecutionsMap, strconv.FormatInt(k, 10), v); err != nil {
+			return err
+		}
+	}
+	for k, v := range execution.RequestCancelInfos {
+		if err := upsert(attrRequestCancelMap, strconv.FormatInt(k, 10), v); err != nil {
+			return err
+		}
+	}
+	for k, v := range execution.SignalInfos {
+		if err := upsert(attrSignalMap, strconv.FormatInt(k, 10), v); err != nil {
+			return err
+		}
+	}
+	for _, id := range execution.SignalRequestedIDs {
+		path := b.path(attrSignalRequested, id)
+		upserted[path] = struct{}{}
+		u.set = append(u.set, path+" = "+b.value(signalRequestedAttr()))
+	}
+
+	for _, k := range execution.ActivityInfoKeysToDelete {
+		remove(attrActivityMap, strconv.FormatInt(k, 10))
+	}
+	for _, k := range execution.TimerInfoKeysToDelete {
+		remove(attrTimerMap, k)
+	}
+	for _, k := range execution.ChildWorkflowInfoKeysToDelete {
+		remove(attrChildExecutionsMap, strconv.FormatInt(k, 10))
+	}
+	for _, k := range execution.RequestCancelInfoKeysToDelete {
+		remove(attrRequestCancelMap, strconv.FormatInt(k, 10))
+	}
+	for _, k := range execution.SignalInfoKeysToDelete {
+		remove(attrSignalMap, strconv.FormatInt(k, 10))
+	}
+	for _, id := range execution.SignalRequestedIDsKeysToDelete {
+		remove(attrSignalRequested, id)
+	}
+	return nil
+}
+
+func setJSONEntry(m map[string]*dynamodb.AttributeValue, key string, v interface{}) error {
+	value, err := jsonAttr(v)
+	if err != nil {
+		return err
+	}
+	m[key] = value
+	return nil
+}
+
+func signalRequestedAttr() *dynamodb.AttributeValue {
+	return &dynamodb.AttributeValue{BOOL: &[]bool{true}[0]}
+}
+
+func parseWorkflowExecution(item map[string]*dynamodb.AttributeValue) (*nosqlplugin.WorkflowExecution, error) {
+	data := &executionData{}
+	if err := getData(item, data); err != nil {
+		return nil, err
+	}
+	state := &nosqlplugin.WorkflowExecution{
+		ExecutionInfo:       data.ExecutionInfo,
+		VersionHistories:    data.VersionHistories,
+		Checksum:            data.Checksum,
+		ActivityInfos:       make(map[int64]*persistence.InternalActivityInfo),
+		TimerInfos:          make(map[string]*persistence.TimerInfo),
+		ChildExecutionInfos: make(map[int64]*persistence.InternalChildExecutionInfo),
+		RequestCancelInfos:  make(map[int64]*persistence.RequestCancelInfo),
+		SignalInfos:         make(map[int64]*persistence.SignalInfo),
+		SignalRequestedIDs:  make(map[string]struct{}),
+	}
+	for k, v := range mapAttr(item, attrActivityMap) {
+		info := &persistence.InternalActivityInfo{}
+		if err := parseInt64Entry(k, v, info, func(id int64) { state.ActivityInfos[id] = info }); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range mapAttr(item, attrTimerMap) {
+		info := &persistence.TimerInfo{}
+		if err := jsonUnmarshalAttr(v, info); err != nil {
+			return nil, err
+		}
+		state.TimerInfos[k] = info
+	}
+	for k, v := range mapAttr(item, attrChildExecutionsMap) {
+		info := &persistence.InternalChildExecutionInfo{}
+		if err := parseInt64Entry(k, v, info, func(id int64) { state.ChildExecutionInfos[id] = info }); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range mapAttr(item, attrRequestCancelMap) {
+		info := &persistence.RequestCancelInfo{}
+		if err := parseInt64Entry(k, v, info, func(id int64) { state.RequestCancelInfos[id] = info }); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range mapAttr(item, attrSignalMap) {
+		info := &persistence.SignalInfo{}
+		if err := parseInt64Entry(k, v, info, func(id int64) { state.SignalInfos[id] = info }); err != nil {
+			return nil, err
+		}
+	}
+	for k := range mapAttr(item, attrSignalRequested) {
+		state.SignalRequestedIDs[k] = struct{}{}
+	}
+	if v, ok := item[attrBufferedEvents]; ok {
+		state.BufferedEvents = make([]*persistence.DataBlob, 0, len(v.L))
+		for _, e := range v.L {
+			blob := &persistence.DataBlob{}
+			if err := jsonUnmarshalAttr(e, blob); err != nil {
+				return nil, err
+			}
+			state.BufferedEvents = append(state.BufferedEvents, blob)
+		}
+	}
+	return state, nil
+}
+
+func parseWorkflowTimerTasks(item map[string]*dynamodb.AttributeValue) ([]persistence.HistoryTaskKey, error) {
+	entries := mapAttr(item, attrWorkflowTimerTasks)
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	keys := make([]persistence.HistoryTaskKey, 0, len(entries))
+	for _, v := range entries {
+		t := workflowTimerTask{}
+		if err := jsonUnmarshalAttr(v, &t); err != nil {
+			return nil, err
+		}
+		keys = append(keys, persistence.NewHistoryTaskKey(t.VisibilityTimestamp, t.TaskID))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Less(keys[j])
+	})
+	return keys, nil
+}
+
+func parseInt64Entry(key string, v *dynamodb.AttributeValue, out interface{}, set func(int64)) error {
+	id, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return err
+	}
+	if err := jsonUnmarshalAttr(v, out); err != nil {
+		return err
+	}
+	set(id)
+	return nil
+}
+
+func parseHistoryTasks(items []map[string]*dynamodb.AttributeValue) ([]*nosqlplugin.HistoryMigrationTask, error) {
+	tasks := make([]*nosqlplugin.HistoryMigrationTask, 0, len(items))
+	for _, item := range items {
+		task := &nosqlplugin.HistoryMigrationTask{}
+		if err := getData(item, task); err != nil {
+			return nil, err
+		}
+		switch {
+		case task.Transfer != nil:
+			task.TaskID = task.Transfer.TaskID
+		case task.Timer != nil:
+			task.TaskID = task.Timer.TaskID
+			task.ScheduledTime = task.Timer.VisibilityTimestamp
+		case task.Replication != nil:
+			task.TaskID = task.Replication.TaskID
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// createWorkflowConditionFailure translates the failed conditions of InsertWorkflowExecutionWithTasks,
+// following the same precedence as the Cassandra implementation
+func (db *ddb) createWorkflowConditionFailure(
+	tx *workflowTransaction,
+	failures conditionFailures,
+	currentWorkflowRequest *nosqlplugin.CurrentWorkflowWriteRequest,
+	execution *nosqlplugin.WorkflowExecutionRequest,
+	shardCondition *nosqlplugin.ShardCondition,
+) error {
+	if err := rangeIDConditionFailure(tx, failures); err != nil {
+		return err
+	}
+	if err := duplicateRequestConditionFailure(tx, failures); err != nil {
+		return err
+	}
+	if item, ok := failures[tx.currentWorkflowIndex]; ok {
+		current, err := parseCurrentWorkflowRow(item)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			msg := fmt.Sprintf("Workflow execution creation condition failed, current workflow doesn't exist. WorkflowId: %v", currentWorkflowRequest.Row.WorkflowID)
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				CurrentWorkflowConditionFailInfo: &msg,
+			}
+		}
+		switch currentWorkflowRequest.WriteMode {
+		case nosqlplugin.CurrentWorkflowWriteModeInsert:
+			msg := fmt.Sprintf("Workflow execution already running. WorkflowId: %v, RunId: %v", currentWorkflowRequest.Row.WorkflowID, current.RunID)
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				WorkflowExecutionAlreadyExists: &nosqlplugin.WorkflowExecutionAlreadyExists{
+					OtherInfo:        msg,
+					CreateRequestID:  current.CreateRequestID,
+					RunID:            current.RunID,
+					State:            current.State,
+					CloseStatus:      current.CloseStatus,
+					LastWriteVersion: current.LastWriteVersion,
+				},
+			}
+		case nosqlplugin.CurrentWorkflowWriteModeUpdate:
+			condition := currentWorkflowRequest.Condition
+			if current.RunID != condition.GetCurrentRunID() {
+				msg := fmt.Sprintf("Workflow execution creation condition failed by mismatch runID. WorkflowId: %v, Expected Current RunID: %v, Actual Current RunID: %v",
+					currentWorkflowRequest.Row.WorkflowID, condition.GetCurrentRunID(), current.RunID)
+				return &nosqlplugin.WorkflowOperationConditionFailure{
+					CurrentWorkflowConditionFailInfo: &msg,
+				}
+			}
+			if condition.LastWriteVersion != nil && *condition.LastWriteVersion != current.LastWriteVersion {
+				msg := fmt.Sprintf("Workflow execution creation condition failed. WorkflowId: %v, Expected Version: %v, Actual Version: %v",
+					currentWorkflowRequest.Row.WorkflowID, *condition.LastWriteVersion, current.LastWriteVersion)
+				return &nosqlplugin.WorkflowOperationConditionFailure{
+					CurrentWorkflowConditionFailInfo: &msg,
+				}
+			}
+			if condition.State != nil && *condition.State != current.State {
+				msg := fmt.Sprintf("Workflow execution creation condition failed. WorkflowId: %v, Expected State: %v, Actual State: %v",
+					currentWorkflowRequest.Row.WorkflowID, *condition.State, current.State)
+				return &nosqlplugin.WorkflowOperationConditionFailure{
+					CurrentWorkflowConditionFailInfo: &msg,
+				}
+			}
+		}
+	}
+	for index := range tx.createdExecutions {
+		if item, ok := failures[index]; ok {
+			lastWriteVersion, _ := getInt64(item, attrLastWriteVersion)
+			msg := fmt.Sprintf("Workflow execution already running. WorkflowId: %v, RunId: %v", execution.WorkflowID, execution.RunID)
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				WorkflowExecutionAlreadyExists: &nosqlplugin.WorkflowExecutionAlreadyExists{
+					OtherInfo:        msg,
+					CreateRequestID:  execution.CreateRequestID,
+					RunID:            execution.RunID,
+					State:            execution.State,
+					CloseStatus:      execution.CloseStatus,
+					LastWriteVersion: lastWriteVersion,
+				},
+			}
+		}
+	}
+	return unknownConditionFailure(shardCondition, failures)
+}
+
+// updateWorkflowConditionFailure translates the failed conditions of UpdateWorkflowExecutionWithTasks,
+// following the same precedence as the Cassandra implementation
+func (db *ddb) updateWorkflowConditionFailure(
+	tx *workflowTransaction,
+	failures conditionFailures,
+	currentWorkflowRequest *nosqlplugin.CurrentWorkflowWriteRequest,
+	shardCondition *nosqlplugin.ShardCondition,
+) error {
+	if err := rangeIDConditionFailure(tx, failures); err != nil {
+		return err
+	}
+	if err := duplicateRequestConditionFailure(tx, failures); err != nil {
+		return err
+	}
+	if item, ok := failures[tx.currentWorkflowIndex]; ok {
+		requestConditionalRunID := currentWorkflowRequest.Condition.GetCurrentRunID()
+		actualCurrRunID := getString(item, attrRunID)
+		if actualCurrRunID != requestConditionalRunID {
+			msg := fmt.Sprintf("Failed to update mutable state. requestConditionalRunID: %v, Actual Value: %v",
+				requestConditionalRunID, actualCurrRunID)
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				CurrentWorkflowConditionFailInfo: &msg,
+			}
+		}
+	}
+	for index, execution := range tx.updatedExecutions {
+		if item, ok := failures[index]; ok {
+			actualNextEventID, _ := getInt64(item, attrNextEventID)
+			msg := fmt.Sprintf("Failed to update mutable state. previousNextEventIDCondition: %v, actualNextEventID: %v, Request Current RunID: %v",
+				*execution.PreviousNextEventIDCondition, actualNextEventID, execution.RunID)
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				UnknownConditionFailureDetails: &msg,
+			}
+		}
+	}
+	return unknownConditionFailure(shardCondition, failures)
+}
+
+func rangeIDConditionFailure(tx *workflowTransaction, failures conditionFailures) error {
+	item, ok := failures[tx.shardIndex]
+	if !ok {
+		return nil
+	}
+	// the item is empty if the shard doesn't exist, which is reported as rangeID 0
+	actualRangeID, _ := getInt64(item, attrRangeID)
+	return &nosqlplugin.WorkflowOperationConditionFailure{
+		ShardRangeIDNotMatch: common.Int64Ptr(actualRangeID),
+	}
+}
+
+func duplicateRequestConditionFailure(tx *workflowTransaction, failures conditionFailures) error {
+	for index, row := range tx.requests {
+		if item, ok := failures[index]; ok {
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				DuplicateRequest: &nosqlplugin.DuplicateRequest{
+					RequestType: row.RequestType,
+					RunID:       getString(item, attrRunID),
+				},
+			}
+		}
+	}
+	return nil
+}
+
+func unknownConditionFailure(shardCondition *nosqlplugin.ShardCondition, failures conditionFailures) error {
+	var indexes []string
+	for index := range failures {
+		indexes = append(indexes, strconv.Itoa(index))
+	}
+	sort.Strings(indexes)
+	msg := fmt.Sprintf("Failed to operate on workflow execution. ShardID: %v, Request RangeID: %v, failed transaction items: (%v)",
+		shardCondition.ShardID, shardCondition.RangeID, strings.Join(indexes, ","))
+	return &nosqlplugin.WorkflowOperationConditionFailure{
+		UnknownConditionFailureDetails: &msg,
+	}
+}
+
+// parseCurrentWorkflowRow returns nil if the item is empty
+func parseCurrentWorkflowRow(item map[string]*dynamodb.AttributeValue) (*nosqlplugin.CurrentWorkflowRow, error) {
+	if len(item) == 0 {
+		return nil, nil
+	}
+	row := &nosqlplugin.CurrentWorkflowRow{}
+	if err := getData(item, row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// historyTaskConditionFailure translates a failed shard condition of inserting history tasks
+func historyTaskConditionFailure(failures conditionFailures, shardIndex int, condition nosqlplugin.ShardCondition) error {
+	if item, ok := failures[shardIndex]; ok && len(item) > 0 {
+		if actualRangeID, err := getInt64(item, attrRangeID); err == nil && actualRangeID != condition.RangeID {
+			return &nosqlplugin.ShardOperationConditionFailure{
+				RangeID: actualRangeID,
+			}
+		}
+	}
+	// It's much safer to return ShardOperationConditionFailure(which will become ShardOwnershipLostError later) as the default
+	// to force the application to reload shard to recover from such errors
+	return &nosqlplugin.ShardOperationConditionFailure{
+		RangeID: -1,
+		Details: fmt.Sprintf("Failed to insert history tasks. ShardID: %v, Request RangeID: %v", condition.ShardID, condition.RangeID),
+	}
+}
+
+func mapAttr(item map[string]*dynamodb.AttributeValue, name string) map[string]*dynamodb.AttributeValue {
+	if v, ok := item[name]; ok {
+		return v.M
+	}
+	return nil
+}
+
+func jsonUnmarshalAttr(v *dynamodb.AttributeValue, out interface{}) error {
+	if v == nil || v.B == nil {
+		return fmt.Errorf("attribute value is not a binary")
+	}
+	return json.Unmarshal(v.B, out)
+}
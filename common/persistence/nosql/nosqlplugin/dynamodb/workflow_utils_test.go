@@ -0,0 +1,197 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package dynamodb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+)
+
+func TestMergeExecutionMaps(t *testing.T) {
+	b := newExpressionBuilder()
+	u := &updateExpression{}
+	err := mergeExecutionMaps(b, u, &nosqlplugin.WorkflowExecutionRequest{
+		TimerInfos: map[string]*persistence.TimerInfo{
+			"t1": {TimerID: "t1"},
+		},
+		SignalRequestedIDs:       []string{"s1"},
+		TimerInfoKeysToDelete:    []string{"t1", "t2"},
+		ActivityInfoKeysToDelete: []int64{5},
+	})
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		b.path(attrTimerMap, "t1") + " = :v0",
+		b.path(attrSignalRequested, "s1") + " = :v1",
+	}, u.set)
+	// t1 is upserted in the same update so only the other keys are removed
+	assert.ElementsMatch(t, []string{
+		b.path(attrTimerMap, "t2"),
+		b.path(attrActivityMap, "5"),
+	}, u.remove)
+}
+
+func TestRangeIDConditionFailure(t *testing.T) {
+	tx := newWorkflowTransaction()
+	tx.shardIndex = 1
+
+	assert.NoError(t, rangeIDConditionFailure(tx, conditionFailures{0: nil}))
+
+	err := rangeIDConditionFailure(tx, conditionFailures{1: {attrRangeID: numberAttr(10)}})
+	assert.Equal(t, &nosqlplugin.WorkflowOperationConditionFailure{ShardRangeIDNotMatch: common.Int64Ptr(10)}, err)
+
+	err = rangeIDConditionFailure(tx, conditionFailures{1: {}})
+	assert.Equal(t, &nosqlplugin.WorkflowOperationConditionFailure{ShardRangeIDNotMatch: common.Int64Ptr(0)}, err)
+}
+
+func TestHistoryTaskConditionFailure(t *testing.T) {
+	condition := nosqlplugin.ShardCondition{ShardID: 1, RangeID: 5}
+	tests := []struct {
+		name     string
+		failures conditionFailures
+		want     int64
+	}{
+		{
+			name:     "range id changed",
+			failures: conditionFailures{2: {attrRangeID: numberAttr(6)}},
+			want:     6,
+		},
+		{
+			name:     "shard item missing",
+			failures: conditionFailures{2: {}},
+			want:     -1,
+		},
+		{
+			name:     "other item failed",
+			failures: conditionFailures{0: map[string]*dynamodb.AttributeValue{}},
+			want:     -1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := historyTaskConditionFailure(tc.failures, 2, condition)
+			var failure *nosqlplugin.ShardOperationConditionFailure
+			require.ErrorAs(t, err, &failure)
+			assert.Equal(t, tc.want, failure.RangeID)
+		})
+	}
+}
+
+// transactionRecorder records the transactions written to DynamoDB
+type transactionRecorder struct {
+	dynamodbiface.DynamoDBAPI
+	transactions [][]*dynamodb.TransactWriteItem
+}
+
+func (r *transactionRecorder) TransactWriteItemsWithContext(
+	_ aws.Context,
+	input *dynamodb.TransactWriteItemsInput,
+	_ ...request.Option,
+) (*dynamodb.TransactWriteItemsOutput, error) {
+	r.transactions = append(r.transactions, input.TransactItems)
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+func TestWriteWorkflowTransaction(t *testing.T) {
+	condition := nosqlplugin.ShardCondition{ShardID: 1, RangeID: 5}
+	execution := &dynamodb.TransactWriteItem{Update: &dynamodb.Update{}}
+	task := &dynamodb.TransactWriteItem{Put: &dynamodb.Put{}}
+	tests := []struct {
+		name     string
+		tasks    int
+		wantSize int
+		wantErr  bool
+	}{
+		{
+			name:     "no tasks",
+			tasks:    0,
+			wantSize: 2,
+		},
+		{
+			name:     "tasks fit in the transaction",
+			tasks:    maxTransactionItems - 2,
+			wantSize: maxTransactionItems,
+		},
+		{
+			name:    "tasks exceed the transaction limit",
+			tasks:   maxTransactionItems - 1,
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := &transactionRecorder{}
+			db := &ddb{client: recorder, cfg: &config.NoSQL{Keyspace: "cadence"}}
+			tx := newWorkflowTransaction()
+			tx.add(execution)
+			for i := 0; i < tc.tasks; i++ {
+				tx.add(task)
+			}
+
+			failures, err := db.writeWorkflowTransaction(context.Background(), tx, condition)
+			assert.Empty(t, failures)
+			if tc.wantErr {
+				var sizeErr *persistence.TransactionSizeLimitError
+				assert.ErrorAs(t, err, &sizeErr)
+				// nothing is written when the workflow write doesn't fit in one transaction
+				assert.Empty(t, recorder.transactions)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, recorder.transactions, 1)
+			items := recorder.transactions[0]
+			assert.Len(t, items, tc.wantSize)
+			assert.Equal(t, execution, items[0])
+			// the transaction is fenced by the shard rangeID
+			assert.NotNil(t, items[len(items)-1].ConditionCheck)
+			assert.Equal(t, len(items)-1, tx.shardIndex)
+		})
+	}
+}
+
+func TestNewHistoryTaskItemUnsupportedCategory(t *testing.T) {
+	_, err := newHistoryTaskItem(1, persistence.HistoryTaskCategory{}, &nosqlplugin.HistoryMigrationTask{})
+	assert.ErrorContains(t, err, "is not supported by DynamoDB")
+}
+
+func TestParseCurrentWorkflowRow(t *testing.T) {
+	row, err := parseCurrentWorkflowRow(nil)
+	require.NoError(t, err)
+	assert.Nil(t, row)
+
+	item, err := newItem("pk", "sk", &nosqlplugin.CurrentWorkflowRow{RunID: "run", LastWriteVersion: 3})
+	require.NoError(t, err)
+	row, err = parseCurrentWorkflowRow(item)
+	require.NoError(t, err)
+	assert.Equal(t, "run", row.RunID)
+	assert.Equal(t, int64(3), row.LastWriteVersion)
+}
@@ -96,6 +96,9 @@ type (
 		// InsertIntoHistoryTreeAndNode inserts one or two rows: tree row and node row(at least one of them)
 		InsertIntoHistoryTreeAndNode(ctx context.Context, treeRow *HistoryTreeRow, nodeRow *HistoryNodeRow) error
 
+		// UpdateHistoryNode replaces the data and encoding of an existing node row, it must not create the row if it does not exist
+		UpdateHistoryNode(ctx context.Context, nodeRow *HistoryNodeRow) error
+
 		// SelectFromHistoryNode read nodes based on a filter
 		SelectFromHistoryNode(ctx context.Context, filter *HistoryNodeFilter) ([]*HistoryNodeRow, []byte, error)
 
@@ -246,11 +249,24 @@ type (
 	*
 	* NOTE 2: TTL(time to live records) is for auto-deleting expired records in visibility. For databases that don't support TTL,
 	* please implement DeleteVisibility method. If TTL is supported, then DeleteVisibility can be a noop.
+	*
+	* NOTE 3: visibility queries(ListWorkflowExecutions, CountWorkflowExecutions) are served by a denormalized copy of the records,
+	* partitioned by domain, status(open or closed) and bucket. See VisibilityBucketFilter.
+	* The copy also keeps the keyword search attributes, which are written by InsertVisibility, UpdateVisibility and UpsertVisibility.
+	* Plugins that don't support it return persistence.ErrVisibilityOperationNotSupported from UpsertVisibility,
+	* SelectVisibilityBucket and SelectClosedVisibilityBuckets.
 	 */
 	VisibilityCRUD interface {
 		InsertVisibility(ctx context.Context, ttlSeconds int64, row *VisibilityRowForInsert) error
 		UpdateVisibility(ctx context.Context, ttlSeconds int64, row *VisibilityRowForUpdate) error
+		// UpsertVisibility overwrites the record of an open workflow, e.g. with new memo and search attributes
+		UpsertVisibility(ctx context.Context, ttlSeconds int64, row *VisibilityRowForInsert) error
 		SelectVisibility(ctx context.Context, filter *VisibilityFilter) (*SelectVisibilityResponse, error)
+		// SelectVisibilityBucket returns a page of records of one bucket, a page shorter than filter.PageSize is the last one
+		SelectVisibilityBucket(ctx context.Context, filter *VisibilityBucketFilter) ([]*VisibilityRow, error)
+		// SelectClosedVisibilityBuckets returns the buckets between minBucket and maxBucket(inclusive) that have closed records,
+		// in descending order
+		SelectClosedVisibilityBuckets(ctx context.Context, domainID string, minBucket, maxBucket int64) ([]int64, error)
 		DeleteVisibility(ctx context.Context, domainID, workflowID, runID string) error
 		// TODO deprecated this in the future in favor of SelectVisibility
 		// Special case: return nil,nil if not found(since we will deprecate it, it's not worth refactor to be consistent)
@@ -260,11 +276,15 @@ type (
 	VisibilityRowForInsert struct {
 		VisibilityRow
 		DomainID string
+		// KeywordSearchAttributes are the JSON encoded values of the keyword search attributes that can be queried
+		KeywordSearchAttributes map[string][]byte
 	}
 
 	VisibilityRowForUpdate struct {
 		VisibilityRow
 		DomainID string
+		// KeywordSearchAttributes are the JSON encoded values of the keyword search attributes that can be queried
+		KeywordSearchAttributes map[string][]byte
 		// NOTE: this is only for some implementation (e.g. Cassandra) that uses multiple tables,
 		// they needs to delete record from the open execution table. Ignore this field if not need it
 		UpdateOpenToClose bool
@@ -291,6 +311,27 @@ type (
 		CloseStatus  int32
 	}
 
+	// VisibilityBucketFilter selects records of one bucket of the denormalized visibility records, in descending
+	// (sort time, run ID) order. Open records are sorted by start time and put into OpenVisibilityBucket(runID).
+	// Closed records are sorted by close time and put into ClosedVisibilityBucket(closeTime)
+	VisibilityBucketFilter struct {
+		DomainID string
+		IsOpen   bool
+		Bucket   int64
+		// MinSortTime and MaxSortTime are inclusive
+		MinSortTime time.Time
+		MaxSortTime time.Time
+		// PageAfter is the last record of the previous page, nil for the first page
+		PageAfter *VisibilitySortKey
+		PageSize  int
+	}
+
+	// VisibilitySortKey is the position of a record within a bucket of the denormalized visibility records
+	VisibilitySortKey struct {
+		SortTime time.Time
+		RunID    string
+	}
+
 	VisibilityFilterType int
 	VisibilitySortType   int
 
@@ -891,6 +891,21 @@ func (mr *MockDBMockRecorder) SelectAllWorkflowExecutions(ctx, shardID, pageToke
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllWorkflowExecutions", reflect.TypeOf((*MockDB)(nil).SelectAllWorkflowExecutions), ctx, shardID, pageToken, pageSize)
 }
 
+// SelectClosedVisibilityBuckets mocks base method.
+func (m *MockDB) SelectClosedVisibilityBuckets(ctx context.Context, domainID string, minBucket, maxBucket int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectClosedVisibilityBuckets", ctx, domainID, minBucket, maxBucket)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectClosedVisibilityBuckets indicates an expected call of SelectClosedVisibilityBuckets.
+func (mr *MockDBMockRecorder) SelectClosedVisibilityBuckets(ctx, domainID, minBucket, maxBucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectClosedVisibilityBuckets", reflect.TypeOf((*MockDB)(nil).SelectClosedVisibilityBuckets), ctx, domainID, minBucket, maxBucket)
+}
+
 // SelectCurrentWorkflow mocks base method.
 func (m *MockDB) SelectCurrentWorkflow(ctx context.Context, shardID int, domainID, workflowID string) (*CurrentWorkflowRow, error) {
 	m.ctrl.T.Helper()
@@ -1244,6 +1259,21 @@ func (mr *MockDBMockRecorder) SelectVisibility(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVisibility", reflect.TypeOf((*MockDB)(nil).SelectVisibility), ctx, filter)
 }
 
+// SelectVisibilityBucket mocks base method.
+func (m *MockDB) SelectVisibilityBucket(ctx context.Context, filter *VisibilityBucketFilter) ([]*VisibilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectVisibilityBucket", ctx, filter)
+	ret0, _ := ret[0].([]*VisibilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectVisibilityBucket indicates an expected call of SelectVisibilityBucket.
+func (mr *MockDBMockRecorder) SelectVisibilityBucket(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVisibilityBucket", reflect.TypeOf((*MockDB)(nil).SelectVisibilityBucket), ctx, filter)
+}
+
 // SelectWorkflowExecution mocks base method.
 func (m *MockDB) SelectWorkflowExecution(ctx context.Context, shardID int, domainID, workflowID, runID string) (*WorkflowExecution, error) {
 	m.ctrl.T.Helper()
@@ -1288,6 +1318,20 @@ func (mr *MockDBMockRecorder) UpdateDomain(ctx, row any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomain", reflect.TypeOf((*MockDB)(nil).UpdateDomain), ctx, row)
 }
 
+// UpdateHistoryNode mocks base method.
+func (m *MockDB) UpdateHistoryNode(ctx context.Context, nodeRow *HistoryNodeRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHistoryNode", ctx, nodeRow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHistoryNode indicates an expected call of UpdateHistoryNode.
+func (mr *MockDBMockRecorder) UpdateHistoryNode(ctx, nodeRow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistoryNode", reflect.TypeOf((*MockDB)(nil).UpdateHistoryNode), ctx, nodeRow)
+}
+
 // UpdateQueueMetadataCas mocks base method.
 func (m *MockDB) UpdateQueueMetadataCas(ctx context.Context, row QueueMetadataRow) error {
 	m.ctrl.T.Helper()
@@ -1386,6 +1430,20 @@ func (mr *MockDBMockRecorder) UpdateWorkflowExecutionWithTasks(ctx, requests, cu
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkflowExecutionWithTasks", reflect.TypeOf((*MockDB)(nil).UpdateWorkflowExecutionWithTasks), ctx, requests, currentWorkflowRequest, mutatedExecution, insertedExecution, activeClusterSelectionPolicyRow, resetExecution, tasksByCategory, shardCondition)
 }
 
+// UpsertVisibility mocks base method.
+func (m *MockDB) UpsertVisibility(ctx context.Context, ttlSeconds int64, row *VisibilityRowForInsert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVisibility", ctx, ttlSeconds, row)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertVisibility indicates an expected call of UpsertVisibility.
+func (mr *MockDBMockRecorder) UpsertVisibility(ctx, ttlSeconds, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVisibility", reflect.TypeOf((*MockDB)(nil).UpsertVisibility), ctx, ttlSeconds, row)
+}
+
 // MocktableCRUD is a mock of tableCRUD interface.
 type MocktableCRUD struct {
 	ctrl     *gomock.Controller
@@ -2068,6 +2126,21 @@ func (mr *MocktableCRUDMockRecorder) SelectAllWorkflowExecutions(ctx, shardID, p
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectAllWorkflowExecutions", reflect.TypeOf((*MocktableCRUD)(nil).SelectAllWorkflowExecutions), ctx, shardID, pageToken, pageSize)
 }
 
+// SelectClosedVisibilityBuckets mocks base method.
+func (m *MocktableCRUD) SelectClosedVisibilityBuckets(ctx context.Context, domainID string, minBucket, maxBucket int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectClosedVisibilityBuckets", ctx, domainID, minBucket, maxBucket)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectClosedVisibilityBuckets indicates an expected call of SelectClosedVisibilityBuckets.
+func (mr *MocktableCRUDMockRecorder) SelectClosedVisibilityBuckets(ctx, domainID, minBucket, maxBucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectClosedVisibilityBuckets", reflect.TypeOf((*MocktableCRUD)(nil).SelectClosedVisibilityBuckets), ctx, domainID, minBucket, maxBucket)
+}
+
 // SelectCurrentWorkflow mocks base method.
 func (m *MocktableCRUD) SelectCurrentWorkflow(ctx context.Context, shardID int, domainID, workflowID string) (*CurrentWorkflowRow, error) {
 	m.ctrl.T.Helper()
@@ -2421,6 +2494,21 @@ func (mr *MocktableCRUDMockRecorder) SelectVisibility(ctx, filter any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVisibility", reflect.TypeOf((*MocktableCRUD)(nil).SelectVisibility), ctx, filter)
 }
 
+// SelectVisibilityBucket mocks base method.
+func (m *MocktableCRUD) SelectVisibilityBucket(ctx context.Context, filter *VisibilityBucketFilter) ([]*VisibilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectVisibilityBucket", ctx, filter)
+	ret0, _ := ret[0].([]*VisibilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectVisibilityBucket indicates an expected call of SelectVisibilityBucket.
+func (mr *MocktableCRUDMockRecorder) SelectVisibilityBucket(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVisibilityBucket", reflect.TypeOf((*MocktableCRUD)(nil).SelectVisibilityBucket), ctx, filter)
+}
+
 // SelectWorkflowExecution mocks base method.
 func (m *MocktableCRUD) SelectWorkflowExecution(ctx context.Context, shardID int, domainID, workflowID, runID string) (*WorkflowExecution, error) {
 	m.ctrl.T.Helper()
@@ -2465,6 +2553,20 @@ func (mr *MocktableCRUDMockRecorder) UpdateDomain(ctx, row any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDomain", reflect.TypeOf((*MocktableCRUD)(nil).UpdateDomain), ctx, row)
 }
 
+// UpdateHistoryNode mocks base method.
+func (m *MocktableCRUD) UpdateHistoryNode(ctx context.Context, nodeRow *HistoryNodeRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHistoryNode", ctx, nodeRow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHistoryNode indicates an expected call of UpdateHistoryNode.
+func (mr *MocktableCRUDMockRecorder) UpdateHistoryNode(ctx, nodeRow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistoryNode", reflect.TypeOf((*MocktableCRUD)(nil).UpdateHistoryNode), ctx, nodeRow)
+}
+
 // UpdateQueueMetadataCas mocks base method.
 func (m *MocktableCRUD) UpdateQueueMetadataCas(ctx context.Context, row QueueMetadataRow) error {
 	m.ctrl.T.Helper()
@@ -2563,6 +2665,20 @@ func (mr *MocktableCRUDMockRecorder) UpdateWorkflowExecutionWithTasks(ctx, reque
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkflowExecutionWithTasks", reflect.TypeOf((*MocktableCRUD)(nil).UpdateWorkflowExecutionWithTasks), ctx, requests, currentWorkflowRequest, mutatedExecution, insertedExecution, activeClusterSelectionPolicyRow, resetExecution, tasksByCategory, shardCondition)
 }
 
+// UpsertVisibility mocks base method.
+func (m *MocktableCRUD) UpsertVisibility(ctx context.Context, ttlSeconds int64, row *VisibilityRowForInsert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVisibility", ctx, ttlSeconds, row)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertVisibility indicates an expected call of UpsertVisibility.
+func (mr *MocktableCRUDMockRecorder) UpsertVisibility(ctx, ttlSeconds, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVisibility", reflect.TypeOf((*MocktableCRUD)(nil).UpsertVisibility), ctx, ttlSeconds, row)
+}
+
 // MockClientErrorChecker is a mock of ClientErrorChecker interface.
 type MockClientErrorChecker struct {
 	ctrl     *gomock.Controller
@@ -2742,6 +2858,20 @@ func (mr *MockHistoryEventsCRUDMockRecorder) SelectFromHistoryTree(ctx, filter a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryTree", reflect.TypeOf((*MockHistoryEventsCRUD)(nil).SelectFromHistoryTree), ctx, filter)
 }
 
+// UpdateHistoryNode mocks base method.
+func (m *MockHistoryEventsCRUD) UpdateHistoryNode(ctx context.Context, nodeRow *HistoryNodeRow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHistoryNode", ctx, nodeRow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateHistoryNode indicates an expected call of UpdateHistoryNode.
+func (mr *MockHistoryEventsCRUDMockRecorder) UpdateHistoryNode(ctx, nodeRow any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistoryNode", reflect.TypeOf((*MockHistoryEventsCRUD)(nil).UpdateHistoryNode), ctx, nodeRow)
+}
+
 // MockMessageQueueCRUD is a mock of MessageQueueCRUD interface.
 type MockMessageQueueCRUD struct {
 	ctrl     *gomock.Controller
@@ -3171,6 +3301,21 @@ func (mr *MockVisibilityCRUDMockRecorder) InsertVisibility(ctx, ttlSeconds, row
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertVisibility", reflect.TypeOf((*MockVisibilityCRUD)(nil).InsertVisibility), ctx, ttlSeconds, row)
 }
 
+// SelectClosedVisibilityBuckets mocks base method.
+func (m *MockVisibilityCRUD) SelectClosedVisibilityBuckets(ctx context.Context, domainID string, minBucket, maxBucket int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectClosedVisibilityBuckets", ctx, domainID, minBucket, maxBucket)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectClosedVisibilityBuckets indicates an expected call of SelectClosedVisibilityBuckets.
+func (mr *MockVisibilityCRUDMockRecorder) SelectClosedVisibilityBuckets(ctx, domainID, minBucket, maxBucket any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectClosedVisibilityBuckets", reflect.TypeOf((*MockVisibilityCRUD)(nil).SelectClosedVisibilityBuckets), ctx, domainID, minBucket, maxBucket)
+}
+
 // SelectOneClosedWorkflow mocks base method.
 func (m *MockVisibilityCRUD) SelectOneClosedWorkflow(ctx context.Context, domainID, workflowID, runID string) (*VisibilityRow, error) {
 	m.ctrl.T.Helper()
@@ -3201,6 +3346,21 @@ func (mr *MockVisibilityCRUDMockRecorder) SelectVisibility(ctx, filter any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVisibility", reflect.TypeOf((*MockVisibilityCRUD)(nil).SelectVisibility), ctx, filter)
 }
 
+// SelectVisibilityBucket mocks base method.
+func (m *MockVisibilityCRUD) SelectVisibilityBucket(ctx context.Context, filter *VisibilityBucketFilter) ([]*VisibilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectVisibilityBucket", ctx, filter)
+	ret0, _ := ret[0].([]*VisibilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectVisibilityBucket indicates an expected call of SelectVisibilityBucket.
+func (mr *MockVisibilityCRUDMockRecorder) SelectVisibilityBucket(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectVisibilityBucket", reflect.TypeOf((*MockVisibilityCRUD)(nil).SelectVisibilityBucket), ctx, filter)
+}
+
 // UpdateVisibility mocks base method.
 func (m *MockVisibilityCRUD) UpdateVisibility(ctx context.Context, ttlSeconds int64, row *VisibilityRowForUpdate) error {
 	m.ctrl.T.Helper()
@@ -3215,6 +3375,20 @@ func (mr *MockVisibilityCRUDMockRecorder) UpdateVisibility(ctx, ttlSeconds, row
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVisibility", reflect.TypeOf((*MockVisibilityCRUD)(nil).UpdateVisibility), ctx, ttlSeconds, row)
 }
 
+// UpsertVisibility mocks base method.
+func (m *MockVisibilityCRUD) UpsertVisibility(ctx context.Context, ttlSeconds int64, row *VisibilityRowForInsert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVisibility", ctx, ttlSeconds, row)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertVisibility indicates an expected call of UpsertVisibility.
+func (mr *MockVisibilityCRUDMockRecorder) UpsertVisibility(ctx, ttlSeconds, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVisibility", reflect.TypeOf((*MockVisibilityCRUD)(nil).UpsertVisibility), ctx, ttlSeconds, row)
+}
+
 // MockTaskCRUD is a mock of TaskCRUD interface.
 type MockTaskCRUD struct {
 	ctrl     *gomock.Controller
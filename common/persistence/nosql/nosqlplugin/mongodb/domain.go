@@ -22,17 +22,63 @@ package mongodb
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
+// domainMetadataID is the ID of the only document in domain_metadata
+const domainMetadataID = "domain_metadata"
+
 // Insert a new record to domain, return error if failed or already exists
 // Return ConditionFailure if the condition doesn't meet
 func (db *mdb) InsertDomain(
 	ctx context.Context,
 	row *nosqlplugin.DomainRow,
 ) error {
-	panic("TODO")
+	return db.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		metadataNotificationVersion, err := db.SelectDomainMetadata(sessCtx)
+		if err != nil {
+			return err
+		}
+
+		domains := db.dbConn.Collection(cadence.DomainsCollectionName)
+		err = domains.FindOne(sessCtx, bson.D{{"name", row.Info.Name}}).Err()
+		if err == nil {
+			db.logger.Warn("Domain already exists")
+			return &types.DomainAlreadyExistsError{
+				Message: fmt.Sprintf("Domain %v already exists", row.Info.Name),
+			}
+		}
+		if !db.IsNotFoundError(err) {
+			return err
+		}
+
+		newRow := *row
+		newRow.FailoverNotificationVersion = persistence.InitialFailoverNotificationVersion
+		newRow.PreviousFailoverVersion = constants.InitialPreviousFailoverVersion
+		newRow.NotificationVersion = metadataNotificationVersion
+		doc, err := newDomainEntry(&newRow)
+		if err != nil {
+			return err
+		}
+		if _, err := domains.InsertOne(sessCtx, doc); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return fmt.Errorf("CreateDomain operation failed because of uuid collision")
+			}
+			return err
+		}
+		return db.updateDomainMetadata(sessCtx, metadataNotificationVersion)
+	})
 }
 
 // Update domain
@@ -40,7 +86,20 @@ func (db *mdb) UpdateDomain(
 	ctx context.Context,
 	row *nosqlplugin.DomainRow,
 ) error {
-	panic("TODO")
+	doc, err := newDomainEntry(row)
+	if err != nil {
+		return err
+	}
+	return db.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		result, err := db.dbConn.Collection(cadence.DomainsCollectionName).ReplaceOne(sessCtx, bson.D{{"_id", doc.ID}}, doc)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return nosqlplugin.NewConditionFailure("domain")
+		}
+		return db.updateDomainMetadata(sessCtx, row.NotificationVersion)
+	})
 }
 
 // Get one domain data, either by domainID or domainName
@@ -49,7 +108,23 @@ func (db *mdb) SelectDomain(
 	domainID *string,
 	domainName *string,
 ) (*nosqlplugin.DomainRow, error) {
-	panic("TODO")
+	var filter bson.D
+	switch {
+	case domainID != nil && domainName != nil:
+		return nil, fmt.Errorf("GetDomain operation failed.  Both ID and Name specified in request")
+	case domainID != nil:
+		filter = bson.D{{"_id", *domainID}}
+	case domainName != nil:
+		filter = bson.D{{"name", *domainName}}
+	default:
+		return nil, fmt.Errorf("GetDomain operation failed.  Both ID and Name are empty")
+	}
+
+	var doc cadence.DomainCollectionEntry
+	if err := db.dbConn.Collection(cadence.DomainsCollectionName).FindOne(ctx, filter).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return parseDomainEntry(&doc)
 }
 
 // Get all domain data
@@ -58,7 +133,26 @@ func (db *mdb) SelectAllDomains(
 	pageSize int,
 	pageToken []byte,
 ) ([]*nosqlplugin.DomainRow, []byte, error) {
-	panic("TODO")
+	docs, nextPageToken, err := findPage[cadence.DomainCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.DomainsCollectionName),
+		bson.D{},
+		bson.D{{"name", 1}},
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]*nosqlplugin.DomainRow, 0, len(docs))
+	for _, doc := range docs {
+		row, err := parseDomainEntry(doc)
+		if err != nil {
+			return nil, nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nextPageToken, nil
 }
 
 // Delete a domain, either by domainID or domainName
@@ -67,11 +161,80 @@ func (db *mdb) DeleteDomain(
 	domainID *string,
 	domainName *string,
 ) error {
-	panic("TODO")
+	var filter bson.D
+	switch {
+	case domainID != nil:
+		filter = bson.D{{"_id", *domainID}}
+	case domainName != nil:
+		filter = bson.D{{"name", *domainName}}
+	default:
+		return fmt.Errorf("must provide either domainID or domainName")
+	}
+	_, err := db.dbConn.Collection(cadence.DomainsCollectionName).DeleteOne(ctx, filter)
+	return err
 }
 
 func (db *mdb) SelectDomainMetadata(
 	ctx context.Context,
 ) (int64, error) {
-	panic("TODO")
+	var doc cadence.DomainMetadataCollectionEntry
+	err := db.dbConn.Collection(cadence.DomainMetadataCollectionName).FindOne(ctx, bson.D{{"_id", domainMetadataID}}).Decode(&doc)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			// the metadata document is created by the first domain
+			return 0, nil
+		}
+		return -1, err
+	}
+	return doc.NotificationVersion, nil
+}
+
+// updateDomainMetadata bumps the notification version, conditioned on the current version
+func (db *mdb) updateDomainMetadata(sessCtx mongo.SessionContext, notificationVersion int64) error {
+	collection := db.dbConn.Collection(cadence.DomainMetadataCollectionName)
+	if notificationVersion == 0 {
+		_, err := collection.InsertOne(sessCtx, cadence.DomainMetadataCollectionEntry{
+			ID:                  domainMetadataID,
+			NotificationVersion: 1,
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			return nosqlplugin.NewConditionFailure("domain")
+		}
+		return err
+	}
+	result, err := collection.UpdateOne(
+		sessCtx,
+		bson.D{{"_id", domainMetadataID}, {"notificationversion", notificationVersion}},
+		bson.D{{"$set", bson.D{{"notificationversion", notificationVersion + 1}}}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		db.logger.Warn("Domain operation failed because of condition update failure on domain metadata record")
+		return nosqlplugin.NewConditionFailure("domain")
+	}
+	return nil
+}
+
+func newDomainEntry(row *nosqlplugin.DomainRow) (*cadence.DomainCollectionEntry, error) {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	return &cadence.DomainCollectionEntry{
+		ID:   row.Info.ID,
+		Name: row.Info.Name,
+		Data: data,
+	}, nil
+}
+
+func parseDomainEntry(doc *cadence.DomainCollectionEntry) (*nosqlplugin.DomainRow, error) {
+	row := &nosqlplugin.DomainRow{}
+	if err := json.Unmarshal(doc.Data, row); err != nil {
+		return nil, err
+	}
+	// CurrentTimeStamp is the time of the request, not part of the domain
+	row.CurrentTimeStamp = time.Time{}
+	return row, nil
 }
@@ -22,17 +22,64 @@ package mongodb
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"go.mongodb.org/mongo-driver/bson"
+
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
 // InsertDomainAuditLog inserts a new audit log entry for a domain operation
 func (db *mdb) InsertDomainAuditLog(ctx context.Context, row *nosqlplugin.DomainAuditLogRow) error {
-	return fmt.Errorf("InsertDomainAuditLog not implemented")
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	_, err = db.dbConn.Collection(cadence.DomainAuditLogCollectionName).InsertOne(ctx, &cadence.DomainAuditLogCollectionEntry{
+		DomainID:      row.DomainID,
+		OperationType: int(row.OperationType),
+		CreatedTime:   row.CreatedTime.UnixNano(),
+		EventID:       row.EventID,
+		Data:          data,
+		Expiry:        expiry(row.CreatedTime, row.TTLSeconds),
+	})
+	return err
 }
 
 // SelectDomainAuditLogs returns audit log entries for a domain and operation type
 func (db *mdb) SelectDomainAuditLogs(ctx context.Context, filter *nosqlplugin.DomainAuditLogFilter) ([]*nosqlplugin.DomainAuditLogRow, []byte, error) {
-	return nil, nil, fmt.Errorf("SelectDomainAuditLogs not implemented")
+	if filter.MinCreatedTime == nil || filter.MaxCreatedTime == nil {
+		return nil, nil, &types.InternalServiceError{
+			Message: "SelectDomainAuditLogs requires non-nil MinCreatedTime and MaxCreatedTime",
+		}
+	}
+	docs, nextPageToken, err := findPage[cadence.DomainAuditLogCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.DomainAuditLogCollectionName),
+		bson.D{
+			{"domainid", filter.DomainID},
+			{"operationtype", int(filter.OperationType)},
+			{"createdtime", bson.D{{"$gte", filter.MinCreatedTime.UnixNano()}, {"$lt", filter.MaxCreatedTime.UnixNano()}}},
+		},
+		// newest first, same as the clustering order of Cassandra
+		bson.D{{"createdtime", -1}, {"eventid", 1}},
+		filter.PageSize,
+		filter.NextPageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]*nosqlplugin.DomainAuditLogRow, 0, len(docs))
+	for _, doc := range docs {
+		row := &nosqlplugin.DomainAuditLogRow{}
+		if err := json.Unmarshal(doc.Data, row); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse domain audit log: %v", err)
+		}
+		row.TTLSeconds = 0
+		rows = append(rows, row)
+	}
+	return rows, nextPageToken, nil
 }
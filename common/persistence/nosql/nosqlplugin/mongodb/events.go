@@ -22,31 +22,180 @@ package mongodb
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
 // InsertIntoHistoryTreeAndNode inserts one or two rows: tree row and node row(at least one of them)
 func (db *mdb) InsertIntoHistoryTreeAndNode(ctx context.Context, treeRow *nosqlplugin.HistoryTreeRow, nodeRow *nosqlplugin.HistoryNodeRow) error {
-	panic("TODO")
+	if treeRow == nil && nodeRow == nil {
+		return fmt.Errorf("require at least a tree row or a node row to insert")
+	}
+	if treeRow == nil || nodeRow == nil {
+		// prefer a single write which is cheaper than a transaction
+		return db.upsertHistoryTreeAndNode(ctx, treeRow, nodeRow)
+	}
+	return db.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		return db.upsertHistoryTreeAndNode(sessCtx, treeRow, nodeRow)
+	})
+}
+
+func (db *mdb) upsertHistoryTreeAndNode(ctx context.Context, treeRow *nosqlplugin.HistoryTreeRow, nodeRow *nosqlplugin.HistoryNodeRow) error {
+	if treeRow != nil {
+		data, err := json.Marshal(treeRow)
+		if err != nil {
+			return err
+		}
+		doc := &cadence.HistoryTreeCollectionEntry{
+			TreeID:   treeRow.TreeID,
+			BranchID: treeRow.BranchID,
+			Data:     data,
+		}
+		filter := bson.D{{"treeid", doc.TreeID}, {"branchid", doc.BranchID}}
+		if err := upsert(ctx, db.dbConn.Collection(cadence.HistoryTreeCollectionName), filter, doc); err != nil {
+			return err
+		}
+	}
+	if nodeRow != nil {
+		var txnID int64
+		if nodeRow.TxnID != nil {
+			txnID = *nodeRow.TxnID
+		}
+		doc := &cadence.HistoryNodeCollectionEntry{
+			TreeID:       nodeRow.TreeID,
+			BranchID:     nodeRow.BranchID,
+			NodeID:       nodeRow.NodeID,
+			TxnID:        txnID,
+			Data:         nodeRow.Data,
+			DataEncoding: nodeRow.DataEncoding,
+		}
+		filter := bson.D{{"treeid", doc.TreeID}, {"branchid", doc.BranchID}, {"nodeid", doc.NodeID}, {"txnid", doc.TxnID}}
+		if err := upsert(ctx, db.dbConn.Collection(cadence.HistoryNodeCollectionName), filter, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateHistoryNode replaces the data and encoding of an existing node row
+func (db *mdb) UpdateHistoryNode(ctx context.Context, nodeRow *nosqlplugin.HistoryNodeRow) error {
+	var txnID int64
+	if nodeRow.TxnID != nil {
+		txnID = *nodeRow.TxnID
+	}
+	// no upsert: the node may have been deleted with its branch meanwhile, in which case there is nothing to update
+	_, err := db.dbConn.Collection(cadence.HistoryNodeCollectionName).UpdateOne(
+		ctx,
+		bson.D{{"treeid", nodeRow.TreeID}, {"branchid", nodeRow.BranchID}, {"nodeid", nodeRow.NodeID}, {"txnid", txnID}},
+		bson.D{{"$set", bson.D{{"data", nodeRow.Data}, {"dataencoding", nodeRow.DataEncoding}}}},
+	)
+	return err
 }
 
 // SelectFromHistoryNode read nodes based on a filter
 func (db *mdb) SelectFromHistoryNode(ctx context.Context, filter *nosqlplugin.HistoryNodeFilter) ([]*nosqlplugin.HistoryNodeRow, []byte, error) {
-	panic("TODO")
+	docs, nextPageToken, err := findPage[cadence.HistoryNodeCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.HistoryNodeCollectionName),
+		bson.D{
+			{"treeid", filter.TreeID},
+			{"branchid", filter.BranchID},
+			{"nodeid", bson.D{{"$gte", filter.MinNodeID}, {"$lt", filter.MaxNodeID}}},
+		},
+		bson.D{{"nodeid", 1}, {"txnid", -1}},
+		filter.PageSize,
+		filter.NextPageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]*nosqlplugin.HistoryNodeRow, 0, len(docs))
+	for _, doc := range docs {
+		txnID := doc.TxnID
+		rows = append(rows, &nosqlplugin.HistoryNodeRow{
+			ShardID:      filter.ShardID,
+			TreeID:       doc.TreeID,
+			BranchID:     doc.BranchID,
+			NodeID:       doc.NodeID,
+			TxnID:        &txnID,
+			Data:         doc.Data,
+			DataEncoding: doc.DataEncoding,
+		})
+	}
+	return rows, nextPageToken, nil
 }
 
 // DeleteFromHistoryTreeAndNode delete a branch record, and a list of ranges of nodes.
 func (db *mdb) DeleteFromHistoryTreeAndNode(ctx context.Context, treeFilter *nosqlplugin.HistoryTreeFilter, nodeFilters []*nosqlplugin.HistoryNodeFilter) error {
-	panic("TODO")
+	// delete the nodes first so that a failure can be retried from the branch record
+	for _, nodeFilter := range nodeFilters {
+		if _, err := db.dbConn.Collection(cadence.HistoryNodeCollectionName).DeleteMany(ctx, bson.D{
+			{"treeid", nodeFilter.TreeID},
+			{"branchid", nodeFilter.BranchID},
+			{"nodeid", bson.D{{"$gte", nodeFilter.MinNodeID}}},
+		}); err != nil {
+			return err
+		}
+	}
+	branchID := ""
+	if treeFilter.BranchID != nil {
+		branchID = *treeFilter.BranchID
+	}
+	_, err := db.dbConn.Collection(cadence.HistoryTreeCollectionName).DeleteOne(ctx, bson.D{
+		{"treeid", treeFilter.TreeID},
+		{"branchid", branchID},
+	})
+	return err
 }
 
 // SelectAllHistoryTrees will return all tree branches with pagination
 func (db *mdb) SelectAllHistoryTrees(ctx context.Context, nextPageToken []byte, pageSize int) ([]*nosqlplugin.HistoryTreeRow, []byte, error) {
-	panic("TODO")
+	docs, token, err := findPage[cadence.HistoryTreeCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.HistoryTreeCollectionName),
+		bson.D{},
+		bson.D{{"treeid", 1}, {"branchid", 1}},
+		pageSize,
+		nextPageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := parseHistoryTreeEntries(docs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rows, token, nil
 }
 
 // SelectFromHistoryTree read branch records for a tree
 func (db *mdb) SelectFromHistoryTree(ctx context.Context, filter *nosqlplugin.HistoryTreeFilter) ([]*nosqlplugin.HistoryTreeRow, error) {
-	panic("TODO")
+	docs, err := findAll[cadence.HistoryTreeCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.HistoryTreeCollectionName),
+		bson.D{{"treeid", filter.TreeID}},
+		bson.D{{"branchid", 1}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return parseHistoryTreeEntries(docs)
+}
+
+func parseHistoryTreeEntries(docs []*cadence.HistoryTreeCollectionEntry) ([]*nosqlplugin.HistoryTreeRow, error) {
+	rows := make([]*nosqlplugin.HistoryTreeRow, 0, len(docs))
+	for _, doc := range docs {
+		row := &nosqlplugin.HistoryTreeRow{}
+		if err := json.Unmarshal(doc.Data, row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
 }
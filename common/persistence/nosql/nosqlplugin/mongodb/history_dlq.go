@@ -22,28 +22,142 @@ package mongodb
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson"
 
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
 // InsertHistoryDLQTaskRow writes a task to the history DLQ.
 func (db *mdb) InsertHistoryDLQTaskRow(ctx context.Context, task *nosqlplugin.HistoryDLQTaskRow) error {
-	return fmt.Errorf("InsertHistoryDLQTaskRow not implemented for MongoDB")
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	doc := &cadence.HistoryTaskDLQCollectionEntry{
+		ShardID:               task.ShardID,
+		DomainID:              task.DomainID,
+		ClusterAttributeScope: task.ClusterAttributeScope,
+		ClusterAttributeName:  task.ClusterAttributeName,
+		TaskType:              task.TaskType,
+		VisibilityTimestamp:   task.VisibilityTimestamp.UnixNano(),
+		TaskID:                task.TaskID,
+		Data:                  data,
+	}
+	filter := append(
+		historyDLQPartitionFilter(task.ShardID, task.DomainID, task.ClusterAttributeScope, task.ClusterAttributeName, task.TaskType),
+		bson.E{Key: "visibilitytimestamp", Value: doc.VisibilityTimestamp},
+		bson.E{Key: "taskid", Value: doc.TaskID},
+	)
+	return upsert(ctx, db.dbConn.Collection(cadence.HistoryTaskDLQCollectionName), filter, doc)
 }
 
+// SelectHistoryDLQTaskRows reads paginated tasks from the history DLQ within [min, max) of (visibility_ts, task_id).
 func (db *mdb) SelectHistoryDLQTaskRows(ctx context.Context, filter nosqlplugin.HistoryDLQTaskFilter) ([]*nosqlplugin.HistoryDLQTaskRow, []byte, error) {
-	return nil, nil, fmt.Errorf("SelectHistoryDLQTaskRows not implemented for MongoDB")
+	query := append(
+		historyDLQPartitionFilter(filter.ShardID, filter.DomainID, filter.ClusterAttributeScope, filter.ClusterAttributeName, filter.TaskType),
+		bson.E{Key: "$and", Value: bson.A{
+			timestampTaskIDFilter("$gt", "$gte", filter.InclusiveMinVisibilityTS.UnixNano(), filter.InclusiveMinTaskID),
+			timestampTaskIDFilter("$lt", "$lt", filter.ExclusiveMaxVisibilityTS.UnixNano(), filter.ExclusiveMaxTaskID),
+		}},
+	)
+	docs, nextPageToken, err := findPage[cadence.HistoryTaskDLQCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.HistoryTaskDLQCollectionName),
+		query,
+		bson.D{{"visibilitytimestamp", 1}, {"taskid", 1}},
+		filter.PageSize,
+		filter.NextPageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]*nosqlplugin.HistoryDLQTaskRow, 0, len(docs))
+	for _, doc := range docs {
+		row := &nosqlplugin.HistoryDLQTaskRow{}
+		if err := json.Unmarshal(doc.Data, row); err != nil {
+			return nil, nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nextPageToken, nil
 }
 
+// RangeDeleteHistoryDLQTaskRows deletes all tasks before the exclusive (visibility_ts, task_id) bound.
 func (db *mdb) RangeDeleteHistoryDLQTaskRows(ctx context.Context, filter nosqlplugin.HistoryDLQTaskRangeDeleteFilter) error {
-	return fmt.Errorf("RangeDeleteHistoryDLQTaskRows not implemented for MongoDB")
+	query := append(
+		historyDLQPartitionFilter(filter.ShardID, filter.DomainID, filter.ClusterAttributeScope, filter.ClusterAttributeName, filter.TaskType),
+		timestampTaskIDFilter("$lt", "$lt", filter.ExclusiveMaxVisibilityTS.UnixNano(), filter.ExclusiveMaxTaskID)...,
+	)
+	_, err := db.dbConn.Collection(cadence.HistoryTaskDLQCollectionName).DeleteMany(ctx, query)
+	return err
 }
 
+// SelectHistoryDLQAckLevelRows reads ack-level rows for a shard.
+// If domainID is non-empty the query is restricted to that domain.
+// If clusterAttributeScope and clusterAttributeName are also non-empty it is
+// further restricted to that cluster attribute.
 func (db *mdb) SelectHistoryDLQAckLevelRows(ctx context.Context, filter nosqlplugin.HistoryDLQAckLevelFilter) ([]*nosqlplugin.HistoryDLQAckLevelRow, error) {
-	return nil, fmt.Errorf("SelectHistoryDLQAckLevelRows not implemented for MongoDB")
+	query := bson.D{{"shardid", filter.ShardID}}
+	if filter.DomainID != "" {
+		query = append(query, bson.E{Key: "domainid", Value: filter.DomainID})
+		if filter.ClusterAttributeScope != "" && filter.ClusterAttributeName != "" {
+			query = append(query,
+				bson.E{Key: "clusterattributescope", Value: filter.ClusterAttributeScope},
+				bson.E{Key: "clusterattributename", Value: filter.ClusterAttributeName},
+			)
+		}
+	}
+	docs, err := findAll[cadence.HistoryTaskDLQAckLevelCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.HistoryTaskDLQAckLevelCollectionName),
+		query,
+		bson.D{{"domainid", 1}, {"clusterattributescope", 1}, {"clusterattributename", 1}, {"tasktype", 1}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]*nosqlplugin.HistoryDLQAckLevelRow, 0, len(docs))
+	for _, doc := range docs {
+		row := &nosqlplugin.HistoryDLQAckLevelRow{}
+		if err := json.Unmarshal(doc.Data, row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
 }
 
+// InsertOrUpdateHistoryDLQAckLevelRow upserts a single ack-level row.
 func (db *mdb) InsertOrUpdateHistoryDLQAckLevelRow(ctx context.Context, row *nosqlplugin.HistoryDLQAckLevelRow) error {
-	return fmt.Errorf("InsertOrUpdateHistoryDLQAckLevelRow not implemented for MongoDB")
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+	doc := &cadence.HistoryTaskDLQAckLevelCollectionEntry{
+		ShardID:               row.ShardID,
+		DomainID:              row.DomainID,
+		ClusterAttributeScope: row.ClusterAttributeScope,
+		ClusterAttributeName:  row.ClusterAttributeName,
+		TaskType:              row.TaskType,
+		Data:                  data,
+	}
+	return upsert(
+		ctx,
+		db.dbConn.Collection(cadence.HistoryTaskDLQAckLevelCollectionName),
+		historyDLQPartitionFilter(row.ShardID, row.DomainID, row.ClusterAttributeScope, row.ClusterAttributeName, row.TaskType),
+		doc,
+	)
+}
+
+func historyDLQPartitionFilter(shardID int, domainID, clusterAttributeScope, clusterAttributeName string, taskType int) bson.D {
+	return bson.D{
+		{"shardid", shardID},
+		{"domainid", domainID},
+		{"clusterattributescope", clusterAttributeScope},
+		{"clusterattributename", clusterAttributeName},
+		{"tasktype", taskType},
+	}
 }
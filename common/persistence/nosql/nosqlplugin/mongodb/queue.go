@@ -22,10 +22,17 @@ package mongodb
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
 // Insert message into queue, return error if failed or already exists
@@ -34,7 +41,15 @@ func (db *mdb) InsertIntoQueue(
 	ctx context.Context,
 	row *nosqlplugin.QueueMessageRow,
 ) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.QueueMessagesCollectionName).InsertOne(ctx, cadence.QueueMessageCollectionEntry{
+		QueueType: int(row.QueueType),
+		MessageID: row.ID,
+		Payload:   row.Payload,
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return nosqlplugin.NewConditionFailure("queue")
+	}
+	return err
 }
 
 // Get the ID of last message inserted into the queue
@@ -42,7 +57,16 @@ func (db *mdb) SelectLastEnqueuedMessageID(
 	ctx context.Context,
 	queueType persistence.QueueType,
 ) (int64, error) {
-	panic("TODO")
+	var doc cadence.QueueMessageCollectionEntry
+	err := db.dbConn.Collection(cadence.QueueMessagesCollectionName).FindOne(
+		ctx,
+		bson.D{{"queuetype", int(queueType)}},
+		options.FindOne().SetSort(bson.D{{"messageid", -1}}),
+	).Decode(&doc)
+	if err != nil {
+		return 0, err
+	}
+	return doc.MessageID, nil
 }
 
 // Read queue messages starting from the exclusiveBeginMessageID
@@ -52,7 +76,20 @@ func (db *mdb) SelectMessagesFrom(
 	exclusiveBeginMessageID int64,
 	maxRows int,
 ) ([]*nosqlplugin.QueueMessageRow, error) {
-	panic("TODO")
+	resp, err := db.SelectMessagesBetween(ctx, nosqlplugin.SelectMessagesBetweenRequest{
+		QueueType:               queueType,
+		ExclusiveBeginMessageID: exclusiveBeginMessageID,
+		InclusiveEndMessageID:   math.MaxInt64,
+		PageSize:                maxRows,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*nosqlplugin.QueueMessageRow, 0, len(resp.Rows))
+	for i := range resp.Rows {
+		result = append(result, &resp.Rows[i])
+	}
+	return result, nil
 }
 
 // Read queue message starting from exclusiveBeginMessageID int64, inclusiveEndMessageID int64
@@ -60,7 +97,29 @@ func (db *mdb) SelectMessagesBetween(
 	ctx context.Context,
 	request nosqlplugin.SelectMessagesBetweenRequest,
 ) (*nosqlplugin.SelectMessagesBetweenResponse, error) {
-	panic("TODO")
+	docs, nextPageToken, err := findPage[cadence.QueueMessageCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.QueueMessagesCollectionName),
+		queueRangeFilter(request.QueueType, request.ExclusiveBeginMessageID, request.InclusiveEndMessageID),
+		bson.D{{"messageid", 1}},
+		request.PageSize,
+		request.NextPageToken,
+	)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]nosqlplugin.QueueMessageRow, 0, len(docs))
+	for _, doc := range docs {
+		rows = append(rows, nosqlplugin.QueueMessageRow{
+			QueueType: request.QueueType,
+			ID:        doc.MessageID,
+			Payload:   doc.Payload,
+		})
+	}
+	return &nosqlplugin.SelectMessagesBetweenResponse{
+		Rows:          rows,
+		NextPageToken: nextPageToken,
+	}, nil
 }
 
 // Delete all messages before exclusiveBeginMessageID
@@ -69,7 +128,11 @@ func (db *mdb) DeleteMessagesBefore(
 	queueType persistence.QueueType,
 	exclusiveBeginMessageID int64,
 ) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.QueueMessagesCollectionName).DeleteMany(ctx, bson.D{
+		{"queuetype", int(queueType)},
+		{"messageid", bson.D{{"$lt", exclusiveBeginMessageID}}},
+	})
+	return err
 }
 
 // Delete all messages in a range between exclusiveBeginMessageID and inclusiveEndMessageID
@@ -79,7 +142,11 @@ func (db *mdb) DeleteMessagesInRange(
 	exclusiveBeginMessageID int64,
 	inclusiveEndMessageID int64,
 ) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.QueueMessagesCollectionName).DeleteMany(
+		ctx,
+		queueRangeFilter(queueType, exclusiveBeginMessageID, inclusiveEndMessageID),
+	)
+	return err
 }
 
 // Delete one message
@@ -88,13 +155,25 @@ func (db *mdb) DeleteMessage(
 	queueType persistence.QueueType,
 	messageID int64,
 ) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.QueueMessagesCollectionName).DeleteOne(ctx, bson.D{
+		{"queuetype", int(queueType)},
+		{"messageid", messageID},
+	})
+	return err
 }
 
 // Insert an empty metadata row, starting from a version
 func (db *mdb) InsertQueueMetadata(ctx context.Context, row nosqlplugin.QueueMetadataRow) error {
-	fmt.Println("not implemented, ignore the eror for testing")
-	return nil
+	doc, err := newQueueMetadataEntry(row.QueueType, map[string]int64{}, row.Version)
+	if err != nil {
+		return err
+	}
+	_, err = db.dbConn.Collection(cadence.QueueMetadataCollectionName).InsertOne(ctx, doc)
+	if mongo.IsDuplicateKeyError(err) {
+		// it's ok if the record exists already
+		return nil
+	}
+	return err
 }
 
 // **Conditionally** update a queue metadata row, if current version is matched(meaning current == row.Version - 1),
@@ -104,7 +183,22 @@ func (db *mdb) UpdateQueueMetadataCas(
 	ctx context.Context,
 	row nosqlplugin.QueueMetadataRow,
 ) error {
-	panic("TODO")
+	doc, err := newQueueMetadataEntry(row.QueueType, row.ClusterAckLevels, row.Version)
+	if err != nil {
+		return err
+	}
+	result, err := db.dbConn.Collection(cadence.QueueMetadataCollectionName).ReplaceOne(
+		ctx,
+		bson.D{{"_id", int(row.QueueType)}, {"version", row.Version - 1}},
+		doc,
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return nosqlplugin.NewConditionFailure("queue")
+	}
+	return nil
 }
 
 // Read a QueueMetadata
@@ -112,13 +206,48 @@ func (db *mdb) SelectQueueMetadata(
 	ctx context.Context,
 	queueType persistence.QueueType,
 ) (*nosqlplugin.QueueMetadataRow, error) {
-	fmt.Println("not implemented, ignore the eror for testing")
-	return nil, nil
+	var doc cadence.QueueMetadataCollectionEntry
+	if err := db.dbConn.Collection(cadence.QueueMetadataCollectionName).FindOne(ctx, bson.D{{"_id", int(queueType)}}).Decode(&doc); err != nil {
+		return nil, err
+	}
+	var ackLevels map[string]int64
+	if err := json.Unmarshal(doc.ClusterAckLevels, &ackLevels); err != nil {
+		return nil, err
+	}
+	// if record exist but ackLevels is empty, we initialize the map
+	if ackLevels == nil {
+		ackLevels = make(map[string]int64)
+	}
+	return &nosqlplugin.QueueMetadataRow{
+		QueueType:        queueType,
+		ClusterAckLevels: ackLevels,
+		Version:          doc.Version,
+	}, nil
 }
 
 func (db *mdb) GetQueueSize(
 	ctx context.Context,
 	queueType persistence.QueueType,
 ) (int64, error) {
-	panic("TODO")
+	return db.dbConn.Collection(cadence.QueueMessagesCollectionName).CountDocuments(ctx, bson.D{{"queuetype", int(queueType)}})
+}
+
+// queueRangeFilter matches the messages in (exclusiveBeginMessageID, inclusiveEndMessageID]
+func queueRangeFilter(queueType persistence.QueueType, exclusiveBeginMessageID, inclusiveEndMessageID int64) bson.D {
+	return bson.D{
+		{"queuetype", int(queueType)},
+		{"messageid", bson.D{{"$gt", exclusiveBeginMessageID}, {"$lte", inclusiveEndMessageID}}},
+	}
+}
+
+func newQueueMetadataEntry(queueType persistence.QueueType, clusterAckLevels map[string]int64, version int64) (*cadence.QueueMetadataCollectionEntry, error) {
+	ackLevels, err := json.Marshal(clusterAckLevels)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize cluster ack levels: %v", err)
+	}
+	return &cadence.QueueMetadataCollectionEntry{
+		QueueType:        int(queueType),
+		Version:          version,
+		ClusterAckLevels: ackLevels,
+	}, nil
 }
@@ -22,31 +22,140 @@ package mongodb
 
 import (
 	"context"
-	"log"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
 // InsertShard creates a new shard, return error is there is any.
 // Return ShardOperationConditionFailure if the condition doesn't meet
 func (db *mdb) InsertShard(ctx context.Context, row *nosqlplugin.ShardRow) error {
-	log.Println("not implemented...ignore the error for testing...")
-	return nil
+	doc, err := newShardEntry(row)
+	if err != nil {
+		return err
+	}
+	_, err = db.dbConn.Collection(cadence.ShardsCollectionName).InsertOne(ctx, doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return db.shardConditionFailure(ctx, row.ShardID, "shard already exists")
+	}
+	return err
 }
 
 // SelectShard gets a shard
 func (db *mdb) SelectShard(ctx context.Context, shardID int, currentClusterName string) (int64, *nosqlplugin.ShardRow, error) {
-	panic("TODO")
+	var doc cadence.ShardCollectionEntry
+	if err := db.dbConn.Collection(cadence.ShardsCollectionName).FindOne(ctx, bson.D{{"_id", shardID}}).Decode(&doc); err != nil {
+		return 0, nil, err
+	}
+	info := &persistence.InternalShardInfo{}
+	if err := json.Unmarshal(doc.Data, info); err != nil {
+		return 0, nil, err
+	}
+	fillShardInfoDefaults(currentClusterName, info)
+	return doc.RangeID, &nosqlplugin.ShardRow{
+		InternalShardInfo: info,
+		Data:              doc.ShardData,
+		DataEncoding:      doc.ShardDataEncoding,
+	}, nil
 }
 
 // UpdateRangeID updates the rangeID, return error is there is any
 // Return ShardOperationConditionFailure if the condition doesn't meet
 func (db *mdb) UpdateRangeID(ctx context.Context, shardID int, rangeID int64, previousRangeID int64) error {
-	panic("TODO")
+	result, err := db.dbConn.Collection(cadence.ShardsCollectionName).UpdateOne(
+		ctx,
+		bson.D{{"_id", shardID}, {"rangeid", previousRangeID}},
+		bson.D{{"$set", bson.D{{"rangeid", rangeID}}}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.shardConditionFailure(ctx, shardID, fmt.Sprintf("previous rangeID %v doesn't match", previousRangeID))
+	}
+	return nil
 }
 
 // UpdateShard updates a shard, return error is there is any.
 // Return ShardOperationConditionFailure if the condition doesn't meet
 func (db *mdb) UpdateShard(ctx context.Context, row *nosqlplugin.ShardRow, previousRangeID int64) error {
-	panic("TODO")
+	doc, err := newShardEntry(row)
+	if err != nil {
+		return err
+	}
+	result, err := db.dbConn.Collection(cadence.ShardsCollectionName).ReplaceOne(
+		ctx,
+		bson.D{{"_id", row.ShardID}, {"rangeid", previousRangeID}},
+		doc,
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.shardConditionFailure(ctx, row.ShardID, fmt.Sprintf("previous rangeID %v doesn't match", previousRangeID))
+	}
+	return nil
+}
+
+func newShardEntry(row *nosqlplugin.ShardRow) (*cadence.ShardCollectionEntry, error) {
+	info := *row.InternalShardInfo
+	info.UpdatedAt = row.CurrentTimestamp
+	data, err := json.Marshal(&info)
+	if err != nil {
+		return nil, err
+	}
+	return &cadence.ShardCollectionEntry{
+		ShardID:           row.ShardID,
+		RangeID:           row.RangeID,
+		Data:              data,
+		ShardData:         row.Data,
+		ShardDataEncoding: row.DataEncoding,
+	}, nil
+}
+
+// shardConditionFailure reads the current rangeID of the shard, because a failed conditional write
+// doesn't return the existing document
+func (db *mdb) shardConditionFailure(ctx context.Context, shardID int, details string) error {
+	rangeID, err := db.selectShardRangeID(ctx, shardID)
+	if err != nil {
+		return err
+	}
+	return &nosqlplugin.ShardOperationConditionFailure{
+		RangeID: rangeID,
+		Details: details,
+	}
+}
+
+func (db *mdb) selectShardRangeID(ctx context.Context, shardID int) (int64, error) {
+	var doc cadence.ShardCollectionEntry
+	if err := db.dbConn.Collection(cadence.ShardsCollectionName).FindOne(ctx, bson.D{{"_id", shardID}}).Decode(&doc); err != nil {
+		return 0, err
+	}
+	return doc.RangeID, nil
+}
+
+func fillShardInfoDefaults(currentCluster string, info *persistence.InternalShardInfo) {
+	if info.ClusterTransferAckLevel == nil {
+		info.ClusterTransferAckLevel = map[string]int64{
+			currentCluster: info.TransferAckLevel,
+		}
+	}
+	if info.ClusterTimerAckLevel == nil {
+		info.ClusterTimerAckLevel = map[string]time.Time{
+			currentCluster: info.TimerAckLevel,
+		}
+	}
+	if info.ClusterReplicationLevel == nil {
+		info.ClusterReplicationLevel = make(map[string]int64)
+	}
+	if info.ReplicationDLQAckLevel == nil {
+		info.ReplicationDLQAckLevel = make(map[string]int64)
+	}
 }
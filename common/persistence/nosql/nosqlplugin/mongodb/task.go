@@ -22,20 +22,48 @@ package mongodb
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
+const initialRangeID = 1 // Id of the first range of a new task list
+
 // SelectTaskList returns a single tasklist row.
 // Return IsNotFoundError if the row doesn't exist
 func (db *mdb) SelectTaskList(ctx context.Context, filter *nosqlplugin.TaskListFilter) (*nosqlplugin.TaskListRow, error) {
-	panic("TODO")
+	var doc cadence.TaskListCollectionEntry
+	if err := db.dbConn.Collection(cadence.TaskListsCollectionName).FindOne(ctx, taskListFilter(filter)).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return parseTaskListEntry(&doc)
 }
 
 // InsertTaskList insert a single tasklist row
 // Return IsConditionFailedError if the row already exists, and also the existing row
 func (db *mdb) InsertTaskList(ctx context.Context, row *nosqlplugin.TaskListRow) error {
-	panic("TODO")
+	newRow := *row
+	newRow.RangeID = initialRangeID
+	newRow.AckLevel = 0
+	doc, err := newTaskListEntry(&newRow, 0)
+	if err != nil {
+		return err
+	}
+	_, err = db.dbConn.Collection(cadence.TaskListsCollectionName).InsertOne(ctx, doc)
+	if mongo.IsDuplicateKeyError(err) {
+		return db.taskListConditionFailure(ctx, &nosqlplugin.TaskListFilter{
+			DomainID:     row.DomainID,
+			TaskListName: row.TaskListName,
+			TaskListType: row.TaskListType,
+		})
+	}
+	return err
 }
 
 // UpdateTaskList updates a single tasklist row
@@ -45,7 +73,7 @@ func (db *mdb) UpdateTaskList(
 	row *nosqlplugin.TaskListRow,
 	previousRangeID int64,
 ) error {
-	panic("TODO")
+	return db.UpdateTaskListWithTTL(ctx, 0, row, previousRangeID)
 }
 
 // UpdateTaskList updates a single tasklist row, and set an TTL on the record
@@ -58,19 +86,70 @@ func (db *mdb) UpdateTaskListWithTTL(
 	row *nosqlplugin.TaskListRow,
 	previousRangeID int64,
 ) error {
-	panic("TODO")
+	doc, err := newTaskListEntry(row, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	filter := &nosqlplugin.TaskListFilter{
+		DomainID:     row.DomainID,
+		TaskListName: row.TaskListName,
+		TaskListType: row.TaskListType,
+	}
+	result, err := db.dbConn.Collection(cadence.TaskListsCollectionName).ReplaceOne(
+		ctx,
+		append(taskListFilter(filter), bson.E{Key: "rangeid", Value: previousRangeID}),
+		doc,
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return db.taskListConditionFailure(ctx, filter)
+	}
+	return nil
 }
 
 // ListTaskList returns all tasklists.
 // Noop if TTL is already implemented in other methods
 func (db *mdb) ListTaskList(ctx context.Context, pageSize int, nextPageToken []byte) (*nosqlplugin.ListTaskListResult, error) {
-	panic("TODO")
+	docs, token, err := findPage[cadence.TaskListCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.TaskListsCollectionName),
+		bson.D{},
+		bson.D{{"domainid", 1}, {"tasklistname", 1}, {"tasklisttype", 1}},
+		pageSize,
+		nextPageToken,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result := &nosqlplugin.ListTaskListResult{
+		NextPageToken: token,
+	}
+	for _, doc := range docs {
+		row, err := parseTaskListEntry(doc)
+		if err != nil {
+			return nil, err
+		}
+		result.TaskLists = append(result.TaskLists, row)
+	}
+	return result, nil
 }
 
 // DeleteTaskList deletes a single tasklist row
 // Return TaskOperationConditionFailure if the condition doesn't meet
 func (db *mdb) DeleteTaskList(ctx context.Context, filter *nosqlplugin.TaskListFilter, previousRangeID int64) error {
-	panic("TODO")
+	result, err := db.dbConn.Collection(cadence.TaskListsCollectionName).DeleteOne(
+		ctx,
+		append(taskListFilter(filter), bson.E{Key: "rangeid", Value: previousRangeID}),
+	)
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return db.taskListConditionFailure(ctx, filter)
+	}
+	return nil
 }
 
 // InsertTasks inserts a batch of tasks
@@ -80,16 +159,83 @@ func (db *mdb) InsertTasks(
 	tasksToInsert []*nosqlplugin.TaskRowForInsert,
 	tasklistCondition *nosqlplugin.TaskListRow,
 ) error {
-	panic("TODO")
+	docs := make([]interface{}, 0, len(tasksToInsert))
+	for _, task := range tasksToInsert {
+		row := task.TaskRow
+		row.DomainID = tasklistCondition.DomainID
+		row.TaskListName = tasklistCondition.TaskListName
+		row.TaskListType = tasklistCondition.TaskListType
+		data, err := json.Marshal(&row)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, &cadence.TaskCollectionEntry{
+			DomainID:     row.DomainID,
+			TaskListName: row.TaskListName,
+			TaskListType: row.TaskListType,
+			TaskID:       row.TaskID,
+			Data:         data,
+			Expiry:       expiry(tasklistCondition.CurrentTimeStamp, int64(task.TTLSeconds)),
+		})
+	}
+
+	filter := &nosqlplugin.TaskListFilter{
+		DomainID:     tasklistCondition.DomainID,
+		TaskListName: tasklistCondition.TaskListName,
+		TaskListType: tasklistCondition.TaskListType,
+	}
+	return db.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		var doc cadence.TaskListCollectionEntry
+		err := db.dbConn.Collection(cadence.TaskListsCollectionName).FindOne(sessCtx, taskListFilter(filter)).Decode(&doc)
+		if err != nil && !db.IsNotFoundError(err) {
+			return err
+		}
+		if doc.RangeID != tasklistCondition.RangeID {
+			return &nosqlplugin.TaskOperationConditionFailure{
+				RangeID: doc.RangeID,
+				Details: fmt.Sprintf("Failed to insert tasks. Request RangeID: %v, Actual RangeID: %v", tasklistCondition.RangeID, doc.RangeID),
+			}
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+		_, err = db.dbConn.Collection(cadence.TasksCollectionName).InsertMany(sessCtx, docs)
+		return err
+	})
 }
 
 // SelectTasks return tasks that associated to a tasklist
 func (db *mdb) SelectTasks(ctx context.Context, filter *nosqlplugin.TasksFilter) ([]*nosqlplugin.TaskRow, error) {
-	panic("TODO")
+	docs, _, err := findPage[cadence.TaskCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.TasksCollectionName),
+		tasksRangeFilter(filter),
+		bson.D{{"taskid", 1}},
+		filter.BatchSize,
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	tasks := make([]*nosqlplugin.TaskRow, 0, len(docs))
+	for _, doc := range docs {
+		task := &nosqlplugin.TaskRow{}
+		if err := json.Unmarshal(doc.Data, task); err != nil {
+			return nil, err
+		}
+		if doc.Expiry != nil {
+			task.Expiry = *doc.Expiry
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
 }
 
 func (db *mdb) GetTasksCount(ctx context.Context, filter *nosqlplugin.TasksFilter) (int64, error) {
-	panic("TODO")
+	return db.dbConn.Collection(cadence.TasksCollectionName).CountDocuments(
+		ctx,
+		append(taskListFilter(&filter.TaskListFilter), bson.E{Key: "taskid", Value: bson.D{{"$gt", filter.MinTaskID}}}),
+	)
 }
 
 // DeleteTask delete a batch tasks that taskIDs less than the row
@@ -97,5 +243,62 @@ func (db *mdb) GetTasksCount(ctx context.Context, filter *nosqlplugin.TasksFilte
 // NOTE: This API ignores the `BatchSize` request parameter i.e. either all tasks leq the task_id will be deleted or an error will
 // be returned to the caller, because rowsDeleted is not supported by Cassandra
 func (db *mdb) RangeDeleteTasks(ctx context.Context, filter *nosqlplugin.TasksFilter) (rowsDeleted int, err error) {
-	panic("TODO")
+	if _, err := db.dbConn.Collection(cadence.TasksCollectionName).DeleteMany(ctx, tasksRangeFilter(filter)); err != nil {
+		return 0, err
+	}
+	return persistence.UnknownNumRowsAffected, nil
+}
+
+func taskListFilter(filter *nosqlplugin.TaskListFilter) bson.D {
+	return bson.D{
+		{"domainid", filter.DomainID},
+		{"tasklistname", filter.TaskListName},
+		{"tasklisttype", filter.TaskListType},
+	}
+}
+
+// tasksRangeFilter matches the tasks in (MinTaskID, MaxTaskID]
+func tasksRangeFilter(filter *nosqlplugin.TasksFilter) bson.D {
+	return append(
+		taskListFilter(&filter.TaskListFilter),
+		bson.E{Key: "taskid", Value: bson.D{{"$gt", filter.MinTaskID}, {"$lte", filter.MaxTaskID}}},
+	)
+}
+
+func newTaskListEntry(row *nosqlplugin.TaskListRow, ttlSeconds int64) (*cadence.TaskListCollectionEntry, error) {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	return &cadence.TaskListCollectionEntry{
+		DomainID:     row.DomainID,
+		TaskListName: row.TaskListName,
+		TaskListType: row.TaskListType,
+		RangeID:      row.RangeID,
+		Data:         data,
+		Expiry:       expiry(row.CurrentTimeStamp, ttlSeconds),
+	}, nil
+}
+
+func parseTaskListEntry(doc *cadence.TaskListCollectionEntry) (*nosqlplugin.TaskListRow, error) {
+	row := &nosqlplugin.TaskListRow{}
+	if err := json.Unmarshal(doc.Data, row); err != nil {
+		return nil, err
+	}
+	row.RangeID = doc.RangeID
+	return row, nil
+}
+
+// taskListConditionFailure reads the current rangeID of the tasklist, because a failed conditional write
+// doesn't return the existing document
+func (db *mdb) taskListConditionFailure(ctx context.Context, filter *nosqlplugin.TaskListFilter) error {
+	var doc cadence.TaskListCollectionEntry
+	err := db.dbConn.Collection(cadence.TaskListsCollectionName).FindOne(ctx, taskListFilter(filter)).Decode(&doc)
+	if err != nil && !db.IsNotFoundError(err) {
+		return err
+	}
+	return &nosqlplugin.TaskOperationConditionFailure{
+		RangeID: doc.RangeID,
+		Details: fmt.Sprintf("range_id=%v", doc.RangeID),
+	}
 }
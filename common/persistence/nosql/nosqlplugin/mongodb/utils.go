@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// withTransaction runs fn in a multi-document transaction, which requires MongoDB to run as a replica set.
+// The driver retries fn on transient errors like write conflicts, so fn must not have side effects other than
+// the database operations using the session context.
+// Any error returned by fn aborts the transaction and is returned as is.
+func (db *mdb) withTransaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error {
+	session, err := db.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	txnOptions := options.Transaction().
+		SetReadConcern(readconcern.Snapshot()).
+		SetWriteConcern(writeconcern.New(writeconcern.WMajority()))
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	}, txnOptions)
+	return err
+}
+
+// findPage reads a page of documents ordered by the sort keys, and returns the token to read the next page.
+// The token holds the sort key values of the last document, so that the next page starts right after it
+// regardless of the documents inserted or deleted in between. The sort keys must identify a document uniquely.
+// A non-positive pageSize reads everything.
+func findPage[T any](
+	ctx context.Context,
+	collection *mongo.Collection,
+	filter bson.D,
+	sort bson.D,
+	pageSize int,
+	pageToken []byte,
+) ([]*T, []byte, error) {
+	if len(pageToken) > 0 {
+		after, err := afterTokenFilter(sort, pageToken)
+		if err != nil {
+			return nil, nil, err
+		}
+		filter = bson.D{{"$and", bson.A{filter, after}}}
+	}
+	findOptions := options.Find().SetSort(sort)
+	if pageSize > 0 {
+		findOptions.SetLimit(int64(pageSize))
+	}
+	cursor, err := collection.Find(ctx, filter, findOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []*T
+	var last bson.Raw
+	for cursor.Next(ctx) {
+		result := new(T)
+		if err := cursor.Decode(result); err != nil {
+			return nil, nil, err
+		}
+		results = append(results, result)
+		last = cursor.Current
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, nil, err
+	}
+	if pageSize <= 0 || len(results) < pageSize {
+		return results, nil, nil
+	}
+	nextPageToken, err := newPageToken(sort, last)
+	if err != nil {
+		return nil, nil, err
+	}
+	return results, nextPageToken, nil
+}
+
+// findAll reads all the documents matching the filter, ordered by the sort keys
+func findAll[T any](ctx context.Context, collection *mongo.Collection, filter bson.D, sort bson.D) ([]*T, error) {
+	results, _, err := findPage[T](ctx, collection, filter, sort, 0, nil)
+	return results, err
+}
+
+func newPageToken(sort bson.D, last bson.Raw) ([]byte, error) {
+	token := make(bson.D, 0, len(sort))
+	for _, key := range sort {
+		value, err := last.LookupErr(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("sort key %v is missing in the document: %v", key.Key, err)
+		}
+		token = append(token, bson.E{Key: key.Key, Value: value})
+	}
+	return bson.Marshal(token)
+}
+
+// afterTokenFilter matches the documents after the one of the token in the sort order, e.g. for sort keys (a, b):
+// {$or: [{a: {$gt: token.a}}, {a: token.a, b: {$gt: token.b}}]}
+func afterTokenFilter(sort bson.D, pageToken []byte) (bson.D, error) {
+	var token bson.D
+	if err := bson.Unmarshal(pageToken, &token); err != nil {
+		return nil, fmt.Errorf("invalid page token: %v", err)
+	}
+	if len(token) != len(sort) {
+		return nil, fmt.Errorf("invalid page token: expect %v sort keys but got %v", len(sort), len(token))
+	}
+	clauses := make(bson.A, 0, len(sort))
+	for i, key := range sort {
+		operator := "$gt"
+		if direction, ok := key.Value.(int); ok && direction < 0 {
+			operator = "$lt"
+		}
+		clause := append(make(bson.D, 0, i+1), token[:i]...)
+		clause = append(clause, bson.E{Key: key.Key, Value: bson.D{{operator, token[i].Value}}})
+		clauses = append(clauses, clause)
+	}
+	return bson.D{{"$or", clauses}}, nil
+}
+
+// expiry returns the time for TTL indexes to delete the document, or nil if the document never expires
+func expiry(now time.Time, ttlSeconds int64) *time.Time {
+	if ttlSeconds <= 0 {
+		return nil
+	}
+	t := now.Add(time.Duration(ttlSeconds) * time.Second)
+	return &t
+}
+
+// timestampTaskIDFilter compares the tuple (visibilitytimestamp, taskid) with (timestamp, taskID), e.g.
+// timestampTaskIDFilter("$lt", "$lt", ts, id) matches (visibilitytimestamp, taskid) < (ts, id).
+// timestampOperator is the strict comparison of the timestamps, and taskIDOperator decides whether the bound is inclusive.
+func timestampTaskIDFilter(timestampOperator, taskIDOperator string, timestamp, taskID int64) bson.D {
+	return bson.D{{"$or", bson.A{
+		bson.D{{"visibilitytimestamp", bson.D{{timestampOperator, timestamp}}}},
+		bson.D{{"visibilitytimestamp", timestamp}, {"taskid", bson.D{{taskIDOperator, taskID}}}},
+	}}}
+}
+
+// upsert replaces the document matching the filter, or inserts it if there is none
+func upsert(ctx context.Context, collection *mongo.Collection, filter bson.D, doc interface{}) error {
+	_, err := collection.ReplaceOne(ctx, filter, doc, options.Replace().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mongodb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestPageToken(t *testing.T) {
+	sort := bson.D{{"createdtime", -1}, {"eventid", 1}}
+	last, err := bson.Marshal(bson.D{{"domainid", "d"}, {"createdtime", int64(10)}, {"eventid", "e"}})
+	require.NoError(t, err)
+
+	token, err := newPageToken(sort, last)
+	require.NoError(t, err)
+
+	filter, err := afterTokenFilter(sort, token)
+	require.NoError(t, err)
+	expected, err := bson.MarshalExtJSON(bson.D{{"$or", bson.A{
+		bson.D{{"createdtime", bson.D{{"$lt", int64(10)}}}},
+		bson.D{{"createdtime", int64(10)}, {"eventid", bson.D{{"$gt", "e"}}}},
+	}}}, false, false)
+	require.NoError(t, err)
+	actual, err := bson.MarshalExtJSON(filter, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestPageTokenErrors(t *testing.T) {
+	sort := bson.D{{"taskid", 1}}
+
+	last, err := bson.Marshal(bson.D{{"shardid", 1}})
+	require.NoError(t, err)
+	_, err = newPageToken(sort, last)
+	assert.Error(t, err)
+
+	_, err = afterTokenFilter(sort, []byte("invalid"))
+	assert.Error(t, err)
+
+	token, err := bson.Marshal(bson.D{{"a", 1}, {"b", 2}})
+	require.NoError(t, err)
+	_, err = afterTokenFilter(sort, token)
+	assert.Error(t, err)
+}
+
+func TestExpiry(t *testing.T) {
+	now := time.Unix(100, 0)
+	assert.Nil(t, expiry(now, 0))
+	assert.Nil(t, expiry(now, -1))
+	assert.Equal(t, time.Unix(160, 0), *expiry(now, 60))
+}
@@ -22,16 +22,30 @@ package mongodb
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
+// Unlike Cassandra, there is a single visibility document per run. A run is open until closetime is set,
+// and the secondary indexes on starttime and closetime serve the different sort orders.
+
 func (db *mdb) InsertVisibility(
 	ctx context.Context,
 	ttlSeconds int64,
 	row *nosqlplugin.VisibilityRowForInsert,
 ) error {
-	panic("TODO")
+	doc, err := newVisibilityEntry(row.DomainID, &row.VisibilityRow, false, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	return db.upsertVisibility(ctx, doc)
 }
 
 func (db *mdb) UpdateVisibility(
@@ -39,26 +53,174 @@ func (db *mdb) UpdateVisibility(
 	ttlSeconds int64,
 	row *nosqlplugin.VisibilityRowForUpdate,
 ) error {
-	panic("TODO")
+	if row.UpdateCloseToOpen {
+		// TODO implement it when where is a need
+		return fmt.Errorf("not supported operation")
+	}
+	doc, err := newVisibilityEntry(row.DomainID, &row.VisibilityRow, true, ttlSeconds)
+	if err != nil {
+		return err
+	}
+	return db.upsertVisibility(ctx, doc)
+}
+
+func (db *mdb) upsertVisibility(ctx context.Context, doc *cadence.VisibilityCollectionEntry) error {
+	return upsert(ctx, db.dbConn.Collection(cadence.VisibilityCollectionName), visibilityFilter(doc.DomainID, doc.WorkflowID, doc.RunID), doc)
 }
 
 func (db *mdb) SelectVisibility(
 	ctx context.Context,
 	filter *nosqlplugin.VisibilityFilter,
 ) (*nosqlplugin.SelectVisibilityResponse, error) {
-	panic("TODO")
+	request := &filter.ListRequest
+	timeRange := bson.D{{"$gte", request.EarliestTime.UnixNano()}, {"$lte", request.LatestTime.UnixNano()}}
+	query := bson.D{{"domainid", request.DomainUUID}}
+	var sort bson.D
+	switch filter.FilterType {
+	case nosqlplugin.AllOpen, nosqlplugin.OpenByWorkflowType, nosqlplugin.OpenByWorkflowID:
+		query = append(query,
+			bson.E{Key: "closetime", Value: bson.D{{"$exists", false}}},
+			bson.E{Key: "starttime", Value: timeRange},
+		)
+		sort = bson.D{{"starttime", -1}, {"runid", -1}}
+	case nosqlplugin.AllClosed, nosqlplugin.ClosedByWorkflowType, nosqlplugin.ClosedByWorkflowID, nosqlplugin.ClosedByClosedStatus:
+		switch filter.SortType {
+		case nosqlplugin.SortByStartTime:
+			query = append(query,
+				bson.E{Key: "closetime", Value: bson.D{{"$exists", true}}},
+				bson.E{Key: "starttime", Value: timeRange},
+			)
+			sort = bson.D{{"starttime", -1}, {"runid", -1}}
+		case nosqlplugin.SortByClosedTime:
+			query = append(query, bson.E{Key: "closetime", Value: timeRange})
+			sort = bson.D{{"closetime", -1}, {"runid", -1}}
+		default:
+			return nil, fmt.Errorf("not supported sorting type: %v", filter.SortType)
+		}
+	default:
+		return nil, fmt.Errorf("not supported filter type: %v", filter.FilterType)
+	}
+	switch filter.FilterType {
+	case nosqlplugin.OpenByWorkflowType, nosqlplugin.ClosedByWorkflowType:
+		query = append(query, bson.E{Key: "workflowtype", Value: filter.WorkflowType})
+	case nosqlplugin.OpenByWorkflowID, nosqlplugin.ClosedByWorkflowID:
+		query = append(query, bson.E{Key: "workflowid", Value: filter.WorkflowID})
+	case nosqlplugin.ClosedByClosedStatus:
+		query = append(query, bson.E{Key: "closestatus", Value: filter.CloseStatus})
+	}
+
+	docs, nextPageToken, err := findPage[cadence.VisibilityCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.VisibilityCollectionName),
+		query,
+		sort,
+		request.PageSize,
+		request.NextPageToken,
+	)
+	if err != nil {
+		return nil, err
+	}
+	executions := make([]*nosqlplugin.VisibilityRow, 0, len(docs))
+	for _, doc := range docs {
+		row := &nosqlplugin.VisibilityRow{}
+		if err := json.Unmarshal(doc.Data, row); err != nil {
+			return nil, err
+		}
+		executions = append(executions, row)
+	}
+	return &nosqlplugin.SelectVisibilityResponse{
+		Executions:    executions,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// UpsertVisibility is not supported because search attributes are not stored
+func (db *mdb) UpsertVisibility(
+	ctx context.Context,
+	ttlSeconds int64,
+	row *nosqlplugin.VisibilityRowForInsert,
+) error {
+	return persistence.ErrVisibilityOperationNotSupported
 }
 
+// SelectVisibilityBucket is not supported because visibility queries are not supported
+func (db *mdb) SelectVisibilityBucket(
+	ctx context.Context,
+	filter *nosqlplugin.VisibilityBucketFilter,
+) ([]*nosqlplugin.VisibilityRow, error) {
+	return nil, persistence.ErrVisibilityOperationNotSupported
+}
+
+// SelectClosedVisibilityBuckets is not supported because visibility queries are not supported
+func (db *mdb) SelectClosedVisibilityBuckets(
+	ctx context.Context,
+	domainID string,
+	minBucket, maxBucket int64,
+) ([]int64, error) {
+	return nil, persistence.ErrVisibilityOperationNotSupported
+}
+
+// DeleteVisibility is a noop because of TTL, except for the admin command which deletes the record of the run
 func (db *mdb) DeleteVisibility(
 	ctx context.Context,
 	domainID, workflowID, runID string,
 ) error {
-	panic("TODO")
+	key := persistence.VisibilityAdminDeletionKey("visibilityAdminDelete")
+	if v := ctx.Value(key); v == nil || !v.(bool) {
+		return nil
+	}
+	_, err := db.dbConn.Collection(cadence.VisibilityCollectionName).DeleteOne(ctx, visibilityFilter(domainID, workflowID, runID))
+	return err
 }
 
 func (db *mdb) SelectOneClosedWorkflow(
 	ctx context.Context,
 	domainID, workflowID, runID string,
 ) (*nosqlplugin.VisibilityRow, error) {
-	panic("TODO")
+	var doc cadence.VisibilityCollectionEntry
+	err := db.dbConn.Collection(cadence.VisibilityCollectionName).FindOne(ctx, visibilityFilter(domainID, workflowID, runID)).Decode(&doc)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			// Special case: return nil,nil if not found(since we will deprecate it, it's not worth refactor to be consistent)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if doc.CloseTime == nil {
+		return nil, nil
+	}
+	row := &nosqlplugin.VisibilityRow{}
+	if err := json.Unmarshal(doc.Data, row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func visibilityFilter(domainID, workflowID, runID string) bson.D {
+	return bson.D{{"domainid", domainID}, {"workflowid", workflowID}, {"runid", runID}}
+}
+
+func newVisibilityEntry(domainID string, row *nosqlplugin.VisibilityRow, closed bool, ttlSeconds int64) (*cadence.VisibilityCollectionEntry, error) {
+	data, err := json.Marshal(row)
+	if err != nil {
+		return nil, err
+	}
+	doc := &cadence.VisibilityCollectionEntry{
+		DomainID:     domainID,
+		WorkflowID:   row.WorkflowID,
+		RunID:        row.RunID,
+		WorkflowType: row.TypeName,
+		StartTime:    row.StartTime.UnixNano(),
+		Data:         data,
+		Expiry:       expiry(time.Now(), ttlSeconds),
+	}
+	if closed {
+		closeTime := row.CloseTime.UnixNano()
+		doc.CloseTime = &closeTime
+		if row.Status != nil {
+			closeStatus := int32(*row.Status)
+			doc.CloseStatus = &closeStatus
+		}
+	}
+	return doc, nil
 }
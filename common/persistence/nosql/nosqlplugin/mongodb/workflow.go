@@ -23,14 +23,23 @@ package mongodb
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
 )
 
 var _ nosqlplugin.WorkflowCRUD = (*mdb)(nil)
 
+// InsertWorkflowExecutionWithTasks checks the conditions in the same order as the Cassandra implementation
+// within a transaction, so that the first failed condition is reported and nothing is written
 func (db *mdb) InsertWorkflowExecutionWithTasks(
 	ctx context.Context,
 	requests *nosqlplugin.WorkflowRequestsWriteRequest,
@@ -40,9 +49,36 @@ func (db *mdb) InsertWorkflowExecutionWithTasks(
 	activeClusterSelectionPolicyRow *nosqlplugin.ActiveClusterSelectionPolicyRow,
 	shardCondition *nosqlplugin.ShardCondition,
 ) error {
-	panic("TODO")
+	shardID := shardCondition.ShardID
+	return db.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if err := db.lockShardForWorkflow(sessCtx, shardCondition); err != nil {
+			return err
+		}
+		if err := db.writeWorkflowRequests(sessCtx, requests, execution.CurrentTimeStamp); err != nil {
+			return err
+		}
+		if err := db.writeCurrentWorkflow(
+			sessCtx,
+			shardID,
+			execution.DomainID,
+			execution.WorkflowID,
+			currentWorkflowRequest,
+			createCurrentWorkflowConditionFailure(currentWorkflowRequest),
+		); err != nil {
+			return err
+		}
+		if err := db.createExecution(sessCtx, shardID, execution); err != nil {
+			return err
+		}
+		if err := db.upsertActiveClusterSelectionPolicy(sessCtx, activeClusterSelectionPolicyRow); err != nil {
+			return err
+		}
+		return db.insertHistoryTasks(sessCtx, shardID, tasksByCategory)
+	})
 }
 
+// UpdateWorkflowExecutionWithTasks checks the conditions in the same order as the Cassandra implementation
+// within a transaction, so that the first failed condition is reported and nothing is written
 func (db *mdb) UpdateWorkflowExecutionWithTasks(
 	ctx context.Context,
 	requests *nosqlplugin.WorkflowRequestsWriteRequest,
@@ -54,113 +90,386 @@ func (db *mdb) UpdateWorkflowExecutionWithTasks(
 	tasksByCategory map[persistence.HistoryTaskCategory][]*nosqlplugin.HistoryMigrationTask,
 	shardCondition *nosqlplugin.ShardCondition,
 ) error {
-	panic("TODO")
+	shardID := shardCondition.ShardID
+	var domainID, workflowID string
+	var timeStamp time.Time
+	switch {
+	case mutatedExecution != nil:
+		domainID, workflowID, timeStamp = mutatedExecution.DomainID, mutatedExecution.WorkflowID, mutatedExecution.CurrentTimeStamp
+	case resetExecution != nil:
+		domainID, workflowID, timeStamp = resetExecution.DomainID, resetExecution.WorkflowID, resetExecution.CurrentTimeStamp
+	default:
+		return fmt.Errorf("at least one of mutatedExecution and resetExecution should be provided")
+	}
+
+	return db.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if err := db.lockShardForWorkflow(sessCtx, shardCondition); err != nil {
+			return err
+		}
+		if err := db.writeWorkflowRequests(sessCtx, requests, timeStamp); err != nil {
+			return err
+		}
+		if err := db.writeCurrentWorkflow(
+			sessCtx,
+			shardID,
+			domainID,
+			workflowID,
+			currentWorkflowRequest,
+			updateCurrentWorkflowConditionFailure(currentWorkflowRequest),
+		); err != nil {
+			return err
+		}
+		if mutatedExecution != nil {
+			if err := db.updateExecution(sessCtx, shardID, mutatedExecution, false); err != nil {
+				return err
+			}
+		}
+		if insertedExecution != nil {
+			if err := db.createExecution(sessCtx, shardID, insertedExecution); err != nil {
+				return err
+			}
+			if err := db.upsertActiveClusterSelectionPolicy(sessCtx, activeClusterSelectionPolicyRow); err != nil {
+				return err
+			}
+		}
+		if resetExecution != nil {
+			if err := db.updateExecution(sessCtx, shardID, resetExecution, true); err != nil {
+				return err
+			}
+		}
+		return db.insertHistoryTasks(sessCtx, shardID, tasksByCategory)
+	})
 }
 
 func (db *mdb) SelectCurrentWorkflow(ctx context.Context, shardID int, domainID, workflowID string) (*nosqlplugin.CurrentWorkflowRow, error) {
-	panic("TODO")
+	return db.selectCurrentWorkflow(ctx, currentWorkflowFilter(shardID, domainID, workflowID))
+}
+
+func (db *mdb) selectCurrentWorkflow(ctx context.Context, filter bson.D) (*nosqlplugin.CurrentWorkflowRow, error) {
+	var doc cadence.CurrentWorkflowCollectionEntry
+	if err := db.dbConn.Collection(cadence.CurrentWorkflowsCollectionName).FindOne(ctx, filter).Decode(&doc); err != nil {
+		return nil, err
+	}
+	return parseCurrentWorkflowEntry(&doc), nil
 }
 
 func (db *mdb) SelectWorkflowExecution(ctx context.Context, shardID int, domainID, workflowID, runID string) (*nosqlplugin.WorkflowExecution, error) {
-	panic("TODO")
+	var doc cadence.ExecutionCollectionEntry
+	err := db.dbConn.Collection(cadence.ExecutionsCollectionName).FindOne(ctx, executionFilter(shardID, domainID, workflowID, runID)).Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+	return parseWorkflowExecution(&doc)
 }
 
 func (db *mdb) DeleteCurrentWorkflow(ctx context.Context, shardID int, domainID, workflowID, currentRunIDCondition string) error {
-	panic("TODO")
+	// same as Cassandra, nothing is deleted if the current workflow has moved on to another run
+	_, err := db.dbConn.Collection(cadence.CurrentWorkflowsCollectionName).DeleteOne(
+		ctx,
+		append(currentWorkflowFilter(shardID, domainID, workflowID), bson.E{Key: "runid", Value: currentRunIDCondition}),
+	)
+	return err
 }
 
 func (db *mdb) DeleteWorkflowExecution(ctx context.Context, shardID int, domainID, workflowID, runID string) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.ExecutionsCollectionName).DeleteOne(ctx, executionFilter(shardID, domainID, workflowID, runID))
+	return err
 }
 
 func (db *mdb) SelectAllCurrentWorkflows(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.CurrentWorkflowExecution, []byte, error) {
-	panic("TODO")
+	docs, nextPageToken, err := findPage[cadence.CurrentWorkflowCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.CurrentWorkflowsCollectionName),
+		bson.D{{"shardid", shardID}},
+		bson.D{{"domainid", 1}, {"workflowid", 1}},
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	executions := make([]*persistence.CurrentWorkflowExecution, 0, len(docs))
+	for _, doc := range docs {
+		executions = append(executions, &persistence.CurrentWorkflowExecution{
+			DomainID:     doc.DomainID,
+			WorkflowID:   doc.WorkflowID,
+			RunID:        doc.RunID,
+			State:        doc.State,
+			CurrentRunID: doc.RunID,
+		})
+	}
+	return executions, nextPageToken, nil
 }
 
 func (db *mdb) SelectAllWorkflowExecutions(ctx context.Context, shardID int, pageToken []byte, pageSize int) ([]*persistence.InternalListConcreteExecutionsEntity, []byte, error) {
-	panic("TODO")
+	docs, nextPageToken, err := findPage[cadence.ExecutionCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.ExecutionsCollectionName),
+		bson.D{{"shardid", shardID}},
+		bson.D{{"domainid", 1}, {"workflowid", 1}, {"runid", 1}},
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	executions := make([]*persistence.InternalListConcreteExecutionsEntity, 0, len(docs))
+	for _, doc := range docs {
+		data := &executionData{}
+		if err := json.Unmarshal(doc.Data, data); err != nil {
+			return nil, nil, err
+		}
+		executions = append(executions, &persistence.InternalListConcreteExecutionsEntity{
+			ExecutionInfo:    data.ExecutionInfo,
+			VersionHistories: data.VersionHistories,
+		})
+	}
+	return executions, nextPageToken, nil
 }
 
 func (db *mdb) IsWorkflowExecutionExists(ctx context.Context, shardID int, domainID, workflowID, runID string) (bool, error) {
-	panic("TODO")
+	count, err := db.dbConn.Collection(cadence.ExecutionsCollectionName).CountDocuments(
+		ctx,
+		executionFilter(shardID, domainID, workflowID, runID),
+		options.Count().SetLimit(1),
+	)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
 }
 
 func (db *mdb) SelectTransferTasksOrderByTaskID(ctx context.Context, shardID, pageSize int, pageToken []byte, inclusiveMinTaskID, exclusiveMaxTaskID int64) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
-	panic("TODO")
+	return db.selectTasksByTaskID(ctx, historyTaskFilter(shardID, taskCategoryTransfer, ""), pageSize, pageToken, inclusiveMinTaskID, exclusiveMaxTaskID)
 }
 
 func (db *mdb) DeleteTransferTask(ctx context.Context, shardID int, keys []persistence.HistoryTaskKey) error {
-	panic("TODO")
+	return db.deleteTasksByTaskID(ctx, historyTaskFilter(shardID, taskCategoryTransfer, ""), keys)
 }
 
 func (db *mdb) RangeDeleteTransferTasks(ctx context.Context, shardID int, inclusiveBeginTaskID, exclusiveEndTaskID int64) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.HistoryTasksCollectionName).DeleteMany(ctx, append(
+		historyTaskFilter(shardID, taskCategoryTransfer, ""),
+		bson.E{Key: "taskid", Value: bson.D{{"$gte", inclusiveBeginTaskID}, {"$lt", exclusiveEndTaskID}}},
+	))
+	return err
 }
 
 func (db *mdb) SelectTimerTasksOrderByVisibilityTime(ctx context.Context, shardID, pageSize int, pageToken []byte, inclusiveMinTime, exclusiveMaxTime time.Time) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
-	panic("TODO")
+	docs, nextPageToken, err := findPage[cadence.HistoryTaskCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.HistoryTasksCollectionName),
+		timerTaskRangeFilter(shardID, inclusiveMinTime, exclusiveMaxTime),
+		bson.D{{"visibilitytimestamp", 1}, {"taskid", 1}},
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks, err := parseHistoryTasks(docs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, nextPageToken, nil
 }
 
 func (db *mdb) DeleteTimerTask(ctx context.Context, shardID int, keys []persistence.HistoryTaskKey) error {
-	panic("TODO")
+	if len(keys) == 0 {
+		return nil
+	}
+	tasks := make(bson.A, 0, len(keys))
+	for _, key := range keys {
+		tasks = append(tasks, bson.D{{"visibilitytimestamp", key.GetScheduledTime().UnixNano()}, {"taskid", key.GetTaskID()}})
+	}
+	_, err := db.dbConn.Collection(cadence.HistoryTasksCollectionName).DeleteMany(ctx, append(
+		historyTaskFilter(shardID, taskCategoryTimer, ""),
+		bson.E{Key: "$or", Value: tasks},
+	))
+	return err
 }
 
 func (db *mdb) RangeDeleteTimerTasks(ctx context.Context, shardID int, inclusiveMinTime, exclusiveMaxTime time.Time) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.HistoryTasksCollectionName).DeleteMany(ctx, timerTaskRangeFilter(shardID, inclusiveMinTime, exclusiveMaxTime))
+	return err
 }
 
 func (db *mdb) SelectReplicationTasksOrderByTaskID(ctx context.Context, shardID, pageSize int, pageToken []byte, inclusiveMinTaskID, exclusiveMaxTaskID int64) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
-	panic("TODO")
+	return db.selectTasksByTaskID(ctx, historyTaskFilter(shardID, taskCategoryReplication, ""), pageSize, pageToken, inclusiveMinTaskID, exclusiveMaxTaskID)
 }
 
 func (db *mdb) DeleteReplicationTask(ctx context.Context, shardID int, keys []persistence.HistoryTaskKey) error {
-	panic("TODO")
+	return db.deleteTasksByTaskID(ctx, historyTaskFilter(shardID, taskCategoryReplication, ""), keys)
 }
 
 func (db *mdb) RangeDeleteReplicationTasks(ctx context.Context, shardID int, exclusiveEndTaskID int64) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.HistoryTasksCollectionName).DeleteMany(ctx, append(
+		historyTaskFilter(shardID, taskCategoryReplication, ""),
+		bson.E{Key: "taskid", Value: bson.D{{"$lt", exclusiveEndTaskID}}},
+	))
+	return err
 }
 
 func (db *mdb) InsertReplicationTask(ctx context.Context, tasks []*nosqlplugin.HistoryMigrationTask, condition nosqlplugin.ShardCondition) error {
-	panic("TODO")
+	if len(tasks) == 0 {
+		return nil
+	}
+	return db.InsertHistoryTasks(ctx, map[persistence.HistoryTaskCategory][]*nosqlplugin.HistoryMigrationTask{
+		persistence.HistoryTaskCategoryReplication: tasks,
+	}, tasks[0].Replication.CurrentTimeStamp, condition)
 }
 
+// InsertHistoryTasks writes the tasks in a transaction which checks the shard rangeID
 func (db *mdb) InsertHistoryTasks(ctx context.Context, tasksByCategory map[persistence.HistoryTaskCategory][]*nosqlplugin.HistoryMigrationTask, currentTimeStamp time.Time, condition nosqlplugin.ShardCondition) error {
-	panic("TODO")
+	return db.withTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		ok, rangeID, err := db.lockShard(sessCtx, condition)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return &nosqlplugin.ShardOperationConditionFailure{
+				RangeID: rangeID,
+				Details: fmt.Sprintf("Failed to insert history tasks. ShardID: %v, Request RangeID: %v, Actual RangeID: %v", condition.ShardID, condition.RangeID, rangeID),
+			}
+		}
+		return db.insertHistoryTasks(sessCtx, condition.ShardID, tasksByCategory)
+	})
 }
 
 func (db *mdb) DeleteCrossClusterTask(ctx context.Context, shardID int, targetCluster string, taskID int64) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.HistoryTasksCollectionName).DeleteOne(ctx, append(
+		historyTaskFilter(shardID, taskCategoryCrossCluster, targetCluster),
+		bson.E{Key: "taskid", Value: taskID},
+	))
+	return err
 }
 
 func (db *mdb) InsertReplicationDLQTask(ctx context.Context, shardID int, sourceCluster string, task *nosqlplugin.HistoryMigrationTask) error {
-	panic("TODO")
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	return db.upsertHistoryTask(ctx, db.dbConn.Collection(cadence.HistoryTasksCollectionName), &cadence.HistoryTaskCollectionEntry{
+		ShardID:  shardID,
+		Category: taskCategoryReplicationDLQ,
+		Cluster:  sourceCluster,
+		TaskID:   task.Replication.TaskID,
+		Data:     data,
+	})
 }
 
 func (db *mdb) SelectReplicationDLQTasksOrderByTaskID(ctx context.Context, shardID int, sourceCluster string, pageSize int, pageToken []byte, inclusiveMinTaskID, exclusiveMaxTaskID int64) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
-	panic("TODO")
+	return db.selectTasksByTaskID(ctx, historyTaskFilter(shardID, taskCategoryReplicationDLQ, sourceCluster), pageSize, pageToken, inclusiveMinTaskID, exclusiveMaxTaskID)
 }
 
 func (db *mdb) SelectReplicationDLQTasksCount(ctx context.Context, shardID int, sourceCluster string) (int64, error) {
-	panic("TODO")
+	return db.dbConn.Collection(cadence.HistoryTasksCollectionName).CountDocuments(ctx, historyTaskFilter(shardID, taskCategoryReplicationDLQ, sourceCluster))
 }
 
 func (db *mdb) DeleteReplicationDLQTask(ctx context.Context, shardID int, sourceCluster string, taskID int64) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.HistoryTasksCollectionName).DeleteOne(ctx, append(
+		historyTaskFilter(shardID, taskCategoryReplicationDLQ, sourceCluster),
+		bson.E{Key: "taskid", Value: taskID},
+	))
+	return err
 }
 
 func (db *mdb) RangeDeleteReplicationDLQTasks(ctx context.Context, shardID int, sourceCluster string, inclusiveBeginTaskID, exclusiveEndTaskID int64) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.HistoryTasksCollectionName).DeleteMany(ctx, append(
+		historyTaskFilter(shardID, taskCategoryReplicationDLQ, sourceCluster),
+		bson.E{Key: "taskid", Value: bson.D{{"$gte", inclusiveBeginTaskID}, {"$lt", exclusiveEndTaskID}}},
+	))
+	return err
 }
 
 func (db *mdb) SelectActiveClusterSelectionPolicy(ctx context.Context, shardID int, domainID, wfID, rID string) (*nosqlplugin.ActiveClusterSelectionPolicyRow, error) {
-	panic("TODO")
+	var doc cadence.ActiveClusterSelectionPolicyCollectionEntry
+	err := db.dbConn.Collection(cadence.ActiveClusterSelectionPoliciesCollectionName).FindOne(ctx, executionFilter(shardID, domainID, wfID, rID)).Decode(&doc)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	policy := &persistence.DataBlob{}
+	if err := json.Unmarshal(doc.Data, policy); err != nil {
+		return nil, err
+	}
+	return &nosqlplugin.ActiveClusterSelectionPolicyRow{
+		ShardID:    shardID,
+		DomainID:   domainID,
+		WorkflowID: wfID,
+		RunID:      rID,
+		Policy:     policy,
+	}, nil
 }
 
 func (db *mdb) DeleteActiveClusterSelectionPolicy(ctx context.Context, shardID int, domainID, wfID, rID string) error {
-	panic("TODO")
+	_, err := db.dbConn.Collection(cadence.ActiveClusterSelectionPoliciesCollectionName).DeleteOne(ctx, executionFilter(shardID, domainID, wfID, rID))
+	return err
 }
 
 func (db *mdb) SelectWorkflowTimerTasks(ctx context.Context, shardID int, domainID, workflowID, runID string) ([]persistence.HistoryTaskKey, error) {
-	panic("TODO")
+	var doc cadence.ExecutionCollectionEntry
+	err := db.dbConn.Collection(cadence.ExecutionsCollectionName).FindOne(
+		ctx,
+		executionFilter(shardID, domainID, workflowID, runID),
+		options.FindOne().SetProjection(bson.D{{fieldWorkflowTimerTasks, 1}}),
+	).Decode(&doc)
+	if err != nil {
+		if db.IsNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseWorkflowTimerTasks(&doc)
+}
+
+func (db *mdb) selectTasksByTaskID(
+	ctx context.Context,
+	filter bson.D,
+	pageSize int,
+	pageToken []byte,
+	inclusiveMinTaskID int64,
+	exclusiveMaxTaskID int64,
+) ([]*nosqlplugin.HistoryMigrationTask, []byte, error) {
+	docs, nextPageToken, err := findPage[cadence.HistoryTaskCollectionEntry](
+		ctx,
+		db.dbConn.Collection(cadence.HistoryTasksCollectionName),
+		append(filter, bson.E{Key: "taskid", Value: bson.D{{"$gte", inclusiveMinTaskID}, {"$lt", exclusiveMaxTaskID}}}),
+		bson.D{{"taskid", 1}},
+		pageSize,
+		pageToken,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	tasks, err := parseHistoryTasks(docs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tasks, nextPageToken, nil
+}
+
+func (db *mdb) deleteTasksByTaskID(ctx context.Context, filter bson.D, keys []persistence.HistoryTaskKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	taskIDs := make(bson.A, 0, len(keys))
+	for _, key := range keys {
+		taskIDs = append(taskIDs, key.GetTaskID())
+	}
+	_, err := db.dbConn.Collection(cadence.HistoryTasksCollectionName).DeleteMany(ctx, append(
+		filter,
+		bson.E{Key: "taskid", Value: bson.D{{"$in", taskIDs}}},
+	))
+	return err
+}
+
+// timerTaskRangeFilter matches the timer tasks in [inclusiveMinTime, exclusiveMaxTime)
+func timerTaskRangeFilter(shardID int, inclusiveMinTime, exclusiveMaxTime time.Time) bson.D {
+	return append(
+		historyTaskFilter(shardID, taskCategoryTimer, ""),
+		bson.E{Key: "visibilitytimestamp", Value: bson.D{{"$gte", inclusiveMinTime.UnixNano()}, {"$lt", exclusiveMaxTime.UnixNano()}}},
+	)
 }
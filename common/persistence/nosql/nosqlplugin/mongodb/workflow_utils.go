@@ -0,0 +1,827 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mongodb
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
+)
+
+// same as Cassandra, workflow requests are kept for 3 hours for deduplication
+const workflowRequestTTLInSeconds = 10800
+
+// the categories of the history_tasks collection
+const (
+	taskCategoryTransfer       = "transfer"
+	taskCategoryTimer          = "timer"
+	taskCategoryReplication    = "replication"
+	taskCategoryReplicationDLQ = "replication_dlq"
+	taskCategoryCrossCluster   = "cross_cluster"
+)
+
+// the map fields of the executions collection
+const (
+	fieldActivityMap        = "activitymap"
+	fieldTimerMap           = "timermap"
+	fieldChildExecutionsMap = "childexecutionsmap"
+	fieldRequestCancelMap   = "requestcancelmap"
+	fieldSignalMap          = "signalmap"
+	fieldSignalRequested    = "signalrequested"
+	fieldWorkflowTimerTasks = "workflowtimertasks"
+	fieldBufferedEvents     = "bufferedevents"
+)
+
+type (
+	// executionData is the Data field of a workflow execution document
+	executionData struct {
+		ExecutionInfo    *persistence.InternalWorkflowExecutionInfo
+		VersionHistories *persistence.DataBlob
+		Checksum         checksum.Checksum
+	}
+
+	// workflowTimerTask is an entry of the workflowtimertasks map
+	workflowTimerTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+	}
+)
+
+func currentWorkflowFilter(shardID int, domainID, workflowID string) bson.D {
+	return bson.D{{"shardid", shardID}, {"domainid", domainID}, {"workflowid", workflowID}}
+}
+
+func executionFilter(shardID int, domainID, workflowID, runID string) bson.D {
+	return bson.D{{"shardid", shardID}, {"domainid", domainID}, {"workflowid", workflowID}, {"runid", runID}}
+}
+
+func historyTaskFilter(shardID int, category, cluster string) bson.D {
+	return bson.D{{"shardid", shardID}, {"category", category}, {"cluster", cluster}}
+}
+
+// encodeMapKey encodes the user provided keys, e.g. timer IDs, which may contain "." or "$" that MongoDB
+// doesn't allow in field names
+func encodeMapKey(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func decodeMapKey(key string) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil {
+		return "", fmt.Errorf("invalid map key %q: %v", key, err)
+	}
+	return string(decoded), nil
+}
+
+func int64MapKey(key int64) string {
+	return strconv.FormatInt(key, 10)
+}
+
+func workflowTimerTaskKey(key persistence.HistoryTaskKey) string {
+	return fmt.Sprintf("%d_%d", key.GetScheduledTime().UnixNano(), key.GetTaskID())
+}
+
+// lockShard checks the rangeID of the shard, and bumps a write counter of the shard document, so that the transaction
+// conflicts with any concurrent change of the shard. Under snapshot isolation, reading the shard alone doesn't.
+// It returns false and the actual rangeID if the condition fails, rangeID is 0 if the shard doesn't exist.
+func (db *mdb) lockShard(sessCtx mongo.SessionContext, condition nosqlplugin.ShardCondition) (bool, int64, error) {
+	result, err := db.dbConn.Collection(cadence.ShardsCollectionName).UpdateOne(
+		sessCtx,
+		bson.D{{"_id", condition.ShardID}, {"rangeid", condition.RangeID}},
+		bson.D{{"$inc", bson.D{{"writes", 1}}}},
+	)
+	if err != nil {
+		return false, 0, err
+	}
+	if result.MatchedCount > 0 {
+		return true, condition.RangeID, nil
+	}
+	rangeID, err := db.selectShardRangeID(sessCtx, condition.ShardID)
+	if err != nil && !db.IsNotFoundError(err) {
+		return false, 0, err
+	}
+	return false, rangeID, nil
+}
+
+func (db *mdb) lockShardForWorkflow(sessCtx mongo.SessionContext, condition *nosqlplugin.ShardCondition) error {
+	ok, rangeID, err := db.lockShard(sessCtx, *condition)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &nosqlplugin.WorkflowOperationConditionFailure{
+			ShardRangeIDNotMatch: common.Int64Ptr(rangeID),
+		}
+	}
+	return nil
+}
+
+func (db *mdb) writeWorkflowRequests(
+	sessCtx mongo.SessionContext,
+	requests *nosqlplugin.WorkflowRequestsWriteRequest,
+	timeStamp time.Time,
+) error {
+	if requests == nil {
+		return nil
+	}
+	collection := db.dbConn.Collection(cadence.WorkflowRequestsCollectionName)
+	for _, row := range requests.Rows {
+		filter := bson.D{
+			{"shardid", row.ShardID},
+			{"domainid", row.DomainID},
+			{"workflowid", row.WorkflowID},
+			{"requesttype", int(row.RequestType)},
+			{"requestid", row.RequestID},
+		}
+		switch requests.WriteMode {
+		case nosqlplugin.WorkflowRequestWriteModeInsert:
+			// the TTL monitor deletes the expired documents in the background, they must be ignored until then
+			var existing cadence.WorkflowRequestCollectionEntry
+			err := collection.FindOne(sessCtx, append(filter, bson.E{Key: "expiry", Value: bson.D{{"$gt", timeStamp}}})).Decode(&existing)
+			if err == nil {
+				return &nosqlplugin.WorkflowOperationConditionFailure{
+					DuplicateRequest: &nosqlplugin.DuplicateRequest{
+						RequestType: row.RequestType,
+						RunID:       existing.RunID,
+					},
+				}
+			}
+			if !db.IsNotFoundError(err) {
+				return err
+			}
+		case nosqlplugin.WorkflowRequestWriteModeUpsert:
+		default:
+			return fmt.Errorf("unknown workflow request write mode %v", requests.WriteMode)
+		}
+		doc := &cadence.WorkflowRequestCollectionEntry{
+			ShardID:     row.ShardID,
+			DomainID:    row.DomainID,
+			WorkflowID:  row.WorkflowID,
+			RequestType: int(row.RequestType),
+			RequestID:   row.RequestID,
+			RunID:       row.RunID,
+			Expiry:      *expiry(timeStamp, workflowRequestTTLInSeconds),
+		}
+		if err := upsert(sessCtx, collection, filter, doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCurrentWorkflow checks the condition of the current workflow, and calls conditionFailure with the
+// existing row(nil if it doesn't exist) to translate a failed condition
+func (db *mdb) writeCurrentWorkflow(
+	sessCtx mongo.SessionContext,
+	shardID int,
+	domainID string,
+	workflowID string,
+	request *nosqlplugin.CurrentWorkflowWriteRequest,
+	conditionFailure func(current *nosqlplugin.CurrentWorkflowRow) error,
+) error {
+	if request.WriteMode == nosqlplugin.CurrentWorkflowWriteModeNoop {
+		return nil
+	}
+	collection := db.dbConn.Collection(cadence.CurrentWorkflowsCollectionName)
+	filter := currentWorkflowFilter(shardID, domainID, workflowID)
+	current, err := db.selectCurrentWorkflow(sessCtx, filter)
+	if err != nil && !db.IsNotFoundError(err) {
+		return err
+	}
+
+	switch request.WriteMode {
+	case nosqlplugin.CurrentWorkflowWriteModeInsert:
+		if current != nil {
+			return conditionFailure(current)
+		}
+	case nosqlplugin.CurrentWorkflowWriteModeUpdate:
+		condition := request.Condition
+		if condition == nil || condition.GetCurrentRunID() == "" {
+			return fmt.Errorf("CurrentWorkflowWriteModeUpdate require Condition.CurrentRunID")
+		}
+		if current == nil || current.RunID != condition.GetCurrentRunID() {
+			return conditionFailure(current)
+		}
+		if condition.LastWriteVersion != nil && condition.State != nil &&
+			(current.LastWriteVersion != *condition.LastWriteVersion || current.State != *condition.State) {
+			return conditionFailure(current)
+		}
+	default:
+		return fmt.Errorf("unknown mode %v", request.WriteMode)
+	}
+
+	row := request.Row
+	return upsert(sessCtx, collection, filter, &cadence.CurrentWorkflowCollectionEntry{
+		ShardID:          shardID,
+		DomainID:         domainID,
+		WorkflowID:       workflowID,
+		RunID:            row.RunID,
+		State:            row.State,
+		CloseStatus:      row.CloseStatus,
+		CreateRequestID:  row.CreateRequestID,
+		LastWriteVersion: row.LastWriteVersion,
+	})
+}
+
+func (db *mdb) createExecution(
+	sessCtx mongo.SessionContext,
+	shardID int,
+	execution *nosqlplugin.WorkflowExecutionRequest,
+) error {
+	if execution.EventBufferWriteMode != nosqlplugin.EventBufferWriteModeNone {
+		return fmt.Errorf("should only support EventBufferWriteModeNone")
+	}
+	if execution.MapsWriteMode != nosqlplugin.WorkflowExecutionMapsWriteModeCreate {
+		return fmt.Errorf("should only support WorkflowExecutionMapsWriteModeCreate")
+	}
+	collection := db.dbConn.Collection(cadence.ExecutionsCollectionName)
+	var existing cadence.ExecutionCollectionEntry
+	err := collection.FindOne(sessCtx, executionFilter(shardID, execution.DomainID, execution.WorkflowID, execution.RunID)).Decode(&existing)
+	if err == nil {
+		msg := fmt.Sprintf("Workflow execution already running. WorkflowId: %v, RunId: %v", execution.WorkflowID, execution.RunID)
+		return &nosqlplugin.WorkflowOperationConditionFailure{
+			WorkflowExecutionAlreadyExists: &nosqlplugin.WorkflowExecutionAlreadyExists{
+				OtherInfo:        msg,
+				CreateRequestID:  execution.CreateRequestID,
+				RunID:            execution.RunID,
+				State:            execution.State,
+				CloseStatus:      execution.CloseStatus,
+				LastWriteVersion: existing.LastWriteVersion,
+			},
+		}
+	}
+	if !db.IsNotFoundError(err) {
+		return err
+	}
+
+	data, err := json.Marshal(newExecutionData(execution))
+	if err != nil {
+		return err
+	}
+	doc := &cadence.ExecutionCollectionEntry{
+		ShardID:          shardID,
+		DomainID:         execution.DomainID,
+		WorkflowID:       execution.WorkflowID,
+		RunID:            execution.RunID,
+		NextEventID:      execution.NextEventID,
+		LastWriteVersion: execution.LastWriteVersion,
+		State:            execution.State,
+		Data:             data,
+	}
+	if err := setExecutionMaps(doc, execution); err != nil {
+		return err
+	}
+	_, err = collection.InsertOne(sessCtx, doc)
+	return err
+}
+
+// updateExecution updates an existing workflow execution, and merges(update mode) or overrides(reset mode) the maps.
+// It returns the actual nextEventID if the condition of PreviousNextEventIDCondition fails.
+func (db *mdb) updateExecution(
+	sessCtx mongo.SessionContext,
+	shardID int,
+	execution *nosqlplugin.WorkflowExecutionRequest,
+	reset bool,
+) error {
+	if reset {
+		if execution.EventBufferWriteMode != nosqlplugin.EventBufferWriteModeClear {
+			return fmt.Errorf("should only support EventBufferWriteModeClear")
+		}
+		if execution.MapsWriteMode != nosqlplugin.WorkflowExecutionMapsWriteModeReset {
+			return fmt.Errorf("should only support WorkflowExecutionMapsWriteModeReset")
+		}
+	} else if execution.MapsWriteMode != nosqlplugin.WorkflowExecutionMapsWriteModeUpdate {
+		return fmt.Errorf("should only support WorkflowExecutionMapsWriteModeUpdate")
+	}
+	if execution.PreviousNextEventIDCondition == nil {
+		return fmt.Errorf("PreviousNextEventIDCondition is required for updating workflow execution")
+	}
+
+	data, err := json.Marshal(newExecutionData(execution))
+	if err != nil {
+		return err
+	}
+	set := bson.D{
+		{"data", data},
+		{"nexteventid", execution.NextEventID},
+		{"lastwriteversion", execution.LastWriteVersion},
+		{"state", execution.State},
+	}
+	var unset bson.D
+	if reset {
+		maps := &cadence.ExecutionCollectionEntry{}
+		if err := setExecutionMaps(maps, execution); err != nil {
+			return err
+		}
+		// workflow timer tasks are always appended, same as other modes
+		set = append(set,
+			bson.E{Key: fieldActivityMap, Value: maps.ActivityMap},
+			bson.E{Key: fieldTimerMap, Value: maps.TimerMap},
+			bson.E{Key: fieldChildExecutionsMap, Value: maps.ChildExecutionsMap},
+			bson.E{Key: fieldRequestCancelMap, Value: maps.RequestCancelMap},
+			bson.E{Key: fieldSignalMap, Value: maps.SignalMap},
+			bson.E{Key: fieldSignalRequested, Value: maps.SignalRequested},
+		)
+	} else if set, unset, err = mergeExecutionMaps(set, execution); err != nil {
+		return err
+	}
+
+	for _, key := range execution.WorkflowTimerTasks {
+		value, err := json.Marshal(workflowTimerTask{VisibilityTimestamp: key.GetScheduledTime(), TaskID: key.GetTaskID()})
+		if err != nil {
+			return err
+		}
+		set = append(set, bson.E{Key: fieldWorkflowTimerTasks + "." + workflowTimerTaskKey(key), Value: value})
+	}
+
+	update := bson.D{}
+	switch execution.EventBufferWriteMode {
+	case nosqlplugin.EventBufferWriteModeClear:
+		set = append(set, bson.E{Key: fieldBufferedEvents, Value: bson.A{}})
+	case nosqlplugin.EventBufferWriteModeAppend:
+		batch, err := json.Marshal(execution.NewBufferedEventBatch)
+		if err != nil {
+			return err
+		}
+		update = append(update, bson.E{Key: "$push", Value: bson.D{{fieldBufferedEvents, batch}}})
+	}
+	update = append(update, bson.E{Key: "$set", Value: set})
+	if len(unset) > 0 {
+		update = append(update, bson.E{Key: "$unset", Value: unset})
+	}
+
+	filter := executionFilter(shardID, execution.DomainID, execution.WorkflowID, execution.RunID)
+	collection := db.dbConn.Collection(cadence.ExecutionsCollectionName)
+	result, err := collection.UpdateOne(
+		sessCtx,
+		append(filter, bson.E{Key: "nexteventid", Value: *execution.PreviousNextEventIDCondition}),
+		update,
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+	var actual cadence.ExecutionCollectionEntry
+	if err := collection.FindOne(sessCtx, filter).Decode(&actual); err != nil && !db.IsNotFoundError(err) {
+		return err
+	}
+	msg := fmt.Sprintf("Failed to update mutable state. previousNextEventIDCondition: %v, actualNextEventID: %v, Request Current RunID: %v",
+		*execution.PreviousNextEventIDCondition, actual.NextEventID, execution.RunID)
+	return &nosqlplugin.WorkflowOperationConditionFailure{
+		UnknownConditionFailureDetails: &msg,
+	}
+}
+
+func (db *mdb) upsertActiveClusterSelectionPolicy(sessCtx mongo.SessionContext, row *nosqlplugin.ActiveClusterSelectionPolicyRow) error {
+	if row == nil || row.Policy == nil {
+		return nil
+	}
+	data, err := json.Marshal(row.Policy)
+	if err != nil {
+		return err
+	}
+	return upsert(
+		sessCtx,
+		db.dbConn.Collection(cadence.ActiveClusterSelectionPoliciesCollectionName),
+		executionFilter(row.ShardID, row.DomainID, row.WorkflowID, row.RunID),
+		&cadence.ActiveClusterSelectionPolicyCollectionEntry{
+			ShardID:    row.ShardID,
+			DomainID:   row.DomainID,
+			WorkflowID: row.WorkflowID,
+			RunID:      row.RunID,
+			Data:       data,
+		},
+	)
+}
+
+func (db *mdb) insertHistoryTasks(
+	sessCtx mongo.SessionContext,
+	shardID int,
+	tasksByCategory map[persistence.HistoryTaskCategory][]*nosqlplugin.HistoryMigrationTask,
+) error {
+	collection := db.dbConn.Collection(cadence.HistoryTasksCollectionName)
+	for category, tasks := range tasksByCategory {
+		for _, task := range tasks {
+			doc, err := newHistoryTaskEntry(shardID, category, task)
+			if err != nil {
+				return err
+			}
+			if err := db.upsertHistoryTask(sessCtx, collection, doc); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (db *mdb) upsertHistoryTask(ctx context.Context, collection *mongo.Collection, doc *cadence.HistoryTaskCollectionEntry) error {
+	filter := append(
+		historyTaskFilter(doc.ShardID, doc.Category, doc.Cluster),
+		bson.E{Key: "visibilitytimestamp", Value: doc.VisibilityTimestamp},
+		bson.E{Key: "taskid", Value: doc.TaskID},
+	)
+	return upsert(ctx, collection, filter, doc)
+}
+
+// newHistoryTaskEntry returns an error for the categories that are not supported,
+// so that tasks are never dropped silently
+func newHistoryTaskEntry(
+	shardID int,
+	category persistence.HistoryTaskCategory,
+	task *nosqlplugin.HistoryMigrationTask,
+) (*cadence.HistoryTaskCollectionEntry, error) {
+	doc := &cadence.HistoryTaskCollectionEntry{ShardID: shardID}
+	switch category.ID() {
+	case persistence.HistoryTaskCategoryIDTransfer:
+		doc.Category = taskCategoryTransfer
+		doc.TaskID = task.Transfer.TaskID
+	case persistence.HistoryTaskCategoryIDTimer:
+		doc.Category = taskCategoryTimer
+		doc.VisibilityTimestamp = task.Timer.VisibilityTimestamp.UnixNano()
+		doc.TaskID = task.Timer.TaskID
+	case persistence.HistoryTaskCategoryIDReplication:
+		doc.Category = taskCategoryReplication
+		doc.TaskID = task.Replication.TaskID
+	default:
+		return nil, fmt.Errorf("history task category %v is not supported by MongoDB", category.Name())
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	doc.Data = data
+	return doc, nil
+}
+
+func newExecutionData(execution *nosqlplugin.WorkflowExecutionRequest) *executionData {
+	data := &executionData{
+		ExecutionInfo:    &execution.InternalWorkflowExecutionInfo,
+		VersionHistories: execution.VersionHistories,
+	}
+	if execution.Checksums != nil {
+		data.Checksum = *execution.Checksums
+	}
+	return data
+}
+
+// setExecutionMaps sets the full maps of an execution. The maps are never nil, because $set of a nil map
+// would store null which can't be updated by the keys later.
+func setExecutionMaps(doc *cadence.ExecutionCollectionEntry, execution *nosqlplugin.WorkflowExecutionRequest) error {
+	doc.ActivityMap = make(map[string][]byte, len(execution.ActivityInfos))
+	for k, v := range execution.ActivityInfos {
+		if err := setJSONEntry(doc.ActivityMap, int64MapKey(k), v); err != nil {
+			return err
+		}
+	}
+	doc.TimerMap = make(map[string][]byte, len(execution.TimerInfos))
+	for k, v := range execution.TimerInfos {
+		if err := setJSONEntry(doc.TimerMap, encodeMapKey(k), v); err != nil {
+			return err
+		}
+	}
+	doc.ChildExecutionsMap = make(map[string][]byte, len(execution.ChildWorkflowInfos))
+	for k, v := range execution.ChildWorkflowInfos {
+		if err := setJSONEntry(doc.ChildExecutionsMap, int64MapKey(k), v); err != nil {
+			return err
+		}
+	}
+	doc.RequestCancelMap = make(map[string][]byte, len(execution.RequestCancelInfos))
+	for k, v := range execution.RequestCancelInfos {
+		if err := setJSONEntry(doc.RequestCancelMap, int64MapKey(k), v); err != nil {
+			return err
+		}
+	}
+	doc.SignalMap = make(map[string][]byte, len(execution.SignalInfos))
+	for k, v := range execution.SignalInfos {
+		if err := setJSONEntry(doc.SignalMap, int64MapKey(k), v); err != nil {
+			return err
+		}
+	}
+	doc.SignalRequested = make(map[string]string, len(execution.SignalRequestedIDs))
+	for _, id := range execution.SignalRequestedIDs {
+		doc.SignalRequested[encodeMapKey(id)] = id
+	}
+	doc.WorkflowTimerTasks = make(map[string][]byte, len(execution.WorkflowTimerTasks))
+	for _, key := range execution.WorkflowTimerTasks {
+		if err := setJSONEntry(doc.WorkflowTimerTasks, workflowTimerTaskKey(key), workflowTimerTask{
+			VisibilityTimestamp: key.GetScheduledTime(),
+			TaskID:              key.GetTaskID(),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeExecutionMaps upserts and deletes the entries of the maps by keys, so that the maps don't need to be read first
+func mergeExecutionMaps(set bson.D, execution *nosqlplugin.WorkflowExecutionRequest) (bson.D, bson.D, error) {
+	upserted := make(map[string]struct{})
+	upsertEntry := func(field, key string, v interface{}) error {
+		value, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		path := field + "." + key
+		upserted[path] = struct{}{}
+		set = append(set, bson.E{Key: path, Value: value})
+		return nil
+	}
+	var unset bson.D
+	removeEntry := func(field, key string) {
+		// MongoDB rejects conflicting paths in one update, the upsert wins
+		path := field + "." + key
+		if _, ok := upserted[path]; !ok {
+			upserted[path] = struct{}{}
+			unset = append(unset, bson.E{Key: path, Value: ""})
+		}
+	}
+
+	for k, v := range execution.ActivityInfos {
+		if err := upsertEntry(fieldActivityMap, int64MapKey(k), v); err != nil {
+			return nil, nil, err
+		}
+	}
+	for k, v := range execution.TimerInfos {
+		if err := upsertEntry(fieldTimerMap, encodeMapKey(k), v); err != nil {
+			return nil, nil, err
+		}
+	}
+	for k, v := range execution.ChildWorkflowInfos {
+		if err := upsertEntry(fieldChildExecutionsMap, int64MapKey(k), v); err != nil {
+			return nil, nil, err
+		}
+	}
+	for k, v := range execution.RequestCancelInfos {
+		if err := upsertEntry(fieldRequestCancelMap, int64MapKey(k), v); err != nil {
+			return nil, nil, err
+		}
+	}
+	for k, v := range execution.SignalInfos {
+		if err := upsertEntry(fieldSignalMap, int64MapKey(k), v); err != nil {
+			return nil, nil, err
+		}
+	}
+	for _, id := range execution.SignalRequestedIDs {
+		path := fieldSignalRequested + "." + encodeMapKey(id)
+		upserted[path] = struct{}{}
+		set = append(set, bson.E{Key: path, Value: id})
+	}
+
+	for _, k := range execution.ActivityInfoKeysToDelete {
+		removeEntry(fieldActivityMap, int64MapKey(k))
+	}
+	for _, k := range execution.TimerInfoKeysToDelete {
+		removeEntry(fieldTimerMap, encodeMapKey(k))
+	}
+	for _, k := range execution.ChildWorkflowInfoKeysToDelete {
+		removeEntry(fieldChildExecutionsMap, int64MapKey(k))
+	}
+	for _, k := range execution.RequestCancelInfoKeysToDelete {
+		removeEntry(fieldRequestCancelMap, int64MapKey(k))
+	}
+	for _, k := range execution.SignalInfoKeysToDelete {
+		removeEntry(fieldSignalMap, int64MapKey(k))
+	}
+	for _, id := range execution.SignalRequestedIDsKeysToDelete {
+		removeEntry(fieldSignalRequested, encodeMapKey(id))
+	}
+	return set, unset, nil
+}
+
+func setJSONEntry(m map[string][]byte, key string, v interface{}) error {
+	value, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	m[key] = value
+	return nil
+}
+
+func parseWorkflowExecution(doc *cadence.ExecutionCollectionEntry) (*nosqlplugin.WorkflowExecution, error) {
+	data := &executionData{}
+	if err := json.Unmarshal(doc.Data, data); err != nil {
+		return nil, err
+	}
+	state := &nosqlplugin.WorkflowExecution{
+		ExecutionInfo:       data.ExecutionInfo,
+		VersionHistories:    data.VersionHistories,
+		Checksum:            data.Checksum,
+		ActivityInfos:       make(map[int64]*persistence.InternalActivityInfo),
+		TimerInfos:          make(map[string]*persistence.TimerInfo),
+		ChildExecutionInfos: make(map[int64]*persistence.InternalChildExecutionInfo),
+		RequestCancelInfos:  make(map[int64]*persistence.RequestCancelInfo),
+		SignalInfos:         make(map[int64]*persistence.SignalInfo),
+		SignalRequestedIDs:  make(map[string]struct{}),
+	}
+	for k, v := range doc.ActivityMap {
+		info := &persistence.InternalActivityInfo{}
+		if err := parseInt64Entry(k, v, info, func(id int64) { state.ActivityInfos[id] = info }); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range doc.TimerMap {
+		timerID, err := decodeMapKey(k)
+		if err != nil {
+			return nil, err
+		}
+		info := &persistence.TimerInfo{}
+		if err := json.Unmarshal(v, info); err != nil {
+			return nil, err
+		}
+		state.TimerInfos[timerID] = info
+	}
+	for k, v := range doc.ChildExecutionsMap {
+		info := &persistence.InternalChildExecutionInfo{}
+		if err := parseInt64Entry(k, v, info, func(id int64) { state.ChildExecutionInfos[id] = info }); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range doc.RequestCancelMap {
+		info := &persistence.RequestCancelInfo{}
+		if err := parseInt64Entry(k, v, info, func(id int64) { state.RequestCancelInfos[id] = info }); err != nil {
+			return nil, err
+		}
+	}
+	for k, v := range doc.SignalMap {
+		info := &persistence.SignalInfo{}
+		if err := parseInt64Entry(k, v, info, func(id int64) { state.SignalInfos[id] = info }); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range doc.SignalRequested {
+		state.SignalRequestedIDs[id] = struct{}{}
+	}
+	state.BufferedEvents = make([]*persistence.DataBlob, 0, len(doc.BufferedEvents))
+	for _, e := range doc.BufferedEvents {
+		blob := &persistence.DataBlob{}
+		if err := json.Unmarshal(e, blob); err != nil {
+			return nil, err
+		}
+		state.BufferedEvents = append(state.BufferedEvents, blob)
+	}
+	return state, nil
+}
+
+func parseWorkflowTimerTasks(doc *cadence.ExecutionCollectionEntry) ([]persistence.HistoryTaskKey, error) {
+	if len(doc.WorkflowTimerTasks) == 0 {
+		return nil, nil
+	}
+	keys := make([]persistence.HistoryTaskKey, 0, len(doc.WorkflowTimerTasks))
+	for _, v := range doc.WorkflowTimerTasks {
+		t := workflowTimerTask{}
+		if err := json.Unmarshal(v, &t); err != nil {
+			return nil, err
+		}
+		keys = append(keys, persistence.NewHistoryTaskKey(t.VisibilityTimestamp, t.TaskID))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Less(keys[j])
+	})
+	return keys, nil
+}
+
+func parseInt64Entry(key string, v []byte, out interface{}, set func(int64)) error {
+	id, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(v, out); err != nil {
+		return err
+	}
+	set(id)
+	return nil
+}
+
+func parseHistoryTasks(docs []*cadence.HistoryTaskCollectionEntry) ([]*nosqlplugin.HistoryMigrationTask, error) {
+	tasks := make([]*nosqlplugin.HistoryMigrationTask, 0, len(docs))
+	for _, doc := range docs {
+		task := &nosqlplugin.HistoryMigrationTask{}
+		if err := json.Unmarshal(doc.Data, task); err != nil {
+			return nil, err
+		}
+		switch {
+		case task.Transfer != nil:
+			task.TaskID = task.Transfer.TaskID
+		case task.Timer != nil:
+			task.TaskID = task.Timer.TaskID
+			task.ScheduledTime = task.Timer.VisibilityTimestamp
+		case task.Replication != nil:
+			task.TaskID = task.Replication.TaskID
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func parseCurrentWorkflowEntry(doc *cadence.CurrentWorkflowCollectionEntry) *nosqlplugin.CurrentWorkflowRow {
+	return &nosqlplugin.CurrentWorkflowRow{
+		ShardID:          doc.ShardID,
+		DomainID:         doc.DomainID,
+		WorkflowID:       doc.WorkflowID,
+		RunID:            doc.RunID,
+		State:            doc.State,
+		CloseStatus:      doc.CloseStatus,
+		CreateRequestID:  doc.CreateRequestID,
+		LastWriteVersion: doc.LastWriteVersion,
+	}
+}
+
+// createCurrentWorkflowConditionFailure translates the failed condition of the current workflow when creating
+// a workflow execution, with the same messages as the Cassandra implementation
+func createCurrentWorkflowConditionFailure(request *nosqlplugin.CurrentWorkflowWriteRequest) func(*nosqlplugin.CurrentWorkflowRow) error {
+	return func(current *nosqlplugin.CurrentWorkflowRow) error {
+		workflowID := request.Row.WorkflowID
+		if current == nil {
+			msg := fmt.Sprintf("Workflow execution creation condition failed, current workflow doesn't exist. WorkflowId: %v", workflowID)
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				CurrentWorkflowConditionFailInfo: &msg,
+			}
+		}
+		if request.WriteMode == nosqlplugin.CurrentWorkflowWriteModeInsert {
+			msg := fmt.Sprintf("Workflow execution already running. WorkflowId: %v, RunId: %v", workflowID, current.RunID)
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				WorkflowExecutionAlreadyExists: &nosqlplugin.WorkflowExecutionAlreadyExists{
+					OtherInfo:        msg,
+					CreateRequestID:  current.CreateRequestID,
+					RunID:            current.RunID,
+					State:            current.State,
+					CloseStatus:      current.CloseStatus,
+					LastWriteVersion: current.LastWriteVersion,
+				},
+			}
+		}
+		condition := request.Condition
+		var msg string
+		switch {
+		case current.RunID != condition.GetCurrentRunID():
+			msg = fmt.Sprintf("Workflow execution creation condition failed by mismatch runID. WorkflowId: %v, Expected Current RunID: %v, Actual Current RunID: %v",
+				workflowID, condition.GetCurrentRunID(), current.RunID)
+		case *condition.LastWriteVersion != current.LastWriteVersion:
+			msg = fmt.Sprintf("Workflow execution creation condition failed. WorkflowId: %v, Expected Version: %v, Actual Version: %v",
+				workflowID, *condition.LastWriteVersion, current.LastWriteVersion)
+		default:
+			msg = fmt.Sprintf("Workflow execution creation condition failed. WorkflowId: %v, Expected State: %v, Actual State: %v",
+				workflowID, *condition.State, current.State)
+		}
+		return &nosqlplugin.WorkflowOperationConditionFailure{
+			CurrentWorkflowConditionFailInfo: &msg,
+		}
+	}
+}
+
+// updateCurrentWorkflowConditionFailure translates the failed condition of the current workflow when updating
+// a workflow execution, with the same messages as the Cassandra implementation
+func updateCurrentWorkflowConditionFailure(request *nosqlplugin.CurrentWorkflowWriteRequest) func(*nosqlplugin.CurrentWorkflowRow) error {
+	return func(current *nosqlplugin.CurrentWorkflowRow) error {
+		var actualCurrRunID string
+		if current != nil {
+			actualCurrRunID = current.RunID
+		}
+		requestConditionalRunID := request.Condition.GetCurrentRunID()
+		if actualCurrRunID != requestConditionalRunID {
+			msg := fmt.Sprintf("Failed to update mutable state. requestConditionalRunID: %v, Actual Value: %v",
+				requestConditionalRunID, actualCurrRunID)
+			return &nosqlplugin.WorkflowOperationConditionFailure{
+				CurrentWorkflowConditionFailInfo: &msg,
+			}
+		}
+		msg := fmt.Sprintf("Failed to update current workflow. WorkflowId: %v, Expected Version: %v, Actual Version: %v, Expected State: %v, Actual State: %v",
+			request.Row.WorkflowID, *request.Condition.LastWriteVersion, current.LastWriteVersion, *request.Condition.State, current.State)
+		return &nosqlplugin.WorkflowOperationConditionFailure{
+			UnknownConditionFailureDetails: &msg,
+		}
+	}
+}
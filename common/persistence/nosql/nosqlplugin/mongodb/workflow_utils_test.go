@@ -0,0 +1,111 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mongodb
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin"
+	"github.com/uber/cadence/schema/mongodb/cadence"
+)
+
+func TestMapKeyEncoding(t *testing.T) {
+	for _, key := range []string{"timer", "a.b", "$c", "", "中文"} {
+		encoded := encodeMapKey(key)
+		assert.NotContains(t, encoded, ".")
+		assert.NotContains(t, encoded, "$")
+		decoded, err := decodeMapKey(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, key, decoded)
+	}
+	_, err := decodeMapKey("!")
+	assert.Error(t, err)
+}
+
+func TestMergeExecutionMaps(t *testing.T) {
+	set, unset, err := mergeExecutionMaps(bson.D{{"state", 1}}, &nosqlplugin.WorkflowExecutionRequest{
+		TimerInfos: map[string]*persistence.TimerInfo{
+			"t.1": {TimerID: "t.1"},
+		},
+		SignalRequestedIDs:       []string{"s1"},
+		TimerInfoKeysToDelete:    []string{"t.1", "t2"},
+		ActivityInfoKeysToDelete: []int64{5},
+	})
+	require.NoError(t, err)
+
+	var setKeys []string
+	for _, e := range set {
+		setKeys = append(setKeys, e.Key)
+	}
+	assert.ElementsMatch(t, []string{
+		"state",
+		fieldTimerMap + "." + encodeMapKey("t.1"),
+		fieldSignalRequested + "." + encodeMapKey("s1"),
+	}, setKeys)
+	// t.1 is upserted in the same update so only the other keys are removed
+	assert.Equal(t, bson.D{
+		{fieldActivityMap + ".5", ""},
+		{fieldTimerMap + "." + encodeMapKey("t2"), ""},
+	}, unset)
+}
+
+func TestParseWorkflowExecution(t *testing.T) {
+	request := &nosqlplugin.WorkflowExecutionRequest{
+		InternalWorkflowExecutionInfo: persistence.InternalWorkflowExecutionInfo{WorkflowID: "wid"},
+		ActivityInfos: map[int64]*persistence.InternalActivityInfo{
+			3: {ScheduleID: 3},
+		},
+		TimerInfos: map[string]*persistence.TimerInfo{
+			"t.1": {TimerID: "t.1"},
+		},
+		SignalRequestedIDs: []string{"s.1"},
+	}
+	doc, err := newExecutionEntryForTest(request)
+	require.NoError(t, err)
+
+	execution, err := parseWorkflowExecution(doc)
+	require.NoError(t, err)
+	assert.Equal(t, "wid", execution.ExecutionInfo.WorkflowID)
+	assert.Equal(t, int64(3), execution.ActivityInfos[3].ScheduleID)
+	assert.Equal(t, "t.1", execution.TimerInfos["t.1"].TimerID)
+	assert.Equal(t, map[string]struct{}{"s.1": {}}, execution.SignalRequestedIDs)
+	assert.Empty(t, execution.BufferedEvents)
+}
+
+func newExecutionEntryForTest(request *nosqlplugin.WorkflowExecutionRequest) (*cadence.ExecutionCollectionEntry, error) {
+	data, err := json.Marshal(newExecutionData(request))
+	if err != nil {
+		return nil, err
+	}
+	doc := &cadence.ExecutionCollectionEntry{Data: data}
+	return doc, setExecutionMaps(doc, request)
+}
+
+func TestNewHistoryTaskEntryUnsupportedCategory(t *testing.T) {
+	_, err := newHistoryTaskEntry(1, persistence.HistoryTaskCategory{}, &nosqlplugin.HistoryMigrationTask{})
+	assert.ErrorContains(t, err, "is not supported by MongoDB")
+}
@@ -23,7 +23,10 @@ package nosqlplugin
 import (
 	"time"
 
+	"github.com/dgryski/go-farm"
+
 	"github.com/uber/cadence/common/checksum"
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/types"
 )
@@ -190,6 +193,7 @@ type (
 		Expiry          time.Time
 		CreatedTime     time.Time
 		PartitionConfig map[string]string
+		Priority        int
 	}
 
 	// TaskListFilter is for filtering tasklist
@@ -440,6 +444,32 @@ const (
 	SortByClosedTime
 )
 
+const (
+	// DefaultNumOpenVisibilityBuckets is the number of buckets that the open records of a domain are spread over,
+	// unless configured with VisibilityOpenBuckets
+	DefaultNumOpenVisibilityBuckets = 8
+	// ClosedVisibilityBucketSize is the time range of the closed records in a bucket
+	ClosedVisibilityBucketSize = 24 * time.Hour
+)
+
+// GetNumOpenVisibilityBuckets returns the number of buckets that the open records of a domain are spread over
+func GetNumOpenVisibilityBuckets(cfg *config.NoSQL) int {
+	if cfg == nil || cfg.VisibilityOpenBuckets <= 0 {
+		return DefaultNumOpenVisibilityBuckets
+	}
+	return cfg.VisibilityOpenBuckets
+}
+
+// OpenVisibilityBucket returns the bucket of the denormalized visibility records that an open record belongs to
+func OpenVisibilityBucket(runID string, numBuckets int) int64 {
+	return int64(farm.Fingerprint32([]byte(runID)) % uint32(numBuckets))
+}
+
+// ClosedVisibilityBucket returns the bucket of the denormalized visibility records that a closed record belongs to
+func ClosedVisibilityBucket(closeTime time.Time) int64 {
+	return closeTime.Unix() / int64(ClosedVisibilityBucketSize/time.Second)
+}
+
 // enums of CurrentWorkflowWriteMode
 const (
 	CurrentWorkflowWriteModeNoop CurrentWorkflowWriteMode = iota
@@ -40,6 +40,7 @@ type (
 var allBlobEncodings = []constants.EncodingType{
 	constants.EncodingTypeThriftRW,
 	constants.EncodingTypeThriftRWSnappy,
+	constants.EncodingTypeThriftRWZstd,
 }
 
 // NewParser constructs a new parser using encoder as specified by encodingType and using decoders specified by decodingTypes
@@ -465,6 +466,8 @@ func getDecoder(encoding constants.EncodingType) (decoder, error) {
 		return newThriftDecoder(), nil
 	case constants.EncodingTypeThriftRWSnappy:
 		return newSnappyThriftDecoder(), nil
+	case constants.EncodingTypeThriftRWZstd:
+		return newZstdThriftDecoder(), nil
 	default:
 		return nil, unsupportedEncodingError(encoding)
 	}
@@ -476,6 +479,8 @@ func getEncoder(encoding constants.EncodingType) (encoder, error) {
 		return newThriftEncoder(), nil
 	case constants.EncodingTypeThriftRWSnappy:
 		return newSnappyThriftEncoder(), nil
+	case constants.EncodingTypeThriftRWZstd:
+		return newZstdThriftEncoder(), nil
 	default:
 		return nil, unsupportedEncodingError(encoding)
 	}
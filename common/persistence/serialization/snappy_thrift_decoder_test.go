@@ -65,7 +65,7 @@ func TestSnappyThriftDecoderRoundTrip(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			blob := encodeWithParser(t, parser, tc.data)
-			decoded := decodeWithParser(t, parser, blob, tc.data)
+			decoded := decodeWithParser(t, parser, blob, constants.EncodingTypeThriftRWSnappy, tc.data)
 			assert.Equal(t, tc.data, decoded)
 		})
 	}
@@ -299,11 +299,11 @@ func encodeWithParser(t *testing.T, parser Parser, data interface{}) []byte {
 	return blob
 }
 
-func decodeWithParser(t *testing.T, parser Parser, blob []byte, data interface{}) interface{} {
+func decodeWithParser(t *testing.T, parser Parser, blob []byte, encodingType constants.EncodingType, data interface{}) interface{} {
 	var result interface{}
 	var err error
 
-	encoding := string(constants.EncodingTypeThriftRWSnappy)
+	encoding := string(encodingType)
 
 	switch data.(type) {
 	case *ShardInfo:
@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package serialization
+
+import (
+	"bytes"
+
+	"go.uber.org/thriftrw/protocol/binary"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/persistence/serialization/compression"
+)
+
+type zstdThriftEncoder struct{}
+
+func newZstdThriftEncoder() encoder {
+	return &zstdThriftEncoder{}
+}
+
+func (e *zstdThriftEncoder) shardInfoToBlob(info *ShardInfo) ([]byte, error) {
+	return zstdThriftRWEncode(shardInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) domainInfoToBlob(info *DomainInfo) ([]byte, error) {
+	return zstdThriftRWEncode(domainInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) historyTreeInfoToBlob(info *HistoryTreeInfo) ([]byte, error) {
+	return zstdThriftRWEncode(historyTreeInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) workflowExecutionInfoToBlob(info *WorkflowExecutionInfo) ([]byte, error) {
+	return zstdThriftRWEncode(workflowExecutionInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) activityInfoToBlob(info *ActivityInfo) ([]byte, error) {
+	return zstdThriftRWEncode(activityInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) childExecutionInfoToBlob(info *ChildExecutionInfo) ([]byte, error) {
+	return zstdThriftRWEncode(childExecutionInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) signalInfoToBlob(info *SignalInfo) ([]byte, error) {
+	return zstdThriftRWEncode(signalInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) requestCancelInfoToBlob(info *RequestCancelInfo) ([]byte, error) {
+	return zstdThriftRWEncode(requestCancelInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) timerInfoToBlob(info *TimerInfo) ([]byte, error) {
+	return zstdThriftRWEncode(timerInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) taskInfoToBlob(info *TaskInfo) ([]byte, error) {
+	return zstdThriftRWEncode(taskInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) taskListInfoToBlob(info *TaskListInfo) ([]byte, error) {
+	return zstdThriftRWEncode(taskListInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) transferTaskInfoToBlob(info *TransferTaskInfo) ([]byte, error) {
+	return zstdThriftRWEncode(transferTaskInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) crossClusterTaskInfoToBlob(info *CrossClusterTaskInfo) ([]byte, error) {
+	return zstdThriftRWEncode(crossClusterTaskInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) timerTaskInfoToBlob(info *TimerTaskInfo) ([]byte, error) {
+	return zstdThriftRWEncode(timerTaskInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) replicationTaskInfoToBlob(info *ReplicationTaskInfo) ([]byte, error) {
+	return zstdThriftRWEncode(replicationTaskInfoToThrift(info))
+}
+
+func (e *zstdThriftEncoder) encodingType() constants.EncodingType {
+	return constants.EncodingTypeThriftRWZstd
+}
+
+func zstdThriftRWEncode(t thriftRWType) ([]byte, error) {
+	var b bytes.Buffer
+	sw := binary.Default.Writer(&b)
+	defer sw.Close()
+	if err := t.Encode(sw); err != nil {
+		return nil, err
+	}
+
+	return compression.ZstdCompress(b.Bytes()), nil
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package serialization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/serialization/compression"
+)
+
+func TestZstdThriftRoundTrip(t *testing.T) {
+	parser, err := NewParser(&persistence.DynamicConfiguration{
+		SerializationEncoding: dynamicproperties.GetStringPropertyFn(string(constants.EncodingTypeThriftRWZstd)),
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		data interface{}
+	}{
+		{name: "ShardInfo", data: shardInfoTestData},
+		{name: "DomainInfo", data: domainInfoTestData},
+		{name: "HistoryTreeInfo", data: historyTreeInfoTestData},
+		{name: "WorkflowExecutionInfo", data: workflowExecutionInfoTestData},
+		{name: "ActivityInfo", data: activityInfoTestData},
+		{name: "ChildExecutionInfo", data: childExecutionInfoTestData},
+		{name: "SignalInfo", data: signalInfoTestData},
+		{name: "RequestCancelInfo", data: requestCancelInfoTestData},
+		{name: "TimerInfo", data: timerInfoTestData},
+		{name: "TaskInfo", data: taskInfoTestData},
+		{name: "TaskListInfo", data: taskListInfoTestData},
+		{name: "TransferTaskInfo", data: transferTaskInfoTestData},
+		{name: "TimerTaskInfo", data: timerTaskInfoTestData},
+		{name: "ReplicationTaskInfo", data: replicationTaskInfoTestData},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			blob := encodeWithParser(t, parser, tc.data)
+			decoded := decodeWithParser(t, parser, blob, constants.EncodingTypeThriftRWZstd, tc.data)
+			assert.Equal(t, tc.data, decoded)
+		})
+	}
+}
+
+func TestZstdThriftDecodableSideBySide(t *testing.T) {
+	for _, encoding := range allBlobEncodings {
+		t.Run(string(encoding), func(t *testing.T) {
+			writer, err := NewParser(&persistence.DynamicConfiguration{
+				SerializationEncoding: dynamicproperties.GetStringPropertyFn(string(encoding)),
+			})
+			require.NoError(t, err)
+			reader, err := NewParser(&persistence.DynamicConfiguration{
+				SerializationEncoding: dynamicproperties.GetStringPropertyFn(string(constants.EncodingTypeThriftRWZstd)),
+			})
+			require.NoError(t, err)
+
+			blob, err := writer.WorkflowExecutionInfoToBlob(workflowExecutionInfoTestData)
+			require.NoError(t, err)
+			assert.Equal(t, encoding, blob.Encoding)
+
+			decoded, err := reader.WorkflowExecutionInfoFromBlob(blob.Data, string(blob.Encoding))
+			require.NoError(t, err)
+			assert.Equal(t, workflowExecutionInfoTestData, decoded)
+		})
+	}
+}
+
+func TestZstdThriftDecoderErrors(t *testing.T) {
+	decoder := newZstdThriftDecoder()
+
+	testCases := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "invalid zstd data",
+			data: []byte("invalid zstd data"),
+		},
+		{
+			name: "valid zstd but invalid thrift",
+			data: compression.ZstdCompress([]byte("not thrift data")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := decoder.workflowExecutionInfoFromBlob(tc.data)
+			assert.Error(t, err)
+			assert.Nil(t, result)
+		})
+	}
+}
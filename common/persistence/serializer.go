@@ -33,6 +33,9 @@ import (
 	"github.com/uber/cadence/common/checksum"
 	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence/encryption"
+	"github.com/uber/cadence/common/persistence/serialization/compression"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/common/types/mapper/thrift"
 )
@@ -102,6 +105,12 @@ type (
 		// serialize/deserialize full replication task payload for DLQ storage
 		SerializeReplicationDLQTask(task *types.ReplicationTask, encodingType constants.EncodingType) (*DataBlob, error)
 		DeserializeReplicationDLQTask(data *DataBlob) (*types.ReplicationTask, error)
+
+		// encrypt/decrypt serialized blobs at rest, the Deserialize methods decrypt transparently
+		EncryptBlob(domainName string, data *DataBlob) (*DataBlob, error)
+		DecryptBlob(data *DataBlob) (*DataBlob, error)
+		// re-wrap a blob sealed with a retired key to the active key, returns false if the blob needs no rewrap
+		RewrapBlob(data *DataBlob) (*DataBlob, bool, error)
 	}
 
 	// CadenceSerializationError is an error type for cadence serialization
@@ -120,7 +129,9 @@ type (
 	}
 
 	serializerImpl struct {
-		thriftrwEncoder codec.BinaryEncoder
+		thriftrwEncoder  codec.BinaryEncoder
+		encryptor        encryption.Encryptor
+		enableEncryption dynamicproperties.BoolPropertyFnWithDomainFilter
 	}
 )
 
@@ -131,6 +142,19 @@ func NewPayloadSerializer() PayloadSerializer {
 	}
 }
 
+// NewPayloadSerializerWithEncryption returns a PayloadSerializer which seals blobs of the domains
+// encryption is enabled for, and opens encrypted blobs of any domain
+func NewPayloadSerializerWithEncryption(
+	encryptor encryption.Encryptor,
+	enableEncryption dynamicproperties.BoolPropertyFnWithDomainFilter,
+) PayloadSerializer {
+	return &serializerImpl{
+		thriftrwEncoder:  codec.NewThriftRWEncoder(),
+		encryptor:        encryptor,
+		enableEncryption: enableEncryption,
+	}
+}
+
 func (t *serializerImpl) SerializeBatchEvents(events []*types.HistoryEvent, encodingType constants.EncodingType) (*DataBlob, error) {
 	return t.serialize(events, encodingType)
 }
@@ -396,6 +420,51 @@ func (t *serializerImpl) DeserializeReplicationDLQTask(data *DataBlob) (*types.R
 	return &task, err
 }
 
+func (t *serializerImpl) EncryptBlob(domainName string, data *DataBlob) (*DataBlob, error) {
+	if data == nil || t.encryptor == nil || !t.enableEncryption(domainName) {
+		return data, nil
+	}
+	if data.Encoding == constants.EncodingTypeEncrypted {
+		return data, nil
+	}
+
+	sealed, err := t.encryptor.Encrypt(domainName, data.Encoding, data.Data)
+	if err != nil {
+		return nil, NewCadenceSerializationError(fmt.Sprintf("failed to encrypt blob: %v", err))
+	}
+	return NewDataBlob(sealed, constants.EncodingTypeEncrypted), nil
+}
+
+func (t *serializerImpl) DecryptBlob(data *DataBlob) (*DataBlob, error) {
+	if data == nil || data.Encoding != constants.EncodingTypeEncrypted {
+		return data, nil
+	}
+	if t.encryptor == nil {
+		return nil, NewCadenceDeserializationError("encrypted blob found but payload encryption is not configured")
+	}
+
+	payload, err := t.encryptor.Decrypt(data.Data)
+	if err != nil {
+		return nil, NewCadenceDeserializationError(fmt.Sprintf("failed to decrypt blob: %v", err))
+	}
+	return NewDataBlob(payload.Data, payload.Encoding), nil
+}
+
+func (t *serializerImpl) RewrapBlob(data *DataBlob) (*DataBlob, bool, error) {
+	if data == nil || data.Encoding != constants.EncodingTypeEncrypted || t.encryptor == nil {
+		return data, false, nil
+	}
+
+	sealed, rewrapped, err := t.encryptor.Rewrap(data.Data)
+	if err != nil {
+		return nil, false, NewCadenceSerializationError(fmt.Sprintf("failed to rewrap blob: %v", err))
+	}
+	if !rewrapped {
+		return data, false, nil
+	}
+	return NewDataBlob(sealed, constants.EncodingTypeEncrypted), true, nil
+}
+
 func (t *serializerImpl) serialize(input interface{}, encodingType constants.EncodingType) (*DataBlob, error) {
 	if input == nil {
 		return nil, nil
@@ -409,6 +478,8 @@ func (t *serializerImpl) serialize(input interface{}, encodingType constants.Enc
 		data, err = t.thriftrwEncode(input)
 	case constants.EncodingTypeThriftRWSnappy:
 		data, err = t.thriftrwsnappyEncode(input)
+	case constants.EncodingTypeThriftRWZstd:
+		data, err = t.thriftrwzstdEncode(input)
 	case constants.EncodingTypeJSON, constants.EncodingTypeUnknown, constants.EncodingTypeEmpty: // For backward-compatibility
 		encodingType = constants.EncodingTypeJSON
 		data, err = json.Marshal(input)
@@ -472,8 +543,16 @@ func (t *serializerImpl) deserialize(data *DataBlob, target interface{}) error {
 		err = t.thriftrwDecode(data.Data, target)
 	case constants.EncodingTypeThriftRWSnappy:
 		err = t.thriftrwsnappyDecode(data.Data, target)
+	case constants.EncodingTypeThriftRWZstd:
+		err = t.thriftrwzstdDecode(data.Data, target)
 	case constants.EncodingTypeJSON, constants.EncodingTypeUnknown, constants.EncodingTypeEmpty: // For backward-compatibility
 		err = json.Unmarshal(data.Data, target)
+	case constants.EncodingTypeEncrypted:
+		decrypted, err := t.DecryptBlob(data)
+		if err != nil {
+			return err
+		}
+		return t.deserialize(decrypted, target)
 	default:
 		return NewUnknownEncodingTypeError(data.GetEncoding())
 	}
@@ -610,6 +689,36 @@ func (t *serializerImpl) thriftrwsnappyDecode(data []byte, target interface{}) e
 	return t.thriftrwDecode(decompressed, target)
 }
 
+func (t *serializerImpl) thriftrwzstdEncode(input interface{}) ([]byte, error) {
+	data, err := t.thriftrwEncode(input)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, nil
+	}
+
+	return compression.ZstdCompress(data), nil
+}
+
+func (t *serializerImpl) thriftrwzstdDecode(data []byte, target interface{}) error {
+	decompressed, err := compression.ZstdDecompress(data)
+	if err != nil {
+		return err
+	}
+
+	return t.thriftrwDecode(decompressed, target)
+}
+
+// decompressZstdBlob turns a zstd compressed thriftrw blob back into a plain thriftrw one
+func decompressZstdBlob(data *DataBlob) (*DataBlob, error) {
+	decompressed, err := compression.ZstdDecompress(data.Data)
+	if err != nil {
+		return nil, NewCadenceDeserializationError(fmt.Sprintf("failed to decompress zstd blob: %v", err))
+	}
+	return NewDataBlob(decompressed, constants.EncodingTypeThriftRW), nil
+}
+
 // NewUnknownEncodingTypeError returns a new instance of encoding type error
 func NewUnknownEncodingTypeError(encodingType constants.EncodingType) error {
 	return &UnknownEncodingTypeError{encodingType: encodingType}
@@ -0,0 +1,116 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+)
+
+var benchmarkEncodings = []constants.EncodingType{
+	constants.EncodingTypeThriftRW,
+	constants.EncodingTypeThriftRWSnappy,
+	constants.EncodingTypeThriftRWZstd,
+}
+
+// generateJSONHeavyHistoryBatch builds a batch resembling workflows passing large JSON documents around
+func generateJSONHeavyHistoryBatch(b *testing.B) []*types.HistoryEvent {
+	type lineItem struct {
+		SKU      string  `json:"sku"`
+		Name     string  `json:"name"`
+		Quantity int     `json:"quantity"`
+		Price    float64 `json:"price"`
+	}
+	items := make([]lineItem, 200)
+	for i := range items {
+		items[i] = lineItem{
+			SKU:      fmt.Sprintf("sku-%06d", i),
+			Name:     fmt.Sprintf("product name number %d", i),
+			Quantity: i % 7,
+			Price:    float64(i) * 1.25,
+		}
+	}
+	payload, err := json.Marshal(map[string]interface{}{"orderID": "order-1", "items": items})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var events []*types.HistoryEvent
+	for i := int64(0); i < 10; i++ {
+		events = append(events, &types.HistoryEvent{
+			ID:        i + 1,
+			Version:   1,
+			EventType: types.EventTypeActivityTaskCompleted.Ptr(),
+			ActivityTaskCompletedEventAttributes: &types.ActivityTaskCompletedEventAttributes{
+				Result:           payload,
+				ScheduledEventID: i,
+				StartedEventID:   i,
+				Identity:         "benchmark-worker",
+			},
+		})
+	}
+	return events
+}
+
+func BenchmarkSerializeBatchEvents(b *testing.B) {
+	serializer := NewPayloadSerializer()
+	events := generateJSONHeavyHistoryBatch(b)
+
+	for _, encoding := range benchmarkEncodings {
+		b.Run(string(encoding), func(b *testing.B) {
+			var blob *DataBlob
+			var err error
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				blob, err = serializer.SerializeBatchEvents(events, encoding)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(blob.Data)), "bytes/blob")
+		})
+	}
+}
+
+func BenchmarkDeserializeBatchEvents(b *testing.B) {
+	serializer := NewPayloadSerializer()
+	events := generateJSONHeavyHistoryBatch(b)
+
+	for _, encoding := range benchmarkEncodings {
+		blob, err := serializer.SerializeBatchEvents(events, encoding)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(string(encoding), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := serializer.DeserializeBatchEvents(blob); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(blob.Data)), "bytes/blob")
+		})
+	}
+}
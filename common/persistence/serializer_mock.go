@@ -43,6 +43,21 @@ func (m *MockPayloadSerializer) EXPECT() *MockPayloadSerializerMockRecorder {
 	return m.recorder
 }
 
+// DecryptBlob mocks base method.
+func (m *MockPayloadSerializer) DecryptBlob(data *DataBlob) (*DataBlob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecryptBlob", data)
+	ret0, _ := ret[0].(*DataBlob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecryptBlob indicates an expected call of DecryptBlob.
+func (mr *MockPayloadSerializerMockRecorder) DecryptBlob(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecryptBlob", reflect.TypeOf((*MockPayloadSerializer)(nil).DecryptBlob), data)
+}
+
 // DeserializeActiveClusterSelectionPolicy mocks base method.
 func (m *MockPayloadSerializer) DeserializeActiveClusterSelectionPolicy(data *DataBlob) (*types.ActiveClusterSelectionPolicy, error) {
 	m.ctrl.T.Helper()
@@ -268,6 +283,37 @@ func (mr *MockPayloadSerializerMockRecorder) DeserializeVisibilityMemo(data any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeserializeVisibilityMemo", reflect.TypeOf((*MockPayloadSerializer)(nil).DeserializeVisibilityMemo), data)
 }
 
+// EncryptBlob mocks base method.
+func (m *MockPayloadSerializer) EncryptBlob(domainName string, data *DataBlob) (*DataBlob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncryptBlob", domainName, data)
+	ret0, _ := ret[0].(*DataBlob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EncryptBlob indicates an expected call of EncryptBlob.
+func (mr *MockPayloadSerializerMockRecorder) EncryptBlob(domainName, data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptBlob", reflect.TypeOf((*MockPayloadSerializer)(nil).EncryptBlob), domainName, data)
+}
+
+// RewrapBlob mocks base method.
+func (m *MockPayloadSerializer) RewrapBlob(data *DataBlob) (*DataBlob, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RewrapBlob", data)
+	ret0, _ := ret[0].(*DataBlob)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RewrapBlob indicates an expected call of RewrapBlob.
+func (mr *MockPayloadSerializerMockRecorder) RewrapBlob(data any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RewrapBlob", reflect.TypeOf((*MockPayloadSerializer)(nil).RewrapBlob), data)
+}
+
 // SerializeActiveClusterSelectionPolicy mocks base method.
 func (m *MockPayloadSerializer) SerializeActiveClusterSelectionPolicy(policy *types.ActiveClusterSelectionPolicy, encodingType constants.EncodingType) (*DataBlob, error) {
 	m.ctrl.T.Helper()
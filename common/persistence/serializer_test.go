@@ -26,11 +26,13 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/checksum"
 	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/persistence/encryption"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -45,11 +47,12 @@ type testDef struct {
 
 // key is encoding type, value is whether the encoding type is supported
 var encodingTypes = map[constants.EncodingType]bool{
-	constants.EncodingTypeEmpty:    true,
-	constants.EncodingTypeUnknown:  true,
-	constants.EncodingTypeJSON:     true,
-	constants.EncodingTypeThriftRW: true,
-	constants.EncodingTypeGob:      false,
+	constants.EncodingTypeEmpty:        true,
+	constants.EncodingTypeUnknown:      true,
+	constants.EncodingTypeJSON:         true,
+	constants.EncodingTypeThriftRW:     true,
+	constants.EncodingTypeThriftRWZstd: true,
+	constants.EncodingTypeGob:          false,
 }
 
 type runnableTest struct {
@@ -319,6 +322,101 @@ func TestSerializers(t *testing.T) {
 	}
 }
 
+func newTestEncryptingSerializer(t *testing.T, activeKey string) PayloadSerializer {
+	keyProvider, err := encryption.NewKeyProvider(encryption.KeyFile{
+		ActiveKey: activeKey,
+		Keys: map[string]string{
+			"k1": "YWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWFhYWE=",
+			"k2": "YmJiYmJiYmJiYmJiYmJiYmJiYmJiYmJiYmJiYmJiYmI=",
+		},
+	})
+	require.NoError(t, err)
+	return NewPayloadSerializerWithEncryption(
+		encryption.NewEncryptor(keyProvider),
+		func(domain string) bool { return domain == "encrypted-domain" },
+	)
+}
+
+func TestSerializerEncryption(t *testing.T) {
+	serializer := newTestEncryptingSerializer(t, "k1")
+	events := generateTestHistoryEventBatch()
+
+	blob, err := serializer.SerializeBatchEvents(events, constants.EncodingTypeThriftRW)
+	require.NoError(t, err)
+
+	t.Run("disabled for domain", func(t *testing.T) {
+		unchanged, err := serializer.EncryptBlob("plain-domain", blob)
+		require.NoError(t, err)
+		assert.Equal(t, blob, unchanged)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		unchanged, err := NewPayloadSerializer().EncryptBlob("encrypted-domain", blob)
+		require.NoError(t, err)
+		assert.Equal(t, blob, unchanged)
+	})
+
+	encrypted, err := serializer.EncryptBlob("encrypted-domain", blob)
+	require.NoError(t, err)
+	assert.Equal(t, constants.EncodingTypeEncrypted, encrypted.GetEncoding())
+	assert.NotEqual(t, blob.Data, encrypted.Data)
+
+	t.Run("already encrypted", func(t *testing.T) {
+		unchanged, err := serializer.EncryptBlob("encrypted-domain", encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, encrypted, unchanged)
+	})
+
+	t.Run("decrypt", func(t *testing.T) {
+		decrypted, err := serializer.DecryptBlob(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, blob, decrypted)
+
+		unchanged, err := serializer.DecryptBlob(blob)
+		require.NoError(t, err)
+		assert.Equal(t, blob, unchanged)
+	})
+
+	t.Run("deserialize", func(t *testing.T) {
+		deserialized, err := serializer.DeserializeBatchEvents(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, events, deserialized)
+	})
+
+	t.Run("deserialize after key rotation", func(t *testing.T) {
+		deserialized, err := newTestEncryptingSerializer(t, "k2").DeserializeBatchEvents(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, events, deserialized)
+	})
+
+	t.Run("deserialize without keys", func(t *testing.T) {
+		_, err := NewPayloadSerializer().DeserializeBatchEvents(encrypted)
+		var deserializationErr *CadenceDeserializationError
+		assert.ErrorAs(t, err, &deserializationErr)
+	})
+
+	t.Run("rewrap", func(t *testing.T) {
+		unchanged, rewrapped, err := serializer.RewrapBlob(encrypted)
+		require.NoError(t, err)
+		assert.False(t, rewrapped, "blob is sealed with the active key")
+		assert.Equal(t, encrypted, unchanged)
+
+		unchanged, rewrapped, err = serializer.RewrapBlob(blob)
+		require.NoError(t, err)
+		assert.False(t, rewrapped, "blob is not encrypted")
+		assert.Equal(t, blob, unchanged)
+
+		rotated := newTestEncryptingSerializer(t, "k2")
+		resealed, rewrapped, err := rotated.RewrapBlob(encrypted)
+		require.NoError(t, err)
+		assert.True(t, rewrapped)
+		assert.Equal(t, constants.EncodingTypeEncrypted, resealed.GetEncoding())
+		deserialized, err := rotated.DeserializeBatchEvents(resealed)
+		require.NoError(t, err)
+		assert.Equal(t, events, deserialized)
+	})
+}
+
 func TestDataBlob_GetData(t *testing.T) {
 	tests := map[string]struct {
 		in          *DataBlob
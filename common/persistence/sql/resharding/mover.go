@@ -0,0 +1,237 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package resharding moves history shards between the database shards of a sharded SQL database
+// while the cluster keeps serving them.
+//
+// A move goes through the following phases:
+//  1. the placement of the history shard is set to copying and its rows are copied to the target database shard,
+//     while the history shard is still served from the source database shard
+//  2. the placement is set to frozen, so that the history shard can not be acquired, and the range ID of the
+//     history shard is incremented in the source database shard, which fences off the current owner
+//  3. the rows changed since the first copy are copied again and both copies are read and compared
+//  4. the placement is set to active, after which the history shard is served from the target database shard
+//  5. optionally, once every host reloaded the placements, the rows are deleted from the source database shard
+//
+// Copies only write the rows which differ between both database shards, so the final copy done while the history
+// shard is frozen is proportional to the changes made during the first copy rather than to the size of the shard.
+//
+// Hosts which read a history shard while it is being moved dual-read it: a record which is missing from the
+// database shard the host last saw the history shard served from is read again from the one it was cut over to.
+//
+// history_node and history_tree rows are sharded by tree ID rather than history shard ID, so they are not moved.
+package resharding
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+)
+
+// This section defines the phases a move reports progress for
+const (
+	PhaseCopy         Phase = "copy"
+	PhaseFreeze       Phase = "freeze"
+	PhaseFinalCopy    Phase = "final-copy"
+	PhaseVerify       Phase = "verify"
+	PhaseCutover      Phase = "cutover"
+	PhaseDeleteSource Phase = "delete-source"
+	PhaseDone         Phase = "done"
+)
+
+type (
+	// Phase is a phase of moving a history shard
+	Phase string
+
+	// Progress is reported as a move makes progress
+	Progress struct {
+		Phase Phase
+		// Table and Rows are only set for the copy phases, after each table is copied.
+		// Rows is the number of rows written to or deleted from the target database shard
+		Table string
+		Rows  int
+		// RangeID is only set for the freeze phase
+		RangeID int64
+	}
+
+	// Mover moves history shards between database shards
+	Mover struct {
+		db         sqlplugin.DB
+		timeSource clock.TimeSource
+		progress   func(Progress)
+	}
+
+	// MoveRequest is the request to move a history shard
+	MoveRequest struct {
+		HistoryShardID  int
+		TargetDBShardID int
+		// DeleteSource deletes the rows of the history shard from the source database shard after the cutover
+		DeleteSource bool
+	}
+)
+
+// NewMover returns a new Mover. progress may be nil
+func NewMover(db sqlplugin.DB, timeSource clock.TimeSource, progress func(Progress)) *Mover {
+	if progress == nil {
+		progress = func(Progress) {}
+	}
+	return &Mover{
+		db:         db,
+		timeSource: timeSource,
+		progress:   progress,
+	}
+}
+
+// Move moves a history shard to another database shard. A move which failed half way can be retried,
+// as long as it is retried with the same target database shard
+func (m *Mover) Move(ctx context.Context, request MoveRequest) error {
+	numDBShards := m.db.GetTotalNumDBShards()
+	if numDBShards <= 1 {
+		return fmt.Errorf("history shards can only be moved in a sharded SQL database")
+	}
+	if request.TargetDBShardID < 0 || request.TargetDBShardID >= numDBShards {
+		return fmt.Errorf("target database shard %v is out of range [0, %v)", request.TargetDBShardID, numDBShards)
+	}
+
+	placement, err := m.db.RefreshHistoryShardPlacement(ctx, request.HistoryShardID)
+	if err != nil {
+		return fmt.Errorf("failed to get placement of history shard %v: %w", request.HistoryShardID, err)
+	}
+	sourceDBShardID := sqlplugin.GetDBShardIDFromHistoryShardID(request.HistoryShardID, numDBShards)
+	if placement != nil {
+		if placement.State != sqlplugin.HistoryShardPlacementStateActive && placement.DBShardID != request.TargetDBShardID {
+			return fmt.Errorf("history shard %v is already being moved to database shard %v", request.HistoryShardID, placement.DBShardID)
+		}
+		sourceDBShardID = placement.GetServingDBShardID()
+	}
+	if sourceDBShardID == request.TargetDBShardID {
+		return fmt.Errorf("history shard %v is already in database shard %v", request.HistoryShardID, request.TargetDBShardID)
+	}
+
+	if err := m.setPlacement(ctx, request.HistoryShardID, request.TargetDBShardID, sourceDBShardID, sqlplugin.HistoryShardPlacementStateCopying); err != nil {
+		return err
+	}
+	if err := m.copy(ctx, PhaseCopy, request.HistoryShardID, sourceDBShardID, request.TargetDBShardID); err != nil {
+		return m.abort(ctx, request.HistoryShardID, sourceDBShardID, err)
+	}
+
+	if err := m.setPlacement(ctx, request.HistoryShardID, request.TargetDBShardID, sourceDBShardID, sqlplugin.HistoryShardPlacementStateFrozen); err != nil {
+		return m.abort(ctx, request.HistoryShardID, sourceDBShardID, err)
+	}
+	rangeID, err := m.db.FenceHistoryShard(ctx, request.HistoryShardID, sourceDBShardID)
+	if err != nil {
+		return m.abort(ctx, request.HistoryShardID, sourceDBShardID, fmt.Errorf("failed to fence history shard %v: %w", request.HistoryShardID, err))
+	}
+	m.progress(Progress{Phase: PhaseFreeze, RangeID: rangeID})
+
+	if err := m.copy(ctx, PhaseFinalCopy, request.HistoryShardID, sourceDBShardID, request.TargetDBShardID); err != nil {
+		return m.abort(ctx, request.HistoryShardID, sourceDBShardID, err)
+	}
+	if err := m.verify(ctx, request.HistoryShardID, sourceDBShardID, request.TargetDBShardID); err != nil {
+		return m.abort(ctx, request.HistoryShardID, sourceDBShardID, err)
+	}
+
+	if err := m.setPlacement(ctx, request.HistoryShardID, request.TargetDBShardID, sourceDBShardID, sqlplugin.HistoryShardPlacementStateActive); err != nil {
+		return m.abort(ctx, request.HistoryShardID, sourceDBShardID, err)
+	}
+	m.progress(Progress{Phase: PhaseCutover})
+
+	if request.DeleteSource {
+		// hosts keep reading the history shard from the source until they reload the placements,
+		// so the rows there are only deleted once every host had a chance to see the cutover
+		if err := m.timeSource.SleepWithContext(ctx, sqlplugin.HistoryShardPlacementsRefreshInterval); err != nil {
+			return fmt.Errorf("history shard %v was moved, but its rows were not deleted from database shard %v: %w", request.HistoryShardID, sourceDBShardID, err)
+		}
+		if err := m.db.DeleteHistoryShardRows(ctx, request.HistoryShardID, sourceDBShardID); err != nil {
+			return fmt.Errorf("history shard %v was moved, but failed to delete its rows from database shard %v: %w", request.HistoryShardID, sourceDBShardID, err)
+		}
+		m.progress(Progress{Phase: PhaseDeleteSource})
+	}
+	m.progress(Progress{Phase: PhaseDone})
+	return nil
+}
+
+func (m *Mover) copy(ctx context.Context, phase Phase, historyShardID, sourceDBShardID, targetDBShardID int) error {
+	err := m.db.CopyHistoryShardRows(ctx, historyShardID, sourceDBShardID, targetDBShardID, func(table string, rows int) {
+		m.progress(Progress{Phase: phase, Table: table, Rows: rows})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy history shard %v: %w", historyShardID, err)
+	}
+	return nil
+}
+
+func (m *Mover) verify(ctx context.Context, historyShardID, sourceDBShardID, targetDBShardID int) error {
+	source, err := m.db.ChecksumHistoryShardRows(ctx, historyShardID, sourceDBShardID)
+	if err != nil {
+		return fmt.Errorf("failed to read history shard %v from database shard %v: %w", historyShardID, sourceDBShardID, err)
+	}
+	target, err := m.db.ChecksumHistoryShardRows(ctx, historyShardID, targetDBShardID)
+	if err != nil {
+		return fmt.Errorf("failed to read history shard %v from database shard %v: %w", historyShardID, targetDBShardID, err)
+	}
+
+	tables := make([]string, 0, len(source))
+	for table := range source {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	for _, table := range tables {
+		if source[table] != target[table] {
+			return fmt.Errorf(
+				"history shard %v does not match in %v table: %v rows in database shard %v, %v rows in database shard %v",
+				historyShardID, table, source[table].Rows, sourceDBShardID, target[table].Rows, targetDBShardID,
+			)
+		}
+	}
+	m.progress(Progress{Phase: PhaseVerify})
+	return nil
+}
+
+// abort serves the history shard from the source database shard again
+func (m *Mover) abort(ctx context.Context, historyShardID, sourceDBShardID int, cause error) error {
+	var err error
+	if sourceDBShardID == sqlplugin.GetDBShardIDFromHistoryShardID(historyShardID, m.db.GetTotalNumDBShards()) {
+		_, err = m.db.DeleteFromHistoryShardPlacements(ctx, &sqlplugin.HistoryShardPlacementsFilter{ShardID: &historyShardID})
+	} else {
+		err = m.setPlacement(ctx, historyShardID, sourceDBShardID, sourceDBShardID, sqlplugin.HistoryShardPlacementStateActive)
+	}
+	if err != nil {
+		return fmt.Errorf("%w, and failed to restore placement of history shard %v: %v", cause, historyShardID, err)
+	}
+	return cause
+}
+
+func (m *Mover) setPlacement(ctx context.Context, historyShardID, dbShardID, sourceDBShardID int, state sqlplugin.HistoryShardPlacementState) error {
+	_, err := m.db.ReplaceIntoHistoryShardPlacements(ctx, &sqlplugin.HistoryShardPlacementRow{
+		ShardID:         historyShardID,
+		DBShardID:       dbShardID,
+		SourceDBShardID: sourceDBShardID,
+		State:           state,
+		LastUpdatedTime: m.timeSource.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update placement of history shard %v: %w", historyShardID, err)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resharding
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+)
+
+func TestMove(t *testing.T) {
+	historyShardID := 5
+	checksums := map[string]sqlplugin.HistoryShardTableChecksum{
+		"shards":     {Rows: 1, Checksum: 1234},
+		"executions": {Rows: 10, Checksum: 5678},
+	}
+	placementIn := func(state sqlplugin.HistoryShardPlacementState) any {
+		return gomock.Cond(func(row *sqlplugin.HistoryShardPlacementRow) bool {
+			return row.ShardID == historyShardID && row.DBShardID == 3 && row.SourceDBShardID == 1 && row.State == state
+		})
+	}
+
+	testCases := []struct {
+		name       string
+		request    MoveRequest
+		mockSetup  func(*sqlplugin.MockDB)
+		wantPhases []Phase
+		wantErr    string
+	}{
+		{
+			name:    "success",
+			request: MoveRequest{HistoryShardID: historyShardID, TargetDBShardID: 3, DeleteSource: true},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				gomock.InOrder(
+					mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), historyShardID).Return(nil, nil),
+					mockDB.EXPECT().ReplaceIntoHistoryShardPlacements(gomock.Any(), placementIn(sqlplugin.HistoryShardPlacementStateCopying)).Return(nil, nil),
+					mockDB.EXPECT().CopyHistoryShardRows(gomock.Any(), historyShardID, 1, 3, gomock.Any()).DoAndReturn(
+						func(_ context.Context, _, _, _ int, progress func(string, int)) error {
+							progress("executions", 10)
+							return nil
+						}),
+					mockDB.EXPECT().ReplaceIntoHistoryShardPlacements(gomock.Any(), placementIn(sqlplugin.HistoryShardPlacementStateFrozen)).Return(nil, nil),
+					mockDB.EXPECT().FenceHistoryShard(gomock.Any(), historyShardID, 1).Return(int64(8), nil),
+					mockDB.EXPECT().CopyHistoryShardRows(gomock.Any(), historyShardID, 1, 3, gomock.Any()).Return(nil),
+					mockDB.EXPECT().ChecksumHistoryShardRows(gomock.Any(), historyShardID, 1).Return(checksums, nil),
+					mockDB.EXPECT().ChecksumHistoryShardRows(gomock.Any(), historyShardID, 3).Return(checksums, nil),
+					mockDB.EXPECT().ReplaceIntoHistoryShardPlacements(gomock.Any(), placementIn(sqlplugin.HistoryShardPlacementStateActive)).Return(nil, nil),
+					mockDB.EXPECT().DeleteHistoryShardRows(gomock.Any(), historyShardID, 1).Return(nil),
+				)
+			},
+			wantPhases: []Phase{PhaseCopy, PhaseFreeze, PhaseVerify, PhaseCutover, PhaseDeleteSource, PhaseDone},
+		},
+		{
+			name:    "checksum mismatch restores the placement",
+			request: MoveRequest{HistoryShardID: historyShardID, TargetDBShardID: 3},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				gomock.InOrder(
+					mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), historyShardID).Return(nil, nil),
+					mockDB.EXPECT().ReplaceIntoHistoryShardPlacements(gomock.Any(), placementIn(sqlplugin.HistoryShardPlacementStateCopying)).Return(nil, nil),
+					mockDB.EXPECT().CopyHistoryShardRows(gomock.Any(), historyShardID, 1, 3, gomock.Any()).Return(nil),
+					mockDB.EXPECT().ReplaceIntoHistoryShardPlacements(gomock.Any(), placementIn(sqlplugin.HistoryShardPlacementStateFrozen)).Return(nil, nil),
+					mockDB.EXPECT().FenceHistoryShard(gomock.Any(), historyShardID, 1).Return(int64(8), nil),
+					mockDB.EXPECT().CopyHistoryShardRows(gomock.Any(), historyShardID, 1, 3, gomock.Any()).Return(nil),
+					mockDB.EXPECT().ChecksumHistoryShardRows(gomock.Any(), historyShardID, 1).Return(checksums, nil),
+					mockDB.EXPECT().ChecksumHistoryShardRows(gomock.Any(), historyShardID, 3).Return(map[string]sqlplugin.HistoryShardTableChecksum{
+						"shards":     {Rows: 1, Checksum: 1234},
+						"executions": {Rows: 9, Checksum: 5670},
+					}, nil),
+					mockDB.EXPECT().DeleteFromHistoryShardPlacements(gomock.Any(), &sqlplugin.HistoryShardPlacementsFilter{ShardID: &historyShardID}).Return(nil, nil),
+				)
+			},
+			wantPhases: []Phase{PhaseFreeze},
+			wantErr:    "history shard 5 does not match in executions table: 10 rows in database shard 1, 9 rows in database shard 3",
+		},
+		{
+			name:    "copy failure restores the placement",
+			request: MoveRequest{HistoryShardID: historyShardID, TargetDBShardID: 3},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				gomock.InOrder(
+					mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), historyShardID).Return(nil, nil),
+					mockDB.EXPECT().ReplaceIntoHistoryShardPlacements(gomock.Any(), placementIn(sqlplugin.HistoryShardPlacementStateCopying)).Return(nil, nil),
+					mockDB.EXPECT().CopyHistoryShardRows(gomock.Any(), historyShardID, 1, 3, gomock.Any()).Return(errors.New("some error")),
+					mockDB.EXPECT().DeleteFromHistoryShardPlacements(gomock.Any(), gomock.Any()).Return(nil, nil),
+				)
+			},
+			wantErr: "failed to copy history shard 5: some error",
+		},
+		{
+			name:    "target is the current database shard",
+			request: MoveRequest{HistoryShardID: historyShardID, TargetDBShardID: 1},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), historyShardID).Return(nil, nil)
+			},
+			wantErr: "history shard 5 is already in database shard 1",
+		},
+		{
+			name:    "history shard is being moved elsewhere",
+			request: MoveRequest{HistoryShardID: historyShardID, TargetDBShardID: 3},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), historyShardID).Return(&sqlplugin.HistoryShardPlacementRow{
+					ShardID:         historyShardID,
+					DBShardID:       2,
+					SourceDBShardID: 1,
+					State:           sqlplugin.HistoryShardPlacementStateCopying,
+				}, nil)
+			},
+			wantErr: "history shard 5 is already being moved to database shard 2",
+		},
+		{
+			name:      "target out of range",
+			request:   MoveRequest{HistoryShardID: historyShardID, TargetDBShardID: 4},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {},
+			wantErr:   "target database shard 4 is out of range [0, 4)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			mockDB.EXPECT().GetTotalNumDBShards().Return(4).AnyTimes()
+			tc.mockSetup(mockDB)
+
+			timeSource := clock.NewMockedTimeSource()
+			if tc.request.DeleteSource {
+				go func() {
+					// deleting the source waits for every host to reload the placements
+					timeSource.BlockUntil(1)
+					timeSource.Advance(sqlplugin.HistoryShardPlacementsRefreshInterval)
+				}()
+			}
+			var phases []Phase
+			mover := NewMover(mockDB, timeSource, func(p Progress) {
+				if len(phases) == 0 || phases[len(phases)-1] != p.Phase {
+					phases = append(phases, p.Phase)
+				}
+			})
+			err := mover.Move(context.Background(), tc.request)
+			if tc.wantErr != "" {
+				assert.EqualError(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.wantPhases, phases)
+		})
+	}
+}
+
+func TestMove_DeleteSourceCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := sqlplugin.NewMockDB(ctrl)
+	mockDB.EXPECT().GetTotalNumDBShards().Return(4).AnyTimes()
+	mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), 5).Return(nil, nil)
+	mockDB.EXPECT().ReplaceIntoHistoryShardPlacements(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+	mockDB.EXPECT().CopyHistoryShardRows(gomock.Any(), 5, 1, 3, gomock.Any()).Return(nil).Times(2)
+	mockDB.EXPECT().FenceHistoryShard(gomock.Any(), 5, 1).Return(int64(8), nil)
+	mockDB.EXPECT().ChecksumHistoryShardRows(gomock.Any(), 5, gomock.Any()).Return(nil, nil).Times(2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	timeSource := clock.NewMockedTimeSource()
+	go func() {
+		timeSource.BlockUntil(1)
+		cancel()
+	}()
+	err := NewMover(mockDB, timeSource, nil).Move(ctx, MoveRequest{HistoryShardID: 5, TargetDBShardID: 3, DeleteSource: true})
+	assert.EqualError(t, err, "history shard 5 was moved, but its rows were not deleted from database shard 1: context canceled")
+}
+
+func TestMove_NotSharded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockDB := sqlplugin.NewMockDB(ctrl)
+	mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+
+	err := NewMover(mockDB, clock.NewMockedTimeSource(), nil).Move(context.Background(), MoveRequest{HistoryShardID: 1, TargetDBShardID: 0})
+	assert.EqualError(t, err, "history shards can only be moved in a sharded SQL database")
+}
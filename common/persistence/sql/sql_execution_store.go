@@ -128,6 +128,24 @@ func (m *sqlExecutionStore) effectiveShardID(requestShardID *int, operation stri
 	return shardID
 }
 
+// readDuringMove is the dual-read of a history shard being moved to another database shard.
+// The read goes to the database shard this host last saw the history shard served from. If the record is not
+// there while the history shard was being moved, the placement is reloaded and, if the history shard was cut
+// over in the meantime, the read is retried in the database shard it is now served from. This keeps hosts
+// which did not refresh their placements yet from missing records, e.g. once the source rows are deleted.
+func (m *sqlExecutionStore) readDuringMove(ctx context.Context, read func() error) error {
+	err := read()
+	var notExists *types.EntityNotExistsError
+	if !errors.As(err, &notExists) || !m.db.IsHistoryShardMoving(m.shardID) {
+		return err
+	}
+	placement, refreshErr := m.db.RefreshHistoryShardPlacement(ctx, m.shardID)
+	if refreshErr != nil || placement == nil || placement.State != sqlplugin.HistoryShardPlacementStateActive {
+		return err
+	}
+	return read()
+}
+
 // txExecuteShardLocked executes f under transaction and with read lock on shard row
 func (m *sqlExecutionStore) txExecuteShardLocked(
 	ctx context.Context,
@@ -159,7 +177,7 @@ func (m *sqlExecutionStore) CreateWorkflowExecution(
 	request *p.InternalCreateWorkflowExecutionRequest,
 ) (response *p.CreateWorkflowExecutionResponse, err error) {
 	shardID := m.effectiveShardID(request.ShardID, "CreateWorkflowExecution")
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(shardID, m.db.GetTotalNumDBShards())
+	dbShardID := m.db.GetDBShardIDFromHistoryShardID(shardID)
 
 	err = m.txExecuteShardLockedFn(ctx, shardID, dbShardID, "CreateWorkflowExecution", request.RangeID, func(tx sqlplugin.Tx) error {
 		response, err = m.createWorkflowExecutionTx(ctx, tx, request, shardID)
@@ -328,6 +346,17 @@ func (m *sqlExecutionStore) getExecutions(
 func (m *sqlExecutionStore) GetWorkflowExecution(
 	ctx context.Context,
 	request *p.InternalGetWorkflowExecutionRequest,
+) (resp *p.InternalGetWorkflowExecutionResponse, err error) {
+	err = m.readDuringMove(ctx, func() (e error) {
+		resp, e = m.getWorkflowExecution(ctx, request)
+		return e
+	})
+	return resp, err
+}
+
+func (m *sqlExecutionStore) getWorkflowExecution(
+	ctx context.Context,
+	request *p.InternalGetWorkflowExecutionRequest,
 ) (resp *p.InternalGetWorkflowExecutionResponse, e error) {
 	recoverPanic := func(recovered interface{}, err *error) {
 		if recovered != nil {
@@ -454,7 +483,7 @@ func (m *sqlExecutionStore) UpdateWorkflowExecution(
 	request *p.InternalUpdateWorkflowExecutionRequest,
 ) error {
 	shardID := m.effectiveShardID(request.ShardID, "UpdateWorkflowExecution")
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(shardID, m.db.GetTotalNumDBShards())
+	dbShardID := m.db.GetDBShardIDFromHistoryShardID(shardID)
 	return m.txExecuteShardLockedFn(ctx, shardID, dbShardID, "UpdateWorkflowExecution", request.RangeID, func(tx sqlplugin.Tx) error {
 		return m.updateWorkflowExecutionTx(ctx, tx, request, shardID)
 	})
@@ -569,7 +598,7 @@ func (m *sqlExecutionStore) ConflictResolveWorkflowExecution(
 	request *p.InternalConflictResolveWorkflowExecutionRequest,
 ) error {
 	shardID := m.effectiveShardID(request.ShardID, "ConflictResolveWorkflowExecution")
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(shardID, m.db.GetTotalNumDBShards())
+	dbShardID := m.db.GetDBShardIDFromHistoryShardID(shardID)
 	return m.txExecuteShardLockedFn(ctx, shardID, dbShardID, "ConflictResolveWorkflowExecution", request.RangeID, func(tx sqlplugin.Tx) error {
 		return m.conflictResolveWorkflowExecutionTx(ctx, tx, request, shardID)
 	})
@@ -690,7 +719,7 @@ func (m *sqlExecutionStore) DeleteWorkflowExecution(
 	request *p.DeleteWorkflowExecutionRequest,
 ) error {
 	shardID := m.effectiveShardID(request.ShardID, "DeleteWorkflowExecution")
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(shardID, m.db.GetTotalNumDBShards())
+	dbShardID := m.db.GetDBShardIDFromHistoryShardID(shardID)
 	domainID := serialization.MustParseUUID(request.DomainID)
 	runID := serialization.MustParseUUID(request.RunID)
 	wfID := request.WorkflowID
@@ -790,6 +819,17 @@ func (m *sqlExecutionStore) DeleteCurrentWorkflowExecution(
 func (m *sqlExecutionStore) GetCurrentExecution(
 	ctx context.Context,
 	request *p.GetCurrentExecutionRequest,
+) (resp *p.GetCurrentExecutionResponse, err error) {
+	err = m.readDuringMove(ctx, func() (e error) {
+		resp, e = m.getCurrentExecution(ctx, request)
+		return e
+	})
+	return resp, err
+}
+
+func (m *sqlExecutionStore) getCurrentExecution(
+	ctx context.Context,
+	request *p.GetCurrentExecutionRequest,
 ) (*p.GetCurrentExecutionResponse, error) {
 
 	shardID := m.effectiveShardID(request.ShardID, "GetCurrentExecution")
@@ -1029,7 +1069,7 @@ func (m *sqlExecutionStore) CreateFailoverMarkerTasks(
 	request *p.CreateFailoverMarkersRequest,
 ) error {
 	shardID := m.effectiveShardID(request.ShardID, "CreateFailoverMarkerTasks")
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(shardID, m.db.GetTotalNumDBShards())
+	dbShardID := m.db.GetDBShardIDFromHistoryShardID(shardID)
 	return m.txExecuteShardLockedFn(ctx, shardID, dbShardID, "CreateFailoverMarkerTasks", request.RangeID, func(tx sqlplugin.Tx) error {
 		replicationTasksRows := make([]sqlplugin.ReplicationTasksRow, len(request.Markers))
 		for i, task := range request.Markers {
@@ -1364,7 +1404,7 @@ func (m *sqlExecutionStore) CompleteHistoryTask(
 	request *p.CompleteHistoryTaskRequest,
 ) error {
 	shardID := m.effectiveShardID(request.ShardID, "CompleteHistoryTask")
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(shardID, m.db.GetTotalNumDBShards())
+	dbShardID := m.db.GetDBShardIDFromHistoryShardID(shardID)
 	return m.txExecute(ctx, dbShardID, "CompleteHistoryTask", func(tx sqlplugin.Tx) error {
 		switch request.TaskCategory.Type() {
 		case p.HistoryTaskCategoryTypeScheduled:
@@ -211,6 +211,68 @@ func TestGetCurrentExecution(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Not found in the source after the history shard was cut over",
+			req: &persistence.GetCurrentExecutionRequest{
+				DomainID:   "abdcea69-61d5-44c3-9d55-afe23505a542",
+				WorkflowID: "aaaa",
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				filter := &sqlplugin.CurrentExecutionsFilter{
+					ShardID:    shardID,
+					DomainID:   serialization.MustParseUUID("abdcea69-61d5-44c3-9d55-afe23505a542"),
+					WorkflowID: "aaaa",
+				}
+				gomock.InOrder(
+					mockDB.EXPECT().SelectFromCurrentExecutions(gomock.Any(), filter).Return(nil, sql.ErrNoRows),
+					mockDB.EXPECT().IsNotFoundError(sql.ErrNoRows).Return(true),
+					mockDB.EXPECT().IsHistoryShardMoving(int(shardID)).Return(true),
+					mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), int(shardID)).Return(&sqlplugin.HistoryShardPlacementRow{
+						ShardID:         int(shardID),
+						DBShardID:       1,
+						SourceDBShardID: 0,
+						State:           sqlplugin.HistoryShardPlacementStateActive,
+					}, nil),
+					mockDB.EXPECT().SelectFromCurrentExecutions(gomock.Any(), filter).Return(&sqlplugin.CurrentExecutionsRow{
+						ShardID:          shardID,
+						DomainID:         serialization.MustParseUUID("abdcea69-61d5-44c3-9d55-afe23505a542"),
+						WorkflowID:       "aaaa",
+						RunID:            serialization.MustParseUUID("fd65967f-777d-45de-8dee-be49dfda6716"),
+						CreateRequestID:  "create",
+						State:            2,
+						CloseStatus:      3,
+						LastWriteVersion: 9,
+					}, nil),
+				)
+			},
+			want: &persistence.GetCurrentExecutionResponse{
+				StartRequestID:   "create",
+				RunID:            "fd65967f-777d-45de-8dee-be49dfda6716",
+				State:            2,
+				CloseStatus:      3,
+				LastWriteVersion: 9,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Not found while the history shard is still being copied",
+			req: &persistence.GetCurrentExecutionRequest{
+				DomainID:   "abdcea69-61d5-44c3-9d55-afe23505a542",
+				WorkflowID: "aaaa",
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().SelectFromCurrentExecutions(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows).Times(1)
+				mockDB.EXPECT().IsNotFoundError(sql.ErrNoRows).Return(true)
+				mockDB.EXPECT().IsHistoryShardMoving(int(shardID)).Return(true)
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), int(shardID)).Return(&sqlplugin.HistoryShardPlacementRow{
+					ShardID:         int(shardID),
+					DBShardID:       1,
+					SourceDBShardID: 0,
+					State:           sqlplugin.HistoryShardPlacementStateCopying,
+				}, nil)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -223,6 +285,7 @@ func TestGetCurrentExecution(t *testing.T) {
 			require.NoError(t, err, "failed to create execution store")
 
 			tc.mockSetup(mockDB)
+			mockDB.EXPECT().IsHistoryShardMoving(gomock.Any()).Return(false).AnyTimes()
 
 			got, err := store.GetCurrentExecution(context.Background(), tc.req)
 			if tc.wantErr {
@@ -757,7 +820,7 @@ func TestDeleteWorkflowExecution(t *testing.T) {
 				RunID:      "bbdcea69-61d5-44c3-9d55-afe23505a542",
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromExecutions(gomock.Any(), &sqlplugin.ExecutionsFilter{
 					ShardID:    int(shardID),
@@ -819,7 +882,7 @@ func TestDeleteWorkflowExecution(t *testing.T) {
 				RunID:      "bbdcea69-61d5-44c3-9d55-afe23505a542",
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromExecutions(gomock.Any(), &sqlplugin.ExecutionsFilter{
 					ShardID:    int(shardID),
@@ -1196,7 +1259,7 @@ func TestCreateWorkflowExecution(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockDB := sqlplugin.NewMockDB(ctrl)
-			mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+			mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 			s := &sqlExecutionStore{
 				shardID: 0,
 				sqlStore: sqlStore{
@@ -1435,7 +1498,7 @@ func TestUpdateWorkflowExecution(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockDB := sqlplugin.NewMockDB(ctrl)
-			mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+			mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 			s := &sqlExecutionStore{
 				shardID: 0,
 				sqlStore: sqlStore{
@@ -1704,7 +1767,7 @@ func TestConflictResolveWorkflowExecution(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			mockDB := sqlplugin.NewMockDB(ctrl)
-			mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+			mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 			s := &sqlExecutionStore{
 				shardID: 0,
 				sqlStore: sqlStore{
@@ -1898,7 +1961,7 @@ func TestCreateFailoverMarkerTasks(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			db := sqlplugin.NewMockDB(ctrl)
-			db.EXPECT().GetTotalNumDBShards().Return(1)
+			db.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 			tx := sqlplugin.NewMockTx(ctrl)
 			parser := serialization.NewMockParser(ctrl)
 			tc.mockSetup(tx, parser)
@@ -2628,6 +2691,7 @@ func TestGetWorkflowExecution(t *testing.T) {
 			db := sqlplugin.NewMockDB(ctrl)
 			parser := serialization.NewMockParser(ctrl)
 			tc.mockSetup(db, parser)
+			db.EXPECT().IsHistoryShardMoving(gomock.Any()).Return(false).AnyTimes()
 			s := &sqlExecutionStore{
 				shardID: 0,
 				sqlStore: sqlStore{
@@ -3094,7 +3158,7 @@ func TestCompleteHistoryTask(t *testing.T) {
 				TaskKeys:     []persistence.HistoryTaskKey{persistence.NewHistoryTaskKey(time.Unix(10, 10), 1)},
 			},
 			setupMock: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromTimerTasks(ctx, &sqlplugin.TimerTasksFilter{
 					ShardID:             shardID,
@@ -3112,7 +3176,7 @@ func TestCompleteHistoryTask(t *testing.T) {
 				TaskKeys:     []persistence.HistoryTaskKey{persistence.NewImmediateTaskKey(2)},
 			},
 			setupMock: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromTransferTasks(ctx, &sqlplugin.TransferTasksFilter{
 					ShardID: shardID,
@@ -3129,7 +3193,7 @@ func TestCompleteHistoryTask(t *testing.T) {
 				TaskKeys:     []persistence.HistoryTaskKey{persistence.NewImmediateTaskKey(3)},
 			},
 			setupMock: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromReplicationTasks(ctx, &sqlplugin.ReplicationTasksFilter{
 					ShardID: shardID,
@@ -3149,7 +3213,7 @@ func TestCompleteHistoryTask(t *testing.T) {
 				},
 			},
 			setupMock: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromTimerTasks(ctx, &sqlplugin.TimerTasksFilter{
 					ShardID:             shardID,
@@ -3171,7 +3235,7 @@ func TestCompleteHistoryTask(t *testing.T) {
 				TaskCategory: persistence.HistoryTaskCategory{},
 			},
 			setupMock: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().Rollback().Return(nil)
 				mockDB.EXPECT().IsNotFoundError(gomock.Any()).Return(true)
@@ -3185,7 +3249,7 @@ func TestCompleteHistoryTask(t *testing.T) {
 				TaskKeys:     []persistence.HistoryTaskKey{persistence.NewHistoryTaskKey(time.Unix(10, 10), 1)},
 			},
 			setupMock: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromTimerTasks(ctx, &sqlplugin.TimerTasksFilter{
 					ShardID:             shardID,
@@ -3204,7 +3268,7 @@ func TestCompleteHistoryTask(t *testing.T) {
 				TaskKeys:     []persistence.HistoryTaskKey{persistence.NewImmediateTaskKey(2)},
 			},
 			setupMock: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromTransferTasks(ctx, &sqlplugin.TransferTasksFilter{
 					ShardID: shardID,
@@ -3222,7 +3286,7 @@ func TestCompleteHistoryTask(t *testing.T) {
 				TaskKeys:     []persistence.HistoryTaskKey{persistence.NewImmediateTaskKey(3)},
 			},
 			setupMock: func(mockDB *sqlplugin.MockDB, mockTx *sqlplugin.MockTx) {
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(ctx, gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().DeleteFromReplicationTasks(ctx, &sqlplugin.ReplicationTasksFilter{
 					ShardID: shardID,
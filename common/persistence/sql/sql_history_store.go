@@ -154,6 +154,27 @@ func (m *sqlHistoryStore) AppendHistoryNodes(
 	return nil
 }
 
+// RewriteHistoryNode replaces the data of an existing node
+func (m *sqlHistoryStore) RewriteHistoryNode(
+	ctx context.Context,
+	request *persistence.InternalRewriteHistoryNodeRequest,
+) error {
+
+	nodeRow := &sqlplugin.HistoryNodeRow{
+		TreeID:       serialization.MustParseUUID(request.TreeID),
+		BranchID:     serialization.MustParseUUID(request.BranchID),
+		NodeID:       request.NodeID,
+		TxnID:        &request.TransactionID,
+		Data:         request.Events.Data,
+		DataEncoding: string(request.Events.Encoding),
+		ShardID:      request.ShardID,
+	}
+	if _, err := m.db.UpdateHistoryNode(ctx, nodeRow); err != nil {
+		return convertCommonErrors(m.db, "RewriteHistoryNode", "", err)
+	}
+	return nil
+}
+
 // ReadHistoryBranch returns history node data for a branch
 func (m *sqlHistoryStore) ReadHistoryBranch(
 	ctx context.Context,
@@ -179,12 +200,13 @@ func (m *sqlHistoryStore) ReadHistoryBranch(
 	}
 
 	filter := &sqlplugin.HistoryNodeFilter{
-		TreeID:    serialization.MustParseUUID(request.TreeID),
-		BranchID:  serialization.MustParseUUID(request.BranchID),
-		MinNodeID: &minNodeID,
-		MaxNodeID: &maxNodeID,
-		PageSize:  request.PageSize,
-		ShardID:   request.ShardID,
+		TreeID:          serialization.MustParseUUID(request.TreeID),
+		BranchID:        serialization.MustParseUUID(request.BranchID),
+		MinNodeID:       &minNodeID,
+		MaxNodeID:       &maxNodeID,
+		PageSize:        request.PageSize,
+		ShardID:         request.ShardID,
+		ReadFromReplica: request.ReadFromReplica,
 	}
 
 	rows, err := m.db.SelectFromHistoryNode(ctx, filter)
@@ -196,6 +218,8 @@ func (m *sqlHistoryStore) ReadHistoryBranch(
 	}
 
 	history := make([]*persistence.DataBlob, 0, int(request.PageSize))
+	nodeIDs := make([]int64, 0, int(request.PageSize))
+	txnIDs := make([]int64, 0, int(request.PageSize))
 	eventBlob := &persistence.DataBlob{}
 
 	for _, row := range rows {
@@ -240,6 +264,8 @@ func (m *sqlHistoryStore) ReadHistoryBranch(
 			lastTxnID = *row.TxnID
 			lastNodeID = row.NodeID
 			history = append(history, eventBlob)
+			nodeIDs = append(nodeIDs, row.NodeID)
+			txnIDs = append(txnIDs, *row.TxnID)
 			eventBlob = &persistence.DataBlob{}
 		}
 	}
@@ -251,6 +277,8 @@ func (m *sqlHistoryStore) ReadHistoryBranch(
 
 	return &persistence.InternalReadHistoryBranchResponse{
 		History:           history,
+		NodeIDs:           nodeIDs,
+		TransactionIDs:    txnIDs,
 		NextPageToken:     pagingToken,
 		LastNodeID:        lastNodeID,
 		LastTransactionID: lastTxnID,
@@ -837,6 +837,65 @@ func TestAppendHistoryNodes(t *testing.T) {
 	}
 }
 
+func TestRewriteHistoryNode(t *testing.T) {
+	req := &persistence.InternalRewriteHistoryNodeRequest{
+		TreeID:        "530ec3d3-f74b-423f-a138-3b35494fe691",
+		BranchID:      "630ec3d3-f74b-423f-a138-3b35494fe691",
+		NodeID:        11,
+		TransactionID: 100,
+		Events:        &persistence.DataBlob{Data: []byte(`a`), Encoding: constants.EncodingTypeEncrypted},
+		ShardID:       1,
+	}
+	testCases := []struct {
+		name      string
+		mockSetup func(*sqlplugin.MockDB)
+		wantErr   bool
+	}{
+		{
+			name: "Success case",
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().UpdateHistoryNode(gomock.Any(), &sqlplugin.HistoryNodeRow{
+					TreeID:       serialization.MustParseUUID("530ec3d3-f74b-423f-a138-3b35494fe691"),
+					BranchID:     serialization.MustParseUUID("630ec3d3-f74b-423f-a138-3b35494fe691"),
+					NodeID:       11,
+					TxnID:        common.Int64Ptr(100),
+					Data:         []byte(`a`),
+					DataEncoding: string(constants.EncodingTypeEncrypted),
+					ShardID:      1,
+				}).Return(&sqlResult{rowsAffected: 1}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "Error case",
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				err := errors.New("some error")
+				mockDB.EXPECT().UpdateHistoryNode(gomock.Any(), gomock.Any()).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(true)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := sqlplugin.NewMockDB(ctrl)
+			store, err := NewHistoryV2Persistence(mockDB, nil, nil, nil)
+			require.NoError(t, err, "Failed to create sql history store")
+
+			tc.mockSetup(mockDB)
+			err = store.RewriteHistoryNode(context.Background(), req)
+			if tc.wantErr {
+				assert.Error(t, err, "Expected an error for test case")
+			} else {
+				assert.NoError(t, err, "Did not expect an error for test case")
+			}
+		})
+	}
+}
+
 func TestReadHistoryBranch(t *testing.T) {
 	testCases := []struct {
 		name      string
@@ -884,12 +943,52 @@ func TestReadHistoryBranch(t *testing.T) {
 			},
 			want: &persistence.InternalReadHistoryBranchResponse{
 				History:           []*persistence.DataBlob{{Data: []byte(`b`), Encoding: constants.EncodingType("b")}},
+				NodeIDs:           []int64{202},
+				TransactionIDs:    []int64{101},
 				NextPageToken:     serializePageToken(202),
 				LastNodeID:        202,
 				LastTransactionID: 101,
 			},
 			wantErr: false,
 		},
+		{
+			name: "Success case - read from replica",
+			req: &persistence.InternalReadHistoryBranchRequest{
+				TreeID:          "530ec3d3-f74b-423f-a138-3b35494fe691",
+				BranchID:        "630ec3d3-f74b-423f-a138-3b35494fe691",
+				MinNodeID:       1,
+				MaxNodeID:       1000,
+				PageSize:        2,
+				ShardID:         1,
+				ReadFromReplica: true,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB) {
+				mockDB.EXPECT().SelectFromHistoryNode(gomock.Any(), &sqlplugin.HistoryNodeFilter{
+					TreeID:          serialization.MustParseUUID("530ec3d3-f74b-423f-a138-3b35494fe691"),
+					BranchID:        serialization.MustParseUUID("630ec3d3-f74b-423f-a138-3b35494fe691"),
+					MinNodeID:       common.Int64Ptr(1),
+					MaxNodeID:       common.Int64Ptr(1000),
+					PageSize:        2,
+					ShardID:         1,
+					ReadFromReplica: true,
+				}).Return([]sqlplugin.HistoryNodeRow{
+					{
+						NodeID:       1,
+						TxnID:        common.Int64Ptr(99),
+						Data:         []byte(`a`),
+						DataEncoding: "a",
+					},
+				}, nil)
+			},
+			want: &persistence.InternalReadHistoryBranchResponse{
+				History:           []*persistence.DataBlob{{Data: []byte(`a`), Encoding: constants.EncodingType("a")}},
+				NodeIDs:           []int64{1},
+				TransactionIDs:    []int64{99},
+				LastNodeID:        1,
+				LastTransactionID: 99,
+			},
+			wantErr: false,
+		},
 		{
 			name: "Success case - no row",
 			req: &persistence.InternalReadHistoryBranchRequest{
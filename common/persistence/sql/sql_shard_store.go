@@ -86,6 +86,18 @@ func (m *sqlShardStore) GetShard(
 	ctx context.Context,
 	request *persistence.InternalGetShardRequest,
 ) (*persistence.InternalGetShardResponse, error) {
+	// the placement is reloaded so that a history shard which was just moved is read from its new database shard
+	placement, err := m.db.RefreshHistoryShardPlacement(ctx, request.ShardID)
+	if err != nil {
+		return nil, convertCommonErrors(m.db, "GetShard", fmt.Sprintf("Failed to get placement of shard, ShardId: %v.", request.ShardID), err)
+	}
+	if placement != nil && placement.State == sqlplugin.HistoryShardPlacementStateFrozen {
+		return nil, &persistence.ShardOwnershipLostError{
+			ShardID: request.ShardID,
+			Msg:     fmt.Sprintf("Shard %v is being moved to database shard %v.", request.ShardID, placement.DBShardID),
+		}
+	}
+
 	row, err := m.db.SelectFromShards(ctx, &sqlplugin.ShardsFilter{ShardID: int64(request.ShardID)})
 	if err != nil {
 		return nil, convertCommonErrors(m.db, "GetShard", fmt.Sprintf("Failed to get shard, ShardId: %v.", request.ShardID), err)
@@ -176,11 +188,14 @@ func (m *sqlShardStore) UpdateShard(
 			Message: fmt.Sprintf("UpdateShard operation failed. Error: %v", err),
 		}
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(request.ShardInfo.ShardID, m.db.GetTotalNumDBShards())
+	dbShardID := m.db.GetDBShardIDFromHistoryShardID(request.ShardInfo.ShardID)
 	return m.txExecute(ctx, dbShardID, "UpdateShard", func(tx sqlplugin.Tx) error {
 		if err := lockShard(ctx, tx, request.ShardInfo.ShardID, request.PreviousRangeID); err != nil {
 			return err
 		}
+		if err := m.checkHistoryShardPlacement(ctx, request.ShardInfo.ShardID, dbShardID); err != nil {
+			return err
+		}
 		result, err := tx.UpdateShards(ctx, row)
 		if err != nil {
 			return err
@@ -196,6 +211,34 @@ func (m *sqlShardStore) UpdateShard(
 	})
 }
 
+// checkHistoryShardPlacement makes sure the history shard is still served from dbShardID and is not frozen.
+// It must be called once the shard row is locked in dbShardID: a move freezes the placement before it fences
+// the shard row, so either the frozen placement is seen here, or fencing waits for the transaction holding
+// the lock and the final copy of the move includes what the transaction wrote.
+func (m *sqlShardStore) checkHistoryShardPlacement(ctx context.Context, shardID int, dbShardID int) error {
+	placement, err := m.db.RefreshHistoryShardPlacement(ctx, shardID)
+	if err != nil {
+		return err
+	}
+	servingDBShardID := sqlplugin.GetDBShardIDFromHistoryShardID(shardID, m.db.GetTotalNumDBShards())
+	if placement != nil {
+		if placement.State == sqlplugin.HistoryShardPlacementStateFrozen {
+			return &persistence.ShardOwnershipLostError{
+				ShardID: shardID,
+				Msg:     fmt.Sprintf("Failed to update shard. Shard %v is being moved to database shard %v.", shardID, placement.DBShardID),
+			}
+		}
+		servingDBShardID = placement.GetServingDBShardID()
+	}
+	if servingDBShardID != dbShardID {
+		return &persistence.ShardOwnershipLostError{
+			ShardID: shardID,
+			Msg:     fmt.Sprintf("Failed to update shard. Shard %v was moved from database shard %v to %v.", shardID, dbShardID, servingDBShardID),
+		}
+	}
+	return nil
+}
+
 // initiated by the owning shard
 func lockShard(ctx context.Context, tx sqlplugin.Tx, shardID int, oldRangeID int64) error {
 	rangeID, err := tx.WriteLockShards(ctx, &sqlplugin.ShardsFilter{ShardID: int64(shardID)})
@@ -54,6 +54,7 @@ func TestGetShard(t *testing.T) {
 				ShardID: 2,
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), gomock.Any()).Return(nil, nil)
 				mockDB.EXPECT().SelectFromShards(gomock.Any(), &sqlplugin.ShardsFilter{ShardID: 2}).Return(&sqlplugin.ShardsRow{
 					ShardID:      2,
 					RangeID:      4,
@@ -133,6 +134,7 @@ func TestGetShard(t *testing.T) {
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
 				err := errors.New("some error")
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), gomock.Any()).Return(nil, nil)
 				mockDB.EXPECT().SelectFromShards(gomock.Any(), gomock.Any()).Return(nil, err)
 				mockDB.EXPECT().IsNotFoundError(err).Return(true)
 			},
@@ -145,6 +147,7 @@ func TestGetShard(t *testing.T) {
 				ShardID: 2,
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), gomock.Any()).Return(nil, nil)
 				mockDB.EXPECT().SelectFromShards(gomock.Any(), &sqlplugin.ShardsFilter{ShardID: 2}).Return(&sqlplugin.ShardsRow{
 					ShardID:      2,
 					RangeID:      4,
@@ -156,6 +159,37 @@ func TestGetShard(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:        "Error case - failed to get placement",
+			clusterName: "active",
+			req: &persistence.InternalGetShardRequest{
+				ShardID: 2,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				err := errors.New("some error")
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), 2).Return(nil, err)
+				mockDB.EXPECT().IsNotFoundError(err).Return(false)
+				mockDB.EXPECT().IsTimeoutError(err).Return(false)
+				mockDB.EXPECT().IsThrottlingError(err).Return(false)
+			},
+			wantErr: true,
+		},
+		{
+			name:        "Error case - shard is being moved",
+			clusterName: "active",
+			req: &persistence.InternalGetShardRequest{
+				ShardID: 2,
+			},
+			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), 2).Return(&sqlplugin.HistoryShardPlacementRow{
+					ShardID:         2,
+					DBShardID:       1,
+					SourceDBShardID: 0,
+					State:           sqlplugin.HistoryShardPlacementStateFrozen,
+				}, nil)
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -229,6 +263,7 @@ func TestCreateShard(t *testing.T) {
 				},
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), gomock.Any()).Return(nil, nil)
 				mockDB.EXPECT().SelectFromShards(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
 				mockDB.EXPECT().IsNotFoundError(gomock.Any()).Return(true)
 				mockParser.EXPECT().ShardInfoToBlob(&serialization.ShardInfo{
@@ -276,6 +311,7 @@ func TestCreateShard(t *testing.T) {
 				ShardInfo: &persistence.InternalShardInfo{},
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), gomock.Any()).Return(nil, nil)
 				mockDB.EXPECT().SelectFromShards(gomock.Any(), gomock.Any()).Return(&sqlplugin.ShardsRow{}, nil)
 				mockParser.EXPECT().ShardInfoFromBlob(gomock.Any(), gomock.Any()).Return(&serialization.ShardInfo{}, nil)
 			},
@@ -292,6 +328,7 @@ func TestCreateShard(t *testing.T) {
 				ShardInfo: &persistence.InternalShardInfo{},
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), gomock.Any()).Return(nil, nil)
 				mockDB.EXPECT().SelectFromShards(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
 				mockDB.EXPECT().IsNotFoundError(gomock.Any()).Return(true)
 				mockParser.EXPECT().ShardInfoToBlob(gomock.Any()).Return(persistence.DataBlob{}, errors.New("some error"))
@@ -305,6 +342,7 @@ func TestCreateShard(t *testing.T) {
 				ShardInfo: &persistence.InternalShardInfo{},
 			},
 			mockSetup: func(mockDB *sqlplugin.MockDB, mockParser *serialization.MockParser) {
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), gomock.Any()).Return(nil, nil)
 				mockDB.EXPECT().SelectFromShards(gomock.Any(), gomock.Any()).Return(nil, sql.ErrNoRows)
 				mockDB.EXPECT().IsNotFoundError(gomock.Any()).Return(true)
 				mockParser.EXPECT().ShardInfoToBlob(gomock.Any()).Return(persistence.DataBlob{
@@ -421,9 +459,11 @@ func TestUpdateShard(t *testing.T) {
 					Encoding: constants.EncodingType("shard"),
 					Data:     []byte(`shard`),
 				}, nil)
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().WriteLockShards(gomock.Any(), &sqlplugin.ShardsFilter{ShardID: 2}).Return(1, nil)
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), 2).Return(nil, nil)
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
 				mockTx.EXPECT().UpdateShards(gomock.Any(), &sqlplugin.ShardsRow{
 					ShardID:      2,
 					RangeID:      4,
@@ -456,7 +496,7 @@ func TestUpdateShard(t *testing.T) {
 					Encoding: constants.EncodingType("shard"),
 					Data:     []byte(`shard`),
 				}, nil)
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
 				err := errors.New("some error")
 				mockTx.EXPECT().WriteLockShards(gomock.Any(), gomock.Any()).Return(0, err)
@@ -476,9 +516,11 @@ func TestUpdateShard(t *testing.T) {
 					Encoding: constants.EncodingType("shard"),
 					Data:     []byte(`shard`),
 				}, nil)
-				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
+				mockDB.EXPECT().GetDBShardIDFromHistoryShardID(gomock.Any()).Return(0)
 				mockDB.EXPECT().BeginTx(gomock.Any(), gomock.Any()).Return(mockTx, nil)
 				mockTx.EXPECT().WriteLockShards(gomock.Any(), gomock.Any()).Return(0, nil)
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), gomock.Any()).Return(nil, nil)
+				mockDB.EXPECT().GetTotalNumDBShards().Return(1)
 				err := errors.New("some error")
 				mockTx.EXPECT().UpdateShards(gomock.Any(), gomock.Any()).Return(nil, err)
 				mockTx.EXPECT().Rollback().Return(nil)
@@ -510,6 +552,88 @@ func TestUpdateShard(t *testing.T) {
 	}
 }
 
+func TestUpdateShardWhileMoving(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := sqlplugin.NewMockDB(ctrl)
+	mockTx := sqlplugin.NewMockTx(ctrl)
+	mockParser := serialization.NewMockParser(ctrl)
+	store, err := NewShardPersistence(mockDB, "active", nil, mockParser)
+	require.NoError(t, err, "Failed to create sql shard store")
+
+	// history shard 2 lives on database shard 0 and is moved to database shard 1. The owner keeps
+	// using database shard 0, as it does until it reloads the placements
+	var placement *sqlplugin.HistoryShardPlacementRow
+	rangeID := 4
+	mockDB.EXPECT().GetTotalNumDBShards().Return(2).AnyTimes()
+	mockDB.EXPECT().GetDBShardIDFromHistoryShardID(2).Return(0).AnyTimes()
+	mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), 2).DoAndReturn(
+		func(context.Context, int) (*sqlplugin.HistoryShardPlacementRow, error) {
+			return placement, nil
+		},
+	).AnyTimes()
+	mockDB.EXPECT().SelectFromShards(gomock.Any(), &sqlplugin.ShardsFilter{ShardID: 2}).DoAndReturn(
+		func(context.Context, *sqlplugin.ShardsFilter) (*sqlplugin.ShardsRow, error) {
+			return &sqlplugin.ShardsRow{ShardID: 2, RangeID: int64(rangeID)}, nil
+		},
+	).AnyTimes()
+	mockDB.EXPECT().BeginTx(gomock.Any(), 0).Return(mockTx, nil).AnyTimes()
+	mockTx.EXPECT().WriteLockShards(gomock.Any(), &sqlplugin.ShardsFilter{ShardID: 2}).DoAndReturn(
+		func(context.Context, *sqlplugin.ShardsFilter) (int, error) {
+			return rangeID, nil
+		},
+	).AnyTimes()
+	mockTx.EXPECT().Rollback().Return(nil).AnyTimes()
+	mockParser.EXPECT().ShardInfoFromBlob(gomock.Any(), gomock.Any()).Return(&serialization.ShardInfo{}, nil).AnyTimes()
+	mockParser.EXPECT().ShardInfoToBlob(gomock.Any()).Return(persistence.DataBlob{}, nil).AnyTimes()
+
+	getShard := func() (int64, error) {
+		resp, err := store.GetShard(context.Background(), &persistence.InternalGetShardRequest{ShardID: 2})
+		if err != nil {
+			return 0, err
+		}
+		return resp.ShardInfo.RangeID, nil
+	}
+	updateShard := func(previousRangeID int64) error {
+		return store.UpdateShard(context.Background(), &persistence.InternalUpdateShardRequest{
+			ShardInfo:       &persistence.InternalShardInfo{ShardID: 2, RangeID: previousRangeID},
+			PreviousRangeID: previousRangeID,
+		})
+	}
+	setPlacement := func(dbShardID int, state sqlplugin.HistoryShardPlacementState) {
+		placement = &sqlplugin.HistoryShardPlacementRow{ShardID: 2, DBShardID: dbShardID, SourceDBShardID: 0, State: state}
+	}
+	var ownershipLost *persistence.ShardOwnershipLostError
+
+	// the owner acquires the history shard while it is being copied
+	setPlacement(1, sqlplugin.HistoryShardPlacementStateCopying)
+	acquiredRangeID, err := getShard()
+	require.NoError(t, err)
+
+	// the placement is frozen, but the shard row is not fenced yet
+	setPlacement(1, sqlplugin.HistoryShardPlacementStateFrozen)
+	assert.True(t, errors.As(updateShard(acquiredRangeID), &ownershipLost), "shard updated while frozen")
+
+	// the shard row is fenced
+	rangeID++
+	assert.True(t, errors.As(updateShard(acquiredRangeID), &ownershipLost), "shard updated after being fenced")
+	_, err = getShard()
+	assert.True(t, errors.As(err, &ownershipLost), "shard acquired while frozen")
+
+	// the history shard is cut over to database shard 1, even a matching range ID is rejected in database shard 0
+	setPlacement(1, sqlplugin.HistoryShardPlacementStateActive)
+	assert.True(t, errors.As(updateShard(int64(rangeID)), &ownershipLost), "shard updated in the database shard it was moved from")
+
+	// the move is aborted instead, so the history shard is served from database shard 0 again
+	placement = nil
+	acquiredRangeID, err = getShard()
+	require.NoError(t, err)
+	mockTx.EXPECT().UpdateShards(gomock.Any(), gomock.Any()).Return(&sqlResult{rowsAffected: 1}, nil)
+	mockTx.EXPECT().Commit().Return(nil)
+	assert.NoError(t, updateShard(acquiredRangeID))
+}
+
 func TestLockShard(t *testing.T) {
 	shardID := 1
 	oldRangeID := int64(99)
@@ -459,6 +459,7 @@ func (m *sqlTaskStore) CreateTasks(
 			TaskID:       v.TaskID,
 			Data:         blob.Data,
 			DataEncoding: string(blob.Encoding),
+			Priority:     v.Data.Priority,
 		}
 		if m.db.SupportsTTL() {
 			currTasksRowWithTTL := sqlplugin.TasksRowWithTTL{
@@ -533,6 +534,7 @@ func (m *sqlTaskStore) GetTasks(
 			Expiry:          info.GetExpiryTimestamp(),
 			CreatedTime:     info.GetCreatedTimestamp(),
 			PartitionConfig: info.GetPartitionConfig(),
+			Priority:        v.Priority,
 		}
 	}
 
@@ -1292,6 +1292,7 @@ func TestCreateTasks(t *testing.T) {
 							ScheduleToStartTimeoutSeconds: 1,
 							DomainID:                      "c9488dc7-20b2-44c3-b2e4-bfea5af62ac0",
 							TaskID:                        999,
+							Priority:                      2,
 						},
 						TaskID: 999,
 					},
@@ -1316,6 +1317,7 @@ func TestCreateTasks(t *testing.T) {
 							TaskID:       999,
 							Data:         []byte(`tl`),
 							DataEncoding: "tl",
+							Priority:     2,
 						},
 						TTL: common.DurationPtr(time.Second),
 					},
@@ -1495,6 +1497,7 @@ func TestGetTasks(t *testing.T) {
 						TaskID:       888,
 						Data:         []byte(`task`),
 						DataEncoding: "task",
+						Priority:     4,
 					},
 				}, nil)
 				mockParser.EXPECT().TaskInfoFromBlob([]byte(`task`), "task").Return(&serialization.TaskInfo{
@@ -1517,6 +1520,7 @@ func TestGetTasks(t *testing.T) {
 						Expiry:          time.Unix(9, 0),
 						CreatedTime:     time.Unix(8, 7),
 						PartitionConfig: map[string]string{"a": "b"},
+						Priority:        4,
 					},
 				},
 			},
@@ -0,0 +1,434 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xwb1989/sqlparser"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+	"github.com/uber/cadence/common/types"
+)
+
+type (
+	// visibilityQueryTranslator translates the visibility query language into SQL expressions
+	// of executions_visibility table. System search attributes are mapped to their columns and
+	// custom search attributes are read from the search_attributes column through the dialect
+	visibilityQueryTranslator struct {
+		dialect               sqlplugin.VisibilityQueryDialect
+		validSearchAttributes map[string]interface{}
+		logger                log.Logger
+	}
+
+	translatedVisibilityQuery struct {
+		// condition uses ? as the placeholders of args, it's empty when the query has no filter
+		condition string
+		args      []interface{}
+		// orderBy is empty when the query doesn't specify an order
+		orderBy string
+	}
+)
+
+const missingValue = "missing"
+
+var (
+	visibilitySystemColumns = map[string]string{
+		definition.WorkflowID:             "workflow_id",
+		definition.RunID:                  "run_id",
+		definition.WorkflowType:           "workflow_type_name",
+		definition.StartTime:              "start_time",
+		definition.ExecutionTime:          "execution_time",
+		definition.CloseTime:              "close_time",
+		definition.CloseStatus:            "close_status",
+		definition.HistoryLength:          "history_length",
+		definition.IsCron:                 "is_cron",
+		definition.NumClusters:            "num_clusters",
+		definition.UpdateTime:             "update_time",
+		definition.CronSchedule:           "cron_schedule",
+		definition.ExecutionStatus:        "execution_status",
+		definition.ScheduledExecutionTime: "scheduled_execution_time",
+	}
+
+	visibilityTimeColumns = map[string]bool{
+		definition.StartTime:              true,
+		definition.ExecutionTime:          true,
+		definition.CloseTime:              true,
+		definition.UpdateTime:             true,
+		definition.ScheduledExecutionTime: true,
+	}
+
+	// search attribute keys are embedded into the JSON paths of the expressions
+	searchAttributeKeyRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+)
+
+func newVisibilityQueryTranslator(
+	dialect sqlplugin.VisibilityQueryDialect,
+	validSearchAttributes map[string]interface{},
+	logger log.Logger,
+) *visibilityQueryTranslator {
+	return &visibilityQueryTranslator{
+		dialect:               dialect,
+		validSearchAttributes: validSearchAttributes,
+		logger:                logger,
+	}
+}
+
+func (t *visibilityQueryTranslator) translate(query string) (*translatedVisibilityQuery, error) {
+	result := &translatedVisibilityQuery{}
+	query = strings.TrimSpace(query)
+	if len(query) == 0 {
+		return result, nil
+	}
+
+	// Build a placeholder query that allows us to easily parse the contents of the where clause.
+	// IMPORTANT: This query is never executed, it is just used to parse the query
+	var placeholderQuery string
+	if common.IsJustOrderByClause(query) {
+		placeholderQuery = fmt.Sprintf("SELECT * FROM dummy %s", query)
+	} else {
+		placeholderQuery = fmt.Sprintf("SELECT * FROM dummy WHERE %s", query)
+	}
+	stmt, err := sqlparser.Parse(placeholderQuery)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query: %v", err)
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok {
+		return nil, errors.New("invalid select query")
+	}
+
+	if sel.Where != nil {
+		result.condition, err = t.translateWhereExpr(result, sel.Where.Expr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.orderBy, err = t.translateOrderBy(sel.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (t *visibilityQueryTranslator) translateWhereExpr(q *translatedVisibilityQuery, expr sqlparser.Expr) (string, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.AndExpr:
+		return t.translateAndOrExpr(q, expr.Left, expr.Right, "AND")
+	case *sqlparser.OrExpr:
+		return t.translateAndOrExpr(q, expr.Left, expr.Right, "OR")
+	case *sqlparser.ComparisonExpr:
+		return t.translateComparisonExpr(q, expr)
+	case *sqlparser.RangeCond:
+		return t.translateRangeExpr(q, expr)
+	case *sqlparser.ParenExpr:
+		return t.translateWhereExpr(q, expr.Expr)
+	default:
+		return "", errors.New("invalid where clause")
+	}
+}
+
+func (t *visibilityQueryTranslator) translateAndOrExpr(q *translatedVisibilityQuery, left, right sqlparser.Expr, operator string) (string, error) {
+	leftRes, err := t.translateWhereExpr(q, left)
+	if err != nil {
+		return "", err
+	}
+	rightRes, err := t.translateWhereExpr(q, right)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("(%s %s %s)", leftRes, operator, rightRes), nil
+}
+
+func (t *visibilityQueryTranslator) translateComparisonExpr(q *translatedVisibilityQuery, expr *sqlparser.ComparisonExpr) (string, error) {
+	colName, ok := expr.Left.(*sqlparser.ColName)
+	if !ok {
+		return "", errors.New("invalid comparison expression")
+	}
+	key, valueType, err := t.searchAttribute(colName)
+	if err != nil {
+		return "", err
+	}
+	column := t.column(key, valueType)
+
+	// the value of a comparison is parsed as a column name when it's not quoted, e.g. CloseTime = missing
+	if val, ok := expr.Right.(*sqlparser.ColName); ok {
+		if val.Name.String() != missingValue {
+			return "", fmt.Errorf("invalid value %q of search attribute %q", val.Name.String(), key)
+		}
+		switch expr.Operator {
+		case sqlparser.EqualStr:
+			return column + " IS NULL", nil
+		case sqlparser.NotEqualStr:
+			return column + " IS NOT NULL", nil
+		default:
+			return "", fmt.Errorf("invalid operator %q for missing value of search attribute %q", expr.Operator, key)
+		}
+	}
+
+	_, isSystemColumn := visibilitySystemColumns[key]
+	isCustomKeyword := !isSystemColumn && valueType == types.IndexedValueTypeKeyword
+	isCustomString := !isSystemColumn && valueType == types.IndexedValueTypeString
+
+	switch expr.Operator {
+	case sqlparser.InStr, sqlparser.NotInStr:
+		tuple, ok := expr.Right.(sqlparser.ValTuple)
+		if !ok || len(tuple) == 0 {
+			return "", fmt.Errorf("invalid value list of search attribute %q", key)
+		}
+		conditions := make([]string, 0, len(tuple))
+		for _, valExpr := range tuple {
+			val, err := t.parseValue(key, valueType, valExpr)
+			if err != nil {
+				return "", err
+			}
+			q.args = append(q.args, val)
+			if isCustomKeyword {
+				conditions = append(conditions, t.dialect.KeywordSearchAttributeContains(key))
+			} else {
+				conditions = append(conditions, "?")
+			}
+		}
+		if isCustomKeyword {
+			res := "(" + strings.Join(conditions, " OR ") + ")"
+			if expr.Operator == sqlparser.NotInStr {
+				return fmt.Sprintf("(%s IS NULL OR NOT %s)", column, res), nil
+			}
+			return res, nil
+		}
+		return fmt.Sprintf("%s %s (%s)", column, strings.ToUpper(expr.Operator), strings.Join(conditions, ", ")), nil
+	case sqlparser.LikeStr, sqlparser.NotLikeStr:
+		if valueType != types.IndexedValueTypeKeyword && valueType != types.IndexedValueTypeString {
+			return "", fmt.Errorf("invalid operator %q for search attribute %q", expr.Operator, key)
+		}
+		val, err := t.parseValue(key, valueType, expr.Right)
+		if err != nil {
+			return "", err
+		}
+		q.args = append(q.args, val)
+		return fmt.Sprintf("%s %s ?", column, strings.ToUpper(expr.Operator)), nil
+	case sqlparser.EqualStr, sqlparser.NotEqualStr, sqlparser.LessThanStr, sqlparser.LessEqualStr,
+		sqlparser.GreaterThanStr, sqlparser.GreaterEqualStr:
+	default:
+		return "", fmt.Errorf("invalid operator %q for search attribute %q", expr.Operator, key)
+	}
+
+	val, err := t.parseValue(key, valueType, expr.Right)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case isCustomKeyword && expr.Operator == sqlparser.EqualStr:
+		// a keyword search attribute may hold a list of values, any of which can match
+		q.args = append(q.args, val)
+		return t.dialect.KeywordSearchAttributeContains(key), nil
+	case isCustomKeyword && expr.Operator == sqlparser.NotEqualStr:
+		q.args = append(q.args, val)
+		return fmt.Sprintf("(%s IS NULL OR NOT %s)", column, t.dialect.KeywordSearchAttributeContains(key)), nil
+	case isCustomString && expr.Operator == sqlparser.EqualStr:
+		// string search attributes are full text in Elasticsearch, the closest match is a substring
+		q.args = append(q.args, "%"+val.(string)+"%")
+		return column + " LIKE ?", nil
+	case isCustomString && expr.Operator == sqlparser.NotEqualStr:
+		q.args = append(q.args, "%"+val.(string)+"%")
+		return fmt.Sprintf("(%s IS NULL OR %s NOT LIKE ?)", column, column), nil
+	case !isSystemColumn && expr.Operator == sqlparser.NotEqualStr:
+		// like Elasticsearch, workflows without the search attribute don't equal any value
+		q.args = append(q.args, val)
+		return fmt.Sprintf("(%s IS NULL OR %s != ?)", column, column), nil
+	default:
+		q.args = append(q.args, val)
+		return fmt.Sprintf("%s %s ?", column, expr.Operator), nil
+	}
+}
+
+// for "between...and..." only
+func (t *visibilityQueryTranslator) translateRangeExpr(q *translatedVisibilityQuery, expr *sqlparser.RangeCond) (string, error) {
+	colName, ok := expr.Left.(*sqlparser.ColName)
+	if !ok {
+		return "", errors.New("invalid range expression")
+	}
+	key, valueType, err := t.searchAttribute(colName)
+	if err != nil {
+		return "", err
+	}
+	from, err := t.parseValue(key, valueType, expr.From)
+	if err != nil {
+		return "", err
+	}
+	to, err := t.parseValue(key, valueType, expr.To)
+	if err != nil {
+		return "", err
+	}
+	q.args = append(q.args, from, to)
+	return fmt.Sprintf("%s %s ? AND ?", t.column(key, valueType), strings.ToUpper(expr.Operator)), nil
+}
+
+func (t *visibilityQueryTranslator) translateOrderBy(orderBy sqlparser.OrderBy) (string, error) {
+	if len(orderBy) == 0 {
+		return "", nil
+	}
+	orders := make([]string, 0, len(orderBy)+1)
+	for _, order := range orderBy {
+		colName, ok := order.Expr.(*sqlparser.ColName)
+		if !ok {
+			return "", errors.New("invalid order by expression")
+		}
+		key, valueType, err := t.searchAttribute(colName)
+		if err != nil {
+			return "", err
+		}
+		direction := "ASC"
+		if order.Direction == sqlparser.DescScr {
+			direction = "DESC"
+		}
+		orders = append(orders, t.column(key, valueType)+" "+direction)
+	}
+	// run_id breaks the ties so that the pages are stable
+	orders = append(orders, "run_id")
+	return strings.Join(orders, ", "), nil
+}
+
+// searchAttribute returns the key and the type of the search attribute referred by colName.
+// Custom search attributes may be prefixed by the query validator of the frontend
+func (t *visibilityQueryTranslator) searchAttribute(colName *sqlparser.ColName) (string, types.IndexedValueType, error) {
+	key := strings.TrimPrefix(colName.Name.String(), definition.Attr+".")
+	fieldType, ok := t.validSearchAttributes[key]
+	if !ok {
+		return "", 0, fmt.Errorf("invalid search attribute %q", key)
+	}
+	if !searchAttributeKeyRegex.MatchString(key) {
+		return "", 0, fmt.Errorf("unsupported search attribute %q", key)
+	}
+	return key, common.ConvertIndexedValueTypeToInternalType(fieldType, t.logger), nil
+}
+
+// column returns the expression to read a search attribute
+func (t *visibilityQueryTranslator) column(key string, valueType types.IndexedValueType) string {
+	if column, ok := visibilitySystemColumns[key]; ok {
+		return column
+	}
+	switch valueType {
+	case types.IndexedValueTypeInt, types.IndexedValueTypeBool, types.IndexedValueTypeDatetime:
+		return t.dialect.IntSearchAttribute(key)
+	case types.IndexedValueTypeDouble:
+		return t.dialect.DoubleSearchAttribute(key)
+	default:
+		return t.dialect.TextSearchAttribute(key)
+	}
+}
+
+// parseValue converts a value of the query into the argument compared with the search attribute.
+// Custom bool and datetime search attributes are compared the way they are stored, see encodeVisibilitySearchAttributes
+func (t *visibilityQueryTranslator) parseValue(key string, valueType types.IndexedValueType, expr sqlparser.Expr) (interface{}, error) {
+	var val string
+	switch expr := expr.(type) {
+	case *sqlparser.SQLVal:
+		val = string(expr.Val)
+	case sqlparser.BoolVal:
+		val = strconv.FormatBool(bool(expr))
+	default:
+		return nil, fmt.Errorf("invalid value of search attribute %q", key)
+	}
+
+	_, isSystemColumn := visibilitySystemColumns[key]
+	switch {
+	case visibilityTimeColumns[key]:
+		nanos, err := parseVisibilityTime(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return time.Unix(0, nanos).UTC(), nil
+	case key == definition.CloseStatus:
+		if status, err := strconv.ParseInt(val, 10, 32); err == nil {
+			return int32(status), nil
+		}
+		var status types.WorkflowExecutionCloseStatus
+		if err := status.UnmarshalText([]byte(val)); err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return int32(status), nil
+	case key == definition.ExecutionStatus:
+		if status, err := strconv.ParseInt(val, 10, 32); err == nil {
+			return int32(status), nil
+		}
+		var status types.WorkflowExecutionStatus
+		if err := status.UnmarshalText([]byte(val)); err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return int32(status), nil
+	}
+
+	switch valueType {
+	case types.IndexedValueTypeInt:
+		res, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return res, nil
+	case types.IndexedValueTypeDouble:
+		res, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return res, nil
+	case types.IndexedValueTypeBool:
+		res, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		if isSystemColumn {
+			return res, nil
+		}
+		if res {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case types.IndexedValueTypeDatetime:
+		res, err := parseVisibilityTime(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of search attribute %q: %v", val, key, err)
+		}
+		return res, nil
+	default:
+		return val, nil
+	}
+}
+
+// parseVisibilityTime parses a time in unix nanoseconds or in RFC3339 format into unix nanoseconds
+func parseVisibilityTime(val string) (int64, error) {
+	if nanos, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return nanos, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, val)
+	if err != nil {
+		return 0, err
+	}
+	return t.UnixNano(), nil
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/types"
+)
+
+type testVisibilityQueryDialect struct{}
+
+func (testVisibilityQueryDialect) IntSearchAttribute(key string) string {
+	return "INT(" + key + ")"
+}
+
+func (testVisibilityQueryDialect) DoubleSearchAttribute(key string) string {
+	return "DOUBLE(" + key + ")"
+}
+
+func (testVisibilityQueryDialect) TextSearchAttribute(key string) string {
+	return "TEXT(" + key + ")"
+}
+
+func (testVisibilityQueryDialect) KeywordSearchAttributeContains(key string) string {
+	return "CONTAINS(" + key + ", ?)"
+}
+
+func (testVisibilityQueryDialect) SearchAttributeIndex(key string, valueType types.IndexedValueType) string {
+	return ""
+}
+
+func TestVisibilityQueryTranslator(t *testing.T) {
+	startTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := map[string]struct {
+		query         string
+		wantCondition string
+		wantArgs      []interface{}
+		wantOrderBy   string
+		wantErr       string
+	}{
+		"empty query": {
+			query: "",
+		},
+		"system keys": {
+			query:         "WorkflowID = 'wid' and WorkflowType != 'type' and HistoryLength > 10",
+			wantCondition: "((workflow_id = ? AND workflow_type_name != ?) AND history_length > ?)",
+			wantArgs:      []interface{}{"wid", "type", int64(10)},
+		},
+		"time keys in nanoseconds and RFC3339": {
+			query:         "StartTime >= 1767323045000000000 or CloseTime < '2026-01-02T03:04:05Z'",
+			wantCondition: "(start_time >= ? OR close_time < ?)",
+			wantArgs:      []interface{}{startTime, startTime},
+		},
+		"close and execution status by name and value": {
+			query:         "CloseStatus = 'TIMED_OUT' and ExecutionStatus = 1",
+			wantCondition: "(close_status = ? AND execution_status = ?)",
+			wantArgs:      []interface{}{int32(5), int32(1)},
+		},
+		"system bool key": {
+			query:         "IsCron = true",
+			wantCondition: "is_cron = ?",
+			wantArgs:      []interface{}{true},
+		},
+		"missing values": {
+			query:         "CloseTime = missing and CustomIntField != missing",
+			wantCondition: "(close_time IS NULL AND INT(CustomIntField) IS NOT NULL)",
+		},
+		"custom keys prefixed by the query validator": {
+			query:         "`Attr.CustomIntField` <= 5 and `Attr.CustomDoubleField` > 1.5",
+			wantCondition: "(INT(CustomIntField) <= ? AND DOUBLE(CustomDoubleField) > ?)",
+			wantArgs:      []interface{}{int64(5), 1.5},
+		},
+		"custom keyword key": {
+			query:         "CustomKeywordField = 'a' or CustomKeywordField != 'b'",
+			wantCondition: "(CONTAINS(CustomKeywordField, ?) OR (TEXT(CustomKeywordField) IS NULL OR NOT CONTAINS(CustomKeywordField, ?)))",
+			wantArgs:      []interface{}{"a", "b"},
+		},
+		"custom keyword key in list": {
+			query:         "CustomKeywordField in ('a', 'b')",
+			wantCondition: "(CONTAINS(CustomKeywordField, ?) OR CONTAINS(CustomKeywordField, ?))",
+			wantArgs:      []interface{}{"a", "b"},
+		},
+		"custom string key": {
+			query:         "CustomStringField = 'text'",
+			wantCondition: "TEXT(CustomStringField) LIKE ?",
+			wantArgs:      []interface{}{"%text%"},
+		},
+		"custom bool and datetime keys": {
+			query:         "CustomBoolField = 'true' and CustomDatetimeField between '2026-01-02T03:04:05Z' and 1767323046000000000",
+			wantCondition: "(INT(CustomBoolField) = ? AND INT(CustomDatetimeField) BETWEEN ? AND ?)",
+			wantArgs:      []interface{}{int64(1), startTime.UnixNano(), int64(1767323046000000000)},
+		},
+		"custom key not equal includes missing values": {
+			query:         "CustomIntField != 1",
+			wantCondition: "(INT(CustomIntField) IS NULL OR INT(CustomIntField) != ?)",
+			wantArgs:      []interface{}{int64(1)},
+		},
+		"system key in list": {
+			query:         "WorkflowType not in ('a', 'b')",
+			wantCondition: "workflow_type_name NOT IN (?, ?)",
+			wantArgs:      []interface{}{"a", "b"},
+		},
+		"order by": {
+			query:         "WorkflowType = 'type' order by CustomIntField desc, StartTime",
+			wantCondition: "workflow_type_name = ?",
+			wantArgs:      []interface{}{"type"},
+			wantOrderBy:   "INT(CustomIntField) DESC, start_time ASC, run_id",
+		},
+		"just order by": {
+			query:       "order by CloseTime desc",
+			wantOrderBy: "close_time DESC, run_id",
+		},
+		"invalid search attribute": {
+			query:   "UnknownField = 1",
+			wantErr: `invalid search attribute "UnknownField"`,
+		},
+		"invalid value": {
+			query:   "CustomIntField = 'abc'",
+			wantErr: `invalid value "abc" of search attribute "CustomIntField"`,
+		},
+		"invalid missing operator": {
+			query:   "CloseTime > missing",
+			wantErr: `invalid operator ">" for missing value of search attribute "CloseTime"`,
+		},
+		"invalid like operator": {
+			query:   "CustomIntField like '1%'",
+			wantErr: `invalid operator "like" for search attribute "CustomIntField"`,
+		},
+		"invalid where clause": {
+			query:   "not WorkflowID = 'wid'",
+			wantErr: "invalid where clause",
+		},
+		"invalid query": {
+			query:   "WorkflowID = ",
+			wantErr: "invalid query",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			translator := newVisibilityQueryTranslator(testVisibilityQueryDialect{}, definition.GetDefaultIndexedKeys(), testlogger.New(t))
+			res, err := translator.translate(tc.query)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantCondition, res.condition)
+			assert.Equal(t, tc.wantArgs, res.args)
+			assert.Equal(t, tc.wantOrderBy, res.orderBy)
+		})
+	}
+}
@@ -21,6 +21,7 @@
 package sql
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -31,7 +32,10 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
 	"github.com/uber/cadence/common/types"
@@ -41,25 +45,33 @@ import (
 type (
 	sqlVisibilityStore struct {
 		sqlStore
+		validSearchAttributes dynamicproperties.MapPropertyFn
 	}
 
 	visibilityPageToken struct {
 		Time  time.Time
 		RunID string
+		// Offset is only used by queries with a custom order
+		Offset int `json:",omitempty"`
 	}
 )
 
 // NewSQLVisibilityStore creates an instance of ExecutionStore
-func NewSQLVisibilityStore(cfg config.SQL, logger log.Logger) (p.VisibilityStore, error) {
+func NewSQLVisibilityStore(cfg config.SQL, logger log.Logger, dc *p.DynamicConfiguration) (p.VisibilityStore, error) {
 	db, err := NewSQLDB(&cfg)
 	if err != nil {
 		return nil, err
 	}
+	validSearchAttributes := dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys())
+	if dc != nil && dc.ValidSearchAttributes != nil {
+		validSearchAttributes = dc.ValidSearchAttributes
+	}
 	return &sqlVisibilityStore{
 		sqlStore: sqlStore{
 			db:     db,
 			logger: logger,
 		},
+		validSearchAttributes: validSearchAttributes,
 	}, nil
 }
 
@@ -67,7 +79,11 @@ func (s *sqlVisibilityStore) RecordWorkflowExecutionStarted(
 	ctx context.Context,
 	request *p.InternalRecordWorkflowExecutionStartedRequest,
 ) error {
-	_, err := s.db.InsertIntoVisibility(ctx, &sqlplugin.VisibilityRow{
+	searchAttributes, err := s.encodeSearchAttributes(request.SearchAttributes)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.InsertIntoVisibility(ctx, &sqlplugin.VisibilityRow{
 		DomainID:               request.DomainUUID,
 		WorkflowID:             request.WorkflowID,
 		RunID:                  request.RunID,
@@ -83,6 +99,7 @@ func (s *sqlVisibilityStore) RecordWorkflowExecutionStarted(
 		ShardID:                request.ShardID,
 		ExecutionStatus:        int32(request.ExecutionStatus),
 		ScheduledExecutionTime: request.ScheduledExecutionTime,
+		SearchAttributes:       searchAttributes,
 	})
 
 	if err != nil {
@@ -114,6 +131,10 @@ func (s *sqlVisibilityStore) RecordWorkflowExecutionClosed(
 		executionStatus = types.WorkflowExecutionStatusTimedOut
 	}
 
+	searchAttributes, err := s.encodeSearchAttributes(request.SearchAttributes)
+	if err != nil {
+		return err
+	}
 	result, err := s.db.ReplaceIntoVisibility(ctx, &sqlplugin.VisibilityRow{
 		DomainID:               request.DomainUUID,
 		WorkflowID:             request.WorkflowID,
@@ -133,6 +154,7 @@ func (s *sqlVisibilityStore) RecordWorkflowExecutionClosed(
 		ShardID:                request.ShardID,
 		ExecutionStatus:        int32(executionStatus),
 		ScheduledExecutionTime: request.ScheduledExecutionTime,
+		SearchAttributes:       searchAttributes,
 	})
 	if err != nil {
 		return convertCommonErrors(s.db, "RecordWorkflowExecutionClosed", "", err)
@@ -160,13 +182,52 @@ func (s *sqlVisibilityStore) RecordWorkflowExecutionUninitialized(
 }
 
 func (s *sqlVisibilityStore) UpsertWorkflowExecution(
-	_ context.Context,
+	ctx context.Context,
 	request *p.InternalUpsertWorkflowExecutionRequest,
 ) error {
 	if p.IsNopUpsertWorkflowRequest(request) {
 		return nil
 	}
-	return p.ErrVisibilityOperationNotSupported
+	searchAttributes, err := s.encodeSearchAttributes(request.SearchAttributes)
+	if err != nil {
+		return err
+	}
+	row := &sqlplugin.VisibilityRow{
+		DomainID:               request.DomainUUID,
+		WorkflowID:             request.WorkflowID,
+		RunID:                  request.RunID,
+		StartTime:              request.StartTimestamp,
+		ExecutionTime:          request.ExecutionTimestamp,
+		WorkflowTypeName:       request.WorkflowTypeName,
+		Memo:                   request.Memo.Data,
+		Encoding:               string(request.Memo.GetEncoding()),
+		IsCron:                 request.IsCron,
+		CronSchedule:           request.CronSchedule,
+		NumClusters:            request.NumClusters,
+		UpdateTime:             request.UpdateTimestamp,
+		ShardID:                int16(request.ShardID),
+		ExecutionStatus:        int32(request.ExecutionStatus),
+		ScheduledExecutionTime: time.Unix(0, request.ScheduledExecutionTimestamp),
+		SearchAttributes:       searchAttributes,
+	}
+	result, err := s.db.UpdateVisibility(ctx, row)
+	if err != nil {
+		return convertCommonErrors(s.db, "UpsertWorkflowExecution", "", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return &types.InternalServiceError{
+			Message: fmt.Sprintf("UpsertWorkflowExecution rowsAffected error: %v", err),
+		}
+	}
+	if rowsAffected == 0 {
+		// the started record may not be written yet. The insert is ignored if the workflow
+		// has been recorded in the meantime, including when it's closed
+		if _, err := s.db.InsertIntoVisibility(ctx, row); err != nil {
+			return convertCommonErrors(s.db, "UpsertWorkflowExecution", "", err)
+		}
+	}
+	return nil
 }
 
 func (s *sqlVisibilityStore) ListOpenWorkflowExecutions(
@@ -338,24 +399,105 @@ func (s *sqlVisibilityStore) DeleteUninitializedWorkflowExecution(
 }
 
 func (s *sqlVisibilityStore) ListWorkflowExecutions(
-	_ context.Context,
-	_ *p.ListWorkflowExecutionsByQueryRequest,
+	ctx context.Context,
+	request *p.ListWorkflowExecutionsByQueryRequest,
 ) (*p.InternalListWorkflowExecutionsResponse, error) {
-	return nil, p.ErrVisibilityOperationNotSupported
+	return s.listWorkflowExecutionsByQuery(ctx, "ListWorkflowExecutions", request)
 }
 
 func (s *sqlVisibilityStore) ScanWorkflowExecutions(
-	_ context.Context,
-	_ *p.ListWorkflowExecutionsByQueryRequest,
+	ctx context.Context,
+	request *p.ListWorkflowExecutionsByQueryRequest,
 ) (*p.InternalListWorkflowExecutionsResponse, error) {
-	return nil, p.ErrVisibilityOperationNotSupported
+	return s.listWorkflowExecutionsByQuery(ctx, "ScanWorkflowExecutions", request)
 }
 
 func (s *sqlVisibilityStore) CountWorkflowExecutions(
-	_ context.Context,
-	_ *p.CountWorkflowExecutionsRequest,
+	ctx context.Context,
+	request *p.CountWorkflowExecutionsRequest,
 ) (*p.CountWorkflowExecutionsResponse, error) {
-	return nil, p.ErrVisibilityOperationNotSupported
+	query, err := s.translateQuery(request.Query)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.db.CountFromVisibilityByQuery(ctx, &sqlplugin.VisibilityQueryFilter{
+		DomainID:  request.DomainUUID,
+		Condition: query.condition,
+		Args:      query.args,
+	})
+	if err != nil {
+		return nil, convertCommonErrors(s.db, "CountWorkflowExecutions", "", err)
+	}
+	return &p.CountWorkflowExecutionsResponse{Count: count}, nil
+}
+
+func (s *sqlVisibilityStore) listWorkflowExecutionsByQuery(
+	ctx context.Context,
+	opName string,
+	request *p.ListWorkflowExecutionsByQueryRequest,
+) (*p.InternalListWorkflowExecutionsResponse, error) {
+	query, err := s.translateQuery(request.Query)
+	if err != nil {
+		return nil, err
+	}
+	filter := &sqlplugin.VisibilityQueryFilter{
+		DomainID:  request.DomainUUID,
+		Condition: query.condition,
+		Args:      query.args,
+		OrderBy:   query.orderBy,
+		PageSize:  request.PageSize,
+	}
+	if len(request.NextPageToken) > 0 {
+		token, err := s.deserializePageToken(request.NextPageToken)
+		if err != nil {
+			return nil, &types.BadRequestError{Message: fmt.Sprintf("invalid next page token: %v", err)}
+		}
+		if query.orderBy == "" {
+			// the rows are ordered by start_time DESC, run_id
+			condition := "(start_time < ? OR (start_time = ? AND run_id > ?))"
+			if filter.Condition != "" {
+				condition = filter.Condition + " AND " + condition
+			}
+			filter.Condition = condition
+			filter.Args = append(filter.Args, token.Time, token.Time, token.RunID)
+		} else {
+			filter.Offset = token.Offset
+		}
+	}
+
+	rows, err := s.db.SelectFromVisibilityByQuery(ctx, filter)
+	if err != nil {
+		return nil, convertCommonErrors(s.db, opName, "", err)
+	}
+	infos := make([]*p.InternalVisibilityWorkflowExecutionInfo, len(rows))
+	for i := range rows {
+		infos[i] = s.rowToInfo(&rows[i])
+	}
+	var nextPageToken []byte
+	if len(rows) > 0 && len(rows) == request.PageSize {
+		lastRow := rows[len(rows)-1]
+		nextPageToken, err = s.serializePageToken(&visibilityPageToken{
+			Time:   lastRow.StartTime,
+			RunID:  lastRow.RunID,
+			Offset: filter.Offset + len(rows),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &p.InternalListWorkflowExecutionsResponse{
+		Executions:    infos,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+func (s *sqlVisibilityStore) translateQuery(query string) (*translatedVisibilityQuery, error) {
+	translator := newVisibilityQueryTranslator(s.db.VisibilityQueryDialect(), s.validSearchAttributes(), s.logger)
+	res, err := translator.translate(query)
+	if err != nil {
+		return nil, &types.BadRequestError{Message: err.Error()}
+	}
+	return res, nil
 }
 
 func (s *sqlVisibilityStore) rowToInfo(row *sqlplugin.VisibilityRow) *p.InternalVisibilityWorkflowExecutionInfo {
@@ -383,6 +525,14 @@ func (s *sqlVisibilityStore) rowToInfo(row *sqlplugin.VisibilityRow) *p.Internal
 		info.CloseTime = *row.CloseTime
 		info.HistoryLength = *row.HistoryLength
 	}
+	if len(row.SearchAttributes) > 0 {
+		searchAttributes, err := s.decodeSearchAttributes(row.SearchAttributes)
+		if err != nil {
+			s.logger.Error("failed to decode visibility search attributes", tag.WorkflowID(row.WorkflowID), tag.WorkflowRunID(row.RunID), tag.Error(err))
+		} else {
+			info.SearchAttributes = searchAttributes
+		}
+	}
 	return info
 }
 
@@ -437,3 +587,72 @@ func (s *sqlVisibilityStore) serializePageToken(token *visibilityPageToken) ([]b
 	data, err := json.Marshal(token)
 	return data, err
 }
+
+// encodeSearchAttributes encodes the search attributes into a JSON object stored in search_attributes column.
+// Datetime values are stored as unix nanoseconds and bool values as 0 or 1 so that they can be compared
+// as integers by all databases
+func (s *sqlVisibilityStore) encodeSearchAttributes(searchAttributes map[string][]byte) ([]byte, error) {
+	if len(searchAttributes) == 0 {
+		return nil, nil
+	}
+	validSearchAttributes := s.validSearchAttributes()
+	encoded := make(map[string]interface{}, len(searchAttributes))
+	for key, data := range searchAttributes {
+		encoded[key] = json.RawMessage(data)
+		fieldType, ok := validSearchAttributes[key]
+		if !ok {
+			continue
+		}
+		switch common.ConvertIndexedValueTypeToInternalType(fieldType, s.logger) {
+		case types.IndexedValueTypeDatetime:
+			var val interface{}
+			if err := json.Unmarshal(data, &val); err != nil {
+				return nil, &types.BadRequestError{Message: fmt.Sprintf("invalid value of search attribute %q: %v", key, err)}
+			}
+			if str, ok := val.(string); ok {
+				t, err := time.Parse(time.RFC3339Nano, str)
+				if err != nil {
+					return nil, &types.BadRequestError{Message: fmt.Sprintf("invalid value of search attribute %q: %v", key, err)}
+				}
+				encoded[key] = t.UnixNano()
+			}
+		case types.IndexedValueTypeBool:
+			var val bool
+			if err := json.Unmarshal(data, &val); err != nil {
+				return nil, &types.BadRequestError{Message: fmt.Sprintf("invalid value of search attribute %q: %v", key, err)}
+			}
+			encoded[key] = 0
+			if val {
+				encoded[key] = 1
+			}
+		}
+	}
+	return json.Marshal(encoded)
+}
+
+// decodeSearchAttributes reverts encodeSearchAttributes
+func (s *sqlVisibilityStore) decodeSearchAttributes(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var searchAttributes map[string]interface{}
+	if err := decoder.Decode(&searchAttributes); err != nil {
+		return nil, err
+	}
+	validSearchAttributes := s.validSearchAttributes()
+	for key, val := range searchAttributes {
+		number, ok := val.(json.Number)
+		fieldType, isValid := validSearchAttributes[key]
+		if !ok || !isValid {
+			continue
+		}
+		switch common.ConvertIndexedValueTypeToInternalType(fieldType, s.logger) {
+		case types.IndexedValueTypeDatetime:
+			if nanos, err := number.Int64(); err == nil {
+				searchAttributes[key] = time.Unix(0, nanos).UTC().Format(time.RFC3339Nano)
+			}
+		case types.IndexedValueTypeBool:
+			searchAttributes[key] = number.String() != "0"
+		}
+	}
+	return searchAttributes, nil
+}
@@ -0,0 +1,267 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/definition"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+	"github.com/uber/cadence/common/types"
+)
+
+func newTestSQLVisibilityStore(t *testing.T, db sqlplugin.DB) *sqlVisibilityStore {
+	return &sqlVisibilityStore{
+		sqlStore:              sqlStore{db: db, logger: testlogger.New(t)},
+		validSearchAttributes: dynamicproperties.GetMapPropertyFn(definition.GetDefaultIndexedKeys()),
+	}
+}
+
+func TestSQLVisibilityStore_ListWorkflowExecutions(t *testing.T) {
+	startTime := time.Unix(0, 1000).UTC()
+	row := sqlplugin.VisibilityRow{
+		DomainID:         "domain-id",
+		WorkflowID:       "wid",
+		RunID:            "rid",
+		WorkflowTypeName: "type",
+		StartTime:        startTime,
+		ExecutionTime:    startTime,
+		Encoding:         string(constants.EncodingTypeThriftRW),
+		SearchAttributes: []byte(`{"CustomKeywordField":["a","b"],"CustomBoolField":1,"CustomDatetimeField":1000}`),
+	}
+
+	tests := map[string]struct {
+		request       *persistence.ListWorkflowExecutionsByQueryRequest
+		mockSetup     func(*sqlplugin.MockDB)
+		wantNextToken *visibilityPageToken
+		wantErr       bool
+	}{
+		"first page with the default order": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: "domain-id",
+				PageSize:   1,
+				Query:      "WorkflowType = 'type'",
+			},
+			mockSetup: func(db *sqlplugin.MockDB) {
+				db.EXPECT().SelectFromVisibilityByQuery(gomock.Any(), &sqlplugin.VisibilityQueryFilter{
+					DomainID:  "domain-id",
+					Condition: "workflow_type_name = ?",
+					Args:      []interface{}{"type"},
+					PageSize:  1,
+				}).Return([]sqlplugin.VisibilityRow{row}, nil)
+			},
+			wantNextToken: &visibilityPageToken{Time: startTime, RunID: "rid", Offset: 1},
+		},
+		"next page with the default order": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID:    "domain-id",
+				PageSize:      2,
+				Query:         "WorkflowType = 'type'",
+				NextPageToken: []byte(`{"Time":"1970-01-01T00:00:00.000001Z","RunID":"rid","Offset":1}`),
+			},
+			mockSetup: func(db *sqlplugin.MockDB) {
+				db.EXPECT().SelectFromVisibilityByQuery(gomock.Any(), &sqlplugin.VisibilityQueryFilter{
+					DomainID:  "domain-id",
+					Condition: "workflow_type_name = ? AND (start_time < ? OR (start_time = ? AND run_id > ?))",
+					Args:      []interface{}{"type", startTime, startTime, "rid"},
+					PageSize:  2,
+				}).Return([]sqlplugin.VisibilityRow{row}, nil)
+			},
+		},
+		"next page with a custom order": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID:    "domain-id",
+				PageSize:      1,
+				Query:         "order by CloseTime",
+				NextPageToken: []byte(`{"Time":"1970-01-01T00:00:00.000001Z","RunID":"rid","Offset":3}`),
+			},
+			mockSetup: func(db *sqlplugin.MockDB) {
+				db.EXPECT().SelectFromVisibilityByQuery(gomock.Any(), &sqlplugin.VisibilityQueryFilter{
+					DomainID: "domain-id",
+					OrderBy:  "close_time ASC, run_id",
+					PageSize: 1,
+					Offset:   3,
+				}).Return([]sqlplugin.VisibilityRow{row}, nil)
+			},
+			wantNextToken: &visibilityPageToken{Time: startTime, RunID: "rid", Offset: 4},
+		},
+		"invalid query": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: "domain-id",
+				PageSize:   1,
+				Query:      "UnknownField = 'a'",
+			},
+			mockSetup: func(db *sqlplugin.MockDB) {},
+			wantErr:   true,
+		},
+		"database error": {
+			request: &persistence.ListWorkflowExecutionsByQueryRequest{
+				DomainUUID: "domain-id",
+				PageSize:   1,
+			},
+			mockSetup: func(db *sqlplugin.MockDB) {
+				db.EXPECT().SelectFromVisibilityByQuery(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				db.EXPECT().IsNotFoundError(gomock.Any()).Return(false)
+				db.EXPECT().IsTimeoutError(gomock.Any()).Return(false)
+				db.EXPECT().IsThrottlingError(gomock.Any()).Return(false)
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			db := sqlplugin.NewMockDB(ctrl)
+			db.EXPECT().VisibilityQueryDialect().Return(testVisibilityQueryDialect{}).AnyTimes()
+			tc.mockSetup(db)
+			store := newTestSQLVisibilityStore(t, db)
+
+			resp, err := store.ListWorkflowExecutions(context.Background(), tc.request)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, resp.Executions, 1)
+			assert.Equal(t, "wid", resp.Executions[0].WorkflowID)
+			assert.Equal(t, map[string]interface{}{
+				"CustomKeywordField":  []interface{}{"a", "b"},
+				"CustomBoolField":     true,
+				"CustomDatetimeField": "1970-01-01T00:00:00.000001Z",
+			}, resp.Executions[0].SearchAttributes)
+			if tc.wantNextToken == nil {
+				assert.Nil(t, resp.NextPageToken)
+				return
+			}
+			token, err := store.deserializePageToken(resp.NextPageToken)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantNextToken, token)
+		})
+	}
+}
+
+func TestSQLVisibilityStore_CountWorkflowExecutions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	db := sqlplugin.NewMockDB(ctrl)
+	db.EXPECT().VisibilityQueryDialect().Return(testVisibilityQueryDialect{})
+	db.EXPECT().CountFromVisibilityByQuery(gomock.Any(), &sqlplugin.VisibilityQueryFilter{
+		DomainID:  "domain-id",
+		Condition: "close_status IS NULL",
+	}).Return(int64(10), nil)
+	store := newTestSQLVisibilityStore(t, db)
+
+	resp, err := store.CountWorkflowExecutions(context.Background(), &persistence.CountWorkflowExecutionsRequest{
+		DomainUUID: "domain-id",
+		Query:      "CloseStatus = missing order by StartTime desc",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), resp.Count)
+}
+
+func TestSQLVisibilityStore_UpsertWorkflowExecution(t *testing.T) {
+	request := &persistence.InternalUpsertWorkflowExecutionRequest{
+		DomainUUID:       "domain-id",
+		WorkflowID:       "wid",
+		RunID:            "rid",
+		WorkflowTypeName: "type",
+		Memo:             persistence.NewDataBlob([]byte("memo"), constants.EncodingTypeThriftRW),
+		SearchAttributes: map[string][]byte{
+			definition.CustomIntField:      []byte(`1`),
+			definition.CustomBoolField:     []byte(`true`),
+			definition.CustomDatetimeField: []byte(`"1970-01-01T00:00:00.000001Z"`),
+		},
+	}
+	wantSearchAttributes := `{"CustomBoolField":1,"CustomDatetimeField":1000,"CustomIntField":1}`
+
+	tests := map[string]struct {
+		mockSetup func(*sqlplugin.MockDB)
+		wantErr   bool
+	}{
+		"updated": {
+			mockSetup: func(db *sqlplugin.MockDB) {
+				db.EXPECT().UpdateVisibility(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, row *sqlplugin.VisibilityRow) (sql.Result, error) {
+						assert.JSONEq(t, wantSearchAttributes, string(row.SearchAttributes))
+						assert.Equal(t, []byte("memo"), row.Memo)
+						return &sqlResult{rowsAffected: 1}, nil
+					})
+			},
+		},
+		"inserted when not recorded yet": {
+			mockSetup: func(db *sqlplugin.MockDB) {
+				db.EXPECT().UpdateVisibility(gomock.Any(), gomock.Any()).Return(&sqlResult{rowsAffected: 0}, nil)
+				db.EXPECT().InsertIntoVisibility(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, row *sqlplugin.VisibilityRow) (sql.Result, error) {
+						assert.JSONEq(t, wantSearchAttributes, string(row.SearchAttributes))
+						assert.Equal(t, "type", row.WorkflowTypeName)
+						return &sqlResult{rowsAffected: 1}, nil
+					})
+			},
+		},
+		"database error": {
+			mockSetup: func(db *sqlplugin.MockDB) {
+				db.EXPECT().UpdateVisibility(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+				db.EXPECT().IsNotFoundError(gomock.Any()).Return(false)
+				db.EXPECT().IsTimeoutError(gomock.Any()).Return(false)
+				db.EXPECT().IsThrottlingError(gomock.Any()).Return(false)
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			db := sqlplugin.NewMockDB(ctrl)
+			tc.mockSetup(db)
+			store := newTestSQLVisibilityStore(t, db)
+
+			err := store.UpsertWorkflowExecution(context.Background(), request)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSQLVisibilityStore_EncodeSearchAttributes_InvalidValue(t *testing.T) {
+	store := newTestSQLVisibilityStore(t, nil)
+	_, err := store.encodeSearchAttributes(map[string][]byte{
+		definition.CustomDatetimeField: []byte(`"yesterday"`),
+	})
+	var badRequest *types.BadRequestError
+	assert.ErrorAs(t, err, &badRequest)
+}
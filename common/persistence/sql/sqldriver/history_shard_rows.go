@@ -0,0 +1,331 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sqldriver
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+)
+
+// The helpers below work on the rows of a history shard regardless of the table schema, so that every plugin can
+// move history shards between database shards with the same code. Queries are rebound to the bindvar of the plugin.
+
+// CopyHistoryShardRows makes the rows of a history shard in the target database shard match the rows in the source one.
+// Only rows which differ are written, so copying again after a first copy only writes the rows changed in between
+func CopyHistoryShardRows(
+	ctx context.Context,
+	driver Driver,
+	tables []sqlplugin.HistoryShardTable,
+	historyShardID int,
+	sourceDBShardID int,
+	targetDBShardID int,
+	progress func(table string, rows int),
+) error {
+	for _, table := range tables {
+		rows, err := copyHistoryShardTable(ctx, driver, table, historyShardID, sourceDBShardID, targetDBShardID)
+		if err != nil {
+			return fmt.Errorf("failed to copy %v rows of history shard %v: %w", table.Name, historyShardID, err)
+		}
+		if progress != nil {
+			progress(table.Name, rows)
+		}
+	}
+	return nil
+}
+
+// ChecksumHistoryShardRows returns the number of rows and an order independent checksum of them per table
+func ChecksumHistoryShardRows(
+	ctx context.Context,
+	driver Driver,
+	tables []sqlplugin.HistoryShardTable,
+	historyShardID int,
+	dbShardID int,
+) (map[string]sqlplugin.HistoryShardTableChecksum, error) {
+	result := make(map[string]sqlplugin.HistoryShardTableChecksum, len(tables))
+	for _, table := range tables {
+		checksum, err := checksumHistoryShardTable(ctx, driver, table.Name, historyShardID, dbShardID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to checksum %v rows of history shard %v: %w", table.Name, historyShardID, err)
+		}
+		result[table.Name] = checksum
+	}
+	return result, nil
+}
+
+// DeleteHistoryShardRows deletes the rows of a history shard from a database shard
+func DeleteHistoryShardRows(
+	ctx context.Context,
+	driver Driver,
+	tables []sqlplugin.HistoryShardTable,
+	historyShardID int,
+	dbShardID int,
+) error {
+	for _, table := range tables {
+		tx, err := driver.BeginTxx(ctx, dbShardID, nil)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, tx.Rebind(deleteHistoryShardRowsQuery(table.Name)), historyShardID); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to delete %v rows of history shard %v: %w", table.Name, historyShardID, err)
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FenceHistoryShard increments the range ID of a history shard in a database shard and returns the new range ID
+func FenceHistoryShard(ctx context.Context, driver Driver, historyShardID int, dbShardID int) (int64, error) {
+	tx, err := driver.BeginTxx(ctx, dbShardID, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, tx.Rebind("UPDATE shards SET range_id = range_id + 1 WHERE shard_id = ?"), historyShardID)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if rowsAffected != 1 {
+		return 0, fmt.Errorf("history shard %v not found in database shard %v", historyShardID, dbShardID)
+	}
+	var rangeID int64
+	if err := tx.GetContext(ctx, &rangeID, tx.Rebind("SELECT range_id FROM shards WHERE shard_id = ?"), historyShardID); err != nil {
+		return 0, err
+	}
+	return rangeID, tx.Commit()
+}
+
+// copyHistoryShardTable compares the rows of the source and target database shards by primary key and only
+// writes the rows which are missing or different in the target, and deletes the ones which are gone from the source.
+// Both sides are read in full, but rows which did not change since a previous copy are not written again.
+// It returns the number of rows written or deleted
+func copyHistoryShardTable(ctx context.Context, driver Driver, table sqlplugin.HistoryShardTable, historyShardID, sourceDBShardID, targetDBShardID int) (int, error) {
+	sourceTx, err := driver.BeginTxx(ctx, sourceDBShardID, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer sourceTx.Rollback()
+	targetTx, err := driver.BeginTxx(ctx, targetDBShardID, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer targetTx.Rollback()
+
+	existing, err := readHistoryShardRowHashes(ctx, targetTx, table, historyShardID)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := sourceTx.QueryxContext(ctx, sourceTx.Rebind(selectHistoryShardRowsQuery(table.Name)), historyShardID)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	keyIndexes, err := primaryKeyIndexes(table, columns)
+	if err != nil {
+		return 0, err
+	}
+	insertQuery := targetTx.Rebind(fmt.Sprintf(
+		"INSERT INTO %v (%v) VALUES (%v)",
+		table.Name,
+		strings.Join(columns, ", "),
+		strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "),
+	))
+	deleteQuery := targetTx.Rebind(deleteHistoryShardRowQuery(table))
+
+	count := 0
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return 0, err
+		}
+		key := primaryKey(values, keyIndexes)
+		if target, ok := existing[rowKey(key)]; ok {
+			delete(existing, rowKey(key))
+			if target.hash == hashRow(values) {
+				continue
+			}
+			if _, err := targetTx.ExecContext(ctx, deleteQuery, append([]interface{}{historyShardID}, key...)...); err != nil {
+				return 0, err
+			}
+		}
+		if _, err := targetTx.ExecContext(ctx, insertQuery, values...); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	// whatever is left in the target was deleted from the source since the previous copy
+	for _, target := range existing {
+		if _, err := targetTx.ExecContext(ctx, deleteQuery, append([]interface{}{historyShardID}, target.key...)...); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	return count, targetTx.Commit()
+}
+
+type historyShardRowHash struct {
+	key  []interface{}
+	hash uint64
+}
+
+// readHistoryShardRowHashes reads the primary key and the hash of every row of a history shard in a table
+func readHistoryShardRowHashes(ctx context.Context, tx *sqlx.Tx, table sqlplugin.HistoryShardTable, historyShardID int) (map[string]historyShardRowHash, error) {
+	rows, err := tx.QueryxContext(ctx, tx.Rebind(selectHistoryShardRowsQuery(table.Name)), historyShardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	keyIndexes, err := primaryKeyIndexes(table, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]historyShardRowHash)
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return nil, err
+		}
+		key := primaryKey(values, keyIndexes)
+		result[rowKey(key)] = historyShardRowHash{key: key, hash: hashRow(values)}
+	}
+	return result, rows.Err()
+}
+
+func primaryKeyIndexes(table sqlplugin.HistoryShardTable, columns []string) ([]int, error) {
+	indexes := make([]int, 0, len(table.PrimaryKey))
+	for _, column := range table.PrimaryKey {
+		index := -1
+		for i, c := range columns {
+			if c == column {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("primary key column %v not found in %v table", column, table.Name)
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+func primaryKey(values []interface{}, keyIndexes []int) []interface{} {
+	key := make([]interface{}, 0, len(keyIndexes))
+	for _, index := range keyIndexes {
+		key = append(key, values[index])
+	}
+	return key
+}
+
+// rowKey turns primary key values into a map key
+func rowKey(key []interface{}) string {
+	var sb strings.Builder
+	for _, value := range key {
+		if b, ok := value.([]byte); ok {
+			sb.Write(b)
+		} else {
+			fmt.Fprintf(&sb, "%v", value)
+		}
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+func checksumHistoryShardTable(ctx context.Context, driver Driver, table string, historyShardID, dbShardID int) (sqlplugin.HistoryShardTableChecksum, error) {
+	var checksum sqlplugin.HistoryShardTableChecksum
+	tx, err := driver.BeginTxx(ctx, dbShardID, nil)
+	if err != nil {
+		return checksum, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryxContext(ctx, tx.Rebind(selectHistoryShardRowsQuery(table)), historyShardID)
+	if err != nil {
+		return checksum, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		values, err := rows.SliceScan()
+		if err != nil {
+			return checksum, err
+		}
+		checksum.Rows++
+		// rows are summed up so that the checksum does not depend on the order they are returned in
+		checksum.Checksum += hashRow(values)
+	}
+	return checksum, rows.Err()
+}
+
+func hashRow(values []interface{}) uint64 {
+	h := fnv.New64a()
+	for _, value := range values {
+		if b, ok := value.([]byte); ok {
+			h.Write(b)
+		} else {
+			fmt.Fprintf(h, "%v", value)
+		}
+		h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+func selectHistoryShardRowsQuery(table string) string {
+	return fmt.Sprintf("SELECT * FROM %v WHERE shard_id = ?", table)
+}
+
+func deleteHistoryShardRowsQuery(table string) string {
+	return fmt.Sprintf("DELETE FROM %v WHERE shard_id = ?", table)
+}
+
+func deleteHistoryShardRowQuery(table sqlplugin.HistoryShardTable) string {
+	query := deleteHistoryShardRowsQuery(table.Name)
+	for _, column := range table.PrimaryKey {
+		query += fmt.Sprintf(" AND %v = ?", column)
+	}
+	return query
+}
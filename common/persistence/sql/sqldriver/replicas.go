@@ -0,0 +1,262 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sqldriver
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/uber/cadence/common/config"
+)
+
+const (
+	// DefaultMaxReplicationLag is the replication lag above which a read replica is not read from, unless configured
+	DefaultMaxReplicationLag = 5 * time.Second
+	// DefaultReplicationLagCheckInterval is how often the replication lag of read replicas is checked, unless configured
+	DefaultReplicationLagCheckInterval = 5 * time.Second
+)
+
+type (
+	// ReplicationLagFunc returns how far a read replica is behind its primary database
+	ReplicationLagFunc func(ctx context.Context, db *sqlx.DB) (time.Duration, error)
+
+	// ReadReplicas keeps the connections to the read replicas of every database shard and tracks their replication lag.
+	// A nil ReadReplicas sends every read to the primary databases
+	ReadReplicas struct {
+		replicas      [][]*readReplica // indexed by dbShardID
+		reads         map[string]struct{}
+		maxLag        time.Duration
+		checkInterval time.Duration
+		lagFunc       ReplicationLagFunc
+		next          atomic.Uint64
+		shutdownCh    chan struct{}
+	}
+
+	readReplica struct {
+		db      *sqlx.DB
+		managed bool
+		healthy atomic.Bool
+	}
+
+	// replicaReadDriver sends reads to a read replica which is not lagging behind, and everything else to the primary
+	replicaReadDriver struct {
+		Driver
+		replicas *ReadReplicas
+	}
+)
+
+// CreateReadReplicas connects to the read replicas configured for the database(s). It returns nil if there are none
+func CreateReadReplicas(cfg *config.SQL, createConnFunc CreateSingleDBConn, lagFunc ReplicationLagFunc) (*ReadReplicas, error) {
+	var replicaConfigs [][]config.SQLReadReplica
+	var primaryConfigs []config.SQL
+	if cfg.UseMultipleDatabases {
+		for _, entry := range cfg.MultipleDatabasesConfig {
+			primary := *cfg
+			primary.User = entry.User
+			primary.Password = entry.Password
+			primary.DatabaseName = entry.DatabaseName
+			primaryConfigs = append(primaryConfigs, primary)
+			replicaConfigs = append(replicaConfigs, entry.ReadReplicas)
+		}
+	} else {
+		primaryConfigs = []config.SQL{*cfg}
+		replicaConfigs = [][]config.SQLReadReplica{cfg.ReadReplicas}
+	}
+
+	total := 0
+	for _, replicas := range replicaConfigs {
+		total += len(replicas)
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	r := &ReadReplicas{
+		replicas:      make([][]*readReplica, len(replicaConfigs)),
+		reads:         make(map[string]struct{}),
+		maxLag:        cfg.ReadReplicaRouting.MaxReplicationLag,
+		checkInterval: cfg.ReadReplicaRouting.LagCheckInterval,
+		lagFunc:       lagFunc,
+		shutdownCh:    make(chan struct{}),
+	}
+	if r.maxLag <= 0 {
+		r.maxLag = DefaultMaxReplicationLag
+	}
+	if r.checkInterval <= 0 {
+		r.checkInterval = DefaultReplicationLagCheckInterval
+	}
+	reads := cfg.ReadReplicaRouting.Reads
+	if len(reads) == 0 {
+		reads = []string{config.SQLReplicaReadVisibility, config.SQLReplicaReadHistory, config.SQLReplicaReadDomain}
+	}
+	for _, read := range reads {
+		r.reads[read] = struct{}{}
+	}
+
+	for dbShardID, replicas := range replicaConfigs {
+		for idx, replica := range replicas {
+			replicaCfg := primaryConfigs[dbShardID]
+			replicaCfg.UseMultipleDatabases = false
+			replicaCfg.MultipleDatabasesConfig = nil
+			replicaCfg.ReadReplicas = nil
+			replicaCfg.ConnectAddr = replica.ConnectAddr
+			if replica.User != "" {
+				replicaCfg.User = replica.User
+				replicaCfg.Password = replica.Password
+			}
+			if replica.DatabaseName != "" {
+				replicaCfg.DatabaseName = replica.DatabaseName
+			}
+			xdb, err := createConnFunc(&replicaCfg)
+			if err != nil {
+				r.closeConnections()
+				return nil, fmt.Errorf("got error of %v to connect to read replica %v of %v database", err, idx, dbShardID)
+			}
+			r.replicas[dbShardID] = append(r.replicas[dbShardID], &readReplica{db: xdb, managed: replica.Managed})
+		}
+	}
+	return r, nil
+}
+
+// Start checks the replication lag of the read replicas and keeps checking it in background until Close is called
+func (r *ReadReplicas) Start() {
+	if r == nil {
+		return
+	}
+	r.checkReplicationLag()
+	go func() {
+		ticker := time.NewTicker(r.checkInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.shutdownCh:
+				return
+			case <-ticker.C:
+				r.checkReplicationLag()
+			}
+		}
+	}()
+}
+
+// Close stops checking the replication lag and closes the connections to the read replicas
+func (r *ReadReplicas) Close() error {
+	if r == nil {
+		return nil
+	}
+	close(r.shutdownCh)
+	return r.closeConnections()
+}
+
+// ReadDriver returns the driver to use for the given kind of read, see config.SQLReadReplicaRouting
+func (r *ReadReplicas) ReadDriver(primary Driver, read string) Driver {
+	if r == nil {
+		return primary
+	}
+	if _, ok := r.reads[read]; !ok {
+		return primary
+	}
+	return &replicaReadDriver{
+		Driver:   primary,
+		replicas: r,
+	}
+}
+
+func (r *ReadReplicas) checkReplicationLag() {
+	for _, replicas := range r.replicas {
+		for _, replica := range replicas {
+			if replica.managed {
+				// the database service takes managed replicas out of rotation itself
+				replica.healthy.Store(true)
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), r.checkInterval)
+			lag, err := r.lagFunc(ctx, replica.db)
+			cancel()
+			replica.healthy.Store(err == nil && lag <= r.maxLag)
+		}
+	}
+}
+
+// pick returns a read replica of the database shard which is not lagging behind, or nil if there is none
+func (r *ReadReplicas) pick(dbShardID int) *sqlx.DB {
+	var replicas []*readReplica
+	switch {
+	case len(r.replicas) == 1:
+		// a single database ignores dbShardID, like the singleton driver does
+		replicas = r.replicas[0]
+	case dbShardID >= 0 && dbShardID < len(r.replicas):
+		replicas = r.replicas[dbShardID]
+	}
+	if len(replicas) == 0 {
+		return nil
+	}
+	start := r.next.Add(1)
+	for i := range replicas {
+		replica := replicas[(start+uint64(i))%uint64(len(replicas))]
+		if replica.healthy.Load() {
+			return replica.db
+		}
+	}
+	return nil
+}
+
+func (r *ReadReplicas) closeConnections() error {
+	var err error
+	for _, replicas := range r.replicas {
+		for _, replica := range replicas {
+			if closeErr := replica.db.Close(); closeErr != nil {
+				err = closeErr
+			}
+		}
+	}
+	return err
+}
+
+// GetContext reads from a read replica, falling back to the primary if the replica fails
+func (d *replicaReadDriver) GetContext(ctx context.Context, dbShardID int, dest interface{}, query string, args ...interface{}) error {
+	if db := d.replicas.pick(dbShardID); db != nil {
+		err := db.GetContext(ctx, dest, query, args...)
+		if err == nil || err == sql.ErrNoRows || ctx.Err() != nil {
+			return err
+		}
+	}
+	return d.Driver.GetContext(ctx, dbShardID, dest, query, args...)
+}
+
+// SelectContext reads from a read replica, falling back to the primary if the replica fails
+func (d *replicaReadDriver) SelectContext(ctx context.Context, dbShardID int, dest interface{}, query string, args ...interface{}) error {
+	if db := d.replicas.pick(dbShardID); db != nil {
+		err := db.SelectContext(ctx, dest, query, args...)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		// rows scanned before the failure are appended to dest, so they have to be dropped before reading again
+		slice := reflect.ValueOf(dest).Elem()
+		slice.Set(reflect.Zero(slice.Type()))
+	}
+	return d.Driver.SelectContext(ctx, dbShardID, dest, query, args...)
+}
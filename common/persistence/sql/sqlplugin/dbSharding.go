@@ -21,6 +21,10 @@
 package sqlplugin
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/dgryski/go-farm"
 
 	"github.com/uber/cadence/common/persistence/serialization"
@@ -36,11 +40,174 @@ const (
 	DbAllShards = -2
 )
 
+// This section defines the states of a history shard moving between database shards
+const (
+	// HistoryShardPlacementStateCopying means the rows of the history shard are being copied to the target
+	// database shard, while the history shard is still served from the source one
+	HistoryShardPlacementStateCopying HistoryShardPlacementState = iota + 1
+	// HistoryShardPlacementStateFrozen means the history shard is fenced in the source database shard and
+	// can not be acquired until it is cut over to the target one
+	HistoryShardPlacementStateFrozen
+	// HistoryShardPlacementStateActive means the history shard is served from the target database shard
+	HistoryShardPlacementStateActive
+)
+
+// HistoryShardTables are the tables sharded by history shard ID which every plugin has. history_node and
+// history_tree are sharded by tree ID, so they stay where they are when a history shard is moved
+var HistoryShardTables = []HistoryShardTable{
+	{Name: "shards"},
+	{Name: "executions", PrimaryKey: []string{"domain_id", "workflow_id", "run_id"}},
+	{Name: "current_executions", PrimaryKey: []string{"domain_id", "workflow_id"}},
+	{Name: "buffered_events", PrimaryKey: []string{"id"}},
+	{Name: "activity_info_maps", PrimaryKey: []string{"domain_id", "workflow_id", "run_id", "schedule_id"}},
+	{Name: "timer_info_maps", PrimaryKey: []string{"domain_id", "workflow_id", "run_id", "timer_id"}},
+	{Name: "child_execution_info_maps", PrimaryKey: []string{"domain_id", "workflow_id", "run_id", "initiated_id"}},
+	{Name: "request_cancel_info_maps", PrimaryKey: []string{"domain_id", "workflow_id", "run_id", "initiated_id"}},
+	{Name: "signal_info_maps", PrimaryKey: []string{"domain_id", "workflow_id", "run_id", "initiated_id"}},
+	{Name: "buffered_replication_task_maps", PrimaryKey: []string{"domain_id", "workflow_id", "run_id", "first_event_id"}},
+	{Name: "signals_requested_sets", PrimaryKey: []string{"domain_id", "workflow_id", "run_id", "signal_id"}},
+	{Name: "transfer_tasks", PrimaryKey: []string{"task_id"}},
+	{Name: "cross_cluster_tasks", PrimaryKey: []string{"target_cluster", "task_id"}},
+	{Name: "timer_tasks", PrimaryKey: []string{"visibility_timestamp", "task_id"}},
+	{Name: "replication_tasks", PrimaryKey: []string{"task_id"}},
+	{Name: "replication_tasks_dlq", PrimaryKey: []string{"source_cluster_name", "task_id"}},
+}
+
+// HistoryShardPlacementsRefreshInterval is how often the history shard placements are reloaded. It bounds how long
+// hosts not owning a moved history shard keep reading it from its previous database shard
+const HistoryShardPlacementsRefreshInterval = time.Minute
+
+type (
+	// HistoryShardPlacementState is the state of a history shard placement
+	HistoryShardPlacementState int
+
+	// HistoryShardTable is a table sharded by history shard ID
+	HistoryShardTable struct {
+		Name string
+		// PrimaryKey are the columns identifying a row of the table within a history shard,
+		// i.e. the primary key without shard_id
+		PrimaryKey []string
+	}
+
+	// HistoryShardPlacements keeps the placements of history shards which were moved off their default database shard.
+	// A nil HistoryShardPlacements maps every history shard to its default database shard
+	HistoryShardPlacements struct {
+		sync.RWMutex
+		placements map[int]HistoryShardPlacementRow
+		load       func(ctx context.Context) ([]HistoryShardPlacementRow, error)
+		shutdownCh chan struct{}
+	}
+)
+
 // GetDBShardIDFromHistoryShardID maps  historyShardID to a DBShardID
 func GetDBShardIDFromHistoryShardID(historyShardID int, numDBShards int) int {
 	return historyShardID % numDBShards
 }
 
+// GetServingDBShardID returns the database shard the history shard is served from
+func (r *HistoryShardPlacementRow) GetServingDBShardID() int {
+	if r.State == HistoryShardPlacementStateActive {
+		return r.DBShardID
+	}
+	return r.SourceDBShardID
+}
+
+// NewHistoryShardPlacements returns HistoryShardPlacements kept up to date with the given load function
+func NewHistoryShardPlacements(load func(ctx context.Context) ([]HistoryShardPlacementRow, error)) *HistoryShardPlacements {
+	return &HistoryShardPlacements{
+		placements: make(map[int]HistoryShardPlacementRow),
+		load:       load,
+		shutdownCh: make(chan struct{}),
+	}
+}
+
+// Start loads the placements and keeps reloading them in background until Stop is called
+func (p *HistoryShardPlacements) Start() error {
+	ctx, cancel := context.WithTimeout(context.Background(), HistoryShardPlacementsRefreshInterval)
+	defer cancel()
+	if err := p.refresh(ctx); err != nil {
+		return err
+	}
+	go p.refreshLoop()
+	return nil
+}
+
+// Stop stops reloading the placements
+func (p *HistoryShardPlacements) Stop() {
+	if p != nil {
+		close(p.shutdownCh)
+	}
+}
+
+// GetDBShardID maps historyShardID to the database shard it is served from
+func (p *HistoryShardPlacements) GetDBShardID(historyShardID int, numDBShards int) int {
+	if p != nil {
+		p.RLock()
+		row, ok := p.placements[historyShardID]
+		p.RUnlock()
+		if ok {
+			return row.GetServingDBShardID()
+		}
+	}
+	return GetDBShardIDFromHistoryShardID(historyShardID, numDBShards)
+}
+
+// IsMoving tells whether the history shard is being copied to or frozen for another database shard
+func (p *HistoryShardPlacements) IsMoving(historyShardID int) bool {
+	if p == nil {
+		return false
+	}
+	p.RLock()
+	defer p.RUnlock()
+	row, ok := p.placements[historyShardID]
+	return ok && row.State != HistoryShardPlacementStateActive
+}
+
+// Update sets the placement of a single history shard, a nil row moves it back to its default database shard
+func (p *HistoryShardPlacements) Update(historyShardID int, row *HistoryShardPlacementRow) {
+	if p == nil {
+		return
+	}
+	p.Lock()
+	defer p.Unlock()
+	if row == nil {
+		delete(p.placements, historyShardID)
+		return
+	}
+	p.placements[historyShardID] = *row
+}
+
+func (p *HistoryShardPlacements) refresh(ctx context.Context) error {
+	rows, err := p.load(ctx)
+	if err != nil {
+		return err
+	}
+	placements := make(map[int]HistoryShardPlacementRow, len(rows))
+	for _, row := range rows {
+		placements[row.ShardID] = row
+	}
+	p.Lock()
+	defer p.Unlock()
+	p.placements = placements
+	return nil
+}
+
+func (p *HistoryShardPlacements) refreshLoop() {
+	ticker := time.NewTicker(HistoryShardPlacementsRefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.shutdownCh:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), HistoryShardPlacementsRefreshInterval)
+			// keep serving the last known placements if reloading fails, it is retried on the next tick
+			_ = p.refresh(ctx)
+			cancel()
+		}
+	}
+}
+
 // GetDBShardIDFromDomainIDAndTasklist maps <domainID, tasklistName> to a DBShardID
 func GetDBShardIDFromDomainIDAndTasklist(domainID, tasklistName string, numDBShards int) int {
 	hash := farm.Hash32([]byte(domainID+"_"+tasklistName)) % uint32(numDBShards)
@@ -19,6 +19,7 @@ import (
 
 	config "github.com/uber/cadence/common/config"
 	persistence "github.com/uber/cadence/common/persistence"
+	types "github.com/uber/cadence/common/types"
 )
 
 // MockPlugin is a mock of Plugin interface.
@@ -75,6 +76,100 @@ func (mr *MockPluginMockRecorder) CreateDB(cfg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDB", reflect.TypeOf((*MockPlugin)(nil).CreateDB), cfg)
 }
 
+// MockVisibilityQueryDialect is a mock of VisibilityQueryDialect interface.
+type MockVisibilityQueryDialect struct {
+	ctrl     *gomock.Controller
+	recorder *MockVisibilityQueryDialectMockRecorder
+	isgomock struct{}
+}
+
+// MockVisibilityQueryDialectMockRecorder is the mock recorder for MockVisibilityQueryDialect.
+type MockVisibilityQueryDialectMockRecorder struct {
+	mock *MockVisibilityQueryDialect
+}
+
+// NewMockVisibilityQueryDialect creates a new mock instance.
+func NewMockVisibilityQueryDialect(ctrl *gomock.Controller) *MockVisibilityQueryDialect {
+	mock := &MockVisibilityQueryDialect{ctrl: ctrl}
+	mock.recorder = &MockVisibilityQueryDialectMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVisibilityQueryDialect) EXPECT() *MockVisibilityQueryDialectMockRecorder {
+	return m.recorder
+}
+
+// DoubleSearchAttribute mocks base method.
+func (m *MockVisibilityQueryDialect) DoubleSearchAttribute(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DoubleSearchAttribute", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// DoubleSearchAttribute indicates an expected call of DoubleSearchAttribute.
+func (mr *MockVisibilityQueryDialectMockRecorder) DoubleSearchAttribute(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DoubleSearchAttribute", reflect.TypeOf((*MockVisibilityQueryDialect)(nil).DoubleSearchAttribute), key)
+}
+
+// IntSearchAttribute mocks base method.
+func (m *MockVisibilityQueryDialect) IntSearchAttribute(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IntSearchAttribute", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// IntSearchAttribute indicates an expected call of IntSearchAttribute.
+func (mr *MockVisibilityQueryDialectMockRecorder) IntSearchAttribute(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IntSearchAttribute", reflect.TypeOf((*MockVisibilityQueryDialect)(nil).IntSearchAttribute), key)
+}
+
+// KeywordSearchAttributeContains mocks base method.
+func (m *MockVisibilityQueryDialect) KeywordSearchAttributeContains(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "KeywordSearchAttributeContains", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// KeywordSearchAttributeContains indicates an expected call of KeywordSearchAttributeContains.
+func (mr *MockVisibilityQueryDialectMockRecorder) KeywordSearchAttributeContains(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "KeywordSearchAttributeContains", reflect.TypeOf((*MockVisibilityQueryDialect)(nil).KeywordSearchAttributeContains), key)
+}
+
+// SearchAttributeIndex mocks base method.
+func (m *MockVisibilityQueryDialect) SearchAttributeIndex(key string, valueType types.IndexedValueType) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAttributeIndex", key, valueType)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// SearchAttributeIndex indicates an expected call of SearchAttributeIndex.
+func (mr *MockVisibilityQueryDialectMockRecorder) SearchAttributeIndex(key, valueType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAttributeIndex", reflect.TypeOf((*MockVisibilityQueryDialect)(nil).SearchAttributeIndex), key, valueType)
+}
+
+// TextSearchAttribute mocks base method.
+func (m *MockVisibilityQueryDialect) TextSearchAttribute(key string) string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TextSearchAttribute", key)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// TextSearchAttribute indicates an expected call of TextSearchAttribute.
+func (mr *MockVisibilityQueryDialectMockRecorder) TextSearchAttribute(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TextSearchAttribute", reflect.TypeOf((*MockVisibilityQueryDialect)(nil).TextSearchAttribute), key)
+}
+
 // MocktableCRUD is a mock of tableCRUD interface.
 type MocktableCRUD struct {
 	ctrl     *gomock.Controller
@@ -99,6 +194,21 @@ func (m *MocktableCRUD) EXPECT() *MocktableCRUDMockRecorder {
 	return m.recorder
 }
 
+// CountFromVisibilityByQuery mocks base method.
+func (m *MocktableCRUD) CountFromVisibilityByQuery(ctx context.Context, filter *VisibilityQueryFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFromVisibilityByQuery", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFromVisibilityByQuery indicates an expected call of CountFromVisibilityByQuery.
+func (mr *MocktableCRUDMockRecorder) CountFromVisibilityByQuery(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFromVisibilityByQuery", reflect.TypeOf((*MocktableCRUD)(nil).CountFromVisibilityByQuery), ctx, filter)
+}
+
 // DeleteFromActiveClusterSelectionPolicy mocks base method.
 func (m *MocktableCRUD) DeleteFromActiveClusterSelectionPolicy(ctx context.Context, filter *ActiveClusterSelectionPolicyFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -234,6 +344,21 @@ func (mr *MocktableCRUDMockRecorder) DeleteFromHistoryNode(ctx, filter any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryNode", reflect.TypeOf((*MocktableCRUD)(nil).DeleteFromHistoryNode), ctx, filter)
 }
 
+// DeleteFromHistoryShardPlacements mocks base method.
+func (m *MocktableCRUD) DeleteFromHistoryShardPlacements(ctx context.Context, filter *HistoryShardPlacementsFilter) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromHistoryShardPlacements", ctx, filter)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromHistoryShardPlacements indicates an expected call of DeleteFromHistoryShardPlacements.
+func (mr *MocktableCRUDMockRecorder) DeleteFromHistoryShardPlacements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryShardPlacements", reflect.TypeOf((*MocktableCRUD)(nil).DeleteFromHistoryShardPlacements), ctx, filter)
+}
+
 // DeleteFromHistoryTree mocks base method.
 func (m *MocktableCRUD) DeleteFromHistoryTree(ctx context.Context, filter *HistoryTreeFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1131,6 +1256,21 @@ func (mr *MocktableCRUDMockRecorder) ReplaceIntoChildExecutionInfoMaps(ctx, rows
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoChildExecutionInfoMaps", reflect.TypeOf((*MocktableCRUD)(nil).ReplaceIntoChildExecutionInfoMaps), ctx, rows)
 }
 
+// ReplaceIntoHistoryShardPlacements mocks base method.
+func (m *MocktableCRUD) ReplaceIntoHistoryShardPlacements(ctx context.Context, row *HistoryShardPlacementRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceIntoHistoryShardPlacements", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceIntoHistoryShardPlacements indicates an expected call of ReplaceIntoHistoryShardPlacements.
+func (mr *MocktableCRUDMockRecorder) ReplaceIntoHistoryShardPlacements(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoHistoryShardPlacements", reflect.TypeOf((*MocktableCRUD)(nil).ReplaceIntoHistoryShardPlacements), ctx, row)
+}
+
 // ReplaceIntoRequestCancelInfoMaps mocks base method.
 func (m *MocktableCRUD) ReplaceIntoRequestCancelInfoMaps(ctx context.Context, rows []RequestCancelInfoMapsRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1356,6 +1496,21 @@ func (mr *MocktableCRUDMockRecorder) SelectFromHistoryNode(ctx, filter any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryNode", reflect.TypeOf((*MocktableCRUD)(nil).SelectFromHistoryNode), ctx, filter)
 }
 
+// SelectFromHistoryShardPlacements mocks base method.
+func (m *MocktableCRUD) SelectFromHistoryShardPlacements(ctx context.Context, filter *HistoryShardPlacementsFilter) ([]HistoryShardPlacementRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromHistoryShardPlacements", ctx, filter)
+	ret0, _ := ret[0].([]HistoryShardPlacementRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromHistoryShardPlacements indicates an expected call of SelectFromHistoryShardPlacements.
+func (mr *MocktableCRUDMockRecorder) SelectFromHistoryShardPlacements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryShardPlacements", reflect.TypeOf((*MocktableCRUD)(nil).SelectFromHistoryShardPlacements), ctx, filter)
+}
+
 // SelectFromHistoryTree mocks base method.
 func (m *MocktableCRUD) SelectFromHistoryTree(ctx context.Context, filter *HistoryTreeFilter) ([]HistoryTreeRow, error) {
 	m.ctrl.T.Helper()
@@ -1566,6 +1721,21 @@ func (mr *MocktableCRUDMockRecorder) SelectFromVisibility(ctx, filter any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromVisibility", reflect.TypeOf((*MocktableCRUD)(nil).SelectFromVisibility), ctx, filter)
 }
 
+// SelectFromVisibilityByQuery mocks base method.
+func (m *MocktableCRUD) SelectFromVisibilityByQuery(ctx context.Context, filter *VisibilityQueryFilter) ([]VisibilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromVisibilityByQuery", ctx, filter)
+	ret0, _ := ret[0].([]VisibilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromVisibilityByQuery indicates an expected call of SelectFromVisibilityByQuery.
+func (mr *MocktableCRUDMockRecorder) SelectFromVisibilityByQuery(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromVisibilityByQuery", reflect.TypeOf((*MocktableCRUD)(nil).SelectFromVisibilityByQuery), ctx, filter)
+}
+
 // SelectLatestConfig mocks base method.
 func (m *MocktableCRUD) SelectLatestConfig(ctx context.Context, rowType int) (*persistence.InternalConfigStoreEntry, error) {
 	m.ctrl.T.Helper()
@@ -1683,6 +1853,21 @@ func (mr *MocktableCRUDMockRecorder) UpdateExecutions(ctx, row any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExecutions", reflect.TypeOf((*MocktableCRUD)(nil).UpdateExecutions), ctx, row)
 }
 
+// UpdateHistoryNode mocks base method.
+func (m *MocktableCRUD) UpdateHistoryNode(ctx context.Context, row *HistoryNodeRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHistoryNode", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHistoryNode indicates an expected call of UpdateHistoryNode.
+func (mr *MocktableCRUDMockRecorder) UpdateHistoryNode(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistoryNode", reflect.TypeOf((*MocktableCRUD)(nil).UpdateHistoryNode), ctx, row)
+}
+
 // UpdateShards mocks base method.
 func (m *MocktableCRUD) UpdateShards(ctx context.Context, row *ShardsRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -1728,6 +1913,21 @@ func (mr *MocktableCRUDMockRecorder) UpdateTaskListsWithTTL(ctx, row any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskListsWithTTL", reflect.TypeOf((*MocktableCRUD)(nil).UpdateTaskListsWithTTL), ctx, row)
 }
 
+// UpdateVisibility mocks base method.
+func (m *MocktableCRUD) UpdateVisibility(ctx context.Context, row *VisibilityRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVisibility", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVisibility indicates an expected call of UpdateVisibility.
+func (mr *MocktableCRUDMockRecorder) UpdateVisibility(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVisibility", reflect.TypeOf((*MocktableCRUD)(nil).UpdateVisibility), ctx, row)
+}
+
 // WriteLockExecutions mocks base method.
 func (m *MocktableCRUD) WriteLockExecutions(ctx context.Context, filter *ExecutionsFilter) (int, error) {
 	m.ctrl.T.Helper()
@@ -1967,6 +2167,21 @@ func (mr *MockTxMockRecorder) Commit() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTx)(nil).Commit))
 }
 
+// CountFromVisibilityByQuery mocks base method.
+func (m *MockTx) CountFromVisibilityByQuery(ctx context.Context, filter *VisibilityQueryFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFromVisibilityByQuery", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFromVisibilityByQuery indicates an expected call of CountFromVisibilityByQuery.
+func (mr *MockTxMockRecorder) CountFromVisibilityByQuery(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFromVisibilityByQuery", reflect.TypeOf((*MockTx)(nil).CountFromVisibilityByQuery), ctx, filter)
+}
+
 // DeleteFromActiveClusterSelectionPolicy mocks base method.
 func (m *MockTx) DeleteFromActiveClusterSelectionPolicy(ctx context.Context, filter *ActiveClusterSelectionPolicyFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -2102,6 +2317,21 @@ func (mr *MockTxMockRecorder) DeleteFromHistoryNode(ctx, filter any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryNode", reflect.TypeOf((*MockTx)(nil).DeleteFromHistoryNode), ctx, filter)
 }
 
+// DeleteFromHistoryShardPlacements mocks base method.
+func (m *MockTx) DeleteFromHistoryShardPlacements(ctx context.Context, filter *HistoryShardPlacementsFilter) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromHistoryShardPlacements", ctx, filter)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromHistoryShardPlacements indicates an expected call of DeleteFromHistoryShardPlacements.
+func (mr *MockTxMockRecorder) DeleteFromHistoryShardPlacements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryShardPlacements", reflect.TypeOf((*MockTx)(nil).DeleteFromHistoryShardPlacements), ctx, filter)
+}
+
 // DeleteFromHistoryTree mocks base method.
 func (m *MockTx) DeleteFromHistoryTree(ctx context.Context, filter *HistoryTreeFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -3055,6 +3285,21 @@ func (mr *MockTxMockRecorder) ReplaceIntoChildExecutionInfoMaps(ctx, rows any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoChildExecutionInfoMaps", reflect.TypeOf((*MockTx)(nil).ReplaceIntoChildExecutionInfoMaps), ctx, rows)
 }
 
+// ReplaceIntoHistoryShardPlacements mocks base method.
+func (m *MockTx) ReplaceIntoHistoryShardPlacements(ctx context.Context, row *HistoryShardPlacementRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceIntoHistoryShardPlacements", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceIntoHistoryShardPlacements indicates an expected call of ReplaceIntoHistoryShardPlacements.
+func (mr *MockTxMockRecorder) ReplaceIntoHistoryShardPlacements(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoHistoryShardPlacements", reflect.TypeOf((*MockTx)(nil).ReplaceIntoHistoryShardPlacements), ctx, row)
+}
+
 // ReplaceIntoRequestCancelInfoMaps mocks base method.
 func (m *MockTx) ReplaceIntoRequestCancelInfoMaps(ctx context.Context, rows []RequestCancelInfoMapsRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -3294,6 +3539,21 @@ func (mr *MockTxMockRecorder) SelectFromHistoryNode(ctx, filter any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryNode", reflect.TypeOf((*MockTx)(nil).SelectFromHistoryNode), ctx, filter)
 }
 
+// SelectFromHistoryShardPlacements mocks base method.
+func (m *MockTx) SelectFromHistoryShardPlacements(ctx context.Context, filter *HistoryShardPlacementsFilter) ([]HistoryShardPlacementRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromHistoryShardPlacements", ctx, filter)
+	ret0, _ := ret[0].([]HistoryShardPlacementRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromHistoryShardPlacements indicates an expected call of SelectFromHistoryShardPlacements.
+func (mr *MockTxMockRecorder) SelectFromHistoryShardPlacements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryShardPlacements", reflect.TypeOf((*MockTx)(nil).SelectFromHistoryShardPlacements), ctx, filter)
+}
+
 // SelectFromHistoryTree mocks base method.
 func (m *MockTx) SelectFromHistoryTree(ctx context.Context, filter *HistoryTreeFilter) ([]HistoryTreeRow, error) {
 	m.ctrl.T.Helper()
@@ -3504,6 +3764,21 @@ func (mr *MockTxMockRecorder) SelectFromVisibility(ctx, filter any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromVisibility", reflect.TypeOf((*MockTx)(nil).SelectFromVisibility), ctx, filter)
 }
 
+// SelectFromVisibilityByQuery mocks base method.
+func (m *MockTx) SelectFromVisibilityByQuery(ctx context.Context, filter *VisibilityQueryFilter) ([]VisibilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromVisibilityByQuery", ctx, filter)
+	ret0, _ := ret[0].([]VisibilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromVisibilityByQuery indicates an expected call of SelectFromVisibilityByQuery.
+func (mr *MockTxMockRecorder) SelectFromVisibilityByQuery(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromVisibilityByQuery", reflect.TypeOf((*MockTx)(nil).SelectFromVisibilityByQuery), ctx, filter)
+}
+
 // SelectLatestConfig mocks base method.
 func (m *MockTx) SelectLatestConfig(ctx context.Context, rowType int) (*persistence.InternalConfigStoreEntry, error) {
 	m.ctrl.T.Helper()
@@ -3621,6 +3896,21 @@ func (mr *MockTxMockRecorder) UpdateExecutions(ctx, row any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExecutions", reflect.TypeOf((*MockTx)(nil).UpdateExecutions), ctx, row)
 }
 
+// UpdateHistoryNode mocks base method.
+func (m *MockTx) UpdateHistoryNode(ctx context.Context, row *HistoryNodeRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHistoryNode", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHistoryNode indicates an expected call of UpdateHistoryNode.
+func (mr *MockTxMockRecorder) UpdateHistoryNode(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistoryNode", reflect.TypeOf((*MockTx)(nil).UpdateHistoryNode), ctx, row)
+}
+
 // UpdateShards mocks base method.
 func (m *MockTx) UpdateShards(ctx context.Context, row *ShardsRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -3666,6 +3956,21 @@ func (mr *MockTxMockRecorder) UpdateTaskListsWithTTL(ctx, row any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskListsWithTTL", reflect.TypeOf((*MockTx)(nil).UpdateTaskListsWithTTL), ctx, row)
 }
 
+// UpdateVisibility mocks base method.
+func (m *MockTx) UpdateVisibility(ctx context.Context, row *VisibilityRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVisibility", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVisibility indicates an expected call of UpdateVisibility.
+func (mr *MockTxMockRecorder) UpdateVisibility(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVisibility", reflect.TypeOf((*MockTx)(nil).UpdateVisibility), ctx, row)
+}
+
 // WriteLockExecutions mocks base method.
 func (m *MockTx) WriteLockExecutions(ctx context.Context, filter *ExecutionsFilter) (int, error) {
 	m.ctrl.T.Helper()
@@ -3735,6 +4040,21 @@ func (mr *MockDBMockRecorder) BeginTx(ctx, dbShardID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTx", reflect.TypeOf((*MockDB)(nil).BeginTx), ctx, dbShardID)
 }
 
+// ChecksumHistoryShardRows mocks base method.
+func (m *MockDB) ChecksumHistoryShardRows(ctx context.Context, historyShardID, dbShardID int) (map[string]HistoryShardTableChecksum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChecksumHistoryShardRows", ctx, historyShardID, dbShardID)
+	ret0, _ := ret[0].(map[string]HistoryShardTableChecksum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChecksumHistoryShardRows indicates an expected call of ChecksumHistoryShardRows.
+func (mr *MockDBMockRecorder) ChecksumHistoryShardRows(ctx, historyShardID, dbShardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChecksumHistoryShardRows", reflect.TypeOf((*MockDB)(nil).ChecksumHistoryShardRows), ctx, historyShardID, dbShardID)
+}
+
 // Close mocks base method.
 func (m *MockDB) Close() error {
 	m.ctrl.T.Helper()
@@ -3749,6 +4069,35 @@ func (mr *MockDBMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDB)(nil).Close))
 }
 
+// CopyHistoryShardRows mocks base method.
+func (m *MockDB) CopyHistoryShardRows(ctx context.Context, historyShardID, sourceDBShardID, targetDBShardID int, progress func(string, int)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CopyHistoryShardRows", ctx, historyShardID, sourceDBShardID, targetDBShardID, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CopyHistoryShardRows indicates an expected call of CopyHistoryShardRows.
+func (mr *MockDBMockRecorder) CopyHistoryShardRows(ctx, historyShardID, sourceDBShardID, targetDBShardID, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CopyHistoryShardRows", reflect.TypeOf((*MockDB)(nil).CopyHistoryShardRows), ctx, historyShardID, sourceDBShardID, targetDBShardID, progress)
+}
+
+// CountFromVisibilityByQuery mocks base method.
+func (m *MockDB) CountFromVisibilityByQuery(ctx context.Context, filter *VisibilityQueryFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountFromVisibilityByQuery", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountFromVisibilityByQuery indicates an expected call of CountFromVisibilityByQuery.
+func (mr *MockDBMockRecorder) CountFromVisibilityByQuery(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountFromVisibilityByQuery", reflect.TypeOf((*MockDB)(nil).CountFromVisibilityByQuery), ctx, filter)
+}
+
 // DeleteFromActiveClusterSelectionPolicy mocks base method.
 func (m *MockDB) DeleteFromActiveClusterSelectionPolicy(ctx context.Context, filter *ActiveClusterSelectionPolicyFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -3884,6 +4233,21 @@ func (mr *MockDBMockRecorder) DeleteFromHistoryNode(ctx, filter any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryNode", reflect.TypeOf((*MockDB)(nil).DeleteFromHistoryNode), ctx, filter)
 }
 
+// DeleteFromHistoryShardPlacements mocks base method.
+func (m *MockDB) DeleteFromHistoryShardPlacements(ctx context.Context, filter *HistoryShardPlacementsFilter) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFromHistoryShardPlacements", ctx, filter)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFromHistoryShardPlacements indicates an expected call of DeleteFromHistoryShardPlacements.
+func (mr *MockDBMockRecorder) DeleteFromHistoryShardPlacements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromHistoryShardPlacements", reflect.TypeOf((*MockDB)(nil).DeleteFromHistoryShardPlacements), ctx, filter)
+}
+
 // DeleteFromHistoryTree mocks base method.
 func (m *MockDB) DeleteFromHistoryTree(ctx context.Context, filter *HistoryTreeFilter) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -4049,6 +4413,20 @@ func (mr *MockDBMockRecorder) DeleteFromVisibility(ctx, filter any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromVisibility", reflect.TypeOf((*MockDB)(nil).DeleteFromVisibility), ctx, filter)
 }
 
+// DeleteHistoryShardRows mocks base method.
+func (m *MockDB) DeleteHistoryShardRows(ctx context.Context, historyShardID, dbShardID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHistoryShardRows", ctx, historyShardID, dbShardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteHistoryShardRows indicates an expected call of DeleteHistoryShardRows.
+func (mr *MockDBMockRecorder) DeleteHistoryShardRows(ctx, historyShardID, dbShardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHistoryShardRows", reflect.TypeOf((*MockDB)(nil).DeleteHistoryShardRows), ctx, historyShardID, dbShardID)
+}
+
 // DeleteMessage mocks base method.
 func (m *MockDB) DeleteMessage(ctx context.Context, queueType persistence.QueueType, messageID int64) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -4094,6 +4472,21 @@ func (mr *MockDBMockRecorder) DeleteMessagesBefore(ctx, queueType, messageID any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessagesBefore", reflect.TypeOf((*MockDB)(nil).DeleteMessagesBefore), ctx, queueType, messageID)
 }
 
+// FenceHistoryShard mocks base method.
+func (m *MockDB) FenceHistoryShard(ctx context.Context, historyShardID, dbShardID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FenceHistoryShard", ctx, historyShardID, dbShardID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FenceHistoryShard indicates an expected call of FenceHistoryShard.
+func (mr *MockDBMockRecorder) FenceHistoryShard(ctx, historyShardID, dbShardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FenceHistoryShard", reflect.TypeOf((*MockDB)(nil).FenceHistoryShard), ctx, historyShardID, dbShardID)
+}
+
 // GetAckLevels mocks base method.
 func (m *MockDB) GetAckLevels(ctx context.Context, queueType persistence.QueueType, forUpdate bool) (map[string]int64, error) {
 	m.ctrl.T.Helper()
@@ -4124,6 +4517,20 @@ func (mr *MockDBMockRecorder) GetAllHistoryTreeBranches(ctx, filter any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHistoryTreeBranches", reflect.TypeOf((*MockDB)(nil).GetAllHistoryTreeBranches), ctx, filter)
 }
 
+// GetDBShardIDFromHistoryShardID mocks base method.
+func (m *MockDB) GetDBShardIDFromHistoryShardID(historyShardID int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDBShardIDFromHistoryShardID", historyShardID)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetDBShardIDFromHistoryShardID indicates an expected call of GetDBShardIDFromHistoryShardID.
+func (mr *MockDBMockRecorder) GetDBShardIDFromHistoryShardID(historyShardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDBShardIDFromHistoryShardID", reflect.TypeOf((*MockDB)(nil).GetDBShardIDFromHistoryShardID), historyShardID)
+}
+
 // GetLastEnqueuedMessageIDForUpdate mocks base method.
 func (m *MockDB) GetLastEnqueuedMessageIDForUpdate(ctx context.Context, queueType persistence.QueueType) (int64, error) {
 	m.ctrl.T.Helper()
@@ -4585,6 +4992,20 @@ func (mr *MockDBMockRecorder) IsDupEntryError(err any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsDupEntryError", reflect.TypeOf((*MockDB)(nil).IsDupEntryError), err)
 }
 
+// IsHistoryShardMoving mocks base method.
+func (m *MockDB) IsHistoryShardMoving(historyShardID int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHistoryShardMoving", historyShardID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsHistoryShardMoving indicates an expected call of IsHistoryShardMoving.
+func (mr *MockDBMockRecorder) IsHistoryShardMoving(historyShardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHistoryShardMoving", reflect.TypeOf((*MockDB)(nil).IsHistoryShardMoving), historyShardID)
+}
+
 // IsNotFoundError mocks base method.
 func (m *MockDB) IsNotFoundError(err error) bool {
 	m.ctrl.T.Helper()
@@ -4835,6 +5256,21 @@ func (mr *MockDBMockRecorder) ReadLockShards(ctx, filter any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadLockShards", reflect.TypeOf((*MockDB)(nil).ReadLockShards), ctx, filter)
 }
 
+// RefreshHistoryShardPlacement mocks base method.
+func (m *MockDB) RefreshHistoryShardPlacement(ctx context.Context, historyShardID int) (*HistoryShardPlacementRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshHistoryShardPlacement", ctx, historyShardID)
+	ret0, _ := ret[0].(*HistoryShardPlacementRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshHistoryShardPlacement indicates an expected call of RefreshHistoryShardPlacement.
+func (mr *MockDBMockRecorder) RefreshHistoryShardPlacement(ctx, historyShardID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshHistoryShardPlacement", reflect.TypeOf((*MockDB)(nil).RefreshHistoryShardPlacement), ctx, historyShardID)
+}
+
 // ReplaceIntoActivityInfoMaps mocks base method.
 func (m *MockDB) ReplaceIntoActivityInfoMaps(ctx context.Context, rows []ActivityInfoMapsRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -4865,6 +5301,21 @@ func (mr *MockDBMockRecorder) ReplaceIntoChildExecutionInfoMaps(ctx, rows any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoChildExecutionInfoMaps", reflect.TypeOf((*MockDB)(nil).ReplaceIntoChildExecutionInfoMaps), ctx, rows)
 }
 
+// ReplaceIntoHistoryShardPlacements mocks base method.
+func (m *MockDB) ReplaceIntoHistoryShardPlacements(ctx context.Context, row *HistoryShardPlacementRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceIntoHistoryShardPlacements", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceIntoHistoryShardPlacements indicates an expected call of ReplaceIntoHistoryShardPlacements.
+func (mr *MockDBMockRecorder) ReplaceIntoHistoryShardPlacements(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceIntoHistoryShardPlacements", reflect.TypeOf((*MockDB)(nil).ReplaceIntoHistoryShardPlacements), ctx, row)
+}
+
 // ReplaceIntoRequestCancelInfoMaps mocks base method.
 func (m *MockDB) ReplaceIntoRequestCancelInfoMaps(ctx context.Context, rows []RequestCancelInfoMapsRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -5090,6 +5541,21 @@ func (mr *MockDBMockRecorder) SelectFromHistoryNode(ctx, filter any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryNode", reflect.TypeOf((*MockDB)(nil).SelectFromHistoryNode), ctx, filter)
 }
 
+// SelectFromHistoryShardPlacements mocks base method.
+func (m *MockDB) SelectFromHistoryShardPlacements(ctx context.Context, filter *HistoryShardPlacementsFilter) ([]HistoryShardPlacementRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromHistoryShardPlacements", ctx, filter)
+	ret0, _ := ret[0].([]HistoryShardPlacementRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromHistoryShardPlacements indicates an expected call of SelectFromHistoryShardPlacements.
+func (mr *MockDBMockRecorder) SelectFromHistoryShardPlacements(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromHistoryShardPlacements", reflect.TypeOf((*MockDB)(nil).SelectFromHistoryShardPlacements), ctx, filter)
+}
+
 // SelectFromHistoryTree mocks base method.
 func (m *MockDB) SelectFromHistoryTree(ctx context.Context, filter *HistoryTreeFilter) ([]HistoryTreeRow, error) {
 	m.ctrl.T.Helper()
@@ -5300,6 +5766,21 @@ func (mr *MockDBMockRecorder) SelectFromVisibility(ctx, filter any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromVisibility", reflect.TypeOf((*MockDB)(nil).SelectFromVisibility), ctx, filter)
 }
 
+// SelectFromVisibilityByQuery mocks base method.
+func (m *MockDB) SelectFromVisibilityByQuery(ctx context.Context, filter *VisibilityQueryFilter) ([]VisibilityRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SelectFromVisibilityByQuery", ctx, filter)
+	ret0, _ := ret[0].([]VisibilityRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SelectFromVisibilityByQuery indicates an expected call of SelectFromVisibilityByQuery.
+func (mr *MockDBMockRecorder) SelectFromVisibilityByQuery(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SelectFromVisibilityByQuery", reflect.TypeOf((*MockDB)(nil).SelectFromVisibilityByQuery), ctx, filter)
+}
+
 // SelectLatestConfig mocks base method.
 func (m *MockDB) SelectLatestConfig(ctx context.Context, rowType int) (*persistence.InternalConfigStoreEntry, error) {
 	m.ctrl.T.Helper()
@@ -5417,6 +5898,21 @@ func (mr *MockDBMockRecorder) UpdateExecutions(ctx, row any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateExecutions", reflect.TypeOf((*MockDB)(nil).UpdateExecutions), ctx, row)
 }
 
+// UpdateHistoryNode mocks base method.
+func (m *MockDB) UpdateHistoryNode(ctx context.Context, row *HistoryNodeRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHistoryNode", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHistoryNode indicates an expected call of UpdateHistoryNode.
+func (mr *MockDBMockRecorder) UpdateHistoryNode(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHistoryNode", reflect.TypeOf((*MockDB)(nil).UpdateHistoryNode), ctx, row)
+}
+
 // UpdateShards mocks base method.
 func (m *MockDB) UpdateShards(ctx context.Context, row *ShardsRow) (sql.Result, error) {
 	m.ctrl.T.Helper()
@@ -5462,6 +5958,35 @@ func (mr *MockDBMockRecorder) UpdateTaskListsWithTTL(ctx, row any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskListsWithTTL", reflect.TypeOf((*MockDB)(nil).UpdateTaskListsWithTTL), ctx, row)
 }
 
+// UpdateVisibility mocks base method.
+func (m *MockDB) UpdateVisibility(ctx context.Context, row *VisibilityRow) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVisibility", ctx, row)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVisibility indicates an expected call of UpdateVisibility.
+func (mr *MockDBMockRecorder) UpdateVisibility(ctx, row any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVisibility", reflect.TypeOf((*MockDB)(nil).UpdateVisibility), ctx, row)
+}
+
+// VisibilityQueryDialect mocks base method.
+func (m *MockDB) VisibilityQueryDialect() VisibilityQueryDialect {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VisibilityQueryDialect")
+	ret0, _ := ret[0].(VisibilityQueryDialect)
+	return ret0
+}
+
+// VisibilityQueryDialect indicates an expected call of VisibilityQueryDialect.
+func (mr *MockDBMockRecorder) VisibilityQueryDialect() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VisibilityQueryDialect", reflect.TypeOf((*MockDB)(nil).VisibilityQueryDialect))
+}
+
 // WriteLockExecutions mocks base method.
 func (m *MockDB) WriteLockExecutions(ctx context.Context, filter *ExecutionsFilter) (int, error) {
 	m.ctrl.T.Helper()
@@ -5677,6 +6202,20 @@ func (mr *MockAdminDBMockRecorder) UpdateSchemaVersion(database, newVersion, min
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSchemaVersion", reflect.TypeOf((*MockAdminDB)(nil).UpdateSchemaVersion), database, newVersion, minCompatibleVersion)
 }
 
+// VisibilityQueryDialect mocks base method.
+func (m *MockAdminDB) VisibilityQueryDialect() VisibilityQueryDialect {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VisibilityQueryDialect")
+	ret0, _ := ret[0].(VisibilityQueryDialect)
+	return ret0
+}
+
+// VisibilityQueryDialect indicates an expected call of VisibilityQueryDialect.
+func (mr *MockAdminDBMockRecorder) VisibilityQueryDialect() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VisibilityQueryDialect", reflect.TypeOf((*MockAdminDB)(nil).VisibilityQueryDialect))
+}
+
 // WriteSchemaUpdateLog mocks base method.
 func (m *MockAdminDB) WriteSchemaUpdateLog(oldVersion, newVersion, manifestMD5, desc string) error {
 	m.ctrl.T.Helper()
@@ -32,6 +32,7 @@ import (
 	"github.com/uber/cadence/common/constants"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/serialization"
+	"github.com/uber/cadence/common/types"
 )
 
 var (
@@ -222,6 +223,7 @@ type (
 		TaskListName string
 		Data         []byte
 		DataEncoding string
+		Priority     int
 	}
 
 	// TaskKeyRow represents a result row giving task keys
@@ -400,6 +402,8 @@ type (
 		// Exclusive
 		MaxNodeID *int64
 		PageSize  int
+		// ReadFromReplica allows reading from a read replica, for branches which can no longer change
+		ReadFromReplica bool
 	}
 
 	// HistoryTreeRow represents a row in history_tree table
@@ -566,6 +570,7 @@ type (
 		ShardID                int16
 		ExecutionStatus        int32
 		ScheduledExecutionTime time.Time
+		SearchAttributes       []byte
 	}
 
 	// VisibilityFilter contains the column names within executions_visibility table that
@@ -582,6 +587,36 @@ type (
 		PageSize         *int
 	}
 
+	// VisibilityQueryFilter contains a visibility query translated into a WHERE clause
+	// of executions_visibility table
+	VisibilityQueryFilter struct {
+		DomainID string
+		// Condition is an optional boolean expression using ? as the placeholders of Args
+		Condition string
+		Args      []interface{}
+		// OrderBy is an optional list of ORDER BY expressions. Rows are ordered by start_time DESC, run_id when it's empty
+		OrderBy  string
+		PageSize int
+		Offset   int
+	}
+
+	// VisibilityQueryDialect renders the expressions to read the search attributes stored
+	// in the search_attributes column of executions_visibility table
+	VisibilityQueryDialect interface {
+		// IntSearchAttribute returns the expression of an int, bool or datetime search attribute
+		IntSearchAttribute(key string) string
+		// DoubleSearchAttribute returns the expression of a double search attribute
+		DoubleSearchAttribute(key string) string
+		// TextSearchAttribute returns the expression of a string or keyword search attribute
+		TextSearchAttribute(key string) string
+		// KeywordSearchAttributeContains returns a condition which is true when the keyword search attribute,
+		// either a single value or a list of values, contains the value bound to its only placeholder
+		KeywordSearchAttributeContains(key string) string
+		// SearchAttributeIndex returns the statement creating the expression index of a search attribute,
+		// or an empty string when the database can't index search attributes of this type
+		SearchAttributeIndex(key string, valueType types.IndexedValueType) string
+	}
+
 	// QueueRow represents a row in queue table
 	QueueRow struct {
 		QueueType      persistence.QueueType
@@ -631,6 +666,29 @@ type (
 		PageMinEventID     *string
 	}
 
+	// HistoryShardPlacementRow represents a row in history_shard_placements table.
+	// Rows only exist for history shards which are being moved or were moved off the
+	// database shard given by GetDBShardIDFromHistoryShardID
+	HistoryShardPlacementRow struct {
+		ShardID         int
+		DBShardID       int
+		SourceDBShardID int
+		State           HistoryShardPlacementState
+		LastUpdatedTime time.Time
+	}
+
+	// HistoryShardPlacementsFilter contains the column names within history_shard_placements table that
+	// can be used to filter results through a WHERE clause. All rows are matched when ShardID is nil
+	HistoryShardPlacementsFilter struct {
+		ShardID *int
+	}
+
+	// HistoryShardTableChecksum summarizes the rows a history shard has in one table
+	HistoryShardTableChecksum struct {
+		Rows     int
+		Checksum uint64
+	}
+
 	// tableCRUD defines the API for interacting with the database tables
 	tableCRUD interface {
 		InsertIntoDomain(ctx context.Context, rows *DomainRow) (sql.Result, error)
@@ -652,6 +710,11 @@ type (
 		ReadLockShards(ctx context.Context, filter *ShardsFilter) (int, error)
 		WriteLockShards(ctx context.Context, filter *ShardsFilter) (int, error)
 
+		// history_shard_placements rows are always kept in the default db shard
+		ReplaceIntoHistoryShardPlacements(ctx context.Context, row *HistoryShardPlacementRow) (sql.Result, error)
+		SelectFromHistoryShardPlacements(ctx context.Context, filter *HistoryShardPlacementsFilter) ([]HistoryShardPlacementRow, error)
+		DeleteFromHistoryShardPlacements(ctx context.Context, filter *HistoryShardPlacementsFilter) (sql.Result, error)
+
 		InsertIntoTasks(ctx context.Context, rows []TasksRow) (sql.Result, error)
 		InsertIntoTasksWithTTL(ctx context.Context, rows []TasksRowWithTTL) (sql.Result, error)
 		// SelectFromTasks retrieves one or more rows from the tasks table
@@ -682,6 +745,7 @@ type (
 
 		// eventsV2
 		InsertIntoHistoryNode(ctx context.Context, row *HistoryNodeRow) (sql.Result, error)
+		UpdateHistoryNode(ctx context.Context, row *HistoryNodeRow) (sql.Result, error)
 		SelectFromHistoryNode(ctx context.Context, filter *HistoryNodeFilter) ([]HistoryNodeRow, error)
 		DeleteFromHistoryNode(ctx context.Context, filter *HistoryNodeFilter) (sql.Result, error)
 		InsertIntoHistoryTree(ctx context.Context, row *HistoryTreeRow) (sql.Result, error)
@@ -858,6 +922,13 @@ type (
 		//     - workflowID, workflowTypeName, closeStatus (along with closed=true)
 		SelectFromVisibility(ctx context.Context, filter *VisibilityFilter) ([]VisibilityRow, error)
 		DeleteFromVisibility(ctx context.Context, filter *VisibilityFilter) (sql.Result, error)
+		// UpdateVisibility updates the memo, search attributes and status of an open workflow in visibility table.
+		// Closed workflows are left as such
+		UpdateVisibility(ctx context.Context, row *VisibilityRow) (sql.Result, error)
+		// SelectFromVisibilityByQuery returns one page of rows matching a translated visibility query
+		SelectFromVisibilityByQuery(ctx context.Context, filter *VisibilityQueryFilter) ([]VisibilityRow, error)
+		// CountFromVisibilityByQuery returns the number of rows matching a translated visibility query
+		CountFromVisibilityByQuery(ctx context.Context, filter *VisibilityQueryFilter) (int64, error)
 
 		InsertIntoQueue(ctx context.Context, row *QueueRow) (sql.Result, error)
 		GetLastEnqueuedMessageIDForUpdate(ctx context.Context, queueType persistence.QueueType) (int64, error)
@@ -917,15 +988,42 @@ type (
 		ErrorChecker
 
 		GetTotalNumDBShards() int
+		// GetDBShardIDFromHistoryShardID maps historyShardID to a DBShardID, taking history shard placements into account
+		GetDBShardIDFromHistoryShardID(historyShardID int) int
+		// RefreshHistoryShardPlacement reloads the placement of a history shard. It returns nil if the history shard
+		// lives on its default database shard
+		RefreshHistoryShardPlacement(ctx context.Context, historyShardID int) (*HistoryShardPlacementRow, error)
+		// IsHistoryShardMoving tells whether the last known placement of a history shard is still copying or frozen,
+		// i.e. whether the history shard may have been cut over to another database shard since
+		IsHistoryShardMoving(historyShardID int) bool
 		BeginTx(ctx context.Context, dbShardID int) (Tx, error)
 		PluginName() string
+		// VisibilityQueryDialect returns the dialect used to query search attributes in visibility table
+		VisibilityQueryDialect() VisibilityQueryDialect
 		Close() error
+
+		// Below methods move the rows of a history shard between database shards. Unlike tableCRUD,
+		// the database shards are passed explicitly instead of being derived from the history shard.
+		// Rows of history_node and history_tree are not moved as they are sharded by tree ID.
+
+		// CopyHistoryShardRows makes the rows of a history shard in the target database shard match the rows in the source one.
+		// Only rows which differ are written, so a copy following a previous one only writes what changed in between.
+		// progress is called after each table is copied
+		CopyHistoryShardRows(ctx context.Context, historyShardID, sourceDBShardID, targetDBShardID int, progress func(table string, rows int)) error
+		// ChecksumHistoryShardRows summarizes the rows of a history shard per table
+		ChecksumHistoryShardRows(ctx context.Context, historyShardID, dbShardID int) (map[string]HistoryShardTableChecksum, error)
+		// DeleteHistoryShardRows deletes all rows of a history shard from a database shard
+		DeleteHistoryShardRows(ctx context.Context, historyShardID, dbShardID int) error
+		// FenceHistoryShard increments the range ID of a history shard in a database shard, so that the current owner
+		// of the history shard can no longer write there. It returns the new range ID
+		FenceHistoryShard(ctx context.Context, historyShardID, dbShardID int) (int64, error)
 	}
 
 	// AdminDB defines the API for admin SQL operations for CLI and testing suites
 	AdminDB interface {
 		adminCRUD
 		PluginName() string
+		VisibilityQueryDialect() VisibilityQueryDialect
 		Close() error
 	}
 
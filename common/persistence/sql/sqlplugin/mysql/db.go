@@ -39,6 +39,8 @@ type (
 		driver      sqldriver.Driver
 		originalDBs []*sqlx.DB
 		numDBShards int
+		placements  *sqlplugin.HistoryShardPlacements
+		replicas    *sqldriver.ReadReplicas
 	}
 )
 
@@ -128,7 +130,12 @@ func (mdb *DB) BeginTx(ctx context.Context, dbShardID int) (sqlplugin.Tx, error)
 	if err != nil {
 		return nil, err
 	}
-	return NewDB(mdb.originalDBs, xtx, dbShardID, mdb.numDBShards, mdb.converter)
+	tx, err := NewDB(mdb.originalDBs, xtx, dbShardID, mdb.numDBShards, mdb.converter)
+	if err != nil {
+		return nil, err
+	}
+	tx.placements = mdb.placements
+	return tx, nil
 }
 
 // Commit commits a previously started transaction
@@ -143,6 +150,8 @@ func (mdb *DB) Rollback() error {
 
 // Close closes the connection to the mysql db
 func (mdb *DB) Close() error {
+	mdb.placements.Stop()
+	mdb.replicas.Close()
 	return mdb.driver.Close()
 }
 
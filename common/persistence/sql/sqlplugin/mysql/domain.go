@@ -25,6 +25,7 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
 )
 
@@ -97,13 +98,14 @@ func (mdb *DB) selectFromDomain(ctx context.Context, filter *sqlplugin.DomainFil
 }
 
 func (mdb *DB) selectAllFromDomain(ctx context.Context, filter *sqlplugin.DomainFilter) ([]sqlplugin.DomainRow, error) {
+	driver := mdb.readDriver(config.SQLReplicaReadDomain)
 	var err error
 	var rows []sqlplugin.DomainRow
 	switch {
 	case filter.GreaterThanID != nil:
-		err = mdb.driver.SelectContext(ctx, sqlplugin.DbDefaultShard, &rows, listDomainsRangeQuery, shardID, *filter.GreaterThanID, *filter.PageSize)
+		err = driver.SelectContext(ctx, sqlplugin.DbDefaultShard, &rows, listDomainsRangeQuery, shardID, *filter.GreaterThanID, *filter.PageSize)
 	default:
-		err = mdb.driver.SelectContext(ctx, sqlplugin.DbDefaultShard, &rows, listDomainsQuery, shardID, filter.PageSize)
+		err = driver.SelectContext(ctx, sqlplugin.DbDefaultShard, &rows, listDomainsQuery, shardID, filter.PageSize)
 	}
 	return rows, err
 }
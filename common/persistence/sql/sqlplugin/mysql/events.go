@@ -24,6 +24,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
 )
 
@@ -33,6 +34,9 @@ const (
 		`shard_id, tree_id, branch_id, node_id, txn_id, data, data_encoding) ` +
 		`VALUES (:shard_id, :tree_id, :branch_id, :node_id, :txn_id, :data, :data_encoding) `
 
+	updateHistoryNodeQuery = `UPDATE history_node SET data = ?, data_encoding = ? ` +
+		`WHERE shard_id = ? AND tree_id = ? AND branch_id = ? AND node_id = ? AND txn_id = ? `
+
 	getHistoryNodesQuery = `SELECT node_id, txn_id, data, data_encoding FROM history_node ` +
 		`WHERE shard_id = ? AND tree_id = ? AND branch_id = ? AND node_id >= ? and node_id < ? ORDER BY shard_id, tree_id, branch_id, node_id, txn_id LIMIT ? `
 
@@ -62,11 +66,23 @@ func (mdb *DB) InsertIntoHistoryNode(ctx context.Context, row *sqlplugin.History
 	return mdb.driver.NamedExecContext(ctx, dbShardID, addHistoryNodesQuery, row)
 }
 
+// UpdateHistoryNode replaces the data of an existing row in history_node table
+func (mdb *DB) UpdateHistoryNode(ctx context.Context, row *sqlplugin.HistoryNodeRow) (sql.Result, error) {
+	dbShardID := sqlplugin.GetDBShardIDFromTreeID(row.TreeID, mdb.GetTotalNumDBShards())
+	// NOTE: txn_id is stored multiplied by -1, see InsertIntoHistoryNode
+	return mdb.driver.ExecContext(ctx, dbShardID, updateHistoryNodeQuery,
+		row.Data, row.DataEncoding, row.ShardID, row.TreeID, row.BranchID, row.NodeID, -*row.TxnID)
+}
+
 // SelectFromHistoryNode reads one or more rows from history_node table
 func (mdb *DB) SelectFromHistoryNode(ctx context.Context, filter *sqlplugin.HistoryNodeFilter) ([]sqlplugin.HistoryNodeRow, error) {
 	var rows []sqlplugin.HistoryNodeRow
 	dbShardID := sqlplugin.GetDBShardIDFromTreeID(filter.TreeID, mdb.GetTotalNumDBShards())
-	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getHistoryNodesQuery,
+	driver := mdb.driver
+	if filter.ReadFromReplica {
+		driver = mdb.readDriver(config.SQLReplicaReadHistory)
+	}
+	err := driver.SelectContext(ctx, dbShardID, &rows, getHistoryNodesQuery,
 		filter.ShardID, filter.TreeID, filter.BranchID, *filter.MinNodeID, *filter.MaxNodeID, filter.PageSize)
 	// NOTE: since we let txn_id multiple by -1 when inserting, we have to revert it back here
 	for _, row := range rows {
@@ -178,13 +178,13 @@ VALUES     (:source_cluster_name,
 
 // InsertIntoExecutions inserts a row into executions table
 func (mdb *DB) InsertIntoExecutions(ctx context.Context, row *sqlplugin.ExecutionsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(row.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(row.ShardID)
 	return mdb.driver.NamedExecContext(ctx, dbShardID, createExecutionQuery, row)
 }
 
 // UpdateExecutions updates a single row in executions table
 func (mdb *DB) UpdateExecutions(ctx context.Context, row *sqlplugin.ExecutionsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(row.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(row.ShardID)
 	return mdb.driver.NamedExecContext(ctx, dbShardID, updateExecutionQuery, row)
 }
 
@@ -192,7 +192,7 @@ func (mdb *DB) UpdateExecutions(ctx context.Context, row *sqlplugin.ExecutionsRo
 // The list execution query result is order by workflow ID only. It may returns duplicate record with pagination.
 func (mdb *DB) SelectFromExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) ([]sqlplugin.ExecutionsRow, error) {
 	var rows []sqlplugin.ExecutionsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	var err error
 	if len(filter.DomainID) == 0 && filter.Size > 0 {
 		err = mdb.driver.SelectContext(ctx, dbShardID, &rows, listExecutionQuery, filter.ShardID, filter.WorkflowID, filter.Size)
@@ -213,14 +213,14 @@ func (mdb *DB) SelectFromExecutions(ctx context.Context, filter *sqlplugin.Execu
 
 // DeleteFromExecutions deletes a single row from executions table
 func (mdb *DB) DeleteFromExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	return mdb.driver.ExecContext(ctx, dbShardID, deleteExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 }
 
 // ReadLockExecutions acquires a write lock on a single row in executions table
 func (mdb *DB) ReadLockExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) (int, error) {
 	var nextEventID int
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	err := mdb.driver.GetContext(ctx, dbShardID, &nextEventID, readLockExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	return nextEventID, err
 }
@@ -228,41 +228,41 @@ func (mdb *DB) ReadLockExecutions(ctx context.Context, filter *sqlplugin.Executi
 // WriteLockExecutions acquires a write lock on a single row in executions table
 func (mdb *DB) WriteLockExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) (int, error) {
 	var nextEventID int
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	err := mdb.driver.GetContext(ctx, dbShardID, &nextEventID, writeLockExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	return nextEventID, err
 }
 
 // InsertIntoCurrentExecutions inserts a single row into current_executions table
 func (mdb *DB) InsertIntoCurrentExecutions(ctx context.Context, row *sqlplugin.CurrentExecutionsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return mdb.driver.NamedExecContext(ctx, dbShardID, createCurrentExecutionQuery, row)
 }
 
 // UpdateCurrentExecutions updates a single row in current_executions table
 func (mdb *DB) UpdateCurrentExecutions(ctx context.Context, row *sqlplugin.CurrentExecutionsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return mdb.driver.NamedExecContext(ctx, dbShardID, updateCurrentExecutionsQuery, row)
 }
 
 // SelectFromCurrentExecutions reads one or more rows from current_executions table
 func (mdb *DB) SelectFromCurrentExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) (*sqlplugin.CurrentExecutionsRow, error) {
 	var row sqlplugin.CurrentExecutionsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.GetContext(ctx, dbShardID, &row, getCurrentExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID)
 	return &row, err
 }
 
 // DeleteFromCurrentExecutions deletes a single row in current_executions table
 func (mdb *DB) DeleteFromCurrentExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	return mdb.driver.ExecContext(ctx, dbShardID, deleteCurrentExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 }
 
 // LockCurrentExecutions acquires a write lock on a single row in current_executions table
 func (mdb *DB) LockCurrentExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) (*sqlplugin.CurrentExecutionsRow, error) {
 	var row sqlplugin.CurrentExecutionsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.GetContext(ctx, dbShardID, &row, lockCurrentExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID)
 	return &row, err
 }
@@ -271,7 +271,7 @@ func (mdb *DB) LockCurrentExecutions(ctx context.Context, filter *sqlplugin.Curr
 // write lock on the result
 func (mdb *DB) LockCurrentExecutionsJoinExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) ([]sqlplugin.CurrentExecutionsRow, error) {
 	var rows []sqlplugin.CurrentExecutionsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, lockCurrentExecutionJoinExecutionsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID)
 	return rows, err
 }
@@ -281,14 +281,14 @@ func (mdb *DB) InsertIntoTransferTasks(ctx context.Context, rows []sqlplugin.Tra
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	return mdb.driver.NamedExecContext(ctx, dbShardID, createTransferTasksQuery, rows)
 }
 
 // SelectFromTransferTasks reads one or more rows from transfer_tasks table
 func (mdb *DB) SelectFromTransferTasks(ctx context.Context, filter *sqlplugin.TransferTasksFilter) ([]sqlplugin.TransferTasksRow, error) {
 	var rows []sqlplugin.TransferTasksRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getTransferTasksQuery, filter.ShardID, filter.InclusiveMinTaskID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	if err != nil {
 		return nil, err
@@ -298,13 +298,13 @@ func (mdb *DB) SelectFromTransferTasks(ctx context.Context, filter *sqlplugin.Tr
 
 // DeleteFromTransferTasks deletes one row from transfer_tasks table
 func (mdb *DB) DeleteFromTransferTasks(ctx context.Context, filter *sqlplugin.TransferTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	return mdb.driver.ExecContext(ctx, dbShardID, deleteTransferTaskQuery, filter.ShardID, filter.TaskID)
 }
 
 // RangeDeleteFromTransferTasks deletes multi rows from transfer_tasks table
 func (mdb *DB) RangeDeleteFromTransferTasks(ctx context.Context, filter *sqlplugin.TransferTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if filter.PageSize > 0 {
 		return mdb.driver.ExecContext(ctx, dbShardID, rangeDeleteTransferTaskByBatchQuery, filter.ShardID, filter.InclusiveMinTaskID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	}
@@ -316,14 +316,14 @@ func (mdb *DB) InsertIntoCrossClusterTasks(ctx context.Context, rows []sqlplugin
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	return mdb.driver.NamedExecContext(ctx, dbShardID, createCrossClusterTasksQuery, rows)
 }
 
 // SelectFromCrossClusterTasks reads one or more rows from cross_cluster_tasks table
 func (mdb *DB) SelectFromCrossClusterTasks(ctx context.Context, filter *sqlplugin.CrossClusterTasksFilter) ([]sqlplugin.CrossClusterTasksRow, error) {
 	var rows []sqlplugin.CrossClusterTasksRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getCrossClusterTasksQuery, filter.TargetCluster, filter.ShardID, filter.MinTaskID, filter.MaxTaskID, filter.PageSize)
 	if err != nil {
 		return nil, err
@@ -333,13 +333,13 @@ func (mdb *DB) SelectFromCrossClusterTasks(ctx context.Context, filter *sqlplugi
 
 // DeleteFromCrossClusterTasks deletes one row from cross_cluster_tasks table
 func (mdb *DB) DeleteFromCrossClusterTasks(ctx context.Context, filter *sqlplugin.CrossClusterTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	return mdb.driver.ExecContext(ctx, dbShardID, deleteCrossClusterTaskQuery, filter.TargetCluster, filter.ShardID, filter.TaskID)
 }
 
 // RangeDeleteFromCrossClusterTasks deletes multi rows from cross_cluster_tasks table
 func (mdb *DB) RangeDeleteFromCrossClusterTasks(ctx context.Context, filter *sqlplugin.CrossClusterTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if filter.PageSize > 0 {
 		return mdb.driver.ExecContext(ctx, dbShardID, rangeDeleteCrossClusterTaskByBatchQuery, filter.TargetCluster, filter.ShardID, filter.MinTaskID, filter.MaxTaskID, filter.PageSize)
 	}
@@ -351,7 +351,7 @@ func (mdb *DB) InsertIntoTimerTasks(ctx context.Context, rows []sqlplugin.TimerT
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	for i := range rows {
 		rows[i].VisibilityTimestamp = mdb.converter.ToDateTime(rows[i].VisibilityTimestamp)
 	}
@@ -361,7 +361,7 @@ func (mdb *DB) InsertIntoTimerTasks(ctx context.Context, rows []sqlplugin.TimerT
 // SelectFromTimerTasks reads one or more rows from timer_tasks table
 func (mdb *DB) SelectFromTimerTasks(ctx context.Context, filter *sqlplugin.TimerTasksFilter) ([]sqlplugin.TimerTasksRow, error) {
 	var rows []sqlplugin.TimerTasksRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	filter.MinVisibilityTimestamp = mdb.converter.ToDateTime(filter.MinVisibilityTimestamp)
 	filter.MaxVisibilityTimestamp = mdb.converter.ToDateTime(filter.MaxVisibilityTimestamp)
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getTimerTasksQuery, filter.ShardID, filter.MinVisibilityTimestamp,
@@ -378,7 +378,7 @@ func (mdb *DB) SelectFromTimerTasks(ctx context.Context, filter *sqlplugin.Timer
 // DeleteFromTimerTasks deletes one row from timer_tasks table
 func (mdb *DB) DeleteFromTimerTasks(ctx context.Context, filter *sqlplugin.TimerTasksFilter) (sql.Result, error) {
 	filter.VisibilityTimestamp = mdb.converter.ToDateTime(filter.VisibilityTimestamp)
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	return mdb.driver.ExecContext(ctx, dbShardID, deleteTimerTaskQuery, filter.ShardID, filter.VisibilityTimestamp, filter.TaskID)
 }
 
@@ -386,7 +386,7 @@ func (mdb *DB) DeleteFromTimerTasks(ctx context.Context, filter *sqlplugin.Timer
 func (mdb *DB) RangeDeleteFromTimerTasks(ctx context.Context, filter *sqlplugin.TimerTasksFilter) (sql.Result, error) {
 	filter.MinVisibilityTimestamp = mdb.converter.ToDateTime(filter.MinVisibilityTimestamp)
 	filter.MaxVisibilityTimestamp = mdb.converter.ToDateTime(filter.MaxVisibilityTimestamp)
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if filter.PageSize > 0 {
 		return mdb.driver.ExecContext(ctx, dbShardID, rangeDeleteTimerTaskByBatchQuery, filter.ShardID, filter.MinVisibilityTimestamp, filter.MaxVisibilityTimestamp, filter.PageSize)
 	}
@@ -398,13 +398,13 @@ func (mdb *DB) InsertIntoBufferedEvents(ctx context.Context, rows []sqlplugin.Bu
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	return mdb.driver.NamedExecContext(ctx, dbShardID, createBufferedEventsQuery, rows)
 }
 
 // SelectFromBufferedEvents reads one or more rows from buffered_events table
 func (mdb *DB) SelectFromBufferedEvents(ctx context.Context, filter *sqlplugin.BufferedEventsFilter) ([]sqlplugin.BufferedEventsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	var rows []sqlplugin.BufferedEventsRow
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getBufferedEventsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -418,7 +418,7 @@ func (mdb *DB) SelectFromBufferedEvents(ctx context.Context, filter *sqlplugin.B
 
 // DeleteFromBufferedEvents deletes one or more rows from buffered_events table
 func (mdb *DB) DeleteFromBufferedEvents(ctx context.Context, filter *sqlplugin.BufferedEventsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	return mdb.driver.ExecContext(ctx, dbShardID, deleteBufferedEventsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 }
 
@@ -427,27 +427,27 @@ func (mdb *DB) InsertIntoReplicationTasks(ctx context.Context, rows []sqlplugin.
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	return mdb.driver.NamedExecContext(ctx, dbShardID, createReplicationTasksQuery, rows)
 }
 
 // SelectFromReplicationTasks reads one or more rows from replication_tasks table
 func (mdb *DB) SelectFromReplicationTasks(ctx context.Context, filter *sqlplugin.ReplicationTasksFilter) ([]sqlplugin.ReplicationTasksRow, error) {
 	var rows []sqlplugin.ReplicationTasksRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getReplicationTasksQuery, filter.ShardID, filter.InclusiveMinTaskID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	return rows, err
 }
 
 // DeleteFromReplicationTasks deletes one row from replication_tasks table
 func (mdb *DB) DeleteFromReplicationTasks(ctx context.Context, filter *sqlplugin.ReplicationTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	return mdb.driver.ExecContext(ctx, dbShardID, deleteReplicationTaskQuery, filter.ShardID, filter.TaskID)
 }
 
 // RangeDeleteFromReplicationTasks deletes multi rows from replication_tasks table
 func (mdb *DB) RangeDeleteFromReplicationTasks(ctx context.Context, filter *sqlplugin.ReplicationTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if filter.PageSize > 0 {
 		return mdb.driver.ExecContext(ctx, dbShardID, rangeDeleteReplicationTaskByBatchQuery, filter.ShardID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	}
@@ -456,14 +456,14 @@ func (mdb *DB) RangeDeleteFromReplicationTasks(ctx context.Context, filter *sqlp
 
 // InsertIntoReplicationTasksDLQ inserts one or more rows into replication_tasks_dlq table
 func (mdb *DB) InsertIntoReplicationTasksDLQ(ctx context.Context, row *sqlplugin.ReplicationTaskDLQRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(row.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(row.ShardID)
 	return mdb.driver.NamedExecContext(ctx, dbShardID, insertReplicationTaskDLQQuery, row)
 }
 
 // SelectFromReplicationTasksDLQ reads one or more rows from replication_tasks_dlq table
 func (mdb *DB) SelectFromReplicationTasksDLQ(ctx context.Context, filter *sqlplugin.ReplicationTasksDLQFilter) ([]sqlplugin.ReplicationTasksRow, error) {
 	var rows []sqlplugin.ReplicationTasksRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	err := mdb.driver.SelectContext(
 		ctx,
 		dbShardID,
@@ -480,7 +480,7 @@ func (mdb *DB) SelectFromReplicationTasksDLQ(ctx context.Context, filter *sqlplu
 // SelectFromReplicationDLQ reads one row from replication_tasks_dlq table
 func (mdb *DB) SelectFromReplicationDLQ(ctx context.Context, filter *sqlplugin.ReplicationTaskDLQFilter) (int64, error) {
 	var size []int64
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if err := mdb.driver.SelectContext(
 		ctx,
 		dbShardID,
@@ -499,7 +499,7 @@ func (mdb *DB) DeleteMessageFromReplicationTasksDLQ(
 	ctx context.Context,
 	filter *sqlplugin.ReplicationTasksDLQFilter,
 ) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 
 	return mdb.driver.ExecContext(
 		ctx,
@@ -516,7 +516,7 @@ func (mdb *DB) RangeDeleteMessageFromReplicationTasksDLQ(
 	ctx context.Context,
 	filter *sqlplugin.ReplicationTasksDLQFilter,
 ) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if filter.PageSize > 0 {
 		return mdb.driver.ExecContext(
 			ctx,
@@ -127,7 +127,7 @@ func (mdb *DB) ReplaceIntoActivityInfoMaps(ctx context.Context, rows []sqlplugin
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	for i := range rows {
 		rows[i].LastHeartbeatUpdatedTime = mdb.converter.ToDateTime(rows[i].LastHeartbeatUpdatedTime)
 	}
@@ -136,7 +136,7 @@ func (mdb *DB) ReplaceIntoActivityInfoMaps(ctx context.Context, rows []sqlplugin
 
 // SelectFromActivityInfoMaps reads one or more rows from activity_info_maps table
 func (mdb *DB) SelectFromActivityInfoMaps(ctx context.Context, filter *sqlplugin.ActivityInfoMapsFilter) ([]sqlplugin.ActivityInfoMapsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.ActivityInfoMapsRow
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getActivityInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -151,7 +151,7 @@ func (mdb *DB) SelectFromActivityInfoMaps(ctx context.Context, filter *sqlplugin
 
 // DeleteFromActivityInfoMaps deletes one or more rows from activity_info_maps table
 func (mdb *DB) DeleteFromActivityInfoMaps(ctx context.Context, filter *sqlplugin.ActivityInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.ScheduleIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInActivityInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.ScheduleIDs)
 		if err != nil {
@@ -181,14 +181,14 @@ func (mdb *DB) ReplaceIntoTimerInfoMaps(ctx context.Context, rows []sqlplugin.Ti
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return mdb.driver.NamedExecContext(ctx, dbShardID, setKeyInTimerInfoMapSQLQuery, rows)
 }
 
 // SelectFromTimerInfoMaps reads one or more rows from timer_info_maps table
 func (mdb *DB) SelectFromTimerInfoMaps(ctx context.Context, filter *sqlplugin.TimerInfoMapsFilter) ([]sqlplugin.TimerInfoMapsRow, error) {
 	var rows []sqlplugin.TimerInfoMapsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getTimerInfoMapSQLQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
 		rows[i].ShardID = int64(filter.ShardID)
@@ -201,7 +201,7 @@ func (mdb *DB) SelectFromTimerInfoMaps(ctx context.Context, filter *sqlplugin.Ti
 
 // DeleteFromTimerInfoMaps deletes one or more rows from timer_info_maps table
 func (mdb *DB) DeleteFromTimerInfoMaps(ctx context.Context, filter *sqlplugin.TimerInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.TimerIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInTimerInfoMapSQLQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.TimerIDs)
 		if err != nil {
@@ -231,14 +231,14 @@ func (mdb *DB) ReplaceIntoChildExecutionInfoMaps(ctx context.Context, rows []sql
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return mdb.driver.NamedExecContext(ctx, dbShardID, setKeyInChildExecutionInfoMapQry, rows)
 }
 
 // SelectFromChildExecutionInfoMaps reads one or more rows from child_execution_info_maps table
 func (mdb *DB) SelectFromChildExecutionInfoMaps(ctx context.Context, filter *sqlplugin.ChildExecutionInfoMapsFilter) ([]sqlplugin.ChildExecutionInfoMapsRow, error) {
 	var rows []sqlplugin.ChildExecutionInfoMapsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getChildExecutionInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
 		rows[i].ShardID = int64(filter.ShardID)
@@ -251,7 +251,7 @@ func (mdb *DB) SelectFromChildExecutionInfoMaps(ctx context.Context, filter *sql
 
 // DeleteFromChildExecutionInfoMaps deletes one or more rows from child_execution_info_maps table
 func (mdb *DB) DeleteFromChildExecutionInfoMaps(ctx context.Context, filter *sqlplugin.ChildExecutionInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.InitiatedIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInChildExecutionInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.InitiatedIDs)
 		if err != nil {
@@ -281,14 +281,14 @@ func (mdb *DB) ReplaceIntoRequestCancelInfoMaps(ctx context.Context, rows []sqlp
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return mdb.driver.NamedExecContext(ctx, dbShardID, setKeyInRequestCancelInfoMapQry, rows)
 }
 
 // SelectFromRequestCancelInfoMaps reads one or more rows from request_cancel_info_maps table
 func (mdb *DB) SelectFromRequestCancelInfoMaps(ctx context.Context, filter *sqlplugin.RequestCancelInfoMapsFilter) ([]sqlplugin.RequestCancelInfoMapsRow, error) {
 	var rows []sqlplugin.RequestCancelInfoMapsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getRequestCancelInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
 		rows[i].ShardID = int64(filter.ShardID)
@@ -301,7 +301,7 @@ func (mdb *DB) SelectFromRequestCancelInfoMaps(ctx context.Context, filter *sqlp
 
 // DeleteFromRequestCancelInfoMaps deletes one or more rows from request_cancel_info_maps table
 func (mdb *DB) DeleteFromRequestCancelInfoMaps(ctx context.Context, filter *sqlplugin.RequestCancelInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.InitiatedIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInRequestCancelInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.InitiatedIDs)
 		if err != nil {
@@ -331,13 +331,13 @@ func (mdb *DB) ReplaceIntoSignalInfoMaps(ctx context.Context, rows []sqlplugin.S
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return mdb.driver.NamedExecContext(ctx, dbShardID, setKeyInSignalInfoMapQry, rows)
 }
 
 // SelectFromSignalInfoMaps reads one or more rows from signal_info_maps table
 func (mdb *DB) SelectFromSignalInfoMaps(ctx context.Context, filter *sqlplugin.SignalInfoMapsFilter) ([]sqlplugin.SignalInfoMapsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.SignalInfoMapsRow
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getSignalInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -351,7 +351,7 @@ func (mdb *DB) SelectFromSignalInfoMaps(ctx context.Context, filter *sqlplugin.S
 
 // DeleteFromSignalInfoMaps deletes one or more rows from signal_info_maps table
 func (mdb *DB) DeleteFromSignalInfoMaps(ctx context.Context, filter *sqlplugin.SignalInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.InitiatedIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInSignalInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.InitiatedIDs)
 		if err != nil {
@@ -395,14 +395,14 @@ func (mdb *DB) InsertIntoSignalsRequestedSets(ctx context.Context, rows []sqlplu
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return mdb.driver.NamedExecContext(ctx, dbShardID, createSignalsRequestedSetQry, rows)
 }
 
 // SelectFromSignalsRequestedSets reads one or more rows from signals_requested_sets table
 func (mdb *DB) SelectFromSignalsRequestedSets(ctx context.Context, filter *sqlplugin.SignalsRequestedSetsFilter) ([]sqlplugin.SignalsRequestedSetsRow, error) {
 	var rows []sqlplugin.SignalsRequestedSetsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, getSignalsRequestedSetQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
 		rows[i].ShardID = int64(filter.ShardID)
@@ -415,7 +415,7 @@ func (mdb *DB) SelectFromSignalsRequestedSets(ctx context.Context, filter *sqlpl
 
 // DeleteFromSignalsRequestedSets deletes one or more rows from signals_requested_sets table
 func (mdb *DB) DeleteFromSignalsRequestedSets(ctx context.Context, filter *sqlplugin.SignalsRequestedSetsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.SignalIDs) > 0 {
 		query, args, err := sqlx.In(deleteSignalsRequestedSetQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.SignalIDs)
 		if err != nil {
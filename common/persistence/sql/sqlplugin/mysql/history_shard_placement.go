@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/uber/cadence/common/persistence/sql/sqldriver"
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+)
+
+const (
+	replaceHistoryShardPlacementQry = `REPLACE INTO history_shard_placements
+ (shard_id, db_shard_id, source_db_shard_id, state, last_updated_time) VALUES (?, ?, ?, ?, ?)`
+
+	getHistoryShardPlacementQry = `SELECT shard_id, db_shard_id, source_db_shard_id, state, last_updated_time
+ FROM history_shard_placements WHERE shard_id = ?`
+
+	listHistoryShardPlacementsQry = `SELECT shard_id, db_shard_id, source_db_shard_id, state, last_updated_time
+ FROM history_shard_placements`
+
+	deleteHistoryShardPlacementQry = `DELETE FROM history_shard_placements WHERE shard_id = ?`
+
+	deleteAllHistoryShardPlacementsQry = `DELETE FROM history_shard_placements`
+)
+
+// historyShardTables are the tables sharded by history shard ID in mysql and sqlite
+var historyShardTables = append(append([]sqlplugin.HistoryShardTable{}, sqlplugin.HistoryShardTables...), sqlplugin.HistoryShardTable{
+	Name:       "active_cluster_selection_policy",
+	PrimaryKey: []string{"domain_id", "workflow_id", "run_id"},
+})
+
+// GetDBShardIDFromHistoryShardID maps historyShardID to the DBShardID the history shard is served from
+func (mdb *DB) GetDBShardIDFromHistoryShardID(historyShardID int) int {
+	return mdb.placements.GetDBShardID(historyShardID, mdb.numDBShards)
+}
+
+// IsHistoryShardMoving tells whether this host last saw the history shard being copied to another database shard
+func (mdb *DB) IsHistoryShardMoving(historyShardID int) bool {
+	return mdb.placements.IsMoving(historyShardID)
+}
+
+// startHistoryShardPlacements loads the history shard placements and keeps them up to date
+func (mdb *DB) startHistoryShardPlacements() error {
+	mdb.placements = sqlplugin.NewHistoryShardPlacements(func(ctx context.Context) ([]sqlplugin.HistoryShardPlacementRow, error) {
+		return mdb.SelectFromHistoryShardPlacements(ctx, &sqlplugin.HistoryShardPlacementsFilter{})
+	})
+	return mdb.placements.Start()
+}
+
+// RefreshHistoryShardPlacement reloads the placement of a history shard
+func (mdb *DB) RefreshHistoryShardPlacement(ctx context.Context, historyShardID int) (*sqlplugin.HistoryShardPlacementRow, error) {
+	if mdb.placements == nil {
+		return nil, nil
+	}
+	rows, err := mdb.SelectFromHistoryShardPlacements(ctx, &sqlplugin.HistoryShardPlacementsFilter{ShardID: &historyShardID})
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		mdb.placements.Update(historyShardID, nil)
+		return nil, nil
+	}
+	mdb.placements.Update(historyShardID, &rows[0])
+	return &rows[0], nil
+}
+
+// ReplaceIntoHistoryShardPlacements replaces a row in history_shard_placements table
+func (mdb *DB) ReplaceIntoHistoryShardPlacements(ctx context.Context, row *sqlplugin.HistoryShardPlacementRow) (sql.Result, error) {
+	return mdb.driver.ExecContext(ctx, sqlplugin.DbDefaultShard, replaceHistoryShardPlacementQry, row.ShardID, row.DBShardID, row.SourceDBShardID, row.State, row.LastUpdatedTime)
+}
+
+// SelectFromHistoryShardPlacements reads one or all rows from history_shard_placements table
+func (mdb *DB) SelectFromHistoryShardPlacements(ctx context.Context, filter *sqlplugin.HistoryShardPlacementsFilter) ([]sqlplugin.HistoryShardPlacementRow, error) {
+	var rows []sqlplugin.HistoryShardPlacementRow
+	var err error
+	if filter.ShardID != nil {
+		err = mdb.driver.SelectContext(ctx, sqlplugin.DbDefaultShard, &rows, getHistoryShardPlacementQry, *filter.ShardID)
+	} else {
+		err = mdb.driver.SelectContext(ctx, sqlplugin.DbDefaultShard, &rows, listHistoryShardPlacementsQry)
+	}
+	return rows, err
+}
+
+// DeleteFromHistoryShardPlacements deletes one or all rows from history_shard_placements table
+func (mdb *DB) DeleteFromHistoryShardPlacements(ctx context.Context, filter *sqlplugin.HistoryShardPlacementsFilter) (sql.Result, error) {
+	if filter.ShardID != nil {
+		return mdb.driver.ExecContext(ctx, sqlplugin.DbDefaultShard, deleteHistoryShardPlacementQry, *filter.ShardID)
+	}
+	return mdb.driver.ExecContext(ctx, sqlplugin.DbDefaultShard, deleteAllHistoryShardPlacementsQry)
+}
+
+// CopyHistoryShardRows makes the rows of a history shard in the target database shard match the rows in the source one
+func (mdb *DB) CopyHistoryShardRows(ctx context.Context, historyShardID, sourceDBShardID, targetDBShardID int, progress func(table string, rows int)) error {
+	return sqldriver.CopyHistoryShardRows(ctx, mdb.driver, historyShardTables, historyShardID, sourceDBShardID, targetDBShardID, progress)
+}
+
+// ChecksumHistoryShardRows summarizes the rows of a history shard per table
+func (mdb *DB) ChecksumHistoryShardRows(ctx context.Context, historyShardID, dbShardID int) (map[string]sqlplugin.HistoryShardTableChecksum, error) {
+	return sqldriver.ChecksumHistoryShardRows(ctx, mdb.driver, historyShardTables, historyShardID, dbShardID)
+}
+
+// DeleteHistoryShardRows deletes all rows of a history shard from a database shard
+func (mdb *DB) DeleteHistoryShardRows(ctx context.Context, historyShardID, dbShardID int) error {
+	return sqldriver.DeleteHistoryShardRows(ctx, mdb.driver, historyShardTables, historyShardID, dbShardID)
+}
+
+// FenceHistoryShard increments the range ID of a history shard in a database shard
+func (mdb *DB) FenceHistoryShard(ctx context.Context, historyShardID, dbShardID int) (int64, error) {
+	return sqldriver.FenceHistoryShard(ctx, mdb.driver, historyShardID, dbShardID)
+}
@@ -69,7 +69,23 @@ func init() {
 
 // CreateDB initialize the DB object
 func (p *plugin) CreateDB(cfg *config.SQL) (sqlplugin.DB, error) {
-	return p.createDB(cfg)
+	db, err := p.createDB(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.UseMultipleDatabases {
+		if err := db.startHistoryShardPlacements(); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	db.replicas, err = sqldriver.CreateReadReplicas(cfg, p.createSingleDBConn, replicationLag)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	db.replicas.Start()
+	return db, nil
 }
 
 // CreateAdminDB initialize the adminDb object
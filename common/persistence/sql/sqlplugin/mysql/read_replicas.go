@@ -0,0 +1,80 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package mysql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/uber/cadence/common/persistence/sql/sqldriver"
+)
+
+const showReplicaStatusQry = `SHOW REPLICA STATUS`
+
+var errNoReplicaStatus = errors.New("read replica does not report a replica status, set managed if its replication is managed by the database service")
+
+// readDriver returns the driver for an eventually consistent read, which is sent to a read replica if the read is routed to them
+func (mdb *DB) readDriver(read string) sqldriver.Driver {
+	return mdb.replicas.ReadDriver(mdb.driver, read)
+}
+
+// replicationLag returns Seconds_Behind_Source of a read replica
+func replicationLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	rows, err := db.QueryxContext(ctx, showReplicaStatusQry)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		// the lag of a replica without a replica status is unknown, managed replicas are not checked at all
+		return 0, errNoReplicaStatus
+	}
+	status := make(map[string]interface{})
+	if err := rows.MapScan(status); err != nil {
+		return 0, err
+	}
+	lag, ok := status["Seconds_Behind_Source"]
+	if !ok {
+		lag = status["Seconds_Behind_Master"]
+	}
+	if lag == nil {
+		return 0, errors.New("replication is not running")
+	}
+	var seconds int64
+	switch v := lag.(type) {
+	case []byte:
+		seconds, err = strconv.ParseInt(string(v), 10, 64)
+	case string:
+		seconds, err = strconv.ParseInt(v, 10, 64)
+	case int64:
+		seconds = v
+	default:
+		err = errors.New("unexpected type of Seconds_Behind_Source")
+	}
+	return time.Duration(seconds) * time.Second, err
+}
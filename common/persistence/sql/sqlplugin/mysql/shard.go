@@ -45,19 +45,19 @@ const (
 
 // InsertIntoShards inserts one or more rows into shards table
 func (mdb *DB) InsertIntoShards(ctx context.Context, row *sqlplugin.ShardsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return mdb.driver.ExecContext(ctx, dbShardID, createShardQry, row.ShardID, row.RangeID, row.Data, row.DataEncoding)
 }
 
 // UpdateShards updates one or more rows into shards table
 func (mdb *DB) UpdateShards(ctx context.Context, row *sqlplugin.ShardsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return mdb.driver.ExecContext(ctx, dbShardID, updateShardQry, row.RangeID, row.Data, row.DataEncoding, row.ShardID)
 }
 
 // SelectFromShards reads one or more rows from shards table
 func (mdb *DB) SelectFromShards(ctx context.Context, filter *sqlplugin.ShardsFilter) (*sqlplugin.ShardsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var row sqlplugin.ShardsRow
 	err := mdb.driver.GetContext(ctx, dbShardID, &row, getShardQry, filter.ShardID)
 	if err != nil {
@@ -68,7 +68,7 @@ func (mdb *DB) SelectFromShards(ctx context.Context, filter *sqlplugin.ShardsFil
 
 // ReadLockShards acquires a read lock on a single row in shards table
 func (mdb *DB) ReadLockShards(ctx context.Context, filter *sqlplugin.ShardsFilter) (int, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rangeID int
 	err := mdb.driver.GetContext(ctx, dbShardID, &rangeID, readLockShardQry, filter.ShardID)
 	return rangeID, err
@@ -76,7 +76,7 @@ func (mdb *DB) ReadLockShards(ctx context.Context, filter *sqlplugin.ShardsFilte
 
 // WriteLockShards acquires a write lock on a single row in shards table
 func (mdb *DB) WriteLockShards(ctx context.Context, filter *sqlplugin.ShardsFilter) (int, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rangeID int
 	err := mdb.driver.GetContext(ctx, dbShardID, &rangeID, lockShardQry, filter.ShardID)
 	return rangeID, err
@@ -73,12 +73,12 @@ task_type = :task_type
 	lockTaskListQry = `SELECT range_id FROM task_lists ` +
 		`WHERE shard_id = ? AND domain_id = ? AND name = ? AND task_type = ? FOR UPDATE`
 
-	getTaskMinMaxQry = `SELECT task_id, data, data_encoding ` +
+	getTaskMinMaxQry = `SELECT task_id, data, data_encoding, priority ` +
 		`FROM tasks ` +
 		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND task_id > ? AND task_id <= ? ` +
 		` ORDER BY task_id LIMIT ?`
 
-	getTaskMinQry = `SELECT task_id, data, data_encoding ` +
+	getTaskMinQry = `SELECT task_id, data, data_encoding, priority ` +
 		`FROM tasks ` +
 		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND task_id > ? ORDER BY task_id LIMIT ?`
 
@@ -87,8 +87,8 @@ task_type = :task_type
 		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND task_id > ?`
 
 	createTaskQry = `INSERT INTO ` +
-		`tasks(domain_id, task_list_name, task_type, task_id, data, data_encoding) ` +
-		`VALUES(:domain_id, :task_list_name, :task_type, :task_id, :data, :data_encoding)`
+		`tasks(domain_id, task_list_name, task_type, task_id, data, data_encoding, priority) ` +
+		`VALUES(:domain_id, :task_list_name, :task_type, :task_id, :data, :data_encoding, :priority)`
 
 	deleteTaskQry = `DELETE FROM tasks ` +
 		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND task_id = ?`
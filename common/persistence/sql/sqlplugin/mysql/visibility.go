@@ -25,18 +25,21 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+	"github.com/uber/cadence/common/types"
 )
 
 const (
 	templateCreateWorkflowExecutionStarted = `INSERT IGNORE INTO executions_visibility (` +
-		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	templateCreateWorkflowExecutionClosed = `REPLACE INTO executions_visibility (` +
-		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, close_time, close_status, history_length, memo, encoding, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, close_time, close_status, history_length, memo, encoding, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	// RunID condition is needed for correct pagination
 	templateConditions = ` AND domain_id = ?
@@ -72,6 +75,17 @@ const (
 		 AND run_id = ?`
 
 	templateDeleteWorkflowExecution = "DELETE FROM executions_visibility WHERE domain_id=? AND run_id=?"
+
+	templateUpdateWorkflowExecution = `UPDATE executions_visibility
+		 SET memo = ?, encoding = ?, search_attributes = ?, update_time = ?, execution_status = ?
+		 WHERE domain_id = ? AND run_id = ? AND close_status IS NULL`
+
+	templateSelectByQuery = `SELECT ` + templateOpenFieldNames + `, close_time, close_status, history_length, search_attributes
+		 FROM executions_visibility WHERE domain_id = ?`
+
+	templateCountByQuery = `SELECT COUNT(*) FROM executions_visibility WHERE domain_id = ?`
+
+	templateDefaultOrderBy = `start_time DESC, run_id`
 )
 
 var errCloseParams = errors.New("missing one of {closeStatus, closeTime, historyLength} params")
@@ -99,7 +113,8 @@ func (mdb *DB) InsertIntoVisibility(ctx context.Context, row *sqlplugin.Visibili
 		row.ShardID,
 		row.ExecutionStatus,
 		row.CronSchedule,
-		scheduledExecutionTime)
+		scheduledExecutionTime,
+		jsonColumnValue(row.SearchAttributes))
 }
 
 // ReplaceIntoVisibility replaces an existing row if it exist or creates a new row in visibility table
@@ -130,7 +145,8 @@ func (mdb *DB) ReplaceIntoVisibility(ctx context.Context, row *sqlplugin.Visibil
 			row.ShardID,
 			row.ExecutionStatus,
 			row.CronSchedule,
-			scheduledExecutionTime)
+			scheduledExecutionTime,
+			jsonColumnValue(row.SearchAttributes))
 	default:
 		return nil, errCloseParams
 	}
@@ -144,6 +160,7 @@ func (mdb *DB) DeleteFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 
 // SelectFromVisibility reads one or more rows from visibility table
 func (mdb *DB) SelectFromVisibility(ctx context.Context, filter *sqlplugin.VisibilityFilter) ([]sqlplugin.VisibilityRow, error) {
+	driver := mdb.readDriver(config.SQLReplicaReadVisibility)
 	dbShardID := sqlplugin.GetDBShardIDFromDomainID(filter.DomainID, mdb.GetTotalNumDBShards())
 	var err error
 	var rows []sqlplugin.VisibilityRow
@@ -156,7 +173,7 @@ func (mdb *DB) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 	switch {
 	case filter.MinStartTime == nil && filter.RunID != nil && filter.Closed:
 		var row sqlplugin.VisibilityRow
-		err = mdb.driver.GetContext(ctx, dbShardID, &row, templateGetClosedWorkflowExecution, filter.DomainID, *filter.RunID)
+		err = driver.GetContext(ctx, dbShardID, &row, templateGetClosedWorkflowExecution, filter.DomainID, *filter.RunID)
 		if err == nil {
 			rows = append(rows, row)
 		}
@@ -165,7 +182,7 @@ func (mdb *DB) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 		if filter.Closed {
 			qry = templateGetClosedWorkflowExecutionsByID
 		}
-		err = mdb.driver.SelectContext(ctx,
+		err = driver.SelectContext(ctx,
 			dbShardID,
 			&rows,
 			qry,
@@ -181,7 +198,7 @@ func (mdb *DB) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 		if filter.Closed {
 			qry = templateGetClosedWorkflowExecutionsByType
 		}
-		err = mdb.driver.SelectContext(ctx,
+		err = driver.SelectContext(ctx,
 			dbShardID,
 			&rows,
 			qry,
@@ -193,7 +210,7 @@ func (mdb *DB) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 			*filter.MaxStartTime,
 			*filter.PageSize)
 	case filter.MinStartTime != nil && filter.CloseStatus != nil:
-		err = mdb.driver.SelectContext(ctx,
+		err = driver.SelectContext(ctx,
 			dbShardID,
 			&rows,
 			templateGetClosedWorkflowExecutionsByStatus,
@@ -209,7 +226,7 @@ func (mdb *DB) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 		if filter.Closed {
 			qry = templateGetClosedWorkflowExecutions
 		}
-		err = mdb.driver.SelectContext(ctx,
+		err = driver.SelectContext(ctx,
 			dbShardID,
 			&rows,
 			qry,
@@ -235,3 +252,121 @@ func (mdb *DB) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 	}
 	return rows, err
 }
+
+// UpdateVisibility updates the memo, search attributes and status of an open workflow in visibility table
+func (mdb *DB) UpdateVisibility(ctx context.Context, row *sqlplugin.VisibilityRow) (sql.Result, error) {
+	dbShardID := sqlplugin.GetDBShardIDFromDomainID(row.DomainID, mdb.GetTotalNumDBShards())
+	return mdb.driver.ExecContext(ctx,
+		dbShardID,
+		templateUpdateWorkflowExecution,
+		row.Memo,
+		row.Encoding,
+		jsonColumnValue(row.SearchAttributes),
+		row.UpdateTime,
+		row.ExecutionStatus,
+		row.DomainID,
+		row.RunID)
+}
+
+// SelectFromVisibilityByQuery reads one page of rows matching a visibility query from visibility table
+func (mdb *DB) SelectFromVisibilityByQuery(ctx context.Context, filter *sqlplugin.VisibilityQueryFilter) ([]sqlplugin.VisibilityRow, error) {
+	driver := mdb.readDriver(config.SQLReplicaReadVisibility)
+	dbShardID := sqlplugin.GetDBShardIDFromDomainID(filter.DomainID, mdb.GetTotalNumDBShards())
+	query, args := mdb.visibilityQuery(templateSelectByQuery, filter)
+	orderBy := filter.OrderBy
+	if orderBy == "" {
+		orderBy = templateDefaultOrderBy
+	}
+	query += ` ORDER BY ` + orderBy + ` LIMIT ? OFFSET ?`
+	args = append(args, filter.PageSize, filter.Offset)
+
+	var rows []sqlplugin.VisibilityRow
+	if err := driver.SelectContext(ctx, dbShardID, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].DomainID = filter.DomainID
+		rows[i].StartTime = mdb.converter.FromDateTime(rows[i].StartTime)
+		rows[i].ExecutionTime = mdb.converter.FromDateTime(rows[i].ExecutionTime)
+		if rows[i].CloseTime != nil {
+			closeTime := mdb.converter.FromDateTime(*rows[i].CloseTime)
+			rows[i].CloseTime = &closeTime
+		}
+	}
+	return rows, nil
+}
+
+// CountFromVisibilityByQuery counts the rows matching a visibility query in visibility table
+func (mdb *DB) CountFromVisibilityByQuery(ctx context.Context, filter *sqlplugin.VisibilityQueryFilter) (int64, error) {
+	driver := mdb.readDriver(config.SQLReplicaReadVisibility)
+	dbShardID := sqlplugin.GetDBShardIDFromDomainID(filter.DomainID, mdb.GetTotalNumDBShards())
+	query, args := mdb.visibilityQuery(templateCountByQuery, filter)
+	var count int64
+	err := driver.GetContext(ctx, dbShardID, &count, query, args...)
+	return count, err
+}
+
+// VisibilityQueryDialect returns the dialect used to query search attributes in visibility table
+func (mdb *DB) VisibilityQueryDialect() sqlplugin.VisibilityQueryDialect {
+	return visibilityQueryDialect{}
+}
+
+func (mdb *DB) visibilityQuery(query string, filter *sqlplugin.VisibilityQueryFilter) (string, []interface{}) {
+	args := []interface{}{filter.DomainID}
+	if filter.Condition != "" {
+		query += ` AND (` + filter.Condition + `)`
+	}
+	for _, arg := range filter.Args {
+		if t, ok := arg.(time.Time); ok {
+			arg = mdb.converter.ToDateTime(t)
+		}
+		args = append(args, arg)
+	}
+	return query, args
+}
+
+// jsonColumnValue returns the value to write into a JSON column. MySQL rejects
+// JSON documents passed as binary strings
+func jsonColumnValue(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
+
+// visibilityQueryDialect reads search attributes from the JSON search_attributes column.
+// The expressions must match the ones of the functional indexes in the schema
+type visibilityQueryDialect struct{}
+
+func (visibilityQueryDialect) IntSearchAttribute(key string) string {
+	return fmt.Sprintf("CAST(search_attributes->>'$.%s' AS SIGNED)", key)
+}
+
+func (visibilityQueryDialect) DoubleSearchAttribute(key string) string {
+	return fmt.Sprintf("CAST(search_attributes->>'$.%s' AS DOUBLE)", key)
+}
+
+func (visibilityQueryDialect) TextSearchAttribute(key string) string {
+	return fmt.Sprintf("search_attributes->>'$.%s'", key)
+}
+
+func (visibilityQueryDialect) KeywordSearchAttributeContains(key string) string {
+	// MEMBER OF treats a single value as an array of one element
+	return fmt.Sprintf("? MEMBER OF(search_attributes->'$.%s')", key)
+}
+
+func (d visibilityQueryDialect) SearchAttributeIndex(key string, valueType types.IndexedValueType) string {
+	var expression string
+	switch valueType {
+	case types.IndexedValueTypeKeyword:
+		// multi-valued index, which is used by MEMBER OF
+		expression = fmt.Sprintf("CAST(search_attributes->'$.%s' AS CHAR(255) ARRAY)", key)
+	case types.IndexedValueTypeInt, types.IndexedValueTypeBool, types.IndexedValueTypeDatetime:
+		expression = d.IntSearchAttribute(key)
+	case types.IndexedValueTypeDouble:
+		expression = d.DoubleSearchAttribute(key)
+	default:
+		return ""
+	}
+	return fmt.Sprintf("CREATE INDEX %s ON executions_visibility (domain_id, (%s))", sqlplugin.SearchAttributeIndexName(key), expression)
+}
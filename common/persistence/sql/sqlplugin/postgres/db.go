@@ -38,6 +38,8 @@ type (
 		driver      sqldriver.Driver
 		originalDBs []*sqlx.DB
 		numDBShards int
+		placements  *sqlplugin.HistoryShardPlacements
+		replicas    *sqldriver.ReadReplicas
 	}
 )
 
@@ -103,7 +105,12 @@ func (pdb *db) BeginTx(ctx context.Context, dbShardID int) (sqlplugin.Tx, error)
 	if err != nil {
 		return nil, err
 	}
-	return newDB(pdb.originalDBs, xtx, dbShardID, pdb.numDBShards)
+	tx, err := newDB(pdb.originalDBs, xtx, dbShardID, pdb.numDBShards)
+	if err != nil {
+		return nil, err
+	}
+	tx.placements = pdb.placements
+	return tx, nil
 }
 
 // Commit commits a previously started transaction
@@ -118,6 +125,8 @@ func (pdb *db) Rollback() error {
 
 // Close closes the connection to the mysql db
 func (pdb *db) Close() error {
+	pdb.placements.Stop()
+	pdb.replicas.Close()
 	return pdb.driver.Close()
 }
 
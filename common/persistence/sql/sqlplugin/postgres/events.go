@@ -24,6 +24,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
 )
 
@@ -33,6 +34,9 @@ const (
 		`shard_id, tree_id, branch_id, node_id, txn_id, data, data_encoding) ` +
 		`VALUES (:shard_id, :tree_id, :branch_id, :node_id, :txn_id, :data, :data_encoding) `
 
+	updateHistoryNodeQuery = `UPDATE history_node SET data = $1, data_encoding = $2 ` +
+		`WHERE shard_id = $3 AND tree_id = $4 AND branch_id = $5 AND node_id = $6 AND txn_id = $7 `
+
 	getHistoryNodesQuery = `SELECT node_id, txn_id, data, data_encoding FROM history_node ` +
 		`WHERE shard_id = $1 AND tree_id = $2 AND branch_id = $3 AND node_id >= $4 and node_id < $5 ORDER BY shard_id, tree_id, branch_id, node_id, txn_id LIMIT $6 `
 
@@ -63,11 +67,23 @@ func (pdb *db) InsertIntoHistoryNode(ctx context.Context, row *sqlplugin.History
 	return pdb.driver.NamedExecContext(ctx, dbShardID, addHistoryNodesQuery, row)
 }
 
+// UpdateHistoryNode replaces the data of an existing row in history_node table
+func (pdb *db) UpdateHistoryNode(ctx context.Context, row *sqlplugin.HistoryNodeRow) (sql.Result, error) {
+	dbShardID := sqlplugin.GetDBShardIDFromTreeID(row.TreeID, pdb.GetTotalNumDBShards())
+	// NOTE: txn_id is stored multiplied by -1, see InsertIntoHistoryNode
+	return pdb.driver.ExecContext(ctx, dbShardID, updateHistoryNodeQuery,
+		row.Data, row.DataEncoding, row.ShardID, row.TreeID, row.BranchID, row.NodeID, -*row.TxnID)
+}
+
 // SelectFromHistoryNode reads one or more rows from history_node table
 func (pdb *db) SelectFromHistoryNode(ctx context.Context, filter *sqlplugin.HistoryNodeFilter) ([]sqlplugin.HistoryNodeRow, error) {
 	dbShardID := sqlplugin.GetDBShardIDFromTreeID(filter.TreeID, pdb.GetTotalNumDBShards())
 	var rows []sqlplugin.HistoryNodeRow
-	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getHistoryNodesQuery,
+	driver := pdb.driver
+	if filter.ReadFromReplica {
+		driver = pdb.readDriver(config.SQLReplicaReadHistory)
+	}
+	err := driver.SelectContext(ctx, dbShardID, &rows, getHistoryNodesQuery,
 		filter.ShardID, filter.TreeID, filter.BranchID, *filter.MinNodeID, *filter.MaxNodeID, filter.PageSize)
 	// NOTE: since we let txn_id multiple by -1 when inserting, we have to revert it back here
 	for _, row := range rows {
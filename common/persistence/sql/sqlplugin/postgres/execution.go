@@ -181,20 +181,20 @@ VALUES     (:source_cluster_name,
 
 // InsertIntoExecutions inserts a row into executions table
 func (pdb *db) InsertIntoExecutions(ctx context.Context, row *sqlplugin.ExecutionsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, createExecutionQuery, row)
 }
 
 // UpdateExecutions updates a single row in executions table
 func (pdb *db) UpdateExecutions(ctx context.Context, row *sqlplugin.ExecutionsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, updateExecutionQuery, row)
 }
 
 // SelectFromExecutions reads a single row from executions table
 // The list execution query result is order by workflow ID only. It may returns duplicate record with pagination.
 func (pdb *db) SelectFromExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) ([]sqlplugin.ExecutionsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.ExecutionsRow
 	var err error
 	if len(filter.DomainID) == 0 && filter.Size > 0 {
@@ -216,13 +216,13 @@ func (pdb *db) SelectFromExecutions(ctx context.Context, filter *sqlplugin.Execu
 
 // DeleteFromExecutions deletes a single row from executions table
 func (pdb *db) DeleteFromExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	return pdb.driver.ExecContext(ctx, dbShardID, deleteExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 }
 
 // ReadLockExecutions acquires a write lock on a single row in executions table
 func (pdb *db) ReadLockExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) (int, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var nextEventID int
 	err := pdb.driver.GetContext(ctx, dbShardID, &nextEventID, readLockExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	return nextEventID, err
@@ -230,7 +230,7 @@ func (pdb *db) ReadLockExecutions(ctx context.Context, filter *sqlplugin.Executi
 
 // WriteLockExecutions acquires a write lock on a single row in executions table
 func (pdb *db) WriteLockExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) (int, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var nextEventID int
 	err := pdb.driver.GetContext(ctx, dbShardID, &nextEventID, writeLockExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	return nextEventID, err
@@ -238,19 +238,19 @@ func (pdb *db) WriteLockExecutions(ctx context.Context, filter *sqlplugin.Execut
 
 // InsertIntoCurrentExecutions inserts a single row into current_executions table
 func (pdb *db) InsertIntoCurrentExecutions(ctx context.Context, row *sqlplugin.CurrentExecutionsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, createCurrentExecutionQuery, row)
 }
 
 // UpdateCurrentExecutions updates a single row in current_executions table
 func (pdb *db) UpdateCurrentExecutions(ctx context.Context, row *sqlplugin.CurrentExecutionsRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, updateCurrentExecutionsQuery, row)
 }
 
 // SelectFromCurrentExecutions reads one or more rows from current_executions table
 func (pdb *db) SelectFromCurrentExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) (*sqlplugin.CurrentExecutionsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var row sqlplugin.CurrentExecutionsRow
 	err := pdb.driver.GetContext(ctx, dbShardID, &row, getCurrentExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID)
 	return &row, err
@@ -258,13 +258,13 @@ func (pdb *db) SelectFromCurrentExecutions(ctx context.Context, filter *sqlplugi
 
 // DeleteFromCurrentExecutions deletes a single row in current_executions table
 func (pdb *db) DeleteFromCurrentExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	return pdb.driver.ExecContext(ctx, dbShardID, deleteCurrentExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 }
 
 // LockCurrentExecutions acquires a write lock on a single row in current_executions table
 func (pdb *db) LockCurrentExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) (*sqlplugin.CurrentExecutionsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var row sqlplugin.CurrentExecutionsRow
 	err := pdb.driver.GetContext(ctx, dbShardID, &row, lockCurrentExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID)
 	return &row, err
@@ -273,7 +273,7 @@ func (pdb *db) LockCurrentExecutions(ctx context.Context, filter *sqlplugin.Curr
 // LockCurrentExecutionsJoinExecutions joins a row in current_executions with executions table and acquires a
 // write lock on the result
 func (pdb *db) LockCurrentExecutionsJoinExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) ([]sqlplugin.CurrentExecutionsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.CurrentExecutionsRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, lockCurrentExecutionJoinExecutionsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID)
 	return rows, err
@@ -284,13 +284,13 @@ func (pdb *db) InsertIntoTransferTasks(ctx context.Context, rows []sqlplugin.Tra
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	return pdb.driver.NamedExecContext(ctx, dbShardID, createTransferTasksQuery, rows)
 }
 
 // SelectFromTransferTasks reads one or more rows from transfer_tasks table
 func (pdb *db) SelectFromTransferTasks(ctx context.Context, filter *sqlplugin.TransferTasksFilter) ([]sqlplugin.TransferTasksRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.TransferTasksRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getTransferTasksQuery, filter.ShardID, filter.InclusiveMinTaskID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	if err != nil {
@@ -301,13 +301,13 @@ func (pdb *db) SelectFromTransferTasks(ctx context.Context, filter *sqlplugin.Tr
 
 // DeleteFromTransferTasks deletes one or more rows from transfer_tasks table
 func (pdb *db) DeleteFromTransferTasks(ctx context.Context, filter *sqlplugin.TransferTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	return pdb.driver.ExecContext(ctx, dbShardID, deleteTransferTaskQuery, filter.ShardID, filter.TaskID)
 }
 
 // RangeDeleteFromTransferTasks deletes multi rows from transfer_tasks table
 func (pdb *db) RangeDeleteFromTransferTasks(ctx context.Context, filter *sqlplugin.TransferTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if filter.PageSize > 0 {
 		return pdb.driver.ExecContext(ctx, dbShardID, rangeDeleteTransferTaskByBatchQuery, filter.ShardID, filter.InclusiveMinTaskID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	}
@@ -319,13 +319,13 @@ func (pdb *db) InsertIntoCrossClusterTasks(ctx context.Context, rows []sqlplugin
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	return pdb.driver.NamedExecContext(ctx, dbShardID, createCrossClusterTasksQuery, rows)
 }
 
 // SelectFromCrossClusterTasks reads one or more rows from cross_cluster_tasks table
 func (pdb *db) SelectFromCrossClusterTasks(ctx context.Context, filter *sqlplugin.CrossClusterTasksFilter) ([]sqlplugin.CrossClusterTasksRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.CrossClusterTasksRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getCrossClusterTasksQuery, filter.TargetCluster, filter.ShardID, filter.MinTaskID, filter.MaxTaskID, filter.PageSize)
 	if err != nil {
@@ -336,13 +336,13 @@ func (pdb *db) SelectFromCrossClusterTasks(ctx context.Context, filter *sqlplugi
 
 // DeleteFromCrossClusterTasks deletes one or more rows from cross_cluster_tasks table
 func (pdb *db) DeleteFromCrossClusterTasks(ctx context.Context, filter *sqlplugin.CrossClusterTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	return pdb.driver.ExecContext(ctx, dbShardID, deleteCrossClusterTaskQuery, filter.TargetCluster, filter.ShardID, filter.TaskID)
 }
 
 // RangeDeleteFromCrossClusterTasks deletes multi rows from cross_cluster_tasks table
 func (pdb *db) RangeDeleteFromCrossClusterTasks(ctx context.Context, filter *sqlplugin.CrossClusterTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if filter.PageSize > 0 {
 		return pdb.driver.ExecContext(ctx, dbShardID, rangeDeleteCrossClusterTaskByBatchQuery, filter.TargetCluster, filter.ShardID, filter.MinTaskID, filter.MaxTaskID, filter.PageSize)
 	}
@@ -354,7 +354,7 @@ func (pdb *db) InsertIntoTimerTasks(ctx context.Context, rows []sqlplugin.TimerT
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	for i := range rows {
 		rows[i].VisibilityTimestamp = pdb.converter.ToPostgresDateTime(rows[i].VisibilityTimestamp)
 	}
@@ -363,7 +363,7 @@ func (pdb *db) InsertIntoTimerTasks(ctx context.Context, rows []sqlplugin.TimerT
 
 // SelectFromTimerTasks reads one or more rows from timer_tasks table
 func (pdb *db) SelectFromTimerTasks(ctx context.Context, filter *sqlplugin.TimerTasksFilter) ([]sqlplugin.TimerTasksRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.TimerTasksRow
 	filter.MinVisibilityTimestamp = pdb.converter.ToPostgresDateTime(filter.MinVisibilityTimestamp)
 	filter.MaxVisibilityTimestamp = pdb.converter.ToPostgresDateTime(filter.MaxVisibilityTimestamp)
@@ -380,14 +380,14 @@ func (pdb *db) SelectFromTimerTasks(ctx context.Context, filter *sqlplugin.Timer
 
 // DeleteFromTimerTasks deletes one or more rows from timer_tasks table
 func (pdb *db) DeleteFromTimerTasks(ctx context.Context, filter *sqlplugin.TimerTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	filter.VisibilityTimestamp = pdb.converter.ToPostgresDateTime(filter.VisibilityTimestamp)
 	return pdb.driver.ExecContext(ctx, dbShardID, deleteTimerTaskQuery, filter.ShardID, filter.VisibilityTimestamp, filter.TaskID)
 }
 
 // RangeDeleteFromTimerTasks deletes multi rows from timer_tasks table
 func (pdb *db) RangeDeleteFromTimerTasks(ctx context.Context, filter *sqlplugin.TimerTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	filter.MinVisibilityTimestamp = pdb.converter.ToPostgresDateTime(filter.MinVisibilityTimestamp)
 	filter.MaxVisibilityTimestamp = pdb.converter.ToPostgresDateTime(filter.MaxVisibilityTimestamp)
 	if filter.PageSize > 0 {
@@ -401,13 +401,13 @@ func (pdb *db) InsertIntoBufferedEvents(ctx context.Context, rows []sqlplugin.Bu
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	return pdb.driver.NamedExecContext(ctx, dbShardID, createBufferedEventsQuery, rows)
 }
 
 // SelectFromBufferedEvents reads one or more rows from buffered_events table
 func (pdb *db) SelectFromBufferedEvents(ctx context.Context, filter *sqlplugin.BufferedEventsFilter) ([]sqlplugin.BufferedEventsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.BufferedEventsRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getBufferedEventsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -421,7 +421,7 @@ func (pdb *db) SelectFromBufferedEvents(ctx context.Context, filter *sqlplugin.B
 
 // DeleteFromBufferedEvents deletes one or more rows from buffered_events table
 func (pdb *db) DeleteFromBufferedEvents(ctx context.Context, filter *sqlplugin.BufferedEventsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	return pdb.driver.ExecContext(ctx, dbShardID, deleteBufferedEventsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 }
 
@@ -430,13 +430,13 @@ func (pdb *db) InsertIntoReplicationTasks(ctx context.Context, rows []sqlplugin.
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(rows[0].ShardID, pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(rows[0].ShardID)
 	return pdb.driver.NamedExecContext(ctx, dbShardID, createReplicationTasksQuery, rows)
 }
 
 // SelectFromReplicationTasks reads one or more rows from replication_tasks table
 func (pdb *db) SelectFromReplicationTasks(ctx context.Context, filter *sqlplugin.ReplicationTasksFilter) ([]sqlplugin.ReplicationTasksRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.ReplicationTasksRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getReplicationTasksQuery, filter.ShardID, filter.InclusiveMinTaskID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	return rows, err
@@ -444,13 +444,13 @@ func (pdb *db) SelectFromReplicationTasks(ctx context.Context, filter *sqlplugin
 
 // DeleteFromReplicationTasks deletes one rows from replication_tasks table
 func (pdb *db) DeleteFromReplicationTasks(ctx context.Context, filter *sqlplugin.ReplicationTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	return pdb.driver.ExecContext(ctx, dbShardID, deleteReplicationTaskQuery, filter.ShardID, filter.TaskID)
 }
 
 // RangeDeleteFromReplicationTasks deletes multi rows from replication_tasks table
 func (pdb *db) RangeDeleteFromReplicationTasks(ctx context.Context, filter *sqlplugin.ReplicationTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if filter.PageSize > 0 {
 		return pdb.driver.ExecContext(ctx, dbShardID, rangeDeleteReplicationTaskByBatchQuery, filter.ShardID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	}
@@ -459,13 +459,13 @@ func (pdb *db) RangeDeleteFromReplicationTasks(ctx context.Context, filter *sqlp
 
 // InsertIntoReplicationTasksDLQ inserts one or more rows into replication_tasks_dlq table
 func (pdb *db) InsertIntoReplicationTasksDLQ(ctx context.Context, row *sqlplugin.ReplicationTaskDLQRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(row.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(row.ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, insertReplicationTaskDLQQuery, row)
 }
 
 // SelectFromReplicationTasksDLQ reads one or more rows from replication_tasks_dlq table
 func (pdb *db) SelectFromReplicationTasksDLQ(ctx context.Context, filter *sqlplugin.ReplicationTasksDLQFilter) ([]sqlplugin.ReplicationTasksRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.ReplicationTasksRow
 	err := pdb.driver.SelectContext(
 		ctx,
@@ -481,7 +481,7 @@ func (pdb *db) SelectFromReplicationTasksDLQ(ctx context.Context, filter *sqlplu
 
 // SelectFromReplicationDLQ reads one row from replication_tasks_dlq table
 func (pdb *db) SelectFromReplicationDLQ(ctx context.Context, filter *sqlplugin.ReplicationTaskDLQFilter) (int64, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var size []int64
 	if err := pdb.driver.SelectContext(
 		ctx,
@@ -500,7 +500,7 @@ func (pdb *db) DeleteMessageFromReplicationTasksDLQ(
 	ctx context.Context,
 	filter *sqlplugin.ReplicationTasksDLQFilter,
 ) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	return pdb.driver.ExecContext(
 		ctx,
 		dbShardID,
@@ -516,7 +516,7 @@ func (pdb *db) RangeDeleteMessageFromReplicationTasksDLQ(
 	ctx context.Context,
 	filter *sqlplugin.ReplicationTasksDLQFilter,
 ) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if filter.PageSize > 0 {
 		return pdb.driver.ExecContext(
 			ctx,
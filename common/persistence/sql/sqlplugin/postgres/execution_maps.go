@@ -164,7 +164,7 @@ func (pdb *db) ReplaceIntoActivityInfoMaps(ctx context.Context, rows []sqlplugin
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	for i := range rows {
 		rows[i].LastHeartbeatUpdatedTime = pdb.converter.ToPostgresDateTime(rows[i].LastHeartbeatUpdatedTime)
 	}
@@ -173,7 +173,7 @@ func (pdb *db) ReplaceIntoActivityInfoMaps(ctx context.Context, rows []sqlplugin
 
 // SelectFromActivityInfoMaps reads one or more rows from activity_info_maps table
 func (pdb *db) SelectFromActivityInfoMaps(ctx context.Context, filter *sqlplugin.ActivityInfoMapsFilter) ([]sqlplugin.ActivityInfoMapsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.ActivityInfoMapsRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getActivityInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -188,7 +188,7 @@ func (pdb *db) SelectFromActivityInfoMaps(ctx context.Context, filter *sqlplugin
 
 // DeleteFromActivityInfoMaps deletes one or more rows from activity_info_maps table
 func (pdb *db) DeleteFromActivityInfoMaps(ctx context.Context, filter *sqlplugin.ActivityInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.ScheduleIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInActivityInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.ScheduleIDs)
 		if err != nil {
@@ -218,13 +218,13 @@ func (pdb *db) ReplaceIntoTimerInfoMaps(ctx context.Context, rows []sqlplugin.Ti
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, setKeyInTimerInfoMapSQLQuery, rows)
 }
 
 // SelectFromTimerInfoMaps reads one or more rows from timer_info_maps table
 func (pdb *db) SelectFromTimerInfoMaps(ctx context.Context, filter *sqlplugin.TimerInfoMapsFilter) ([]sqlplugin.TimerInfoMapsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.TimerInfoMapsRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getTimerInfoMapSQLQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -238,7 +238,7 @@ func (pdb *db) SelectFromTimerInfoMaps(ctx context.Context, filter *sqlplugin.Ti
 
 // DeleteFromTimerInfoMaps deletes one or more rows from timer_info_maps table
 func (pdb *db) DeleteFromTimerInfoMaps(ctx context.Context, filter *sqlplugin.TimerInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.TimerIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInTimerInfoMapSQLQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.TimerIDs)
 		if err != nil {
@@ -268,13 +268,13 @@ func (pdb *db) ReplaceIntoChildExecutionInfoMaps(ctx context.Context, rows []sql
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, setKeyInChildExecutionInfoMapQry, rows)
 }
 
 // SelectFromChildExecutionInfoMaps reads one or more rows from child_execution_info_maps table
 func (pdb *db) SelectFromChildExecutionInfoMaps(ctx context.Context, filter *sqlplugin.ChildExecutionInfoMapsFilter) ([]sqlplugin.ChildExecutionInfoMapsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.ChildExecutionInfoMapsRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getChildExecutionInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -288,7 +288,7 @@ func (pdb *db) SelectFromChildExecutionInfoMaps(ctx context.Context, filter *sql
 
 // DeleteFromChildExecutionInfoMaps deletes one or more rows from child_execution_info_maps table
 func (pdb *db) DeleteFromChildExecutionInfoMaps(ctx context.Context, filter *sqlplugin.ChildExecutionInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.InitiatedIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInChildExecutionInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.InitiatedIDs)
 		if err != nil {
@@ -318,13 +318,13 @@ func (pdb *db) ReplaceIntoRequestCancelInfoMaps(ctx context.Context, rows []sqlp
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, setKeyInRequestCancelInfoMapQry, rows)
 }
 
 // SelectFromRequestCancelInfoMaps reads one or more rows from request_cancel_info_maps table
 func (pdb *db) SelectFromRequestCancelInfoMaps(ctx context.Context, filter *sqlplugin.RequestCancelInfoMapsFilter) ([]sqlplugin.RequestCancelInfoMapsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.RequestCancelInfoMapsRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getRequestCancelInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -338,7 +338,7 @@ func (pdb *db) SelectFromRequestCancelInfoMaps(ctx context.Context, filter *sqlp
 
 // DeleteFromRequestCancelInfoMaps deletes one or more rows from request_cancel_info_maps table
 func (pdb *db) DeleteFromRequestCancelInfoMaps(ctx context.Context, filter *sqlplugin.RequestCancelInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.InitiatedIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInRequestCancelInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.InitiatedIDs)
 		if err != nil {
@@ -368,13 +368,13 @@ func (pdb *db) ReplaceIntoSignalInfoMaps(ctx context.Context, rows []sqlplugin.S
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, setKeyInSignalInfoMapQry, rows)
 }
 
 // SelectFromSignalInfoMaps reads one or more rows from signal_info_maps table
 func (pdb *db) SelectFromSignalInfoMaps(ctx context.Context, filter *sqlplugin.SignalInfoMapsFilter) ([]sqlplugin.SignalInfoMapsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.SignalInfoMapsRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getSignalInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -388,7 +388,7 @@ func (pdb *db) SelectFromSignalInfoMaps(ctx context.Context, filter *sqlplugin.S
 
 // DeleteFromSignalInfoMaps deletes one or more rows from signal_info_maps table
 func (pdb *db) DeleteFromSignalInfoMaps(ctx context.Context, filter *sqlplugin.SignalInfoMapsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.InitiatedIDs) > 0 {
 		query, args, err := sqlx.In(deleteKeyInSignalInfoMapQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.InitiatedIDs)
 		if err != nil {
@@ -404,13 +404,13 @@ func (pdb *db) InsertIntoSignalsRequestedSets(ctx context.Context, rows []sqlplu
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return pdb.driver.NamedExecContext(ctx, dbShardID, createSignalsRequestedSetQuery, rows)
 }
 
 // SelectFromSignalsRequestedSets reads one or more rows from signals_requested_sets table
 func (pdb *db) SelectFromSignalsRequestedSets(ctx context.Context, filter *sqlplugin.SignalsRequestedSetsFilter) ([]sqlplugin.SignalsRequestedSetsRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rows []sqlplugin.SignalsRequestedSetsRow
 	err := pdb.driver.SelectContext(ctx, dbShardID, &rows, getSignalsRequestedSetQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	for i := 0; i < len(rows); i++ {
@@ -424,7 +424,7 @@ func (pdb *db) SelectFromSignalsRequestedSets(ctx context.Context, filter *sqlpl
 
 // DeleteFromSignalsRequestedSets deletes one or more rows from signals_requested_sets table
 func (pdb *db) DeleteFromSignalsRequestedSets(ctx context.Context, filter *sqlplugin.SignalsRequestedSetsFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), pdb.GetTotalNumDBShards())
+	dbShardID := pdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	if len(filter.SignalIDs) > 0 {
 		query, args, err := sqlx.In(deleteSignalsRequestedSetQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID, filter.SignalIDs)
 		if err != nil {
@@ -60,7 +60,23 @@ func (d *plugin) CreateDB(cfg *config.SQL) (sqlplugin.DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	return newDB(conns, nil, sqlplugin.DbShardUndefined, cfg.NumShards)
+	pdb, err := newDB(conns, nil, sqlplugin.DbShardUndefined, cfg.NumShards)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.UseMultipleDatabases {
+		if err := pdb.startHistoryShardPlacements(); err != nil {
+			pdb.Close()
+			return nil, err
+		}
+	}
+	pdb.replicas, err = sqldriver.CreateReadReplicas(cfg, d.createSingleDBConn, replicationLag)
+	if err != nil {
+		pdb.Close()
+		return nil, err
+	}
+	pdb.replicas.Start()
+	return pdb, nil
 }
 
 // CreateAdminDB initialize the adminDB object
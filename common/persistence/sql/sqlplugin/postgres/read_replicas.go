@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/uber/cadence/common/persistence/sql/sqldriver"
+)
+
+// replicationStatusQry reads what the replication lag of a read replica is derived from. The WAL receiver is only
+// reported to roles with the privileges of pg_read_all_stats, others see a NULL status
+const replicationStatusQry = `SELECT pg_is_in_recovery() AS in_recovery,
+ EXISTS (SELECT 1 FROM pg_stat_wal_receiver WHERE status = 'streaming') AS streaming,
+ pg_last_wal_receive_lsn() IS NOT DISTINCT FROM pg_last_wal_replay_lsn() AS replayed_all,
+ EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()) AS last_replay_age`
+
+var (
+	errReplicationNotStreaming = errors.New("read replica is not streaming from the primary, its replication lag is unknown")
+	errUnknownReplicationLag   = errors.New("read replica did not replay any transaction yet, its replication lag is unknown")
+)
+
+type replicationStatus struct {
+	InRecovery    bool            `db:"in_recovery"`
+	Streaming     bool            `db:"streaming"`
+	ReplayedAll   bool            `db:"replayed_all"`
+	LastReplayAge sql.NullFloat64 `db:"last_replay_age"`
+}
+
+// readDriver returns the driver for an eventually consistent read, which is sent to a read replica if the read is routed to them
+func (pdb *db) readDriver(read string) sqldriver.Driver {
+	return pdb.replicas.ReadDriver(pdb.driver, read)
+}
+
+// replicationLag returns how long ago a read replica replayed the last transaction it received
+func replicationLag(ctx context.Context, db *sqlx.DB) (time.Duration, error) {
+	var status replicationStatus
+	if err := db.GetContext(ctx, &status, replicationStatusQry); err != nil {
+		return 0, err
+	}
+	return status.lag()
+}
+
+func (s replicationStatus) lag() (time.Duration, error) {
+	if !s.InRecovery {
+		return 0, nil
+	}
+	// a replica which is disconnected from the primary has replayed everything it received, but
+	// does not know how much it did not receive
+	if !s.Streaming {
+		return 0, errReplicationNotStreaming
+	}
+	// a replica which replayed everything it received is not lagging behind, even if the primary has not written for a while
+	if s.ReplayedAll {
+		return 0, nil
+	}
+	if !s.LastReplayAge.Valid {
+		return 0, errUnknownReplicationLag
+	}
+	return time.Duration(s.LastReplayAge.Float64 * float64(time.Second)), nil
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package postgres
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicationStatusLag(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  replicationStatus
+		want    time.Duration
+		wantErr error
+	}{
+		{
+			name:   "not a replica",
+			status: replicationStatus{},
+			want:   0,
+		},
+		{
+			name: "streaming and replayed everything it received",
+			status: replicationStatus{
+				InRecovery:    true,
+				Streaming:     true,
+				ReplayedAll:   true,
+				LastReplayAge: sql.NullFloat64{Float64: 600, Valid: true},
+			},
+			want: 0,
+		},
+		{
+			name: "streaming and replaying",
+			status: replicationStatus{
+				InRecovery:    true,
+				Streaming:     true,
+				LastReplayAge: sql.NullFloat64{Float64: 2.5, Valid: true},
+			},
+			want: 2500 * time.Millisecond,
+		},
+		{
+			name: "streaming without any replayed transaction",
+			status: replicationStatus{
+				InRecovery: true,
+				Streaming:  true,
+			},
+			wantErr: errUnknownReplicationLag,
+		},
+		{
+			name: "disconnected after replaying everything it received",
+			status: replicationStatus{
+				InRecovery:    true,
+				ReplayedAll:   true,
+				LastReplayAge: sql.NullFloat64{Float64: 600, Valid: true},
+			},
+			wantErr: errReplicationNotStreaming,
+		},
+		{
+			name: "disconnected while replaying",
+			status: replicationStatus{
+				InRecovery:    true,
+				LastReplayAge: sql.NullFloat64{Float64: 1, Valid: true},
+			},
+			wantErr: errReplicationNotStreaming,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lag, err := tt.status.lag()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, lag)
+		})
+	}
+}
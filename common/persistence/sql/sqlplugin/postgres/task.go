@@ -73,12 +73,12 @@ task_type = :task_type
 	lockTaskListQry = `SELECT range_id FROM task_lists ` +
 		`WHERE shard_id = $1 AND domain_id = $2 AND name = $3 AND task_type = $4 FOR UPDATE`
 
-	getTaskMinMaxQry = `SELECT task_id, data, data_encoding ` +
+	getTaskMinMaxQry = `SELECT task_id, data, data_encoding, priority ` +
 		`FROM tasks ` +
 		`WHERE domain_id = $1 AND task_list_name = $2 AND task_type = $3 AND task_id > $4 AND task_id <= $5 ` +
 		` ORDER BY task_id LIMIT $6`
 
-	getTaskMinQry = `SELECT task_id, data, data_encoding ` +
+	getTaskMinQry = `SELECT task_id, data, data_encoding, priority ` +
 		`FROM tasks ` +
 		`WHERE domain_id = $1 AND task_list_name = $2 AND task_type = $3 AND task_id > $4 ORDER BY task_id LIMIT $5`
 
@@ -87,8 +87,8 @@ task_type = :task_type
 		`WHERE domain_id = $1 AND task_list_name = $2 AND task_type = $3 AND task_id > $4`
 
 	createTaskQry = `INSERT INTO ` +
-		`tasks(domain_id, task_list_name, task_type, task_id, data, data_encoding) ` +
-		`VALUES(:domain_id, :task_list_name, :task_type, :task_id, :data, :data_encoding)`
+		`tasks(domain_id, task_list_name, task_type, task_id, data, data_encoding, priority) ` +
+		`VALUES(:domain_id, :task_list_name, :task_type, :task_id, :data, :data_encoding, :priority)`
 
 	deleteTaskQry = `DELETE FROM tasks ` +
 		`WHERE domain_id = $1 AND task_list_name = $2 AND task_type = $3 AND task_id = $4`
@@ -26,19 +26,24 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/jmoiron/sqlx"
+
+	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+	"github.com/uber/cadence/common/types"
 )
 
 const (
 	templateCreateWorkflowExecutionStarted = `INSERT INTO executions_visibility (` +
-		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time) ` +
-		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
          ON CONFLICT (domain_id, run_id) DO NOTHING`
 
 	templateCreateWorkflowExecutionClosed = `INSERT INTO executions_visibility (` +
-		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, close_time, close_status, history_length, memo, encoding, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time) ` +
-		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, close_time, close_status, history_length, memo, encoding, is_cron, num_clusters, update_time, shard_id, execution_status, cron_schedule, scheduled_execution_time, search_attributes) ` +
+		`VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		ON CONFLICT (domain_id, run_id) DO UPDATE
 		  SET workflow_id = excluded.workflow_id,
 		      start_time = excluded.start_time,
@@ -55,7 +60,8 @@ const (
 				shard_id = excluded.shard_id,
 				execution_status = excluded.execution_status,
 				cron_schedule = excluded.cron_schedule,
-				scheduled_execution_time = excluded.scheduled_execution_time`
+				scheduled_execution_time = excluded.scheduled_execution_time,
+				search_attributes = excluded.search_attributes`
 
 	// RunID condition is needed for correct pagination
 	templateConditions1 = ` AND domain_id = $1
@@ -98,6 +104,19 @@ const (
 		 AND run_id = $2`
 
 	templateDeleteWorkflowExecution = "DELETE FROM executions_visibility WHERE domain_id=$1 AND run_id=$2"
+
+	templateUpdateWorkflowExecution = `UPDATE executions_visibility
+		 SET memo = $1, encoding = $2, search_attributes = $3, update_time = $4, execution_status = $5
+		 WHERE domain_id = $6 AND run_id = $7 AND close_status IS NULL`
+
+	// the queries below use ? as placeholders as their conditions are built by the visibility store,
+	// they are rebound before being executed
+	templateSelectByQuery = `SELECT ` + templateOpenFieldNames + `, close_time, close_status, history_length, search_attributes
+		 FROM executions_visibility WHERE domain_id = ?`
+
+	templateCountByQuery = `SELECT COUNT(*) FROM executions_visibility WHERE domain_id = ?`
+
+	templateDefaultOrderBy = `start_time DESC, run_id`
 )
 
 var errCloseParams = errors.New("missing one of {closeStatus, closeTime, historyLength} params")
@@ -123,7 +142,8 @@ func (pdb *db) InsertIntoVisibility(ctx context.Context, row *sqlplugin.Visibili
 		row.ShardID,
 		row.ExecutionStatus,
 		row.CronSchedule,
-		scheduledExecutionTime)
+		scheduledExecutionTime,
+		jsonColumnValue(row.SearchAttributes))
 }
 
 // ReplaceIntoVisibility replaces an existing row if it exist or creates a new row in visibility table
@@ -152,7 +172,8 @@ func (pdb *db) ReplaceIntoVisibility(ctx context.Context, row *sqlplugin.Visibil
 			row.ShardID,
 			row.ExecutionStatus,
 			row.CronSchedule,
-			scheduledExecutionTime)
+			scheduledExecutionTime,
+			jsonColumnValue(row.SearchAttributes))
 	default:
 		return nil, errCloseParams
 	}
@@ -166,6 +187,7 @@ func (pdb *db) DeleteFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 
 // SelectFromVisibility reads one or more rows from visibility table
 func (pdb *db) SelectFromVisibility(ctx context.Context, filter *sqlplugin.VisibilityFilter) ([]sqlplugin.VisibilityRow, error) {
+	driver := pdb.readDriver(config.SQLReplicaReadVisibility)
 	dbShardID := sqlplugin.GetDBShardIDFromDomainID(filter.DomainID, pdb.GetTotalNumDBShards())
 	var err error
 	var rows []sqlplugin.VisibilityRow
@@ -178,7 +200,7 @@ func (pdb *db) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 	switch {
 	case filter.MinStartTime == nil && filter.RunID != nil && filter.Closed:
 		var row sqlplugin.VisibilityRow
-		err = pdb.driver.GetContext(ctx, dbShardID, &row, templateGetClosedWorkflowExecution, filter.DomainID, *filter.RunID)
+		err = driver.GetContext(ctx, dbShardID, &row, templateGetClosedWorkflowExecution, filter.DomainID, *filter.RunID)
 		if err == nil {
 			rows = append(rows, row)
 		}
@@ -187,7 +209,7 @@ func (pdb *db) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 		if filter.Closed {
 			qry = templateGetClosedWorkflowExecutionsByID
 		}
-		err = pdb.driver.SelectContext(ctx, dbShardID, &rows,
+		err = driver.SelectContext(ctx, dbShardID, &rows,
 			qry,
 			*filter.WorkflowID,
 			filter.DomainID,
@@ -201,7 +223,7 @@ func (pdb *db) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 		if filter.Closed {
 			qry = templateGetClosedWorkflowExecutionsByType
 		}
-		err = pdb.driver.SelectContext(ctx, dbShardID, &rows,
+		err = driver.SelectContext(ctx, dbShardID, &rows,
 			qry,
 			*filter.WorkflowTypeName,
 			filter.DomainID,
@@ -211,7 +233,7 @@ func (pdb *db) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 			*filter.MaxStartTime,
 			*filter.PageSize)
 	case filter.MinStartTime != nil && filter.CloseStatus != nil:
-		err = pdb.driver.SelectContext(ctx, dbShardID, &rows,
+		err = driver.SelectContext(ctx, dbShardID, &rows,
 			templateGetClosedWorkflowExecutionsByStatus,
 			*filter.CloseStatus,
 			filter.DomainID,
@@ -227,7 +249,7 @@ func (pdb *db) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 		}
 		minSt := pdb.converter.ToPostgresDateTime(*filter.MinStartTime)
 		maxSt := pdb.converter.ToPostgresDateTime(*filter.MaxStartTime)
-		err = pdb.driver.SelectContext(ctx, dbShardID, &rows,
+		err = driver.SelectContext(ctx, dbShardID, &rows,
 			qry,
 			filter.DomainID,
 			minSt,
@@ -253,3 +275,120 @@ func (pdb *db) SelectFromVisibility(ctx context.Context, filter *sqlplugin.Visib
 	}
 	return rows, err
 }
+
+// UpdateVisibility updates the memo, search attributes and status of an open workflow in visibility table
+func (pdb *db) UpdateVisibility(ctx context.Context, row *sqlplugin.VisibilityRow) (sql.Result, error) {
+	dbShardID := sqlplugin.GetDBShardIDFromDomainID(row.DomainID, pdb.GetTotalNumDBShards())
+	return pdb.driver.ExecContext(ctx, dbShardID, templateUpdateWorkflowExecution,
+		row.Memo,
+		row.Encoding,
+		jsonColumnValue(row.SearchAttributes),
+		row.UpdateTime,
+		row.ExecutionStatus,
+		row.DomainID,
+		row.RunID)
+}
+
+// SelectFromVisibilityByQuery reads one page of rows matching a visibility query from visibility table
+func (pdb *db) SelectFromVisibilityByQuery(ctx context.Context, filter *sqlplugin.VisibilityQueryFilter) ([]sqlplugin.VisibilityRow, error) {
+	driver := pdb.readDriver(config.SQLReplicaReadVisibility)
+	dbShardID := sqlplugin.GetDBShardIDFromDomainID(filter.DomainID, pdb.GetTotalNumDBShards())
+	query, args := pdb.visibilityQuery(templateSelectByQuery, filter)
+	orderBy := filter.OrderBy
+	if orderBy == "" {
+		orderBy = templateDefaultOrderBy
+	}
+	query += ` ORDER BY ` + orderBy + ` LIMIT ? OFFSET ?`
+	args = append(args, filter.PageSize, filter.Offset)
+
+	var rows []sqlplugin.VisibilityRow
+	if err := driver.SelectContext(ctx, dbShardID, &rows, sqlx.Rebind(sqlx.BindType(PluginName), query), args...); err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].DomainID = filter.DomainID
+		rows[i].StartTime = pdb.converter.FromPostgresDateTime(rows[i].StartTime)
+		rows[i].ExecutionTime = pdb.converter.FromPostgresDateTime(rows[i].ExecutionTime)
+		if rows[i].CloseTime != nil {
+			closeTime := pdb.converter.FromPostgresDateTime(*rows[i].CloseTime)
+			rows[i].CloseTime = &closeTime
+		}
+		rows[i].RunID = strings.TrimSpace(rows[i].RunID)
+		rows[i].WorkflowID = strings.TrimSpace(rows[i].WorkflowID)
+	}
+	return rows, nil
+}
+
+// CountFromVisibilityByQuery counts the rows matching a visibility query in visibility table
+func (pdb *db) CountFromVisibilityByQuery(ctx context.Context, filter *sqlplugin.VisibilityQueryFilter) (int64, error) {
+	driver := pdb.readDriver(config.SQLReplicaReadVisibility)
+	dbShardID := sqlplugin.GetDBShardIDFromDomainID(filter.DomainID, pdb.GetTotalNumDBShards())
+	query, args := pdb.visibilityQuery(templateCountByQuery, filter)
+	var count int64
+	err := driver.GetContext(ctx, dbShardID, &count, sqlx.Rebind(sqlx.BindType(PluginName), query), args...)
+	return count, err
+}
+
+// VisibilityQueryDialect returns the dialect used to query search attributes in visibility table
+func (pdb *db) VisibilityQueryDialect() sqlplugin.VisibilityQueryDialect {
+	return visibilityQueryDialect{}
+}
+
+func (pdb *db) visibilityQuery(query string, filter *sqlplugin.VisibilityQueryFilter) (string, []interface{}) {
+	args := []interface{}{filter.DomainID}
+	if filter.Condition != "" {
+		query += ` AND (` + filter.Condition + `)`
+	}
+	for _, arg := range filter.Args {
+		if t, ok := arg.(time.Time); ok {
+			arg = pdb.converter.ToPostgresDateTime(t)
+		}
+		args = append(args, arg)
+	}
+	return query, args
+}
+
+// jsonColumnValue returns the value to write into a JSONB column, which can't be written as bytea
+func jsonColumnValue(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
+
+// visibilityQueryDialect reads search attributes from the JSONB search_attributes column.
+// The expressions must match the ones of the expression indexes in the schema
+type visibilityQueryDialect struct{}
+
+func (visibilityQueryDialect) IntSearchAttribute(key string) string {
+	return fmt.Sprintf("((search_attributes->>'%s')::BIGINT)", key)
+}
+
+func (visibilityQueryDialect) DoubleSearchAttribute(key string) string {
+	return fmt.Sprintf("((search_attributes->>'%s')::DOUBLE PRECISION)", key)
+}
+
+func (visibilityQueryDialect) TextSearchAttribute(key string) string {
+	return fmt.Sprintf("(search_attributes->>'%s')", key)
+}
+
+func (visibilityQueryDialect) KeywordSearchAttributeContains(key string) string {
+	// an array contains a primitive value as well as the value itself
+	return fmt.Sprintf("(search_attributes->'%s') @> to_jsonb(?::TEXT)", key)
+}
+
+func (d visibilityQueryDialect) SearchAttributeIndex(key string, valueType types.IndexedValueType) string {
+	name := sqlplugin.SearchAttributeIndexName(key)
+	// CONCURRENTLY doesn't block the writes to the table while the index is built
+	switch valueType {
+	case types.IndexedValueTypeKeyword:
+		// GIN index, which is used by the @> operator
+		return fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON executions_visibility USING GIN ((search_attributes->'%s'))", name, key)
+	case types.IndexedValueTypeInt, types.IndexedValueTypeBool, types.IndexedValueTypeDatetime:
+		return fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON executions_visibility (domain_id, %s)", name, d.IntSearchAttribute(key))
+	case types.IndexedValueTypeDouble:
+		return fmt.Sprintf("CREATE INDEX CONCURRENTLY %s ON executions_visibility (domain_id, %s)", name, d.DoubleSearchAttribute(key))
+	default:
+		return ""
+	}
+}
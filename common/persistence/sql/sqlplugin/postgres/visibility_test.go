@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/uber/cadence/common/types"
+)
+
+// The statements of the default custom search attributes must match the indexes created by the schema
+func TestVisibilityQueryDialectSearchAttributeIndex(t *testing.T) {
+	tests := []struct {
+		key       string
+		valueType types.IndexedValueType
+		want      string
+	}{
+		{
+			key:       "CustomKeywordField",
+			valueType: types.IndexedValueTypeKeyword,
+			want:      "CREATE INDEX CONCURRENTLY by_custom_keyword_field ON executions_visibility USING GIN ((search_attributes->'CustomKeywordField'))",
+		},
+		{
+			key:       "CustomIntField",
+			valueType: types.IndexedValueTypeInt,
+			want:      "CREATE INDEX CONCURRENTLY by_custom_int_field ON executions_visibility (domain_id, ((search_attributes->>'CustomIntField')::BIGINT))",
+		},
+		{
+			key:       "CustomDoubleField",
+			valueType: types.IndexedValueTypeDouble,
+			want:      "CREATE INDEX CONCURRENTLY by_custom_double_field ON executions_visibility (domain_id, ((search_attributes->>'CustomDoubleField')::DOUBLE PRECISION))",
+		},
+		{
+			key:       "CustomBoolField",
+			valueType: types.IndexedValueTypeBool,
+			want:      "CREATE INDEX CONCURRENTLY by_custom_bool_field ON executions_visibility (domain_id, ((search_attributes->>'CustomBoolField')::BIGINT))",
+		},
+		{
+			key:       "CustomDatetimeField",
+			valueType: types.IndexedValueTypeDatetime,
+			want:      "CREATE INDEX CONCURRENTLY by_custom_datetime_field ON executions_visibility (domain_id, ((search_attributes->>'CustomDatetimeField')::BIGINT))",
+		},
+		{
+			key:       "CustomStringField",
+			valueType: types.IndexedValueTypeString,
+			want:      "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, visibilityQueryDialect{}.SearchAttributeIndex(tt.key, tt.valueType))
+		})
+	}
+}
@@ -42,7 +42,7 @@ const (
 )
 
 func (mdb *DB) InsertIntoActiveClusterSelectionPolicy(ctx context.Context, row *sqlplugin.ActiveClusterSelectionPolicyRow) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(row.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(row.ShardID)
 	return mdb.driver.ExecContext(
 		ctx,
 		dbShardID,
@@ -57,7 +57,7 @@ func (mdb *DB) InsertIntoActiveClusterSelectionPolicy(ctx context.Context, row *
 }
 
 func (mdb *DB) SelectFromActiveClusterSelectionPolicy(ctx context.Context, filter *sqlplugin.ActiveClusterSelectionPolicyFilter) (*sqlplugin.ActiveClusterSelectionPolicyRow, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	var row sqlplugin.ActiveClusterSelectionPolicyRow
 	err := mdb.driver.GetContext(
 		ctx,
@@ -76,7 +76,7 @@ func (mdb *DB) SelectFromActiveClusterSelectionPolicy(ctx context.Context, filte
 }
 
 func (mdb *DB) DeleteFromActiveClusterSelectionPolicy(ctx context.Context, filter *sqlplugin.ActiveClusterSelectionPolicyFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	return mdb.driver.ExecContext(
 		ctx,
 		dbShardID,
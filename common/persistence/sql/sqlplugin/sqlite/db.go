@@ -35,6 +35,8 @@ import (
 	_ "github.com/ncruces/go-sqlite3/driver"
 	// import embed sqlite db
 	_ "github.com/ncruces/go-sqlite3/embed"
+	// import memdb vfs, so in-memory databases can be opened by name with the vfs=memdb attribute
+	_ "github.com/ncruces/go-sqlite3/vfs/memdb"
 )
 
 var (
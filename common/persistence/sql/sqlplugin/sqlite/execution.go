@@ -86,7 +86,7 @@ WHERE (shard_id, visibility_timestamp, task_id) IN (SELECT shard_id, visibility_
 // ReadLockExecutions acquires a write lock on a single row in executions table
 func (mdb *DB) ReadLockExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) (int, error) {
 	var nextEventID int
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	err := mdb.driver.GetContext(ctx, dbShardID, &nextEventID, readLockExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	return nextEventID, err
 }
@@ -94,7 +94,7 @@ func (mdb *DB) ReadLockExecutions(ctx context.Context, filter *sqlplugin.Executi
 // WriteLockExecutions acquires a write lock on a single row in executions table
 func (mdb *DB) WriteLockExecutions(ctx context.Context, filter *sqlplugin.ExecutionsFilter) (int, error) {
 	var nextEventID int
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	err := mdb.driver.GetContext(ctx, dbShardID, &nextEventID, writeLockExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	return nextEventID, err
 }
@@ -103,7 +103,7 @@ func (mdb *DB) WriteLockExecutions(ctx context.Context, filter *sqlplugin.Execut
 // write lock on the result
 func (mdb *DB) LockCurrentExecutionsJoinExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) ([]sqlplugin.CurrentExecutionsRow, error) {
 	var rows []sqlplugin.CurrentExecutionsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.SelectContext(ctx, dbShardID, &rows, lockCurrentExecutionJoinExecutionsQuery, filter.ShardID, filter.DomainID, filter.WorkflowID)
 	return rows, err
 }
@@ -111,14 +111,14 @@ func (mdb *DB) LockCurrentExecutionsJoinExecutions(ctx context.Context, filter *
 // LockCurrentExecutions acquires a write lock on a single row in current_executions table
 func (mdb *DB) LockCurrentExecutions(ctx context.Context, filter *sqlplugin.CurrentExecutionsFilter) (*sqlplugin.CurrentExecutionsRow, error) {
 	var row sqlplugin.CurrentExecutionsRow
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	err := mdb.driver.GetContext(ctx, dbShardID, &row, lockCurrentExecutionQuery, filter.ShardID, filter.DomainID, filter.WorkflowID)
 	return &row, err
 }
 
 // RangeDeleteFromTransferTasks deletes multi rows from transfer_tasks table
 func (mdb *DB) RangeDeleteFromTransferTasks(ctx context.Context, filter *sqlplugin.TransferTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if filter.PageSize > 0 {
 		return mdb.driver.ExecContext(ctx, dbShardID, rangeDeleteTransferTaskByBatchQuery, filter.ShardID, filter.InclusiveMinTaskID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	}
@@ -127,7 +127,7 @@ func (mdb *DB) RangeDeleteFromTransferTasks(ctx context.Context, filter *sqlplug
 
 // RangeDeleteFromReplicationTasks deletes multi rows from replication_tasks table
 func (mdb *DB) RangeDeleteFromReplicationTasks(ctx context.Context, filter *sqlplugin.ReplicationTasksFilter) (sql.Result, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if filter.PageSize > 0 {
 		return mdb.driver.ExecContext(ctx, dbShardID, rangeDeleteReplicationTaskByBatchQuery, filter.ShardID, filter.ExclusiveMaxTaskID, filter.PageSize)
 	}
@@ -138,7 +138,7 @@ func (mdb *DB) RangeDeleteFromReplicationTasks(ctx context.Context, filter *sqlp
 func (mdb *DB) RangeDeleteFromTimerTasks(ctx context.Context, filter *sqlplugin.TimerTasksFilter) (sql.Result, error) {
 	filter.MinVisibilityTimestamp = mdb.converter.ToDateTime(filter.MinVisibilityTimestamp)
 	filter.MaxVisibilityTimestamp = mdb.converter.ToDateTime(filter.MaxVisibilityTimestamp)
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(filter.ShardID, mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(filter.ShardID)
 	if filter.PageSize > 0 {
 		return mdb.driver.ExecContext(ctx, dbShardID, rangeDeleteTimerTaskByBatchQuery, filter.ShardID, filter.MinVisibilityTimestamp, filter.MaxVisibilityTimestamp, filter.PageSize)
 	}
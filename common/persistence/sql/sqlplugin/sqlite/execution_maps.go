@@ -40,6 +40,6 @@ func (mdb *DB) InsertIntoSignalsRequestedSets(ctx context.Context, rows []sqlplu
 	if len(rows) == 0 {
 		return nil, nil
 	}
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(rows[0].ShardID))
 	return mdb.driver.NamedExecContext(ctx, dbShardID, createSignalsRequestedSetQry, rows)
 }
@@ -35,7 +35,7 @@ const (
 
 // WriteLockShards acquires a write lock on a single row in shards table
 func (mdb *DB) WriteLockShards(ctx context.Context, filter *sqlplugin.ShardsFilter) (int, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rangeID int
 	err := mdb.driver.GetContext(ctx, dbShardID, &rangeID, lockShardQry, filter.ShardID)
 	return rangeID, err
@@ -43,7 +43,7 @@ func (mdb *DB) WriteLockShards(ctx context.Context, filter *sqlplugin.ShardsFilt
 
 // ReadLockShards acquires a read lock on a single row in shards table
 func (mdb *DB) ReadLockShards(ctx context.Context, filter *sqlplugin.ShardsFilter) (int, error) {
-	dbShardID := sqlplugin.GetDBShardIDFromHistoryShardID(int(filter.ShardID), mdb.GetTotalNumDBShards())
+	dbShardID := mdb.GetDBShardIDFromHistoryShardID(int(filter.ShardID))
 	var rangeID int
 	err := mdb.driver.GetContext(ctx, dbShardID, &rangeID, readLockShardQry, filter.ShardID)
 	return rangeID, err
@@ -25,14 +25,16 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+	"github.com/uber/cadence/common/types"
 )
 
 const (
 	templateCreateWorkflowExecutionStarted = `INSERT OR IGNORE INTO executions_visibility (` +
-		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, is_cron, num_clusters, update_time, shard_id) ` +
-		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		`domain_id, workflow_id, run_id, start_time, execution_time, workflow_type_name, memo, encoding, is_cron, num_clusters, update_time, shard_id, search_attributes) ` +
+		`VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 )
 
 // InsertIntoVisibility inserts a row into visibility table. If an row already exist,
@@ -54,5 +56,51 @@ func (mdb *DB) InsertIntoVisibility(ctx context.Context, row *sqlplugin.Visibili
 		row.IsCron,
 		row.NumClusters,
 		row.UpdateTime,
-		row.ShardID)
+		row.ShardID,
+		jsonColumnValue(row.SearchAttributes))
+}
+
+// VisibilityQueryDialect returns the dialect used to query search attributes in visibility table
+func (mdb *DB) VisibilityQueryDialect() sqlplugin.VisibilityQueryDialect {
+	return visibilityQueryDialect{}
+}
+
+// jsonColumnValue returns the value to write into a JSON text column. JSON functions
+// don't accept documents stored as BLOB
+func jsonColumnValue(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
+
+// visibilityQueryDialect reads search attributes from the JSON text search_attributes column.
+// The expressions must match the ones of the expression indexes in the schema
+type visibilityQueryDialect struct{}
+
+func (visibilityQueryDialect) IntSearchAttribute(key string) string {
+	return fmt.Sprintf("json_extract(search_attributes, '$.%s')", key)
+}
+
+func (visibilityQueryDialect) DoubleSearchAttribute(key string) string {
+	return fmt.Sprintf("json_extract(search_attributes, '$.%s')", key)
+}
+
+func (visibilityQueryDialect) TextSearchAttribute(key string) string {
+	return fmt.Sprintf("json_extract(search_attributes, '$.%s')", key)
+}
+
+func (visibilityQueryDialect) KeywordSearchAttributeContains(key string) string {
+	// json_each returns a single row when the value is not an array
+	return fmt.Sprintf("EXISTS (SELECT 1 FROM json_each(search_attributes, '$.%s') WHERE json_each.value = ?)", key)
+}
+
+func (d visibilityQueryDialect) SearchAttributeIndex(key string, valueType types.IndexedValueType) string {
+	switch valueType {
+	case types.IndexedValueTypeInt, types.IndexedValueTypeBool, types.IndexedValueTypeDatetime, types.IndexedValueTypeDouble:
+		// keyword search attributes can't be indexed because json_each is a table-valued function
+		return fmt.Sprintf("CREATE INDEX %s ON executions_visibility (domain_id, %s)", sqlplugin.SearchAttributeIndexName(key), d.IntSearchAttribute(key))
+	default:
+		return ""
+	}
 }
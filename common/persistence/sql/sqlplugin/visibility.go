@@ -0,0 +1,43 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sqlplugin
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchAttributeIndexName returns the name of the index of a search attribute in executions_visibility table,
+// e.g. by_custom_keyword_field for CustomKeywordField
+func SearchAttributeIndexName(key string) string {
+	var name strings.Builder
+	name.WriteString("by_")
+	for i, r := range key {
+		// keys are validated to be ASCII, so the previous character is a single byte
+		if i > 0 && unicode.IsUpper(r) {
+			if prev := rune(key[i-1]); unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				name.WriteByte('_')
+			}
+		}
+		name.WriteRune(unicode.ToLower(r))
+	}
+	return name.String()
+}
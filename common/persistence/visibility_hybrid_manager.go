@@ -53,6 +53,27 @@ const (
 // ResponseComparatorContextKey is for Pinot/ES response comparator. This struct will be passed into ctx as a key.
 type ResponseComparatorContextKey string
 
+// VisibilityStoreOverrideKey is the context key that pins reads and writes of the hybrid visibility manager to a single store.
+type VisibilityStoreOverrideKey string
+
+const visibilityStoreOverrideKey = VisibilityStoreOverrideKey("visibilityStoreOverride")
+
+// WithVisibilityStoreOverride returns a context that makes the hybrid visibility manager read from and write to
+// the given store ("db", "es", "os" or "pinot") only, ignoring the read and write store dynamic configs.
+// It is used by the visibility backfill workflow to replay records into a store before reads are switched to it.
+func WithVisibilityStoreOverride(ctx context.Context, storeName string) context.Context {
+	return context.WithValue(ctx, visibilityStoreOverrideKey, storeName)
+}
+
+func getVisibilityStoreOverride(ctx context.Context) (string, bool) {
+	storeName, ok := ctx.Value(visibilityStoreOverrideKey).(string)
+	if !ok {
+		return "", false
+	}
+	storeName = strings.ToLower(strings.TrimSpace(storeName))
+	return storeName, storeName != ""
+}
+
 type OperationType string
 
 var Operation = struct {
@@ -220,6 +241,13 @@ func (v *visibilityHybridManager) chooseVisibilityModeForAdmin() string {
 }
 
 func (v *visibilityHybridManager) chooseVisibilityManagerForWrite(ctx context.Context, visFunc func(string) error) error {
+	if storeName, ok := getVisibilityStoreOverride(ctx); ok {
+		if _, err := v.getOverrideVisibilityManager(storeName); err != nil {
+			return err
+		}
+		return visFunc(storeName)
+	}
+
 	var writeMode string
 	if v.writeVisibilityStoreName != nil {
 		writeMode = v.writeVisibilityStoreName()
@@ -315,7 +343,10 @@ func (v *visibilityHybridManager) ListOpenWorkflowExecutions(
 	ctx context.Context,
 	request *ListWorkflowExecutionsRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ListOpenWorkflowExecutions, request, v.logger)
 	}
@@ -327,7 +358,10 @@ func (v *visibilityHybridManager) ListClosedWorkflowExecutions(
 	ctx context.Context,
 	request *ListWorkflowExecutionsRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ListClosedWorkflowExecutions, request, v.logger)
 	}
@@ -338,7 +372,10 @@ func (v *visibilityHybridManager) ListOpenWorkflowExecutionsByType(
 	ctx context.Context,
 	request *ListWorkflowExecutionsByTypeRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ListOpenWorkflowExecutionsByType, request, v.logger)
 	}
@@ -349,7 +386,10 @@ func (v *visibilityHybridManager) ListClosedWorkflowExecutionsByType(
 	ctx context.Context,
 	request *ListWorkflowExecutionsByTypeRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ListClosedWorkflowExecutionsByType, request, v.logger)
 	}
@@ -360,7 +400,10 @@ func (v *visibilityHybridManager) ListOpenWorkflowExecutionsByWorkflowID(
 	ctx context.Context,
 	request *ListWorkflowExecutionsByWorkflowIDRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ListOpenWorkflowExecutionsByWorkflowID, request, v.logger)
 	}
@@ -371,7 +414,10 @@ func (v *visibilityHybridManager) ListClosedWorkflowExecutionsByWorkflowID(
 	ctx context.Context,
 	request *ListWorkflowExecutionsByWorkflowIDRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ListClosedWorkflowExecutionsByWorkflowID, request, v.logger)
 	}
@@ -382,7 +428,10 @@ func (v *visibilityHybridManager) ListClosedWorkflowExecutionsByStatus(
 	ctx context.Context,
 	request *ListClosedWorkflowExecutionsByStatusRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ListClosedWorkflowExecutionsByStatus, request, v.logger)
 	}
@@ -393,7 +442,10 @@ func (v *visibilityHybridManager) GetClosedWorkflowExecution(
 	ctx context.Context,
 	request *GetClosedWorkflowExecutionRequest,
 ) (*GetClosedWorkflowExecutionResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.GetClosedWorkflowExecution, request, v.logger)
 	}
@@ -404,7 +456,10 @@ func (v *visibilityHybridManager) ListWorkflowExecutions(
 	ctx context.Context,
 	request *ListWorkflowExecutionsByQueryRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ListWorkflowExecutions, request, v.logger)
 	}
@@ -415,7 +470,10 @@ func (v *visibilityHybridManager) ScanWorkflowExecutions(
 	ctx context.Context,
 	request *ListWorkflowExecutionsByQueryRequest,
 ) (*ListWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.ScanWorkflowExecutions, request, v.logger)
 	}
@@ -426,14 +484,22 @@ func (v *visibilityHybridManager) CountWorkflowExecutions(
 	ctx context.Context,
 	request *CountWorkflowExecutionsRequest,
 ) (*CountWorkflowExecutionsResponse, error) {
-	manager, shadowMgr := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	manager, shadowMgr, err := v.chooseVisibilityManagerForRead(ctx, request.Domain)
+	if err != nil {
+		return nil, err
+	}
 	if shadowMgr != nil {
 		go shadow(shadowMgr.CountWorkflowExecutions, request, v.logger)
 	}
 	return manager.CountWorkflowExecutions(ctx, request)
 }
 
-func (v *visibilityHybridManager) chooseVisibilityManagerForRead(ctx context.Context, domain string) (VisibilityManager, VisibilityManager) {
+func (v *visibilityHybridManager) chooseVisibilityManagerForRead(ctx context.Context, domain string) (VisibilityManager, VisibilityManager, error) {
+	if storeName, ok := getVisibilityStoreOverride(ctx); ok {
+		mgr, err := v.getOverrideVisibilityManager(storeName)
+		return mgr, nil, err
+	}
+
 	var visibilityMgr, shadowMgr VisibilityManager
 	stores := strings.Split(v.readVisibilityStoreName(domain), ",")
 	for i := range stores {
@@ -452,7 +518,19 @@ func (v *visibilityHybridManager) chooseVisibilityManagerForRead(ctx context.Con
 		shadowMgr = v.visibilityMgrs[stores[1]]
 	}
 
-	return visibilityMgr, shadowMgr
+	return visibilityMgr, shadowMgr, nil
+}
+
+// getOverrideVisibilityManager returns the manager of a store pinned through WithVisibilityStoreOverride.
+// Unlike the dynamic config driven routing, it never falls back to db because callers rely on talking to exactly that store.
+func (v *visibilityHybridManager) getOverrideVisibilityManager(storeName string) (VisibilityManager, error) {
+	mgr, ok := v.visibilityMgrs[storeName]
+	if !ok || mgr == nil {
+		return nil, &types.BadRequestError{
+			Message: fmt.Sprintf("Visibility store %s is not configured", storeName),
+		}
+	}
+	return mgr, nil
 }
 
 func shadow[ReqT any, ResT any](f func(ctx context.Context, request ReqT) (ResT, error), request ReqT, logger log.Logger) {
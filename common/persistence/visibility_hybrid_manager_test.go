@@ -2217,3 +2217,77 @@ func TestVisibilityHybridCountWorkflowExecutions(t *testing.T) {
 		})
 	}
 }
+
+func TestVisibilityHybridStoreOverride(t *testing.T) {
+	closedRequest := &RecordWorkflowExecutionClosedRequest{Domain: "test-domain"}
+	listRequest := &ListWorkflowExecutionsByWorkflowIDRequest{
+		ListWorkflowExecutionsRequest: ListWorkflowExecutionsRequest{
+			Domain: "test-domain",
+		},
+	}
+
+	tests := map[string]struct {
+		overrideStore  string
+		withPinot      bool
+		mockAffordance func(dbMgr, esMgr, pinotMgr *MockVisibilityManager)
+		expectedError  bool
+	}{
+		"write and read only the overridden store": {
+			overrideStore: pinotStoreName,
+			withPinot:     true,
+			mockAffordance: func(dbMgr, esMgr, pinotMgr *MockVisibilityManager) {
+				pinotMgr.EXPECT().RecordWorkflowExecutionClosed(gomock.Any(), closedRequest).Return(nil).Times(1)
+				pinotMgr.EXPECT().ListOpenWorkflowExecutionsByWorkflowID(gomock.Any(), listRequest).Return(&ListWorkflowExecutionsResponse{}, nil).Times(1)
+			},
+		},
+		"override is case insensitive": {
+			overrideStore: " DB ",
+			mockAffordance: func(dbMgr, esMgr, pinotMgr *MockVisibilityManager) {
+				dbMgr.EXPECT().RecordWorkflowExecutionClosed(gomock.Any(), closedRequest).Return(nil).Times(1)
+				dbMgr.EXPECT().ListOpenWorkflowExecutionsByWorkflowID(gomock.Any(), listRequest).Return(&ListWorkflowExecutionsResponse{}, nil).Times(1)
+			},
+		},
+		"overridden store is not configured": {
+			overrideStore:  pinotStoreName,
+			mockAffordance: func(dbMgr, esMgr, pinotMgr *MockVisibilityManager) {},
+			expectedError:  true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			dbMgr := NewMockVisibilityManager(ctrl)
+			esMgr := NewMockVisibilityManager(ctrl)
+			pinotMgr := NewMockVisibilityManager(ctrl)
+			test.mockAffordance(dbMgr, esMgr, pinotMgr)
+
+			visibilityMgrs := map[string]VisibilityManager{
+				dbVisStoreName: dbMgr,
+				esStoreName:    esMgr,
+			}
+			if test.withPinot {
+				visibilityMgrs[pinotStoreName] = pinotMgr
+			}
+			visibilityManager := NewVisibilityHybridManager(
+				visibilityMgrs,
+				dynamicproperties.GetStringPropertyFnFilteredByDomain(dualStoreName),
+				dynamicproperties.GetStringPropertyFn(dualReadStoreName),
+				nil,
+				testStoreName,
+				log.NewNoop(),
+			)
+
+			ctx := WithVisibilityStoreOverride(context.Background(), test.overrideStore)
+			writeErr := visibilityManager.RecordWorkflowExecutionClosed(ctx, closedRequest)
+			_, readErr := visibilityManager.ListOpenWorkflowExecutionsByWorkflowID(ctx, listRequest)
+			if test.expectedError {
+				assert.Error(t, writeErr)
+				assert.Error(t, readErr)
+			} else {
+				assert.NoError(t, writeErr)
+				assert.NoError(t, readErr)
+			}
+		})
+	}
+}
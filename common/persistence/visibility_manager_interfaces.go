@@ -28,6 +28,9 @@
 // Generate metered wrapper.
 //go:generate gowrap gen -g -p . -i VisibilityManager -t ./wrappers/templates/metered.tmpl -o wrappers/metered/visibility_generated.go
 
+// Generate traced wrapper.
+//go:generate gowrap gen -g -p . -i VisibilityManager -t ./wrappers/templates/traced.tmpl -o wrappers/traced/visibility_generated.go
+
 package persistence
 
 import (
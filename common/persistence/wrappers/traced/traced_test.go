@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package traced
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/tracing"
+)
+
+func TestTracedShardManager(t *testing.T) {
+	tests := map[string]struct {
+		err            error
+		expectedStatus codes.Code
+	}{
+		"success": {
+			expectedStatus: codes.Unset,
+		},
+		"failure": {
+			err:            errors.New("persistence failure"),
+			expectedStatus: codes.Error,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			exporter := tracetest.NewInMemoryExporter()
+			provider := tracing.NewTracerProviderWithExporter(exporter, "cadence-history", 1)
+
+			mocked := persistence.NewMockShardManager(ctrl)
+			mocked.EXPECT().GetName().Return("mock")
+			mocked.EXPECT().GetShard(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, _ *persistence.GetShardRequest) (*persistence.GetShardResponse, error) {
+					assert.True(t, trace.SpanContextFromContext(ctx).IsValid(), "span must be propagated to the wrapped manager")
+					return &persistence.GetShardResponse{}, tc.err
+				})
+
+			wrapped := NewShardManager(mocked, provider)
+			assert.Equal(t, "mock", wrapped.GetName())
+			_, err := wrapped.GetShard(context.Background(), &persistence.GetShardRequest{ShardID: 1})
+			assert.Equal(t, tc.err, err)
+
+			require.NoError(t, provider.ForceFlush(context.Background()))
+			spans := exporter.GetSpans()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "ShardManager.GetShard", spans[0].Name)
+			assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind)
+			assert.Equal(t, tc.expectedStatus, spans[0].Status.Code)
+		})
+	}
+}
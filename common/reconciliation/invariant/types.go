@@ -66,6 +66,9 @@ const (
 	// MismatchedRecords checks that current and concrete execution records agree on close status
 	MismatchedRecords Name = "mismatched_records"
 
+	// VisibilityConsistent checks that the visibility record of an execution agrees with the execution in the primary store
+	VisibilityConsistent Name = "visibility_consistent"
+
 	// CollectionMutableState is the collection of invariants relating to mutable state
 	CollectionMutableState Collection = 0
 	// CollectionHistory is the collection  of invariants relating to history
@@ -0,0 +1,319 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dgryski/go-farm"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// visibility records are written asynchronously by the transfer queue,
+	// executions updated more recently than this are not checked to avoid reporting in-flight records
+	visibilityRecordGracePeriod = 10 * time.Minute
+	// visibility stores keep start time with different precisions
+	visibilityTimestampTolerance = time.Second
+	visibilityLookupPageSize     = 10
+
+	// VisibilityDriftMissingOpen means the execution is open but has no visibility record
+	VisibilityDriftMissingOpen = "missing_open"
+	// VisibilityDriftMissingClosed means the execution is closed but has no visibility record
+	VisibilityDriftMissingClosed = "missing_closed"
+	// VisibilityDriftStaleOpen means the execution is closed but visibility still has its open record
+	VisibilityDriftStaleOpen = "stale_open"
+	// VisibilityDriftUnexpectedClosed means the execution is open but visibility has a closed record
+	VisibilityDriftUnexpectedClosed = "unexpected_closed"
+	// VisibilityDriftCloseStatus means the close status in visibility differs from the execution
+	VisibilityDriftCloseStatus = "close_status_mismatch"
+)
+
+type (
+	visibilityConsistent struct {
+		pr                persistence.Retryer
+		dc                cache.DomainCache
+		visibilityManager persistence.VisibilityManager
+		historyClient     history.Client
+		timeSource        clock.TimeSource
+		scope             metrics.Scope
+		sampleRate        float64
+	}
+)
+
+// NewVisibilityConsistent returns a new invariant for checking that the visibility record of a concrete execution
+// agrees with the execution in the primary store. Only sampleRate of the executions are checked, sampled by run ID
+// so that a fixer with a sampleRate of 1 picks up all the executions reported by a scanner.
+// Corruptions are fixed by regenerating the tasks of the execution, which re-emits its visibility transfer task.
+func NewVisibilityConsistent(
+	pr persistence.Retryer,
+	dc cache.DomainCache,
+	visibilityManager persistence.VisibilityManager,
+	historyClient history.Client,
+	timeSource clock.TimeSource,
+	scope metrics.Scope,
+	sampleRate float64,
+) Invariant {
+	return &visibilityConsistent{
+		pr:                pr,
+		dc:                dc,
+		visibilityManager: visibilityManager,
+		historyClient:     historyClient,
+		timeSource:        timeSource,
+		scope:             scope,
+		sampleRate:        sampleRate,
+	}
+}
+
+func (v *visibilityConsistent) Check(
+	ctx context.Context,
+	execution interface{},
+) CheckResult {
+	if checkResult := validateCheckContext(ctx, v.Name()); checkResult != nil {
+		return *checkResult
+	}
+
+	concreteExecution, ok := execution.(*entity.ConcreteExecution)
+	if !ok {
+		return v.failed("failed to check: expected concrete execution", "")
+	}
+	if !v.sampled(concreteExecution.RunID) {
+		return v.healthy("skipped execution which is not sampled")
+	}
+	if v.visibilityManager == nil {
+		return v.failed("failed to check: visibility manager is not configured", "")
+	}
+	domainName, err := v.dc.GetDomainName(concreteExecution.DomainID)
+	if err != nil {
+		return v.failed("failed to fetch domain name", err.Error())
+	}
+
+	resp, err := v.pr.GetWorkflowExecution(ctx, &persistence.GetWorkflowExecutionRequest{
+		DomainID: concreteExecution.DomainID,
+		Execution: types.WorkflowExecution{
+			WorkflowID: concreteExecution.WorkflowID,
+			RunID:      concreteExecution.RunID,
+		},
+		DomainName: domainName,
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return v.healthy("skipped execution which no longer exists")
+		}
+		return v.failed("failed to get concrete execution", err.Error())
+	}
+	info := resp.State.ExecutionInfo
+	if !Open(info.State) && info.State != persistence.WorkflowStateCompleted {
+		// zombie and corrupted executions are never recorded in visibility
+		return v.healthy("skipped execution which is not recorded in visibility")
+	}
+	if v.timeSource.Now().Sub(info.LastUpdatedTimestamp) < visibilityRecordGracePeriod {
+		return v.healthy("skipped execution which was updated recently")
+	}
+
+	closedRecord, err := v.getClosedRecord(ctx, domainName, info)
+	if err != nil {
+		return v.failed("failed to get closed visibility record", err.Error())
+	}
+
+	if Open(info.State) {
+		if closedRecord != nil {
+			return v.corrupted(VisibilityDriftUnexpectedClosed, "execution is open but visibility has its closed record",
+				fmt.Sprintf("close status in visibility is %v", closedRecord.CloseStatus))
+		}
+		found, err := v.hasOpenRecord(ctx, domainName, info)
+		if err != nil {
+			return v.failed("failed to list open visibility records", err.Error())
+		}
+		if !found {
+			return v.corrupted(VisibilityDriftMissingOpen, "execution is open but has no visibility record", "")
+		}
+		return v.healthy("")
+	}
+
+	if closedRecord != nil {
+		expected := persistence.ToInternalWorkflowExecutionCloseStatus(info.CloseStatus)
+		actual := closedRecord.CloseStatus
+		if expected == nil || actual == nil || *expected != *actual {
+			return v.corrupted(VisibilityDriftCloseStatus, "close status in visibility does not match execution",
+				fmt.Sprintf("expected %v, visibility has %v", expected, actual))
+		}
+		return v.healthy("")
+	}
+	found, err := v.hasOpenRecord(ctx, domainName, info)
+	if err != nil {
+		return v.failed("failed to list open visibility records", err.Error())
+	}
+	if found {
+		return v.corrupted(VisibilityDriftStaleOpen, "execution is closed but visibility only has its open record", "")
+	}
+	return v.corrupted(VisibilityDriftMissingClosed, "execution is closed but has no visibility record", "")
+}
+
+func (v *visibilityConsistent) Fix(
+	ctx context.Context,
+	execution interface{},
+) FixResult {
+	if fixResult := validateFixContext(ctx, v.Name()); fixResult != nil {
+		return *fixResult
+	}
+
+	fixResult, checkResult := checkBeforeFix(ctx, v, execution)
+	if fixResult != nil {
+		return *fixResult
+	}
+	concreteExecution := execution.(*entity.ConcreteExecution)
+	domainName, err := v.dc.GetDomainName(concreteExecution.DomainID)
+	if err != nil {
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: v.Name(),
+			CheckResult:   *checkResult,
+			Info:          "failed to fetch domain name",
+			InfoDetails:   err.Error(),
+		}
+	}
+	if err := v.historyClient.RefreshWorkflowTasks(ctx, &types.HistoryRefreshWorkflowTasksRequest{
+		DomainUIID: concreteExecution.DomainID,
+		Request: &types.RefreshWorkflowTasksRequest{
+			Domain: domainName,
+			Execution: &types.WorkflowExecution{
+				WorkflowID: concreteExecution.WorkflowID,
+				RunID:      concreteExecution.RunID,
+			},
+		},
+	}); err != nil {
+		return FixResult{
+			FixResultType: FixResultTypeFailed,
+			InvariantName: v.Name(),
+			CheckResult:   *checkResult,
+			Info:          "failed to refresh workflow tasks",
+			InfoDetails:   err.Error(),
+		}
+	}
+	return FixResult{
+		FixResultType: FixResultTypeFixed,
+		InvariantName: v.Name(),
+		CheckResult:   *checkResult,
+		Info:          "refreshed workflow tasks to re-emit visibility task",
+	}
+}
+
+func (v *visibilityConsistent) Name() Name {
+	return VisibilityConsistent
+}
+
+func (v *visibilityConsistent) sampled(runID string) bool {
+	if v.sampleRate >= 1 {
+		return true
+	}
+	if v.sampleRate <= 0 {
+		return false
+	}
+	const buckets = 10000
+	return farm.Fingerprint32([]byte(runID))%buckets < uint32(v.sampleRate*buckets)
+}
+
+// getClosedRecord returns nil if visibility has no closed record of the execution
+func (v *visibilityConsistent) getClosedRecord(
+	ctx context.Context,
+	domainName string,
+	info *persistence.WorkflowExecutionInfo,
+) (*types.WorkflowExecutionInfo, error) {
+	resp, err := v.visibilityManager.GetClosedWorkflowExecution(ctx, &persistence.GetClosedWorkflowExecutionRequest{
+		DomainUUID: info.DomainID,
+		Domain:     domainName,
+		Execution: types.WorkflowExecution{
+			WorkflowID: info.WorkflowID,
+			RunID:      info.RunID,
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*types.EntityNotExistsError); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return resp.Execution, nil
+}
+
+func (v *visibilityConsistent) hasOpenRecord(
+	ctx context.Context,
+	domainName string,
+	info *persistence.WorkflowExecutionInfo,
+) (bool, error) {
+	startTime := info.StartTimestamp.UnixNano()
+	resp, err := v.visibilityManager.ListOpenWorkflowExecutionsByWorkflowID(ctx, &persistence.ListWorkflowExecutionsByWorkflowIDRequest{
+		ListWorkflowExecutionsRequest: persistence.ListWorkflowExecutionsRequest{
+			DomainUUID:   info.DomainID,
+			Domain:       domainName,
+			EarliestTime: startTime - int64(visibilityTimestampTolerance),
+			LatestTime:   startTime + int64(visibilityTimestampTolerance),
+			PageSize:     visibilityLookupPageSize,
+		},
+		WorkflowID: info.WorkflowID,
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, record := range resp.Executions {
+		if record.GetExecution().GetRunID() == info.RunID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (v *visibilityConsistent) healthy(info string) CheckResult {
+	return CheckResult{
+		CheckResultType: CheckResultTypeHealthy,
+		InvariantName:   v.Name(),
+		Info:            info,
+	}
+}
+
+func (v *visibilityConsistent) failed(info, details string) CheckResult {
+	return CheckResult{
+		CheckResultType: CheckResultTypeFailed,
+		InvariantName:   v.Name(),
+		Info:            info,
+		InfoDetails:     details,
+	}
+}
+
+func (v *visibilityConsistent) corrupted(driftType, info, details string) CheckResult {
+	v.scope.Tagged(metrics.VisibilityDriftTypeTag(driftType)).IncCounter(metrics.ScannerVisibilityDriftCount)
+	return CheckResult{
+		CheckResultType: CheckResultTypeCorrupted,
+		InvariantName:   v.Name(),
+		Info:            info,
+		InfoDetails:     details,
+	}
+}
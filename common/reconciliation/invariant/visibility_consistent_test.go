@@ -0,0 +1,438 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package invariant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber-go/tally"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestVisibilityConsistent_Check(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	startTime := now.Add(-time.Hour)
+	updatedTime := now.Add(-time.Hour)
+
+	executionResponse := func(state, closeStatus int, lastUpdated time.Time) *persistence.GetWorkflowExecutionResponse {
+		return &persistence.GetWorkflowExecutionResponse{
+			State: &persistence.WorkflowMutableState{
+				ExecutionInfo: &persistence.WorkflowExecutionInfo{
+					DomainID:             domainID,
+					WorkflowID:           workflowID,
+					RunID:                runID,
+					State:                state,
+					CloseStatus:          closeStatus,
+					StartTimestamp:       startTime,
+					LastUpdatedTimestamp: lastUpdated,
+				},
+			},
+		}
+	}
+	closedRecord := func(status types.WorkflowExecutionCloseStatus) *persistence.GetClosedWorkflowExecutionResponse {
+		return &persistence.GetClosedWorkflowExecutionResponse{
+			Execution: &types.WorkflowExecutionInfo{
+				Execution:   &types.WorkflowExecution{WorkflowID: workflowID, RunID: runID},
+				CloseStatus: status.Ptr(),
+			},
+		}
+	}
+	openRecords := func(runIDs ...string) *persistence.ListWorkflowExecutionsResponse {
+		resp := &persistence.ListWorkflowExecutionsResponse{}
+		for _, id := range runIDs {
+			resp.Executions = append(resp.Executions, &types.WorkflowExecutionInfo{
+				Execution: &types.WorkflowExecution{WorkflowID: workflowID, RunID: id},
+			})
+		}
+		return resp
+	}
+
+	testCases := []struct {
+		name           string
+		entity         interface{}
+		sampleRate     float64
+		getExecResp    *persistence.GetWorkflowExecutionResponse
+		getExecErr     error
+		getClosedResp  *persistence.GetClosedWorkflowExecutionResponse
+		getClosedErr   error
+		listOpenResp   *persistence.ListWorkflowExecutionsResponse
+		listOpenErr    error
+		expectedResult CheckResult
+	}{
+		{
+			name:       "not a concrete execution",
+			entity:     &entity.CurrentExecution{},
+			sampleRate: 1,
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   VisibilityConsistent,
+				Info:            "failed to check: expected concrete execution",
+			},
+		},
+		{
+			name:       "execution is not sampled",
+			entity:     getOpenConcreteExecution(),
+			sampleRate: 0,
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistent,
+				Info:            "skipped execution which is not sampled",
+			},
+		},
+		{
+			name:       "execution no longer exists",
+			entity:     getOpenConcreteExecution(),
+			sampleRate: 1,
+			getExecErr: &types.EntityNotExistsError{},
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistent,
+				Info:            "skipped execution which no longer exists",
+			},
+		},
+		{
+			name:       "failed to get execution",
+			entity:     getOpenConcreteExecution(),
+			sampleRate: 1,
+			getExecErr: errors.New("persistence error"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   VisibilityConsistent,
+				Info:            "failed to get concrete execution",
+				InfoDetails:     "persistence error",
+			},
+		},
+		{
+			name:        "zombie execution is skipped",
+			entity:      getOpenConcreteExecution(),
+			sampleRate:  1,
+			getExecResp: executionResponse(persistence.WorkflowStateZombie, persistence.WorkflowCloseStatusNone, updatedTime),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistent,
+				Info:            "skipped execution which is not recorded in visibility",
+			},
+		},
+		{
+			name:        "recently updated execution is skipped",
+			entity:      getOpenConcreteExecution(),
+			sampleRate:  1,
+			getExecResp: executionResponse(openState, persistence.WorkflowCloseStatusNone, now.Add(-time.Minute)),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistent,
+				Info:            "skipped execution which was updated recently",
+			},
+		},
+		{
+			name:         "failed to get closed record",
+			entity:       getOpenConcreteExecution(),
+			sampleRate:   1,
+			getExecResp:  executionResponse(openState, persistence.WorkflowCloseStatusNone, updatedTime),
+			getClosedErr: errors.New("visibility error"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   VisibilityConsistent,
+				Info:            "failed to get closed visibility record",
+				InfoDetails:     "visibility error",
+			},
+		},
+		{
+			name:          "open execution with closed record",
+			entity:        getOpenConcreteExecution(),
+			sampleRate:    1,
+			getExecResp:   executionResponse(openState, persistence.WorkflowCloseStatusNone, updatedTime),
+			getClosedResp: closedRecord(types.WorkflowExecutionCloseStatusCompleted),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VisibilityConsistent,
+				Info:            "execution is open but visibility has its closed record",
+				InfoDetails:     "close status in visibility is COMPLETED",
+			},
+		},
+		{
+			name:         "open execution without open record",
+			entity:       getOpenConcreteExecution(),
+			sampleRate:   1,
+			getExecResp:  executionResponse(openState, persistence.WorkflowCloseStatusNone, updatedTime),
+			getClosedErr: &types.EntityNotExistsError{},
+			listOpenResp: openRecords("another-run-id"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VisibilityConsistent,
+				Info:            "execution is open but has no visibility record",
+			},
+		},
+		{
+			name:         "failed to list open records",
+			entity:       getOpenConcreteExecution(),
+			sampleRate:   1,
+			getExecResp:  executionResponse(openState, persistence.WorkflowCloseStatusNone, updatedTime),
+			getClosedErr: &types.EntityNotExistsError{},
+			listOpenErr:  errors.New("visibility error"),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeFailed,
+				InvariantName:   VisibilityConsistent,
+				Info:            "failed to list open visibility records",
+				InfoDetails:     "visibility error",
+			},
+		},
+		{
+			name:         "open execution with open record",
+			entity:       getOpenConcreteExecution(),
+			sampleRate:   1,
+			getExecResp:  executionResponse(openState, persistence.WorkflowCloseStatusNone, updatedTime),
+			getClosedErr: &types.EntityNotExistsError{},
+			listOpenResp: openRecords(runID),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistent,
+			},
+		},
+		{
+			name:          "closed execution with matching closed record",
+			entity:        getClosedConcreteExecution(),
+			sampleRate:    1,
+			getExecResp:   executionResponse(closedState, persistence.WorkflowCloseStatusCompleted, updatedTime),
+			getClosedResp: closedRecord(types.WorkflowExecutionCloseStatusCompleted),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeHealthy,
+				InvariantName:   VisibilityConsistent,
+			},
+		},
+		{
+			name:          "closed execution with mismatched close status",
+			entity:        getClosedConcreteExecution(),
+			sampleRate:    1,
+			getExecResp:   executionResponse(closedState, persistence.WorkflowCloseStatusFailed, updatedTime),
+			getClosedResp: closedRecord(types.WorkflowExecutionCloseStatusCompleted),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VisibilityConsistent,
+				Info:            "close status in visibility does not match execution",
+				InfoDetails:     "expected FAILED, visibility has COMPLETED",
+			},
+		},
+		{
+			name:         "closed execution with only open record",
+			entity:       getClosedConcreteExecution(),
+			sampleRate:   1,
+			getExecResp:  executionResponse(closedState, persistence.WorkflowCloseStatusCompleted, updatedTime),
+			getClosedErr: &types.EntityNotExistsError{},
+			listOpenResp: openRecords(runID),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VisibilityConsistent,
+				Info:            "execution is closed but visibility only has its open record",
+			},
+		},
+		{
+			name:         "closed execution without any record",
+			entity:       getClosedConcreteExecution(),
+			sampleRate:   1,
+			getExecResp:  executionResponse(closedState, persistence.WorkflowCloseStatusCompleted, updatedTime),
+			getClosedErr: &types.EntityNotExistsError{},
+			listOpenResp: openRecords(),
+			expectedResult: CheckResult{
+				CheckResultType: CheckResultTypeCorrupted,
+				InvariantName:   VisibilityConsistent,
+				Info:            "execution is closed but has no visibility record",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			pr := persistence.NewMockRetryer(ctrl)
+			domainCache := cache.NewMockDomainCache(ctrl)
+			visibilityManager := persistence.NewMockVisibilityManager(ctrl)
+
+			domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil).AnyTimes()
+			if tc.getExecResp != nil || tc.getExecErr != nil {
+				pr.EXPECT().GetWorkflowExecution(gomock.Any(), &persistence.GetWorkflowExecutionRequest{
+					DomainID:   domainID,
+					Execution:  types.WorkflowExecution{WorkflowID: workflowID, RunID: runID},
+					DomainName: domainName,
+				}).Return(tc.getExecResp, tc.getExecErr).Times(1)
+			}
+			if tc.getClosedResp != nil || tc.getClosedErr != nil {
+				visibilityManager.EXPECT().GetClosedWorkflowExecution(gomock.Any(), &persistence.GetClosedWorkflowExecutionRequest{
+					DomainUUID: domainID,
+					Domain:     domainName,
+					Execution:  types.WorkflowExecution{WorkflowID: workflowID, RunID: runID},
+				}).Return(tc.getClosedResp, tc.getClosedErr).Times(1)
+			}
+			if tc.listOpenResp != nil || tc.listOpenErr != nil {
+				visibilityManager.EXPECT().ListOpenWorkflowExecutionsByWorkflowID(gomock.Any(), &persistence.ListWorkflowExecutionsByWorkflowIDRequest{
+					ListWorkflowExecutionsRequest: persistence.ListWorkflowExecutionsRequest{
+						DomainUUID:   domainID,
+						Domain:       domainName,
+						EarliestTime: startTime.Add(-time.Second).UnixNano(),
+						LatestTime:   startTime.Add(time.Second).UnixNano(),
+						PageSize:     visibilityLookupPageSize,
+					},
+					WorkflowID: workflowID,
+				}).Return(tc.listOpenResp, tc.listOpenErr).Times(1)
+			}
+
+			i := NewVisibilityConsistent(pr, domainCache, visibilityManager, history.NewMockClient(ctrl), clock.NewMockedTimeSourceAt(now), metrics.NoopScope, tc.sampleRate)
+			assert.Equal(t, tc.expectedResult, i.Check(context.Background(), tc.entity))
+		})
+	}
+}
+
+func TestVisibilityConsistent_CheckEmitsDriftMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	pr := persistence.NewMockRetryer(ctrl)
+	domainCache := cache.NewMockDomainCache(ctrl)
+	visibilityManager := persistence.NewMockVisibilityManager(ctrl)
+	testScope := tally.NewTestScope("", nil)
+	scope := metrics.NewClient(testScope, metrics.Worker, metrics.MigrationConfig{}).Scope(metrics.ShardScannerScope)
+
+	now := time.Now()
+	domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil)
+	pr.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{
+		State: &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				DomainID:             domainID,
+				WorkflowID:           workflowID,
+				RunID:                runID,
+				State:                closedState,
+				CloseStatus:          persistence.WorkflowCloseStatusCompleted,
+				LastUpdatedTimestamp: now.Add(-time.Hour),
+			},
+		},
+	}, nil)
+	visibilityManager.EXPECT().GetClosedWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, &types.EntityNotExistsError{})
+	visibilityManager.EXPECT().ListOpenWorkflowExecutionsByWorkflowID(gomock.Any(), gomock.Any()).Return(&persistence.ListWorkflowExecutionsResponse{}, nil)
+
+	i := NewVisibilityConsistent(pr, domainCache, visibilityManager, nil, clock.NewMockedTimeSourceAt(now), scope, 1)
+	result := i.Check(context.Background(), getClosedConcreteExecution())
+	assert.Equal(t, CheckResultTypeCorrupted, result.CheckResultType)
+
+	var drifts int64
+	for _, counter := range testScope.Snapshot().Counters() {
+		if counter.Name() == "scanner_visibility_drift" && counter.Tags()["visibility_drift_type"] == VisibilityDriftMissingClosed {
+			drifts += counter.Value()
+		}
+	}
+	assert.Equal(t, int64(1), drifts)
+}
+
+func TestVisibilityConsistent_Sampling(t *testing.T) {
+	i := &visibilityConsistent{sampleRate: 0.5}
+	sampled := 0
+	for n := 0; n < 1000; n++ {
+		runID := fmt.Sprintf("test-run-id-%d", n)
+		if i.sampled(runID) {
+			sampled++
+			// sampling is deterministic so the fixer picks up the executions reported by the scanner
+			assert.True(t, i.sampled(runID))
+		}
+	}
+	assert.InDelta(t, 500, sampled, 100)
+	assert.True(t, (&visibilityConsistent{sampleRate: 1}).sampled(runID))
+	assert.False(t, (&visibilityConsistent{sampleRate: 0}).sampled(runID))
+}
+
+func TestVisibilityConsistent_Fix(t *testing.T) {
+	testCases := []struct {
+		name           string
+		refreshErr     error
+		expectedType   FixResultType
+		expectedInfo   string
+		expectedDetail string
+	}{
+		{
+			name:         "tasks refreshed",
+			expectedType: FixResultTypeFixed,
+			expectedInfo: "refreshed workflow tasks to re-emit visibility task",
+		},
+		{
+			name:           "failed to refresh tasks",
+			refreshErr:     errors.New("history error"),
+			expectedType:   FixResultTypeFailed,
+			expectedInfo:   "failed to refresh workflow tasks",
+			expectedDetail: "history error",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			pr := persistence.NewMockRetryer(ctrl)
+			domainCache := cache.NewMockDomainCache(ctrl)
+			visibilityManager := persistence.NewMockVisibilityManager(ctrl)
+			historyClient := history.NewMockClient(ctrl)
+
+			now := time.Now()
+			domainCache.EXPECT().GetDomainName(domainID).Return(domainName, nil).AnyTimes()
+			pr.EXPECT().GetWorkflowExecution(gomock.Any(), gomock.Any()).Return(&persistence.GetWorkflowExecutionResponse{
+				State: &persistence.WorkflowMutableState{
+					ExecutionInfo: &persistence.WorkflowExecutionInfo{
+						DomainID:             domainID,
+						WorkflowID:           workflowID,
+						RunID:                runID,
+						State:                openState,
+						LastUpdatedTimestamp: now.Add(-time.Hour),
+					},
+				},
+			}, nil)
+			visibilityManager.EXPECT().GetClosedWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, &types.EntityNotExistsError{})
+			visibilityManager.EXPECT().ListOpenWorkflowExecutionsByWorkflowID(gomock.Any(), gomock.Any()).Return(&persistence.ListWorkflowExecutionsResponse{}, nil)
+			historyClient.EXPECT().RefreshWorkflowTasks(gomock.Any(), &types.HistoryRefreshWorkflowTasksRequest{
+				DomainUIID: domainID,
+				Request: &types.RefreshWorkflowTasksRequest{
+					Domain:    domainName,
+					Execution: &types.WorkflowExecution{WorkflowID: workflowID, RunID: runID},
+				},
+			}).Return(tc.refreshErr)
+
+			i := NewVisibilityConsistent(pr, domainCache, visibilityManager, historyClient, clock.NewMockedTimeSourceAt(now), metrics.NoopScope, 1)
+			result := i.Fix(context.Background(), getOpenConcreteExecution())
+			assert.Equal(t, tc.expectedType, result.FixResultType)
+			assert.Equal(t, tc.expectedInfo, result.Info)
+			assert.Equal(t, tc.expectedDetail, result.InfoDetails)
+			assert.Equal(t, CheckResultTypeCorrupted, result.CheckResult.CheckResultType)
+		})
+	}
+}
+
+func TestVisibilityConsistent_FixSkipsHealthyExecution(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	i := NewVisibilityConsistent(persistence.NewMockRetryer(ctrl), cache.NewMockDomainCache(ctrl), persistence.NewMockVisibilityManager(ctrl), history.NewMockClient(ctrl), clock.NewMockedTimeSource(), metrics.NoopScope, 0)
+	result := i.Fix(context.Background(), getOpenConcreteExecution())
+	assert.Equal(t, FixResultTypeSkipped, result.FixResultType)
+}
@@ -23,6 +23,7 @@ package resource
 import (
 	"github.com/cadence-workflow/shard-manager/service/sharddistributor/client/clientcommon"
 	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	"go.uber.org/zap"
 
@@ -96,6 +97,7 @@ type (
 		OSConfig                   *config.ElasticSearchConfig
 		AsyncWorkflowQueueProvider queue.Provider
 		TimeSource                 clock.TimeSource
+		TracerProvider             trace.TracerProvider // This can be nil, a no-op provider will be used if so
 		// HistoryClientFn is used by integration tests to mock a history client
 		HistoryClientFn func() history.Client
 		// NewPersistenceBeanFn can be used to override the default persistence bean creation in unit tests to avoid DB setup
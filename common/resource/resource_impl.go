@@ -29,6 +29,8 @@ import (
 	smcommon "github.com/cadence-workflow/shard-manager/common"
 	"github.com/cadence-workflow/shard-manager/service/sharddistributor/client/executorclient"
 	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	"go.uber.org/yarpc"
 	"go.uber.org/zap"
@@ -101,6 +103,7 @@ type Impl struct {
 	timeSource              clock.TimeSource
 	payloadSerializer       persistence.PayloadSerializer
 	metricsClient           metrics.Client
+	tracerProvider          trace.TracerProvider
 	messagingClient         messaging.Client
 	blobstoreClient         blobstore.Client
 	archivalMetadata        archiver.ArchivalMetadata
@@ -201,6 +204,11 @@ func New(
 
 	params.PersistenceConfig.HostName = hostname
 
+	tracerProvider := params.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = noop.NewTracerProvider()
+	}
+
 	persistenceFactory := persistenceClient.NewFactory(
 		&params.PersistenceConfig,
 		func() float64 {
@@ -213,6 +221,7 @@ func New(
 		},
 		params.ClusterMetadata.GetCurrentClusterName(),
 		params.MetricsClient,
+		tracerProvider,
 		logger,
 		persistence.NewDynamicConfiguration(dynamicCollection),
 	)
@@ -363,6 +372,7 @@ func New(
 		timeSource:              clock.NewRealTimeSource(),
 		payloadSerializer:       persistence.NewPayloadSerializer(),
 		metricsClient:           params.MetricsClient,
+		tracerProvider:          tracerProvider,
 		messagingClient:         params.MessagingClient,
 		blobstoreClient:         params.BlobstoreClient,
 		archivalMetadata:        params.ArchivalMetadata,
@@ -536,6 +546,11 @@ func (h *Impl) GetMetricsClient() metrics.Client {
 	return h.metricsClient
 }
 
+// GetTracerProvider return tracer provider
+func (h *Impl) GetTracerProvider() trace.TracerProvider {
+	return h.tracerProvider
+}
+
 // GetMessagingClient return messaging client
 func (h *Impl) GetMessagingClient() messaging.Client {
 	return h.messagingClient
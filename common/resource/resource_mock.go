@@ -14,6 +14,7 @@ import (
 
 	executorclient "github.com/cadence-workflow/shard-manager/service/sharddistributor/client/executorclient"
 	tally "github.com/uber-go/tally"
+	trace "go.opentelemetry.io/otel/trace"
 	workflowserviceclient "go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	gomock "go.uber.org/mock/gomock"
 	yarpc "go.uber.org/yarpc"
@@ -742,6 +743,20 @@ func (mr *MockResourceMockRecorder) GetTimeSource() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeSource", reflect.TypeOf((*MockResource)(nil).GetTimeSource))
 }
 
+// GetTracerProvider mocks base method.
+func (m *MockResource) GetTracerProvider() trace.TracerProvider {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTracerProvider")
+	ret0, _ := ret[0].(trace.TracerProvider)
+	return ret0
+}
+
+// GetTracerProvider indicates an expected call of GetTracerProvider.
+func (mr *MockResourceMockRecorder) GetTracerProvider() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTracerProvider", reflect.TypeOf((*MockResource)(nil).GetTracerProvider))
+}
+
 // GetVisibilityManager mocks base method.
 func (m *MockResource) GetVisibilityManager() persistence.VisibilityManager {
 	m.ctrl.T.Helper()
@@ -28,6 +28,8 @@ import (
 	oldgomock "github.com/golang/mock/gomock" // client library cannot change from the old gomock
 	"github.com/stretchr/testify/mock"
 	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	publicservicetest "go.uber.org/cadence/.gen/go/cadence/workflowservicetest"
 	"go.uber.org/mock/gomock"
@@ -78,6 +80,7 @@ type (
 		TimeSource              clock.TimeSource
 		PayloadSerializer       persistence.PayloadSerializer
 		MetricsClient           metrics.Client
+		TracerProvider          trace.TracerProvider
 		ArchivalMetadata        *archiver.MockArchivalMetadata
 		ArchiverProvider        *provider.MockArchiverProvider
 		BlobstoreClient         *blobstore.MockClient
@@ -196,6 +199,7 @@ func NewTest(
 		TimeSource:              clock.NewRealTimeSource(),
 		PayloadSerializer:       persistence.NewPayloadSerializer(),
 		MetricsClient:           metrics.NewClient(scope, serviceMetricsIndex, metrics.MigrationConfig{}),
+		TracerProvider:          noop.NewTracerProvider(),
 		ArchivalMetadata:        &archiver.MockArchivalMetadata{},
 		ArchiverProvider:        provider.NewMockArchiverProvider(controller),
 		BlobstoreClient:         blobstore.NewMockClient(controller),
@@ -306,6 +310,11 @@ func (s *Test) GetMetricsClient() metrics.Client {
 	return s.MetricsClient
 }
 
+// GetTracerProvider for testing
+func (s *Test) GetTracerProvider() trace.TracerProvider {
+	return s.TracerProvider
+}
+
 // GetMetricsScope for testing
 func (s *Test) GetMetricsScope() tally.Scope {
 	return s.MetricsScope
@@ -25,6 +25,7 @@ package resource
 import (
 	"github.com/cadence-workflow/shard-manager/service/sharddistributor/client/executorclient"
 	"github.com/uber-go/tally"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	"go.uber.org/yarpc"
 	"go.uber.org/zap"
@@ -83,6 +84,7 @@ type Resource interface {
 	GetTimeSource() clock.TimeSource
 	GetPayloadSerializer() persistence.PayloadSerializer
 	GetMetricsClient() metrics.Client
+	GetTracerProvider() trace.TracerProvider
 	GetArchiverProvider() provider.ArchiverProvider
 	GetMessagingClient() messaging.Client
 	GetBlobstoreClient() blobstore.Client
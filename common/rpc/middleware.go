@@ -24,7 +24,12 @@ import (
 	"context"
 	"encoding/json"
 	"io"
+	"strconv"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/cadence/worker"
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/api/transport"
@@ -33,6 +38,9 @@ import (
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/isolationgroup"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/taskfairness"
+	"github.com/uber/cadence/common/taskpriority"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -249,16 +257,95 @@ func (m *ForwardPartitionConfigMiddleware) Call(ctx context.Context, request *tr
 }
 
 // ClientPartitionConfigMiddleware stores the partition config and isolation group of the request into the context
-// It reads a header from client request and uses it as the isolation group
+// It reads a header from client request and uses it as the isolation group. The task priority and fairness key headers,
+// when they hold valid values, are recorded in the partition config as well; invalid values are ignored.
 type ClientPartitionConfigMiddleware struct{}
 
 func (m *ClientPartitionConfigMiddleware) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.UnaryHandler) error {
+	partitionConfig := map[string]string{}
 	zone, _ := req.Headers.Get(common.ClientIsolationGroupHeaderName)
 	if zone != "" {
-		ctx = isolationgroup.ContextWithConfig(ctx, map[string]string{
-			isolationgroup.GroupKey: zone,
-		})
+		partitionConfig[isolationgroup.GroupKey] = zone
 		ctx = isolationgroup.ContextWithIsolationGroup(ctx, zone)
 	}
+	if v, ok := req.Headers.Get(common.ClientTaskPriorityHeaderName); ok {
+		if priority, err := taskpriority.Parse(v); err == nil {
+			partitionConfig[taskpriority.PartitionConfigKey] = strconv.Itoa(priority)
+		}
+	}
+	if key, ok := req.Headers.Get(common.ClientTaskFairnessKeyHeaderName); ok && taskfairness.Validate(key) == nil {
+		partitionConfig[taskfairness.PartitionConfigKey] = key
+	}
+	if len(partitionConfig) > 0 {
+		ctx = isolationgroup.ContextWithConfig(ctx, partitionConfig)
+	}
 	return h.Handle(ctx, req, resw)
 }
+
+// TracingInboundMiddleware handles each request in a server span. The span continues
+// the caller's trace when the request carries a trace context, and starts a new trace otherwise.
+type TracingInboundMiddleware struct {
+	Tracer trace.Tracer
+}
+
+func (m *TracingInboundMiddleware) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter, h transport.UnaryHandler) error {
+	ctx = tracing.Propagator.Extract(ctx, headersCarrier{headers: &req.Headers})
+	ctx, span := m.Tracer.Start(ctx, req.Procedure,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(rpcSpanAttributes(req)...),
+	)
+	err := h.Handle(ctx, req, resw)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// TracingOutboundMiddleware makes each call in a client span and passes the span's
+// trace context on to the callee in the request headers.
+type TracingOutboundMiddleware struct {
+	Tracer trace.Tracer
+}
+
+func (m *TracingOutboundMiddleware) Call(ctx context.Context, request *transport.Request, out transport.UnaryOutbound) (*transport.Response, error) {
+	ctx, span := m.Tracer.Start(ctx, request.Procedure,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(rpcSpanAttributes(request)...),
+	)
+	tracing.Propagator.Inject(ctx, headersCarrier{headers: &request.Headers})
+	response, err := out.Call(ctx, request)
+	if err == nil && response != nil && response.ApplicationError {
+		span.SetStatus(codes.Error, "application error")
+	}
+	tracing.EndSpan(span, err)
+	return response, err
+}
+
+func rpcSpanAttributes(req *transport.Request) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		semconv.RPCService(req.Service),
+		semconv.RPCMethod(req.Procedure),
+		attribute.String("rpc.caller", req.Caller),
+		attribute.String("rpc.transport", req.Transport),
+	}
+}
+
+// headersCarrier lets the OpenTelemetry propagators read and write yarpc transport headers
+type headersCarrier struct {
+	headers *transport.Headers
+}
+
+func (c headersCarrier) Get(key string) string {
+	value, _ := c.headers.Get(key)
+	return value
+}
+
+func (c headersCarrier) Set(key, value string) {
+	*c.headers = c.headers.With(key, value)
+}
+
+func (c headersCarrier) Keys() []string {
+	keys := make([]string, 0, c.headers.Len())
+	for key := range c.headers.Items() {
+		keys = append(keys, key)
+	}
+	return keys
+}
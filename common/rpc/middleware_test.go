@@ -24,13 +24,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/yarpc/api/transport"
 	"go.uber.org/yarpc/yarpctest"
 
@@ -38,6 +44,9 @@ import (
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/isolationgroup"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/taskfairness"
+	"github.com/uber/cadence/common/taskpriority"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -317,6 +326,53 @@ func TestClientPartitionConfigMiddleware(t *testing.T) {
 		assert.Equal(t, "dca1", isolationgroup.IsolationGroupFromContext(h.ctx))
 	})
 
+	t.Run("it records the task priority", func(t *testing.T) {
+		m := &ClientPartitionConfigMiddleware{}
+		h := &fakeHandler{}
+		headers := transport.NewHeaders().
+			With(common.ClientIsolationGroupHeaderName, "dca1").
+			With(common.ClientTaskPriorityHeaderName, "1")
+		err := m.Handle(context.Background(), &transport.Request{Headers: headers}, nil, h)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			isolationgroup.GroupKey:         "dca1",
+			taskpriority.PartitionConfigKey: "1",
+		}, isolationgroup.ConfigFromContext(h.ctx))
+	})
+
+	t.Run("it ignores an invalid task priority", func(t *testing.T) {
+		m := &ClientPartitionConfigMiddleware{}
+		h := &fakeHandler{}
+		headers := transport.NewHeaders().With(common.ClientTaskPriorityHeaderName, "urgent")
+		ctx := context.Background()
+		err := m.Handle(ctx, &transport.Request{Headers: headers}, nil, h)
+		assert.NoError(t, err)
+		assert.Nil(t, isolationgroup.ConfigFromContext(h.ctx))
+		assert.Equal(t, ctx, h.ctx)
+	})
+
+	t.Run("it records the task fairness key", func(t *testing.T) {
+		m := &ClientPartitionConfigMiddleware{}
+		h := &fakeHandler{}
+		headers := transport.NewHeaders().With(common.ClientTaskFairnessKeyHeaderName, "customer-1")
+		err := m.Handle(context.Background(), &transport.Request{Headers: headers}, nil, h)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			taskfairness.PartitionConfigKey: "customer-1",
+		}, isolationgroup.ConfigFromContext(h.ctx))
+		assert.Equal(t, "", isolationgroup.IsolationGroupFromContext(h.ctx))
+	})
+
+	t.Run("it ignores an invalid task fairness key", func(t *testing.T) {
+		m := &ClientPartitionConfigMiddleware{}
+		h := &fakeHandler{}
+		headers := transport.NewHeaders().With(common.ClientTaskFairnessKeyHeaderName, strings.Repeat("a", taskfairness.MaxKeyLength+1))
+		ctx := context.Background()
+		err := m.Handle(ctx, &transport.Request{Headers: headers}, nil, h)
+		assert.NoError(t, err)
+		assert.Nil(t, isolationgroup.ConfigFromContext(h.ctx))
+	})
+
 	t.Run("noop when header is empty", func(t *testing.T) {
 		m := &ClientPartitionConfigMiddleware{}
 		h := &fakeHandler{}
@@ -428,6 +484,78 @@ func TestCallerInfoOutboundMiddleware(t *testing.T) {
 	})
 }
 
+func TestTracingMiddleware(t *testing.T) {
+	newTracer := func() (trace.Tracer, *tracetest.SpanRecorder) {
+		recorder := tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		return tracing.Tracer(provider), recorder
+	}
+	const (
+		traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentID    = "00f067aa0ba902b7"
+		traceparent = "00-" + traceID + "-" + parentID + "-01"
+	)
+
+	t.Run("inbound continues the caller's trace", func(t *testing.T) {
+		tracer, recorder := newTracer()
+		m := &TracingInboundMiddleware{Tracer: tracer}
+		h := &fakeHandler{}
+		req := &transport.Request{
+			Service:   "cadence-frontend",
+			Procedure: "uber.cadence.api.v1.WorkflowAPI::StartWorkflowExecution",
+			Headers:   transport.NewHeaders().With("traceparent", traceparent),
+		}
+		err := m.Handle(context.Background(), req, nil, h)
+		assert.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, req.Procedure, spans[0].Name())
+		assert.Equal(t, trace.SpanKindServer, spans[0].SpanKind())
+		assert.Equal(t, traceID, spans[0].SpanContext().TraceID().String())
+		assert.Equal(t, parentID, spans[0].Parent().SpanID().String())
+		assert.Equal(t, spans[0].SpanContext(), trace.SpanContextFromContext(h.ctx))
+	})
+
+	t.Run("inbound starts a trace and records the error", func(t *testing.T) {
+		tracer, recorder := newTracer()
+		m := &TracingInboundMiddleware{Tracer: tracer}
+		err := m.Handle(context.Background(), &transport.Request{Procedure: "proc"}, nil, &fakeFailingHandler{err: errors.New("boom")})
+		assert.EqualError(t, err, "boom")
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.False(t, spans[0].Parent().IsValid())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+	})
+
+	t.Run("outbound injects the client span", func(t *testing.T) {
+		tracer, recorder := newTracer()
+		m := &TracingOutboundMiddleware{Tracer: tracer}
+		var injected string
+		req := &transport.Request{Procedure: "proc", Headers: transport.NewHeaders().With("traceparent", traceparent)}
+		_, err := m.Call(context.Background(), req, &fakeOutbound{verify: func(r *transport.Request) {
+			injected, _ = r.Headers.Get("traceparent")
+		}, response: &transport.Response{ApplicationError: true}})
+		assert.NoError(t, err)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		sc := spans[0].SpanContext()
+		assert.Equal(t, "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01", injected, "the forwarded trace context must be replaced")
+	})
+}
+
+type fakeFailingHandler struct {
+	err error
+}
+
+func (h *fakeFailingHandler) Handle(ctx context.Context, req *transport.Request, resw transport.ResponseWriter) error {
+	return h.err
+}
+
 type fakeHandler struct {
 	ctx context.Context
 }
@@ -28,6 +28,7 @@ import (
 	"regexp"
 	"strconv"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/yarpc"
 	yarpctls "go.uber.org/yarpc/api/transport/tls"
 
@@ -37,6 +38,7 @@ import (
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/tracing"
 )
 
 // Params allows to configure rpc.Factory
@@ -180,6 +182,17 @@ func NewParams(serviceName string, config *config.Config, dc *dynamicconfig.Coll
 	}, nil
 }
 
+// WithTracing adds span propagation to the middleware of params. The tracing
+// inbound middleware runs first so that the others handle the request inside its
+// span, and the tracing outbound middleware runs last so that the trace context it
+// injects replaces any forwarded from the inbound request.
+func WithTracing(params Params, provider trace.TracerProvider) Params {
+	tracer := tracing.Tracer(provider)
+	params.InboundMiddleware.Unary = yarpc.UnaryInboundMiddleware(&TracingInboundMiddleware{Tracer: tracer}, params.InboundMiddleware.Unary)
+	params.OutboundMiddleware.Unary = yarpc.UnaryOutboundMiddleware(params.OutboundMiddleware.Unary, &TracingOutboundMiddleware{Tracer: tracer})
+	return params
+}
+
 func getForwardingRules(dc *dynamicconfig.Collection) ([]config.HeaderRule, error) {
 	var forwardingRules []config.HeaderRule
 	dynForwarding := dc.GetListProperty(dynamicproperties.HeaderForwardingRules)()
@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/uber/cadence/common/config"
 	"github.com/uber/cadence/common/dynamicconfig"
@@ -99,3 +100,9 @@ func TestNewParams(t *testing.T) {
 	assert.NotNil(t, net.ParseIP(ip))
 	assert.NotNil(t, params.InboundTLS)
 }
+
+func TestWithTracing(t *testing.T) {
+	params := WithTracing(Params{}, noop.NewTracerProvider())
+	assert.NotNil(t, params.InboundMiddleware.Unary)
+	assert.NotNil(t, params.OutboundMiddleware.Unary)
+}
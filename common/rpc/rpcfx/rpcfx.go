@@ -25,6 +25,7 @@ package rpcfx
 import (
 	"fmt"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/fx"
 
 	"github.com/uber/cadence/common/config"
@@ -48,6 +49,7 @@ type paramsBuilderParams struct {
 	Logger            log.Logger
 	DynamicCollection *dynamicconfig.Collection
 	MetricsClient     metrics.Client
+	TracerProvider    trace.TracerProvider `optional:"true"`
 }
 
 func paramsBuilder(p paramsBuilderParams) (rpc.Params, error) {
@@ -55,6 +57,9 @@ func paramsBuilder(p paramsBuilderParams) (rpc.Params, error) {
 	if err != nil {
 		return rpc.Params{}, fmt.Errorf("create rpc params: %w", err)
 	}
+	if p.TracerProvider != nil {
+		res = rpc.WithTracing(res, p.TracerProvider)
+	}
 	return res, nil
 }
 
@@ -65,6 +65,17 @@ func newIWRRSchedule[K comparable, V any](items map[K]weightedContainer[V]) *iwr
 	}
 }
 
+// NewIWRRKeySchedule creates an IWRR schedule over the keys of weights, where each
+// key appears as many times per cycle as its weight
+// Keys with weight <= 0 are ignored
+func NewIWRRKeySchedule[K comparable](weights map[K]int) Schedule[K] {
+	items := make(map[K]weightedContainer[K], len(weights))
+	for key, weight := range weights {
+		items[key] = weightedContainer[K]{weight: weight, item: key}
+	}
+	return newIWRRSchedule(items)
+}
+
 // NewIterator creates a new stateful iterator for this schedule
 func (s *iwrrSchedule[V]) NewIterator() Iterator[V] {
 	if len(s.items) == 0 {
@@ -348,3 +348,17 @@ func TestIWRRSchedule_StatelessSchedule_MultipleIterators(t *testing.T) {
 	require.True(t, ok2)
 	assert.Equal(t, item1, i2)
 }
+
+func TestIWRRKeySchedule(t *testing.T) {
+	schedule := NewIWRRKeySchedule(map[string]int{"a": 3, "b": 1, "c": 0})
+
+	// keys with weight <= 0 are ignored
+	assert.Equal(t, 4, schedule.Len())
+
+	var keys []string
+	iter := schedule.NewIterator()
+	for key, ok := iter.TryNext(); ok; key, ok = iter.TryNext() {
+		keys = append(keys, key)
+	}
+	assert.Equal(t, []string{"a", "a", "a", "b"}, keys)
+}
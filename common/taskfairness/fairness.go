@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package taskfairness defines the fairness key of decision and activity tasks.
+// Matching shares a task list's backlog between fairness keys (a customer or
+// tenant ID, for example) so that one busy key cannot starve the others. Like
+// the task priority, the key is recorded in the workflow's partition config and
+// persisted with every task the workflow schedules.
+package taskfairness
+
+import (
+	"fmt"
+)
+
+const (
+	// PartitionConfigKey is the partition config entry that holds the fairness key
+	PartitionConfigKey = "task-fairness-key"
+
+	// MaxKeyLength is the longest fairness key that is accepted
+	MaxKeyLength = 256
+
+	// DefaultWeight is the weight of a fairness key that has no configured weight
+	DefaultWeight = 1
+)
+
+// Validate rejects fairness keys that are empty or longer than MaxKeyLength
+func Validate(key string) error {
+	if key == "" {
+		return fmt.Errorf("fairness key is empty")
+	}
+	if len(key) > MaxKeyLength {
+		return fmt.Errorf("fairness key is %d bytes long, the limit is %d", len(key), MaxKeyLength)
+	}
+	return nil
+}
+
+// FromPartitionConfig returns the fairness key recorded in a partition config,
+// or an empty string when there is none
+func FromPartitionConfig(partitionConfig map[string]string) string {
+	return partitionConfig[PartitionConfigKey]
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+// Package taskpriority defines the dispatch priority of decision and activity tasks.
+// A workflow's priority is recorded in its partition config, so it is copied onto
+// every task the workflow schedules and persisted with the task in matching.
+package taskpriority
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// PartitionConfigKey is the partition config entry that holds the task priority
+	PartitionConfigKey = "task-priority"
+
+	// Highest is the most urgent priority. Lower values are dispatched first.
+	Highest = 1
+	// Lowest is the least urgent priority
+	Lowest = 5
+	// Default is the priority of tasks that don't carry one
+	Default = 3
+	// NumLevels is the number of distinct priorities
+	NumLevels = Lowest - Highest + 1
+)
+
+// Parse parses a priority and rejects values outside [Highest, Lowest]
+func Parse(s string) (int, error) {
+	p, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid task priority %q: %w", s, err)
+	}
+	if p < Highest || p > Lowest {
+		return 0, fmt.Errorf("invalid task priority %d: must be between %d and %d", p, Highest, Lowest)
+	}
+	return p, nil
+}
+
+// FromPartitionConfig returns the priority recorded in a partition config,
+// or Default when it is missing or invalid
+func FromPartitionConfig(partitionConfig map[string]string) int {
+	v, ok := partitionConfig[PartitionConfigKey]
+	if !ok {
+		return Default
+	}
+	p, err := Parse(v)
+	if err != nil {
+		return Default
+	}
+	return p
+}
@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package tracing sets up OpenTelemetry tracing for cadence services.
+//
+// Each service gets its own trace.TracerProvider, built from config.Tracing and
+// passed down explicitly like the metrics client. The provider is a no-op unless
+// tracing is enabled, so instrumentation can always start spans.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/uber/cadence/common/config"
+)
+
+const instrumentationName = "github.com/uber/cadence"
+
+// Propagator carries the span context and baggage across RPC boundaries
+// using the W3C trace context headers
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(
+	propagation.TraceContext{},
+	propagation.Baggage{},
+)
+
+// NewTracerProvider builds the tracer provider of a service from its config.
+// When tracing is disabled the provider is a no-op. The returned function
+// flushes buffered spans and shuts the exporter down.
+func NewTracerProvider(ctx context.Context, cfg config.Tracing, serviceName string) (trace.TracerProvider, func(context.Context) error, error) {
+	if !cfg.Enabled {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLP.Endpoint)}
+	if cfg.OTLP.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	if len(cfg.OTLP.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(cfg.OTLP.Headers))
+	}
+	if cfg.OTLP.Timeout > 0 {
+		opts = append(opts, otlptracegrpc.WithTimeout(cfg.OTLP.Timeout))
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create otlp trace exporter: %w", err)
+	}
+	provider := NewTracerProviderWithExporter(exporter, serviceName, cfg.GetSamplingRate())
+	return provider, provider.Shutdown, nil
+}
+
+// NewTracerProviderWithExporter builds a tracer provider that batches spans to
+// the given exporter. Tests use it with an in-process exporter such as
+// tracetest.InMemoryExporter and call ForceFlush before asserting on spans.
+func NewTracerProviderWithExporter(exporter sdktrace.SpanExporter, serviceName string, samplingRate float64) *sdktrace.TracerProvider {
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRate))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName))),
+	)
+}
+
+// Tracer returns the tracer that cadence instrumentation starts spans with
+func Tracer(provider trace.TracerProvider) trace.Tracer {
+	return provider.Tracer(instrumentationName)
+}
+
+// EndSpan marks the span as failed if err is not nil and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/uber/cadence/common/config"
+)
+
+func TestNewTracerProviderDisabled(t *testing.T) {
+	provider, shutdown, err := NewTracerProvider(context.Background(), config.Tracing{}, "cadence-frontend")
+	require.NoError(t, err)
+	_, span := Tracer(provider).Start(context.Background(), "op")
+	assert.False(t, span.IsRecording())
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestNewTracerProviderWithExporter(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewTracerProviderWithExporter(exporter, "cadence-history", 1)
+
+	_, span := Tracer(provider).Start(context.Background(), "ok")
+	EndSpan(span, nil)
+	_, span = Tracer(provider).Start(context.Background(), "failed")
+	EndSpan(span, errors.New("boom"))
+	require.NoError(t, provider.ForceFlush(context.Background()))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "ok", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].Status.Code)
+	assert.Equal(t, "failed", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.Equal(t, "boom", spans[1].Status.Description)
+	assert.Contains(t, spans[1].Resource.Attributes(), semconv.ServiceName("cadence-history"))
+}
+
+func TestNewTracerProviderWithExporterSampling(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := NewTracerProviderWithExporter(exporter, "cadence-matching", 0)
+
+	_, span := Tracer(provider).Start(context.Background(), "dropped")
+	assert.False(t, span.IsRecording())
+	EndSpan(span, nil)
+	require.NoError(t, provider.ForceFlush(context.Background()))
+	assert.Empty(t, exporter.GetSpans())
+}
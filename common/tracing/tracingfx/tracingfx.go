@@ -0,0 +1,59 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tracingfx
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/fx"
+
+	"github.com/uber/cadence/common/config"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/tracing"
+)
+
+// Module provides the tracer provider of the service for fx application.
+var Module = fx.Module("tracingfx",
+	fx.Provide(buildTracerProvider))
+
+type params struct {
+	fx.In
+
+	Lifecycle       fx.Lifecycle
+	Logger          log.Logger
+	Config          config.Config
+	ServiceFullName string `name:"service-full-name"`
+}
+
+func buildTracerProvider(p params) (trace.TracerProvider, error) {
+	provider, shutdown, err := tracing.NewTracerProvider(context.Background(), p.Config.Tracing, p.ServiceFullName)
+	if err != nil {
+		return nil, fmt.Errorf("create tracer provider: %w", err)
+	}
+	if p.Config.Tracing.Enabled {
+		p.Logger.Info("exporting traces", tag.Address(p.Config.Tracing.OTLP.Endpoint))
+	}
+	p.Lifecycle.Append(fx.StopHook(shutdown))
+	return provider, nil
+}
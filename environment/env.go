@@ -75,6 +75,13 @@ const (
 	// MongoDefaultPort is Mongo default port
 	MongoDefaultPort = "27017"
 
+	// DynamoDBSeeds env
+	DynamoDBSeeds = "DYNAMODB_SEEDS"
+	// DynamoDBPort env
+	DynamoDBPort = "DYNAMODB_PORT"
+	// DynamoDBDefaultPort is the default port of DynamoDB Local
+	DynamoDBDefaultPort = "8000"
+
 	// KafkaSeeds env
 	KafkaSeeds = "KAFKA_SEEDS"
 	// KafkaPort env
@@ -277,6 +284,25 @@ func GetMongoPort() (int, error) {
 	return strconv.Atoi(port)
 }
 
+// GetDynamoDBAddress return the DynamoDB address
+func GetDynamoDBAddress() string {
+	addr := os.Getenv(DynamoDBSeeds)
+	if addr == "" {
+		addr = Localhost
+	}
+	return addr
+}
+
+// GetDynamoDBPort return the DynamoDB port
+func GetDynamoDBPort() (int, error) {
+	port := os.Getenv(DynamoDBPort)
+	if port == "" {
+		port = DynamoDBDefaultPort
+	}
+
+	return strconv.Atoi(port)
+}
+
 func setEnv(key string, val string) error {
 	if err := os.Setenv(key, val); err != nil {
 		return fmt.Errorf("setting env %q: %w", key, err)
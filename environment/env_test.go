@@ -604,6 +604,87 @@ func TestGetMongoPort(t *testing.T) {
 	}
 }
 
+func TestGetDynamoDBAddress(t *testing.T) {
+	tests := []struct {
+		name      string
+		envVarKey string
+		envVarVal string
+		wantVal   any
+	}{
+		{
+			name:      "default",
+			envVarKey: DynamoDBSeeds,
+			envVarVal: "",
+			wantVal:   Localhost,
+		},
+		{
+			name:      "custom",
+			envVarKey: DynamoDBSeeds,
+			envVarVal: "dynamodbseed",
+			wantVal:   "dynamodbseed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(tt.envVarKey, tt.envVarVal)
+			gotVal := GetDynamoDBAddress()
+
+			if gotVal != tt.wantVal {
+				t.Fatalf("GetDynamoDBAddress() = %v, want %v", gotVal, tt.wantVal)
+			}
+		})
+	}
+}
+
+func TestGetDynamoDBPort(t *testing.T) {
+	tests := []struct {
+		name      string
+		envVarKey string
+		envVarVal string
+		wantErr   bool
+		wantVal   any
+	}{
+		{
+			name:      "default",
+			envVarKey: DynamoDBPort,
+			envVarVal: "",
+			wantErr:   false,
+			wantVal:   mustConvertInt(t, DynamoDBDefaultPort),
+		},
+		{
+			name:      "non-int port",
+			envVarKey: DynamoDBPort,
+			envVarVal: "xyz",
+			wantErr:   true,
+		},
+		{
+			name:      "custom port",
+			envVarKey: DynamoDBPort,
+			envVarVal: "8001",
+			wantVal:   8001,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(tt.envVarKey, tt.envVarVal)
+			gotVal, err := GetDynamoDBPort()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetDynamoDBPort() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil || tt.wantErr {
+				return
+			}
+
+			if gotVal != tt.wantVal {
+				t.Fatalf("GetDynamoDBPort() = %v, want %v", gotVal, tt.wantVal)
+			}
+		})
+	}
+}
+
 func mustConvertInt(t *testing.T, s string) int {
 	v, err := strconv.Atoi(s)
 	if err != nil {
@@ -222,6 +222,7 @@ func (s *WorkflowTimerTaskCleanupSuite) newExecutionManager() persistence.Execut
 		func() float64 { return 1000 },
 		s.TestCluster.testBase.ClusterMetadata.GetCurrentClusterName(),
 		metrics.NewNoopMetricsClient(),
+		nil,
 		s.Logger,
 		&s.TestCluster.testBase.DynamicConfiguration,
 	)
@@ -397,6 +398,7 @@ func (s *WorkflowTimerTaskCleanupDisabledSuite) newExecutionManager() persistenc
 		func() float64 { return 1000 },
 		s.TestCluster.testBase.ClusterMetadata.GetCurrentClusterName(),
 		metrics.NewNoopMetricsClient(),
+		nil,
 		s.Logger,
 		&s.TestCluster.testBase.DynamicConfiguration,
 	)
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cadence
+
+// below are the names of all DynamoDB tables, without the prefix of the configured keyspace.
+// All tables share the same key schema: a string partition key "pk" and a string sort key "sk".
+const (
+	ShardsTableName                         = "shards"
+	CurrentWorkflowsTableName               = "current_workflows"
+	ExecutionsTableName                     = "executions"
+	WorkflowRequestsTableName               = "workflow_requests"
+	ActiveClusterSelectionPoliciesTableName = "active_cluster_selection_policies"
+	HistoryTasksTableName                   = "history_tasks"
+	HistoryTreeTableName                    = "history_tree"
+	HistoryNodeTableName                    = "history_node"
+	DomainsTableName                        = "domains"
+	QueueTableName                          = "queue"
+	QueueMetadataTableName                  = "queue_metadata"
+	TaskListsTableName                      = "task_lists"
+	TasksTableName                          = "tasks"
+	VisibilityTableName                     = "visibility"
+	ClusterConfigTableName                  = "cluster_config"
+	DomainAuditLogTableName                 = "domain_audit_log"
+	HistoryTaskDLQTableName                 = "history_task_dlq"
+	HistoryTaskDLQAckLevelTableName         = "history_task_dlq_ack_level"
+)
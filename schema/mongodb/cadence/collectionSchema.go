@@ -20,15 +20,39 @@
 
 package cadence
 
+import "time"
+
 // below are the names of all mongoDB collections
 const (
-	ClusterConfigCollectionName = "cluster_config"
+	ClusterConfigCollectionName                  = "cluster_config"
+	ShardsCollectionName                         = "shards"
+	DomainsCollectionName                        = "domains"
+	DomainMetadataCollectionName                 = "domain_metadata"
+	DomainAuditLogCollectionName                 = "domain_audit_log"
+	TaskListsCollectionName                      = "task_lists"
+	TasksCollectionName                          = "tasks"
+	QueueMessagesCollectionName                  = "queue_messages"
+	QueueMetadataCollectionName                  = "queue_metadata"
+	HistoryTreeCollectionName                    = "history_tree"
+	HistoryNodeCollectionName                    = "history_node"
+	VisibilityCollectionName                     = "executions_visibility"
+	CurrentWorkflowsCollectionName               = "current_workflows"
+	ExecutionsCollectionName                     = "executions"
+	HistoryTasksCollectionName                   = "history_tasks"
+	WorkflowRequestsCollectionName               = "workflow_requests"
+	ActiveClusterSelectionPoliciesCollectionName = "active_cluster_selection_policies"
+	HistoryTaskDLQCollectionName                 = "history_task_dlq"
+	HistoryTaskDLQAckLevelCollectionName         = "history_task_dlq_ack_level"
 )
 
 // NOTE1: MongoDB collection is schemaless -- there is no schema file for collection. We use Go lang structs to define the collection fields.
 
 // NOTE2: MongoDB doesn't allow using camel case or underscore in the field names
 
+// NOTE3: Following the recommendation of nosqlplugin, only the fields used in filters, sorting or conditions are
+// stored as fields. All the others are stored in the Data field as a JSON blob, so that adding a new field to the
+// data models doesn't require a schema change.
+
 // ClusterConfigCollectionEntry is the schema of configStore
 // IMPORTANT: making change to this struct is changing the MongoDB collection schema. Please make sure it's backward compatible(e.g., don't delete the field, or change the annotation value).
 type ClusterConfigCollectionEntry struct {
@@ -39,3 +63,186 @@ type ClusterConfigCollectionEntry struct {
 	DataEncoding         string `json:"dataencoding"`
 	UnixTimestampSeconds int64  `json:"unixtimestampseconds"`
 }
+
+// ShardCollectionEntry is the schema of shards, Data is the JSON encoded shard info
+type ShardCollectionEntry struct {
+	ShardID           int    `bson:"_id"`
+	RangeID           int64  `bson:"rangeid"`
+	Data              []byte `bson:"data"`
+	ShardData         []byte `bson:"sharddata"`
+	ShardDataEncoding string `bson:"sharddataencoding"`
+}
+
+// DomainCollectionEntry is the schema of domains, Data is the JSON encoded domain row
+type DomainCollectionEntry struct {
+	ID   string `bson:"_id"`
+	Name string `bson:"name"`
+	Data []byte `bson:"data"`
+}
+
+// DomainMetadataCollectionEntry is the schema of domain_metadata, which has a single document
+type DomainMetadataCollectionEntry struct {
+	ID                  string `bson:"_id"`
+	NotificationVersion int64  `bson:"notificationversion"`
+}
+
+// DomainAuditLogCollectionEntry is the schema of domain_audit_log, Data is the JSON encoded audit log row
+type DomainAuditLogCollectionEntry struct {
+	DomainID      string     `bson:"domainid"`
+	OperationType int        `bson:"operationtype"`
+	CreatedTime   int64      `bson:"createdtime"` // unix nanoseconds
+	EventID       string     `bson:"eventid"`
+	Data          []byte     `bson:"data"`
+	Expiry        *time.Time `bson:"expiry,omitempty"`
+}
+
+// TaskListCollectionEntry is the schema of task_lists, Data is the JSON encoded tasklist row
+type TaskListCollectionEntry struct {
+	DomainID     string     `bson:"domainid"`
+	TaskListName string     `bson:"tasklistname"`
+	TaskListType int        `bson:"tasklisttype"`
+	RangeID      int64      `bson:"rangeid"`
+	Data         []byte     `bson:"data"`
+	Expiry       *time.Time `bson:"expiry,omitempty"`
+}
+
+// TaskCollectionEntry is the schema of tasks, Data is the JSON encoded task row
+type TaskCollectionEntry struct {
+	DomainID     string     `bson:"domainid"`
+	TaskListName string     `bson:"tasklistname"`
+	TaskListType int        `bson:"tasklisttype"`
+	TaskID       int64      `bson:"taskid"`
+	Data         []byte     `bson:"data"`
+	Expiry       *time.Time `bson:"expiry,omitempty"`
+}
+
+// QueueMessageCollectionEntry is the schema of queue_messages
+type QueueMessageCollectionEntry struct {
+	QueueType int    `bson:"queuetype"`
+	MessageID int64  `bson:"messageid"`
+	Payload   []byte `bson:"payload"`
+}
+
+// QueueMetadataCollectionEntry is the schema of queue_metadata, ClusterAckLevels is JSON encoded
+type QueueMetadataCollectionEntry struct {
+	QueueType        int    `bson:"_id"`
+	Version          int64  `bson:"version"`
+	ClusterAckLevels []byte `bson:"clusteracklevels"`
+}
+
+// HistoryTreeCollectionEntry is the schema of history_tree, Data is the JSON encoded tree row
+type HistoryTreeCollectionEntry struct {
+	TreeID   string `bson:"treeid"`
+	BranchID string `bson:"branchid"`
+	Data     []byte `bson:"data"`
+}
+
+// HistoryNodeCollectionEntry is the schema of history_node
+type HistoryNodeCollectionEntry struct {
+	TreeID       string `bson:"treeid"`
+	BranchID     string `bson:"branchid"`
+	NodeID       int64  `bson:"nodeid"`
+	TxnID        int64  `bson:"txnid"`
+	Data         []byte `bson:"data"`
+	DataEncoding string `bson:"dataencoding"`
+}
+
+// VisibilityCollectionEntry is the schema of executions_visibility, Data is the JSON encoded visibility row.
+// CloseTime and CloseStatus are only set for closed workflows.
+type VisibilityCollectionEntry struct {
+	DomainID     string     `bson:"domainid"`
+	WorkflowID   string     `bson:"workflowid"`
+	RunID        string     `bson:"runid"`
+	WorkflowType string     `bson:"workflowtype"`
+	StartTime    int64      `bson:"starttime"` // unix nanoseconds
+	CloseTime    *int64     `bson:"closetime,omitempty"`
+	CloseStatus  *int32     `bson:"closestatus,omitempty"`
+	Data         []byte     `bson:"data"`
+	Expiry       *time.Time `bson:"expiry,omitempty"`
+}
+
+// CurrentWorkflowCollectionEntry is the schema of current_workflows
+type CurrentWorkflowCollectionEntry struct {
+	ShardID          int    `bson:"shardid"`
+	DomainID         string `bson:"domainid"`
+	WorkflowID       string `bson:"workflowid"`
+	RunID            string `bson:"runid"`
+	State            int    `bson:"state"`
+	CloseStatus      int    `bson:"closestatus"`
+	CreateRequestID  string `bson:"createrequestid"`
+	LastWriteVersion int64  `bson:"lastwriteversion"`
+}
+
+// ExecutionCollectionEntry is the schema of executions, Data is the JSON encoded execution info.
+// The values of the maps are JSON encoded, so that an entry can be updated without reading the whole map.
+type ExecutionCollectionEntry struct {
+	ShardID            int               `bson:"shardid"`
+	DomainID           string            `bson:"domainid"`
+	WorkflowID         string            `bson:"workflowid"`
+	RunID              string            `bson:"runid"`
+	NextEventID        int64             `bson:"nexteventid"`
+	LastWriteVersion   int64             `bson:"lastwriteversion"`
+	State              int               `bson:"state"`
+	Data               []byte            `bson:"data"`
+	ActivityMap        map[string][]byte `bson:"activitymap,omitempty"`
+	TimerMap           map[string][]byte `bson:"timermap,omitempty"`
+	ChildExecutionsMap map[string][]byte `bson:"childexecutionsmap,omitempty"`
+	RequestCancelMap   map[string][]byte `bson:"requestcancelmap,omitempty"`
+	SignalMap          map[string][]byte `bson:"signalmap,omitempty"`
+	SignalRequested    map[string]string `bson:"signalrequested,omitempty"`
+	WorkflowTimerTasks map[string][]byte `bson:"workflowtimertasks,omitempty"`
+	BufferedEvents     [][]byte          `bson:"bufferedevents,omitempty"`
+}
+
+// HistoryTaskCollectionEntry is the schema of history_tasks, Data is the JSON encoded task.
+// Cluster is the target cluster of cross cluster tasks, or the source cluster of replication DLQ tasks.
+type HistoryTaskCollectionEntry struct {
+	ShardID             int    `bson:"shardid"`
+	Category            string `bson:"category"`
+	Cluster             string `bson:"cluster"`
+	VisibilityTimestamp int64  `bson:"visibilitytimestamp"` // unix nanoseconds, only for timer tasks
+	TaskID              int64  `bson:"taskid"`
+	Data                []byte `bson:"data"`
+}
+
+// WorkflowRequestCollectionEntry is the schema of workflow_requests
+type WorkflowRequestCollectionEntry struct {
+	ShardID     int       `bson:"shardid"`
+	DomainID    string    `bson:"domainid"`
+	WorkflowID  string    `bson:"workflowid"`
+	RequestType int       `bson:"requesttype"`
+	RequestID   string    `bson:"requestid"`
+	RunID       string    `bson:"runid"`
+	Expiry      time.Time `bson:"expiry"`
+}
+
+// ActiveClusterSelectionPolicyCollectionEntry is the schema of active_cluster_selection_policies, Data is the JSON encoded policy
+type ActiveClusterSelectionPolicyCollectionEntry struct {
+	ShardID    int    `bson:"shardid"`
+	DomainID   string `bson:"domainid"`
+	WorkflowID string `bson:"workflowid"`
+	RunID      string `bson:"runid"`
+	Data       []byte `bson:"data"`
+}
+
+// HistoryTaskDLQCollectionEntry is the schema of history_task_dlq, Data is the JSON encoded task row
+type HistoryTaskDLQCollectionEntry struct {
+	ShardID               int    `bson:"shardid"`
+	DomainID              string `bson:"domainid"`
+	ClusterAttributeScope string `bson:"clusterattributescope"`
+	ClusterAttributeName  string `bson:"clusterattributename"`
+	TaskType              int    `bson:"tasktype"`
+	VisibilityTimestamp   int64  `bson:"visibilitytimestamp"` // unix nanoseconds
+	TaskID                int64  `bson:"taskid"`
+	Data                  []byte `bson:"data"`
+}
+
+// HistoryTaskDLQAckLevelCollectionEntry is the schema of history_task_dlq_ack_level, Data is the JSON encoded ack level row
+type HistoryTaskDLQAckLevelCollectionEntry struct {
+	ShardID               int    `bson:"shardid"`
+	DomainID              string `bson:"domainid"`
+	ClusterAttributeScope string `bson:"clusterattributescope"`
+	ClusterAttributeName  string `bson:"clusterattributename"`
+	TaskType              int    `bson:"tasktype"`
+	Data                  []byte `bson:"data"`
+}
@@ -94,6 +94,7 @@ CREATE TABLE tasks (
   --
   data MEDIUMBLOB NOT NULL,
   data_encoding VARCHAR(16) NOT NULL,
+  priority TINYINT NOT NULL DEFAULT 0,
   PRIMARY KEY (domain_id, task_list_name, task_type, task_id)
 );
 
@@ -303,3 +304,13 @@ CREATE TABLE active_cluster_selection_policy (
   data_encoding VARCHAR(16)  NOT NULL,
   PRIMARY KEY (shard_id, domain_id, workflow_id, run_id)
 );
+
+CREATE TABLE history_shard_placements (
+  shard_id           INT         NOT NULL,
+  --
+  db_shard_id        INT         NOT NULL,
+  source_db_shard_id INT         NOT NULL,
+  state              INT         NOT NULL,
+  last_updated_time  DATETIME(6) NOT NULL,
+  PRIMARY KEY (shard_id)
+);
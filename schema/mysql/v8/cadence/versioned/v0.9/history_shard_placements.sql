@@ -0,0 +1,9 @@
+CREATE TABLE history_shard_placements (
+  shard_id           INT         NOT NULL,
+  --
+  db_shard_id        INT         NOT NULL,
+  source_db_shard_id INT         NOT NULL,
+  state              INT         NOT NULL,
+  last_updated_time  DATETIME(6) NOT NULL,
+  PRIMARY KEY (shard_id)
+);
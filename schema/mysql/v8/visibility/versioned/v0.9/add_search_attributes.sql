@@ -0,0 +1,9 @@
+-- Add search_attributes field to store the search attributes of workflows as a JSON object
+ALTER TABLE executions_visibility ADD search_attributes JSON NULL;
+
+-- Index the default custom search attributes. Other registered search attributes are indexed with `cadence admin db index-search-attr`
+CREATE INDEX by_custom_keyword_field ON executions_visibility (domain_id, (CAST(search_attributes->'$.CustomKeywordField' AS CHAR(255) ARRAY)));
+CREATE INDEX by_custom_int_field ON executions_visibility (domain_id, (CAST(search_attributes->>'$.CustomIntField' AS SIGNED)));
+CREATE INDEX by_custom_double_field ON executions_visibility (domain_id, (CAST(search_attributes->>'$.CustomDoubleField' AS DOUBLE)));
+CREATE INDEX by_custom_bool_field ON executions_visibility (domain_id, (CAST(search_attributes->>'$.CustomBoolField' AS SIGNED)));
+CREATE INDEX by_custom_datetime_field ON executions_visibility (domain_id, (CAST(search_attributes->>'$.CustomDatetimeField' AS SIGNED)));
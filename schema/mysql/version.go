@@ -23,7 +23,7 @@ package mysql
 // NOTE: whenever there is a new data base schema update, plz update the following versions
 
 // Version is the MySQL database release version
-const Version = "0.8"
+const Version = "0.10"
 
 // VisibilityVersion is the MySQL visibility database release version
-const VisibilityVersion = "0.8"
+const VisibilityVersion = "0.9"
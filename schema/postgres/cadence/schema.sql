@@ -92,6 +92,7 @@ CREATE TABLE tasks (
   --
   data BYTEA NOT NULL,
   data_encoding VARCHAR(16) NOT NULL,
+  priority SMALLINT NOT NULL DEFAULT 0,
   PRIMARY KEY (domain_id, task_list_name, task_type, task_id)
 );
 
@@ -290,3 +291,13 @@ CREATE TABLE domain_audit_log (
   comment                 TEXT NOT NULL DEFAULT '',
   PRIMARY KEY (domain_id, operation_type, created_time, event_id)
 );
+
+CREATE TABLE history_shard_placements (
+  shard_id           INTEGER   NOT NULL,
+  --
+  db_shard_id        INTEGER   NOT NULL,
+  source_db_shard_id INTEGER   NOT NULL,
+  state              INTEGER   NOT NULL,
+  last_updated_time  TIMESTAMP NOT NULL,
+  PRIMARY KEY (shard_id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE history_shard_placements (
+  shard_id           INTEGER   NOT NULL,
+  --
+  db_shard_id        INTEGER   NOT NULL,
+  source_db_shard_id INTEGER   NOT NULL,
+  state              INTEGER   NOT NULL,
+  last_updated_time  TIMESTAMP NOT NULL,
+  PRIMARY KEY (shard_id)
+);
@@ -18,6 +18,7 @@ CREATE TABLE executions_visibility (
   cron_schedule            VARCHAR(255) NULL,
   execution_status         INTEGER NULL,
   scheduled_execution_time TIMESTAMP NULL,
+  search_attributes        JSONB NULL,
 
   PRIMARY KEY  (domain_id, run_id)
 );
@@ -26,3 +27,8 @@ CREATE INDEX by_type_start_time ON executions_visibility (domain_id, workflow_ty
 CREATE INDEX by_workflow_id_start_time ON executions_visibility (domain_id, workflow_id, close_status, start_time DESC, run_id);
 CREATE INDEX by_status_by_close_time ON executions_visibility (domain_id, close_status, start_time DESC, run_id);
 CREATE INDEX by_close_time_by_status ON executions_visibility (domain_id, close_time DESC, run_id, close_status);
+CREATE INDEX by_custom_keyword_field ON executions_visibility USING GIN ((search_attributes->'CustomKeywordField'));
+CREATE INDEX by_custom_int_field ON executions_visibility (domain_id, ((search_attributes->>'CustomIntField')::BIGINT));
+CREATE INDEX by_custom_double_field ON executions_visibility (domain_id, ((search_attributes->>'CustomDoubleField')::DOUBLE PRECISION));
+CREATE INDEX by_custom_bool_field ON executions_visibility (domain_id, ((search_attributes->>'CustomBoolField')::BIGINT));
+CREATE INDEX by_custom_datetime_field ON executions_visibility (domain_id, ((search_attributes->>'CustomDatetimeField')::BIGINT));
@@ -0,0 +1,9 @@
+-- Add search_attributes field to store the search attributes of workflows as a JSON object
+ALTER TABLE executions_visibility ADD search_attributes JSONB NULL;
+
+-- Index the default custom search attributes. Other registered search attributes are indexed with `cadence admin db index-search-attr`
+CREATE INDEX by_custom_keyword_field ON executions_visibility USING GIN ((search_attributes->'CustomKeywordField'));
+CREATE INDEX by_custom_int_field ON executions_visibility (domain_id, ((search_attributes->>'CustomIntField')::BIGINT));
+CREATE INDEX by_custom_double_field ON executions_visibility (domain_id, ((search_attributes->>'CustomDoubleField')::DOUBLE PRECISION));
+CREATE INDEX by_custom_bool_field ON executions_visibility (domain_id, ((search_attributes->>'CustomBoolField')::BIGINT));
+CREATE INDEX by_custom_datetime_field ON executions_visibility (domain_id, ((search_attributes->>'CustomDatetimeField')::BIGINT));
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN priority TINYINT NOT NULL DEFAULT 0;
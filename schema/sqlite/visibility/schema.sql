@@ -19,6 +19,7 @@ CREATE TABLE executions_visibility
     cron_schedule            TEXT                       NULL,
     execution_status         INT                        NULL,
     scheduled_execution_time TIMESTAMP                  NULL,
+    search_attributes        TEXT                       NULL,
 
     PRIMARY KEY (domain_id, run_id)
 );
@@ -27,3 +28,7 @@ CREATE INDEX by_type_start_time ON executions_visibility (domain_id, workflow_ty
 CREATE INDEX by_workflow_id_start_time ON executions_visibility (domain_id, workflow_id, close_status, start_time DESC, run_id);
 CREATE INDEX by_status_by_close_time ON executions_visibility (domain_id, close_status, start_time DESC, run_id);
 CREATE INDEX by_close_time_by_status ON executions_visibility (domain_id, close_time DESC, run_id, close_status);
+CREATE INDEX by_custom_int_field ON executions_visibility (domain_id, json_extract(search_attributes, '$.CustomIntField'));
+CREATE INDEX by_custom_double_field ON executions_visibility (domain_id, json_extract(search_attributes, '$.CustomDoubleField'));
+CREATE INDEX by_custom_bool_field ON executions_visibility (domain_id, json_extract(search_attributes, '$.CustomBoolField'));
+CREATE INDEX by_custom_datetime_field ON executions_visibility (domain_id, json_extract(search_attributes, '$.CustomDatetimeField'));
@@ -0,0 +1,8 @@
+-- Add search_attributes field to store the search attributes of workflows as a JSON object
+ALTER TABLE executions_visibility ADD search_attributes TEXT;
+
+-- Index the default custom search attributes. Other registered search attributes are indexed with `cadence admin db index-search-attr`
+CREATE INDEX by_custom_int_field ON executions_visibility (domain_id, json_extract(search_attributes, '$.CustomIntField'));
+CREATE INDEX by_custom_double_field ON executions_visibility (domain_id, json_extract(search_attributes, '$.CustomDoubleField'));
+CREATE INDEX by_custom_bool_field ON executions_visibility (domain_id, json_extract(search_attributes, '$.CustomBoolField'));
+CREATE INDEX by_custom_datetime_field ON executions_visibility (domain_id, json_extract(search_attributes, '$.CustomDatetimeField'));
@@ -2046,6 +2046,8 @@ func (wh *WorkflowHandler) GetWorkflowExecutionHistory(
 				nextPageToken,
 				token.TransientDecision,
 				token.BranchToken,
+				// history of a closed workflow is immutable, so a replica is safe to read from
+				!token.IsWorkflowRunning,
 			)
 		}
 		if err != nil {
@@ -2840,38 +2842,47 @@ func (wh *WorkflowHandler) getHistory(
 	nextPageToken []byte,
 	transientDecision *types.TransientDecisionInfo,
 	branchToken []byte,
+	readFromReplica bool,
 ) (*types.History, []byte, error) {
 
-	var size int
-
 	isFirstPage := len(nextPageToken) == 0
 	shardID := common.WorkflowIDToHistoryShard(execution.WorkflowID, wh.config.NumHistoryShards)
-	var err error
-	historyEvents, size, nextPageToken, err := persistenceutils.ReadFullPageV2Events(ctx, wh.GetHistoryManager(), &persistence.ReadHistoryBranchRequest{
-		BranchToken:   branchToken,
-		MinEventID:    firstEventID,
-		MaxEventID:    nextEventID,
-		PageSize:      int(pageSize),
-		NextPageToken: nextPageToken,
-		ShardID:       common.IntPtr(shardID),
-		DomainName:    domainName,
-	})
+	readPage := func(fromReplica bool) ([]*types.HistoryEvent, []byte, error) {
+		historyEvents, size, token, err := persistenceutils.ReadFullPageV2Events(ctx, wh.GetHistoryManager(), &persistence.ReadHistoryBranchRequest{
+			BranchToken:     branchToken,
+			MinEventID:      firstEventID,
+			MaxEventID:      nextEventID,
+			PageSize:        int(pageSize),
+			NextPageToken:   nextPageToken,
+			ShardID:         common.IntPtr(shardID),
+			DomainName:      domainName,
+			ReadFromReplica: fromReplica,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		scope.RecordTimer(metrics.HistorySize, time.Duration(size))
+		scope.IntExponentialHistogram(metrics.HistorySizeHistogram, size)
+		return historyEvents, token, nil
+	}
 
+	historyEvents, nextPageToken, err := readPage(readFromReplica)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	scope.RecordTimer(metrics.HistorySize, time.Duration(size))
-	scope.IntExponentialHistogram(metrics.HistorySizeHistogram, size)
-
 	isLastPage := len(nextPageToken) == 0
-	if err := verifyHistoryIsComplete(
-		historyEvents,
-		firstEventID,
-		nextEventID-1,
-		isFirstPage,
-		isLastPage,
-		int(pageSize)); err != nil {
+	err = verifyHistoryIsComplete(historyEvents, firstEventID, nextEventID-1, isFirstPage, isLastPage, int(pageSize))
+	if err != nil && readFromReplica {
+		// a lagging replica may not have all events yet, re-read the page from the primary
+		historyEvents, nextPageToken, err = readPage(false)
+		if err != nil {
+			return nil, nil, err
+		}
+		isLastPage = len(nextPageToken) == 0
+		err = verifyHistoryIsComplete(historyEvents, firstEventID, nextEventID-1, isFirstPage, isLastPage, int(pageSize))
+	}
+	if err != nil {
 		scope.IncCounter(metrics.CadenceErrIncompleteHistoryCounter)
 		wh.GetLogger().Error("getHistory: incomplete history",
 			tag.WorkflowDomainID(domainID),
@@ -2994,6 +3005,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(
 			nil,
 			matchingResp.DecisionInfo,
 			branchToken,
+			false,
 		)
 		if err != nil {
 			return nil, err
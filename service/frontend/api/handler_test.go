@@ -1815,12 +1815,60 @@ func (s *workflowHandlerSuite) TestGetHistory() {
 	wh := s.getWorkflowHandler(s.newConfig(dc.NewInMemoryClient()))
 
 	scope := metrics.NoopScope
-	actualHistory, token, err := wh.getHistory(context.Background(), scope, domainID, domainName, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken)
+	actualHistory, token, err := wh.getHistory(context.Background(), scope, domainID, domainName, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken, false)
 	s.NoError(err)
 	s.NotNil(actualHistory)
 	s.Equal([]byte{}, token)
 }
 
+func (s *workflowHandlerSuite) TestGetHistory_ReplicaFallbackToPrimary() {
+	domainID := uuid.New()
+	domainName := uuid.New()
+	firstEventID := int64(100)
+	nextEventID := int64(101)
+	branchToken := []byte{1}
+	we := types.WorkflowExecution{
+		WorkflowID: "wid",
+		RunID:      "rid",
+	}
+	shardID := common.WorkflowIDToHistoryShard(we.WorkflowID, numHistoryShards)
+	req := &persistence.ReadHistoryBranchRequest{
+		BranchToken:     branchToken,
+		MinEventID:      firstEventID,
+		MaxEventID:      nextEventID,
+		PageSize:        0,
+		NextPageToken:   []byte{},
+		ShardID:         common.IntPtr(shardID),
+		DomainName:      domainName,
+		ReadFromReplica: true,
+	}
+	// the replica is lagging behind and does not have the requested events yet
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.Anything, req).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents:    []*types.HistoryEvent{},
+		NextPageToken:    []byte{},
+		LastFirstEventID: nextEventID,
+	}, nil).Once()
+	primaryReq := *req
+	primaryReq.ReadFromReplica = false
+	s.mockHistoryV2Mgr.On("ReadHistoryBranch", mock.Anything, &primaryReq).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: []*types.HistoryEvent{
+			{
+				ID: int64(100),
+			},
+		},
+		NextPageToken:    []byte{},
+		Size:             1,
+		LastFirstEventID: nextEventID,
+	}, nil).Once()
+
+	wh := s.getWorkflowHandler(s.newConfig(dc.NewInMemoryClient()))
+
+	actualHistory, token, err := wh.getHistory(context.Background(), metrics.NoopScope, domainID, domainName, we, firstEventID, nextEventID, 0, []byte{}, nil, branchToken, true)
+	s.NoError(err)
+	s.Len(actualHistory.Events, 1)
+	s.Equal([]byte{}, token)
+}
+
 func (s *workflowHandlerSuite) TestListArchivedVisibility_Failure_InvalidRequest() {
 	wh := s.getWorkflowHandler(s.newConfig(dc.NewInMemoryClient()))
 
@@ -78,7 +78,7 @@ func (e *executorWrapper) isActiveTask(
 	wfID := task.GetWorkflowID()
 	rID := task.GetRunID()
 
-	activeClusterInfo, err := e.activeClusterMgr.GetActiveClusterInfoByWorkflow(context.Background(), domainID, wfID, rID)
+	activeClusterInfo, err := e.activeClusterMgr.GetActiveClusterInfoByWorkflow(contextWithSpan(context.Background(), task), domainID, wfID, rID)
 	if err != nil {
 		e.logger.Warn("Failed to get active cluster info, process task as active.", tag.WorkflowDomainID(domainID), tag.WorkflowID(wfID), tag.WorkflowRunID(rID), tag.Error(err))
 		return true
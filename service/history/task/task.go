@@ -21,11 +21,15 @@
 package task
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/backoff"
 	"github.com/uber/cadence/common/cache"
@@ -37,6 +41,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	ctask "github.com/uber/cadence/common/task"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/execution"
 	"github.com/uber/cadence/service/history/shard"
@@ -95,6 +100,8 @@ type (
 		rescheduler              Rescheduler
 		criticalRetryCount       dynamicproperties.IntPropertyFn
 		isPreviousExecutorActive bool
+		// span of the execution attempt in progress, nil between attempts
+		span trace.Span
 
 		// TODO: following three fields should be removed after new task lifecycle is implemented
 		taskFilter        Filter
@@ -210,7 +217,10 @@ func (t *taskImpl) Execute() error {
 		taskListTaggedScope.IncCounter(metrics.TaskRequestsPerTaskList)
 		taskListTaggedScope.ExponentialHistogram(metrics.TaskProcessingLatencyPerTaskListHistogram, processingLatency)
 	}()
+	t.span = t.startSpan()
 	executeResponse, err := t.taskExecutor.Execute(t)
+	tracing.EndSpan(t.span, err)
+	t.span = nil
 	t.scope = executeResponse.Scope
 	taskListTaggedScope = t.scope.Tagged(common.GetTaskListTag(t.GetOriginalTaskList(), t.GetOriginalTaskListKind()))
 	if t.GetAttempt() == 0 {
@@ -226,6 +236,34 @@ func (t *taskImpl) Execute() error {
 	return err
 }
 
+// startSpan starts a root span for one execution attempt of the task.
+// Executors pick it up with contextWithSpan, so the calls they make are traced as its children.
+func (t *taskImpl) startSpan() trace.Span {
+	_, span := tracing.Tracer(t.shard.GetService().GetTracerProvider()).Start(
+		context.Background(),
+		"HistoryTask."+t.GetTaskCategory().Name(),
+		trace.WithAttributes(
+			attribute.Int("cadence.shard_id", t.shard.GetShardID()),
+			attribute.String("cadence.domain_id", t.GetDomainID()),
+			attribute.String("cadence.workflow_id", t.GetWorkflowID()),
+			attribute.String("cadence.run_id", t.GetRunID()),
+			attribute.Int64("cadence.task_id", t.GetTaskID()),
+			attribute.Int("cadence.task_type", t.GetTaskType()),
+			attribute.Int("cadence.attempt", t.GetAttempt()),
+		),
+	)
+	return span
+}
+
+// contextWithSpan returns ctx carrying the span of the task's execution attempt in progress,
+// or ctx itself if the task is not being executed
+func contextWithSpan(ctx context.Context, task Task) context.Context {
+	if t, ok := task.(*taskImpl); ok && t.span != nil {
+		return trace.ContextWithSpan(ctx, t.span)
+	}
+	return ctx
+}
+
 func (t *taskImpl) resetAttempt() {
 	t.Lock()
 	defer t.Unlock()
@@ -29,6 +29,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.uber.org/mock/gomock"
 
 	"github.com/uber/cadence/common/cache"
@@ -39,6 +42,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	ctask "github.com/uber/cadence/common/task"
+	"github.com/uber/cadence/common/tracing"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/config"
 	"github.com/uber/cadence/service/history/constants"
@@ -85,6 +89,11 @@ func (s *taskSuite) SetupTest() {
 	s.mockTaskRedispatcher = NewMockRedispatcher(s.controller)
 	s.mockTaskInfo = persistence.NewMockTask(s.controller)
 	s.mockTaskInfo.EXPECT().GetDomainID().Return(constants.TestDomainID).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetWorkflowID().Return(constants.TestWorkflowID).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetRunID().Return(constants.TestRunID).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetTaskID().Return(int64(1234)).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetTaskType().Return(persistence.TransferTaskTypeDecisionTask).AnyTimes()
+	s.mockTaskInfo.EXPECT().GetTaskCategory().Return(persistence.HistoryTaskCategoryTransfer).AnyTimes()
 	s.mockTaskInfo.EXPECT().GetOriginalTaskList().Return("test-task-list").AnyTimes()
 	s.mockTaskInfo.EXPECT().GetOriginalTaskListKind().Return(types.TaskListKindNormal).AnyTimes()
 	s.mockShard.Resource.DomainCache.EXPECT().GetDomainName(constants.TestDomainID).Return(constants.TestDomainName, nil).AnyTimes()
@@ -136,6 +145,42 @@ func (s *taskSuite) TestExecute_Success() {
 	s.NoError(err)
 }
 
+func (s *taskSuite) TestExecute_Tracing() {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewTracerProviderWithExporter(exporter, "cadence-history", 1)
+	s.mockShard.Resource.TracerProvider = provider
+
+	task := s.newTestTask(func(task persistence.Task) (bool, error) {
+		return true, nil
+	})
+
+	executionErr := errors.New("some random error")
+	s.mockTaskExecutor.EXPECT().Execute(task).DoAndReturn(func(task Task) (ExecuteResponse, error) {
+		// calls the executor makes are traced as children of the task span
+		_, span := tracing.Tracer(provider).Start(contextWithSpan(context.Background(), task), "child")
+		span.End()
+		return ExecuteResponse{
+			Scope:        metrics.NoopScope,
+			IsActiveTask: true,
+		}, executionErr
+	}).Times(1)
+
+	s.Equal(executionErr, task.Execute())
+	s.NoError(provider.ForceFlush(context.Background()))
+
+	spans := exporter.GetSpans()
+	s.Len(spans, 2)
+	child, root := spans[0], spans[1]
+	s.Equal("HistoryTask."+persistence.HistoryTaskCategoryTransfer.Name(), root.Name)
+	s.Equal(codes.Error, root.Status.Code)
+	s.Contains(root.Attributes, attribute.String("cadence.workflow_id", constants.TestWorkflowID))
+	s.Contains(root.Attributes, attribute.Int64("cadence.task_id", 1234))
+	s.Equal(root.SpanContext.SpanID(), child.Parent.SpanID())
+
+	// the span is not handed out once the attempt is over
+	s.Equal(context.Background(), contextWithSpan(context.Background(), task))
+}
+
 func (s *taskSuite) TestHandleErr_ErrEntityNotExists() {
 	taskBase := s.newTestTask(func(task persistence.Task) (bool, error) {
 		return true, nil
@@ -96,33 +96,34 @@ func (t *timerActiveTaskExecutor) Execute(task Task) (ExecuteResponse, error) {
 		Scope:        scope,
 		IsActiveTask: true,
 	}
+	taskCtx := contextWithSpan(t.ctx, task)
 	switch timerTask := task.GetInfo().(type) {
 	case *persistence.UserTimerTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeUserTimerTimeoutTask(ctx, timerTask)
 	case *persistence.ActivityTimeoutTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeActivityTimeoutTask(ctx, timerTask)
 	case *persistence.DecisionTimeoutTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeDecisionTimeoutTask(ctx, timerTask)
 	case *persistence.WorkflowTimeoutTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeWorkflowTimeoutTask(ctx, timerTask)
 	case *persistence.ActivityRetryTimerTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeActivityRetryTimerTask(ctx, timerTask)
 	case *persistence.WorkflowBackoffTimerTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeWorkflowBackoffTimerTask(ctx, timerTask)
 	case *persistence.DeleteHistoryEventTask:
-		ctx, cancel := context.WithTimeout(t.ctx, time.Duration(t.config.DeleteHistoryEventContextTimeout())*time.Second)
+		ctx, cancel := context.WithTimeout(taskCtx, time.Duration(t.config.DeleteHistoryEventContextTimeout())*time.Second)
 		defer cancel()
 		return executeResponse, t.executeDeleteHistoryEventTask(ctx, timerTask)
 	default:
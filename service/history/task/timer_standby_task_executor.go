@@ -107,21 +107,22 @@ func (t *timerStandbyTaskExecutor) Execute(task Task) (ExecuteResponse, error) {
 		Scope:        scope,
 		IsActiveTask: false,
 	}
+	taskCtx := contextWithSpan(t.ctx, task)
 	switch timerTask := task.GetInfo().(type) {
 	case *persistence.UserTimerTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeUserTimerTimeoutTask(ctx, timerTask)
 	case *persistence.ActivityTimeoutTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeActivityTimeoutTask(ctx, timerTask)
 	case *persistence.DecisionTimeoutTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeDecisionTimeoutTask(ctx, timerTask)
 	case *persistence.WorkflowTimeoutTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeWorkflowTimeoutTask(ctx, timerTask)
 	case *persistence.ActivityRetryTimerTask:
@@ -129,12 +130,12 @@ func (t *timerStandbyTaskExecutor) Execute(task Task) (ExecuteResponse, error) {
 		// TODO: add error logs
 		return executeResponse, nil
 	case *persistence.WorkflowBackoffTimerTask:
-		ctx, cancel := context.WithTimeout(t.ctx, taskDefaultTimeout)
+		ctx, cancel := context.WithTimeout(taskCtx, taskDefaultTimeout)
 		defer cancel()
 		return executeResponse, t.executeWorkflowBackoffTimerTask(ctx, timerTask)
 	case *persistence.DeleteHistoryEventTask:
 		// special timeout for delete history event
-		deleteHistoryEventContext, deleteHistoryEventCancel := context.WithTimeout(t.ctx, time.Duration(t.config.DeleteHistoryEventContextTimeout())*time.Second)
+		deleteHistoryEventContext, deleteHistoryEventCancel := context.WithTimeout(taskCtx, time.Duration(t.config.DeleteHistoryEventContextTimeout())*time.Second)
 		defer deleteHistoryEventCancel()
 		return executeResponse, t.executeDeleteHistoryEventTask(deleteHistoryEventContext, timerTask)
 	default:
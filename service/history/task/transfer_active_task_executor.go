@@ -132,7 +132,7 @@ func (t *transferActiveTaskExecutor) Execute(task Task) (ExecuteResponse, error)
 		Scope:        scope,
 		IsActiveTask: true,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), taskDefaultTimeout)
+	ctx, cancel := context.WithTimeout(contextWithSpan(context.Background(), task), taskDefaultTimeout)
 	defer cancel()
 
 	switch transferTask := task.GetInfo().(type) {
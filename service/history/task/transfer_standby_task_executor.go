@@ -102,7 +102,7 @@ func (t *transferStandbyTaskExecutor) Execute(task Task) (ExecuteResponse, error
 		Scope:        scope,
 		IsActiveTask: false,
 	}
-	ctx, cancel := context.WithTimeout(context.Background(), taskDefaultTimeout)
+	ctx, cancel := context.WithTimeout(contextWithSpan(context.Background(), task), taskDefaultTimeout)
 	defer cancel()
 
 	switch transferTask := task.GetInfo().(type) {
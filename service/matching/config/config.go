@@ -79,6 +79,10 @@ type (
 		IsolationGroupHasPollersSustainedDuration dynamicproperties.DurationPropertyFnWithTaskListInfoFilters
 		IsolationGroupNoPollersSustainedDuration  dynamicproperties.DurationPropertyFnWithTaskListInfoFilters
 		IsolationGroupsPerPartition               dynamicproperties.IntPropertyFnWithTaskListInfoFilters
+		EnableTaskPriority                        dynamicproperties.BoolPropertyFnWithTaskListInfoFilters
+		TaskPriorityStarvationThreshold           dynamicproperties.IntPropertyFnWithTaskListInfoFilters
+		EnableTaskFairness                        dynamicproperties.BoolPropertyFnWithTaskListInfoFilters
+		TaskFairnessKeyWeights                    dynamicproperties.MapPropertyFnWithDomainFilter
 
 		// Time to hold a poll request before returning an empty response if there are no tasks
 		LongPollExpirationInterval dynamicproperties.DurationPropertyFnWithTaskListInfoFilters
@@ -153,6 +157,12 @@ type (
 		IsolationGroupHasPollersSustainedDuration func() time.Duration
 		IsolationGroupNoPollersSustainedDuration  func() time.Duration
 		IsolationGroupsPerPartition               func() int
+		// priority configuration
+		EnableTaskPriority              func() bool
+		TaskPriorityStarvationThreshold func() int
+		// fairness configuration
+		EnableTaskFairness     func() bool
+		TaskFairnessKeyWeights func() map[string]interface{}
 		// taskWriter configuration
 		OutstandingTaskAppendsThreshold      func() int
 		MaxTaskBatchSize                     func() int
@@ -233,6 +243,10 @@ func NewConfig(dc *dynamicconfig.Collection, operationalDC *dynamicconfig.Collec
 		IsolationGroupHasPollersSustainedDuration:  dc.GetDurationPropertyFilteredByTaskListInfo(dynamicproperties.MatchingIsolationGroupHasPollersSustainedDuration),
 		IsolationGroupNoPollersSustainedDuration:   dc.GetDurationPropertyFilteredByTaskListInfo(dynamicproperties.MatchingIsolationGroupNoPollersSustainedDuration),
 		IsolationGroupsPerPartition:                dc.GetIntPropertyFilteredByTaskListInfo(dynamicproperties.MatchingIsolationGroupsPerPartition),
+		EnableTaskPriority:                         dc.GetBoolPropertyFilteredByTaskListInfo(dynamicproperties.MatchingEnableTaskPriority),
+		TaskPriorityStarvationThreshold:            dc.GetIntPropertyFilteredByTaskListInfo(dynamicproperties.MatchingTaskPriorityStarvationThreshold),
+		EnableTaskFairness:                         dc.GetBoolPropertyFilteredByTaskListInfo(dynamicproperties.MatchingEnableTaskFairness),
+		TaskFairnessKeyWeights:                     dc.GetMapPropertyFilteredByDomain(dynamicproperties.MatchingTaskFairnessKeyWeights),
 		TaskIsolationDuration:                      dc.GetDurationPropertyFilteredByTaskListInfo(dynamicproperties.TaskIsolationDuration),
 		TaskIsolationPollerWindow:                  dc.GetDurationPropertyFilteredByTaskListInfo(dynamicproperties.TaskIsolationPollerWindow),
 		HostName:                                   hostName,
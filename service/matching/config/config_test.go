@@ -104,6 +104,10 @@ func TestNewConfig(t *testing.T) {
 		"IsolationGroupHasPollersSustainedDuration": {dynamicproperties.MatchingIsolationGroupHasPollersSustainedDuration, time.Duration(39)},
 		"IsolationGroupNoPollersSustainedDuration":  {dynamicproperties.MatchingIsolationGroupNoPollersSustainedDuration, time.Duration(40)},
 		"IsolationGroupsPerPartition":               {dynamicproperties.MatchingIsolationGroupsPerPartition, 41},
+		"EnableTaskPriority":                        {dynamicproperties.MatchingEnableTaskPriority, true},
+		"TaskPriorityStarvationThreshold":           {dynamicproperties.MatchingTaskPriorityStarvationThreshold, 44},
+		"EnableTaskFairness":                        {dynamicproperties.MatchingEnableTaskFairness, true},
+		"TaskFairnessKeyWeights":                    {dynamicproperties.MatchingTaskFairnessKeyWeights, map[string]interface{}{"tenant": 3}},
 		"EnableReturnAllTaskListKinds":              {dynamicproperties.MatchingEnableReturnAllTaskListKinds, true},
 		"AppendTaskTimeout":                         {dynamicproperties.AppendTaskTimeout, time.Duration(42)},
 		"RecordTaskStartedTimeout":                  {dynamicproperties.MatchingRecordTaskStartedTimeout, time.Duration(43)},
@@ -188,6 +192,8 @@ func getValue(f *reflect.Value) interface{} {
 			return fn()
 		case dynamicproperties.MapPropertyFn:
 			return fn()
+		case dynamicproperties.MapPropertyFnWithDomainFilter:
+			return fn("domain")
 		case dynamicproperties.StringPropertyFn:
 			return fn()
 		case dynamicproperties.FloatPropertyFnWithTaskListInfoFilters:
@@ -54,6 +54,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/rpc"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/taskpriority"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/matching/config"
 	"github.com/uber/cadence/service/matching/event"
@@ -453,6 +454,7 @@ func (e *matchingEngineImpl) AddDecisionTask(
 		ScheduleToStartTimeoutSeconds: request.GetScheduleToStartTimeoutSeconds(),
 		CreatedTime:                   e.timeSource.Now(),
 		PartitionConfig:               request.GetPartitionConfig(),
+		Priority:                      taskpriority.FromPartitionConfig(request.GetPartitionConfig()),
 	}
 
 	syncMatched, err := tlMgr.AddTask(hCtx.Context, tasklist.AddTaskParams{
@@ -529,6 +531,7 @@ func (e *matchingEngineImpl) AddActivityTask(
 		ScheduleToStartTimeoutSeconds: request.GetScheduleToStartTimeoutSeconds(),
 		CreatedTime:                   e.timeSource.Now(),
 		PartitionConfig:               request.GetPartitionConfig(),
+		Priority:                      taskpriority.FromPartitionConfig(request.GetPartitionConfig()),
 	}
 
 	syncMatched, err := tlMgr.AddTask(hCtx.Context, tasklist.AddTaskParams{
@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tasklist
+
+import (
+	"sync"
+
+	"github.com/uber/cadence/common/messaging"
+)
+
+type (
+	// backlogKey is the share of the backlog a task is read and buffered under
+	backlogKey struct {
+		priority    int
+		fairnessKey string
+	}
+
+	// backlogMode is how backlog tasks are keyed
+	backlogMode struct {
+		priority bool
+		fairness bool
+	}
+
+	// skippedTasks are the tasks of a key that were read past without being
+	// buffered because the key had no room in its task buffer
+	skippedTasks struct {
+		// from is the lowest task ID of the key that may still be skipped; every
+		// task of the key below it has been buffered
+		from int64
+		// count is the number of skipped tasks
+		count int64
+	}
+
+	// backlogAckManager tracks the ack level of a backlog that is not buffered in
+	// task ID order. The taskReader reads the backlog in task ID order, but a key
+	// with no room in its buffer has its tasks skipped, so a busy key does not
+	// hold back the keys read after it. Skipped tasks are read again, and
+	// buffered, once the key has room.
+	//
+	// Tasks buffered in ID order are tracked by the wrapped AckManager. Skipped
+	// tasks and the tasks buffered when they are read again are tracked here, and
+	// hold back the ack level so that none of them is deleted before it is
+	// dispatched.
+	backlogAckManager struct {
+		messaging.AckManager
+
+		sync.Mutex
+		skipped map[backlogKey]*skippedTasks
+		// refilled holds the tasks read again from the skipped tasks that have not
+		// been acked yet
+		refilled map[int64]struct{}
+		// mode is how the skipped tasks were keyed. Keys must not change while
+		// tasks are skipped, or a skipped task could be read again under a key it
+		// was not skipped under and be lost.
+		mode backlogMode
+	}
+)
+
+func newBacklogAckManager(ackManager messaging.AckManager) *backlogAckManager {
+	return &backlogAckManager{
+		AckManager: ackManager,
+		skipped:    make(map[backlogKey]*skippedTasks),
+		refilled:   make(map[int64]struct{}),
+	}
+}
+
+// AckItem acks a task that was buffered, whether in ID order or when it was
+// read again
+func (m *backlogAckManager) AckItem(id int64) int64 {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.refilled[id]; ok {
+		delete(m.refilled, id)
+		return m.ackLevel(m.AckManager.GetAckLevel())
+	}
+	return m.ackLevel(m.AckManager.AckItem(id))
+}
+
+// GetAckLevel returns the highest task ID below which every task has been acked
+func (m *backlogAckManager) GetAckLevel() int64 {
+	m.Lock()
+	defer m.Unlock()
+	return m.ackLevel(m.AckManager.GetAckLevel())
+}
+
+// GetBacklogCount returns the number of tasks that have been read or skipped
+// but not acked
+func (m *backlogAckManager) GetBacklogCount() int64 {
+	m.Lock()
+	defer m.Unlock()
+	count := m.AckManager.GetBacklogCount() + int64(len(m.refilled))
+	for _, s := range m.skipped {
+		count += s.count
+	}
+	return count
+}
+
+func (m *backlogAckManager) ackLevel(ackLevel int64) int64 {
+	for _, s := range m.skipped {
+		ackLevel = min(ackLevel, s.from-1)
+	}
+	for id := range m.refilled {
+		ackLevel = min(ackLevel, id-1)
+	}
+	return ackLevel
+}
+
+// skippedMode returns the mode the skipped tasks were keyed with, and false if
+// no task is skipped
+func (m *backlogAckManager) skippedMode() (backlogMode, bool) {
+	m.Lock()
+	defer m.Unlock()
+	return m.mode, len(m.skipped) > 0
+}
+
+// isSkipped returns true if key has skipped tasks. A task of such a key must be
+// skipped too, so that the tasks of a key are buffered in ID order.
+func (m *backlogAckManager) isSkipped(key backlogKey) bool {
+	m.Lock()
+	defer m.Unlock()
+	_, ok := m.skipped[key]
+	return ok
+}
+
+// skippedKeys returns the lowest task ID that may be skipped of each key with
+// skipped tasks
+func (m *backlogAckManager) skippedKeys() map[backlogKey]int64 {
+	m.Lock()
+	defer m.Unlock()
+	keys := make(map[backlogKey]int64, len(m.skipped))
+	for key, s := range m.skipped {
+		keys[key] = s.from
+	}
+	return keys
+}
+
+// hasSkippedAbove returns true if a task more urgent than priority is skipped
+func (m *backlogAckManager) hasSkippedAbove(priority int) bool {
+	m.Lock()
+	defer m.Unlock()
+	if !m.mode.priority {
+		return false
+	}
+	for key := range m.skipped {
+		if key.priority < priority {
+			return true
+		}
+	}
+	return false
+}
+
+// skip records that a task of key, keyed under mode, was read but not buffered
+// because the key had no room
+func (m *backlogAckManager) skip(key backlogKey, mode backlogMode, id int64) {
+	m.Lock()
+	defer m.Unlock()
+	if _, ok := m.refilled[id]; ok {
+		delete(m.refilled, id)
+	} else {
+		// acking it in the wrapped AckManager is safe: the skipped task holds
+		// back the ack level until it is read again
+		m.AckManager.AckItem(id)
+	}
+	if len(m.skipped) == 0 {
+		m.mode = mode
+	}
+	s, ok := m.skipped[key]
+	if !ok {
+		m.skipped[key] = &skippedTasks{from: id, count: 1}
+		return
+	}
+	s.from = min(s.from, id)
+	s.count++
+}
+
+// refill records that a skipped task of key was read again and buffered
+func (m *backlogAckManager) refill(key backlogKey, id int64) {
+	m.Lock()
+	defer m.Unlock()
+	m.refilled[id] = struct{}{}
+	m.drop(key)
+}
+
+// expire records that a skipped task of key expired before it was read again
+func (m *backlogAckManager) expire(key backlogKey) {
+	m.Lock()
+	defer m.Unlock()
+	m.drop(key)
+}
+
+func (m *backlogAckManager) drop(key backlogKey) {
+	if s, ok := m.skipped[key]; ok && s.count > 0 {
+		s.count--
+	}
+}
+
+// advance records that key has no skipped task below from. The key has no
+// skipped tasks left once from is past the read level.
+func (m *backlogAckManager) advance(key backlogKey, from int64) {
+	m.Lock()
+	defer m.Unlock()
+	s, ok := m.skipped[key]
+	if !ok {
+		return
+	}
+	s.from = max(s.from, from)
+	if s.from > m.AckManager.GetReadLevel() {
+		delete(m.skipped, key)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tasklist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/messaging"
+	"github.com/uber/cadence/common/taskpriority"
+)
+
+func TestBacklogAckManager(t *testing.T) {
+	m := newBacklogAckManager(messaging.NewAckManager(testlogger.New(t)))
+	mode := backlogMode{priority: true}
+	low := backlogKey{priority: taskpriority.Lowest}
+
+	for id := int64(1); id <= 5; id++ {
+		require.NoError(t, m.ReadItem(id))
+	}
+	// tasks 3 and 4 had no room
+	m.skip(low, mode, 3)
+	m.skip(low, mode, 4)
+	assert.Equal(t, int64(5), m.GetBacklogCount())
+	assert.Equal(t, map[backlogKey]int64{low: 3}, m.skippedKeys())
+	skippedMode, ok := m.skippedMode()
+	require.True(t, ok)
+	assert.Equal(t, mode, skippedMode)
+
+	for _, id := range []int64{1, 2, 5} {
+		m.AckItem(id)
+	}
+	assert.Equal(t, int64(2), m.GetAckLevel(), "skipped tasks hold back the ack level")
+	assert.Equal(t, int64(2), m.GetBacklogCount())
+
+	// task 3 is read again, and task 4 has expired by then
+	m.refill(low, 3)
+	m.expire(low)
+	m.advance(low, m.GetReadLevel()+1)
+	assert.Empty(t, m.skippedKeys())
+	_, ok = m.skippedMode()
+	assert.False(t, ok)
+	assert.Equal(t, int64(2), m.GetAckLevel(), "a task read again holds back the ack level until it is acked")
+	assert.Equal(t, int64(1), m.GetBacklogCount())
+
+	assert.Equal(t, int64(5), m.AckItem(3))
+	assert.Zero(t, m.GetBacklogCount())
+}
+
+func TestBacklogAckManagerHasSkippedAbove(t *testing.T) {
+	m := newBacklogAckManager(messaging.NewAckManager(testlogger.New(t)))
+	require.NoError(t, m.ReadItem(1))
+	m.skip(backlogKey{priority: 2}, backlogMode{priority: true}, 1)
+
+	assert.False(t, m.hasSkippedAbove(taskpriority.Highest))
+	assert.False(t, m.hasSkippedAbove(2))
+	assert.True(t, m.hasSkippedAbove(3))
+
+	m = newBacklogAckManager(messaging.NewAckManager(testlogger.New(t)))
+	require.NoError(t, m.ReadItem(1))
+	m.skip(backlogKey{priority: taskpriority.Default}, backlogMode{}, 1)
+	assert.False(t, m.hasSkippedAbove(taskpriority.Lowest), "tasks skipped without priority have none")
+}
@@ -23,6 +23,8 @@ package tasklist
 import (
 	"github.com/uber/cadence/common/isolationgroup"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/taskfairness"
+	"github.com/uber/cadence/common/taskpriority"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -94,6 +96,11 @@ func newInternalTask(
 		}
 		partitionConfig[isolationgroup.GroupKey] = isolationGroup
 		partitionConfig[isolationgroup.WorkflowIDKey] = task.Event.PartitionConfig[isolationgroup.WorkflowIDKey]
+		for _, key := range []string{taskpriority.PartitionConfigKey, taskfairness.PartitionConfigKey} {
+			if value, ok := task.Event.PartitionConfig[key]; ok {
+				partitionConfig[key] = value
+			}
+		}
 		task.Event.PartitionConfig = partitionConfig
 	}
 	return task
@@ -137,6 +144,24 @@ func (task *InternalTask) IsSyncMatch() bool {
 	return task.ResponseC != nil
 }
 
+// Priority returns the priority of an activity or decision task, or the
+// default priority for any other task
+func (task *InternalTask) Priority() int {
+	if task == nil || task.Event == nil || task.Event.TaskInfo == nil {
+		return taskpriority.Default
+	}
+	return taskPriority(task.Event.TaskInfo)
+}
+
+// taskPriority returns the priority persisted with a task. Tasks written before
+// the priority was persisted fall back to the priority in their partition config.
+func taskPriority(info *persistence.TaskInfo) int {
+	if info.Priority >= taskpriority.Highest && info.Priority <= taskpriority.Lowest {
+		return info.Priority
+	}
+	return taskpriority.FromPartitionConfig(info.PartitionConfig)
+}
+
 func (task *InternalTask) Info() persistence.TaskInfo {
 	if task == nil || task.Event == nil || task.Event.TaskInfo == nil {
 		return persistence.TaskInfo{}
@@ -0,0 +1,305 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package tasklist
+
+import (
+	"context"
+	"sync"
+
+	"github.com/uber/cadence/common/persistence"
+	ctask "github.com/uber/cadence/common/task"
+	"github.com/uber/cadence/common/taskfairness"
+	"github.com/uber/cadence/common/taskpriority"
+)
+
+// taskBuffer holds backlog tasks read from the database until they are dispatched
+// to a poller. Tasks are queued per priority and handed out most urgent first. A
+// queue that has been passed over starvationThreshold times in a row is served
+// next regardless of its priority, so a steady stream of urgent tasks cannot
+// hold back the rest of the backlog forever. Within a priority, tasks are shared
+// between their fairness keys by a fairQueue.
+//
+// A buffer has a single consumer (the dispatcher of its isolation group) and a
+// single producer (the getTasks pump). The producer waits for room with put
+// when every task has the same key, and otherwise uses tryPut, which never
+// waits, so that a key that has its share of the buffer does not hold back the
+// tasks of other keys read after it.
+type taskBuffer struct {
+	sync.Mutex
+	queues  [taskpriority.NumLevels]fairQueue
+	skipped [taskpriority.NumLevels]int
+	size    int
+
+	// used holds one token per buffered task, so put blocks while the buffer is full
+	used chan struct{}
+	// readyC is signalled whenever a task is added
+	readyC chan struct{}
+
+	starvationThreshold func() int
+	fairnessWeights     func() map[string]int
+}
+
+func newTaskBuffer(capacity int, starvationThreshold func() int, fairnessWeights func() map[string]int) *taskBuffer {
+	// get waits on readyC rather than on used, so a zero capacity would make
+	// put block forever; keep at least a single slot
+	return &taskBuffer{
+		used:                make(chan struct{}, max(capacity, 1)),
+		readyC:              make(chan struct{}, 1),
+		starvationThreshold: starvationThreshold,
+		fairnessWeights:     fairnessWeights,
+	}
+}
+
+// put adds a task at the given priority and fairness key, blocking while the
+// buffer is full. It returns false if ctx is done before there is room.
+func (b *taskBuffer) put(ctx context.Context, task *persistence.TaskInfo, priority int, fairnessKey string) bool {
+	select {
+	case b.used <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+
+	level := priority - taskpriority.Highest
+	b.Lock()
+	if b.queues[level].size == 0 {
+		// waiting starts when the queue gets a task, not when it was last served
+		b.skipped[level] = 0
+	}
+	b.queues[level].push(fairnessKey, task)
+	b.size++
+	b.Unlock()
+
+	select {
+	case b.readyC <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// tryPut adds a task of key if key has less than its share of the buffer and
+// the buffer is not full. It never blocks.
+func (b *taskBuffer) tryPut(task *persistence.TaskInfo, key backlogKey, skipped map[backlogKey]int64) bool {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.size >= b.capacity() || b.countOf(key) >= b.share(key, skipped) {
+		return false
+	}
+	level := key.priority - taskpriority.Highest
+	// a get may have taken its task but not yet given back its token, so this
+	// can wait, but only briefly
+	b.used <- struct{}{}
+
+	if b.queues[level].size == 0 {
+		b.skipped[level] = 0
+	}
+	b.queues[level].push(key.fairnessKey, task)
+	b.size++
+
+	select {
+	case b.readyC <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// share returns the number of tasks key may have buffered. The buffer is shared
+// between key, the keys with buffered tasks and the keys with skipped tasks in
+// proportion to the weights of their fairness keys.
+func (b *taskBuffer) share(key backlogKey, skipped map[backlogKey]int64) int {
+	active := map[backlogKey]struct{}{key: {}}
+	for level := range b.queues {
+		for fairnessKey := range b.queues[level].tasks {
+			active[backlogKey{priority: level + taskpriority.Highest, fairnessKey: fairnessKey}] = struct{}{}
+		}
+	}
+	for k := range skipped {
+		active[k] = struct{}{}
+	}
+	weights := b.fairnessWeights()
+	total := 0
+	for k := range active {
+		total += fairnessWeight(weights, k.fairnessKey)
+	}
+	return max(b.capacity()*fairnessWeight(weights, key.fairnessKey)/total, 1)
+}
+
+// countOf returns the number of buffered tasks of key
+func (b *taskBuffer) countOf(key backlogKey) int {
+	return len(b.queues[key.priority-taskpriority.Highest].tasks[key.fairnessKey])
+}
+
+// needsRefill returns true if a key with skipped tasks is down to half its
+// share of the buffer, so its skipped tasks are worth reading again
+func (b *taskBuffer) needsRefill(skipped map[backlogKey]int64) bool {
+	b.Lock()
+	defer b.Unlock()
+	for key := range skipped {
+		if b.countOf(key) <= b.share(key, skipped)/2 {
+			return true
+		}
+	}
+	return false
+}
+
+// get removes and returns the next task to dispatch, blocking until one is
+// available. It returns false if ctx is done first.
+func (b *taskBuffer) get(ctx context.Context) (*persistence.TaskInfo, bool) {
+	for {
+		if task := b.pop(); task != nil {
+			<-b.used
+			return task, true
+		}
+		select {
+		case <-b.readyC:
+		case <-ctx.Done():
+			return nil, false
+		}
+	}
+}
+
+func (b *taskBuffer) pop() *persistence.TaskInfo {
+	b.Lock()
+	defer b.Unlock()
+
+	threshold := b.starvationThreshold()
+	next, starved := -1, -1
+	for level := range b.queues {
+		if b.queues[level].size == 0 {
+			continue
+		}
+		if next < 0 {
+			next = level
+		}
+		if threshold > 0 && b.skipped[level] >= threshold && (starved < 0 || b.skipped[level] > b.skipped[starved]) {
+			starved = level
+		}
+	}
+	if next < 0 {
+		return nil
+	}
+	if starved >= 0 {
+		next = starved
+	}
+
+	task := b.queues[next].pop(b.fairnessWeights())
+	b.size--
+	b.skipped[next] = 0
+	for level := range b.queues {
+		if level != next && b.queues[level].size > 0 {
+			b.skipped[level]++
+		}
+	}
+	return task
+}
+
+// hasTaskAbove returns true if a task more urgent than priority is buffered
+func (b *taskBuffer) hasTaskAbove(priority int) bool {
+	b.Lock()
+	defer b.Unlock()
+	for level := 0; level < priority-taskpriority.Highest; level++ {
+		if b.queues[level].size > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *taskBuffer) count() int {
+	b.Lock()
+	defer b.Unlock()
+	return b.size
+}
+
+func (b *taskBuffer) capacity() int {
+	return cap(b.used)
+}
+
+// fairQueue holds the tasks of one priority in a FIFO queue per fairness key and
+// hands them out in the interleaved weighted round robin order of common/task: a
+// key with weight w gets w turns per cycle, spread out rather than back to back.
+// A cycle is scheduled over the keys that have tasks when it starts, so a key
+// that gets its first task mid cycle waits at most one cycle for its turn.
+type fairQueue struct {
+	tasks map[string][]*persistence.TaskInfo
+	size  int
+
+	// remaining turns of the current cycle
+	cycle ctask.Iterator[string]
+}
+
+func (q *fairQueue) push(key string, task *persistence.TaskInfo) {
+	if q.tasks == nil {
+		q.tasks = make(map[string][]*persistence.TaskInfo)
+	}
+	q.tasks[key] = append(q.tasks[key], task)
+	q.size++
+}
+
+// pop removes the next task. Keys missing from weights have the default weight.
+func (q *fairQueue) pop(weights map[string]int) *persistence.TaskInfo {
+	if q.size == 0 {
+		return nil
+	}
+	for {
+		if q.cycle != nil {
+			for key, ok := q.cycle.TryNext(); ok; key, ok = q.cycle.TryNext() {
+				// a key can run out of tasks before its turns are used up
+				if len(q.tasks[key]) > 0 {
+					return q.take(key)
+				}
+			}
+		}
+		q.cycle = ctask.NewIWRRKeySchedule(q.weights(weights)).NewIterator()
+	}
+}
+
+// weights returns the weight of every key with buffered tasks
+func (q *fairQueue) weights(configured map[string]int) map[string]int {
+	weights := make(map[string]int, len(q.tasks))
+	for key := range q.tasks {
+		weights[key] = fairnessWeight(configured, key)
+	}
+	return weights
+}
+
+// fairnessWeight returns the weight of a fairness key. Keys missing from
+// configured have the default weight.
+func fairnessWeight(configured map[string]int, key string) int {
+	if w, ok := configured[key]; ok {
+		// every key must be served eventually
+		return max(w, taskfairness.DefaultWeight)
+	}
+	return taskfairness.DefaultWeight
+}
+
+// take removes the first task of key
+func (q *fairQueue) take(key string) *persistence.TaskInfo {
+	queue := q.tasks[key]
+	task := queue[0]
+	queue[0] = nil
+	if len(queue) == 1 {
+		delete(q.tasks, key)
+	} else {
+		q.tasks[key] = queue[1:]
+	}
+	q.size--
+	return task
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package tasklist
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/taskpriority"
+)
+
+func TestTaskBufferDispatchOrder(t *testing.T) {
+	cases := []struct {
+		name      string
+		threshold int
+		// priorities in the order the tasks are put
+		put []int
+		// priorities in the order the tasks are expected to be dispatched
+		expected []int
+	}{
+		{
+			name:     "most urgent first",
+			put:      []int{3, 5, 1, 3, 2},
+			expected: []int{1, 2, 3, 3, 5},
+		},
+		{
+			name:     "same priority in backlog order",
+			put:      []int{4, 4, 4},
+			expected: []int{4, 4, 4},
+		},
+		{
+			name:      "starved queue is served",
+			threshold: 2,
+			put:       []int{1, 1, 1, 1, 1, 5},
+			expected:  []int{1, 1, 5, 1, 1, 1},
+		},
+		{
+			name:      "longest starved queue first",
+			threshold: 2,
+			put:       []int{1, 1, 1, 1, 4, 5},
+			expected:  []int{1, 1, 4, 5, 1, 1},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffer := newTaskBuffer(len(tc.put), func() int { return tc.threshold }, noFairnessWeights)
+			for i, priority := range tc.put {
+				require.True(t, buffer.put(context.Background(), &persistence.TaskInfo{TaskID: int64(i), ScheduleID: int64(priority)}, priority, ""))
+			}
+			assert.Equal(t, len(tc.put), buffer.count())
+
+			var dispatched []int
+			for range tc.put {
+				task, ok := buffer.get(context.Background())
+				require.True(t, ok)
+				dispatched = append(dispatched, int(task.ScheduleID))
+			}
+			assert.Equal(t, tc.expected, dispatched)
+			assert.Zero(t, buffer.count())
+		})
+	}
+}
+
+func TestTaskBufferHasTaskAbove(t *testing.T) {
+	buffer := newTaskBuffer(2, func() int { return 0 }, noFairnessWeights)
+	assert.False(t, buffer.hasTaskAbove(taskpriority.Lowest))
+
+	require.True(t, buffer.put(context.Background(), &persistence.TaskInfo{}, 2, ""))
+	assert.False(t, buffer.hasTaskAbove(taskpriority.Highest))
+	assert.False(t, buffer.hasTaskAbove(2))
+	assert.True(t, buffer.hasTaskAbove(3))
+	assert.True(t, buffer.hasTaskAbove(taskpriority.Lowest))
+}
+
+func TestTaskBufferTryPut(t *testing.T) {
+	low := backlogKey{priority: taskpriority.Lowest}
+	high := backlogKey{priority: taskpriority.Highest}
+	buffer := newTaskBuffer(4, func() int { return 0 }, noFairnessWeights)
+
+	// a key with nothing skipped alongside it has the whole buffer
+	for id := int64(1); id <= 3; id++ {
+		require.True(t, buffer.tryPut(&persistence.TaskInfo{TaskID: id}, low, nil))
+	}
+	assert.False(t, buffer.needsRefill(map[backlogKey]int64{low: 4}))
+
+	// once another key has skipped tasks, they get half each
+	skipped := map[backlogKey]int64{low: 4, high: 5}
+	assert.False(t, buffer.tryPut(&persistence.TaskInfo{TaskID: 4}, low, skipped), "low is over its share")
+	assert.True(t, buffer.needsRefill(skipped), "high has nothing buffered")
+	require.True(t, buffer.tryPut(&persistence.TaskInfo{TaskID: 5}, high, skipped))
+	assert.False(t, buffer.tryPut(&persistence.TaskInfo{TaskID: 6}, high, skipped), "the buffer is full")
+
+	task, ok := buffer.get(context.Background())
+	require.True(t, ok)
+	assert.Equal(t, int64(5), task.TaskID)
+	require.True(t, buffer.tryPut(&persistence.TaskInfo{TaskID: 6}, high, skipped))
+	assert.False(t, buffer.tryPut(&persistence.TaskInfo{TaskID: 7}, high, skipped), "the buffer is full")
+}
+
+func TestTaskBufferTryPutWeightedShares(t *testing.T) {
+	a := backlogKey{priority: taskpriority.Default, fairnessKey: "a"}
+	b := backlogKey{priority: taskpriority.Default, fairnessKey: "b"}
+	buffer := newTaskBuffer(8, func() int { return 0 }, func() map[string]int { return map[string]int{"a": 3} })
+	skipped := map[backlogKey]int64{a: 1, b: 1}
+
+	put := func(key backlogKey) int {
+		n := 0
+		for buffer.tryPut(&persistence.TaskInfo{}, key, skipped) {
+			n++
+		}
+		return n
+	}
+	assert.Equal(t, 6, put(a), "a has three times the weight of b")
+	assert.Equal(t, 2, put(b))
+	assert.False(t, buffer.needsRefill(skipped))
+}
+
+func TestTaskBufferBlocking(t *testing.T) {
+	buffer := newTaskBuffer(1, func() int { return 0 }, noFairnessWeights)
+	assert.Equal(t, 1, buffer.capacity())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, ok := buffer.get(ctx)
+	assert.False(t, ok, "get must give up once the context is done")
+
+	require.True(t, buffer.put(context.Background(), &persistence.TaskInfo{TaskID: 1}, taskpriority.Default, ""))
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.False(t, buffer.put(ctx, &persistence.TaskInfo{TaskID: 2}, taskpriority.Default, ""), "put must wait while the buffer is full")
+
+	task, ok := buffer.get(context.Background())
+	require.True(t, ok)
+	assert.Equal(t, int64(1), task.TaskID)
+	assert.True(t, buffer.put(context.Background(), &persistence.TaskInfo{TaskID: 3}, taskpriority.Default, ""))
+}
+
+func TestTaskBufferZeroCapacity(t *testing.T) {
+	buffer := newTaskBuffer(0, func() int { return 0 }, noFairnessWeights)
+	assert.Equal(t, 1, buffer.capacity())
+	require.True(t, buffer.put(context.Background(), &persistence.TaskInfo{}, taskpriority.Default, ""))
+}
+
+func TestTaskBufferFairness(t *testing.T) {
+	cases := []struct {
+		name    string
+		weights map[string]int
+		// fairness keys in the order the tasks are put
+		put []string
+		// fairness keys expected in each round robin cycle; the order of keys of
+		// equal weight within a cycle is not defined
+		cycles [][]string
+	}{
+		{
+			name:   "single key",
+			put:    []string{"a", "a", "a"},
+			cycles: [][]string{{"a"}, {"a"}, {"a"}},
+		},
+		{
+			name:   "equal weights take turns",
+			put:    []string{"a", "a", "a", "a", "b", "b", "c"},
+			cycles: [][]string{{"a", "b", "c"}, {"a", "b"}, {"a"}, {"a"}},
+		},
+		{
+			name:    "weighted keys",
+			weights: map[string]int{"a": 3},
+			put:     []string{"a", "a", "a", "a", "a", "a", "b", "b", "b"},
+			cycles:  [][]string{{"a", "a", "a", "b"}, {"a", "a", "a", "b"}, {"b"}},
+		},
+		{
+			name:    "weights below one count as one",
+			weights: map[string]int{"a": 0, "b": -1},
+			put:     []string{"a", "a", "b", "b"},
+			cycles:  [][]string{{"a", "b"}, {"a", "b"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buffer := newTaskBuffer(len(tc.put), func() int { return 0 }, func() map[string]int { return tc.weights })
+			for i, key := range tc.put {
+				require.True(t, buffer.put(context.Background(), &persistence.TaskInfo{TaskID: int64(i), WorkflowID: key}, taskpriority.Default, key))
+			}
+
+			lastTaskID := make(map[string]int64)
+			for _, cycle := range tc.cycles {
+				var dispatched []string
+				for range cycle {
+					task, ok := buffer.get(context.Background())
+					require.True(t, ok)
+					dispatched = append(dispatched, task.WorkflowID)
+
+					// tasks of a key keep their backlog order
+					if last, ok := lastTaskID[task.WorkflowID]; ok {
+						assert.Greater(t, task.TaskID, last)
+					}
+					lastTaskID[task.WorkflowID] = task.TaskID
+				}
+				assert.ElementsMatch(t, cycle, dispatched)
+			}
+			assert.Zero(t, buffer.count())
+		})
+	}
+}
+
+func noFairnessWeights() map[string]int {
+	return nil
+}
@@ -123,8 +123,8 @@ type (
 		taskReader      *taskReader // reads tasks from db and async matches it with poller
 		liveness        *liveness.Liveness
 		taskGC          *taskGC
-		taskAckManager  messaging.AckManager // tracks ackLevel for delivered messages
-		matcher         TaskMatcher          // for matching a task producer with a poller
+		taskAckManager  *backlogAckManager // tracks ackLevel for delivered messages
+		matcher         TaskMatcher        // for matching a task producer with a poller
 		limiter         *taskListLimiter
 		clusterMetadata cluster.Metadata
 		domainCache     cache.DomainCache
@@ -193,7 +193,7 @@ func NewManager(p ManagerParams) (Manager, error) {
 		taskListKind:    p.TaskListKind,
 		logger:          p.Logger.WithTags(tag.WorkflowDomainName(domainName), tag.WorkflowTaskListName(p.TaskList.GetName()), tag.WorkflowTaskListType(p.TaskList.GetType())),
 		db:              db,
-		taskAckManager:  messaging.NewAckManager(p.Logger),
+		taskAckManager:  newBacklogAckManager(messaging.NewAckManager(p.Logger)),
 		taskGC:          newTaskGC(db, taskListConfig),
 		config:          taskListConfig,
 		matchingClient:  p.MatchingClient,
@@ -835,6 +835,12 @@ func (c *taskListManagerImpl) TaskListID() *Identifier {
 // trySyncMatch performs to match the domain synchronously.
 func (c *taskListManagerImpl) trySyncMatch(ctx context.Context, params AddTaskParams, isolationGroup string) (bool, error) {
 	task := newInternalTask(params.TaskInfo, nil, params.Source, params.ForwardedFrom, true, isolationGroup)
+	if c.config.EnableTaskPriority() && c.taskReader.hasMoreUrgentBacklog(isolationGroup, task.Priority()) {
+		// more urgent backlog tasks are waiting for the same pollers; write this
+		// task to the backlog instead of letting it jump ahead of them
+		c.scope.IncCounter(metrics.SyncMatchSkippedForPriorityPerTaskListCounter)
+		return false, nil
+	}
 	childCtx := ctx
 	cancel := func() {}
 
@@ -1113,6 +1119,18 @@ func newTaskListConfig(id *Identifier, cfg *config.Config, domainName string) *c
 		IsolationGroupsPerPartition: func() int {
 			return cfg.IsolationGroupsPerPartition(domainName, taskListName, taskType)
 		},
+		EnableTaskPriority: func() bool {
+			return cfg.EnableTaskPriority(domainName, taskListName, taskType)
+		},
+		TaskPriorityStarvationThreshold: func() int {
+			return cfg.TaskPriorityStarvationThreshold(domainName, taskListName, taskType)
+		},
+		EnableTaskFairness: func() bool {
+			return cfg.EnableTaskFairness(domainName, taskListName, taskType)
+		},
+		TaskFairnessKeyWeights: func() map[string]interface{} {
+			return cfg.TaskFairnessKeyWeights(domainName)
+		},
 		QPSTrackerInterval: func() time.Duration {
 			return cfg.QPSTrackerInterval(domainName, taskListName, taskType)
 		},
@@ -54,6 +54,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/stats"
+	"github.com/uber/cadence/common/taskpriority"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/history/constants"
 	"github.com/uber/cadence/service/matching/config"
@@ -132,7 +133,7 @@ func TestDeliverBufferTasks(t *testing.T) {
 		func(tlm *taskListManagerImpl) { tlm.taskReader.cancelFunc() },
 		func(tlm *taskListManagerImpl) {
 			tlm.limiter.ReportLimit(0.1)
-			tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup] <- &persistence.TaskInfo{}
+			tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup].put(context.Background(), &persistence.TaskInfo{}, taskpriority.Default, "")
 			err := tlm.matcher.(*taskMatcherImpl).ratelimit(context.Background()) // consume the token
 			assert.NoError(t, err)
 			tlm.taskReader.cancelFunc()
@@ -166,7 +167,7 @@ func TestDeliverBufferTasks_NoPollers(t *testing.T) {
 	logger := testlogger.New(t)
 
 	tlm := createTestTaskListManager(t, logger, controller)
-	tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup] <- &persistence.TaskInfo{}
+	tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup].put(context.Background(), &persistence.TaskInfo{}, taskpriority.Default, "")
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -641,6 +642,100 @@ func TestAddTaskStandby(t *testing.T) {
 	require.False(t, syncMatch)
 }
 
+func TestTrySyncMatchDefersToMoreUrgentBacklog(t *testing.T) {
+	cases := []struct {
+		name           string
+		enablePriority bool
+		// buffered and skipped are the priorities of backlog tasks in the task
+		// buffer and skipped in the database
+		buffered, skipped []int
+		priority          int
+		syncMatch         bool
+	}{
+		{
+			name:           "no backlog",
+			enablePriority: true,
+			priority:       taskpriority.Lowest,
+			syncMatch:      true,
+		},
+		{
+			name:           "more urgent task buffered",
+			enablePriority: true,
+			buffered:       []int{taskpriority.Highest},
+			priority:       taskpriority.Default,
+			syncMatch:      false,
+		},
+		{
+			name:           "more urgent task skipped",
+			enablePriority: true,
+			skipped:        []int{taskpriority.Highest},
+			priority:       taskpriority.Default,
+			syncMatch:      false,
+		},
+		{
+			name:           "backlog of the same or lower priority",
+			enablePriority: true,
+			buffered:       []int{taskpriority.Default},
+			skipped:        []int{taskpriority.Lowest},
+			priority:       taskpriority.Default,
+			syncMatch:      true,
+		},
+		{
+			name:      "priority disabled",
+			buffered:  []int{taskpriority.Highest},
+			priority:  taskpriority.Lowest,
+			syncMatch: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			cfg := defaultTestConfig()
+			cfg.EnableTaskPriority = dynamicproperties.GetBoolPropertyFnFilteredByTaskListInfo(tc.enablePriority)
+			tlm := createTestTaskListManagerWithConfig(t, testlogger.New(t), controller, cfg, clock.NewMockedTimeSource())
+
+			taskID := int64(0)
+			for _, priority := range tc.buffered {
+				taskID++
+				require.True(t, tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup].put(context.Background(), &persistence.TaskInfo{TaskID: taskID}, priority, ""))
+			}
+			for _, priority := range tc.skipped {
+				taskID++
+				require.NoError(t, tlm.taskAckManager.ReadItem(taskID))
+				tlm.taskAckManager.skip(backlogKey{priority: priority}, backlogMode{priority: true}, taskID)
+			}
+
+			var polled atomic.Bool
+			wait := ensureAsyncReady(time.Second, func(ctx context.Context) {
+				task, err := tlm.matcher.Poll(ctx, "")
+				if err == nil {
+					polled.Store(true)
+					task.Finish(nil)
+				}
+			})
+			params := AddTaskParams{
+				TaskInfo: &persistence.TaskInfo{
+					DomainID:   "domainId",
+					RunID:      "run1",
+					WorkflowID: "workflow1",
+					ScheduleID: 5,
+					Priority:   tc.priority,
+				},
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			syncMatch, err := tlm.trySyncMatch(ctx, params, defaultTaskBufferIsolationGroup)
+			cancel()
+			require.NoError(t, err)
+			assert.Equal(t, tc.syncMatch, syncMatch)
+			if !syncMatch {
+				tlm.matcher.DisconnectBlockedPollers()
+			}
+			wait()
+			assert.Equal(t, tc.syncMatch, polled.Load())
+		})
+	}
+}
+
 // return a client side tasklist throttle error from the rate limiter.
 // The expected behaviour is to retry
 func TestRateLimitErrorsFromTasklistDispatch(t *testing.T) {
@@ -1031,9 +1126,9 @@ func TestTaskListManagerGetTaskBatch(t *testing.T) {
 
 	// wait until all tasks are read by the task pump and enqeued into the in-memory buffer
 	// at the end of this step, ackManager readLevel will also be equal to the buffer size
-	expectedBufSize := min(cap(tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup]), taskCount)
+	expectedBufSize := min(tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup].capacity(), taskCount)
 	assert.True(t, awaitCondition(func() bool {
-		return len(tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup]) == expectedBufSize
+		return tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup].count() == expectedBufSize
 	}, 10*time.Second))
 
 	// stop all goroutines that read / write tasks in the background
@@ -1318,7 +1413,7 @@ func TestTaskExpiryAndCompletion(t *testing.T) {
 			// wait until all tasks are loaded by into in-memory buffers by task list manager
 			// the buffer size should be one less than expected because dispatcher will dequeue the head
 			assert.True(t, awaitCondition(func() bool {
-				return len(tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup]) >= (taskCount/2 - 1)
+				return tlm.taskReader.taskBuffers[defaultTaskBufferIsolationGroup].count() >= (taskCount/2 - 1)
 			}, time.Second))
 
 			remaining := taskCount
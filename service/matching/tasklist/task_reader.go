@@ -25,6 +25,7 @@ package tasklist
 import (
 	"context"
 	"errors"
+	"math"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -39,9 +40,10 @@ import (
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log"
 	"github.com/uber/cadence/common/log/tag"
-	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/taskfairness"
+	"github.com/uber/cadence/common/taskpriority"
 	"github.com/uber/cadence/common/types"
 	"github.com/uber/cadence/service/matching/config"
 	"github.com/uber/cadence/service/matching/event"
@@ -64,7 +66,7 @@ type (
 		// that are enqueued for pollers to pickup. It's written to by
 		// - getTasksPump - the primary means of loading async matching tasks
 		// - task dispatch redirection - when a task is redirected from another isolation group
-		taskBuffers     map[string]chan *persistence.TaskInfo
+		taskBuffers     map[string]*taskBuffer
 		notifyC         chan struct{} // Used as signal to notify pump of new tasks
 		tlMgr           *taskListManagerImpl
 		taskListID      *Identifier
@@ -72,7 +74,7 @@ type (
 		db              *taskListDB
 		taskWriter      *taskWriter
 		taskGC          *taskGC
-		taskAckManager  messaging.AckManager
+		taskAckManager  *backlogAckManager
 		domainCache     cache.DomainCache
 		clusterMetadata cluster.Metadata
 		timeSource      clock.TimeSource
@@ -84,6 +86,7 @@ type (
 		cancelCtx                context.Context
 		cancelFunc               context.CancelFunc
 		stopped                  int64 // set to 1 if the reader is stopped or is shutting down
+		refillRequested          int32 // set to 1 when skipped tasks should be read again
 		logger                   log.Logger
 		scope                    metrics.Scope
 		throttleRetry            *backoff.ThrottleRetry
@@ -100,7 +103,7 @@ type (
 
 func newTaskReader(tlMgr *taskListManagerImpl, isolationGroups []string) *taskReader {
 	ctx, cancel := context.WithCancel(context.Background())
-	taskBuffers := make(map[string]chan *persistence.TaskInfo)
+	taskBuffers := make(map[string]*taskBuffer)
 
 	// Validate batch size to prevent system failures
 	batchSize := tlMgr.config.GetTasksBatchSize()
@@ -112,11 +115,7 @@ func newTaskReader(tlMgr *taskListManagerImpl, isolationGroups []string) *taskRe
 		batchSize = fallback
 	}
 
-	taskBuffers[defaultTaskBufferIsolationGroup] = make(chan *persistence.TaskInfo, batchSize-1)
-	for _, g := range isolationGroups {
-		taskBuffers[g] = make(chan *persistence.TaskInfo, batchSize-1)
-	}
-	return &taskReader{
+	tr := &taskReader{
 		tlMgr:          tlMgr,
 		taskListID:     tlMgr.taskListID,
 		config:         tlMgr.config,
@@ -145,6 +144,11 @@ func newTaskReader(tlMgr *taskListManagerImpl, isolationGroups []string) *taskRe
 			backoff.WithRetryableError(persistence.IsTransientError),
 		),
 	}
+	taskBuffers[defaultTaskBufferIsolationGroup] = newTaskBuffer(batchSize-1, tlMgr.config.TaskPriorityStarvationThreshold, tr.fairnessWeights)
+	for _, g := range isolationGroups {
+		taskBuffers[g] = newTaskBuffer(batchSize-1, tlMgr.config.TaskPriorityStarvationThreshold, tr.fairnessWeights)
+	}
+	return tr
 }
 
 func (tr *taskReader) Start() {
@@ -186,27 +190,26 @@ func (tr *taskReader) Signal() {
 }
 
 func (tr *taskReader) dispatchBufferedTasks(isolationGroup string) {
-dispatchLoop:
+	buffer := tr.taskBuffers[isolationGroup]
 	for {
-		select {
-		case taskInfo, ok := <-tr.taskBuffers[isolationGroup]:
-			if !ok { // Task list getTasks pump is shutdown
-				break dispatchLoop
-			}
-			event.Log(event.E{
-				TaskListName: tr.taskListID.GetName(),
-				TaskListType: tr.taskListID.GetType(),
-				TaskListKind: &tr.tlMgr.taskListKind,
-				TaskInfo:     *taskInfo,
-				EventName:    "Attempting to Dispatch Buffered Task",
-			})
-			breakDispatchLoop := tr.dispatchSingleTaskFromBufferWithRetries(taskInfo)
-			if breakDispatchLoop {
-				// shutting down
-				break dispatchLoop
-			}
-		case <-tr.cancelCtx.Done():
-			break dispatchLoop
+		taskInfo, ok := buffer.get(tr.cancelCtx)
+		if !ok { // shutting down
+			return
+		}
+		event.Log(event.E{
+			TaskListName: tr.taskListID.GetName(),
+			TaskListType: tr.taskListID.GetType(),
+			TaskListKind: &tr.tlMgr.taskListKind,
+			TaskInfo:     *taskInfo,
+			EventName:    "Attempting to Dispatch Buffered Task",
+		})
+		if skipped := tr.taskAckManager.skippedKeys(); len(skipped) > 0 && buffer.needsRefill(skipped) {
+			tr.requestRefill()
+		}
+		breakDispatchLoop := tr.dispatchSingleTaskFromBufferWithRetries(taskInfo)
+		if breakDispatchLoop {
+			// shutting down
+			return
 		}
 	}
 }
@@ -222,6 +225,11 @@ getTasksPumpLoop:
 			break getTasksPumpLoop
 		case <-tr.notifyC:
 			{
+				if atomic.CompareAndSwapInt32(&tr.refillRequested, 1, 0) && !tr.readSkippedTasks() {
+					tr.requestRefill() // retry the read
+					continue getTasksPumpLoop
+				}
+
 				initialReadLevel := tr.taskAckManager.GetReadLevel()
 				maxReadLevel := tr.taskWriter.GetMaxReadLevel()
 
@@ -266,7 +274,7 @@ getTasksPumpLoop:
 						tag.Error(err))
 					// keep going as saving ack is not critical
 				}
-				tr.Signal() // periodically signal pump to check persistence for tasks
+				tr.requestRefill() // periodically signal pump to check persistence for new and skipped tasks
 				updateAckTimer.Reset(tr.config.UpdateAckInterval())
 			}
 		}
@@ -332,15 +340,16 @@ func (tr *taskReader) isTaskExpired(t *persistence.TaskInfo) bool {
 }
 
 func (tr *taskReader) addTasksToBuffer(tasks []*persistence.TaskInfo) bool {
+	mode := tr.backlogMode()
 	for _, t := range tasks {
-		if !tr.addSingleTaskToBuffer(t) {
+		if !tr.addSingleTaskToBuffer(t, mode) {
 			return false // we are shutting down the task list
 		}
 	}
 	return true
 }
 
-func (tr *taskReader) addSingleTaskToBuffer(task *persistence.TaskInfo) bool {
+func (tr *taskReader) addSingleTaskToBuffer(task *persistence.TaskInfo, mode backlogMode) bool {
 	if tr.isTaskExpired(task) {
 		tr.scope.IncCounter(metrics.ExpiredTasksPerTaskListCounter)
 		// Also increment readLevel for expired tasks otherwise it could result in
@@ -352,18 +361,145 @@ func (tr *taskReader) addSingleTaskToBuffer(task *persistence.TaskInfo) bool {
 	if err != nil {
 		tr.logger.Fatal("critical bug when adding item to ackManager", tag.Error(err))
 	}
+	key := tr.backlogKey(task, mode)
+	if mode == (backlogMode{}) {
+		// every task has the same key, so there is nothing to read ahead for;
+		// wait for room instead
+		// Ignore the isolation duration as we're just putting it into a buffer to be dispatched later.
+		isolationGroup, _ := tr.getIsolationGroupForTask(tr.cancelCtx, task)
+		return tr.bufferFor(isolationGroup).put(tr.cancelCtx, task, key.priority, key.fairnessKey)
+	}
+	// tasks of a key with skipped tasks are skipped too, so that each key is
+	// buffered in backlog order
+	if tr.taskAckManager.isSkipped(key) {
+		tr.taskAckManager.skip(key, mode, task.TaskID)
+		return true
+	}
+	if !tr.tryBuffer(task, key) {
+		tr.taskAckManager.skip(key, mode, task.TaskID)
+	}
+	return true
+}
+
+// tryBuffer adds a task to its buffer if its key has room, without waiting for
+// room
+func (tr *taskReader) tryBuffer(task *persistence.TaskInfo, key backlogKey) bool {
 	// Ignore the isolation duration as we're just putting it into a buffer to be dispatched later.
 	isolationGroup, _ := tr.getIsolationGroupForTask(tr.cancelCtx, task)
-	buffer, ok := tr.taskBuffers[isolationGroup]
-	if !ok {
-		buffer = tr.taskBuffers[defaultTaskBufferIsolationGroup]
-	}
-	select {
-	case buffer <- task:
+	return tr.bufferFor(isolationGroup).tryPut(task, key, tr.taskAckManager.skippedKeys())
+}
+
+// readSkippedTasks reads the skipped tasks again, from the lowest skipped task
+// ID up to the read level, and buffers them while their keys have room. It
+// returns false if the tasks could not be read.
+func (tr *taskReader) readSkippedTasks() bool {
+	skipped := tr.taskAckManager.skippedKeys()
+	if len(skipped) == 0 {
 		return true
-	case <-tr.cancelCtx.Done():
-		return false
 	}
+	mode, _ := tr.taskAckManager.skippedMode()
+	readLevel := int64(math.MaxInt64)
+	for _, from := range skipped {
+		readLevel = min(readLevel, from-1)
+	}
+	maxReadLevel := tr.taskAckManager.GetReadLevel()
+
+	for len(skipped) > 0 && readLevel < maxReadLevel {
+		if tr.cancelCtx.Err() != nil {
+			return true
+		}
+		tasks, err := tr.getTaskBatchWithRange(readLevel, maxReadLevel)
+		if err != nil {
+			return false
+		}
+		for _, task := range tasks {
+			key := tr.backlogKey(task, mode)
+			if from, ok := skipped[key]; !ok || task.TaskID < from {
+				// buffered already, or its key is out of room
+				continue
+			}
+			if tr.isTaskExpired(task) {
+				tr.scope.IncCounter(metrics.ExpiredTasksPerTaskListCounter)
+				tr.taskAckManager.expire(key)
+				continue
+			}
+			// recorded before it is buffered, as it can be acked as soon as it is
+			tr.taskAckManager.refill(key, task.TaskID)
+			if !tr.tryBuffer(task, key) {
+				// the key is out of room again, and its skipped tasks start here
+				tr.taskAckManager.skip(key, mode, task.TaskID)
+				tr.taskAckManager.advance(key, task.TaskID)
+				delete(skipped, key)
+			}
+		}
+		readLevel = maxReadLevel
+		if len(tasks) > 0 {
+			readLevel = tasks[len(tasks)-1].TaskID
+		}
+		// the keys still being read have no skipped task up to the read level
+		for key := range skipped {
+			tr.taskAckManager.advance(key, readLevel+1)
+		}
+	}
+	return true
+}
+
+// requestRefill signals the pump to read the skipped tasks again
+func (tr *taskReader) requestRefill() {
+	atomic.StoreInt32(&tr.refillRequested, 1)
+	tr.Signal()
+}
+
+func (tr *taskReader) bufferFor(isolationGroup string) *taskBuffer {
+	if buffer, ok := tr.taskBuffers[isolationGroup]; ok {
+		return buffer
+	}
+	return tr.taskBuffers[defaultTaskBufferIsolationGroup]
+}
+
+// backlogMode returns how backlog tasks are keyed: as the skipped tasks were
+// keyed while any task is skipped, and as configured otherwise
+func (tr *taskReader) backlogMode() backlogMode {
+	if mode, ok := tr.taskAckManager.skippedMode(); ok {
+		return mode
+	}
+	return backlogMode{priority: tr.config.EnableTaskPriority(), fairness: tr.config.EnableTaskFairness()}
+}
+
+// backlogKey returns the key a task is read and buffered under. Its priority is
+// the priority the task is dispatched at: every task is dispatched at the
+// default priority, in backlog order, unless priority dispatch is enabled for
+// the task list. Its fairness key is the key it shares the backlog under:
+// every task shares a single key unless fairness is enabled for the task list.
+func (tr *taskReader) backlogKey(task *persistence.TaskInfo, mode backlogMode) backlogKey {
+	key := backlogKey{priority: taskpriority.Default}
+	if mode.priority {
+		key.priority = taskPriority(task)
+	}
+	if mode.fairness {
+		key.fairnessKey = taskfairness.FromPartitionConfig(task.PartitionConfig)
+	}
+	return key
+}
+
+// hasMoreUrgentBacklog returns true if a backlog task more urgent than the given
+// priority is waiting to be dispatched to the isolation group's pollers, either
+// in the task buffer or skipped in the database
+func (tr *taskReader) hasMoreUrgentBacklog(isolationGroup string, priority int) bool {
+	return tr.bufferFor(isolationGroup).hasTaskAbove(priority) || tr.taskAckManager.hasSkippedAbove(priority)
+}
+
+// fairnessWeights returns the configured weight of each fairness key
+func (tr *taskReader) fairnessWeights() map[string]int {
+	if !tr.config.EnableTaskFairness() {
+		return nil
+	}
+	weights, err := dynamicproperties.ConvertDynamicConfigMapPropertyToStringIntMap(tr.config.TaskFairnessKeyWeights())
+	if err != nil {
+		tr.logger.Warn("invalid task fairness key weights, using the default weight for every key", tag.Error(err))
+		return nil
+	}
+	return weights
 }
 
 func (tr *taskReader) persistAckLevel() error {
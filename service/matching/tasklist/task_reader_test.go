@@ -40,6 +40,8 @@ import (
 	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
 	"github.com/uber/cadence/common/log/testlogger"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/taskfairness"
+	"github.com/uber/cadence/common/taskpriority"
 	"github.com/uber/cadence/service/matching/config"
 )
 
@@ -383,6 +385,117 @@ func TestTaskPump(t *testing.T) {
 	}
 }
 
+func TestTaskReaderReadsPastLessUrgentBacklog(t *testing.T) {
+	controller := gomock.NewController(t)
+	timeSource := clock.NewMockedTimeSource()
+	c := defaultConfig()
+	c.EnableTaskPriority = dynamicproperties.GetBoolPropertyFnFilteredByTaskListInfo(true)
+	c.TaskPriorityStarvationThreshold = dynamicproperties.GetIntPropertyFilteredByTaskListInfo(0)
+	tlm := createTestTaskListManagerWithConfig(t, testlogger.New(t), controller, c, timeSource)
+	reader := tlm.taskReader
+	reader.getIsolationGroupForTask = func(ctx context.Context, info *persistence.TaskInfo) (string, time.Duration) {
+		return defaultTaskBufferIsolationGroup, noIsolationTimeout
+	}
+	buffer := reader.taskBuffers[defaultTaskBufferIsolationGroup]
+
+	// a backlog several times the size of the buffer, with the most urgent
+	// tasks written last
+	const lowTasks, highTasks = 50, 3
+	const total = lowTasks + highTasks
+	for i := int64(1); i <= total; i++ {
+		task := newTask(timeSource)
+		task.Priority = taskpriority.Lowest
+		if i > lowTasks {
+			task.Priority = taskpriority.Highest
+		}
+		_, err := tlm.db.CreateTasks([]*persistence.CreateTaskInfo{{Data: task, TaskID: i}})
+		require.NoError(t, err)
+	}
+	tlm.taskWriter.maxReadLevel = total
+
+	tasks, _, _, err := reader.getTaskBatch(tlm.taskAckManager.GetReadLevel(), total)
+	require.NoError(t, err)
+	require.Len(t, tasks, total)
+	require.True(t, reader.addTasksToBuffer(tasks))
+	assert.Equal(t, buffer.capacity(), buffer.count())
+
+	var dispatched []int64
+	for len(dispatched) < total {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		task, ok := buffer.get(ctx)
+		cancel()
+		require.True(t, ok, "every task must be buffered eventually")
+		dispatched = append(dispatched, task.TaskID)
+		reader.completeTask(task, nil)
+		require.True(t, reader.readSkippedTasks())
+	}
+
+	// the urgent tasks are read as soon as a less urgent task makes room
+	assert.Equal(t, []int64{1, 51, 52, 53}, dispatched[:4])
+	assert.Len(t, dedupTaskIDs(dispatched), total, "every task must be dispatched exactly once")
+	assert.Equal(t, int64(total), tlm.taskAckManager.GetAckLevel())
+	assert.Zero(t, tlm.taskAckManager.GetBacklogCount())
+}
+
+func TestTaskReaderReadsPastLargeBacklogOfOneFairnessKey(t *testing.T) {
+	controller := gomock.NewController(t)
+	timeSource := clock.NewMockedTimeSource()
+	c := defaultConfig()
+	c.EnableTaskFairness = dynamicproperties.GetBoolPropertyFnFilteredByTaskListInfo(true)
+	tlm := createTestTaskListManagerWithConfig(t, testlogger.New(t), controller, c, timeSource)
+	reader := tlm.taskReader
+	reader.getIsolationGroupForTask = func(ctx context.Context, info *persistence.TaskInfo) (string, time.Duration) {
+		return defaultTaskBufferIsolationGroup, noIsolationTimeout
+	}
+	buffer := reader.taskBuffers[defaultTaskBufferIsolationGroup]
+
+	// a backlog of one key many times the size of the buffer, followed by a
+	// few tasks of another key
+	const busyTasks, otherTasks = 100, 3
+	const total = busyTasks + otherTasks
+	for i := int64(1); i <= total; i++ {
+		task := newTask(timeSource)
+		task.PartitionConfig[taskfairness.PartitionConfigKey] = "busy"
+		if i > busyTasks {
+			task.PartitionConfig[taskfairness.PartitionConfigKey] = "other"
+		}
+		_, err := tlm.db.CreateTasks([]*persistence.CreateTaskInfo{{Data: task, TaskID: i}})
+		require.NoError(t, err)
+	}
+	tlm.taskWriter.maxReadLevel = total
+
+	tasks, _, _, err := reader.getTaskBatch(tlm.taskAckManager.GetReadLevel(), total)
+	require.NoError(t, err)
+	require.Len(t, tasks, total)
+	require.True(t, reader.addTasksToBuffer(tasks))
+
+	var dispatched []int64
+	for len(dispatched) < total {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		task, ok := buffer.get(ctx)
+		cancel()
+		require.True(t, ok, "every task must be buffered eventually")
+		dispatched = append(dispatched, task.TaskID)
+		reader.completeTask(task, nil)
+		require.True(t, reader.readSkippedTasks())
+	}
+
+	// the other key takes turns with the busy key as soon as the busy key
+	// makes room, instead of waiting for the busy backlog to be dispatched
+	assert.Subset(t, dispatched[:2*otherTasks+2], []int64{101, 102, 103})
+	assert.Len(t, dedupTaskIDs(dispatched), total, "every task must be dispatched exactly once")
+	assert.Equal(t, int64(total), tlm.taskAckManager.GetAckLevel())
+	assert.Zero(t, tlm.taskAckManager.GetBacklogCount())
+}
+
+func dedupTaskIDs(ids []int64) map[int64]struct{} {
+	set := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		set[id] = struct{}{}
+	}
+	return set
+}
+
 func defaultConfig() *config.Config {
 	config := config.NewConfig(dynamicconfig.NewNopCollection(), dynamicconfig.NewNopCollection(), "some random hostname", commonConfig.RPC{}, func() []string {
 		return defaultIsolationGroups
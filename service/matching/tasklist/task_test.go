@@ -30,6 +30,8 @@ import (
 
 	"github.com/uber/cadence/common/isolationgroup"
 	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/taskfairness"
+	"github.com/uber/cadence/common/taskpriority"
 	"github.com/uber/cadence/common/types"
 )
 
@@ -128,6 +130,24 @@ func TestNewInternalTask(t *testing.T) {
 				isolationgroup.WorkflowIDKey:    "workflowID",
 			},
 		},
+		{
+			name:           "tasklist isolation - priority and fairness key preserved",
+			source:         types.TaskSourceDbBacklog,
+			isolationGroup: "a",
+			partitionConfig: map[string]string{
+				isolationgroup.GroupKey:         "a",
+				isolationgroup.WorkflowIDKey:    "workflowID",
+				taskpriority.PartitionConfigKey: "1",
+				taskfairness.PartitionConfigKey: "customer-1",
+			},
+			expectedPartitionConfig: map[string]string{
+				isolationgroup.OriginalGroupKey: "a",
+				isolationgroup.GroupKey:         "a",
+				isolationgroup.WorkflowIDKey:    "workflowID",
+				taskpriority.PartitionConfigKey: "1",
+				taskfairness.PartitionConfigKey: "customer-1",
+			},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -146,6 +166,46 @@ func TestNewInternalTask(t *testing.T) {
 	}
 }
 
+func TestInternalTaskPriority(t *testing.T) {
+	cases := []struct {
+		name     string
+		task     *InternalTask
+		expected int
+	}{
+		{
+			name:     "query task",
+			task:     &InternalTask{Query: &queryTaskInfo{TaskID: "query"}},
+			expected: taskpriority.Default,
+		},
+		{
+			name:     "no priority",
+			task:     &InternalTask{Event: &genericTaskInfo{TaskInfo: defaultTaskInfo(nil)}},
+			expected: taskpriority.Default,
+		},
+		{
+			name: "with priority",
+			task: &InternalTask{Event: &genericTaskInfo{TaskInfo: defaultTaskInfo(map[string]string{
+				taskpriority.PartitionConfigKey: "2",
+			})}},
+			expected: 2,
+		},
+		{
+			name: "persisted priority",
+			task: &InternalTask{Event: &genericTaskInfo{TaskInfo: func() *persistence.TaskInfo {
+				info := defaultTaskInfo(map[string]string{taskpriority.PartitionConfigKey: "4"})
+				info.Priority = 1
+				return info
+			}()}},
+			expected: 1,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.task.Priority())
+		})
+	}
+}
+
 func defaultTaskInfo(partitionConfig map[string]string) *persistence.TaskInfo {
 	return &persistence.TaskInfo{
 		DomainID:                      "DomainID",
@@ -258,6 +258,7 @@ func (m *TestTaskManager) CreateTasks(
 			ScheduleID:      scheduleID,
 			TaskID:          task.TaskID,
 			PartitionConfig: task.Data.PartitionConfig,
+			Priority:        task.Data.Priority,
 		}
 		if task.Data.ScheduleToStartTimeoutSeconds != 0 {
 			info.Expiry = m.timeSource.Now().Add(time.Duration(task.Data.ScheduleToStartTimeoutSeconds) * time.Second)
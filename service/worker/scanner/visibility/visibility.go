@@ -0,0 +1,224 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/dynamicconfig/dynamicproperties"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/pagination"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/reconciliation/entity"
+	"github.com/uber/cadence/common/reconciliation/fetcher"
+	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/common/reconciliation/store"
+	"github.com/uber/cadence/common/resource"
+	"github.com/uber/cadence/service/worker/scanner/shardscanner"
+)
+
+const (
+	// ScannerWFTypeName defines workflow type name for visibility scanner
+	ScannerWFTypeName   = "cadence-sys-visibility-scanner-workflow"
+	wfid                = "cadence-sys-visibility-scanner"
+	scannerTaskListName = "cadence-sys-visibility-scanner-tasklist-0"
+
+	// FixerWFTypeName defines workflow type name for visibility fixer
+	FixerWFTypeName   = "cadence-sys-visibility-fixer-workflow"
+	fixerTaskListName = "cadence-sys-visibility-fixer-tasklist-0"
+	fixerwfid         = "cadence-sys-visibility-fixer"
+	sampleRateKey     = "sample_rate"
+)
+
+// ScannerWorkflow starts visibility scanner.
+func ScannerWorkflow(
+	ctx workflow.Context,
+	params shardscanner.ScannerWorkflowParams,
+) error {
+	wf, err := shardscanner.NewScannerWorkflow(ctx, ScannerWFTypeName, params)
+	if err != nil {
+		return err
+	}
+
+	return wf.Start(ctx)
+}
+
+// FixerWorkflow starts visibility fixer.
+func FixerWorkflow(
+	ctx workflow.Context,
+	params shardscanner.FixerWorkflowParams,
+) error {
+	wf, err := shardscanner.NewFixerWorkflow(ctx, FixerWFTypeName, params)
+	if err != nil {
+		return err
+	}
+
+	return wf.Start(ctx)
+}
+
+// ScannerHooks provides hooks for visibility scanner.
+func ScannerHooks() *shardscanner.ScannerHooks {
+	h, err := shardscanner.NewScannerHooks(Manager, Iterator, Config)
+	if err != nil {
+		return nil
+	}
+
+	return h
+}
+
+// FixerHooks provides hooks needed for visibility fixer.
+func FixerHooks() *shardscanner.FixerHooks {
+	h, err := shardscanner.NewFixerHooks(FixerManager, FixerIterator, visibilityCustomConfig)
+	if err != nil {
+		return nil
+	}
+	return h
+}
+
+func visibilityCustomConfig(_ shardscanner.FixerContext) shardscanner.CustomScannerConfig {
+	// must be non-empty to pass backwards-compat check,
+	// currently this is not read anywhere because "fixer enabled"
+	// means "run this one invariant's fixes".
+	return map[string]string{
+		string(invariant.VisibilityConsistent): "true",
+	}
+}
+
+// Manager provides invariant manager for visibility scanner.
+func Manager(
+	ctx context.Context,
+	pr persistence.Retryer,
+	params shardscanner.ScanShardActivityParams,
+	cache cache.DomainCache,
+) invariant.Manager {
+	sampleRate, err := strconv.ParseFloat(params.ScannerConfig[sampleRateKey], 64)
+	if err != nil {
+		sampleRate = 0
+	}
+	scannerCtx, err := shardscanner.GetScannerContext(ctx)
+	if err != nil {
+		// without a visibility manager every check reports a failure instead of silently passing
+		return invariant.NewInvariantManager(getInvariants(pr, cache, nil, metrics.NoopScope, sampleRate))
+	}
+	return invariant.NewInvariantManager(getInvariants(pr, cache, scannerCtx.Resource, scannerCtx.Scope, sampleRate))
+}
+
+// Iterator provides iterator for visibility scanner.
+func Iterator(
+	ctx context.Context,
+	pr persistence.Retryer,
+	params shardscanner.ScanShardActivityParams,
+) pagination.Iterator {
+	return fetcher.ConcreteExecutionIterator(ctx, pr, params.PageSize)
+}
+
+// FixerIterator provides iterator for visibility fixer.
+func FixerIterator(
+	ctx context.Context,
+	client blobstore.Client,
+	keys store.Keys,
+	_ shardscanner.FixShardActivityParams,
+) store.ScanOutputIterator {
+	return store.NewBlobstoreIterator(ctx, client, keys, &entity.ConcreteExecution{})
+}
+
+// FixerManager provides invariant manager for visibility fixer.
+// All executions reported by the scanner are rechecked, and metrics are only emitted by the scanner.
+func FixerManager(
+	ctx context.Context,
+	pr persistence.Retryer,
+	_ shardscanner.FixShardActivityParams,
+	cache cache.DomainCache,
+) invariant.Manager {
+	fixerCtx, err := shardscanner.GetFixerContext(ctx)
+	if err != nil {
+		return invariant.NewInvariantManager(getInvariants(pr, cache, nil, metrics.NoopScope, 1))
+	}
+	return invariant.NewInvariantManager(getInvariants(pr, cache, fixerCtx.Resource, metrics.NoopScope, 1))
+}
+
+// Config resolves dynamic config for visibility scanner.
+func Config(ctx shardscanner.ScannerContext) shardscanner.CustomScannerConfig {
+	res := shardscanner.CustomScannerConfig{}
+	res[sampleRateKey] = strconv.FormatFloat(ctx.Config.DynamicCollection.GetFloat64Property(dynamicproperties.VisibilityScannerSampleRate)(), 'f', -1, 64)
+	return res
+}
+
+// ScannerConfig configures visibility scanner
+func ScannerConfig(dc *dynamicconfig.Collection) *shardscanner.ScannerConfig {
+	return &shardscanner.ScannerConfig{
+		ScannerWFTypeName: ScannerWFTypeName,
+		FixerWFTypeName:   FixerWFTypeName,
+		DynamicParams: shardscanner.DynamicParams{
+			ScannerEnabled:          dc.GetBoolProperty(dynamicproperties.VisibilityScannerEnabled),
+			FixerEnabled:            dc.GetBoolProperty(dynamicproperties.VisibilityFixerEnabled),
+			Concurrency:             dc.GetIntProperty(dynamicproperties.VisibilityScannerConcurrency),
+			PageSize:                dc.GetIntProperty(dynamicproperties.VisibilityScannerPersistencePageSize),
+			BlobstoreFlushThreshold: dc.GetIntProperty(dynamicproperties.VisibilityScannerBlobstoreFlushThreshold),
+			ActivityBatchSize:       dc.GetIntProperty(dynamicproperties.VisibilityScannerActivityBatchSize),
+			AllowDomain:             dc.GetBoolPropertyFilteredByDomain(dynamicproperties.VisibilityFixerDomainAllow),
+		},
+		DynamicCollection: dc,
+		ScannerHooks:      ScannerHooks,
+		FixerHooks:        FixerHooks,
+
+		StartWorkflowOptions: client.StartWorkflowOptions{
+			ID:                           wfid,
+			TaskList:                     scannerTaskListName,
+			ExecutionStartToCloseTimeout: 20 * 365 * 24 * time.Hour,
+			WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+			CronSchedule:                 "0 */12 * * *",
+		},
+		StartFixerOptions: client.StartWorkflowOptions{
+			ID:                           fixerwfid,
+			TaskList:                     fixerTaskListName,
+			ExecutionStartToCloseTimeout: 20 * 365 * 24 * time.Hour,
+			WorkflowIDReusePolicy:        client.WorkflowIDReusePolicyAllowDuplicate,
+			CronSchedule:                 "0 */12 * * *",
+		},
+	}
+}
+
+func getInvariants(
+	pr persistence.Retryer,
+	cache cache.DomainCache,
+	res resource.Resource,
+	scope metrics.Scope,
+	sampleRate float64,
+) []invariant.Invariant {
+	if res == nil {
+		return []invariant.Invariant{
+			invariant.NewVisibilityConsistent(pr, cache, nil, nil, clock.NewRealTimeSource(), scope, sampleRate),
+		}
+	}
+	return []invariant.Invariant{
+		invariant.NewVisibilityConsistent(pr, cache, res.GetVisibilityManager(), res.GetHistoryClient(), res.GetTimeSource(), scope, sampleRate),
+	}
+}
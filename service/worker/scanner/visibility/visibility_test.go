@@ -0,0 +1,128 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibility
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/dynamicconfig"
+	"github.com/uber/cadence/common/reconciliation/invariant"
+	"github.com/uber/cadence/common/reconciliation/store"
+	"github.com/uber/cadence/service/worker/scanner/shardscanner"
+)
+
+type visibilityWorkflowsSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+}
+
+func TestVisibilityWorkflowsSuite(t *testing.T) {
+	suite.Run(t, new(visibilityWorkflowsSuite))
+}
+
+func (s *visibilityWorkflowsSuite) SetupSuite() {
+	workflow.Register(ScannerWorkflow)
+}
+
+func (s *visibilityWorkflowsSuite) TestScannerConfig_SetsHooks() {
+	cfg := ScannerConfig(dynamicconfig.NewNopCollection())
+	s.Equal(ScannerWFTypeName, cfg.ScannerWFTypeName)
+	s.Equal(FixerWFTypeName, cfg.FixerWFTypeName)
+	s.NotNil(cfg.ScannerHooks())
+	s.NotNil(cfg.FixerHooks())
+}
+
+func (s *visibilityWorkflowsSuite) TestConfig_ResolvesSampleRate() {
+	cfg := ScannerConfig(dynamicconfig.NewNopCollection())
+	s.Equal(shardscanner.CustomScannerConfig{sampleRateKey: "0.01"}, Config(shardscanner.ScannerContext{Config: cfg}))
+	s.Equal(shardscanner.CustomScannerConfig{string(invariant.VisibilityConsistent): "true"}, visibilityCustomConfig(shardscanner.FixerContext{}))
+}
+
+func (s *visibilityWorkflowsSuite) TestScannerWorkflow_Success() {
+	env := s.NewTestWorkflowEnvironment()
+	cconfig := shardscanner.CustomScannerConfig{
+		sampleRateKey: "0.5",
+	}
+	env.OnActivity(shardscanner.ActivityScannerConfig, mock.Anything, mock.Anything).Return(shardscanner.ResolvedScannerWorkflowConfig{
+		GenericScannerConfig: shardscanner.GenericScannerConfig{
+			Enabled:           true,
+			Concurrency:       1,
+			ActivityBatchSize: 2,
+		},
+		CustomScannerConfig: cconfig,
+	}, nil)
+	env.OnActivity(shardscanner.ActivityScannerEmitMetrics, mock.Anything, mock.Anything).Return(nil)
+	env.OnActivity(shardscanner.ActivityScanShard, mock.Anything, shardscanner.ScanShardActivityParams{
+		Shards:        []int{0, 1},
+		ScannerConfig: cconfig,
+	}).Return([]shardscanner.ScanReport{
+		{
+			ShardID: 0,
+			Stats: shardscanner.ScanStats{
+				EntitiesCount: 10,
+			},
+		},
+		{
+			ShardID: 1,
+			Stats: shardscanner.ScanStats{
+				EntitiesCount:  10,
+				CorruptedCount: 1,
+				CorruptionByType: map[invariant.Name]int64{
+					invariant.VisibilityConsistent: 1,
+				},
+			},
+			Result: shardscanner.ScanResult{
+				ShardScanKeys: &shardscanner.ScanKeys{
+					Corrupt: &store.Keys{
+						UUID:    "test_uuid",
+						MinPage: 0,
+						MaxPage: 1,
+					},
+				},
+			},
+		},
+	}, nil)
+
+	env.ExecuteWorkflow(ScannerWorkflow, shardscanner.ScannerWorkflowParams{
+		Shards: shardscanner.Shards{
+			List: []int{0, 1},
+		},
+	})
+	s.True(env.IsWorkflowCompleted())
+	s.NoError(env.GetWorkflowError())
+
+	aggValue, err := env.QueryWorkflow(shardscanner.AggregateReportQuery)
+	s.NoError(err)
+	var agg shardscanner.AggregateScanReportResult
+	s.NoError(aggValue.Get(&agg))
+	s.Equal(shardscanner.AggregateScanReportResult{
+		EntitiesCount:  20,
+		CorruptedCount: 1,
+		CorruptionByType: map[invariant.Name]int64{
+			invariant.VisibilityConsistent: 1,
+		},
+	}, agg)
+}
@@ -54,7 +54,9 @@ import (
 	"github.com/uber/cadence/service/worker/scanner/shardscanner"
 	"github.com/uber/cadence/service/worker/scanner/tasklist"
 	"github.com/uber/cadence/service/worker/scanner/timers"
+	"github.com/uber/cadence/service/worker/scanner/visibility"
 	"github.com/uber/cadence/service/worker/scheduler"
+	"github.com/uber/cadence/service/worker/visibilitybackfill"
 )
 
 type (
@@ -95,24 +97,39 @@ type (
 		EnableESAnalyzer                    dynamicproperties.BoolPropertyFn
 		EnableAsyncWorkflowConsumption      dynamicproperties.BoolPropertyFn
 		EnableDomainAuditLogging            dynamicproperties.BoolPropertyFn
+		EnableVisibilityBackfill            dynamicproperties.BoolPropertyFn
 		HostName                            string
+
+		// visibility configs are used by the visibility backfill workflow and the visibility scanner
+		WriteVisibilityStoreName        dynamicproperties.StringPropertyFn
+		ReadVisibilityStoreName         dynamicproperties.StringPropertyFnWithDomainFilter
+		EnableReadFromClosedExecutionV2 dynamicproperties.BoolPropertyFn
+		ESIndexMaxResultWindow          dynamicproperties.IntPropertyFn
+		ValidSearchAttributes           dynamicproperties.MapPropertyFn
+		PinotOptimizedQueryColumns      dynamicproperties.MapPropertyFn
 	}
 )
 
 // NewService builds a new cadence-worker service
 func NewService(params *resource.Params) (resource.Resource, error) {
 	serviceConfig := NewConfig(params)
-	serviceResource, err := resource.New(
-		params,
-		service.Worker,
-		&service.Config{
-			PersistenceMaxQPS:        serviceConfig.PersistenceMaxQPS,
-			PersistenceGlobalMaxQPS:  serviceConfig.PersistenceGlobalMaxQPS,
-			ThrottledLoggerMaxRPS:    serviceConfig.ThrottledLogRPS,
-			IsErrorRetryableFunction: common.IsServiceTransientError,
-			// worker service doesn't need visibility config as it never call visibilityManager API
-		},
-	)
+	resourceConfig := &service.Config{
+		PersistenceMaxQPS:        serviceConfig.PersistenceMaxQPS,
+		PersistenceGlobalMaxQPS:  serviceConfig.PersistenceGlobalMaxQPS,
+		ThrottledLoggerMaxRPS:    serviceConfig.ThrottledLogRPS,
+		IsErrorRetryableFunction: common.IsServiceTransientError,
+	}
+	if serviceConfig.visibilityManagerRequired() {
+		// visibility manager is used by the visibility scanner, and by the visibility backfill workflow
+		// which pins the store to read from and write to through the request context
+		resourceConfig.WriteVisibilityStoreName = serviceConfig.WriteVisibilityStoreName
+		resourceConfig.ReadVisibilityStoreName = serviceConfig.ReadVisibilityStoreName
+		resourceConfig.EnableReadDBVisibilityFromClosedExecutionV2 = serviceConfig.EnableReadFromClosedExecutionV2
+		resourceConfig.ESIndexMaxResultWindow = serviceConfig.ESIndexMaxResultWindow
+		resourceConfig.ValidSearchAttributes = serviceConfig.ValidSearchAttributes
+		resourceConfig.PinotOptimizedQueryColumns = serviceConfig.PinotOptimizedQueryColumns
+	}
+	serviceResource, err := resource.New(params, service.Worker, resourceConfig)
 	if err != nil {
 		return nil, err
 	}
@@ -157,6 +174,7 @@ func NewConfig(params *resource.Params) *Config {
 				executions.ConcreteExecutionConfig(dc),
 				executions.CurrentExecutionConfig(dc),
 				timers.ScannerConfig(dc),
+				visibility.ScannerConfig(dc),
 			},
 			MaxWorkflowRetentionInDays: dc.GetIntProperty(dynamicproperties.MaxRetentionDays),
 		},
@@ -198,13 +216,16 @@ func NewConfig(params *resource.Params) *Config {
 		DomainReplicationMaxRetryDuration:   dc.GetDurationProperty(dynamicproperties.WorkerReplicationTaskMaxRetryDuration),
 		EnableAsyncWorkflowConsumption:      dc.GetBoolProperty(dynamicproperties.EnableAsyncWorkflowConsumption),
 		EnableDomainAuditLogging:            dc.GetBoolProperty(dynamicproperties.EnableDomainAuditLogging),
+		EnableVisibilityBackfill:            dc.GetBoolProperty(dynamicproperties.VisibilityBackfillEnabled),
 		HostName:                            params.HostName,
-	}
-	advancedVisWritingMode := dc.GetStringProperty(
-		dynamicproperties.WriteVisibilityStoreName,
-	)
-
-	if shouldStartIndexer(params, advancedVisWritingMode) {
+		WriteVisibilityStoreName:            dc.GetStringProperty(dynamicproperties.WriteVisibilityStoreName),
+		ReadVisibilityStoreName:             dc.GetStringPropertyFilteredByDomain(dynamicproperties.ReadVisibilityStoreName),
+		EnableReadFromClosedExecutionV2:     dc.GetBoolProperty(dynamicproperties.EnableReadFromClosedExecutionV2),
+		ESIndexMaxResultWindow:              dc.GetIntProperty(dynamicproperties.FrontendESIndexMaxResultWindow),
+		ValidSearchAttributes:               dc.GetMapProperty(dynamicproperties.ValidSearchAttributes),
+		PinotOptimizedQueryColumns:          dc.GetMapProperty(dynamicproperties.PinotOptimizedQueryColumns),
+	}
+	if shouldStartIndexer(params, config.WriteVisibilityStoreName) {
 		config.IndexerCfg = &indexer.Config{
 			IndexerConcurrency:             dc.GetIntProperty(dynamicproperties.WorkerIndexerConcurrency),
 			ESProcessorNumOfWorkers:        dc.GetIntProperty(dynamicproperties.WorkerESProcessorNumOfWorkers),
@@ -219,6 +240,23 @@ func NewConfig(params *resource.Params) *Config {
 	return config
 }
 
+// visibilityManagerRequired returns whether a worker component reading or writing visibility records is enabled.
+// The visibility manager is created with the service, so enabling one of them requires restarting the worker.
+func (c *Config) visibilityManagerRequired() bool {
+	if c.EnableVisibilityBackfill() {
+		return true
+	}
+	for _, scannerCfg := range c.ScannerCfg.ShardScanners {
+		if scannerCfg.ScannerWFTypeName != visibility.ScannerWFTypeName {
+			continue
+		}
+		if scannerCfg.DynamicParams.ScannerEnabled() || scannerCfg.DynamicParams.FixerEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
 // Start is called to start the service
 func (s *Service) Start() {
 	if !atomic.CompareAndSwapInt32(&s.status, common.DaemonStatusInitialized, common.DaemonStatusStarted) {
@@ -244,6 +282,9 @@ func (s *Service) Start() {
 	s.startReplicator()
 	s.startDiagnostics()
 	s.startDomainDeprecation()
+	if s.config.EnableVisibilityBackfill() {
+		s.startVisibilityBackfill()
+	}
 
 	if s.GetArchivalMetadata().GetHistoryConfig().ClusterConfiguredForArchival() {
 		s.startArchiver()
@@ -524,6 +565,29 @@ func (s *Service) startDomainDeprecation() {
 	}
 }
 
+func (s *Service) startVisibilityBackfill() {
+	if s.GetVisibilityManager() == nil {
+		s.GetLogger().Warn("visibility backfill was enabled after the worker started, restart the worker to start it")
+		return
+	}
+	params := visibilitybackfill.Params{
+		ServiceClient:     s.params.PublicClient,
+		VisibilityManager: s.GetVisibilityManager(),
+		HistoryManager:    s.GetHistoryManager(),
+		ShardManager:      s.GetShardManager(),
+		ExecutionManagers: s.Resource,
+		DomainCache:       s.GetDomainCache(),
+		TimeSource:        s.GetTimeSource(),
+		Tally:             s.params.MetricScope,
+		Logger:            s.GetLogger(),
+	}
+
+	if err := visibilitybackfill.New(params).Start(); err != nil {
+		s.Stop()
+		s.GetLogger().Fatal("error starting visibility backfill", tag.Error(err))
+	}
+}
+
 func (s *Service) ensureDomainExists(domain string) {
 	_, err := s.GetDomainManager().GetDomain(context.Background(), &persistence.GetDomainRequest{Name: domain})
 	switch err.(type) {
@@ -0,0 +1,531 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"golang.org/x/time/rate"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/log/tag"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	// rangeSizeBits mirrors the RangeSizeBits of the history service: the task IDs of a shard owned
+	// under range ID r are allocated from [r << rangeSizeBits, (r+1) << rangeSizeBits)
+	rangeSizeBits = 20
+	// timestampTolerance absorbs the precision lost when visibility stores persist timestamps
+	timestampTolerance = int64(time.Second)
+	// lookupPageSize is the page size used to look up the open records of a workflow ID in the target store
+	lookupPageSize = 10
+	secondsInDay   = 24 * 60 * 60
+)
+
+var errInvalidExecution = errors.New("invalid execution")
+
+type (
+	shardBackfill struct {
+		*backfiller
+		params  BackfillParams
+		shardID int
+		taskID  int64
+	}
+
+	// visibilityRecord is the visibility record of an execution rebuilt from the primary store,
+	// started is set for open executions and closed for closed ones
+	visibilityRecord struct {
+		started *persistence.RecordWorkflowExecutionStartedRequest
+		closed  *persistence.RecordWorkflowExecutionClosedRequest
+	}
+)
+
+// BackfillShardActivity replays the visibility records of the executions of a shard into the target store.
+// Progress is checkpointed through heartbeats at page boundaries, a retried activity resumes from the last page it started.
+func (b *backfiller) BackfillShardActivity(ctx context.Context, params shardActivityParams) (*Report, error) {
+	if b.visibilityMgr == nil {
+		return nil, cadence.NewCustomError(ErrVisibilityNotConfiguredNonRetryable)
+	}
+	logger := b.logger.WithTags(tag.ShardID(params.ShardID))
+	executionMgr, err := b.executionManagers.GetExecutionManager(params.ShardID)
+	if err != nil {
+		return nil, err
+	}
+	shardResp, err := b.shardMgr.GetShard(ctx, &persistence.GetShardRequest{ShardID: params.ShardID})
+	if err != nil {
+		return nil, err
+	}
+
+	shard := &shardBackfill{
+		backfiller: b,
+		params:     params.Params,
+		shardID:    params.ShardID,
+		// Stores such as ES use the task ID as the version of a record. Replayed records use the largest task ID
+		// of the previous range of the shard: it is newer than the visibility tasks of previous shard owners
+		// and older than every task generated from now on, so a replayed record never overrides a live one.
+		taskID: shardResp.ShardInfo.RangeID<<rangeSizeBits - 1,
+	}
+	ctx = persistence.WithVisibilityStoreOverride(ctx, params.Params.TargetStore)
+
+	rps := max(1, params.Params.RPS/params.Params.Concurrency)
+	limiter := rate.NewLimiter(rate.Limit(rps), rps)
+	details := getHeartbeatDetails(ctx, logger)
+	report := details.Report
+	for {
+		resp, err := executionMgr.ListConcreteExecutions(ctx, &persistence.ListConcreteExecutionsRequest{
+			PageSize:  params.Params.PageSize,
+			PageToken: details.PageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, execution := range resp.Executions {
+			if err := limiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+			if err := shard.backfillExecution(ctx, execution, &report); err != nil {
+				return nil, err
+			}
+			activity.RecordHeartbeat(ctx, details)
+		}
+
+		details = shardHeartbeatDetails{
+			PageToken: resp.PageToken,
+			Report:    report,
+		}
+		activity.RecordHeartbeat(ctx, details)
+		if len(resp.PageToken) == 0 {
+			break
+		}
+	}
+
+	report.ShardsCompleted = 1
+	logger.Info("Visibility backfill of shard completed", tag.Counter(int(report.Scanned)))
+	return &report, nil
+}
+
+func getHeartbeatDetails(ctx context.Context, logger log.Logger) shardHeartbeatDetails {
+	var details shardHeartbeatDetails
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &details); err != nil {
+			logger.Error("Failed to recover from last heartbeat, start over from beginning", tag.Error(err))
+			return shardHeartbeatDetails{}
+		}
+	}
+	return details
+}
+
+// backfillExecution compares the visibility record of an execution with the target store and writes it when needed.
+// Only errors worth retrying the page for are returned, inconsistencies are recorded in the report.
+func (s *shardBackfill) backfillExecution(
+	ctx context.Context,
+	execution *persistence.ListConcreteExecutionsEntity,
+	report *Report,
+) error {
+	info := execution.ExecutionInfo
+	report.Scanned++
+	// zombie, void and corrupted executions never get visibility records
+	if info.State != persistence.WorkflowStateCreated &&
+		info.State != persistence.WorkflowStateRunning &&
+		info.State != persistence.WorkflowStateCompleted {
+		report.Skipped++
+		return nil
+	}
+
+	domainEntry, err := s.domainCache.GetDomainByID(info.DomainID)
+	if err != nil {
+		if isEntityNotExistsError(err) {
+			report.Skipped++
+			return nil
+		}
+		return err
+	}
+	if s.params.Domain != "" && s.params.Domain != domainEntry.GetInfo().Name {
+		report.Skipped++
+		return nil
+	}
+	// history does not record the close of executions left out of the longer retention sample either
+	if info.State == persistence.WorkflowStateCompleted &&
+		domainEntry.IsSampledForLongerRetentionEnabled(info.WorkflowID) &&
+		!domainEntry.IsSampledForLongerRetention(info.WorkflowID) {
+		report.Skipped++
+		return nil
+	}
+
+	record, err := s.buildRecord(ctx, execution, domainEntry)
+	if err != nil {
+		if errors.Is(err, errInvalidExecution) || isEntityNotExistsError(err) {
+			report.Failed++
+			report.addDiff(s.newDiff(info, DiffTypeFailed, err.Error()))
+			return nil
+		}
+		return err
+	}
+
+	if !s.params.Force {
+		diffType, details, err := s.compare(ctx, record)
+		if err != nil {
+			return err
+		}
+		switch diffType {
+		case "":
+			report.Consistent++
+			return nil
+		case DiffTypeMissing:
+			report.Missing++
+		case DiffTypeStale:
+			report.Stale++
+		case DiffTypeMismatched:
+			report.Mismatched++
+		}
+		report.addDiff(s.newDiff(info, diffType, details))
+		if s.params.DryRun {
+			return nil
+		}
+	}
+
+	if record.closed != nil {
+		err = s.visibilityMgr.RecordWorkflowExecutionClosed(ctx, record.closed)
+	} else {
+		err = s.visibilityMgr.RecordWorkflowExecutionStarted(ctx, record.started)
+	}
+	if err != nil {
+		return err
+	}
+	report.Written++
+	return nil
+}
+
+// buildRecord rebuilds the visibility record of an execution the same way the history transfer tasks do.
+func (s *shardBackfill) buildRecord(
+	ctx context.Context,
+	execution *persistence.ListConcreteExecutionsEntity,
+	domainEntry *cache.DomainCacheEntry,
+) (*visibilityRecord, error) {
+	info := execution.ExecutionInfo
+	domainName := domainEntry.GetInfo().Name
+	branchToken, err := getBranchToken(execution)
+	if err != nil {
+		return nil, err
+	}
+	startEvents, err := s.readHistoryBatch(ctx, branchToken, domainName, constants.FirstEventID, constants.FirstEventID+1)
+	if err != nil {
+		return nil, err
+	}
+	startAttributes := startEvents[0].GetWorkflowExecutionStartedEventAttributes()
+	if startAttributes == nil {
+		return nil, fmt.Errorf("%w: first event is %v", errInvalidExecution, startEvents[0].GetEventType())
+	}
+
+	startTimestamp := startEvents[0].GetTimestamp()
+	// executions without backoff use 0 as execution time, see getWorkflowExecutionTimestamp of history
+	executionTimestamp := time.Unix(0, 0).UnixNano()
+	scheduledExecutionTimestamp := startTimestamp
+	backoffSeconds := startAttributes.GetFirstDecisionTaskBackoffSeconds()
+	if backoffSeconds > 0 {
+		executionTimestamp = startTimestamp + int64(backoffSeconds)*int64(time.Second)
+		scheduledExecutionTimestamp = executionTimestamp
+	}
+	var memo *types.Memo
+	if info.Memo != nil {
+		memo = &types.Memo{Fields: info.Memo}
+	}
+	workflowExecution := types.WorkflowExecution{
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	}
+	clusterAttribute := info.ActiveClusterSelectionPolicy.GetClusterAttribute()
+	numClusters := int16(len(domainEntry.GetReplicationConfig().Clusters))
+	updateTimestamp := s.timeSource.Now().UnixNano()
+
+	if info.State != persistence.WorkflowStateCompleted {
+		executionStatus := types.WorkflowExecutionStatusStarted
+		// the first decision of an execution with backoff is only scheduled when the backoff fires,
+		// db visibility can't be updated from pending so it always records started
+		if backoffSeconds > 0 && info.NextEventID == constants.FirstEventID+1 && s.params.TargetStore != constants.VisibilityModeDB {
+			executionStatus = types.WorkflowExecutionStatusPending
+		}
+		return &visibilityRecord{
+			started: &persistence.RecordWorkflowExecutionStartedRequest{
+				DomainUUID:                  info.DomainID,
+				Domain:                      domainName,
+				Execution:                   workflowExecution,
+				WorkflowTypeName:            info.WorkflowTypeName,
+				StartTimestamp:              startTimestamp,
+				ExecutionTimestamp:          executionTimestamp,
+				WorkflowTimeout:             int64(info.WorkflowTimeout),
+				TaskID:                      s.taskID,
+				Memo:                        memo,
+				TaskList:                    info.TaskList,
+				IsCron:                      len(info.CronSchedule) > 0,
+				NumClusters:                 numClusters,
+				ClusterAttributeScope:       clusterAttribute.GetScope(),
+				ClusterAttributeName:        clusterAttribute.GetName(),
+				UpdateTimestamp:             updateTimestamp,
+				SearchAttributes:            info.SearchAttributes,
+				ShardID:                     int16(s.shardID),
+				ExecutionStatus:             executionStatus,
+				CronSchedule:                info.CronSchedule,
+				ScheduledExecutionTimestamp: scheduledExecutionTimestamp,
+			},
+		}, nil
+	}
+
+	closeStatus := persistence.ToInternalWorkflowExecutionCloseStatus(info.CloseStatus)
+	if closeStatus == nil {
+		return nil, fmt.Errorf("%w: completed execution has no close status", errInvalidExecution)
+	}
+	closeTimestamp, err := s.getCloseTimestamp(ctx, info, branchToken, domainName)
+	if err != nil {
+		return nil, err
+	}
+	return &visibilityRecord{
+		closed: &persistence.RecordWorkflowExecutionClosedRequest{
+			DomainUUID:                  info.DomainID,
+			Domain:                      domainName,
+			Execution:                   workflowExecution,
+			WorkflowTypeName:            info.WorkflowTypeName,
+			StartTimestamp:              startTimestamp,
+			ExecutionTimestamp:          executionTimestamp,
+			CloseTimestamp:              closeTimestamp,
+			Status:                      *closeStatus,
+			HistoryLength:               info.NextEventID - 1,
+			RetentionSeconds:            int64(domainEntry.GetRetentionDays(info.WorkflowID)) * secondsInDay,
+			TaskID:                      s.taskID,
+			Memo:                        memo,
+			TaskList:                    info.TaskList,
+			IsCron:                      len(info.CronSchedule) > 0,
+			CronSchedule:                info.CronSchedule,
+			NumClusters:                 numClusters,
+			ClusterAttributeScope:       clusterAttribute.GetScope(),
+			ClusterAttributeName:        clusterAttribute.GetName(),
+			UpdateTimestamp:             updateTimestamp,
+			SearchAttributes:            info.SearchAttributes,
+			ShardID:                     int16(s.shardID),
+			ExecutionStatus:             toExecutionStatus(*closeStatus),
+			ScheduledExecutionTimestamp: scheduledExecutionTimestamp,
+		},
+	}, nil
+}
+
+// getCloseTimestamp returns the timestamp of the completion event, which is the last event of the history
+func (s *shardBackfill) getCloseTimestamp(
+	ctx context.Context,
+	info *persistence.WorkflowExecutionInfo,
+	branchToken []byte,
+	domainName string,
+) (int64, error) {
+	if info.CompletionEvent != nil {
+		return info.CompletionEvent.GetTimestamp(), nil
+	}
+	if info.CompletionEventBatchID <= constants.FirstEventID {
+		return info.LastUpdatedTimestamp.UnixNano(), nil
+	}
+	events, err := s.readHistoryBatch(ctx, branchToken, domainName, info.CompletionEventBatchID, info.NextEventID)
+	if err != nil {
+		return 0, err
+	}
+	return events[len(events)-1].GetTimestamp(), nil
+}
+
+func (s *shardBackfill) readHistoryBatch(
+	ctx context.Context,
+	branchToken []byte,
+	domainName string,
+	minEventID int64,
+	maxEventID int64,
+) ([]*types.HistoryEvent, error) {
+	shardID := s.shardID
+	resp, err := s.historyMgr.ReadHistoryBranch(ctx, &persistence.ReadHistoryBranchRequest{
+		BranchToken: branchToken,
+		MinEventID:  minEventID,
+		MaxEventID:  maxEventID,
+		PageSize:    1,
+		ShardID:     &shardID,
+		DomainName:  domainName,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.HistoryEvents) == 0 {
+		return nil, fmt.Errorf("%w: no history events in [%d, %d)", errInvalidExecution, minEventID, maxEventID)
+	}
+	return resp.HistoryEvents, nil
+}
+
+// compare looks up the record of the execution in the target store, it returns an empty DiffType when they are consistent
+func (s *shardBackfill) compare(ctx context.Context, record *visibilityRecord) (DiffType, string, error) {
+	if record.closed != nil {
+		expected := record.closed
+		resp, err := s.visibilityMgr.GetClosedWorkflowExecution(ctx, &persistence.GetClosedWorkflowExecutionRequest{
+			DomainUUID: expected.DomainUUID,
+			Domain:     expected.Domain,
+			Execution:  expected.Execution,
+		})
+		if err != nil && !isEntityNotExistsError(err) {
+			return "", "", err
+		}
+		if err == nil && resp.Execution != nil {
+			if details := diffClosedRecord(expected, resp.Execution); details != "" {
+				return DiffTypeMismatched, details, nil
+			}
+			return "", "", nil
+		}
+
+		open, err := s.findOpenRecord(ctx, expected.DomainUUID, expected.Domain, expected.Execution, expected.StartTimestamp)
+		if err != nil {
+			return "", "", err
+		}
+		if open != nil {
+			return DiffTypeStale, "target store only has the open record", nil
+		}
+		return DiffTypeMissing, "", nil
+	}
+
+	expected := record.started
+	open, err := s.findOpenRecord(ctx, expected.DomainUUID, expected.Domain, expected.Execution, expected.StartTimestamp)
+	if err != nil {
+		return "", "", err
+	}
+	if open == nil {
+		return DiffTypeMissing, "", nil
+	}
+	if details := diffOpenRecord(expected, open); details != "" {
+		return DiffTypeMismatched, details, nil
+	}
+	return "", "", nil
+}
+
+func (s *shardBackfill) findOpenRecord(
+	ctx context.Context,
+	domainID string,
+	domainName string,
+	execution types.WorkflowExecution,
+	startTimestamp int64,
+) (*types.WorkflowExecutionInfo, error) {
+	resp, err := s.visibilityMgr.ListOpenWorkflowExecutionsByWorkflowID(ctx, &persistence.ListWorkflowExecutionsByWorkflowIDRequest{
+		ListWorkflowExecutionsRequest: persistence.ListWorkflowExecutionsRequest{
+			DomainUUID:   domainID,
+			Domain:       domainName,
+			EarliestTime: startTimestamp - timestampTolerance,
+			LatestTime:   startTimestamp + timestampTolerance,
+			PageSize:     lookupPageSize,
+		},
+		WorkflowID: execution.WorkflowID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range resp.Executions {
+		if record.GetExecution().GetRunID() == execution.RunID {
+			return record, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *shardBackfill) newDiff(info *persistence.WorkflowExecutionInfo, diffType DiffType, details string) Diff {
+	return Diff{
+		ShardID:    s.shardID,
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+		Type:       diffType,
+		Details:    details,
+	}
+}
+
+func diffOpenRecord(expected *persistence.RecordWorkflowExecutionStartedRequest, actual *types.WorkflowExecutionInfo) string {
+	var diffs []string
+	if name := actual.GetType().GetName(); name != expected.WorkflowTypeName {
+		diffs = append(diffs, fmt.Sprintf("workflow type is %q instead of %q", name, expected.WorkflowTypeName))
+	}
+	if !timestampsMatch(actual.GetStartTime(), expected.StartTimestamp) {
+		diffs = append(diffs, fmt.Sprintf("start time is %d instead of %d", actual.GetStartTime(), expected.StartTimestamp))
+	}
+	return strings.Join(diffs, "; ")
+}
+
+func diffClosedRecord(expected *persistence.RecordWorkflowExecutionClosedRequest, actual *types.WorkflowExecutionInfo) string {
+	var diffs []string
+	if name := actual.GetType().GetName(); name != expected.WorkflowTypeName {
+		diffs = append(diffs, fmt.Sprintf("workflow type is %q instead of %q", name, expected.WorkflowTypeName))
+	}
+	if !timestampsMatch(actual.GetStartTime(), expected.StartTimestamp) {
+		diffs = append(diffs, fmt.Sprintf("start time is %d instead of %d", actual.GetStartTime(), expected.StartTimestamp))
+	}
+	if !timestampsMatch(actual.GetCloseTime(), expected.CloseTimestamp) {
+		diffs = append(diffs, fmt.Sprintf("close time is %d instead of %d", actual.GetCloseTime(), expected.CloseTimestamp))
+	}
+	if actual.CloseStatus == nil || *actual.CloseStatus != expected.Status {
+		diffs = append(diffs, fmt.Sprintf("close status is %v instead of %v", actual.CloseStatus, expected.Status))
+	}
+	return strings.Join(diffs, "; ")
+}
+
+func timestampsMatch(actual, expected int64) bool {
+	delta := actual - expected
+	return delta > -timestampTolerance && delta < timestampTolerance
+}
+
+func getBranchToken(execution *persistence.ListConcreteExecutionsEntity) ([]byte, error) {
+	if execution.VersionHistories == nil {
+		return execution.ExecutionInfo.BranchToken, nil
+	}
+	versionHistory, err := execution.VersionHistories.GetCurrentVersionHistory()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidExecution, err)
+	}
+	return versionHistory.GetBranchToken(), nil
+}
+
+func toExecutionStatus(closeStatus types.WorkflowExecutionCloseStatus) types.WorkflowExecutionStatus {
+	switch closeStatus {
+	case types.WorkflowExecutionCloseStatusFailed:
+		return types.WorkflowExecutionStatusFailed
+	case types.WorkflowExecutionCloseStatusCanceled:
+		return types.WorkflowExecutionStatusCanceled
+	case types.WorkflowExecutionCloseStatusTerminated:
+		return types.WorkflowExecutionStatusTerminated
+	case types.WorkflowExecutionCloseStatusContinuedAsNew:
+		return types.WorkflowExecutionStatusContinuedAsNew
+	case types.WorkflowExecutionCloseStatusTimedOut:
+		return types.WorkflowExecutionStatusTimedOut
+	default:
+		return types.WorkflowExecutionStatusCompleted
+	}
+}
+
+func isEntityNotExistsError(err error) bool {
+	var entityNotExists *types.EntityNotExistsError
+	return errors.As(err, &entityNotExists)
+}
@@ -0,0 +1,392 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/log/testlogger"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/types"
+)
+
+const (
+	testDomainID   = "test-domain-id"
+	testDomainName = "test-domain"
+	testWorkflowID = "test-workflow-id"
+	testRunID      = "test-run-id"
+	testShardID    = 3
+	testRangeID    = int64(5)
+)
+
+var (
+	testStartTime = time.Unix(1700000000, 0)
+	testCloseTime = testStartTime.Add(time.Hour)
+)
+
+type (
+	testExecutionManagers struct {
+		executionMgr persistence.ExecutionManager
+	}
+
+	activityTestDeps struct {
+		visibilityMgr *persistence.MockVisibilityManager
+		historyMgr    *persistence.MockHistoryManager
+		executionMgr  *persistence.MockExecutionManager
+		domainCache   *cache.MockDomainCache
+	}
+)
+
+func (m testExecutionManagers) GetExecutionManager(int) (persistence.ExecutionManager, error) {
+	return m.executionMgr, nil
+}
+
+func newActivityTest(t *testing.T) (*testsuite.TestActivityEnvironment, *activityTestDeps) {
+	ctrl := gomock.NewController(t)
+	deps := &activityTestDeps{
+		visibilityMgr: persistence.NewMockVisibilityManager(ctrl),
+		historyMgr:    persistence.NewMockHistoryManager(ctrl),
+		executionMgr:  persistence.NewMockExecutionManager(ctrl),
+		domainCache:   cache.NewMockDomainCache(ctrl),
+	}
+	shardMgr := persistence.NewMockShardManager(ctrl)
+	shardMgr.EXPECT().GetShard(gomock.Any(), &persistence.GetShardRequest{ShardID: testShardID}).
+		Return(&persistence.GetShardResponse{ShardInfo: &persistence.ShardInfo{ShardID: testShardID, RangeID: testRangeID}}, nil).AnyTimes()
+	domainEntry := cache.NewLocalDomainCacheEntryForTest(
+		&persistence.DomainInfo{ID: testDomainID, Name: testDomainName},
+		&persistence.DomainConfig{Retention: 7},
+		"active",
+	)
+	deps.domainCache.EXPECT().GetDomainByID(testDomainID).Return(domainEntry, nil).AnyTimes()
+
+	b := &backfiller{
+		visibilityMgr:     deps.visibilityMgr,
+		historyMgr:        deps.historyMgr,
+		shardMgr:          shardMgr,
+		executionManagers: testExecutionManagers{executionMgr: deps.executionMgr},
+		domainCache:       deps.domainCache,
+		timeSource:        clock.NewMockedTimeSourceAt(testCloseTime.Add(time.Minute)),
+		logger:            testlogger.New(t),
+	}
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+	env.RegisterActivityWithOptions(b.BackfillShardActivity, activity.RegisterOptions{Name: backfillShardActivity})
+	return env, deps
+}
+
+func testShardParams(modify func(*BackfillParams)) shardActivityParams {
+	params := setDefaultParams(BackfillParams{
+		TargetStore:  "pinot",
+		StartShardID: 0,
+		EndShardID:   15,
+	})
+	if modify != nil {
+		modify(&params)
+	}
+	return shardActivityParams{Params: params, ShardID: testShardID}
+}
+
+func testOpenExecution() *persistence.ListConcreteExecutionsEntity {
+	return &persistence.ListConcreteExecutionsEntity{
+		ExecutionInfo: &persistence.WorkflowExecutionInfo{
+			DomainID:         testDomainID,
+			WorkflowID:       testWorkflowID,
+			RunID:            testRunID,
+			WorkflowTypeName: "test-workflow-type",
+			TaskList:         "test-tasklist",
+			WorkflowTimeout:  3600,
+			State:            persistence.WorkflowStateRunning,
+			CloseStatus:      persistence.WorkflowCloseStatusNone,
+			NextEventID:      5,
+			StartTimestamp:   testStartTime,
+			BranchToken:      []byte("branch-token"),
+			Memo:             map[string][]byte{"memo": []byte("value")},
+			SearchAttributes: map[string][]byte{"CustomKeywordField": []byte(`"keyword"`)},
+		},
+	}
+}
+
+func testClosedExecution() *persistence.ListConcreteExecutionsEntity {
+	execution := testOpenExecution()
+	execution.ExecutionInfo.State = persistence.WorkflowStateCompleted
+	execution.ExecutionInfo.CloseStatus = persistence.WorkflowCloseStatusFailed
+	execution.ExecutionInfo.NextEventID = 8
+	execution.ExecutionInfo.CompletionEvent = &types.HistoryEvent{
+		ID:        7,
+		Timestamp: common.Int64Ptr(testCloseTime.UnixNano()),
+		EventType: types.EventTypeWorkflowExecutionFailed.Ptr(),
+	}
+	return execution
+}
+
+func (d *activityTestDeps) expectListExecutions(pageToken []byte, executions ...*persistence.ListConcreteExecutionsEntity) {
+	d.executionMgr.EXPECT().ListConcreteExecutions(gomock.Any(), &persistence.ListConcreteExecutionsRequest{
+		PageSize:  DefaultPageSize,
+		PageToken: pageToken,
+	}).Return(&persistence.ListConcreteExecutionsResponse{Executions: executions}, nil)
+}
+
+func (d *activityTestDeps) expectStartEvent(backoffSeconds int32) {
+	d.historyMgr.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, request *persistence.ReadHistoryBranchRequest) (*persistence.ReadHistoryBranchResponse, error) {
+			if request.MinEventID != 1 || request.MaxEventID != 2 || *request.ShardID != testShardID {
+				return nil, errors.New("unexpected history read")
+			}
+			return &persistence.ReadHistoryBranchResponse{
+				HistoryEvents: []*types.HistoryEvent{{
+					ID:        1,
+					Timestamp: common.Int64Ptr(testStartTime.UnixNano()),
+					EventType: types.EventTypeWorkflowExecutionStarted.Ptr(),
+					WorkflowExecutionStartedEventAttributes: &types.WorkflowExecutionStartedEventAttributes{
+						FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoffSeconds),
+					},
+				}},
+			}, nil
+		})
+}
+
+func (d *activityTestDeps) expectOpenRecords(records ...*types.WorkflowExecutionInfo) {
+	d.visibilityMgr.EXPECT().ListOpenWorkflowExecutionsByWorkflowID(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *persistence.ListWorkflowExecutionsByWorkflowIDRequest) (*persistence.ListWorkflowExecutionsResponse, error) {
+			if request.WorkflowID != testWorkflowID ||
+				request.EarliestTime > testStartTime.UnixNano() ||
+				request.LatestTime < testStartTime.UnixNano() {
+				return nil, errors.New("unexpected open record lookup")
+			}
+			return &persistence.ListWorkflowExecutionsResponse{Executions: records}, nil
+		})
+}
+
+func testRecord(closeStatus *types.WorkflowExecutionCloseStatus) *types.WorkflowExecutionInfo {
+	record := &types.WorkflowExecutionInfo{
+		Execution: &types.WorkflowExecution{WorkflowID: testWorkflowID, RunID: testRunID},
+		Type:      &types.WorkflowType{Name: "test-workflow-type"},
+		StartTime: common.Int64Ptr(testStartTime.UnixNano()),
+	}
+	if closeStatus != nil {
+		record.CloseTime = common.Int64Ptr(testCloseTime.UnixNano())
+		record.CloseStatus = closeStatus
+	}
+	return record
+}
+
+func executeShardActivity(t *testing.T, env *testsuite.TestActivityEnvironment, params shardActivityParams) (*Report, error) {
+	val, err := env.ExecuteActivity(backfillShardActivity, params)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	require.NoError(t, val.Get(&report))
+	return &report, nil
+}
+
+func TestBackfillShardActivity_OpenExecutionMissing(t *testing.T) {
+	env, deps := newActivityTest(t)
+	deps.expectListExecutions(nil, testOpenExecution())
+	deps.expectStartEvent(0)
+	deps.expectOpenRecords()
+	deps.visibilityMgr.EXPECT().RecordWorkflowExecutionStarted(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *persistence.RecordWorkflowExecutionStartedRequest) error {
+			assert.Equal(t, testDomainName, request.Domain)
+			assert.Equal(t, testStartTime.UnixNano(), request.StartTimestamp)
+			assert.Equal(t, int64(0), request.ExecutionTimestamp)
+			assert.Equal(t, testRangeID<<rangeSizeBits-1, request.TaskID)
+			assert.Equal(t, int16(testShardID), request.ShardID)
+			assert.Equal(t, int16(1), request.NumClusters)
+			assert.Equal(t, types.WorkflowExecutionStatusStarted, request.ExecutionStatus)
+			assert.Equal(t, []byte("value"), request.Memo.Fields["memo"])
+			return nil
+		})
+
+	report, err := executeShardActivity(t, env, testShardParams(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Scanned)
+	assert.Equal(t, int64(1), report.Missing)
+	assert.Equal(t, int64(1), report.Written)
+	assert.Equal(t, 1, report.ShardsCompleted)
+	require.Len(t, report.Diffs, 1)
+	assert.Equal(t, Diff{ShardID: testShardID, DomainID: testDomainID, WorkflowID: testWorkflowID, RunID: testRunID, Type: DiffTypeMissing}, report.Diffs[0])
+}
+
+func TestBackfillShardActivity_OpenExecutionPending(t *testing.T) {
+	env, deps := newActivityTest(t)
+	execution := testOpenExecution()
+	execution.ExecutionInfo.NextEventID = 2
+	deps.expectListExecutions(nil, execution)
+	deps.expectStartEvent(60)
+	deps.expectOpenRecords()
+	deps.visibilityMgr.EXPECT().RecordWorkflowExecutionStarted(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *persistence.RecordWorkflowExecutionStartedRequest) error {
+			expectedExecutionTime := testStartTime.Add(time.Minute).UnixNano()
+			assert.Equal(t, expectedExecutionTime, request.ExecutionTimestamp)
+			assert.Equal(t, expectedExecutionTime, request.ScheduledExecutionTimestamp)
+			assert.Equal(t, types.WorkflowExecutionStatusPending, request.ExecutionStatus)
+			return nil
+		})
+
+	report, err := executeShardActivity(t, env, testShardParams(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Written)
+}
+
+func TestBackfillShardActivity_OpenExecutionConsistent(t *testing.T) {
+	env, deps := newActivityTest(t)
+	deps.expectListExecutions(nil, testOpenExecution())
+	deps.expectStartEvent(0)
+	deps.expectOpenRecords(testRecord(nil))
+
+	report, err := executeShardActivity(t, env, testShardParams(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Consistent)
+	assert.Equal(t, int64(0), report.Written)
+	assert.Empty(t, report.Diffs)
+}
+
+func TestBackfillShardActivity_ClosedExecutionStale(t *testing.T) {
+	env, deps := newActivityTest(t)
+	deps.expectListExecutions(nil, testClosedExecution())
+	deps.expectStartEvent(0)
+	deps.visibilityMgr.EXPECT().GetClosedWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(nil, &types.EntityNotExistsError{})
+	deps.expectOpenRecords(testRecord(nil))
+	deps.visibilityMgr.EXPECT().RecordWorkflowExecutionClosed(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, request *persistence.RecordWorkflowExecutionClosedRequest) error {
+			assert.Equal(t, testCloseTime.UnixNano(), request.CloseTimestamp)
+			assert.Equal(t, types.WorkflowExecutionCloseStatusFailed, request.Status)
+			assert.Equal(t, types.WorkflowExecutionStatusFailed, request.ExecutionStatus)
+			assert.Equal(t, int64(7), request.HistoryLength)
+			assert.Equal(t, int64(7*secondsInDay), request.RetentionSeconds)
+			return nil
+		})
+
+	report, err := executeShardActivity(t, env, testShardParams(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Stale)
+	assert.Equal(t, int64(1), report.Written)
+}
+
+func TestBackfillShardActivity_ClosedExecutionMismatchedDryRun(t *testing.T) {
+	env, deps := newActivityTest(t)
+	deps.expectListExecutions(nil, testClosedExecution())
+	deps.expectStartEvent(0)
+	deps.visibilityMgr.EXPECT().GetClosedWorkflowExecution(gomock.Any(), gomock.Any()).
+		Return(&persistence.GetClosedWorkflowExecutionResponse{
+			Execution: testRecord(types.WorkflowExecutionCloseStatusCompleted.Ptr()),
+		}, nil)
+
+	report, err := executeShardActivity(t, env, testShardParams(func(params *BackfillParams) {
+		params.DryRun = true
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Mismatched)
+	assert.Equal(t, int64(0), report.Written)
+	require.Len(t, report.Diffs, 1)
+	assert.Equal(t, DiffTypeMismatched, report.Diffs[0].Type)
+	assert.Contains(t, report.Diffs[0].Details, "close status")
+}
+
+func TestBackfillShardActivity_Force(t *testing.T) {
+	env, deps := newActivityTest(t)
+	deps.expectListExecutions(nil, testClosedExecution())
+	deps.expectStartEvent(0)
+	deps.visibilityMgr.EXPECT().RecordWorkflowExecutionClosed(gomock.Any(), gomock.Any()).Return(nil)
+
+	report, err := executeShardActivity(t, env, testShardParams(func(params *BackfillParams) {
+		params.Force = true
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Written)
+	assert.Empty(t, report.Diffs)
+}
+
+func TestBackfillShardActivity_Skipped(t *testing.T) {
+	env, deps := newActivityTest(t)
+	zombie := testOpenExecution()
+	zombie.ExecutionInfo.State = persistence.WorkflowStateZombie
+	deps.expectListExecutions(nil, zombie, testOpenExecution())
+
+	report, err := executeShardActivity(t, env, testShardParams(func(params *BackfillParams) {
+		params.Domain = "another-domain"
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), report.Scanned)
+	assert.Equal(t, int64(2), report.Skipped)
+}
+
+func TestBackfillShardActivity_HistoryNotFound(t *testing.T) {
+	env, deps := newActivityTest(t)
+	deps.expectListExecutions(nil, testOpenExecution())
+	deps.historyMgr.EXPECT().ReadHistoryBranch(gomock.Any(), gomock.Any()).Return(nil, &types.EntityNotExistsError{Message: "history not found"})
+
+	report, err := executeShardActivity(t, env, testShardParams(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), report.Failed)
+	require.Len(t, report.Diffs, 1)
+	assert.Equal(t, DiffTypeFailed, report.Diffs[0].Type)
+}
+
+func TestBackfillShardActivity_TargetStoreError(t *testing.T) {
+	env, deps := newActivityTest(t)
+	deps.expectListExecutions(nil, testOpenExecution())
+	deps.expectStartEvent(0)
+	deps.visibilityMgr.EXPECT().ListOpenWorkflowExecutionsByWorkflowID(gomock.Any(), gomock.Any()).Return(nil, &types.InternalServiceError{Message: "boom"})
+
+	_, err := executeShardActivity(t, env, testShardParams(nil))
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestBackfillShardActivity_ResumeFromHeartbeat(t *testing.T) {
+	env, deps := newActivityTest(t)
+	env.SetHeartbeatDetails(shardHeartbeatDetails{
+		PageToken: []byte("page-2"),
+		Report:    Report{Scanned: 10, Consistent: 10},
+	})
+	deps.expectListExecutions([]byte("page-2"), testOpenExecution())
+	deps.expectStartEvent(0)
+	deps.expectOpenRecords(testRecord(nil))
+
+	report, err := executeShardActivity(t, env, testShardParams(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), report.Scanned)
+	assert.Equal(t, int64(11), report.Consistent)
+}
+
+func TestBackfillShardActivity_VisibilityNotConfigured(t *testing.T) {
+	ts := &testsuite.WorkflowTestSuite{}
+	env := ts.NewTestActivityEnvironment()
+	b := &backfiller{logger: testlogger.New(t)}
+	env.RegisterActivityWithOptions(b.BackfillShardActivity, activity.RegisterOptions{Name: backfillShardActivity})
+
+	_, err := env.ExecuteActivity(backfillShardActivity, testShardParams(nil))
+	assert.ErrorContains(t, err, ErrVisibilityNotConfiguredNonRetryable)
+}
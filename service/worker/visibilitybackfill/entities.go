@@ -0,0 +1,130 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+const (
+	// DiffTypeMissing means the target store has no record of the execution
+	DiffTypeMissing DiffType = "missing"
+	// DiffTypeStale means the execution is closed but the target store only has its open record
+	DiffTypeStale DiffType = "stale"
+	// DiffTypeMismatched means the record in the target store disagrees with the primary store
+	DiffTypeMismatched DiffType = "mismatched"
+	// DiffTypeFailed means the visibility record of the execution could not be rebuilt from the primary store
+	DiffTypeFailed DiffType = "failed"
+
+	// maxDiffSamples bounds the number of diffs kept in a report so that it fits into workflow results and queries
+	maxDiffSamples = 100
+)
+
+type (
+	// BackfillParams contains the parameters of the visibility backfill workflow.
+	BackfillParams struct {
+		// TargetStore is the visibility store to replay records into: "db", "es", "os" or "pinot"
+		TargetStore string `json:"target_store"`
+		// StartShardID and EndShardID are the inclusive range of history shards to scan
+		StartShardID int `json:"start_shard_id"`
+		EndShardID   int `json:"end_shard_id"`
+		// Domain limits the backfill to a single domain when set
+		Domain string `json:"domain,omitempty"`
+		// RPS is the total number of executions processed per second across all shards
+		RPS int `json:"rps"`
+		// Concurrency is the number of shards processed in parallel
+		Concurrency int `json:"concurrency"`
+		// PageSize is the number of executions read from a shard at a time
+		PageSize int `json:"page_size"`
+		// DryRun only compares the target store with the primary store and reports the diffs
+		DryRun bool `json:"dry_run,omitempty"`
+		// Force writes every record without comparing it with the target store first
+		Force bool `json:"force,omitempty"`
+		// Progress is set by the workflow itself when it continues as new
+		Progress *Report `json:"progress,omitempty"`
+	}
+
+	// Report is the consistency report of a visibility backfill.
+	// The workflow exposes it through the report query and returns it as the result.
+	Report struct {
+		// NextShardID is the checkpoint of the workflow, all shards before it have been processed
+		NextShardID     int   `json:"next_shard_id"`
+		ShardsCompleted int   `json:"shards_completed"`
+		FailedShards    []int `json:"failed_shards,omitempty"`
+		// Scanned is the number of executions read from the primary store, including the skipped ones
+		Scanned int64 `json:"scanned"`
+		// Skipped is the number of executions outside of the domain filter, of deleted domains or
+		// in a state that is never recorded in visibility
+		Skipped    int64 `json:"skipped"`
+		Consistent int64 `json:"consistent"`
+		Missing    int64 `json:"missing"`
+		Stale      int64 `json:"stale"`
+		Mismatched int64 `json:"mismatched"`
+		Failed     int64 `json:"failed"`
+		// Written is the number of records written into the target store
+		Written int64 `json:"written"`
+		// Diffs is a sample of the inconsistencies found, bounded by maxDiffSamples
+		Diffs []Diff `json:"diffs,omitempty"`
+	}
+
+	// DiffType is the kind of inconsistency between the primary store and the target store
+	DiffType string
+
+	// Diff describes the inconsistency of a single execution
+	Diff struct {
+		ShardID    int      `json:"shard_id"`
+		DomainID   string   `json:"domain_id"`
+		WorkflowID string   `json:"workflow_id"`
+		RunID      string   `json:"run_id"`
+		Type       DiffType `json:"type"`
+		Details    string   `json:"details,omitempty"`
+	}
+
+	shardActivityParams struct {
+		Params  BackfillParams `json:"params"`
+		ShardID int            `json:"shard_id"`
+	}
+
+	// shardHeartbeatDetails is the checkpoint of a shard activity: the page being processed
+	// and the report as of the beginning of that page
+	shardHeartbeatDetails struct {
+		PageToken []byte `json:"page_token,omitempty"`
+		Report    Report `json:"report"`
+	}
+)
+
+// merge adds the counters and diffs of a shard report into r
+func (r *Report) merge(other *Report) {
+	r.ShardsCompleted += other.ShardsCompleted
+	r.Scanned += other.Scanned
+	r.Skipped += other.Skipped
+	r.Consistent += other.Consistent
+	r.Missing += other.Missing
+	r.Stale += other.Stale
+	r.Mismatched += other.Mismatched
+	r.Failed += other.Failed
+	r.Written += other.Written
+	for _, diff := range other.Diffs {
+		r.addDiff(diff)
+	}
+}
+
+func (r *Report) addDiff(diff Diff) {
+	if len(r.Diffs) < maxDiffSamples {
+		r.Diffs = append(r.Diffs, diff)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"github.com/opentracing/opentracing-go"
+	"github.com/uber-go/tally"
+	"go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/worker"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/log"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// Backfiller hosts the workflow replaying visibility records of existing executions into a visibility store
+	Backfiller interface {
+		Start() error
+		Stop()
+	}
+
+	// ExecutionManagerProvider returns the execution manager of a history shard
+	ExecutionManagerProvider interface {
+		GetExecutionManager(shardID int) (persistence.ExecutionManager, error)
+	}
+
+	backfiller struct {
+		svcClient         workflowserviceclient.Interface
+		visibilityMgr     persistence.VisibilityManager
+		historyMgr        persistence.HistoryManager
+		shardMgr          persistence.ShardManager
+		executionManagers ExecutionManagerProvider
+		domainCache       cache.DomainCache
+		timeSource        clock.TimeSource
+		worker            worker.Worker
+		tally             tally.Scope
+		logger            log.Logger
+	}
+
+	// Params contains the dependencies of the visibility backfill worker
+	Params struct {
+		ServiceClient     workflowserviceclient.Interface
+		VisibilityManager persistence.VisibilityManager
+		HistoryManager    persistence.HistoryManager
+		ShardManager      persistence.ShardManager
+		ExecutionManagers ExecutionManagerProvider
+		DomainCache       cache.DomainCache
+		TimeSource        clock.TimeSource
+		Tally             tally.Scope
+		Logger            log.Logger
+	}
+)
+
+// New creates a new visibility backfill worker.
+func New(params Params) Backfiller {
+	return &backfiller{
+		svcClient:         params.ServiceClient,
+		visibilityMgr:     params.VisibilityManager,
+		historyMgr:        params.HistoryManager,
+		shardMgr:          params.ShardManager,
+		executionManagers: params.ExecutionManagers,
+		domainCache:       params.DomainCache,
+		timeSource:        params.TimeSource,
+		tally:             params.Tally,
+		logger:            params.Logger,
+	}
+}
+
+// Start starts the worker
+func (b *backfiller) Start() error {
+	workerOpts := worker.Options{
+		MetricsScope:                     b.tally,
+		Tracer:                           opentracing.GlobalTracer(),
+		MaxConcurrentActivityTaskPollers: 4,
+		MaxConcurrentDecisionTaskPollers: 4,
+	}
+	newWorker := worker.New(b.svcClient, constants.SystemLocalDomainName, TaskListName, workerOpts)
+	newWorker.RegisterWorkflowWithOptions(b.VisibilityBackfillWorkflow, workflow.RegisterOptions{Name: WorkflowTypeName})
+	newWorker.RegisterActivityWithOptions(b.BackfillShardActivity, activity.RegisterOptions{Name: backfillShardActivity})
+	b.worker = newWorker
+	return newWorker.Start()
+}
+
+func (b *backfiller) Stop() {
+	b.worker.Stop()
+}
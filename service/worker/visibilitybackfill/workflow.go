@@ -0,0 +1,172 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/workflow"
+	"go.uber.org/zap"
+
+	"github.com/uber/cadence/common/constants"
+)
+
+const (
+	// WorkflowTypeName is the workflow type of the visibility backfill workflow
+	WorkflowTypeName = "cadence-sys-visibility-backfill-workflow"
+	// TaskListName is the task list of the visibility backfill workflow
+	TaskListName = "cadence-sys-visibility-backfill-tasklist"
+	// WorkflowID is the default workflow ID used by the admin CLI, so that only one backfill runs at a time
+	WorkflowID = "cadence-sys-visibility-backfill"
+	// QueryType is the query type returning the current Report of the workflow
+	QueryType = "report"
+
+	backfillShardActivity = "cadence-sys-visibility-backfill-shard-activity"
+
+	// ErrInvalidParamsNonRetryable is the error reason of invalid workflow parameters
+	ErrInvalidParamsNonRetryable = "invalid visibility backfill params"
+	// ErrVisibilityNotConfiguredNonRetryable is the error reason used when the worker has no visibility manager
+	ErrVisibilityNotConfiguredNonRetryable = "visibility is not configured on worker"
+
+	// DefaultRPS is the default RPS
+	DefaultRPS = 100
+	// DefaultConcurrency is the default number of shards processed in parallel
+	DefaultConcurrency = 4
+	// DefaultPageSize is the default page size
+	DefaultPageSize = 100
+
+	// shardsPerRun is the number of shards processed before the workflow continues as new to bound its history size
+	shardsPerRun = 500
+)
+
+var (
+	activityRetryPolicy = cadence.RetryPolicy{
+		InitialInterval:    10 * time.Second,
+		BackoffCoefficient: 1.7,
+		MaximumInterval:    5 * time.Minute,
+		ExpirationInterval: 24 * time.Hour,
+		NonRetriableErrorReasons: []string{
+			ErrInvalidParamsNonRetryable,
+			ErrVisibilityNotConfiguredNonRetryable,
+		},
+	}
+
+	activityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    24 * time.Hour,
+		HeartbeatTimeout:       time.Minute,
+		RetryPolicy:            &activityRetryPolicy,
+	}
+
+	validTargetStores = map[string]struct{}{
+		constants.VisibilityModeDB:    {},
+		constants.VisibilityModeES:    {},
+		constants.VisibilityModeOS:    {},
+		constants.VisibilityModePinot: {},
+	}
+)
+
+// VisibilityBackfillWorkflow scans executions of a range of shards and replays their visibility records into the target store.
+// Shards are processed in batches of params.Concurrency, a shard failing after all retries is recorded in the report
+// instead of failing the workflow so that it can be retried on its own.
+func (b *backfiller) VisibilityBackfillWorkflow(ctx workflow.Context, params BackfillParams) (*Report, error) {
+	logger := workflow.GetLogger(ctx)
+	params = setDefaultParams(params)
+	if err := validateParams(params); err != nil {
+		return nil, err
+	}
+
+	report := params.Progress
+	if report == nil {
+		report = &Report{NextShardID: params.StartShardID}
+	}
+	params.Progress = nil
+	err := workflow.SetQueryHandler(ctx, QueryType, func() (*Report, error) {
+		return report, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	activityCtx := workflow.WithActivityOptions(ctx, activityOptions)
+	runEndShardID := min(report.NextShardID+shardsPerRun, params.EndShardID+1)
+	for report.NextShardID < runEndShardID {
+		batchEndShardID := min(report.NextShardID+params.Concurrency, runEndShardID)
+		futures := make([]workflow.Future, 0, batchEndShardID-report.NextShardID)
+		for shardID := report.NextShardID; shardID < batchEndShardID; shardID++ {
+			futures = append(futures, workflow.ExecuteActivity(activityCtx, backfillShardActivity, shardActivityParams{
+				Params:  params,
+				ShardID: shardID,
+			}))
+		}
+
+		for i, future := range futures {
+			shardID := report.NextShardID + i
+			var shardReport Report
+			if err := future.Get(ctx, &shardReport); err != nil {
+				logger.Error("Failed to backfill visibility of shard", zap.Int("shard-id", shardID), zap.Error(err))
+				report.FailedShards = append(report.FailedShards, shardID)
+				continue
+			}
+			report.merge(&shardReport)
+		}
+		report.NextShardID = batchEndShardID
+	}
+
+	if report.NextShardID <= params.EndShardID {
+		params.Progress = report
+		return nil, workflow.NewContinueAsNewError(ctx, WorkflowTypeName, params)
+	}
+
+	logger.Info("Visibility backfill completed",
+		zap.String("target-store", params.TargetStore),
+		zap.Int("shards-completed", report.ShardsCompleted),
+		zap.Int("shards-failed", len(report.FailedShards)))
+	return report, nil
+}
+
+func setDefaultParams(params BackfillParams) BackfillParams {
+	if params.RPS <= 0 {
+		params.RPS = DefaultRPS
+	}
+	if params.Concurrency <= 0 {
+		params.Concurrency = DefaultConcurrency
+	}
+	if params.PageSize <= 0 {
+		params.PageSize = DefaultPageSize
+	}
+	return params
+}
+
+func validateParams(params BackfillParams) error {
+	if _, ok := validTargetStores[params.TargetStore]; !ok {
+		return cadence.NewCustomError(ErrInvalidParamsNonRetryable, fmt.Sprintf("unknown target store %q", params.TargetStore))
+	}
+	if params.StartShardID < 0 || params.EndShardID < params.StartShardID {
+		return cadence.NewCustomError(ErrInvalidParamsNonRetryable, fmt.Sprintf("invalid shard range [%d, %d]", params.StartShardID, params.EndShardID))
+	}
+	if params.DryRun && params.Force {
+		return cadence.NewCustomError(ErrInvalidParamsNonRetryable, "dry run and force cannot be used together")
+	}
+	return nil
+}
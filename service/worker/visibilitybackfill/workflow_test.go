@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package visibilitybackfill
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/testsuite"
+	"go.uber.org/cadence/workflow"
+
+	"github.com/uber/cadence/common/log/testlogger"
+)
+
+type visibilityBackfillWorkflowTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+	workflowEnv *testsuite.TestWorkflowEnvironment
+}
+
+func TestVisibilityBackfillWorkflowTestSuite(t *testing.T) {
+	suite.Run(t, new(visibilityBackfillWorkflowTestSuite))
+}
+
+func (s *visibilityBackfillWorkflowTestSuite) SetupTest() {
+	s.workflowEnv = s.NewTestWorkflowEnvironment()
+	b := &backfiller{logger: testlogger.New(s.T())}
+	s.workflowEnv.RegisterWorkflowWithOptions(b.VisibilityBackfillWorkflow, workflow.RegisterOptions{Name: WorkflowTypeName})
+	s.workflowEnv.RegisterActivityWithOptions(b.BackfillShardActivity, activity.RegisterOptions{Name: backfillShardActivity})
+}
+
+func (s *visibilityBackfillWorkflowTestSuite) TearDownTest() {
+	s.workflowEnv.AssertExpectations(s.T())
+}
+
+func (s *visibilityBackfillWorkflowTestSuite) TestWorkflow_Success() {
+	s.workflowEnv.OnActivity(backfillShardActivity, mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, params shardActivityParams) (*Report, error) {
+			s.Equal("es", params.Params.TargetStore)
+			s.Equal(DefaultRPS, params.Params.RPS)
+			s.Nil(params.Params.Progress)
+			if params.ShardID == 3 {
+				return nil, errors.New("shard failed")
+			}
+			return &Report{
+				ShardsCompleted: 1,
+				Scanned:         10,
+				Consistent:      8,
+				Missing:         2,
+				Written:         2,
+				Diffs:           []Diff{{ShardID: params.ShardID, Type: DiffTypeMissing}},
+			}, nil
+		}).Times(5)
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, BackfillParams{
+		TargetStore:  "es",
+		StartShardID: 1,
+		EndShardID:   5,
+		Concurrency:  2,
+	})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.NoError(s.workflowEnv.GetWorkflowError())
+
+	var report Report
+	s.NoError(s.workflowEnv.GetWorkflowResult(&report))
+	s.Equal(6, report.NextShardID)
+	s.Equal(4, report.ShardsCompleted)
+	s.Equal([]int{3}, report.FailedShards)
+	s.Equal(int64(40), report.Scanned)
+	s.Equal(int64(8), report.Missing)
+	s.Len(report.Diffs, 4)
+
+	queryResult, err := s.workflowEnv.QueryWorkflow(QueryType)
+	s.NoError(err)
+	var queried Report
+	s.NoError(queryResult.Get(&queried))
+	s.Equal(report, queried)
+}
+
+func (s *visibilityBackfillWorkflowTestSuite) TestWorkflow_ContinueAsNew() {
+	s.workflowEnv.OnActivity(backfillShardActivity, mock.Anything, mock.Anything).Return(&Report{ShardsCompleted: 1}, nil).Times(shardsPerRun)
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, BackfillParams{
+		TargetStore:  "pinot",
+		StartShardID: 0,
+		EndShardID:   shardsPerRun,
+		Concurrency:  50,
+	})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	var continueAsNewErr *workflow.ContinueAsNewError
+	s.ErrorAs(s.workflowEnv.GetWorkflowError(), &continueAsNewErr)
+}
+
+func (s *visibilityBackfillWorkflowTestSuite) TestWorkflow_ResumeFromProgress() {
+	s.workflowEnv.OnActivity(backfillShardActivity, mock.Anything, mock.MatchedBy(func(params shardActivityParams) bool {
+		return params.ShardID == 2
+	})).Return(&Report{ShardsCompleted: 1, Scanned: 5}, nil).Once()
+
+	s.workflowEnv.ExecuteWorkflow(WorkflowTypeName, BackfillParams{
+		TargetStore:  "db",
+		StartShardID: 0,
+		EndShardID:   2,
+		Progress:     &Report{NextShardID: 2, ShardsCompleted: 2, Scanned: 10},
+	})
+	s.True(s.workflowEnv.IsWorkflowCompleted())
+	s.NoError(s.workflowEnv.GetWorkflowError())
+
+	var report Report
+	s.NoError(s.workflowEnv.GetWorkflowResult(&report))
+	s.Equal(3, report.ShardsCompleted)
+	s.Equal(int64(15), report.Scanned)
+}
+
+func (s *visibilityBackfillWorkflowTestSuite) TestWorkflow_InvalidParams() {
+	for name, params := range map[string]BackfillParams{
+		"unknown target store": {TargetStore: "mongo", EndShardID: 1},
+		"invalid shard range":  {TargetStore: "es", StartShardID: 2, EndShardID: 1},
+		"dry run and force":    {TargetStore: "es", DryRun: true, Force: true},
+	} {
+		s.Run(name, func() {
+			env := s.NewTestWorkflowEnvironment()
+			b := &backfiller{logger: testlogger.New(s.T())}
+			env.RegisterWorkflowWithOptions(b.VisibilityBackfillWorkflow, workflow.RegisterOptions{Name: WorkflowTypeName})
+			env.ExecuteWorkflow(WorkflowTypeName, params)
+			s.True(env.IsWorkflowCompleted())
+			s.ErrorContains(env.GetWorkflowError(), ErrInvalidParamsNonRetryable)
+		})
+	}
+}
+
+func TestReportMerge(t *testing.T) {
+	report := &Report{Scanned: 1}
+	shardReport := &Report{ShardsCompleted: 1, Scanned: 2, Stale: 1, Written: 1}
+	for i := 0; i < maxDiffSamples; i++ {
+		shardReport.Diffs = append(shardReport.Diffs, Diff{Type: DiffTypeStale})
+	}
+
+	report.merge(shardReport)
+	report.merge(shardReport)
+
+	assert.Equal(t, 2, report.ShardsCompleted)
+	assert.Equal(t, int64(5), report.Scanned)
+	assert.Equal(t, int64(2), report.Stale)
+	assert.Equal(t, int64(2), report.Written)
+	assert.Len(t, report.Diffs, maxDiffSamples)
+}
@@ -30,6 +30,7 @@ import (
 
 	"github.com/uber/cadence/common/reconciliation/invariant"
 	"github.com/uber/cadence/service/worker/scanner/executions"
+	"github.com/uber/cadence/service/worker/visibilitybackfill"
 )
 
 func newAdminWorkflowCommands() []*cli.Command {
@@ -684,6 +685,103 @@ func newAdminElasticSearchCommands() []*cli.Command {
 	}
 }
 
+func newAdminVisibilityCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:        "backfill",
+			Aliases:     []string{"bf"},
+			Usage:       "Replay visibility records of existing executions from the primary store into a visibility store",
+			Subcommands: newAdminVisibilityBackfillCommands(),
+		},
+	}
+}
+
+func newAdminVisibilityBackfillCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:    "start",
+			Aliases: []string{"s"},
+			Usage:   "start visibility backfill workflow, requires worker.visibilityBackfillEnabled on the worker service",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagTargetStore,
+					Usage: "Visibility store to backfill. (Options: db, es, os, pinot)",
+				},
+				&cli.IntFlag{
+					Name:  FlagLowerShardBound,
+					Usage: "First history shard to backfill",
+				},
+				&cli.IntFlag{
+					Name:  FlagUpperShardBound,
+					Usage: "Last history shard to backfill (inclusive), usually numHistoryShards - 1",
+				},
+				&cli.StringFlag{
+					Name:    FlagDomain,
+					Aliases: []string{"do"},
+					Usage:   "Optional domain to backfill, all domains are backfilled when not provided",
+				},
+				&cli.IntFlag{
+					Name:  FlagRPS,
+					Usage: "Optional number of executions processed per second across all shards",
+					Value: visibilitybackfill.DefaultRPS,
+				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Usage: "Optional number of shards processed in parallel",
+					Value: visibilitybackfill.DefaultConcurrency,
+				},
+				&cli.IntFlag{
+					Name:    FlagPageSize,
+					Aliases: []string{"ps"},
+					Usage:   "Optional number of executions read from a shard at a time",
+					Value:   visibilitybackfill.DefaultPageSize,
+				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "Only compare the target store with the primary store and report the inconsistencies",
+				},
+				&cli.BoolFlag{
+					Name:    FlagForce,
+					Aliases: []string{"f"},
+					Usage:   "Write every record without comparing it with the target store first",
+				},
+			},
+			Action: AdminVisibilityBackfillStart,
+		},
+		{
+			Name:    "describe",
+			Aliases: []string{"d"},
+			Usage:   "show the consistency report of visibility backfill workflow",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagRunID,
+					Aliases: []string{"rid", "r"},
+					Usage:   "Optional run ID, the latest run is described when not provided",
+				},
+			},
+			Action: AdminVisibilityBackfillDescribe,
+		},
+		{
+			Name:    "abort",
+			Aliases: []string{"a"},
+			Usage:   "abort visibility backfill workflow",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    FlagReason,
+					Aliases: []string{"re"},
+					Usage:   "Optional reason why abort",
+				},
+				&cli.StringFlag{
+					Name:    FlagRunID,
+					Aliases: []string{"rid", "r"},
+					Usage:   "Optional run ID",
+				},
+			},
+			Action: AdminVisibilityBackfillAbort,
+		},
+	}
+}
+
 func newAdminTaskListCommands() []*cli.Command {
 	return []*cli.Command{
 		{
@@ -1013,6 +1111,49 @@ func newDBCommands() []*cli.Command {
 			),
 			Action: AdminDBClean,
 		},
+		{
+			Name:  "reshard",
+			Usage: "move a history shard to another database shard of a sharded SQL database, while the cluster keeps running",
+			Flags: append(getDBFlags(),
+				&cli.IntFlag{
+					Name:     FlagShardID,
+					Aliases:  []string{"sid"},
+					Usage:    "ID of the history shard to move",
+					Required: true,
+				},
+				&cli.IntFlag{
+					Name:     FlagTargetDBShard,
+					Usage:    "ID of the database shard to move the history shard to",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  FlagDeleteSource,
+					Usage: "delete the rows of the history shard from its previous database shard once it is moved and every host reloaded the placements, which takes about a minute",
+				},
+			),
+			Action: AdminDBReshard,
+		},
+		{
+			Name:  "index-search-attr",
+			Usage: "create the index of a registered search attribute in the SQL visibility database, so the queries on it don't scan the visibility table",
+			Flags: append(getDBFlags(),
+				&cli.StringFlag{
+					Name:     FlagSearchAttributesKey,
+					Usage:    "Search Attribute key to be indexed",
+					Required: true,
+				},
+				&cli.IntFlag{
+					Name:     FlagSearchAttributesType,
+					Usage:    "Search Attribute value type. [1:Keyword, 2:Int, 3:Double, 4:Bool, 5:Datetime]",
+					Required: true,
+				},
+				&cli.BoolFlag{
+					Name:  FlagDryRun,
+					Usage: "only print the statement creating the index",
+				},
+			),
+			Action: AdminDBIndexSearchAttribute,
+		},
 		{
 			Name:  "decode_thrift",
 			Usage: "decode thrift object, print into JSON if the data is matching with any supported struct",
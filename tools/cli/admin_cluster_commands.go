@@ -83,6 +83,7 @@ func AdminAddSearchAttribute(c *cli.Context) error {
 		return commoncli.Problem("Add search attribute failed.", err)
 	}
 	fmt.Println("Success. Note that for a multi-node Cadence cluster, DynamicConfig MUST be updated separately to whitelist the new attributes.")
+	fmt.Println("With SQL visibility, run `cadence admin db index-search-attr` to index the new attribute.")
 	return nil
 }
 
@@ -0,0 +1,82 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/common/visibility"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+// AdminDBIndexSearchAttribute creates the index of a search attribute in every database of the SQL visibility store.
+// The schema only indexes the default custom search attributes
+func AdminDBIndexSearchAttribute(c *cli.Context) error {
+	key, err := getRequiredOption(c, FlagSearchAttributesKey)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	if err := visibility.ValidateSearchAttributeKey(key); err != nil {
+		return commoncli.Problem("Invalid search-attribute key.", err)
+	}
+	valType, err := getRequiredIntOption(c, FlagSearchAttributesType)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	if !isValueTypeValid(valType) {
+		return commoncli.Problem("Unknown Search Attributes value type.", nil)
+	}
+	ctx, cancel, err := newTimedContext(c, defaultContextTimeoutForIndexSearchAttribute)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	dbs, err := getDeps(c).initializeSQLVisibilityAdminDBs(c)
+	if err != nil {
+		return commoncli.Problem("Error in Admin DB index search attribute: ", err)
+	}
+	defer func() {
+		for _, db := range dbs {
+			db.Close()
+		}
+	}()
+
+	output := getDeps(c).Output()
+	// every database of the visibility store uses the same plugin
+	stmt := dbs[0].VisibilityQueryDialect().SearchAttributeIndex(key, types.IndexedValueType(valType))
+	if stmt == "" {
+		return commoncli.Problem(fmt.Sprintf("%v search attributes can't be indexed in %v.", intValTypeToString(valType), dbs[0].PluginName()), nil)
+	}
+	fmt.Fprintf(output, "%v;\n", stmt)
+	if c.Bool(FlagDryRun) {
+		return nil
+	}
+	for i, db := range dbs {
+		if err := db.ExecSchemaOperationQuery(ctx, stmt); err != nil {
+			return commoncli.Problem(fmt.Sprintf("Failed to index search attribute %v in database %v.", key, i), err)
+		}
+	}
+	fmt.Fprintf(output, "Successfully indexed search attribute %v.\n", key)
+	return nil
+}
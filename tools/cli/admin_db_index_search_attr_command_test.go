@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/tools/cli/clitest"
+)
+
+func TestAdminDBIndexSearchAttribute(t *testing.T) {
+	const stmt = "CREATE INDEX by_order_id ON executions_visibility (domain_id, order_id)"
+
+	newMockAdminDB := func(td *cliTestData, valueType types.IndexedValueType, stmt string) *sqlplugin.MockAdminDB {
+		dialect := sqlplugin.NewMockVisibilityQueryDialect(td.ctrl)
+		dialect.EXPECT().SearchAttributeIndex("OrderID", valueType).Return(stmt).AnyTimes()
+		db := sqlplugin.NewMockAdminDB(td.ctrl)
+		db.EXPECT().VisibilityQueryDialect().Return(dialect).AnyTimes()
+		db.EXPECT().PluginName().Return("fakesql").AnyTimes()
+		db.EXPECT().Close().Return(nil)
+		return db
+	}
+
+	tests := []struct {
+		name           string
+		testSetup      func(td *cliTestData) *cli.Context
+		errContains    string // empty if no error is expected
+		expectedOutput string
+	}{
+		{
+			name: "no key argument",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app, clitest.IntArgument(FlagSearchAttributesType, 2))
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "invalid key",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagSearchAttributesKey, "order-id"),
+					clitest.IntArgument(FlagSearchAttributesType, 2),
+				)
+			},
+			errContains: "Invalid search-attribute key.",
+		},
+		{
+			name: "no type argument",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagSearchAttributesKey, "OrderID"))
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "unknown type",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagSearchAttributesKey, "OrderID"),
+					clitest.IntArgument(FlagSearchAttributesType, 6),
+				)
+			},
+			errContains: "Unknown Search Attributes value type.",
+		},
+		{
+			name: "failed to initialize database",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockManagerFactory.EXPECT().initializeSQLVisibilityAdminDBs(gomock.Any()).Return(nil, errors.New("critical error"))
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagSearchAttributesKey, "OrderID"),
+					clitest.IntArgument(FlagSearchAttributesType, 2),
+				)
+			},
+			errContains: "critical error",
+		},
+		{
+			name: "type can't be indexed",
+			testSetup: func(td *cliTestData) *cli.Context {
+				db := newMockAdminDB(td, types.IndexedValueTypeString, "")
+				td.mockManagerFactory.EXPECT().initializeSQLVisibilityAdminDBs(gomock.Any()).Return([]sqlplugin.AdminDB{db}, nil)
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagSearchAttributesKey, "OrderID"),
+					clitest.IntArgument(FlagSearchAttributesType, 0),
+				)
+			},
+			errContains: "String search attributes can't be indexed in fakesql.",
+		},
+		{
+			name: "dry run",
+			testSetup: func(td *cliTestData) *cli.Context {
+				db := newMockAdminDB(td, types.IndexedValueTypeInt, stmt)
+				td.mockManagerFactory.EXPECT().initializeSQLVisibilityAdminDBs(gomock.Any()).Return([]sqlplugin.AdminDB{db}, nil)
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagSearchAttributesKey, "OrderID"),
+					clitest.IntArgument(FlagSearchAttributesType, 2),
+					clitest.BoolArgument(FlagDryRun, true),
+				)
+			},
+			expectedOutput: stmt + ";\n",
+		},
+		{
+			name: "failed to create the index",
+			testSetup: func(td *cliTestData) *cli.Context {
+				db := newMockAdminDB(td, types.IndexedValueTypeInt, stmt)
+				db.EXPECT().ExecSchemaOperationQuery(gomock.Any(), stmt).Return(errors.New("duplicate key name"))
+				td.mockManagerFactory.EXPECT().initializeSQLVisibilityAdminDBs(gomock.Any()).Return([]sqlplugin.AdminDB{db}, nil)
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagSearchAttributesKey, "OrderID"),
+					clitest.IntArgument(FlagSearchAttributesType, 2),
+				)
+			},
+			errContains:    "duplicate key name",
+			expectedOutput: stmt + ";\n",
+		},
+		{
+			name: "index is created in every database",
+			testSetup: func(td *cliTestData) *cli.Context {
+				var dbs []sqlplugin.AdminDB
+				for i := 0; i < 2; i++ {
+					db := newMockAdminDB(td, types.IndexedValueTypeInt, stmt)
+					db.EXPECT().ExecSchemaOperationQuery(gomock.Any(), stmt).Return(nil)
+					dbs = append(dbs, db)
+				}
+				td.mockManagerFactory.EXPECT().initializeSQLVisibilityAdminDBs(gomock.Any()).Return(dbs, nil)
+				return clitest.NewCLIContext(t, td.app,
+					clitest.StringArgument(FlagSearchAttributesKey, "OrderID"),
+					clitest.IntArgument(FlagSearchAttributesType, 2),
+				)
+			},
+			expectedOutput: stmt + ";\n" +
+				"Successfully indexed search attribute OrderID.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			cliCtx := tt.testSetup(td)
+
+			err := AdminDBIndexSearchAttribute(cliCtx)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+			assert.Equal(t, tt.expectedOutput, td.consoleOutput())
+		})
+	}
+}
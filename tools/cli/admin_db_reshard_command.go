@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common/clock"
+	"github.com/uber/cadence/common/persistence/sql/resharding"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+// AdminDBReshard moves a history shard to another database shard of a sharded SQL database
+func AdminDBReshard(c *cli.Context) error {
+	shardID, err := getRequiredIntOption(c, FlagShardID)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	targetDBShard, err := getRequiredIntOption(c, FlagTargetDBShard)
+	if err != nil {
+		return commoncli.Problem("Required flag not found", err)
+	}
+	ctx, cancel, err := newTimedContext(c, defaultContextTimeoutForReshard)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	db, err := getDeps(c).initializeSQLDB(c)
+	if err != nil {
+		return commoncli.Problem("Error in Admin DB reshard: ", err)
+	}
+	defer db.Close()
+
+	output := getDeps(c).Output()
+	mover := resharding.NewMover(db, clock.NewRealTimeSource(), func(p resharding.Progress) {
+		switch p.Phase {
+		case resharding.PhaseCopy, resharding.PhaseFinalCopy:
+			fmt.Fprintf(output, "[%v] copied %v rows of %v\n", p.Phase, p.Rows, p.Table)
+		case resharding.PhaseFreeze:
+			fmt.Fprintf(output, "[%v] fenced shard %v with range ID %v\n", p.Phase, shardID, p.RangeID)
+		default:
+			fmt.Fprintf(output, "[%v]\n", p.Phase)
+		}
+	})
+	err = mover.Move(ctx, resharding.MoveRequest{
+		HistoryShardID:  shardID,
+		TargetDBShardID: targetDBShard,
+		DeleteSource:    c.Bool(FlagDeleteSource),
+	})
+	if err != nil {
+		return commoncli.Problem(fmt.Sprintf("Failed to move shard %v to database shard %v.", shardID, targetDBShard), err)
+	}
+	fmt.Fprintf(output, "Successfully moved shard %v to database shard %v.\n", shardID, targetDBShard)
+	return nil
+}
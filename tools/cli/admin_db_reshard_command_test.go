@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
+	"github.com/uber/cadence/tools/cli/clitest"
+)
+
+func TestAdminDBReshard(t *testing.T) {
+	tests := []struct {
+		name           string
+		testSetup      func(td *cliTestData) *cli.Context
+		errContains    string // empty if no error is expected
+		expectedOutput string
+	}{
+		{
+			name: "no shardID argument",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app, clitest.IntArgument(FlagTargetDBShard, 1))
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "no target database shard argument",
+			testSetup: func(td *cliTestData) *cli.Context {
+				return clitest.NewCLIContext(t, td.app, clitest.IntArgument(FlagShardID, testShardID))
+			},
+			errContains: "Required flag not found",
+		},
+		{
+			name: "failed to initialize database",
+			testSetup: func(td *cliTestData) *cli.Context {
+				td.mockManagerFactory.EXPECT().initializeSQLDB(gomock.Any()).Return(nil, errors.New("critical error"))
+				return clitest.NewCLIContext(t, td.app,
+					clitest.IntArgument(FlagShardID, testShardID),
+					clitest.IntArgument(FlagTargetDBShard, 1),
+				)
+			},
+			errContains: "critical error",
+		},
+		{
+			name: "shard is moved",
+			testSetup: func(td *cliTestData) *cli.Context {
+				mockDB := sqlplugin.NewMockDB(td.ctrl)
+				mockDB.EXPECT().GetTotalNumDBShards().Return(2).AnyTimes()
+				mockDB.EXPECT().RefreshHistoryShardPlacement(gomock.Any(), testShardID).Return(nil, nil)
+				mockDB.EXPECT().ReplaceIntoHistoryShardPlacements(gomock.Any(), gomock.Any()).Return(nil, nil).Times(3)
+				mockDB.EXPECT().CopyHistoryShardRows(gomock.Any(), testShardID, 0, 1, gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, _, _ int, progress func(string, int)) error {
+						progress("executions", 3)
+						return nil
+					}).Times(2)
+				mockDB.EXPECT().FenceHistoryShard(gomock.Any(), testShardID, 0).Return(int64(5), nil)
+				mockDB.EXPECT().ChecksumHistoryShardRows(gomock.Any(), testShardID, gomock.Any()).Return(nil, nil).Times(2)
+				mockDB.EXPECT().Close().Return(nil)
+				td.mockManagerFactory.EXPECT().initializeSQLDB(gomock.Any()).Return(mockDB, nil)
+				return clitest.NewCLIContext(t, td.app,
+					clitest.IntArgument(FlagShardID, testShardID),
+					clitest.IntArgument(FlagTargetDBShard, 1),
+				)
+			},
+			expectedOutput: "[copy] copied 3 rows of executions\n" +
+				"[freeze] fenced shard 1234 with range ID 5\n" +
+				"[final-copy] copied 3 rows of executions\n" +
+				"[verify]\n" +
+				"[cutover]\n" +
+				"[done]\n" +
+				"Successfully moved shard 1234 to database shard 1.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			cliCtx := tt.testSetup(td)
+
+			err := AdminDBReshard(cliCtx)
+			if tt.errContains == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+			assert.Equal(t, tt.expectedOutput, td.consoleOutput())
+		})
+	}
+}
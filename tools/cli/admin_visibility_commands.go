@@ -0,0 +1,197 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pborman/uuid"
+	"github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/visibilitybackfill"
+	"github.com/uber/cadence/tools/common/commoncli"
+)
+
+const (
+	defaultVisibilityBackfillAbortReason            = "Visibility backfill aborted through admin CLI"
+	defaultVisibilityBackfillWorkflowTimeoutSeconds = 30 * 24 * 60 * 60
+)
+
+// AdminVisibilityBackfillStart starts the visibility backfill workflow
+func AdminVisibilityBackfillStart(c *cli.Context) error {
+	targetStore, err := getRequiredOption(c, FlagTargetStore)
+	if err != nil {
+		return commoncli.Problem("Required flag not found: ", err)
+	}
+	if !c.IsSet(FlagLowerShardBound) || !c.IsSet(FlagUpperShardBound) {
+		return commoncli.Problem(fmt.Sprintf("Required flags not found: --%s and --%s", FlagLowerShardBound, FlagUpperShardBound), nil)
+	}
+	params := visibilitybackfill.BackfillParams{
+		TargetStore:  targetStore,
+		StartShardID: c.Int(FlagLowerShardBound),
+		EndShardID:   c.Int(FlagUpperShardBound),
+		Domain:       c.String(FlagDomain),
+		RPS:          c.Int(FlagRPS),
+		Concurrency:  c.Int(FlagConcurrency),
+		PageSize:     c.Int(FlagPageSize),
+		DryRun:       c.Bool(FlagDryRun),
+		Force:        c.Bool(FlagForce),
+	}
+	if params.StartShardID < 0 || params.StartShardID > params.EndShardID {
+		return commoncli.Problem(fmt.Sprintf("Invalid shard range [%d, %d]", params.StartShardID, params.EndShardID), nil)
+	}
+	if params.DryRun && params.Force {
+		return commoncli.Problem(fmt.Sprintf("--%s and --%s cannot be used together", FlagDryRun, FlagForce), nil)
+	}
+	input, err := json.Marshal(params)
+	if err != nil {
+		return commoncli.Problem("Failed to serialize visibility backfill params", err)
+	}
+
+	client, err := getCadenceClient(c)
+	if err != nil {
+		return err
+	}
+	tcCtx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	op, err := getOperatorFn()
+	if err != nil {
+		return commoncli.Problem("Error in getting operator: ", err)
+	}
+	memo, err := getWorkflowMemo(map[string]interface{}{
+		constants.MemoKeyForOperator: op,
+	})
+	if err != nil {
+		return commoncli.Problem("Failed to serialize memo", err)
+	}
+	request := &types.StartWorkflowExecutionRequest{
+		Domain:                              constants.SystemLocalDomainName,
+		RequestID:                           uuid.New(),
+		WorkflowID:                          visibilitybackfill.WorkflowID,
+		WorkflowIDReusePolicy:               types.WorkflowIDReusePolicyAllowDuplicate.Ptr(),
+		TaskList:                            &types.TaskList{Name: visibilitybackfill.TaskListName},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(defaultVisibilityBackfillWorkflowTimeoutSeconds),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(defaultDecisionTimeoutInSeconds),
+		Identity:                            getCliIdentity(),
+		Input:                               input,
+		Memo:                                memo,
+		WorkflowType:                        &types.WorkflowType{Name: visibilitybackfill.WorkflowTypeName},
+	}
+	resp, err := client.StartWorkflowExecution(tcCtx, request)
+	if err != nil {
+		return commoncli.Problem("Failed to start visibility backfill workflow", err)
+	}
+
+	output := getDeps(c).Output()
+	output.Write([]byte("Visibility backfill workflow started\n"))
+	output.Write([]byte("wid: " + visibilitybackfill.WorkflowID + "\n"))
+	output.Write([]byte("rid: " + resp.GetRunID() + "\n"))
+	return nil
+}
+
+// AdminVisibilityBackfillDescribe prints the progress report of the visibility backfill workflow
+func AdminVisibilityBackfillDescribe(c *cli.Context) error {
+	client, err := getCadenceClient(c)
+	if err != nil {
+		return err
+	}
+	tcCtx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	report, err := queryVisibilityBackfill(tcCtx, client, getRunID(c))
+	if err != nil {
+		return err
+	}
+	prettyPrintJSONObject(getDeps(c).Output(), report)
+	return nil
+}
+
+// AdminVisibilityBackfillAbort terminates the visibility backfill workflow
+func AdminVisibilityBackfillAbort(c *cli.Context) error {
+	client, err := getCadenceClient(c)
+	if err != nil {
+		return err
+	}
+	tcCtx, cancel, err := newContext(c)
+	defer cancel()
+	if err != nil {
+		return commoncli.Problem("Error in creating context: ", err)
+	}
+	reason := c.String(FlagReason)
+	if len(reason) == 0 {
+		reason = defaultVisibilityBackfillAbortReason
+	}
+	request := &types.TerminateWorkflowExecutionRequest{
+		Domain: constants.SystemLocalDomainName,
+		WorkflowExecution: &types.WorkflowExecution{
+			WorkflowID: visibilitybackfill.WorkflowID,
+			RunID:      getRunID(c),
+		},
+		Reason:   reason,
+		Identity: getCliIdentity(),
+	}
+	if err := client.TerminateWorkflowExecution(tcCtx, request); err != nil {
+		return commoncli.Problem("Failed to abort visibility backfill workflow", err)
+	}
+
+	getDeps(c).Output().Write([]byte("Visibility backfill aborted\n"))
+	return nil
+}
+
+func queryVisibilityBackfill(
+	tcCtx context.Context,
+	client frontend.Client,
+	runID string,
+) (*visibilitybackfill.Report, error) {
+	request := &types.QueryWorkflowRequest{
+		Domain: constants.SystemLocalDomainName,
+		Execution: &types.WorkflowExecution{
+			WorkflowID: visibilitybackfill.WorkflowID,
+			RunID:      runID,
+		},
+		Query: &types.WorkflowQuery{
+			QueryType: visibilitybackfill.QueryType,
+		},
+	}
+	queryResp, err := client.QueryWorkflow(tcCtx, request)
+	if err != nil {
+		return nil, commoncli.Problem("Failed to query visibility backfill workflow", err)
+	}
+	if queryResp.GetQueryResult() == nil {
+		return nil, commoncli.Problem("QueryResult has no value", nil)
+	}
+	var report visibilitybackfill.Report
+	if err := json.Unmarshal(queryResp.GetQueryResult(), &report); err != nil {
+		return nil, commoncli.Problem("Unable to deserialize QueryResult", err)
+	}
+	return &report, nil
+}
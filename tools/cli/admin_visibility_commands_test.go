@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"go.uber.org/yarpc"
+
+	"github.com/uber/cadence/common/constants"
+	"github.com/uber/cadence/common/types"
+	"github.com/uber/cadence/service/worker/visibilitybackfill"
+	"github.com/uber/cadence/tools/cli/clitest"
+)
+
+func TestAdminVisibilityBackfillStart(t *testing.T) {
+	oldGetOperatorFn := getOperatorFn
+	getOperatorFn = func() (string, error) { return "test-user", nil }
+	defer func() {
+		getOperatorFn = oldGetOperatorFn
+	}()
+
+	tests := []struct {
+		name           string
+		args           []clitest.CliArgument
+		mockSetup      func(t *testing.T, td *cliTestData)
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name: "Success",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagTargetStore, "es"),
+				clitest.IntArgument(FlagLowerShardBound, 0),
+				clitest.IntArgument(FlagUpperShardBound, 15),
+				clitest.StringArgument(FlagDomain, testDomain),
+				clitest.IntArgument(FlagRPS, 50),
+				clitest.IntArgument(FlagConcurrency, 2),
+				clitest.IntArgument(FlagPageSize, 10),
+				clitest.BoolArgument(FlagDryRun, true),
+			},
+			mockSetup: func(t *testing.T, td *cliTestData) {
+				td.mockFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, req *types.StartWorkflowExecutionRequest, _ ...yarpc.CallOption) (*types.StartWorkflowExecutionResponse, error) {
+						assert.Equal(t, constants.SystemLocalDomainName, req.Domain)
+						assert.Equal(t, visibilitybackfill.WorkflowID, req.WorkflowID)
+						assert.Equal(t, visibilitybackfill.WorkflowTypeName, req.WorkflowType.GetName())
+						assert.Equal(t, visibilitybackfill.TaskListName, req.TaskList.GetName())
+
+						var params visibilitybackfill.BackfillParams
+						require.NoError(t, json.Unmarshal(req.Input, &params))
+						assert.Equal(t, visibilitybackfill.BackfillParams{
+							TargetStore:  "es",
+							StartShardID: 0,
+							EndShardID:   15,
+							Domain:       testDomain,
+							RPS:          50,
+							Concurrency:  2,
+							PageSize:     10,
+							DryRun:       true,
+						}, params)
+						return &types.StartWorkflowExecutionResponse{RunID: "test-run-id"}, nil
+					}).Times(1)
+			},
+			expectedOutput: "Visibility backfill workflow started\nwid: cadence-sys-visibility-backfill\nrid: test-run-id\n",
+		},
+		{
+			name: "MissingTargetStore",
+			args: []clitest.CliArgument{
+				clitest.IntArgument(FlagLowerShardBound, 0),
+				clitest.IntArgument(FlagUpperShardBound, 15),
+			},
+			mockSetup:     func(t *testing.T, td *cliTestData) {},
+			expectedError: "Required flag not found",
+		},
+		{
+			name: "MissingShardBounds",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagTargetStore, "es"),
+			},
+			mockSetup:     func(t *testing.T, td *cliTestData) {},
+			expectedError: "Required flags not found",
+		},
+		{
+			name: "InvalidShardRange",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagTargetStore, "es"),
+				clitest.IntArgument(FlagLowerShardBound, 10),
+				clitest.IntArgument(FlagUpperShardBound, 5),
+			},
+			mockSetup:     func(t *testing.T, td *cliTestData) {},
+			expectedError: "Invalid shard range",
+		},
+		{
+			name: "DryRunWithForce",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagTargetStore, "es"),
+				clitest.IntArgument(FlagLowerShardBound, 0),
+				clitest.IntArgument(FlagUpperShardBound, 15),
+				clitest.BoolArgument(FlagDryRun, true),
+				clitest.BoolArgument(FlagForce, true),
+			},
+			mockSetup:     func(t *testing.T, td *cliTestData) {},
+			expectedError: "cannot be used together",
+		},
+		{
+			name: "StartWorkflowExecutionError",
+			args: []clitest.CliArgument{
+				clitest.StringArgument(FlagTargetStore, "pinot"),
+				clitest.IntArgument(FlagLowerShardBound, 0),
+				clitest.IntArgument(FlagUpperShardBound, 15),
+			},
+			mockSetup: func(t *testing.T, td *cliTestData) {
+				td.mockFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("failed to start workflow")).Times(1)
+			},
+			expectedError: "Failed to start visibility backfill workflow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td := newCLITestData(t)
+			tt.mockSetup(t, td)
+
+			cliCtx := clitest.NewCLIContext(t, td.app, tt.args...)
+
+			err := AdminVisibilityBackfillStart(cliCtx)
+			if tt.expectedError != "" {
+				assert.ErrorContains(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.expectedOutput, td.consoleOutput())
+		})
+	}
+}
+
+func TestAdminVisibilityBackfillDescribe(t *testing.T) {
+	report := visibilitybackfill.Report{
+		NextShardID:     4,
+		ShardsCompleted: 3,
+		FailedShards:    []int{1},
+		Scanned:         100,
+		Missing:         2,
+		Written:         2,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		td := newCLITestData(t)
+		td.mockFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *types.QueryWorkflowRequest, _ ...yarpc.CallOption) (*types.QueryWorkflowResponse, error) {
+				assert.Equal(t, &types.QueryWorkflowRequest{
+					Domain: constants.SystemLocalDomainName,
+					Execution: &types.WorkflowExecution{
+						WorkflowID: visibilitybackfill.WorkflowID,
+						RunID:      "test-run-id",
+					},
+					Query: &types.WorkflowQuery{
+						QueryType: visibilitybackfill.QueryType,
+					},
+				}, req)
+				result, err := json.Marshal(report)
+				require.NoError(t, err)
+				return &types.QueryWorkflowResponse{QueryResult: result}, nil
+			}).Times(1)
+
+		cliCtx := clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagRunID, "test-run-id"))
+		require.NoError(t, AdminVisibilityBackfillDescribe(cliCtx))
+
+		var got visibilitybackfill.Report
+		require.NoError(t, json.Unmarshal([]byte(td.consoleOutput()), &got))
+		assert.Equal(t, report, got)
+	})
+
+	t.Run("QueryWorkflowError", func(t *testing.T) {
+		td := newCLITestData(t)
+		td.mockFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("failed to query workflow")).Times(1)
+
+		cliCtx := clitest.NewCLIContext(t, td.app)
+		assert.ErrorContains(t, AdminVisibilityBackfillDescribe(cliCtx), "Failed to query visibility backfill workflow")
+	})
+
+	t.Run("EmptyQueryResult", func(t *testing.T) {
+		td := newCLITestData(t)
+		td.mockFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(&types.QueryWorkflowResponse{}, nil).Times(1)
+
+		cliCtx := clitest.NewCLIContext(t, td.app)
+		assert.ErrorContains(t, AdminVisibilityBackfillDescribe(cliCtx), "QueryResult has no value")
+	})
+}
+
+func TestAdminVisibilityBackfillAbort(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		td := newCLITestData(t)
+		td.mockFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *types.TerminateWorkflowExecutionRequest, _ ...yarpc.CallOption) error {
+				assert.Equal(t, constants.SystemLocalDomainName, req.Domain)
+				assert.Equal(t, &types.WorkflowExecution{WorkflowID: visibilitybackfill.WorkflowID}, req.WorkflowExecution)
+				assert.Equal(t, defaultVisibilityBackfillAbortReason, req.Reason)
+				return nil
+			}).Times(1)
+
+		cliCtx := clitest.NewCLIContext(t, td.app)
+		require.NoError(t, AdminVisibilityBackfillAbort(cliCtx))
+		assert.Equal(t, "Visibility backfill aborted\n", td.consoleOutput())
+	})
+
+	t.Run("TerminateError", func(t *testing.T) {
+		td := newCLITestData(t)
+		td.mockFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).Return(fmt.Errorf("terminate failed")).Times(1)
+
+		cliCtx := clitest.NewCLIContext(t, td.app, clitest.StringArgument(FlagReason, "test"))
+		assert.ErrorContains(t, AdminVisibilityBackfillAbort(cliCtx), "Failed to abort visibility backfill workflow")
+	})
+}
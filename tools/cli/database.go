@@ -37,6 +37,7 @@ import (
 	"github.com/uber/cadence/common/persistence/client"
 	"github.com/uber/cadence/common/persistence/nosql/nosqlplugin/cassandra"
 	"github.com/uber/cadence/common/persistence/sql"
+	"github.com/uber/cadence/common/persistence/sql/sqlplugin"
 	"github.com/uber/cadence/common/reconciliation/invariant"
 	"github.com/uber/cadence/tools/common/flag"
 )
@@ -158,6 +159,8 @@ type ManagerFactory interface {
 	initializeHistoryManager(c *cli.Context) (persistence.HistoryManager, error)
 	initializeShardManager(c *cli.Context) (persistence.ShardManager, error)
 	initializeDomainManager(c *cli.Context) (persistence.DomainManager, error)
+	initializeSQLDB(c *cli.Context) (sqlplugin.DB, error)
+	initializeSQLVisibilityAdminDBs(c *cli.Context) ([]sqlplugin.AdminDB, error)
 	initPersistenceFactory(c *cli.Context) (client.Factory, error)
 	initializeInvariantManager(ivs []invariant.Invariant) (invariant.Manager, error)
 }
@@ -214,6 +217,66 @@ func (f *defaultManagerFactory) initializeDomainManager(c *cli.Context) (persist
 	return domainManager, nil
 }
 
+func (f *defaultManagerFactory) initializeSQLDB(c *cli.Context) (sqlplugin.DB, error) {
+	cfg, err := getDeps(c).ServerConfig(c)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load server config: %w", err)
+	}
+	defaultStore, err := overrideDataStore(c, cfg.Persistence.DataStores[cfg.Persistence.DefaultStore])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize SQL database: %w", err)
+	}
+	if defaultStore.SQL == nil {
+		return nil, fmt.Errorf("Default data store is not a SQL database")
+	}
+	db, err := sql.NewSQLDB(defaultStore.SQL)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize SQL database: %w", err)
+	}
+	return db, nil
+}
+
+// initializeSQLVisibilityAdminDBs connects to every database of the visibility store one by one,
+// because the sharded SQL driver doesn't run schema operations
+func (f *defaultManagerFactory) initializeSQLVisibilityAdminDBs(c *cli.Context) ([]sqlplugin.AdminDB, error) {
+	cfg, err := getDeps(c).ServerConfig(c)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load server config: %w", err)
+	}
+	visibilityStore, err := overrideDataStore(c, cfg.Persistence.DataStores[cfg.Persistence.VisibilityStore])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to initialize SQL database: %w", err)
+	}
+	if visibilityStore.SQL == nil {
+		return nil, fmt.Errorf("Visibility data store is not a SQL database")
+	}
+	sqlConfigs := []config.SQL{*visibilityStore.SQL}
+	if visibilityStore.SQL.UseMultipleDatabases {
+		sqlConfigs = sqlConfigs[:0]
+		for _, entry := range visibilityStore.SQL.MultipleDatabasesConfig {
+			sqlConfig := *visibilityStore.SQL
+			sqlConfig.UseMultipleDatabases = false
+			sqlConfig.User = entry.User
+			sqlConfig.Password = entry.Password
+			sqlConfig.DatabaseName = entry.DatabaseName
+			sqlConfig.ConnectAddr = entry.ConnectAddr
+			sqlConfigs = append(sqlConfigs, sqlConfig)
+		}
+	}
+	dbs := make([]sqlplugin.AdminDB, 0, len(sqlConfigs))
+	for i := range sqlConfigs {
+		db, err := sql.NewSQLAdminDB(&sqlConfigs[i])
+		if err != nil {
+			for _, db := range dbs {
+				db.Close()
+			}
+			return nil, fmt.Errorf("Failed to initialize SQL database %v: %w", sqlConfigs[i].DatabaseName, err)
+		}
+		dbs = append(dbs, db)
+	}
+	return dbs, nil
+}
+
 func (f *defaultManagerFactory) getPersistenceFactory(c *cli.Context) (client.Factory, error) {
 	var err error
 	if f.persistenceFactory == nil {
@@ -260,6 +323,7 @@ func (f *defaultManagerFactory) initPersistenceFactory(c *cli.Context) (client.F
 		func() float64 { return rps },
 		cfg.ClusterGroupMetadata.CurrentClusterName,
 		metrics.NewNoopMetricsClient(),
+		nil,
 		log.NewNoop(),
 		&persistence.DynamicConfiguration{
 			EnableSQLAsyncTransaction: dynamicproperties.GetBoolPropertyFn(false),
@@ -60,6 +60,8 @@ const (
 	defaultContextTimeout                        = defaultContextTimeoutInSeconds * time.Second
 	defaultContextTimeoutForLongPoll             = 2 * time.Minute
 	defaultContextTimeoutForListArchivedWorkflow = 3 * time.Minute
+	defaultContextTimeoutForReshard              = time.Hour
+	defaultContextTimeoutForIndexSearchAttribute = time.Hour
 
 	defaultDecisionTimeoutInSeconds = 10
 	defaultPageSizeForList          = 500
@@ -39,6 +39,8 @@ const (
 	FlagDBPort                         = "db_port"
 	FlagDBRegion                       = "db_region"
 	FlagDBShard                        = "db_shard"
+	FlagTargetDBShard                  = "target_db_shard"
+	FlagDeleteSource                   = "delete_source"
 	FlagProtoVersion                   = "protocol_version"
 	FlagDomainID                       = "domain_id"
 	FlagDomain                         = "domain"
@@ -71,6 +73,8 @@ const (
 	FlagCatchUpWindow                  = "catch_up_window"
 	FlagPauseOnFailure                 = "pause_on_failure"
 	FlagBufferLimit                    = "buffer_limit"
+	FlagCronChainAction                = "old_chain"
+	FlagScheduleIDPrefix               = "schedule_id_prefix"
 	FlagCronSchedule                   = "cron"
 	FlagWorkflowType                   = "workflow_type"
 	FlagWorkflowStatus                 = "status"
@@ -247,6 +251,7 @@ const (
 	FlagClusterAttributeName           = "cluster_attribute_name"
 	FlagClusterAttributesJSON          = "cluster_attributes_json"
 	FlagBatchV2                        = "v2"
+	FlagTargetStore                    = "target_store"
 
 	FlagClustersUsage = "Clusters (example: --clusters clusterA,clusterB or --cl clusterA --cl clusterB)"
 )
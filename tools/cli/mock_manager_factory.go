@@ -17,6 +17,7 @@ import (
 
 	persistence "github.com/uber/cadence/common/persistence"
 	client "github.com/uber/cadence/common/persistence/client"
+	sqlplugin "github.com/uber/cadence/common/persistence/sql/sqlplugin"
 	invariant "github.com/uber/cadence/common/reconciliation/invariant"
 )
 
@@ -119,6 +120,36 @@ func (mr *MockManagerFactoryMockRecorder) initializeInvariantManager(ivs any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initializeInvariantManager", reflect.TypeOf((*MockManagerFactory)(nil).initializeInvariantManager), ivs)
 }
 
+// initializeSQLDB mocks base method.
+func (m *MockManagerFactory) initializeSQLDB(c *cli.Context) (sqlplugin.DB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "initializeSQLDB", c)
+	ret0, _ := ret[0].(sqlplugin.DB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// initializeSQLDB indicates an expected call of initializeSQLDB.
+func (mr *MockManagerFactoryMockRecorder) initializeSQLDB(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initializeSQLDB", reflect.TypeOf((*MockManagerFactory)(nil).initializeSQLDB), c)
+}
+
+// initializeSQLVisibilityAdminDBs mocks base method.
+func (m *MockManagerFactory) initializeSQLVisibilityAdminDBs(c *cli.Context) ([]sqlplugin.AdminDB, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "initializeSQLVisibilityAdminDBs", c)
+	ret0, _ := ret[0].([]sqlplugin.AdminDB)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// initializeSQLVisibilityAdminDBs indicates an expected call of initializeSQLVisibilityAdminDBs.
+func (mr *MockManagerFactoryMockRecorder) initializeSQLVisibilityAdminDBs(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "initializeSQLVisibilityAdminDBs", reflect.TypeOf((*MockManagerFactory)(nil).initializeSQLVisibilityAdminDBs), c)
+}
+
 // initializeShardManager mocks base method.
 func (m *MockManagerFactory) initializeShardManager(c *cli.Context) (persistence.ShardManager, error) {
 	m.ctrl.T.Helper()
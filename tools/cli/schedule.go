@@ -263,6 +263,32 @@ var (
 		scheduleIDFlag,
 	}
 
+	migrateCronFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  FlagWorkflowType,
+			Usage: "Only migrate cron workflows of this type",
+		},
+		&cli.StringFlag{
+			Name:  FlagScheduleIDPrefix,
+			Usage: "Prefix for the new schedule IDs; each schedule ID is this prefix plus the cron workflow ID",
+		},
+		&cli.StringFlag{
+			Name:  FlagCronChainAction,
+			Usage: "What to do with each old cron chain after its schedule is created: terminate, drain (wait for the current run to finish, then stop the chain), keep",
+			Value: "terminate",
+		},
+		&cli.BoolFlag{
+			Name:  FlagDryRun,
+			Usage: "Report what would be migrated without creating schedules or touching workflows",
+		},
+		&cli.IntFlag{
+			Name:    FlagPageSize,
+			Aliases: []string{"ps"},
+			Usage:   "Page size for listing open workflows",
+			Value:   100,
+		},
+	}
+
 	listScheduleFlags = []cli.Flag{
 		&cli.IntFlag{
 			Name:    FlagPageSize,
@@ -373,6 +399,19 @@ func newScheduleCommands() []*cli.Command {
 				})
 			},
 		},
+		{
+			Name:  "migrate-cron",
+			Usage: "Replace open cron workflows in a domain with equivalent schedules",
+			Flags: migrateCronFlags,
+			Action: func(c *cli.Context) error {
+				if err := checkNoAdditionalArgsPassed(c); err != nil {
+					return err
+				}
+				return withScheduleClient(c, func(sc *scheduleCLIImpl) error {
+					return sc.MigrateCronWorkflows(c)
+				})
+			},
+		},
 		{
 			Name:    "list",
 			Aliases: []string{"l"},
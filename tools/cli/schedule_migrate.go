@@ -0,0 +1,376 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	cli "github.com/urfave/cli/v2"
+
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+	commoncli "github.com/uber/cadence/tools/common/commoncli"
+)
+
+// What migrate-cron does with the old cron chain once its schedule exists.
+const (
+	cronChainTerminate = "terminate"
+	cronChainDrain     = "drain"
+	cronChainKeep      = "keep"
+)
+
+var cronChainOutcome = map[string]string{
+	cronChainTerminate: "old chain terminated",
+	cronChainDrain:     "old chain drained",
+	cronChainKeep:      "old chain kept",
+}
+
+// cronMigration is one row of the migrate-cron report.
+type cronMigration struct {
+	WorkflowID   string
+	WorkflowType string
+	Cron         string
+	ScheduleID   string
+	Overlap      types.ScheduleOverlapPolicy
+	Result       string
+
+	// runID is the current run of a chain waiting to be drained
+	runID    string
+	draining bool
+}
+
+// MigrateCronWorkflows turns every open cron workflow in the domain into a
+// schedule with the same cron, start action and overlap behavior, then stops
+// the old cron chain. With --dry_run it only reports what it would do.
+func (sc *scheduleCLIImpl) MigrateCronWorkflows(c *cli.Context) error {
+	domain, err := getRequiredOption(c, FlagDomain)
+	if err != nil {
+		return err
+	}
+	chainAction := strings.ToLower(c.String(FlagCronChainAction))
+	switch chainAction {
+	case cronChainTerminate, cronChainDrain, cronChainKeep:
+	default:
+		return commoncli.Problem(fmt.Sprintf("Unknown --%s %q. Valid: terminate, drain, keep", FlagCronChainAction, chainAction), nil)
+	}
+	dryRun := c.Bool(FlagDryRun)
+	idPrefix := c.String(FlagScheduleIDPrefix)
+
+	executions, err := sc.listOpenCronWorkflows(c, domain, c.String(FlagWorkflowType))
+	if err != nil {
+		return err
+	}
+	if len(executions) == 0 {
+		fmt.Println("No open cron workflows found.")
+		return nil
+	}
+
+	var report []cronMigration
+	for _, exec := range executions {
+		report = append(report, sc.migrateCronWorkflow(c, domain, idPrefix+exec.GetExecution().GetWorkflowID(), exec, chainAction, dryRun))
+	}
+	sc.drainCronChains(c, domain, report)
+	printCronMigrationReport(report, dryRun)
+	return nil
+}
+
+func (sc *scheduleCLIImpl) listOpenCronWorkflows(c *cli.Context, domain, workflowType string) ([]*types.WorkflowExecutionInfo, error) {
+	request := &types.ListOpenWorkflowExecutionsRequest{
+		Domain:          domain,
+		MaximumPageSize: int32(c.Int(FlagPageSize)),
+		StartTimeFilter: &types.StartTimeFilter{
+			EarliestTime: common.Int64Ptr(0),
+			LatestTime:   common.Int64Ptr(time.Now().UnixNano()),
+		},
+	}
+	if workflowType != "" {
+		request.TypeFilter = &types.WorkflowTypeFilter{Name: workflowType}
+	}
+
+	var cronExecutions []*types.WorkflowExecutionInfo
+	for {
+		ctx, cancel, err := newContext(c)
+		if err != nil {
+			return nil, commoncli.Problem("Error creating context", err)
+		}
+		resp, err := sc.frontendClient.ListOpenWorkflowExecutions(ctx, request)
+		cancel()
+		if err != nil {
+			return nil, commoncli.Problem("Failed to list open workflows", err)
+		}
+		for _, exec := range resp.GetExecutions() {
+			if exec.IsCron {
+				cronExecutions = append(cronExecutions, exec)
+			}
+		}
+		if len(resp.GetNextPageToken()) == 0 {
+			return cronExecutions, nil
+		}
+		request.NextPageToken = resp.GetNextPageToken()
+	}
+}
+
+func (sc *scheduleCLIImpl) migrateCronWorkflow(
+	c *cli.Context,
+	domain string,
+	scheduleID string,
+	exec *types.WorkflowExecutionInfo,
+	chainAction string,
+	dryRun bool,
+) cronMigration {
+	wfID := exec.GetExecution().GetWorkflowID()
+	row := cronMigration{
+		WorkflowID:   wfID,
+		WorkflowType: exec.GetType().GetName(),
+		ScheduleID:   scheduleID,
+	}
+	if exec.ParentExecution != nil {
+		// The parent restarts its child crons on its own; migrating one would
+		// leave the parent and the schedule both starting runs.
+		row.Result = "skipped: child workflow"
+		return row
+	}
+
+	ctx, cancel, err := newContext(c)
+	if err != nil {
+		row.Result = fmt.Sprintf("failed: %v", err)
+		return row
+	}
+	defer cancel()
+
+	started, err := sc.firstStartedEvent(ctx, domain, exec.GetExecution())
+	if err != nil {
+		row.Result = fmt.Sprintf("failed: %v", err)
+		return row
+	}
+	request := buildCronMigrationRequest(domain, scheduleID, wfID, started)
+	row.Cron = request.Spec.CronExpression
+	row.Overlap = request.Policies.OverlapPolicy
+	if row.Cron == "" {
+		row.Result = "skipped: no cron schedule on start event"
+		return row
+	}
+
+	if dryRun {
+		row.Result = "would migrate, then " + chainAction + " old chain"
+		return row
+	}
+
+	// An existing schedule with this ID fails here with a BadRequestError, so
+	// a re-run never touches a chain whose schedule it did not just create.
+	if _, err := sc.frontendClient.CreateSchedule(ctx, request); err != nil {
+		row.Result = fmt.Sprintf("failed: create schedule: %v", err)
+		return row
+	}
+
+	switch chainAction {
+	case cronChainTerminate:
+		err = sc.terminateCronRun(ctx, domain, wfID, "", scheduleID)
+	case cronChainDrain:
+		if time.Unix(0, exec.GetExecutionTime()).After(time.Now()) {
+			// The current run is still waiting for its cron backoff and has done
+			// no work yet, so there is nothing to drain.
+			err = sc.terminateCronRun(ctx, domain, wfID, exec.GetExecution().GetRunID(), scheduleID)
+			break
+		}
+		row.runID = exec.GetExecution().GetRunID()
+		row.draining = true
+		row.Result = "migrated, draining old chain"
+		return row
+	}
+	if err != nil {
+		row.Result = fmt.Sprintf("migrated, but failed to %s old chain: %v", chainAction, err)
+		return row
+	}
+	row.Result = "migrated, " + cronChainOutcome[chainAction]
+	return row
+}
+
+func (sc *scheduleCLIImpl) terminateCronRun(ctx context.Context, domain, workflowID, runID, scheduleID string) error {
+	return sc.frontendClient.TerminateWorkflowExecution(ctx, &types.TerminateWorkflowExecutionRequest{
+		Domain:            domain,
+		WorkflowExecution: &types.WorkflowExecution{WorkflowID: workflowID, RunID: runID},
+		Reason:            fmt.Sprintf("migrated to schedule %q", scheduleID),
+		Identity:          getCliIdentity(),
+	})
+}
+
+// drainCronChains waits for the current run of every chain being drained to
+// finish, then stops the chain. The chains are drained concurrently because a
+// run can take as long as its execution timeout.
+func (sc *scheduleCLIImpl) drainCronChains(c *cli.Context, domain string, report []cronMigration) {
+	var wg sync.WaitGroup
+	for i := range report {
+		row := &report[i]
+		if !row.draining {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := sc.drainCronChain(c, domain, row.WorkflowID, row.runID, row.ScheduleID); err != nil {
+				row.Result = fmt.Sprintf("migrated, but failed to drain old chain: %v", err)
+				return
+			}
+			row.Result = "migrated, " + cronChainOutcome[cronChainDrain]
+		}()
+	}
+	wg.Wait()
+}
+
+// drainCronChain lets the current run of a cron chain finish its work, then
+// stops the chain by terminating the run the cron schedule started next. That
+// run is still waiting for its cron backoff, so terminating it interrupts no
+// work. Retries of the current run are followed, they are still its work.
+func (sc *scheduleCLIImpl) drainCronChain(c *cli.Context, domain, workflowID, runID, scheduleID string) error {
+	for {
+		closeEvent, err := sc.waitForRunClose(c, domain, workflowID, runID)
+		if err != nil {
+			return err
+		}
+		attributes := closeEvent.WorkflowExecutionContinuedAsNewEventAttributes
+		if attributes == nil {
+			// the chain ended on its own
+			return nil
+		}
+		runID = attributes.GetNewExecutionRunID()
+		if attributes.GetInitiator() != types.ContinueAsNewInitiatorCronSchedule {
+			continue
+		}
+		ctx, cancel, err := newContext(c)
+		if err != nil {
+			return err
+		}
+		err = sc.terminateCronRun(ctx, domain, workflowID, runID, scheduleID)
+		cancel()
+		return err
+	}
+}
+
+// waitForRunClose long polls the history of a run until its close event is written.
+func (sc *scheduleCLIImpl) waitForRunClose(c *cli.Context, domain, workflowID, runID string) (*types.HistoryEvent, error) {
+	request := &types.GetWorkflowExecutionHistoryRequest{
+		Domain:                 domain,
+		Execution:              &types.WorkflowExecution{WorkflowID: workflowID, RunID: runID},
+		WaitForNewEvent:        true,
+		HistoryEventFilterType: types.HistoryEventFilterTypeCloseEvent.Ptr(),
+		SkipArchival:           true,
+	}
+	for {
+		ctx, cancel, err := newContextForLongPoll(c)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := sc.frontendClient.GetWorkflowExecutionHistory(ctx, request)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("wait for run %v to close: %w", runID, err)
+		}
+		if events := resp.GetHistory().GetEvents(); len(events) > 0 {
+			return events[len(events)-1], nil
+		}
+		request.NextPageToken = resp.GetNextPageToken()
+	}
+}
+
+// firstStartedEvent returns the start attributes of the current run, which a
+// cron chain copies forward on every run.
+func (sc *scheduleCLIImpl) firstStartedEvent(ctx context.Context, domain string, execution *types.WorkflowExecution) (*types.WorkflowExecutionStartedEventAttributes, error) {
+	resp, err := sc.frontendClient.GetWorkflowExecutionHistory(ctx, &types.GetWorkflowExecutionHistoryRequest{
+		Domain:          domain,
+		Execution:       execution,
+		MaximumPageSize: 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get history: %w", err)
+	}
+	events := resp.GetHistory().GetEvents()
+	if len(events) == 0 || events[0].WorkflowExecutionStartedEventAttributes == nil {
+		return nil, errors.New("history does not start with WorkflowExecutionStarted")
+	}
+	return events[0].WorkflowExecutionStartedEventAttributes, nil
+}
+
+// buildCronMigrationRequest maps a cron workflow's start attributes onto an
+// equivalent schedule. The old workflow ID becomes the action's workflow ID
+// prefix so runs started by the schedule stay easy to find.
+func buildCronMigrationRequest(domain, scheduleID, workflowID string, started *types.WorkflowExecutionStartedEventAttributes) *types.CreateScheduleRequest {
+	overlap, bufferLimit := scheduleOverlapFromCron(started.CronOverlapPolicy)
+	spec := &types.ScheduleSpec{CronExpression: started.CronSchedule}
+	if started.JitterStartSeconds != nil {
+		spec.Jitter = time.Duration(*started.JitterStartSeconds) * time.Second
+	}
+	return &types.CreateScheduleRequest{
+		Domain:     domain,
+		ScheduleID: scheduleID,
+		Spec:       spec,
+		Action: &types.ScheduleAction{
+			StartWorkflow: &types.StartWorkflowAction{
+				WorkflowType:                        started.WorkflowType,
+				TaskList:                            started.TaskList,
+				Input:                               started.Input,
+				WorkflowIDPrefix:                    workflowID,
+				ExecutionStartToCloseTimeoutSeconds: started.ExecutionStartToCloseTimeoutSeconds,
+				TaskStartToCloseTimeoutSeconds:      started.TaskStartToCloseTimeoutSeconds,
+				RetryPolicy:                         started.RetryPolicy,
+				Memo:                                started.Memo,
+				SearchAttributes:                    started.SearchAttributes,
+			},
+		},
+		Policies: &types.SchedulePolicies{
+			OverlapPolicy: overlap,
+			CatchUpPolicy: types.ScheduleCatchUpPolicySkip,
+			BufferLimit:   bufferLimit,
+		},
+	}
+}
+
+// scheduleOverlapFromCron maps a cron overlap policy to the schedule overlap
+// policy with the same behavior. BUFFER_ONE keeps at most one pending run.
+func scheduleOverlapFromCron(policy *types.CronOverlapPolicy) (types.ScheduleOverlapPolicy, int32) {
+	if policy != nil && *policy == types.CronOverlapPolicyBufferOne {
+		return types.ScheduleOverlapPolicyBuffer, 1
+	}
+	return types.ScheduleOverlapPolicySkipNew, 0
+}
+
+func printCronMigrationReport(report []cronMigration, dryRun bool) {
+	if dryRun {
+		fmt.Println("Dry run: no schedules were created and no workflows were changed.")
+	}
+	fmt.Printf("  %-40s  %-25s  %-20s  %-10s  %s\n", "WORKFLOW ID", "WORKFLOW TYPE", "CRON", "OVERLAP", "RESULT")
+	migrated := 0
+	for _, row := range report {
+		overlap := ""
+		if row.Overlap != types.ScheduleOverlapPolicyInvalid {
+			overlap = row.Overlap.String()
+		}
+		fmt.Printf("  %-40s  %-25s  %-20s  %-10s  %s\n", row.WorkflowID, row.WorkflowType, row.Cron, overlap, row.Result)
+		if strings.HasPrefix(row.Result, "migrated") || strings.HasPrefix(row.Result, "would migrate") {
+			migrated++
+		}
+	}
+	fmt.Printf("\n%d of %d cron workflows migrated.\n", migrated, len(report))
+}
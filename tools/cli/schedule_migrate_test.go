@@ -0,0 +1,276 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"flag"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/mock/gomock"
+
+	"github.com/uber/cadence/client/frontend"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/types"
+)
+
+func TestScheduleOverlapFromCron(t *testing.T) {
+	overlap, limit := scheduleOverlapFromCron(nil)
+	assert.Equal(t, types.ScheduleOverlapPolicySkipNew, overlap)
+	assert.Equal(t, int32(0), limit)
+
+	overlap, limit = scheduleOverlapFromCron(types.CronOverlapPolicySkipped.Ptr())
+	assert.Equal(t, types.ScheduleOverlapPolicySkipNew, overlap)
+	assert.Equal(t, int32(0), limit)
+
+	overlap, limit = scheduleOverlapFromCron(types.CronOverlapPolicyBufferOne.Ptr())
+	assert.Equal(t, types.ScheduleOverlapPolicyBuffer, overlap)
+	assert.Equal(t, int32(1), limit)
+}
+
+func TestBuildCronMigrationRequest(t *testing.T) {
+	started := cronStartedAttributes("0 * * * *")
+	started.JitterStartSeconds = common.Int32Ptr(30)
+	started.CronOverlapPolicy = types.CronOverlapPolicyBufferOne.Ptr()
+
+	req := buildCronMigrationRequest("test-domain", "mig-hourly", "hourly", started)
+	assert.Equal(t, "test-domain", req.Domain)
+	assert.Equal(t, "mig-hourly", req.ScheduleID)
+	assert.Equal(t, &types.ScheduleSpec{CronExpression: "0 * * * *", Jitter: 30 * time.Second}, req.Spec)
+	assert.Equal(t, &types.SchedulePolicies{
+		OverlapPolicy: types.ScheduleOverlapPolicyBuffer,
+		CatchUpPolicy: types.ScheduleCatchUpPolicySkip,
+		BufferLimit:   1,
+	}, req.Policies)
+
+	sw := req.Action.StartWorkflow
+	require.NotNil(t, sw)
+	assert.Equal(t, "hourly", sw.WorkflowIDPrefix)
+	assert.Equal(t, started.WorkflowType, sw.WorkflowType)
+	assert.Equal(t, started.TaskList, sw.TaskList)
+	assert.Equal(t, started.Input, sw.Input)
+	assert.Equal(t, started.ExecutionStartToCloseTimeoutSeconds, sw.ExecutionStartToCloseTimeoutSeconds)
+	assert.Equal(t, started.Memo, sw.Memo)
+}
+
+func TestScheduleCLI_MigrateCronWorkflows(t *testing.T) {
+	openWorkflows := &types.ListOpenWorkflowExecutionsResponse{
+		Executions: []*types.WorkflowExecutionInfo{
+			{
+				Execution: &types.WorkflowExecution{WorkflowID: "hourly", RunID: "r1"},
+				Type:      &types.WorkflowType{Name: "report"},
+				IsCron:    true,
+			},
+			{
+				Execution: &types.WorkflowExecution{WorkflowID: "one-off", RunID: "r2"},
+				Type:      &types.WorkflowType{Name: "report"},
+			},
+			{
+				Execution:       &types.WorkflowExecution{WorkflowID: "child-cron", RunID: "r3"},
+				Type:            &types.WorkflowType{Name: "report"},
+				IsCron:          true,
+				ParentExecution: &types.WorkflowExecution{WorkflowID: "parent", RunID: "r0"},
+			},
+		},
+	}
+	history := &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{Events: []*types.HistoryEvent{{
+			EventType:                               types.EventTypeWorkflowExecutionStarted.Ptr(),
+			WorkflowExecutionStartedEventAttributes: cronStartedAttributes("0 * * * *"),
+		}}},
+	}
+	retriedClose := &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{Events: []*types.HistoryEvent{{
+			EventType: types.EventTypeWorkflowExecutionContinuedAsNew.Ptr(),
+			WorkflowExecutionContinuedAsNewEventAttributes: &types.WorkflowExecutionContinuedAsNewEventAttributes{
+				NewExecutionRunID: "r1-retry",
+				Initiator:         types.ContinueAsNewInitiatorRetryPolicy.Ptr(),
+			},
+		}}},
+	}
+	cronClose := &types.GetWorkflowExecutionHistoryResponse{
+		History: &types.History{Events: []*types.HistoryEvent{{
+			EventType: types.EventTypeWorkflowExecutionContinuedAsNew.Ptr(),
+			WorkflowExecutionContinuedAsNewEventAttributes: &types.WorkflowExecutionContinuedAsNewEventAttributes{
+				NewExecutionRunID: "r1-next",
+				Initiator:         types.ContinueAsNewInitiatorCronSchedule.Ptr(),
+			},
+		}}},
+	}
+
+	tests := map[string]struct {
+		args       []string
+		mockFn     func(*frontend.MockClient)
+		wantOutput []string
+		wantErr    bool
+	}{
+		"dry run creates nothing": {
+			args: []string{"--" + FlagDryRun},
+			mockFn: func(m *frontend.MockClient) {
+				m.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any()).Return(openWorkflows, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil)
+			},
+			wantOutput: []string{"Dry run", "would migrate, then terminate old chain", "skipped: child workflow", "1 of 2"},
+		},
+		"terminate old chain": {
+			args: []string{"--" + FlagScheduleIDPrefix, "mig-"},
+			mockFn: func(m *frontend.MockClient) {
+				m.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any()).Return(openWorkflows, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil)
+				m.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, req *types.CreateScheduleRequest, _ ...interface{}) (*types.CreateScheduleResponse, error) {
+						assert.Equal(t, "mig-hourly", req.ScheduleID)
+						return &types.CreateScheduleResponse{ScheduleID: req.ScheduleID}, nil
+					})
+				m.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, req *types.TerminateWorkflowExecutionRequest, _ ...interface{}) error {
+						assert.Equal(t, "hourly", req.WorkflowExecution.WorkflowID)
+						assert.Empty(t, req.WorkflowExecution.RunID, "terminate must target the current run of the chain")
+						return nil
+					})
+			},
+			wantOutput: []string{"migrated, old chain terminated"},
+		},
+		"drain old chain": {
+			args: []string{"--" + FlagCronChainAction, "drain"},
+			mockFn: func(m *frontend.MockClient) {
+				m.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any()).Return(openWorkflows, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil)
+				m.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return(&types.CreateScheduleResponse{}, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, req *types.GetWorkflowExecutionHistoryRequest, _ ...interface{}) (*types.GetWorkflowExecutionHistoryResponse, error) {
+						assert.Equal(t, "r1", req.Execution.RunID)
+						assert.True(t, req.WaitForNewEvent)
+						assert.Equal(t, types.HistoryEventFilterTypeCloseEvent, req.GetHistoryEventFilterType())
+						return retriedClose, nil
+					})
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, req *types.GetWorkflowExecutionHistoryRequest, _ ...interface{}) (*types.GetWorkflowExecutionHistoryResponse, error) {
+						assert.Equal(t, "r1-retry", req.Execution.RunID, "a retry of the current run must be waited for")
+						return cronClose, nil
+					})
+				m.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, req *types.TerminateWorkflowExecutionRequest, _ ...interface{}) error {
+						assert.Equal(t, "hourly", req.WorkflowExecution.WorkflowID)
+						assert.Equal(t, "r1-next", req.WorkflowExecution.RunID, "only the next cron run may be terminated")
+						return nil
+					})
+			},
+			wantOutput: []string{"migrated, old chain drained"},
+		},
+		"drain old chain whose run ended": {
+			args: []string{"--" + FlagCronChainAction, "drain"},
+			mockFn: func(m *frontend.MockClient) {
+				m.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any()).Return(openWorkflows, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil)
+				m.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return(&types.CreateScheduleResponse{}, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&types.GetWorkflowExecutionHistoryResponse{}, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(&types.GetWorkflowExecutionHistoryResponse{
+					History: &types.History{Events: []*types.HistoryEvent{{
+						EventType: types.EventTypeWorkflowExecutionTerminated.Ptr(),
+					}}},
+				}, nil)
+			},
+			wantOutput: []string{"migrated, old chain drained"},
+		},
+		"drain old chain waiting for cron backoff": {
+			args: []string{"--" + FlagCronChainAction, "drain"},
+			mockFn: func(m *frontend.MockClient) {
+				backoff := &types.ListOpenWorkflowExecutionsResponse{Executions: []*types.WorkflowExecutionInfo{{
+					Execution:     &types.WorkflowExecution{WorkflowID: "hourly", RunID: "r1"},
+					Type:          &types.WorkflowType{Name: "report"},
+					IsCron:        true,
+					ExecutionTime: common.Int64Ptr(time.Now().Add(time.Hour).UnixNano()),
+				}}}
+				m.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any()).Return(backoff, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil)
+				m.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).Return(&types.CreateScheduleResponse{}, nil)
+				m.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, req *types.TerminateWorkflowExecutionRequest, _ ...interface{}) error {
+						assert.Equal(t, "r1", req.WorkflowExecution.RunID)
+						return nil
+					})
+			},
+			wantOutput: []string{"migrated, old chain drained"},
+		},
+		"create failure leaves old chain alone": {
+			mockFn: func(m *frontend.MockClient) {
+				m.EXPECT().ListOpenWorkflowExecutions(gomock.Any(), gomock.Any()).Return(openWorkflows, nil)
+				m.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(history, nil)
+				m.EXPECT().CreateSchedule(gomock.Any(), gomock.Any()).
+					Return(nil, &types.BadRequestError{Message: `schedule "hourly" already exists`})
+			},
+			wantOutput: []string{"failed: create schedule", "0 of 2"},
+		},
+		"unknown old chain action": {
+			args:    []string{"--" + FlagCronChainAction, "delete"},
+			mockFn:  func(m *frontend.MockClient) {},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			mockClient := frontend.NewMockClient(mockCtrl)
+			tt.mockFn(mockClient)
+
+			app := newScheduleTestApp(t, mockClient)
+			set := flag.NewFlagSet("test", 0)
+			set.String(FlagDomain, "test-domain", "")
+			set.String(FlagWorkflowType, "", "")
+			set.String(FlagScheduleIDPrefix, "", "")
+			set.String(FlagCronChainAction, cronChainTerminate, "")
+			set.Bool(FlagDryRun, false, "")
+			set.Int(FlagPageSize, 100, "")
+			require.NoError(t, set.Parse(tt.args))
+			c := cli.NewContext(app, set, nil)
+
+			sc := &scheduleCLIImpl{frontendClient: mockClient}
+			var err error
+			out := captureStdout(t, func() {
+				err = sc.MigrateCronWorkflows(c)
+			})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, want := range tt.wantOutput {
+				assert.Contains(t, out, want)
+			}
+		})
+	}
+}
+
+func cronStartedAttributes(cron string) *types.WorkflowExecutionStartedEventAttributes {
+	return &types.WorkflowExecutionStartedEventAttributes{
+		WorkflowType:                        &types.WorkflowType{Name: "report"},
+		TaskList:                            &types.TaskList{Name: "reports"},
+		Input:                               []byte(`{"region":"eu"}`),
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(600),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(10),
+		CronSchedule:                        cron,
+		Memo:                                &types.Memo{Fields: map[string][]byte{"owner": []byte(`"team-a"`)}},
+	}
+}
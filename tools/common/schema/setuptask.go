@@ -21,6 +21,7 @@
 package schema
 
 import (
+	"io/fs"
 	"log"
 	"os"
 )
@@ -60,7 +61,13 @@ func (task *SetupTask) Run() error {
 	}
 
 	if len(config.SchemaFilePath) > 0 {
-		file, err := os.Open(config.SchemaFilePath)
+		var file fs.File
+		var err error
+		if config.SchemaFS != nil {
+			file, err = config.SchemaFS.Open(config.SchemaFilePath)
+		} else {
+			file, err = os.Open(config.SchemaFilePath)
+		}
 		if err != nil {
 			return err
 		}
@@ -46,6 +46,7 @@ type (
 	// params need by the SetupTask
 	SetupConfig struct {
 		SchemaFilePath    string
+		SchemaFS          fs.FS // file system SchemaFilePath is read from, local file system if nil
 		InitialVersion    string
 		Overwrite         bool // overwrite previous data
 		DisableVersioning bool // do not use schema versioning
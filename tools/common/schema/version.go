@@ -22,6 +22,7 @@ package schema
 
 import (
 	"fmt"
+	"io/fs"
 	"regexp"
 	"strconv"
 	"strings"
@@ -96,3 +97,25 @@ func parseValidateVersion(ver string) (string, error) {
 	}
 	return ver, nil
 }
+
+// LatestVersion returns the highest version of the versioned schema directories (vx.x) within dir of fsys
+func LatestVersion(fsys fs.FS, dir string) (string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+	var latest string
+	for _, entry := range entries {
+		if !entry.IsDir() || !versionStrRegex.MatchString(entry.Name()) {
+			continue
+		}
+		ver := dirToVersion(entry.Name())
+		if len(latest) == 0 || cmpVersion(ver, latest) > 0 {
+			latest = ver
+		}
+	}
+	if len(latest) == 0 {
+		return "", fmt.Errorf("no versioned schema directory found in %v", dir)
+	}
+	return latest, nil
+}
@@ -22,6 +22,7 @@ package schema
 
 import (
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -99,6 +100,28 @@ func (s *VersionTestSuite) TestParseValidateVersion() {
 	}
 }
 
+func (s *VersionTestSuite) TestLatestVersion() {
+	fsys := fstest.MapFS{
+		"cadence/schema.sql":                   {},
+		"cadence/versioned/v0.1/manifest.json": {},
+		"cadence/versioned/v0.10/base.sql":     {},
+		"cadence/versioned/v0.9/manifest.json": {},
+		"cadence/versioned/s0.1-0.9/base.sql":  {},
+		"cadence/versioned/v1.0.txt":           {},
+		"visibility/schema.sql":                {},
+	}
+
+	ver, err := LatestVersion(fsys, "cadence/versioned")
+	s.NoError(err)
+	s.Equal("0.10", ver)
+
+	_, err = LatestVersion(fsys, "visibility")
+	s.Error(err)
+
+	_, err = LatestVersion(fsys, "missing")
+	s.Error(err)
+}
+
 func (s *VersionTestSuite) execParseValidateTest(input string, output string, isErr bool) {
 	ver, err := parseValidateVersion(input)
 	if isErr {